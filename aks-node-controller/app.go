@@ -8,12 +8,19 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
 
 	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
 	"github.com/Azure/agentbaker/aks-node-controller/pkg/nodeconfigutils"
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/telemetry"
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/validation"
 	"gopkg.in/fsnotify.v1"
 )
 
@@ -21,6 +28,14 @@ type App struct {
 	// cmdRunner is a function that runs the given command.
 	// the goal of this field is to make it easier to test the app by mocking the command runner.
 	cmdRunner func(cmd *exec.Cmd) error
+	// client is the http client used to download artifacts, http.DefaultClient is used when nil.
+	client *http.Client
+	// telemetry receives the provisioning events, no events are exported when nil.
+	telemetry telemetry.Exporter
+	// imdsEndpoint is the IMDS token endpoint, imdsTokenEndpoint is used when empty.
+	imdsEndpoint string
+	// imdsComputeEndpoint is the IMDS compute metadata endpoint, imdsComputeEndpoint is used when empty.
+	imdsComputeEndpoint string
 }
 
 func cmdRunner(cmd *exec.Cmd) error {
@@ -29,11 +44,48 @@ func cmdRunner(cmd *exec.Cmd) error {
 
 type ProvisionFlags struct {
 	ProvisionConfig string
+	// TelemetryFile additionally appends the provisioning events to this file as JSON lines.
+	TelemetryFile string
+	// OnReprovision is the policy applied when the node is already provisioned with a different configuration.
+	OnReprovision string
+	// ProvisionedConfigFile records the configuration of the last successful provisioning, the reprovision guard is disabled when empty.
+	ProvisionedConfigFile string
 }
 
 type ProvisionStatusFiles struct {
 	ProvisionJSONFile     string
 	ProvisionCompleteFile string
+	// ProvisionInProgressFile exists while the controller provisions the node, provision.complete isn't final until it
+	// is removed. It is ignored when empty.
+	ProvisionInProgressFile string
+}
+
+// provisionPaths are the host paths provisioning writes to outside of its phases, overridden by tests.
+type provisionPaths struct {
+	StatusFiles ProvisionStatusFiles
+	// Root is the root of the filesystem the security posture and the network features are collected from.
+	Root                   string
+	KubeletConfigDropInDir string
+	KubeletHooksDropIn     string
+	SecurityPostureFile    string
+	NetworkFeaturesFile    string
+	NodeMetadataFile       string
+	MOTDFile               string
+}
+
+var defaultProvisionPaths = provisionPaths{
+	StatusFiles: ProvisionStatusFiles{
+		ProvisionJSONFile:       provisionJSONFilePath,
+		ProvisionCompleteFile:   provisionCompleteFilePath,
+		ProvisionInProgressFile: provisionInProgressFilePath,
+	},
+	Root:                   "/",
+	KubeletConfigDropInDir: parser.KubeletConfigDropInDir,
+	KubeletHooksDropIn:     kubeletHooksDropInPath,
+	SecurityPostureFile:    securityPostureFilePath,
+	NetworkFeaturesFile:    networkFeaturesFilePath,
+	NodeMetadataFile:       nodeMetadataFilePath,
+	MOTDFile:               motdFilePath,
 }
 
 func (a *App) Run(ctx context.Context, args []string) int {
@@ -56,6 +108,9 @@ func (a *App) run(ctx context.Context, args []string) error {
 	case "provision":
 		fs := flag.NewFlagSet("provision", flag.ContinueOnError)
 		provisionConfig := fs.String("provision-config", "", "path to the provision config file")
+		telemetryFile := fs.String("telemetry-file", "", "path of a file the provisioning events are appended to as JSON lines")
+		onReprovision := fs.String("on-reprovision", reprovisionFail,
+			"policy when the node is already provisioned with a different configuration: fail, skip or reconfigure")
 		err := fs.Parse(args[2:])
 		if err != nil {
 			return fmt.Errorf("parse args: %w", err)
@@ -63,19 +118,160 @@ func (a *App) run(ctx context.Context, args []string) error {
 		if provisionConfig == nil || *provisionConfig == "" {
 			return errors.New("--provision-config is required")
 		}
-		return a.Provision(ctx, ProvisionFlags{ProvisionConfig: *provisionConfig})
+		if !validReprovisionPolicy(*onReprovision) {
+			return fmt.Errorf("invalid --on-reprovision policy %q", *onReprovision)
+		}
+		return a.Provision(ctx, ProvisionFlags{
+			ProvisionConfig:       *provisionConfig,
+			TelemetryFile:         *telemetryFile,
+			OnReprovision:         *onReprovision,
+			ProvisionedConfigFile: provisionedConfigFilePath,
+		})
 	case "provision-wait":
-		provisionStatusFiles := ProvisionStatusFiles{ProvisionJSONFile: provisionJSONFilePath, ProvisionCompleteFile: provisionCompleteFilePath}
-		provisionOutput, err := a.ProvisionWait(ctx, provisionStatusFiles)
+		fs := flag.NewFlagSet("provision-wait", flag.ContinueOnError)
+		securityPosture := fs.Bool("security-posture", false, "print the security posture report instead of provision.json once provisioning is complete")
+		err := fs.Parse(args[2:])
+		if err != nil {
+			return fmt.Errorf("parse args: %w", err)
+		}
+		provisionOutput, err := a.ProvisionWait(ctx, defaultProvisionPaths.StatusFiles)
+		if err == nil && *securityPosture {
+			provisionOutput, err = readSecurityPostureReport(securityPostureFilePath)
+		}
 		fmt.Println(provisionOutput)
 		slog.Info("provision-wait finished", "provisionOutput", provisionOutput)
 		return err
+	case "prefetch":
+		fs := flag.NewFlagSet("prefetch", flag.ContinueOnError)
+		provisionConfig := fs.String("provision-config", "", "path to the provision config file")
+		concurrency := fs.Int("concurrency", defaultPrefetchConcurrency, "maximum number of artifacts fetched in parallel")
+		err := fs.Parse(args[2:])
+		if err != nil {
+			return fmt.Errorf("parse args: %w", err)
+		}
+		if *provisionConfig == "" {
+			return errors.New("--provision-config is required")
+		}
+		return a.Prefetch(ctx, PrefetchFlags{ProvisionConfig: *provisionConfig, Concurrency: *concurrency, Root: "/"})
+	case "update-registry-mirrors":
+		fs := flag.NewFlagSet("update-registry-mirrors", flag.ContinueOnError)
+		provisionConfig := fs.String("provision-config", "", "path to the updated provision config file")
+		err := fs.Parse(args[2:])
+		if err != nil {
+			return fmt.Errorf("parse args: %w", err)
+		}
+		if *provisionConfig == "" {
+			return errors.New("--provision-config is required")
+		}
+		return a.UpdateRegistryMirrors(ctx, RegistryMirrorFlags{ProvisionConfig: *provisionConfig, CertsDir: containerdCertsDir})
+	case "repro-bundle":
+		fs := flag.NewFlagSet("repro-bundle", flag.ContinueOnError)
+		provisionConfig := fs.String("provision-config", "", "path to the provision config file")
+		output := fs.String("output", "", "path of the .tar.gz bundle")
+		includeLogs := fs.Bool("include-logs", false, "add the provisioning logs of the node")
+		err := fs.Parse(args[2:])
+		if err != nil {
+			return fmt.Errorf("parse args: %w", err)
+		}
+		if *provisionConfig == "" || *output == "" {
+			return errors.New("--provision-config and --output are required")
+		}
+		logPaths, logCommands := nodeLogSources(runtime.GOOS)
+		return a.ReproBundle(ctx, ReproBundleFlags{
+			ProvisionConfig: *provisionConfig,
+			Output:          *output,
+			IncludeLogs:     *includeLogs,
+			LogPaths:        logPaths,
+			LogCommands:     logCommands,
+		})
+	case "run-hooks":
+		fs := flag.NewFlagSet("run-hooks", flag.ContinueOnError)
+		provisionConfig := fs.String("provision-config", "", "path to the provision config file")
+		hook := fs.String("hook", "", "hook whose custom scripts are run: post-network, pre-kubelet or post-kubelet")
+		err := fs.Parse(args[2:])
+		if err != nil {
+			return fmt.Errorf("parse args: %w", err)
+		}
+		if *provisionConfig == "" || *hook == "" {
+			return errors.New("--provision-config and --hook are required")
+		}
+		return a.RunHooks(ctx, RunHooksFlags{ProvisionConfig: *provisionConfig, Hook: *hook, LogDir: customScriptsLogDir})
+	case "update":
+		fs := flag.NewFlagSet("update", flag.ContinueOnError)
+		version := fs.String("version", "", "aks-node-controller version to install")
+		checksum := fs.String("sha256", "", "expected sha256 checksum of the aks-node-controller binary")
+		err := fs.Parse(args[2:])
+		if err != nil {
+			return fmt.Errorf("parse args: %w", err)
+		}
+		if *version == "" || *checksum == "" {
+			return errors.New("--version and --sha256 are required")
+		}
+		binaryPath, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("get executable path: %w", err)
+		}
+		return a.Update(ctx, UpdateFlags{
+			Version:          *version,
+			SHA256:           *checksum,
+			ArtifactEndpoint: artifactEndpoint,
+			BinaryPath:       binaryPath,
+		})
+	case "rotate-kubelet-cert":
+		fs := flag.NewFlagSet("rotate-kubelet-cert", flag.ContinueOnError)
+		apiServerURL := fs.String("apiserver-url", "", "URL of the kubernetes API server")
+		nodeName := fs.String("node-name", "", "name of the node, defaults to the hostname")
+		interval := fs.Duration("interval", 0, "interval between rotation checks, checks once when not set")
+		err := fs.Parse(args[2:])
+		if err != nil {
+			return fmt.Errorf("parse args: %w", err)
+		}
+		if *apiServerURL == "" {
+			return errors.New("--apiserver-url is required")
+		}
+		if *nodeName == "" {
+			if *nodeName, err = os.Hostname(); err != nil {
+				return fmt.Errorf("get hostname: %w", err)
+			}
+		}
+		return a.RotateKubeletCert(ctx, RotateKubeletCertFlags{
+			APIServerURL:    *apiServerURL,
+			NodeName:        strings.ToLower(*nodeName),
+			Interval:        *interval,
+			PKIDir:          kubeletPKIDir,
+			StateFile:       kubeletCertRotationStateFile,
+			CACertFile:      kubernetesCACertFile,
+			BootstrapClient: secureTLSBootstrapClientPath,
+		})
+	case "deprovision":
+		fs := flag.NewFlagSet("deprovision", flag.ContinueOnError)
+		apiServerURL := fs.String("apiserver-url", "", "URL of the kubernetes API server, the Node object is deleted when set")
+		nodeName := fs.String("node-name", "", "name of the node, defaults to the hostname")
+		err := fs.Parse(args[2:])
+		if err != nil {
+			return fmt.Errorf("parse args: %w", err)
+		}
+		if *nodeName == "" {
+			if *nodeName, err = os.Hostname(); err != nil {
+				return fmt.Errorf("get hostname: %w", err)
+			}
+		}
+		return a.Deprovision(ctx, DeprovisionFlags{
+			APIServerURL: *apiServerURL,
+			NodeName:     strings.ToLower(*nodeName),
+			Root:         "/",
+			StatusFile:   deprovisionStatusFilePath,
+		})
 	default:
 		return fmt.Errorf("unknown command: %s", args[1])
 	}
 }
 
 func (a *App) Provision(ctx context.Context, flags ProvisionFlags) error {
+	return a.provision(ctx, flags, defaultProvisionPaths)
+}
+
+func (a *App) provision(ctx context.Context, flags ProvisionFlags, paths provisionPaths) (err error) {
 	inputJSON, err := os.ReadFile(flags.ProvisionConfig)
 	if err != nil {
 		return fmt.Errorf("open provision file %s: %w", flags.ProvisionConfig, err)
@@ -88,14 +284,343 @@ func (a *App) Provision(ctx context.Context, flags ProvisionFlags) error {
 	if config.Version != "v0" {
 		return fmt.Errorf("unsupported version: %s", config.Version)
 	}
+	if err := validation.Validate(config); err != nil {
+		return fmt.Errorf("invalid provision config: %w", err)
+	}
+
+	if err := validateCustomScripts(config.GetCustomScripts()); err != nil {
+		return fmt.Errorf("invalid custom scripts: %w", err)
+	}
+	if err := parser.ValidateKubeletConfigDropIns(config); err != nil {
+		return fmt.Errorf("invalid kubelet config: %w", err)
+	}
+	if err := parser.ValidateCustomLinuxOsConfig(config); err != nil {
+		return fmt.Errorf("invalid custom linux os config: %w", err)
+	}
+	if err := parser.ValidateNetworkConfig(config.GetNetworkConfig()); err != nil {
+		return fmt.Errorf("invalid network config: %w", err)
+	}
+	if err := validateLocalDiskConfig(config.GetLocalDiskConfig()); err != nil {
+		return fmt.Errorf("invalid local disk config: %w", err)
+	}
+	if err := parser.ValidateSwapConfig(config); err != nil {
+		return fmt.Errorf("invalid swap config: %w", err)
+	}
+	if err := parser.ValidateHugepagesConfig(config); err != nil {
+		return fmt.Errorf("invalid hugepages config: %w", err)
+	}
+	if err := validateTrustedCACertificates(config.GetTrustedCaCertificates()); err != nil {
+		return fmt.Errorf("invalid trusted CA certificates: %w", err)
+	}
+	if err := parser.ValidateRuntimeClasses(config); err != nil {
+		return fmt.Errorf("invalid runtime classes: %w", err)
+	}
+	if err := parser.ValidateNodeMetadata(config); err != nil {
+		return fmt.Errorf("invalid node metadata: %w", err)
+	}
+	if err := parser.ValidateCredentialProviders(config); err != nil {
+		return fmt.Errorf("invalid credential providers: %w", err)
+	}
+	if err := validateMIGProfiles(config); err != nil {
+		return fmt.Errorf("invalid mig profiles: %w", err)
+	}
+	if err := parser.ValidateNodeLocalDNS(config); err != nil {
+		return fmt.Errorf("invalid node local DNS config: %w", err)
+	}
+	if err := parser.ValidateOutboundType(config); err != nil {
+		return fmt.Errorf("invalid outbound type: %w", err)
+	}
+	if err := parser.ValidateContainerdConfig(config); err != nil {
+		return fmt.Errorf("invalid containerd config: %w", err)
+	}
+	if err := parser.ValidateKubeletServingCert(config); err != nil {
+		return fmt.Errorf("invalid kubelet serving cert config: %w", err)
+	}
+	if err := parser.ValidateResourceManagerPolicies(config); err != nil {
+		return fmt.Errorf("invalid resource manager policies: %w", err)
+	}
+	if err := parser.ValidateSandboxImage(config); err != nil {
+		return fmt.Errorf("invalid sandbox image: %w", err)
+	}
+	if err := parser.ValidateTimeSync(config); err != nil {
+		return fmt.Errorf("invalid time sync config: %w", err)
+	}
+	if err := parser.ValidateIPFamilies(config); err != nil {
+		return fmt.Errorf("invalid ip families: %w", err)
+	}
+	if err := parser.ValidateSSHConfig(config); err != nil {
+		return fmt.Errorf("invalid ssh config: %w", err)
+	}
+	if err := parser.ValidateWindowsConfig(config); err != nil {
+		return fmt.Errorf("invalid windows config: %w", err)
+	}
+	if err := parser.ValidateInfiniBand(config); err != nil {
+		return fmt.Errorf("invalid infiniband config: %w", err)
+	}
+	if err := parser.ValidateVMSecurityConfig(config); err != nil {
+		return fmt.Errorf("invalid vm security config: %w", err)
+	}
+
+	if flags.ProvisionedConfigFile != "" {
+		if handled, err := a.guardReprovision(ctx, config, flags); handled {
+			return err
+		}
+	}
+
+	if err := clearCancelledProvisionStatus(paths.StatusFiles.ProvisionJSONFile); err != nil {
+		return fmt.Errorf("clear cancelled provision status: %w", err)
+	}
+	// CSE writes provision.complete before the controller checks the node, provision-wait waits for the marker to be
+	// removed so that it doesn't report a success the controller replaces with a failure.
+	if err := markProvisionInProgress(paths.StatusFiles); err != nil {
+		return err
+	}
+	defer func() {
+		if clearErr := clearProvisionInProgress(paths.StatusFiles); clearErr != nil {
+			slog.Error("failed to clear the provision in progress marker", "error", clearErr)
+		}
+	}()
+
+	exporter := a.telemetry
+	if flags.TelemetryFile != "" {
+		fileExporter := &telemetry.JSONLines{Path: flags.TelemetryFile}
+		if exporter == nil {
+			exporter = fileExporter
+		} else {
+			exporter = telemetry.Multi{exporter, fileExporter}
+		}
+	}
+	operationID := telemetry.NewOperationID()
+
+	startTime := time.Now()
+	defer func() {
+		emitEvent(ctx, exporter, telemetry.NewEvent(operationID, "Provision", startTime, errToExitCode(err), "", ctx.Err() != nil))
+	}()
+	statusFiles := paths.StatusFiles
+	var phases provisionPhases
+	var vhd *nodeconfigutils.VHDMetadata
+	// endPhase exports the event of a phase and records it in provision.json.
+	endPhase := func(name string, start time.Time, phaseErr error, message string) {
+		phases.add(name, start, time.Now(), phaseErr)
+		emitEvent(ctx, exporter, telemetry.NewEvent(operationID, name, start, errToExitCode(phaseErr), message, ctx.Err() != nil))
+	}
+	defer func() {
+		if recordErr := recordProvisionDetails(statusFiles.ProvisionJSONFile, phases, vhd); recordErr != nil {
+			slog.Error("failed to record provisioning phases", "error", recordErr)
+		}
+	}()
+
+	// a phase failing before CSE runs is reported by provision-wait like a CSE failure.
+	if len(config.GetTrustedCaCertificates()) > 0 {
+		// the certificates must be trusted before CSE and containerd pull anything.
+		if err := a.installTrustedCACertificates(ctx, config.GetTrustedCaCertificates(), nodeTrustStore("/")); err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("install trusted CA certificates: %w", err))
+		}
+	}
+
+	if len(config.GetRegistryMirrors()) > 0 {
+		// CSE only configures the bootstrap profile registry, containerd must use the mirrors for the images CSE pulls.
+		if err := applyRegistryMirrors(config, nodeContainerdCertsDir(config)); err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("configure registry mirrors: %w", err))
+		}
+	}
+
+	if err := writeKubeletConfigDropIns(config, paths.KubeletConfigDropInDir); err != nil {
+		return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("write kubelet config drop-ins: %w", err))
+	}
+
+	if config.GetCustomLinuxOsConfig().GetDisableLegacyKernelModules() {
+		if err := blacklistLegacyKernelModules(legacyModulesBlacklist); err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("disable legacy kernel modules: %w", err))
+		}
+	}
+
+	if len(config.GetCustomLinuxOsConfig().GetKernelModules()) > 0 {
+		// CSE applies the sysctls, modules such as nf_conntrack must be loaded for their sysctls to exist.
+		if err := a.loadKernelModules(ctx, config, kernelModulesLoadFile, kernelModulesOptionsFile); err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("load kernel modules: %w", err))
+		}
+	}
+
+	if parser.HasConfidentialComputing(config) {
+		// the SGX device plugin and the attestation daemonsets start as soon as the node joins.
+		if err := a.configureConfidentialComputing(ctx, config, confidentialModulesFile, confidentialUdevRulesFile); err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("configure confidential computing: %w", err))
+		}
+	}
+
+	if parser.HasMeasuredBoot(config) {
+		// the components CSE installs are measured once the policy is loaded.
+		if err := configureMeasuredBoot(defaultMeasuredBootPaths); err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("configure measured boot: %w", err))
+		}
+	}
+
+	if config.GetLocalDiskConfig().GetLayout() != aksnodeconfigv1.LocalDiskLayout_LOCAL_DISK_LAYOUT_UNSPECIFIED {
+		// containerd and kubelet state must be on the local disks before CSE starts them.
+		if err := a.provisionLocalDisks(ctx, config.GetLocalDiskConfig(), defaultLocalDiskPaths); err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("provision local disks: %w", err))
+		}
+	}
+
+	if parser.HasSwapConfig(config) {
+		if err := a.configureSwap(ctx, config.GetCustomLinuxOsConfig().GetSwapConfig(), defaultSwapPaths); err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("configure swap: %w", err))
+		}
+	}
 
-	cmd, err := parser.BuildCSECmd(ctx, config)
+	if parser.HasHugepagesConfig(config) {
+		if err := a.configureHugepages(ctx, config, defaultHugepagesPaths); err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("configure hugepages: %w", err))
+		}
+	}
+
+	if parser.NodeLocalDNSEnabled(config) {
+		// kubelet hands the cache address to pods as their DNS server from the first pod on.
+		if err := a.setupNodeLocalDNS(ctx, config, systemdUnitDir); err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("set up node local DNS: %w", err))
+		}
+	}
+
+	if parser.HasIPFamilies(config) && parser.DualStackEnabled(config) {
+		if err := a.setupDualStack(ctx, config, systemdUnitDir); err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("set up dual-stack networking: %w", err))
+		}
+	}
+
+	if parser.HasSSHDConfigDropIn(config) {
+		if err := a.configureSSH(ctx, config, "/"); err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("configure ssh: %w", err))
+		}
+	}
+
+	if parser.HasCredentialProviders(config) {
+		// kubelet loads the credential provider config when CSE starts it.
+		if err := a.installCredentialProviders(ctx, config, "/"); err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("install credential providers: %w", err))
+		}
+	}
+
+	if parser.HasTimeSyncConfig(config) {
+		// kubelet's TLS bootstrap fails with a skewed clock.
+		if err := a.configureTimeSync(ctx, config, "/", timeSyncTimeout); err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("configure time sync: %w", err))
+		}
+	}
+
+	cseConfig := config
+	if parser.ArtifactStreamingEnabled(config) {
+		if !supportsArtifactStreaming("/") {
+			// containerd can't start with a snapshotter which doesn't exist, images are pulled instead.
+			slog.Warn("node image doesn't support artifact streaming, it is disabled")
+			cseConfig = parser.WithoutArtifactStreaming(config)
+		} else if err := a.enableArtifactStreaming(ctx); err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("enable artifact streaming: %w", err))
+		}
+	}
+
+	if parser.HasIPFamilies(config) {
+		// kubelet reports a single IPv4 address of the node unless --node-ip lists the address of each family.
+		addrs, err := interfaceAddrs(primaryInterface)
+		if err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("get addresses of %s: %w", primaryInterface, err))
+		}
+		ips, err := nodeIPs(addrs, parser.IPFamilies(config))
+		if err != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("get node IPs: %w", err))
+		}
+		cseConfig = parser.WithNodeIPs(cseConfig, ips)
+	}
+
+	cmd, err := parser.BuildCSECmd(ctx, cseConfig)
 	if err != nil {
-		return fmt.Errorf("build CSE command: %w", err)
+		return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), fmt.Errorf("build CSE command: %w", err))
+	}
+	setCancelProcessGroup(cmd)
+	debugConfig := config.GetDebugConfig()
+	defer logLevel.Set(slog.LevelInfo)
+
+	enterPhase(debugConfig, "CustomScripts")
+	hooksStart := time.Now()
+	err = a.installKubeletHooks(config.GetCustomScripts(), paths.KubeletHooksDropIn, flags.ProvisionConfig)
+	if err == nil {
+		err = a.runCustomScripts(ctx, config.GetCustomScripts(), aksnodeconfigv1.CustomScriptHook_CUSTOM_SCRIPT_HOOK_POST_NETWORK, customScriptsLogDir)
+	}
+	if len(config.GetCustomScripts()) > 0 {
+		endPhase("CustomScripts", hooksStart, err, errorMessage(err))
+	}
+	if ctx.Err() != nil {
+		if writeErr := writeCancelledProvisionStatus(statusFiles.ProvisionJSONFile, "", time.Since(startTime)); writeErr != nil {
+			slog.Error("failed to record cancelled provision status", "error", writeErr)
+		}
+		return fmt.Errorf("provisioning cancelled: %w", ctx.Err())
+	}
+	if err != nil {
+		if writeErr := failProvision(statusFiles, errToExitCode(err), "", time.Since(startTime), err); writeErr != nil {
+			slog.Error("failed to record custom script failure", "error", writeErr)
+		}
+		return err
+	}
+
+	enterPhase(debugConfig, "GPUDriver")
+	gpuStart := time.Now()
+	gpuInstalled, err := a.installGPUDriver(ctx, config)
+	if gpuInstalled {
+		endPhase("GPUDriver", gpuStart, err, errorMessage(err))
+		// the driver is installed, CSE only validates it.
+		cmd.Env = setEnv(cmd.Env, "CONFIG_GPU_DRIVER_IF_NEEDED", "false")
+	}
+	if ctx.Err() != nil {
+		if writeErr := writeCancelledProvisionStatus(statusFiles.ProvisionJSONFile, "", time.Since(startTime)); writeErr != nil {
+			slog.Error("failed to record cancelled provision status", "error", writeErr)
+		}
+		return fmt.Errorf("provisioning cancelled: %w", ctx.Err())
 	}
+	if err != nil {
+		if writeErr := failProvision(statusFiles, errToExitCode(err), "", time.Since(startTime), err); writeErr != nil {
+			slog.Error("failed to record gpu driver failure", "error", writeErr)
+		}
+		return err
+	}
+
+	if len(config.GetGpuConfig().GetMigProfiles()) > 0 {
+		// the GPUs must be partitioned before CSE starts kubelet and the device plugin advertises them.
+		enterPhase(debugConfig, "MIG")
+		migStart := time.Now()
+		err = a.applyMIGProfiles(ctx, config)
+		endPhase("MIG", migStart, err, errorMessage(err))
+		if err != nil || ctx.Err() != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), err)
+		}
+	}
+
+	if parser.HasInfiniBand(config) {
+		// the RDMA device plugin advertises the InfiniBand NIC once CSE starts kubelet.
+		enterPhase(debugConfig, "InfiniBand")
+		infiniBandStart := time.Now()
+		err = a.setupInfiniBand(ctx, config, defaultInfiniBandPaths)
+		endPhase("InfiniBand", infiniBandStart, err, errorMessage(err))
+		if err != nil || ctx.Err() != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), err)
+		}
+	}
+
+	if parser.HasWindowsHardeningProfile(config) {
+		// the baseline is in place before CSE starts containerd and kubelet.
+		enterPhase(debugConfig, "WindowsHardening")
+		hardeningStart := time.Now()
+		err = a.applyWindowsHardening(ctx, config, windowsHardeningReportFilePath, time.Now)
+		endPhase("WindowsHardening", hardeningStart, err, errorMessage(err))
+		if err != nil || ctx.Err() != nil {
+			return recordProvisionFailure(ctx, statusFiles, time.Since(startTime), err)
+		}
+	}
+
 	var stdoutBuf, stderrBuf bytes.Buffer
 	cmd.Stdout = io.MultiWriter(os.Stdout, &stdoutBuf)
 	cmd.Stderr = io.MultiWriter(os.Stderr, &stderrBuf)
+	enterPhase(debugConfig, "CSE")
+	cseStartTime := time.Now()
 	err = a.cmdRunner(cmd)
 	exitCode := -1
 	if cmd.ProcessState != nil {
@@ -103,16 +628,95 @@ func (a *App) Provision(ctx context.Context, flags ProvisionFlags) error {
 	}
 	// Is it ok to log a single line? Is it too much?
 	slog.Info("CSE finished", "exitCode", exitCode, "stdout", stdoutBuf.String(), "stderr", stderrBuf.String(), "error", err)
+	endPhase("CSE", cseStartTime, err, stderrTail(stderrBuf.String()))
+	if ctx.Err() != nil {
+		if writeErr := writeCancelledProvisionStatus(statusFiles.ProvisionJSONFile, stdoutBuf.String(), time.Since(startTime)); writeErr != nil {
+			slog.Error("failed to record cancelled provision status", "error", writeErr)
+		}
+		return fmt.Errorf("provisioning cancelled: %w", ctx.Err())
+	}
+	// crictl and ctr aren't on Windows nodes, whose containerd listens on a named pipe: the check is Linux only.
+	if err == nil && !parser.IsWindows(config) {
+		enterPhase(debugConfig, "ContainerdReadiness")
+		readinessStart := time.Now()
+		err = a.waitForContainerdReady(ctx, containerdReadiness{
+			SandboxImage: parser.SandboxImage(config),
+			Timeout:      containerdReadinessTimeout,
+			Interval:     containerdReadinessInterval,
+		})
+		if err == nil && parser.SandboxImage(config) != "" {
+			err = a.pinImage(ctx, parser.SandboxImage(config))
+		}
+		endPhase("ContainerdReadiness", readinessStart, err, errorMessage(err))
+		if err != nil && ctx.Err() == nil {
+			if writeErr := failProvision(statusFiles, containerdNotReadyExitCode, stdoutBuf.String(), time.Since(startTime), err); writeErr != nil {
+				slog.Error("failed to record containerd readiness failure", "error", writeErr)
+			}
+		}
+	}
+	if err == nil && len(config.GetKubeletConfig().GetNodeAnnotations()) > 0 {
+		// the node is usable without its annotations, a failure is reported without failing provisioning.
+		annotationsStart := time.Now()
+		annotationsErr := a.applyNodeAnnotations(ctx, config)
+		endPhase("NodeAnnotations", annotationsStart, annotationsErr, errorMessage(annotationsErr))
+		if annotationsErr != nil {
+			slog.Error("failed to apply node annotations", "error", annotationsErr)
+		}
+	}
+	if err == nil && parser.KubeletServingCertRotationEnabled(config) {
+		// the node runs pods without its serving certificate, only logs, exec and metrics fail until the CSR is approved.
+		// A failure is reported without failing provisioning.
+		servingCertStart := time.Now()
+		servingCertErr := waitForKubeletServingCert(ctx, kubeletServingCert{
+			Path:         kubeletServingCertFile,
+			RequiredSANs: config.GetKubeletConfig().GetServingCertConfig().GetRequiredSans(),
+			Timeout:      kubeletServingCertTimeout,
+			Interval:     kubeletServingCertInterval,
+		})
+		endPhase("KubeletServingCert", servingCertStart, servingCertErr, errorMessage(servingCertErr))
+		if servingCertErr != nil {
+			slog.Error("kubelet serving certificate check failed", "error", servingCertErr)
+		}
+	}
+	report, postureErr := collectSecurityPosture(paths.Root, config, time.Now())
+	if postureErr == nil {
+		postureErr = writeSecurityPostureReport(paths.SecurityPostureFile, report)
+	}
+	if postureErr != nil {
+		slog.Error("failed to generate security posture report", "error", postureErr)
+	}
+	if featuresErr := recordNetworkFeatures(paths.NetworkFeaturesFile, collectNetworkFeatures(paths.Root, config.GetNetworkConfig())); featuresErr != nil {
+		slog.Error("failed to record network features", "error", featuresErr)
+	}
+	if err == nil {
+		metadata, metadataErr := a.collectNodeMetadata(ctx, config, time.Now())
+		if metadataErr != nil {
+			slog.Error("node metadata is incomplete", "error", metadataErr)
+		}
+		if metadata.VHDVersion != unknownVersion {
+			vhd = &nodeconfigutils.VHDMetadata{ImageVersion: metadata.VHDVersion}
+		}
+		if writeErr := writeNodeMetadata(paths.NodeMetadataFile, paths.MOTDFile, metadata); writeErr != nil {
+			slog.Error("failed to stamp node metadata", "error", writeErr)
+		}
+	}
+	// provision.json is consumed by provision-wait and the RP, surface schema drift in CSE early.
+	if _, statErr := os.Stat(statusFiles.ProvisionJSONFile); statErr == nil {
+		if _, validateErr := readProvisionStatus(statusFiles.ProvisionJSONFile); validateErr != nil {
+			slog.Error("provision.json doesn't match the provision status schema", "error", validateErr)
+		}
+	}
+	if err == nil && flags.ProvisionedConfigFile != "" {
+		if writeErr := writeProvisionedConfig(flags.ProvisionedConfigFile, config); writeErr != nil {
+			slog.Error("failed to record provisioned config, the reprovision guard won't detect this provisioning", "error", writeErr)
+		}
+	}
 	return err
 }
 
 func (a *App) ProvisionWait(ctx context.Context, filepaths ProvisionStatusFiles) (string, error) {
-	if _, err := os.Stat(filepaths.ProvisionCompleteFile); err == nil {
-		data, err := os.ReadFile(filepaths.ProvisionJSONFile)
-		if err != nil {
-			return "", fmt.Errorf("failed to read provision.json: %w", err)
-		}
-		return string(data), nil
+	if provisionComplete(filepaths) {
+		return readProvisionStatus(filepaths.ProvisionJSONFile)
 	}
 
 	watcher, err := fsnotify.NewWatcher()
@@ -121,25 +725,32 @@ func (a *App) ProvisionWait(ctx context.Context, filepaths ProvisionStatusFiles)
 	}
 	defer watcher.Close()
 
-	// Watch the directory containing the provision complete file
-	dir := filepath.Dir(filepaths.ProvisionCompleteFile)
-	err = os.MkdirAll(dir, 0755) // create the directory if it doesn't exist
-	if err != nil {
-		return "", fmt.Errorf("failed to create directory %s: %w", dir, err)
+	// Watch the directories containing the provision complete and in progress files
+	dirs := []string{filepath.Dir(filepaths.ProvisionCompleteFile)}
+	if filepaths.ProvisionInProgressFile != "" && filepath.Dir(filepaths.ProvisionInProgressFile) != dirs[0] {
+		dirs = append(dirs, filepath.Dir(filepaths.ProvisionInProgressFile))
 	}
-	if err = watcher.Add(dir); err != nil {
-		return "", fmt.Errorf("failed to watch directory: %w", err)
+	for _, dir := range dirs {
+		err = os.MkdirAll(dir, 0755) // create the directory if it doesn't exist
+		if err != nil {
+			return "", fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+		if err = watcher.Add(dir); err != nil {
+			return "", fmt.Errorf("failed to watch directory: %w", err)
+		}
+	}
+	// the files may have changed before the watch started.
+	if provisionComplete(filepaths) {
+		return readProvisionStatus(filepaths.ProvisionJSONFile)
 	}
 
 	for {
 		select {
 		case event := <-watcher.Events:
-			if event.Op&fsnotify.Create == fsnotify.Create && event.Name == filepaths.ProvisionCompleteFile {
-				data, err := os.ReadFile(filepaths.ProvisionJSONFile)
-				if err != nil {
-					return "", fmt.Errorf("failed to read provision.json: %w", err)
-				}
-				return string(data), nil
+			created := event.Op&fsnotify.Create == fsnotify.Create && event.Name == filepaths.ProvisionCompleteFile
+			removed := event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 && event.Name == filepaths.ProvisionInProgressFile
+			if (created || removed) && provisionComplete(filepaths) {
+				return readProvisionStatus(filepaths.ProvisionJSONFile)
 			}
 
 		case err := <-watcher.Errors:
@@ -167,3 +778,29 @@ func errToExitCode(err error) int {
 	}
 	return 1
 }
+
+// emitEvent exports a provisioning event, telemetry failures never fail provisioning.
+func emitEvent(ctx context.Context, exporter telemetry.Exporter, event telemetry.Event) {
+	if exporter == nil {
+		return
+	}
+	if err := exporter.Export(ctx, event); err != nil {
+		slog.Warn("failed to export telemetry event", "phase", event.Phase, "error", err)
+	}
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// stderrTail keeps the end of the CSE stderr, which usually contains the failure reason, within the size of an event.
+func stderrTail(stderr string) string {
+	const maxLen = 1024
+	if len(stderr) > maxLen {
+		return stderr[len(stderr)-maxLen:]
+	}
+	return stderr
+}
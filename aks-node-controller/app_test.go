@@ -10,7 +10,10 @@ import (
 	"testing"
 	"time"
 
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/nodeconfigutils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // MockCmdRunner is a simple mock for cmdRunner.
@@ -148,8 +151,129 @@ func TestApp_Provision(t *testing.T) {
 	}
 }
 
+// testProvisionConfig returns the path of a provision config with the required fields, mutated by mutate.
+func testProvisionConfig(t *testing.T, mutate func(config *aksnodeconfigv1.Configuration)) string {
+	config := &aksnodeconfigv1.Configuration{
+		Version:    "v0",
+		AuthConfig: &aksnodeconfigv1.AuthConfig{SubscriptionId: "sub"},
+		ClusterConfig: &aksnodeconfigv1.ClusterConfig{
+			ResourceGroup:        "rg",
+			Location:             "eastus",
+			ClusterNetworkConfig: &aksnodeconfigv1.ClusterNetworkConfig{VnetName: "vnet", RouteTable: "rt"},
+		},
+		ApiServerConfig: &aksnodeconfigv1.ApiServerConfig{ApiServerName: "mycluster.hcp.eastus.azmk8s.io"},
+	}
+	if mutate != nil {
+		mutate(config)
+	}
+	data, err := nodeconfigutils.MarshalConfigurationV1(config)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+// testProvisionPaths returns provision paths under a temporary directory.
+func testProvisionPaths(t *testing.T) provisionPaths {
+	dir := t.TempDir()
+	return provisionPaths{
+		StatusFiles: ProvisionStatusFiles{
+			ProvisionJSONFile:       filepath.Join(dir, "provision.json"),
+			ProvisionCompleteFile:   filepath.Join(dir, "provision.complete"),
+			ProvisionInProgressFile: filepath.Join(dir, "run", "provision.inprogress"),
+		},
+		Root:                   filepath.Join(dir, "root"),
+		KubeletConfigDropInDir: filepath.Join(dir, "kubelet.conf.d"),
+		KubeletHooksDropIn:     filepath.Join(dir, "50-aks-custom-scripts.conf"),
+		SecurityPostureFile:    filepath.Join(dir, "security-posture.json"),
+		NetworkFeaturesFile:    filepath.Join(dir, "network-features.json"),
+		NodeMetadataFile:       filepath.Join(dir, "aks-node-metadata.json"),
+		MOTDFile:               filepath.Join(dir, "motd"),
+	}
+}
+
+func TestApp_ProvisionWaitForControllerChecks(t *testing.T) {
+	paths := testProvisionPaths(t)
+	configPath := testProvisionConfig(t, func(config *aksnodeconfigv1.Configuration) {
+		config.KubeBinaryConfig = &aksnodeconfigv1.KubeBinaryConfig{PodInfraContainerImageUrl: "mcr.microsoft.com/oss/kubernetes/pause:3.6"}
+	})
+	app := &App{
+		imdsComputeEndpoint: "http://127.0.0.1:0",
+		cmdRunner: func(cmd *exec.Cmd) error {
+			switch filepath.Base(cmd.Args[0]) {
+			case "bash":
+				// CSE reports success and writes provision.complete before the controller checks containerd.
+				if err := os.WriteFile(paths.StatusFiles.ProvisionJSONFile, []byte(`{"ExitCode":"0","Output":"","Error":"","ExecDuration":"1"}`), 0644); err != nil {
+					return err
+				}
+				return os.WriteFile(paths.StatusFiles.ProvisionCompleteFile, nil, 0644)
+			case "ctr":
+				time.Sleep(200 * time.Millisecond)
+				return errors.New("image not found")
+			}
+			return nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	type result struct {
+		output string
+		err    error
+	}
+	waited := make(chan result, 1)
+	go func() {
+		output, err := app.ProvisionWait(ctx, paths.StatusFiles)
+		waited <- result{output, err}
+	}()
+
+	err := app.provision(ctx, ProvisionFlags{ProvisionConfig: configPath}, paths)
+	require.ErrorContains(t, err, "image not found")
+	got := <-waited
+	require.NoError(t, got.err)
+	status, err := nodeconfigutils.ParseProvisionStatus([]byte(got.output))
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(containerdNotReadyExitCode), status.ExitCode)
+	assert.Contains(t, status.Error, "image not found")
+	phases := []string{}
+	for _, phase := range status.Phases {
+		phases = append(phases, phase.Name)
+	}
+	assert.Equal(t, []string{"CSE", "ContainerdReadiness"}, phases)
+	assert.NoFileExists(t, paths.StatusFiles.ProvisionInProgressFile)
+}
+
+func TestApp_ProvisionRecordsPreCSEFailure(t *testing.T) {
+	paths := testProvisionPaths(t)
+	// the drop-in directory can't be created over a file.
+	paths.KubeletConfigDropInDir = filepath.Join(t.TempDir(), "kubelet.conf.d")
+	require.NoError(t, os.WriteFile(paths.KubeletConfigDropInDir, nil, 0644))
+	configPath := testProvisionConfig(t, func(config *aksnodeconfigv1.Configuration) {
+		config.KubernetesVersion = "1.30.0"
+		config.KubeletConfig = &aksnodeconfigv1.KubeletConfig{KubeletConfigDropIns: map[string]string{"10-max-pods.conf": "maxPods: 50\n"}}
+	})
+	ran := false
+	app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+		ran = true
+		return nil
+	}}
+
+	err := app.provision(context.Background(), ProvisionFlags{ProvisionConfig: configPath}, paths)
+	require.ErrorContains(t, err, "write kubelet config drop-ins")
+	assert.False(t, ran, "CSE ran after a failed phase")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	output, err := app.ProvisionWait(ctx, paths.StatusFiles)
+	require.NoError(t, err)
+	status, err := nodeconfigutils.ParseProvisionStatus([]byte(output))
+	require.NoError(t, err)
+	assert.Equal(t, "1", status.ExitCode)
+	assert.Contains(t, status.Error, "write kubelet config drop-ins")
+}
+
 func TestApp_ProvisionWait(t *testing.T) {
-	testData := "hello world"
+	testData := `{"ExitCode":"0","Output":"hello world","Error":"","ExecDuration":"1"}`
 
 	tests := []struct {
 		name      string
@@ -177,6 +301,38 @@ func TestApp_ProvisionWait(t *testing.T) {
 				os.Create(provisionStatusFiles.ProvisionCompleteFile)
 			},
 		},
+		{
+			name:      "invalid provision.json",
+			wantsErr:  true,
+			errString: "invalid provision.json",
+			setup: func(provisionStatusFiles ProvisionStatusFiles) {
+				os.WriteFile(provisionStatusFiles.ProvisionJSONFile, []byte(`{"Output":"hello world"}`), 0644)
+				os.Create(provisionStatusFiles.ProvisionCompleteFile)
+			},
+		},
+		{
+			name:     "wait for the controller to finish provisioning",
+			wantsErr: false,
+			setup: func(provisionStatusFiles ProvisionStatusFiles) {
+				os.WriteFile(provisionStatusFiles.ProvisionJSONFile, []byte(testData), 0644)
+				os.Create(provisionStatusFiles.ProvisionCompleteFile)
+				os.Create(provisionStatusFiles.ProvisionInProgressFile)
+				go func() {
+					time.Sleep(200 * time.Millisecond)
+					os.Remove(provisionStatusFiles.ProvisionInProgressFile)
+				}()
+			},
+		},
+		{
+			name:      "controller still provisioning",
+			wantsErr:  true,
+			errString: "context deadline exceeded waiting for provision complete",
+			setup: func(provisionStatusFiles ProvisionStatusFiles) {
+				os.WriteFile(provisionStatusFiles.ProvisionJSONFile, []byte(testData), 0644)
+				os.Create(provisionStatusFiles.ProvisionCompleteFile)
+				os.Create(provisionStatusFiles.ProvisionInProgressFile)
+			},
+		},
 		{
 			name:      "timeout waiting for completion",
 			wantsErr:  true,
@@ -192,9 +348,10 @@ func TestApp_ProvisionWait(t *testing.T) {
 			assert.NoError(t, err)
 			tempFile := filepath.Join(tempDir, "testfile.txt")
 			completeFile := filepath.Join(tempDir, "provision.complete")
+			inProgressFile := filepath.Join(tempDir, "provision.inprogress")
 			defer os.RemoveAll(tempDir)
 
-			provisionStatusFiles := ProvisionStatusFiles{ProvisionJSONFile: tempFile, ProvisionCompleteFile: completeFile}
+			provisionStatusFiles := ProvisionStatusFiles{ProvisionJSONFile: tempFile, ProvisionCompleteFile: completeFile, ProvisionInProgressFile: inProgressFile}
 			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 			defer cancel()
 
@@ -205,7 +362,7 @@ func TestApp_ProvisionWait(t *testing.T) {
 				tt.setup(provisionStatusFiles)
 			}
 
-			data, err := app.ProvisionWait(ctx, ProvisionStatusFiles{ProvisionJSONFile: tempFile, ProvisionCompleteFile: completeFile})
+			data, err := app.ProvisionWait(ctx, provisionStatusFiles)
 			if tt.wantsErr {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errString)
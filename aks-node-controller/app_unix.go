@@ -0,0 +1,24 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// setCancelProcessGroup starts the command in its own process group and makes context cancellation
+// send SIGTERM to the whole group, so that processes spawned by CSE aren't orphaned when the controller is stopped.
+func setCancelProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+	}
+	cmd.WaitDelay = cseCancelGracePeriod
+}
+
+// replaceBinary renames the new binary over the current one, the running process keeps the binary it was started from.
+func replaceBinary(newPath, path string) error {
+	return os.Rename(newPath, path)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// setCancelProcessGroup makes context cancellation kill the command. Windows has no process groups to signal, so only
+// the command itself is killed.
+func setCancelProcessGroup(cmd *exec.Cmd) {
+	cmd.Cancel = func() error {
+		return cmd.Process.Kill()
+	}
+	cmd.WaitDelay = cseCancelGracePeriod
+}
+
+// replaceBinary replaces the binary of the running process. Windows doesn't allow it to be overwritten but allows it
+// to be renamed, so it is moved aside before the new binary takes its place, and removed by the next update.
+func replaceBinary(newPath, path string) error {
+	oldPath := path + ".old"
+	if err := os.Remove(oldPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.Rename(path, oldPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.Rename(newPath, path); err != nil {
+		_ = os.Rename(oldPath, path)
+		return err
+	}
+	return nil
+}
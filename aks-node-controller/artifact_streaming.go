@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+const (
+	overlaybdSnapshotterPath = "/opt/overlaybd/snapshotter/overlaybd-snapshotter"
+	acrConfigPath            = "/opt/acr/bin/acr-config"
+)
+
+// artifactStreamingUnits are the units of the overlaybd snapshotter, started in order: the TCMU backstore serving
+// the image layers, the snapshotter containerd reaches through its proxy plugin and the ACR mirror.
+var artifactStreamingUnits = []string{"overlaybd-tcmu.service", "overlaybd-snapshotter.service", "acr-mirror.service"}
+
+// supportsArtifactStreaming returns whether the node image under root ships the overlaybd snapshotter, which node
+// images built before artifact streaming was released don't.
+func supportsArtifactStreaming(root string) bool {
+	for _, path := range []string{overlaybdSnapshotterPath, acrConfigPath} {
+		if _, err := os.Stat(filepath.Join(root, path)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// enableArtifactStreaming starts the overlaybd snapshotter and points it at ACR before CSE restarts containerd with
+// the overlaybd proxy plugin.
+func (a *App) enableArtifactStreaming(ctx context.Context) error {
+	for _, unit := range artifactStreamingUnits {
+		if err := a.cmdRunner(exec.CommandContext(ctx, "systemctl", "enable", "--now", unit)); err != nil {
+			return fmt.Errorf("enable %s: %w", unit, err)
+		}
+	}
+	if err := a.cmdRunner(exec.CommandContext(ctx, acrConfigPath, "--enable-containerd", "azurecr.io")); err != nil {
+		return fmt.Errorf("configure ACR mirror: %w", err)
+	}
+	slog.Info("artifact streaming enabled")
+	return nil
+}
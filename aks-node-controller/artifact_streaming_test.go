@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSupportsArtifactStreaming(t *testing.T) {
+	root := t.TempDir()
+	assert.False(t, supportsArtifactStreaming(root))
+
+	for _, path := range []string{overlaybdSnapshotterPath, acrConfigPath} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, path), nil, 0755))
+	}
+	assert.True(t, supportsArtifactStreaming(root))
+}
+
+func TestApp_enableArtifactStreaming(t *testing.T) {
+	t.Run("units started", func(t *testing.T) {
+		var commands []string
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			commands = append(commands, strings.Join(cmd.Args, " "))
+			return nil
+		}}
+		require.NoError(t, app.enableArtifactStreaming(context.Background()))
+		assert.Equal(t, []string{
+			"systemctl enable --now overlaybd-tcmu.service",
+			"systemctl enable --now overlaybd-snapshotter.service",
+			"systemctl enable --now acr-mirror.service",
+			"/opt/acr/bin/acr-config --enable-containerd azurecr.io",
+		}, commands)
+	})
+
+	t.Run("unit fails", func(t *testing.T) {
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			if strings.Contains(strings.Join(cmd.Args, " "), "overlaybd-snapshotter") {
+				return errors.New("unit not found")
+			}
+			return nil
+		}}
+		err := app.enableArtifactStreaming(context.Background())
+		assert.EqualError(t, err, "enable overlaybd-snapshotter.service: unit not found")
+	})
+}
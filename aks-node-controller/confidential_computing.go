@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os/exec"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// configureConfidentialComputing creates the attestation group, loads the attestation kernel modules of confidential
+// VMs and applies the udev rules giving the group access to the SGX and attestation devices.
+func (a *App) configureConfidentialComputing(ctx context.Context, config *aksnodeconfigv1.Configuration, modulesFile, udevRulesFile string) error {
+	group := parser.ConfidentialComputingGroup(config)
+	if err := a.cmdRunner(exec.CommandContext(ctx, "groupadd", "--force", "--system", group)); err != nil {
+		return fmt.Errorf("create group %s: %w", group, err)
+	}
+
+	modules := parser.ConfidentialComputingKernelModules(config)
+	if len(modules) > 0 {
+		if err := writeFileAtomic(modulesFile, []byte(parser.ConfidentialComputingModulesLoadContent(config)), 0644); err != nil {
+			return err
+		}
+		for _, module := range modules {
+			if err := a.cmdRunner(exec.CommandContext(ctx, "modprobe", module)); err != nil {
+				return fmt.Errorf("modprobe %s: %w", module, err)
+			}
+		}
+	}
+
+	if err := writeFileAtomic(udevRulesFile, []byte(parser.ConfidentialComputingUdevRules(config)), 0644); err != nil {
+		return err
+	}
+	if err := a.cmdRunner(exec.CommandContext(ctx, "udevadm", "control", "--reload-rules")); err != nil {
+		return fmt.Errorf("udevadm reload rules: %w", err)
+	}
+	// the devices already exist, the rules only apply to them once they are triggered again.
+	if err := a.cmdRunner(exec.CommandContext(ctx, "udevadm", "trigger", "--action=add", "--subsystem-match=misc",
+		"--subsystem-match=tpmrm")); err != nil {
+		return fmt.Errorf("udevadm trigger: %w", err)
+	}
+	slog.Info("confidential computing configured", "type", parser.ConfidentialComputingType(config), "group", group)
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_configureConfidentialComputing(t *testing.T) {
+	run := func(t *testing.T, config *aksnodeconfigv1.Configuration) ([]string, string, string) {
+		dir := t.TempDir()
+		modulesFile := filepath.Join(dir, "modules-load.d", "aks-confidential-computing.conf")
+		udevRulesFile := filepath.Join(dir, "rules.d", "90-aks-confidential-computing.rules")
+		var commands []string
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			commands = append(commands, strings.Join(cmd.Args, " "))
+			return nil
+		}}
+		require.NoError(t, app.configureConfidentialComputing(context.Background(), config, modulesFile, udevRulesFile))
+		return commands, modulesFile, udevRulesFile
+	}
+
+	t.Run("SGX", func(t *testing.T) {
+		config := &aksnodeconfigv1.Configuration{VmSize: "Standard_DC4s_v3"}
+		commands, modulesFile, udevRulesFile := run(t, config)
+		assert.Equal(t, []string{
+			"groupadd --force --system sgx_prv",
+			"udevadm control --reload-rules",
+			"udevadm trigger --action=add --subsystem-match=misc --subsystem-match=tpmrm",
+		}, commands)
+		assert.NoFileExists(t, modulesFile)
+		rules, err := os.ReadFile(udevRulesFile)
+		require.NoError(t, err)
+		assert.Equal(t, parser.ConfidentialComputingUdevRules(config), string(rules))
+	})
+
+	t.Run("SEV-SNP", func(t *testing.T) {
+		config := &aksnodeconfigv1.Configuration{VmSize: "Standard_DC4as_v5"}
+		commands, modulesFile, _ := run(t, config)
+		assert.Equal(t, []string{
+			"groupadd --force --system tss",
+			"modprobe sev-guest",
+			"udevadm control --reload-rules",
+			"udevadm trigger --action=add --subsystem-match=misc --subsystem-match=tpmrm",
+		}, commands)
+		modules, err := os.ReadFile(modulesFile)
+		require.NoError(t, err)
+		assert.Equal(t, "sev-guest\n", string(modules))
+	})
+
+	t.Run("command failure", func(t *testing.T) {
+		dir := t.TempDir()
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			if cmd.Args[0] == "modprobe" {
+				return assert.AnError
+			}
+			return nil
+		}}
+		err := app.configureConfidentialComputing(context.Background(), &aksnodeconfigv1.Configuration{VmSize: "Standard_EC8es_v5"},
+			filepath.Join(dir, "modules.conf"), filepath.Join(dir, "udev.rules"))
+		assert.ErrorContains(t, err, "modprobe tdx_guest")
+	})
+}
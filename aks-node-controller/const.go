@@ -6,4 +6,30 @@ const (
 	logFile                   = "/var/log/azure/aks-node-controller.log"
 	provisionJSONFilePath     = "/var/log/azure/aks/provision.json"
 	provisionCompleteFilePath = "/opt/azure/containers/provision.complete"
+	provisionedConfigFilePath = "/opt/azure/containers/aks-node-controller-provisioned-config.json"
+	securityPostureFilePath   = "/var/log/azure/aks/security-posture.json"
+	networkFeaturesFilePath   = "/var/log/azure/aks/network-features.json"
+	deprovisionStatusFilePath = "/var/log/azure/aks/deprovision.json"
+	nodeMetadataFilePath      = "/etc/aks-node-metadata.json"
+	motdFilePath              = "/etc/motd"
+	containerdCertsDir        = "/etc/containerd/certs.d"
+	customScriptsLogDir       = "/var/log/azure/aks/custom-scripts"
+	kubeletHooksDropInPath    = "/etc/systemd/system/kubelet.service.d/50-aks-custom-scripts.conf"
+	artifactEndpoint          = "https://acs-mirror.azureedge.net"
+	clusterProvisionLogFile   = "/var/log/azure/cluster-provision.log"
+	kernelModulesLoadFile     = "/etc/modules-load.d/aks-node-controller.conf"
+	kernelModulesOptionsFile  = "/etc/modprobe.d/aks-node-controller.conf"
+	legacyModulesBlacklist    = "/etc/modprobe.d/aks-legacy-modules-blacklist.conf"
+	confidentialModulesFile   = "/etc/modules-load.d/aks-confidential-computing.conf"
+	confidentialUdevRulesFile = "/etc/udev/rules.d/90-aks-confidential-computing.rules"
+
+	kubeletPKIDir                = "/var/lib/kubelet/pki"
+	kubeletCertRotationStateFile = "/var/lib/kubelet/aks-node-controller-cert-rotation.json"
+	kubernetesCACertFile         = "/etc/kubernetes/certs/ca.crt"
+	secureTLSBootstrapClientPath = "/opt/azure/tlsbootstrap/tls-bootstrap-client"
+	systemdUnitDir               = "/etc/systemd/system"
 )
+
+// provisionInProgressFilePath is under /run so that a marker left behind by a controller which didn't exit is cleared
+// on reboot.
+const provisionInProgressFilePath = "/run/aks-node-controller/provision.inprogress"
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// installCredentialProviders installs the plugin binaries of the kubelet credential providers and writes their
+// configuration under root. The ACR providers use the binary CSE installs, under another name it is linked to.
+func (a *App) installCredentialProviders(ctx context.Context, config *aksnodeconfigv1.Configuration, root string) error {
+	d, err := a.newDownloader(config)
+	if err != nil {
+		return err
+	}
+	binDir := filepath.Join(root, parser.CredentialProviderBinDir)
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		return fmt.Errorf("create %s: %w", binDir, err)
+	}
+	for _, provider := range config.GetCredentialProviders().GetProviders() {
+		name := parser.CredentialProviderName(provider)
+		switch {
+		case provider.GetDownloadUrl() != "":
+			if err := installPluginBinary(ctx, provider.GetDownloadUrl(), binDir, name, d); err != nil {
+				return fmt.Errorf("install credential provider %s: %w", name, err)
+			}
+		case name != parser.ACRCredentialProviderName:
+			link := filepath.Join(binDir, name)
+			if err := os.Remove(link); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("remove %s: %w", link, err)
+			}
+			if err := os.Symlink(parser.ACRCredentialProviderName, link); err != nil {
+				return fmt.Errorf("link credential provider %s: %w", name, err)
+			}
+		}
+		if provider.GetType() == aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_ACR_CROSS_TENANT {
+			cloudConfig, err := parser.CrossTenantCloudConfig(provider)
+			if err != nil {
+				return fmt.Errorf("render cloud config of credential provider %s: %w", name, err)
+			}
+			if err := writeFileAtomic(filepath.Join(root, parser.CrossTenantCloudConfigFile(provider)), []byte(cloudConfig), 0600); err != nil {
+				return err
+			}
+		}
+	}
+	configFile := filepath.Join(root, parser.CredentialProviderConfigFile)
+	if err := writeFileAtomic(configFile, []byte(parser.CredentialProviderConfig(config)), 0644); err != nil {
+		return err
+	}
+	slog.Info("credential providers installed", "count", len(config.GetCredentialProviders().GetProviders()))
+	return nil
+}
+
+// installPluginBinary downloads the plugin binary, or the tar.gz archive containing it, and installs it as
+// binDir/name.
+func installPluginBinary(ctx context.Context, rawURL, binDir, name string, d downloader) error {
+	tmpDir, err := os.MkdirTemp(binDir, ".download-*")
+	if err != nil {
+		return fmt.Errorf("create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	if err := downloadFile(ctx, rawURL, tmpDir, d); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", tmpDir, err)
+	}
+	if len(entries) != 1 {
+		return fmt.Errorf("expected a single file downloaded from %s, got %d", rawURL, len(entries))
+	}
+	binary := filepath.Join(tmpDir, entries[0].Name())
+	if strings.HasSuffix(binary, ".tar.gz") || strings.HasSuffix(binary, ".tgz") {
+		archive := binary
+		binary = filepath.Join(tmpDir, name)
+		if err := extractFromTarGz(archive, name, binary); err != nil {
+			return err
+		}
+	}
+	if err := os.Chmod(binary, 0755); err != nil {
+		return fmt.Errorf("chmod %s: %w", binary, err)
+	}
+	if err := os.Rename(binary, filepath.Join(binDir, name)); err != nil {
+		return fmt.Errorf("install %s: %w", name, err)
+	}
+	return nil
+}
+
+// extractFromTarGz writes the regular file of the archive named name, in any directory, to dest.
+func extractFromTarGz(archive, name, dest string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", archive, err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("%s not found in %s", name, filepath.Base(archive))
+		}
+		if err != nil {
+			return fmt.Errorf("read %s: %w", archive, err)
+		}
+		if header.Typeflag != tar.TypeReg || filepath.Base(header.Name) != name {
+			continue
+		}
+		out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, tr); err != nil { //nolint:gosec // the archive is downloaded from the configured URL
+			out.Close()
+			return fmt.Errorf("extract %s: %w", name, err)
+		}
+		return out.Close()
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_installCredentialProviders(t *testing.T) {
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	for name, content := range map[string]string{"README.md": "readme", "bin/gcr-credential-provider": "gcr binary"} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/gcr-credential-provider.tar.gz":
+			_, _ = w.Write(archive.Bytes())
+		case "/ecr-credential-provider":
+			_, _ = w.Write([]byte("ecr binary"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := &aksnodeconfigv1.Configuration{
+		CredentialProviders: &aksnodeconfigv1.CredentialProviders{
+			Providers: []*aksnodeconfigv1.CredentialProvider{
+				{Type: aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_ACR},
+				{
+					Type:        aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_ACR_CROSS_TENANT,
+					Name:        "acr-cross-tenant",
+					MatchImages: []string{"contoso.azurecr.io"},
+					TenantId:    "tenant",
+					ClientId:    "client",
+				},
+				{
+					Type:        aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_CUSTOM,
+					Name:        "ecr-credential-provider",
+					DownloadUrl: server.URL + "/ecr-credential-provider",
+					MatchImages: []string{"*.dkr.ecr.*.amazonaws.com"},
+				},
+				{
+					Type:        aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_CUSTOM,
+					Name:        "gcr-credential-provider",
+					DownloadUrl: server.URL + "/gcr-credential-provider.tar.gz",
+					MatchImages: []string{"*.gcr.io"},
+				},
+			},
+		},
+	}
+	app := &App{client: server.Client()}
+	root := t.TempDir()
+	binDir := filepath.Join(root, parser.CredentialProviderBinDir)
+
+	// running it again replaces the installed binaries and links.
+	for range 2 {
+		require.NoError(t, app.installCredentialProviders(context.Background(), config, root))
+	}
+
+	for name, want := range map[string]string{"ecr-credential-provider": "ecr binary", "gcr-credential-provider": "gcr binary"} {
+		data, err := os.ReadFile(filepath.Join(binDir, name))
+		require.NoError(t, err)
+		assert.Equal(t, want, string(data))
+		info, err := os.Stat(filepath.Join(binDir, name))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	}
+	target, err := os.Readlink(filepath.Join(binDir, "acr-cross-tenant"))
+	require.NoError(t, err)
+	assert.Equal(t, parser.ACRCredentialProviderName, target)
+	entries, err := os.ReadDir(binDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3, "temporary download directories are removed")
+
+	cloudConfig, err := os.ReadFile(filepath.Join(root, parser.CredentialProviderCloudConfigDir, "acr-cross-tenant.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"tenantId": "tenant", "useManagedIdentityExtension": true, "userAssignedIdentityID": "client"}`, string(cloudConfig))
+	providerConfig, err := os.ReadFile(filepath.Join(root, parser.CredentialProviderConfigFile))
+	require.NoError(t, err)
+	assert.Equal(t, parser.CredentialProviderConfig(config), string(providerConfig))
+
+	t.Run("binary missing from the archive", func(t *testing.T) {
+		config := &aksnodeconfigv1.Configuration{
+			CredentialProviders: &aksnodeconfigv1.CredentialProviders{
+				Providers: []*aksnodeconfigv1.CredentialProvider{{
+					Type:        aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_CUSTOM,
+					Name:        "other-credential-provider",
+					DownloadUrl: server.URL + "/gcr-credential-provider.tar.gz",
+					MatchImages: []string{"*.example.com"},
+				}},
+			},
+		}
+		err := app.installCredentialProviders(context.Background(), config, t.TempDir())
+		assert.EqualError(t, err, "install credential provider other-credential-provider: other-credential-provider not found in gcr-credential-provider.tar.gz")
+	})
+}
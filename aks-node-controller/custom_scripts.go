@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/nodeconfigutils"
+)
+
+const (
+	defaultCustomScriptTimeout = 5 * time.Minute
+	// kubeletDefaultStartTimeout is the systemd default TimeoutStartSec, kubelet hook timeouts are added to it.
+	kubeletDefaultStartTimeout = 90 * time.Second
+)
+
+// customScriptHooks maps the hook names used on the command line and in log file names to the configuration hooks.
+var customScriptHooks = map[string]aksnodeconfigv1.CustomScriptHook{
+	"post-network": aksnodeconfigv1.CustomScriptHook_CUSTOM_SCRIPT_HOOK_POST_NETWORK,
+	"pre-kubelet":  aksnodeconfigv1.CustomScriptHook_CUSTOM_SCRIPT_HOOK_PRE_KUBELET,
+	"post-kubelet": aksnodeconfigv1.CustomScriptHook_CUSTOM_SCRIPT_HOOK_POST_KUBELET,
+}
+
+func customScriptHookName(hook aksnodeconfigv1.CustomScriptHook) string {
+	for name, h := range customScriptHooks {
+		if h == hook {
+			return name
+		}
+	}
+	return hook.String()
+}
+
+type RunHooksFlags struct {
+	ProvisionConfig string
+	Hook            string
+	// LogDir receives the output of every script as <hook>-<name>.log.
+	LogDir string
+}
+
+// CustomScriptError is returned when a custom script fails or exceeds its timeout.
+type CustomScriptError struct {
+	Hook string
+	Name string
+	Err  error
+}
+
+func (e *CustomScriptError) Error() string {
+	return fmt.Sprintf("custom script %q of hook %s failed: %s", e.Name, e.Hook, e.Err)
+}
+
+func (e *CustomScriptError) Unwrap() error {
+	return e.Err
+}
+
+// RunHooks runs the custom scripts of a hook, it is invoked by the kubelet drop-in for the kubelet hooks.
+func (a *App) RunHooks(ctx context.Context, flags RunHooksFlags) error {
+	hook, ok := customScriptHooks[flags.Hook]
+	if !ok {
+		return fmt.Errorf("unknown hook %q", flags.Hook)
+	}
+	inputJSON, err := os.ReadFile(flags.ProvisionConfig)
+	if err != nil {
+		return fmt.Errorf("open provision file %s: %w", flags.ProvisionConfig, err)
+	}
+	config, err := nodeconfigutils.UnmarshalConfigurationV1(inputJSON)
+	if err != nil {
+		return fmt.Errorf("unmarshal provision config: %w", err)
+	}
+	return a.runCustomScripts(ctx, config.GetCustomScripts(), hook, flags.LogDir)
+}
+
+// validateCustomScripts checks the custom scripts before provisioning starts,
+// so that a misconfigured script doesn't leave a half-provisioned node.
+func validateCustomScripts(scripts []*aksnodeconfigv1.CustomScript) error {
+	names := map[string]bool{}
+	for i, script := range scripts {
+		name := script.GetName()
+		switch {
+		case name == "":
+			return fmt.Errorf("custom script %d: name is required", i)
+		case strings.ContainsAny(name, "/\\") || name == "." || name == "..":
+			return fmt.Errorf("custom script %q: name must be usable as a file name", name)
+		case names[name]:
+			return fmt.Errorf("custom script %q: duplicate name", name)
+		case script.GetHook() == aksnodeconfigv1.CustomScriptHook_CUSTOM_SCRIPT_HOOK_UNSPECIFIED:
+			return fmt.Errorf("custom script %q: hook is required", name)
+		case script.GetTimeoutSeconds() < 0:
+			return fmt.Errorf("custom script %q: timeout must not be negative", name)
+		}
+		names[name] = true
+	}
+	return nil
+}
+
+// runCustomScripts runs the scripts of hook sequentially in configuration order and stops at the first failure,
+// a script can rely on the scripts configured before it.
+func (a *App) runCustomScripts(ctx context.Context, scripts []*aksnodeconfigv1.CustomScript, hook aksnodeconfigv1.CustomScriptHook, logDir string) error {
+	hookName := customScriptHookName(hook)
+	for _, script := range scripts {
+		if script.GetHook() != hook {
+			continue
+		}
+		if err := a.runCustomScript(ctx, script, hookName, logDir); err != nil {
+			return &CustomScriptError{Hook: hookName, Name: script.GetName(), Err: err}
+		}
+	}
+	return nil
+}
+
+func (a *App) runCustomScript(ctx context.Context, script *aksnodeconfigv1.CustomScript, hookName, logDir string) error {
+	timeout := customScriptTimeout(script)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return fmt.Errorf("create %s: %w", logDir, err)
+	}
+	logPath := filepath.Join(logDir, hookName+"-"+script.GetName()+".log")
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", logPath, err)
+	}
+	defer logFile.Close()
+
+	slog.Info("running custom script", "hook", hookName, "name", script.GetName(), "timeout", timeout)
+	start := time.Now()
+	cmd := exec.CommandContext(ctx, "/bin/bash", "-c", script.GetScript())
+	cmd.Stdout = io.MultiWriter(os.Stdout, logFile)
+	cmd.Stderr = io.MultiWriter(os.Stderr, logFile)
+	setCancelProcessGroup(cmd)
+	err = a.cmdRunner(cmd)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+	slog.Info("custom script finished", "hook", hookName, "name", script.GetName(), "duration", time.Since(start), "log", logPath, "error", err)
+	return err
+}
+
+func customScriptTimeout(script *aksnodeconfigv1.CustomScript) time.Duration {
+	if script.GetTimeoutSeconds() > 0 {
+		return time.Duration(script.GetTimeoutSeconds()) * time.Second
+	}
+	return defaultCustomScriptTimeout
+}
+
+// renderKubeletHooksDropIn returns the kubelet.service drop-in running the pre-kubelet and post-kubelet scripts,
+// or an empty string when there is no such script. The start timeout is raised by the script timeouts so that
+// systemd doesn't kill kubelet while a hook is still within its own timeout.
+func renderKubeletHooksDropIn(scripts []*aksnodeconfigv1.CustomScript, executable, provisionConfig string) string {
+	var hooks []string
+	hookTimeout := time.Duration(0)
+	for _, name := range []string{"pre-kubelet", "post-kubelet"} {
+		hook := customScriptHooks[name]
+		found := false
+		for _, script := range scripts {
+			if script.GetHook() == hook {
+				found = true
+				hookTimeout += customScriptTimeout(script)
+			}
+		}
+		if !found {
+			continue
+		}
+		directive := "ExecStartPre"
+		if name == "post-kubelet" {
+			directive = "ExecStartPost"
+		}
+		hooks = append(hooks, fmt.Sprintf("%s=%s run-hooks --provision-config=%s --hook=%s", directive, executable, provisionConfig, name))
+	}
+	if len(hooks) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("[Service]\nTimeoutStartSec=%d\n%s\n", int((kubeletDefaultStartTimeout + hookTimeout).Seconds()), strings.Join(hooks, "\n"))
+}
+
+// installKubeletHooks writes the kubelet drop-in before CSE starts kubelet, or removes a stale one.
+func (a *App) installKubeletHooks(scripts []*aksnodeconfigv1.CustomScript, dropInPath, provisionConfig string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("get executable path: %w", err)
+	}
+	if provisionConfig, err = filepath.Abs(provisionConfig); err != nil {
+		return fmt.Errorf("get absolute path of %s: %w", provisionConfig, err)
+	}
+	content := renderKubeletHooksDropIn(scripts, executable, provisionConfig)
+	if content == "" {
+		if err = os.Remove(dropInPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove %s: %w", dropInPath, err)
+		}
+		return nil
+	}
+	if err = writeFileAtomic(dropInPath, []byte(content), 0644); err != nil {
+		return err
+	}
+	cmd := exec.Command("systemctl", "daemon-reload")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err = a.cmdRunner(cmd); err != nil {
+		return fmt.Errorf("reload systemd units: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	hookPostNetwork = aksnodeconfigv1.CustomScriptHook_CUSTOM_SCRIPT_HOOK_POST_NETWORK
+	hookPreKubelet  = aksnodeconfigv1.CustomScriptHook_CUSTOM_SCRIPT_HOOK_PRE_KUBELET
+	hookPostKubelet = aksnodeconfigv1.CustomScriptHook_CUSTOM_SCRIPT_HOOK_POST_KUBELET
+)
+
+func TestValidateCustomScripts(t *testing.T) {
+	tests := []struct {
+		name    string
+		scripts []*aksnodeconfigv1.CustomScript
+		wantErr string
+	}{
+		{
+			name: "valid",
+			scripts: []*aksnodeconfigv1.CustomScript{
+				{Name: "a", Hook: hookPostNetwork},
+				{Name: "b", Hook: hookPreKubelet, TimeoutSeconds: 10},
+			},
+		},
+		{
+			name:    "missing name",
+			scripts: []*aksnodeconfigv1.CustomScript{{Hook: hookPostNetwork}},
+			wantErr: "custom script 0: name is required",
+		},
+		{
+			name:    "name with path separator",
+			scripts: []*aksnodeconfigv1.CustomScript{{Name: "../a", Hook: hookPostNetwork}},
+			wantErr: "name must be usable as a file name",
+		},
+		{
+			name:    "duplicate name",
+			scripts: []*aksnodeconfigv1.CustomScript{{Name: "a", Hook: hookPostNetwork}, {Name: "a", Hook: hookPreKubelet}},
+			wantErr: `custom script "a": duplicate name`,
+		},
+		{
+			name:    "missing hook",
+			scripts: []*aksnodeconfigv1.CustomScript{{Name: "a"}},
+			wantErr: `custom script "a": hook is required`,
+		},
+		{
+			name:    "negative timeout",
+			scripts: []*aksnodeconfigv1.CustomScript{{Name: "a", Hook: hookPostNetwork, TimeoutSeconds: -1}},
+			wantErr: "timeout must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCustomScripts(tt.scripts)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunCustomScripts(t *testing.T) {
+	app := &App{cmdRunner: cmdRunner}
+
+	t.Run("scripts of the hook run in order and their output is captured", func(t *testing.T) {
+		logDir := t.TempDir()
+		marker := filepath.Join(t.TempDir(), "order")
+		scripts := []*aksnodeconfigv1.CustomScript{
+			{Name: "first", Hook: hookPostNetwork, Script: "echo first >> " + marker},
+			{Name: "kubelet", Hook: hookPreKubelet, Script: "echo kubelet >> " + marker},
+			{Name: "second", Hook: hookPostNetwork, Script: "echo second >> " + marker + "; echo hello; echo oops >&2"},
+		}
+		require.NoError(t, app.runCustomScripts(context.Background(), scripts, hookPostNetwork, logDir))
+
+		order, err := os.ReadFile(marker)
+		require.NoError(t, err)
+		assert.Equal(t, "first\nsecond\n", string(order))
+		output, err := os.ReadFile(filepath.Join(logDir, "post-network-second.log"))
+		require.NoError(t, err)
+		assert.Equal(t, "hello\noops\n", string(output))
+		assert.NoFileExists(t, filepath.Join(logDir, "pre-kubelet-kubelet.log"))
+	})
+
+	t.Run("a failing script stops the hook", func(t *testing.T) {
+		logDir := t.TempDir()
+		scripts := []*aksnodeconfigv1.CustomScript{
+			{Name: "fails", Hook: hookPostKubelet, Script: "exit 3"},
+			{Name: "skipped", Hook: hookPostKubelet, Script: "true"},
+		}
+		err := app.runCustomScripts(context.Background(), scripts, hookPostKubelet, logDir)
+		var scriptErr *CustomScriptError
+		require.ErrorAs(t, err, &scriptErr)
+		assert.Equal(t, "fails", scriptErr.Name)
+		assert.Equal(t, "post-kubelet", scriptErr.Hook)
+		assert.NoFileExists(t, filepath.Join(logDir, "post-kubelet-skipped.log"))
+	})
+
+	t.Run("a script exceeding its timeout is killed", func(t *testing.T) {
+		scripts := []*aksnodeconfigv1.CustomScript{{Name: "slow", Hook: hookPreKubelet, Script: "sleep 30", TimeoutSeconds: 1}}
+		err := app.runCustomScripts(context.Background(), scripts, hookPreKubelet, t.TempDir())
+		assert.ErrorContains(t, err, `custom script "slow" of hook pre-kubelet failed: timed out after 1s`)
+	})
+}
+
+func TestRenderKubeletHooksDropIn(t *testing.T) {
+	assert.Empty(t, renderKubeletHooksDropIn([]*aksnodeconfigv1.CustomScript{{Name: "a", Hook: hookPostNetwork}}, "/usr/bin/aks-node-controller", "/etc/config.json"))
+
+	dropIn := renderKubeletHooksDropIn([]*aksnodeconfigv1.CustomScript{
+		{Name: "a", Hook: hookPostKubelet, TimeoutSeconds: 30},
+		{Name: "b", Hook: hookPreKubelet},
+	}, "/usr/bin/aks-node-controller", "/etc/config.json")
+	assert.Equal(t, `[Service]
+TimeoutStartSec=420
+ExecStartPre=/usr/bin/aks-node-controller run-hooks --provision-config=/etc/config.json --hook=pre-kubelet
+ExecStartPost=/usr/bin/aks-node-controller run-hooks --provision-config=/etc/config.json --hook=post-kubelet
+`, dropIn)
+}
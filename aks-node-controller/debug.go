@@ -0,0 +1,36 @@
+package main
+
+import (
+	"log/slog"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// logLevel is the level of the default logger, Provision adjusts it for each phase from the debug configuration.
+var logLevel = new(slog.LevelVar)
+
+// phaseLogLevel returns the log level of a provisioning phase. A phase without its own level uses the
+// configuration-wide level, which defaults to info.
+func phaseLogLevel(debugConfig *aksnodeconfigv1.DebugConfig, phase string) slog.Level {
+	level, ok := debugConfig.GetPhaseLogLevels()[phase]
+	if !ok || level == aksnodeconfigv1.LogLevel_LOG_LEVEL_UNSPECIFIED {
+		level = debugConfig.GetLogLevel()
+	}
+	switch level {
+	case aksnodeconfigv1.LogLevel_LOG_LEVEL_DEBUG:
+		return slog.LevelDebug
+	case aksnodeconfigv1.LogLevel_LOG_LEVEL_WARN:
+		return slog.LevelWarn
+	case aksnodeconfigv1.LogLevel_LOG_LEVEL_ERROR:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// enterPhase sets the log level of the phase which is about to start.
+func enterPhase(debugConfig *aksnodeconfigv1.DebugConfig, phase string) {
+	level := phaseLogLevel(debugConfig, phase)
+	logLevel.Set(level)
+	slog.Debug("entering provisioning phase", "phase", phase, "logLevel", level)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"log/slog"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhaseLogLevel(t *testing.T) {
+	tests := []struct {
+		name        string
+		debugConfig *aksnodeconfigv1.DebugConfig
+		phase       string
+		want        slog.Level
+	}{
+		{
+			name:  "defaults to info",
+			phase: "CSE",
+			want:  slog.LevelInfo,
+		},
+		{
+			name:        "configuration-wide level",
+			debugConfig: &aksnodeconfigv1.DebugConfig{LogLevel: aksnodeconfigv1.LogLevel_LOG_LEVEL_WARN},
+			phase:       "CSE",
+			want:        slog.LevelWarn,
+		},
+		{
+			name: "phase level overrides configuration-wide level",
+			debugConfig: &aksnodeconfigv1.DebugConfig{
+				LogLevel:       aksnodeconfigv1.LogLevel_LOG_LEVEL_ERROR,
+				PhaseLogLevels: map[string]aksnodeconfigv1.LogLevel{"GPUDriver": aksnodeconfigv1.LogLevel_LOG_LEVEL_DEBUG},
+			},
+			phase: "GPUDriver",
+			want:  slog.LevelDebug,
+		},
+		{
+			name: "other phases keep the configuration-wide level",
+			debugConfig: &aksnodeconfigv1.DebugConfig{
+				LogLevel:       aksnodeconfigv1.LogLevel_LOG_LEVEL_ERROR,
+				PhaseLogLevels: map[string]aksnodeconfigv1.LogLevel{"GPUDriver": aksnodeconfigv1.LogLevel_LOG_LEVEL_DEBUG},
+			},
+			phase: "ContainerdReadiness",
+			want:  slog.LevelError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, phaseLogLevel(tt.debugConfig, tt.phase))
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+)
+
+// deprovisionServices are stopped in order, kubelet first so that it doesn't restart the containers.
+var deprovisionServices = []string{"kubelet.service", "containerd.service"}
+
+// deprovisionPaths are the bootstrap credentials, secrets and generated configuration removed from the node.
+// Logs are kept for forensic workflows.
+var deprovisionPaths = []string{
+	"/var/lib/kubelet/bootstrap-kubeconfig",
+	"/var/lib/kubelet/kubeconfig",
+	kubeletPKIDir,
+	kubeletCertRotationStateFile,
+	"/etc/kubernetes/certs",
+	"/etc/kubernetes/azure.json",
+	"/etc/default/kubelet",
+	parser.KubeletConfigDropInDir,
+	kubeletHooksDropInPath,
+	provisionedConfigFilePath,
+	provisionCompleteFilePath,
+}
+
+type DeprovisionFlags struct {
+	// APIServerURL, when set, deletes the Node object of the node with its kubelet client certificate before the
+	// certificate is removed.
+	APIServerURL string
+	NodeName     string
+	// Root is prepended to the node paths, it is "/" on a node.
+	Root string
+	// StatusFile records the outcome of the deprovisioning.
+	StatusFile string
+}
+
+// DeprovisionStatus is written to the deprovision status file.
+type DeprovisionStatus struct {
+	StartTime       time.Time `json:"startTime"`
+	EndTime         time.Time `json:"endTime"`
+	NodeDeleted     bool      `json:"nodeDeleted"`
+	StoppedServices []string  `json:"stoppedServices,omitempty"`
+	RemovedPaths    []string  `json:"removedPaths,omitempty"`
+	// Errors lists the steps which failed, the other steps are still run.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Deprovision unbootstraps the node so that it can be recycled securely: the Node object is deleted, kubelet and
+// containerd are stopped, and the bootstrap credentials, secrets and generated configuration are removed. Every
+// step is attempted even when a previous one failed, and the outcome is written to the status file.
+func (a *App) Deprovision(ctx context.Context, flags DeprovisionFlags) error {
+	status := DeprovisionStatus{StartTime: time.Now().UTC()}
+	var errs []error
+	fail := func(err error) {
+		errs = append(errs, err)
+		status.Errors = append(status.Errors, err.Error())
+		slog.Error("deprovision step failed", "error", err)
+	}
+
+	// the node is deleted first, the kubelet client certificate is removed below.
+	if flags.APIServerURL != "" {
+		if err := deleteNode(ctx, flags); err != nil {
+			fail(fmt.Errorf("delete node %s: %w", flags.NodeName, err))
+		} else {
+			status.NodeDeleted = true
+		}
+	}
+
+	for _, service := range deprovisionServices {
+		if err := a.cmdRunner(exec.CommandContext(ctx, "systemctl", "disable", "--now", service)); err != nil {
+			fail(fmt.Errorf("stop %s: %w", service, err))
+			continue
+		}
+		status.StoppedServices = append(status.StoppedServices, service)
+	}
+
+	for _, path := range deprovisionPaths {
+		path = filepath.Join(flags.Root, path)
+		if _, err := os.Lstat(path); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err := os.RemoveAll(path); err != nil {
+			fail(fmt.Errorf("remove %s: %w", path, err))
+			continue
+		}
+		status.RemovedPaths = append(status.RemovedPaths, path)
+	}
+
+	status.EndTime = time.Now().UTC()
+	data, err := json.Marshal(status)
+	if err != nil {
+		return errors.Join(append(errs, fmt.Errorf("marshal deprovision status: %w", err))...)
+	}
+	if err := writeFileAtomic(flags.StatusFile, data, 0644); err != nil {
+		errs = append(errs, err)
+	}
+	slog.Info("node deprovisioned", "nodeDeleted", status.NodeDeleted, "removedPaths", len(status.RemovedPaths), "errors", len(status.Errors))
+	return errors.Join(errs...)
+}
+
+// deleteNode deletes the Node object, authenticated with the kubelet client certificate. The node authorizer lets
+// a node delete its own Node object. A node which is already deleted isn't an error.
+func deleteNode(ctx context.Context, flags DeprovisionFlags) error {
+	client, err := kubeletClient(flags.Root)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, flags.APIServerURL+"/api/v1/nodes/"+flags.NodeName, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound || (resp.StatusCode >= 200 && resp.StatusCode <= 299) {
+		return nil
+	}
+	data, _ := io.ReadAll(resp.Body)
+	return fmt.Errorf("unexpected status %s: %s", resp.Status, data)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKubeletClientCert writes a self-signed kubelet client certificate and key bundle under root.
+func writeKubeletClientCert(t *testing.T, root string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "system:node:node1", Organization: []string{"system:nodes"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	bundle := append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})...)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, kubeletPKIDir), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, kubeletPKIDir, "kubelet-client-current.pem"), bundle, 0600))
+}
+
+func TestApp_Deprovision(t *testing.T) {
+	var deleted []string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "system:node:node1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			deleted = append(deleted, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	setupNode := func(t *testing.T) string {
+		root := t.TempDir()
+		writeKubeletClientCert(t, root)
+		for _, path := range []string{"/var/lib/kubelet/bootstrap-kubeconfig", "/etc/kubernetes/azure.json", provisionCompleteFilePath, logFile} {
+			require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(root, path)), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte("secret"), 0600))
+		}
+		ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(kubernetesCACertFile)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, kubernetesCACertFile), ca, 0644))
+		return root
+	}
+	readStatus := func(t *testing.T, path string) DeprovisionStatus {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var status DeprovisionStatus
+		require.NoError(t, json.Unmarshal(data, &status))
+		return status
+	}
+
+	t.Run("node is deleted and unbootstrapped", func(t *testing.T) {
+		deleted = nil
+		root := setupNode(t)
+		var commands []string
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			commands = append(commands, strings.Join(cmd.Args, " "))
+			return nil
+		}}
+		statusFile := filepath.Join(t.TempDir(), "deprovision.json")
+
+		err := app.Deprovision(context.Background(), DeprovisionFlags{APIServerURL: server.URL, NodeName: "node1", Root: root, StatusFile: statusFile})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/api/v1/nodes/node1"}, deleted)
+		assert.Equal(t, []string{"systemctl disable --now kubelet.service", "systemctl disable --now containerd.service"}, commands)
+		for _, path := range []string{"/var/lib/kubelet/bootstrap-kubeconfig", "/etc/kubernetes/azure.json", "/etc/kubernetes/certs", kubeletPKIDir, provisionCompleteFilePath} {
+			assert.NoFileExists(t, filepath.Join(root, path))
+		}
+		assert.FileExists(t, filepath.Join(root, logFile))
+
+		status := readStatus(t, statusFile)
+		assert.True(t, status.NodeDeleted)
+		assert.Len(t, status.StoppedServices, 2)
+		assert.Len(t, status.RemovedPaths, 5)
+		assert.Empty(t, status.Errors)
+	})
+
+	t.Run("failed steps don't stop the deprovisioning", func(t *testing.T) {
+		root := setupNode(t)
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			if cmd.Args[len(cmd.Args)-1] == "containerd.service" {
+				return errors.New("exit status 1")
+			}
+			return nil
+		}}
+		statusFile := filepath.Join(t.TempDir(), "deprovision.json")
+
+		err := app.Deprovision(context.Background(), DeprovisionFlags{NodeName: "node1", Root: root, StatusFile: statusFile})
+		assert.ErrorContains(t, err, "stop containerd.service: exit status 1")
+		assert.NoFileExists(t, filepath.Join(root, "/etc/kubernetes/azure.json"))
+
+		status := readStatus(t, statusFile)
+		assert.False(t, status.NodeDeleted)
+		assert.Equal(t, []string{"kubelet.service"}, status.StoppedServices)
+		assert.Equal(t, []string{"stop containerd.service: exit status 1"}, status.Errors)
+	})
+}
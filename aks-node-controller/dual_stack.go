@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// primaryInterface is the interface of the primary NIC of Azure VMs, which holds the node addresses.
+const primaryInterface = "eth0"
+
+// setupDualStack installs and starts the unit enabling IPv6 forwarding and masquerading the secondary CIDRs, before
+// CSE starts kubelet and the first pods.
+func (a *App) setupDualStack(ctx context.Context, config *aksnodeconfigv1.Configuration, systemdDir string) error {
+	if err := a.installUnit(ctx, systemdDir, parser.DualStackUnit, parser.DualStackUnitContent(config)); err != nil {
+		return err
+	}
+	slog.Info("dual-stack networking configured", "secondaryCIDRs", config.GetNetworkConfig().GetSecondaryCidrs())
+	return nil
+}
+
+// interfaceAddrs returns the addresses of the interface.
+func interfaceAddrs(name string) ([]net.Addr, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return iface.Addrs()
+}
+
+// nodeIPs returns the first global unicast address of each IP family, in the order of the families.
+func nodeIPs(addrs []net.Addr, families []aksnodeconfigv1.IPFamily) ([]string, error) {
+	ips := make([]string, 0, len(families))
+	for _, family := range families {
+		found := ""
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || !ipNet.IP.IsGlobalUnicast() {
+				continue
+			}
+			if (ipNet.IP.To4() == nil) == (family == aksnodeconfigv1.IPFamily_IP_FAMILY_IPV6) {
+				found = ipNet.IP.String()
+				break
+			}
+		}
+		if found == "" {
+			return nil, fmt.Errorf("no %v address found", family)
+		}
+		ips = append(ips, found)
+	}
+	return ips, nil
+}
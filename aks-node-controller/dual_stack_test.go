@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeIPs(t *testing.T) {
+	addrs := []net.Addr{
+		&net.IPNet{IP: net.ParseIP("fe80::1"), Mask: net.CIDRMask(64, 128)},
+		&net.IPNet{IP: net.ParseIP("10.224.0.4"), Mask: net.CIDRMask(16, 32)},
+		&net.IPNet{IP: net.ParseIP("fd00::4"), Mask: net.CIDRMask(64, 128)},
+		&net.IPNet{IP: net.ParseIP("10.224.0.5"), Mask: net.CIDRMask(16, 32)},
+	}
+	ipv4, ipv6 := aksnodeconfigv1.IPFamily_IP_FAMILY_IPV4, aksnodeconfigv1.IPFamily_IP_FAMILY_IPV6
+
+	ips, err := nodeIPs(addrs, []aksnodeconfigv1.IPFamily{ipv4, ipv6})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.224.0.4", "fd00::4"}, ips)
+
+	ips, err = nodeIPs(addrs, []aksnodeconfigv1.IPFamily{ipv6, ipv4})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fd00::4", "10.224.0.4"}, ips)
+
+	_, err = nodeIPs(addrs[:2], []aksnodeconfigv1.IPFamily{ipv4, ipv6})
+	assert.EqualError(t, err, "no IP_FAMILY_IPV6 address found", "link-local addresses aren't node addresses")
+}
+
+func TestApp_setupDualStack(t *testing.T) {
+	config := &aksnodeconfigv1.Configuration{
+		NetworkConfig: &aksnodeconfigv1.NetworkConfig{
+			IpFamilies:     []aksnodeconfigv1.IPFamily{aksnodeconfigv1.IPFamily_IP_FAMILY_IPV4, aksnodeconfigv1.IPFamily_IP_FAMILY_IPV6},
+			SecondaryCidrs: []string{"fd00:10:244::/64"},
+		},
+	}
+	systemdDir := t.TempDir()
+	var commands []string
+	app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+		commands = append(commands, strings.Join(cmd.Args, " "))
+		return nil
+	}}
+	require.NoError(t, app.setupDualStack(context.Background(), config, systemdDir))
+	assert.Equal(t, []string{"systemctl daemon-reload", "systemctl enable --now aks-dual-stack.service"}, commands)
+	unit, err := os.ReadFile(filepath.Join(systemdDir, parser.DualStackUnit))
+	require.NoError(t, err)
+	assert.Equal(t, parser.DualStackUnitContent(config), string(unit))
+}
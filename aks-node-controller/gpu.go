@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/pkg/agent"
+)
+
+const (
+	gpuDriverImageRepository = "mcr.microsoft.com/aks/aks-gpu"
+	gpuDriverInstallTimeout  = 10 * time.Minute
+)
+
+// GPU exit codes, they match the ones of the CSE scripts so that existing failure analytics keep working.
+const (
+	errGPUDownloadTimeout       = 83
+	errGPUDriversStartFail      = 84
+	errGPUDriversInstallTimeout = 85
+	errGPUInfoROMCorrupted      = 87
+)
+
+// GPUDriverError is returned by the GPU driver phase, its exit code identifies the failure mode.
+type GPUDriverError struct {
+	Code   int
+	VMSize string
+	Err    error
+}
+
+func (e *GPUDriverError) Error() string {
+	return fmt.Sprintf("gpu driver setup failed on %s (exit code %d): %s", e.VMSize, e.Code, e.Err)
+}
+
+func (e *GPUDriverError) Unwrap() error {
+	return e.Err
+}
+
+func (e *GPUDriverError) ExitCode() int {
+	return e.Code
+}
+
+// gpuDriverImage returns the driver installer image for the VM size, grid drivers are used for converged SKUs.
+func gpuDriverImage(vmSize string) string {
+	return fmt.Sprintf("%s-%s:%s-%s", gpuDriverImageRepository, agent.GetGPUDriverType(vmSize),
+		agent.GetGPUDriverVersion(vmSize), agent.GetAKSGPUImageSHA(vmSize))
+}
+
+// installGPUDriver installs the Nvidia driver when the configuration asks for it and verifies that nvidia-smi works.
+// It returns false when there is nothing to install.
+func (a *App) installGPUDriver(ctx context.Context, config *aksnodeconfigv1.Configuration) (bool, error) {
+	gpuConfig := config.GetGpuConfig()
+	if !gpuConfig.GetEnableNvidia() || !gpuConfig.GetConfigGpuDriver() {
+		return false, nil
+	}
+	vmSize := config.GetVmSize()
+	// nodes without outbound access pull the driver image from the bootstrap profile container registry.
+	image := parser.BootstrapImage(config, gpuDriverImage(vmSize))
+	slog.Info("installing gpu driver", "vmSize", vmSize, "image", image)
+
+	if err := a.runGPUCommand(ctx, nil, "ctr", "--namespace", "k8s.io", "image", "pull", image); err != nil {
+		return true, &GPUDriverError{Code: errGPUDownloadTimeout, VMSize: vmSize, Err: fmt.Errorf("pull %s: %w", image, err)}
+	}
+
+	installCtx, cancel := context.WithTimeout(ctx, gpuDriverInstallTimeout)
+	defer cancel()
+	err := a.runGPUCommand(installCtx, nil, "ctr", "--namespace", "k8s.io", "run", "--privileged", "--rm", "--net-host",
+		"--with-ns", "pid:/proc/1/ns/pid",
+		"--mount", "type=bind,src=/opt/gpu,dst=/mnt/gpu,options=rbind",
+		"--mount", "type=bind,src=/opt/actions,dst=/mnt/actions,options=rbind",
+		image, "gpuinstall", "/entrypoint.sh", "install")
+	if err != nil {
+		return true, &GPUDriverError{Code: errGPUDriversInstallTimeout, VMSize: vmSize, Err: fmt.Errorf("install driver: %w", err)}
+	}
+
+	if agent.GPUNeedsFabricManager(vmSize) {
+		if err = a.runGPUCommand(ctx, nil, "systemctl", "enable", "--now", "nvidia-fabricmanager"); err != nil {
+			return true, &GPUDriverError{Code: errGPUDriversStartFail, VMSize: vmSize, Err: fmt.Errorf("start nvidia-fabricmanager: %w", err)}
+		}
+	}
+
+	var smiOutput bytes.Buffer
+	if err = a.runGPUCommand(ctx, &smiOutput, "nvidia-smi"); err != nil {
+		code := errGPUDriversStartFail
+		if strings.Contains(smiOutput.String(), "infoROM is corrupted") {
+			code = errGPUInfoROMCorrupted
+		}
+		return true, &GPUDriverError{Code: code, VMSize: vmSize, Err: fmt.Errorf("nvidia-smi: %w", err)}
+	}
+	slog.Info("gpu driver installed", "vmSize", vmSize)
+	return true, nil
+}
+
+func (a *App) runGPUCommand(ctx context.Context, output *bytes.Buffer, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if output != nil {
+		cmd.Stdout = io.MultiWriter(os.Stdout, output)
+		cmd.Stderr = io.MultiWriter(os.Stderr, output)
+	}
+	slog.Debug("running gpu command", "args", cmd.Args)
+	return a.cmdRunner(cmd)
+}
+
+// setEnv sets key in the environment, replacing any existing value.
+func setEnv(env []string, key, value string) []string {
+	result := make([]string, 0, len(env)+1)
+	for _, kv := range env {
+		if !strings.HasPrefix(kv, key+"=") {
+			result = append(result, kv)
+		}
+	}
+	return append(result, key+"="+value)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_InstallGPUDriver(t *testing.T) {
+	enableNvidia := true
+	gpuConfig := func(vmSize string) *aksnodeconfigv1.Configuration {
+		return &aksnodeconfigv1.Configuration{
+			VmSize:    vmSize,
+			GpuConfig: &aksnodeconfigv1.GpuConfig{EnableNvidia: &enableNvidia, ConfigGpuDriver: true},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		config        *aksnodeconfigv1.Configuration
+		failCommand   string
+		smiOutput     string
+		wantInstalled bool
+		wantCommands  []string
+		wantExitCode  int
+	}{
+		{
+			name:   "non gpu node",
+			config: &aksnodeconfigv1.Configuration{VmSize: "Standard_D2s_v3"},
+		},
+		{
+			name:          "cuda driver",
+			config:        gpuConfig("Standard_NC6s_v3"),
+			wantInstalled: true,
+			wantCommands:  []string{"ctr image pull", "ctr run", "nvidia-smi"},
+		},
+		{
+			name:          "fabric manager is started for nd h100",
+			config:        gpuConfig("Standard_ND96isr_H100_v5"),
+			wantInstalled: true,
+			wantCommands:  []string{"ctr image pull", "ctr run", "systemctl enable", "nvidia-smi"},
+		},
+		{
+			name:          "image pull failure",
+			config:        gpuConfig("Standard_NC6s_v3"),
+			failCommand:   "ctr image pull",
+			wantInstalled: true,
+			wantCommands:  []string{"ctr image pull"},
+			wantExitCode:  errGPUDownloadTimeout,
+		},
+		{
+			name:          "corrupted info ROM",
+			config:        gpuConfig("Standard_NC6s_v3"),
+			failCommand:   "nvidia-smi",
+			smiOutput:     "WARNING: infoROM is corrupted at gpu 0001:00:00.0",
+			wantInstalled: true,
+			wantCommands:  []string{"ctr image pull", "ctr run", "nvidia-smi"},
+			wantExitCode:  errGPUInfoROMCorrupted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands := []string{}
+			app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+				name := commandName(cmd)
+				commands = append(commands, name)
+				if name == "nvidia-smi" && tt.smiOutput != "" {
+					_, _ = cmd.Stdout.Write([]byte(tt.smiOutput))
+				}
+				if name == tt.failCommand {
+					return &ExitError{Code: 1}
+				}
+				return nil
+			}}
+
+			installed, err := app.installGPUDriver(context.Background(), tt.config)
+			assert.Equal(t, tt.wantInstalled, installed)
+			if tt.wantExitCode != 0 {
+				var gpuErr *GPUDriverError
+				require.True(t, errors.As(err, &gpuErr))
+				assert.Equal(t, tt.wantExitCode, errToExitCode(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.wantCommands == nil {
+				tt.wantCommands = []string{}
+			}
+			assert.Equal(t, tt.wantCommands, commands)
+		})
+	}
+}
+
+// commandName returns the binary and the sub-command, e.g. "ctr image pull".
+func commandName(cmd *exec.Cmd) string {
+	switch cmd.Args[0] {
+	case "ctr":
+		if cmd.Args[3] == "run" {
+			return "ctr run"
+		}
+		return "ctr " + strings.Join(cmd.Args[3:5], " ")
+	case "systemctl":
+		return "systemctl " + cmd.Args[1]
+	default:
+		return cmd.Args[0]
+	}
+}
+
+func TestGPUDriverImage(t *testing.T) {
+	assert.True(t, strings.HasPrefix(gpuDriverImage("Standard_NC6s_v3"), "mcr.microsoft.com/aks/aks-gpu-cuda:"))
+	assert.True(t, strings.HasPrefix(gpuDriverImage("Standard_NV36ads_A10_v5"), "mcr.microsoft.com/aks/aks-gpu-grid:"))
+}
+
+func TestSetEnv(t *testing.T) {
+	env := setEnv([]string{"A=1", "CONFIG_GPU_DRIVER_IF_NEEDED=true", "B=2"}, "CONFIG_GPU_DRIVER_IF_NEEDED", "false")
+	assert.Equal(t, []string{"A=1", "B=2", "CONFIG_GPU_DRIVER_IF_NEEDED=false"}, env)
+}
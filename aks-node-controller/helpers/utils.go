@@ -27,7 +27,7 @@ import (
 	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
 	"github.com/Azure/agentbaker/pkg/agent"
 	"github.com/Azure/agentbaker/pkg/agent/datamodel"
-	"github.com/blang/semver"
+	"github.com/Azure/agentbaker/pkg/agent/kubeversion"
 )
 
 const numInPair = 2
@@ -70,15 +70,9 @@ func GetDefaultOutboundCommand() string {
 	return "curl -v --insecure --proxy-insecure https://mcr.microsoft.com/v2/"
 }
 
-func GetKubeletNodeLabels(agentPool *datamodel.AgentPoolProfile) map[string]string {
-	kubeletLabels := map[string]string{
-		"agentpool":                      agentPool.Name,
-		"kubernetes.azure.com/agentpool": agentPool.Name,
-	}
-	for key, val := range agentPool.CustomNodeLabels {
-		kubeletLabels[key] = val
-	}
-	return kubeletLabels
+// GetKubeletNodeLabels returns the standard node labels for the node being bootstrapped.
+func GetKubeletNodeLabels(nbc *datamodel.NodeBootstrappingConfiguration) map[string]string {
+	return nbc.GetNodeLabels()
 }
 
 // NOTE: The following functions are slightly modified versions of those that are already in agent/utils.go.
@@ -236,11 +230,11 @@ func ValidateAndSetLinuxKubeletFlags(kubeletFlags map[string]string, cs *datamod
 	}
 }
 
-// IsKubernetesVersionGe returns true if actualVersion is greater than or equal to version.
+// IsKubernetesVersionGe returns true if actualVersion is greater than or equal to version. actualVersion is parsed
+// leniently, and false is returned when either version can't be parsed, use kubeversion.GreaterOrEqual to get the error.
 func IsKubernetesVersionGe(actualVersion, version string) bool {
-	v1, _ := semver.Make(actualVersion)
-	v2, _ := semver.Make(version)
-	return v1.GE(v2)
+	ge, err := kubeversion.GreaterOrEqual(actualVersion, version)
+	return err == nil && ge
 }
 
 func strKeyValToMapBool(str string, strDelim string, pairDelim string) map[string]bool {
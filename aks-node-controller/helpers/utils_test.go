@@ -133,7 +133,7 @@ func Test_getNetworkPolicyType(t *testing.T) {
 
 func Test_getKubeletNodeLabels(t *testing.T) {
 	type args struct {
-		ap *datamodel.AgentPoolProfile
+		nbc *datamodel.NodeBootstrappingConfiguration
 	}
 	tests := []struct {
 		name string
@@ -143,35 +143,63 @@ func Test_getKubeletNodeLabels(t *testing.T) {
 		{
 			name: "KubeletNodeLabels default labels",
 			args: args{
-				ap: &datamodel.AgentPoolProfile{
-					Name: "agentPool0",
+				nbc: &datamodel.NodeBootstrappingConfiguration{
+					AgentPoolProfile: &datamodel.AgentPoolProfile{
+						Name: "agentPool0",
+					},
 				},
 			},
 			want: map[string]string{
 				"agentpool":                      "agentPool0",
 				"kubernetes.azure.com/agentpool": "agentPool0",
+				"kubernetes.io/arch":             "amd64",
 			},
 		},
 		{
 			name: "KubeletNodeLabels with CustomNodeLabels",
 			args: args{
-				ap: &datamodel.AgentPoolProfile{
-					Name: "agentPool0",
-					CustomNodeLabels: map[string]string{
-						"a": "b",
+				nbc: &datamodel.NodeBootstrappingConfiguration{
+					AgentPoolProfile: &datamodel.AgentPoolProfile{
+						Name: "agentPool0",
+						CustomNodeLabels: map[string]string{
+							"a": "b",
+						},
 					},
 				},
 			},
 			want: map[string]string{
 				"agentpool":                      "agentPool0",
 				"kubernetes.azure.com/agentpool": "agentPool0",
+				"kubernetes.io/arch":             "amd64",
 				"a":                              "b",
 			},
 		},
+		{
+			name: "KubeletNodeLabels of the node being bootstrapped",
+			args: args{
+				nbc: &datamodel.NodeBootstrappingConfiguration{
+					AgentPoolProfile: &datamodel.AgentPoolProfile{
+						Name:   "agentPool0",
+						Distro: datamodel.AKSUbuntuArm64Containerd2204Gen2,
+					},
+					OSSKU:        "Ubuntu",
+					FIPSEnabled:  true,
+					EnableNvidia: true,
+				},
+			},
+			want: map[string]string{
+				"agentpool":                         "agentPool0",
+				"kubernetes.azure.com/agentpool":    "agentPool0",
+				"kubernetes.azure.com/os-sku":       "Ubuntu",
+				"kubernetes.azure.com/fips_enabled": "true",
+				"kubernetes.azure.com/accelerator":  "nvidia",
+				"kubernetes.io/arch":                "arm64",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := GetKubeletNodeLabels(tt.args.ap); !reflect.DeepEqual(got, tt.want) {
+			if got := GetKubeletNodeLabels(tt.args.nbc); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("GetKubeletNodeLabels() = %v, want %v", got, tt.want)
 			}
 		})
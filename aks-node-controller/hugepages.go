@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+const hugepagesGrubDropIn = "50-aks-hugepages.cfg"
+
+// hugepagesPaths are the host paths used to configure hugepages, overridden by tests.
+type hugepagesPaths struct {
+	// MemInfo is /proc/meminfo.
+	MemInfo string
+	// HugepagesDir is /sys/kernel/mm/hugepages, with a directory per page size.
+	HugepagesDir string
+	// GrubDir is /etc/default/grub.d.
+	GrubDir string
+	// SystemdDir is where the hugepages unit is written.
+	SystemdDir string
+}
+
+var defaultHugepagesPaths = hugepagesPaths{
+	MemInfo:      "/proc/meminfo",
+	HugepagesDir: "/sys/kernel/mm/hugepages",
+	GrubDir:      "/etc/default/grub.d",
+	SystemdDir:   systemdUnitDir,
+}
+
+// configureHugepages checks that the hugepages fit the memory of the node, then preallocates them. A runtime
+// allocation is done by a systemd unit on every boot and must get every page. A boot allocation adds the pages to the
+// kernel command line, it's only guaranteed from the next boot, as many pages as possible are allocated until then.
+func (a *App) configureHugepages(ctx context.Context, config *aksnodeconfigv1.Configuration, paths hugepagesPaths) error {
+	memTotal, err := readMemTotal(paths.MemInfo)
+	if err != nil {
+		return err
+	}
+	if err := parser.ValidateHugepagesFit(config, memTotal); err != nil {
+		return err
+	}
+	count := int(config.GetCustomLinuxOsConfig().GetHugepagesConfig().GetCount())
+	nrHugepages := filepath.Join(paths.HugepagesDir, fmt.Sprintf("hugepages-%dkB", parser.HugepageSizeKB(config)), "nr_hugepages")
+
+	if !parser.HugepagesBootAllocation(config) {
+		if err := a.installUnit(ctx, paths.SystemdDir, parser.HugepagesUnit, parser.HugepagesUnitContent(config)); err != nil {
+			return err
+		}
+		allocated, err := readHugepagesCount(nrHugepages)
+		if err != nil {
+			return err
+		}
+		if allocated < count {
+			return fmt.Errorf("only %d of %d hugepages could be allocated", allocated, count)
+		}
+		slog.Info("hugepages allocated", "count", count, "sizeKB", parser.HugepageSizeKB(config))
+		return nil
+	}
+
+	if err := writeFileAtomic(filepath.Join(paths.GrubDir, hugepagesGrubDropIn), []byte(parser.HugepagesGrubDropIn(config)), 0644); err != nil {
+		return err
+	}
+	if err := a.updateGrub(ctx); err != nil {
+		return err
+	}
+	if err := os.WriteFile(nrHugepages, []byte(strconv.Itoa(count)), 0644); err != nil {
+		return fmt.Errorf("allocate hugepages: %w", err)
+	}
+	allocated, err := readHugepagesCount(nrHugepages)
+	if err != nil {
+		return err
+	}
+	if allocated < count {
+		slog.Warn("not every hugepage could be allocated, the full count is reserved from the next boot", "allocated", allocated, "count", count)
+	}
+	slog.Info("hugepages reserved on the kernel command line", "args", parser.HugepagesKernelArgs(config))
+	return nil
+}
+
+// updateGrub regenerates the grub config with update-grub on Ubuntu and grub2-mkconfig on Azure Linux.
+func (a *App) updateGrub(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, "update-grub")
+	if _, err := exec.LookPath("update-grub"); err != nil {
+		cmd = exec.CommandContext(ctx, "grub2-mkconfig", "-o", "/boot/grub2/grub.cfg")
+	}
+	if err := a.cmdRunner(cmd); err != nil {
+		return fmt.Errorf("%s: %w", filepath.Base(cmd.Args[0]), err)
+	}
+	return nil
+}
+
+// readMemTotal returns the MemTotal of meminfo in bytes.
+func readMemTotal(memInfo string) (int64, error) {
+	data, err := os.ReadFile(memInfo)
+	if err != nil {
+		return 0, fmt.Errorf("read %s: %w", memInfo, err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 3 && fields[0] == "MemTotal:" && fields[2] == "kB" {
+			kb, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid MemTotal %q", fields[1])
+			}
+			return kb << 10, nil
+		}
+	}
+	return 0, fmt.Errorf("no MemTotal in %s", memInfo)
+}
+
+func readHugepagesCount(nrHugepages string) (int, error) {
+	data, err := os.ReadFile(nrHugepages)
+	if err != nil {
+		return 0, fmt.Errorf("read %s: %w", nrHugepages, err)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid hugepages count in %s: %w", nrHugepages, err)
+	}
+	return count, nil
+}
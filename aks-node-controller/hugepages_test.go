@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_configureHugepages(t *testing.T) {
+	newPaths := func(t *testing.T, allocated string) hugepagesPaths {
+		dir := t.TempDir()
+		paths := hugepagesPaths{
+			MemInfo:      filepath.Join(dir, "meminfo"),
+			HugepagesDir: filepath.Join(dir, "hugepages"),
+			GrubDir:      filepath.Join(dir, "grub.d"),
+			SystemdDir:   filepath.Join(dir, "systemd"),
+		}
+		require.NoError(t, os.WriteFile(paths.MemInfo, []byte("MemTotal:        8388608 kB\nMemFree:         7340032 kB\n"), 0644))
+		for _, size := range []string{"hugepages-2048kB", "hugepages-1048576kB"} {
+			require.NoError(t, os.MkdirAll(filepath.Join(paths.HugepagesDir, size), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(paths.HugepagesDir, size, "nr_hugepages"), []byte(allocated+"\n"), 0644))
+		}
+		return paths
+	}
+	config := func(size aksnodeconfigv1.HugepageSize, count int32, allocation aksnodeconfigv1.HugepagesAllocation) *aksnodeconfigv1.Configuration {
+		return &aksnodeconfigv1.Configuration{CustomLinuxOsConfig: &aksnodeconfigv1.CustomLinuxOsConfig{
+			HugepagesConfig: &aksnodeconfigv1.HugepagesConfig{PageSize: size, Count: count, Allocation: allocation},
+		}}
+	}
+
+	t.Run("runtime", func(t *testing.T) {
+		paths := newPaths(t, "1024")
+		var commands []string
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			commands = append(commands, strings.Join(cmd.Args, " "))
+			return nil
+		}}
+		hugepages := config(aksnodeconfigv1.HugepageSize_HUGEPAGE_SIZE_2MI, 1024, aksnodeconfigv1.HugepagesAllocation_HUGEPAGES_ALLOCATION_UNSPECIFIED)
+
+		require.NoError(t, app.configureHugepages(context.Background(), hugepages, paths))
+		assert.Equal(t, []string{"systemctl daemon-reload", "systemctl enable --now " + parser.HugepagesUnit}, commands)
+		assert.FileExists(t, filepath.Join(paths.SystemdDir, parser.HugepagesUnit))
+	})
+
+	t.Run("runtime allocation short", func(t *testing.T) {
+		paths := newPaths(t, "2")
+		app := &App{cmdRunner: func(*exec.Cmd) error { return nil }}
+		hugepages := config(aksnodeconfigv1.HugepageSize_HUGEPAGE_SIZE_1GI, 4, aksnodeconfigv1.HugepagesAllocation_HUGEPAGES_ALLOCATION_RUNTIME)
+
+		err := app.configureHugepages(context.Background(), hugepages, paths)
+		assert.EqualError(t, err, "only 2 of 4 hugepages could be allocated")
+	})
+
+	t.Run("boot", func(t *testing.T) {
+		paths := newPaths(t, "0")
+		var commands []string
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			commands = append(commands, filepath.Base(cmd.Args[0]))
+			return nil
+		}}
+		hugepages := config(aksnodeconfigv1.HugepageSize_HUGEPAGE_SIZE_1GI, 4, aksnodeconfigv1.HugepagesAllocation_HUGEPAGES_ALLOCATION_BOOT)
+
+		require.NoError(t, app.configureHugepages(context.Background(), hugepages, paths))
+		require.Len(t, commands, 1)
+		assert.Contains(t, []string{"update-grub", "grub2-mkconfig"}, commands[0])
+		dropIn, err := os.ReadFile(filepath.Join(paths.GrubDir, hugepagesGrubDropIn))
+		require.NoError(t, err)
+		assert.Equal(t, parser.HugepagesGrubDropIn(hugepages), string(dropIn))
+		nrHugepages, err := os.ReadFile(filepath.Join(paths.HugepagesDir, "hugepages-1048576kB", "nr_hugepages"))
+		require.NoError(t, err)
+		assert.Equal(t, "4", string(nrHugepages))
+	})
+
+	t.Run("doesn't fit", func(t *testing.T) {
+		paths := newPaths(t, "0")
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			t.Fatalf("unexpected command %v", cmd.Args)
+			return nil
+		}}
+		hugepages := config(aksnodeconfigv1.HugepageSize_HUGEPAGE_SIZE_1GI, 8, aksnodeconfigv1.HugepagesAllocation_HUGEPAGES_ALLOCATION_BOOT)
+
+		err := app.configureHugepages(context.Background(), hugepages, paths)
+		assert.EqualError(t, err, "8 hugepages of 1048576kB (8192Mi) and the 0Mi kubelet reserves don't fit in the 8192Mi of the node")
+	})
+}
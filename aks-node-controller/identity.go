@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	imdsTokenEndpoint = "http://169.254.169.254/metadata/identity/oauth2/token"
+	imdsAPIVersion    = "2018-02-01"
+	storageResource   = "https://storage.azure.com/"
+	acrResource       = "https://containerregistry.azure.net"
+	// acrTokenUser is the user name ACR expects with a refresh token obtained from an AAD token.
+	acrTokenUser = "00000000-0000-0000-0000-000000000000"
+	// blobAPIVersion is the first storage API version supporting bearer token authorization.
+	blobAPIVersion = "2017-11-09"
+)
+
+// managedIdentity acquires tokens of a user-assigned managed identity from IMDS and caches them for the
+// duration of the command, provisioning is short enough for tokens not to expire.
+type managedIdentity struct {
+	clientID string
+	// endpoint is the IMDS token endpoint.
+	endpoint string
+	client   *http.Client
+
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+func newManagedIdentity(clientID, endpoint string, client *http.Client) *managedIdentity {
+	if clientID == "" {
+		return nil
+	}
+	if endpoint == "" {
+		endpoint = imdsTokenEndpoint
+	}
+	return &managedIdentity{clientID: clientID, endpoint: endpoint, client: client, tokens: map[string]string{}}
+}
+
+// token returns an access token of the identity for resource.
+func (m *managedIdentity) token(ctx context.Context, resource string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if token, ok := m.tokens[resource]; ok {
+		return token, nil
+	}
+	query := url.Values{"api-version": {imdsAPIVersion}, "resource": {resource}, "client_id": {m.clientID}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Metadata", "true")
+	var body struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err = m.doJSON(req, &body); err != nil {
+		return "", fmt.Errorf("get token for %s from IMDS: %w", resource, err)
+	}
+	m.tokens[resource] = body.AccessToken
+	return body.AccessToken, nil
+}
+
+// acrRefreshToken exchanges an AAD token of the identity for a refresh token of registry, which is used as the
+// password of registry logins.
+func (m *managedIdentity) acrRefreshToken(ctx context.Context, registry string) (string, error) {
+	aadToken, err := m.token(ctx, acrResource)
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{"grant_type": {"access_token"}, "service": {registry}, "access_token": {aadToken}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+registry+"/oauth2/exchange", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	var body struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err = m.doJSON(req, &body); err != nil {
+		return "", fmt.Errorf("exchange token for %s: %w", registry, err)
+	}
+	return body.RefreshToken, nil
+}
+
+func (m *managedIdentity) doJSON(req *http.Request, v any) error {
+	client := m.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// authorizeDownload adds the identity's credentials to requests for Azure storage blobs, other hosts are left anonymous.
+func (m *managedIdentity) authorizeDownload(req *http.Request) error {
+	if m == nil || !strings.HasSuffix(req.URL.Hostname(), ".blob.core.windows.net") {
+		return nil
+	}
+	token, err := m.token(req.Context(), storageResource)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("x-ms-version", blobAPIVersion)
+	return nil
+}
+
+// registryCredentials returns the ctr --user value for pulling image, or an empty string when the image
+// isn't hosted on an Azure container registry.
+func (m *managedIdentity) registryCredentials(ctx context.Context, image string) (string, error) {
+	if m == nil {
+		return "", nil
+	}
+	registry, _, _ := strings.Cut(image, "/")
+	if !strings.HasSuffix(registry, ".azurecr.io") {
+		return "", nil
+	}
+	refreshToken, err := m.acrRefreshToken(ctx, registry)
+	if err != nil {
+		return "", err
+	}
+	return acrTokenUser + ":" + refreshToken, nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagedIdentity(t *testing.T) {
+	requests := 0
+	imds := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "true", r.Header.Get("Metadata"))
+		assert.Equal(t, "client-id", r.URL.Query().Get("client_id"))
+		_, _ = w.Write([]byte(`{"access_token":"token-for-` + r.URL.Query().Get("resource") + `"}`))
+	}))
+	defer imds.Close()
+
+	t.Run("no identity", func(t *testing.T) {
+		identity := newManagedIdentity("", imds.URL, imds.Client())
+		assert.Nil(t, identity)
+		req := httptest.NewRequest(http.MethodGet, "https://account.blob.core.windows.net/container/file.tgz", nil)
+		require.NoError(t, identity.authorizeDownload(req))
+		assert.Empty(t, req.Header.Get("Authorization"))
+		credentials, err := identity.registryCredentials(context.Background(), "myacr.azurecr.io/pause:3.6")
+		require.NoError(t, err)
+		assert.Empty(t, credentials)
+	})
+
+	t.Run("storage downloads are authorized and tokens are cached", func(t *testing.T) {
+		requests = 0
+		identity := newManagedIdentity("client-id", imds.URL, imds.Client())
+		for i := 0; i < 2; i++ {
+			req := httptest.NewRequest(http.MethodGet, "https://account.blob.core.windows.net/container/file.tgz", nil)
+			require.NoError(t, identity.authorizeDownload(req))
+			assert.Equal(t, "Bearer token-for-"+storageResource, req.Header.Get("Authorization"))
+			assert.Equal(t, blobAPIVersion, req.Header.Get("x-ms-version"))
+		}
+		assert.Equal(t, 1, requests)
+
+		req := httptest.NewRequest(http.MethodGet, "https://acs-mirror.azureedge.net/file.tgz", nil)
+		require.NoError(t, identity.authorizeDownload(req))
+		assert.Empty(t, req.Header.Get("Authorization"))
+	})
+
+	t.Run("images outside of ACR are pulled anonymously", func(t *testing.T) {
+		identity := newManagedIdentity("client-id", imds.URL, imds.Client())
+		credentials, err := identity.registryCredentials(context.Background(), "mcr.microsoft.com/oss/kubernetes/pause:3.6")
+		require.NoError(t, err)
+		assert.Empty(t, credentials)
+	})
+
+	t.Run("AAD token is exchanged for an ACR refresh token", func(t *testing.T) {
+		registry := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/oauth2/exchange", r.URL.Path)
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "token-for-"+acrResource, r.PostForm.Get("access_token"))
+			assert.Equal(t, "access_token", r.PostForm.Get("grant_type"))
+			_, _ = w.Write([]byte(`{"refresh_token":"refresh-for-` + r.PostForm.Get("service") + `"}`))
+		}))
+		defer registry.Close()
+		host := strings.TrimPrefix(registry.URL, "https://")
+
+		identity := newManagedIdentity("client-id", imds.URL, registry.Client())
+		refreshToken, err := identity.acrRefreshToken(context.Background(), host)
+		require.NoError(t, err)
+		assert.Equal(t, "refresh-for-"+host, refreshToken)
+	})
+}
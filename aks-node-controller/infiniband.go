@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+const mofedInstallTimeout = 20 * time.Minute
+
+// infiniBandPaths are the host paths used to set up InfiniBand, overridden by tests.
+type infiniBandPaths struct {
+	// DownloadDir is where the MOFED tarball is downloaded and extracted, it is removed once installed.
+	DownloadDir string
+	// ModulesFile is the modules-load.d file loading the InfiniBand modules on boot.
+	ModulesFile string
+	// OpenIBD is the init script of the MOFED drivers, restarting it loads the installed drivers.
+	OpenIBD string
+	// SysClassDir lists the InfiniBand devices, /sys/class/infiniband.
+	SysClassDir string
+}
+
+var defaultInfiniBandPaths = infiniBandPaths{
+	DownloadDir: "/opt/azure/mofed",
+	ModulesFile: "/etc/modules-load.d/aks-infiniband.conf",
+	OpenIBD:     "/etc/init.d/openibd",
+	SysClassDir: "/sys/class/infiniband",
+}
+
+// setupInfiniBand installs the MOFED drivers when the node image doesn't ship them, loads the InfiniBand modules and
+// checks that the InfiniBand NIC the VM size exposes through SR-IOV shows up, so that a node without it fails
+// provisioning instead of running RDMA workloads over the Ethernet NIC.
+func (a *App) setupInfiniBand(ctx context.Context, config *aksnodeconfigv1.Configuration, paths infiniBandPaths) error {
+	if err := a.cmdRunner(exec.CommandContext(ctx, "ofed_info", "-s")); err == nil {
+		slog.Info("MOFED drivers already installed")
+	} else if err := a.installMOFED(ctx, config, paths); err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(paths.ModulesFile, []byte(parser.InfiniBandModulesLoadContent(config)), 0644); err != nil {
+		return err
+	}
+	for _, module := range parser.InfiniBandKernelModules(config) {
+		if err := a.cmdRunner(exec.CommandContext(ctx, "modprobe", module)); err != nil {
+			return fmt.Errorf("modprobe %s: %w", module, err)
+		}
+	}
+
+	devices, err := os.ReadDir(paths.SysClassDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read %s: %w", paths.SysClassDir, err)
+	}
+	if len(devices) == 0 {
+		return fmt.Errorf("no InfiniBand device found on %s, the VM size %s should expose one", paths.SysClassDir, config.GetVmSize())
+	}
+	slog.Info("InfiniBand set up", "devices", len(devices))
+	return nil
+}
+
+// installMOFED downloads the MOFED tarball of the configuration, installs the drivers for the running kernel without
+// updating the firmware, which the host manages, and restarts openibd to load them.
+func (a *App) installMOFED(ctx context.Context, config *aksnodeconfigv1.Configuration, paths infiniBandPaths) error {
+	rawURL := config.GetNetworkConfig().GetMofedPackageUrl()
+	if rawURL == "" {
+		return errors.New("the node image doesn't ship the MOFED drivers and no mofed package URL is set")
+	}
+	d, err := a.newDownloader(config)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(paths.DownloadDir, 0755); err != nil {
+		return fmt.Errorf("create %s: %w", paths.DownloadDir, err)
+	}
+	tmpDir, err := os.MkdirTemp(paths.DownloadDir, ".download-*")
+	if err != nil {
+		return fmt.Errorf("create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	slog.Info("installing MOFED drivers", "url", rawURL)
+	if err = downloadFile(ctx, rawURL, tmpDir, d); err != nil {
+		return fmt.Errorf("download MOFED: %w", err)
+	}
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", tmpDir, err)
+	}
+	if len(entries) != 1 {
+		return fmt.Errorf("expected a single file downloaded from %s, got %d", rawURL, len(entries))
+	}
+
+	installerDir := filepath.Join(tmpDir, "mofed")
+	if err = os.MkdirAll(installerDir, 0755); err != nil {
+		return fmt.Errorf("create %s: %w", installerDir, err)
+	}
+	// the tarball has a single MLNX_OFED_LINUX-<version>-<distro> directory.
+	if err = a.cmdRunner(exec.CommandContext(ctx, "tar", "-xzf", filepath.Join(tmpDir, entries[0].Name()), "-C", installerDir,
+		"--strip-components=1")); err != nil {
+		return fmt.Errorf("extract MOFED: %w", err)
+	}
+	installCtx, cancel := context.WithTimeout(ctx, mofedInstallTimeout)
+	defer cancel()
+	if err = a.cmdRunner(exec.CommandContext(installCtx, filepath.Join(installerDir, "mlnxofedinstall"), "--without-fw-update",
+		"--add-kernel-support", "--skip-repo", "--force")); err != nil {
+		return fmt.Errorf("install MOFED: %w", err)
+	}
+	if err = a.cmdRunner(exec.CommandContext(ctx, paths.OpenIBD, "restart")); err != nil {
+		return fmt.Errorf("restart openibd: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_setupInfiniBand(t *testing.T) {
+	newPaths := func(t *testing.T, devices ...string) infiniBandPaths {
+		dir := t.TempDir()
+		paths := infiniBandPaths{
+			DownloadDir: filepath.Join(dir, "mofed"),
+			ModulesFile: filepath.Join(dir, "modules-load.d", "aks-infiniband.conf"),
+			OpenIBD:     filepath.Join(dir, "openibd"),
+			SysClassDir: filepath.Join(dir, "infiniband"),
+		}
+		for _, device := range devices {
+			require.NoError(t, os.MkdirAll(filepath.Join(paths.SysClassDir, device), 0755))
+		}
+		return paths
+	}
+	newConfig := func(mofedPackageURL string) *aksnodeconfigv1.Configuration {
+		return &aksnodeconfigv1.Configuration{
+			VmSize:        "Standard_HB120rs_v3",
+			NetworkConfig: &aksnodeconfigv1.NetworkConfig{EnableInfiniband: true, MofedPackageUrl: mofedPackageURL},
+		}
+	}
+
+	t.Run("MOFED shipped by the node image", func(t *testing.T) {
+		paths := newPaths(t, "mlx5_ib0")
+		var commands []string
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			commands = append(commands, strings.Join(cmd.Args, " "))
+			return nil
+		}}
+		require.NoError(t, app.setupInfiniBand(context.Background(), newConfig(""), paths))
+		assert.Equal(t, []string{"ofed_info -s", "modprobe ib_ipoib", "modprobe ib_umad", "modprobe rdma_ucm"}, commands)
+		modules, err := os.ReadFile(paths.ModulesFile)
+		require.NoError(t, err)
+		assert.Equal(t, "ib_ipoib\nib_umad\nrdma_ucm\n", string(modules))
+	})
+
+	t.Run("MOFED installed from the package URL", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("tarball"))
+		}))
+		defer server.Close()
+		paths := newPaths(t, "mlx5_ib0")
+		var commands []string
+		app := &App{client: server.Client(), cmdRunner: func(cmd *exec.Cmd) error {
+			commands = append(commands, strings.Join(cmd.Args, " "))
+			if cmd.Args[0] == "ofed_info" {
+				return assert.AnError
+			}
+			return nil
+		}}
+		require.NoError(t, app.setupInfiniBand(context.Background(), newConfig(server.URL+"/MLNX_OFED_LINUX-23.10-ubuntu22.04-x86_64.tgz"), paths))
+		require.Len(t, commands, 7)
+		assert.Regexp(t, `^tar -xzf .*/MLNX_OFED_LINUX-23.10-ubuntu22.04-x86_64.tgz -C .*/mofed --strip-components=1$`, commands[1])
+		assert.Regexp(t, `/mofed/mlnxofedinstall --without-fw-update --add-kernel-support --skip-repo --force$`, commands[2])
+		assert.Equal(t, paths.OpenIBD+" restart", commands[3])
+		// the tarball and the extracted installer are removed once installed.
+		entries, err := os.ReadDir(paths.DownloadDir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("MOFED missing without a package URL", func(t *testing.T) {
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error { return assert.AnError }}
+		err := app.setupInfiniBand(context.Background(), newConfig(""), newPaths(t, "mlx5_ib0"))
+		assert.EqualError(t, err, "the node image doesn't ship the MOFED drivers and no mofed package URL is set")
+	})
+
+	t.Run("no InfiniBand device", func(t *testing.T) {
+		paths := newPaths(t)
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error { return nil }}
+		err := app.setupInfiniBand(context.Background(), newConfig(""), paths)
+		assert.EqualError(t, err, "no InfiniBand device found on "+paths.SysClassDir+", the VM size Standard_HB120rs_v3 should expose one")
+	})
+}
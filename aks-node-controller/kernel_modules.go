@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// loadKernelModules writes the kernel modules to modules-load.d, so that they are loaded on boot, and their
+// parameters to modprobe.d, then loads them for the current boot.
+func (a *App) loadKernelModules(ctx context.Context, config *aksnodeconfigv1.Configuration, loadFile, optionsFile string) error {
+	files := map[string]string{
+		loadFile:    parser.KernelModulesLoadContent(config),
+		optionsFile: parser.KernelModulesOptionsContent(config),
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("create %s: %w", filepath.Dir(path), err)
+		}
+		if err := writeFileAtomic(path, []byte(content), 0644); err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+	}
+	for _, module := range config.GetCustomLinuxOsConfig().GetKernelModules() {
+		cmd := exec.CommandContext(ctx, "modprobe", module.GetName())
+		if err := a.cmdRunner(cmd); err != nil {
+			return fmt.Errorf("modprobe %s: %w", module.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// blacklistLegacyKernelModules writes the modprobe.d file which prevents legacy filesystem and network protocol
+// modules from being loaded. Modules which are already loaded stay loaded until the next reboot.
+func blacklistLegacyKernelModules(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create %s: %w", filepath.Dir(path), err)
+	}
+	if err := writeFileAtomic(path, []byte(parser.LegacyKernelModulesBlacklistContent()), 0644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_LoadKernelModules(t *testing.T) {
+	dir := t.TempDir()
+	loadFile := filepath.Join(dir, "modules-load.d", "aks-node-controller.conf")
+	optionsFile := filepath.Join(dir, "modprobe.d", "aks-node-controller.conf")
+	config := &aksnodeconfigv1.Configuration{CustomLinuxOsConfig: &aksnodeconfigv1.CustomLinuxOsConfig{
+		KernelModules: []*aksnodeconfigv1.KernelModule{
+			{Name: "nf_conntrack", Parameters: []string{"hashsize=262144"}},
+			{Name: "br_netfilter"},
+		},
+	}}
+	var loaded [][]string
+	app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+		loaded = append(loaded, cmd.Args)
+		return nil
+	}}
+	require.NoError(t, app.loadKernelModules(context.Background(), config, loadFile, optionsFile))
+	assert.Equal(t, [][]string{{"modprobe", "nf_conntrack"}, {"modprobe", "br_netfilter"}}, loaded)
+	content, err := os.ReadFile(loadFile)
+	require.NoError(t, err)
+	assert.Equal(t, "nf_conntrack\nbr_netfilter\n", string(content))
+	content, err = os.ReadFile(optionsFile)
+	require.NoError(t, err)
+	assert.Equal(t, "options nf_conntrack hashsize=262144\n", string(content))
+}
+
+func TestBlacklistLegacyKernelModules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "modprobe.d", "aks-legacy-modules-blacklist.conf")
+	require.NoError(t, blacklistLegacyKernelModules(path))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "install dccp /bin/false\nblacklist dccp\n")
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// writeKubeletConfigDropIns writes the kubelet config drop-in files to dir, which kubelet reads through --config-dir.
+// Drop-ins written by a previous provisioning and no longer in the configuration are removed.
+func writeKubeletConfigDropIns(config *aksnodeconfigv1.Configuration, dir string) error {
+	dropIns := parser.KubeletConfigDropIns(config)
+	existing, err := filepath.Glob(filepath.Join(dir, "*.conf"))
+	if err != nil {
+		return fmt.Errorf("list kubelet config drop-ins: %w", err)
+	}
+	for _, path := range existing {
+		if _, ok := dropIns[filepath.Base(path)]; !ok {
+			if err := os.Remove(path); err != nil {
+				return fmt.Errorf("remove kubelet config drop-in %s: %w", path, err)
+			}
+		}
+	}
+	if len(dropIns) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create %s: %w", dir, err)
+	}
+	for name, content := range dropIns {
+		if err := writeFileAtomic(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			return fmt.Errorf("write kubelet config drop-in %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteKubeletConfigDropIns(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "kubelet.conf.d")
+	config := &aksnodeconfigv1.Configuration{KubeletConfig: &aksnodeconfigv1.KubeletConfig{
+		KubeletConfigDropIns: map[string]string{"10-max-pods.conf": "maxPods: 50", "20-eviction.conf": "evictionHard: {}"},
+	}}
+	require.NoError(t, writeKubeletConfigDropIns(config, dir))
+	content, err := os.ReadFile(filepath.Join(dir, "10-max-pods.conf"))
+	require.NoError(t, err)
+	assert.Equal(t, "maxPods: 50", string(content))
+
+	config.KubeletConfig.KubeletConfigDropIns = map[string]string{"10-max-pods.conf": "maxPods: 60"}
+	require.NoError(t, writeKubeletConfigDropIns(config, dir))
+	content, err = os.ReadFile(filepath.Join(dir, "10-max-pods.conf"))
+	require.NoError(t, err)
+	assert.Equal(t, "maxPods: 60", string(content))
+	assert.NoFileExists(t, filepath.Join(dir, "20-eviction.conf"))
+
+	require.NoError(t, writeKubeletConfigDropIns(&aksnodeconfigv1.Configuration{}, filepath.Join(t.TempDir(), "missing")))
+}
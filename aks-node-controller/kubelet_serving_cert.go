@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"net"
+	"slices"
+	"time"
+)
+
+const (
+	// kubeletServingCertFile is where kubelet links its serving certificate once the CSR is approved and issued.
+	kubeletServingCertFile     = kubeletPKIDir + "/kubelet-server-current.pem"
+	kubeletServingCertTimeout  = 2 * time.Minute
+	kubeletServingCertInterval = 5 * time.Second
+)
+
+type kubeletServingCert struct {
+	Path         string
+	RequiredSANs []string
+	Timeout      time.Duration
+	Interval     time.Duration
+}
+
+// waitForKubeletServingCert waits for kubelet to install the serving certificate issued by the API server, which
+// only happens once its CSR is approved, and checks that the certificate is valid for the required SANs.
+func waitForKubeletServingCert(ctx context.Context, servingCert kubeletServingCert) error {
+	ctx, cancel := context.WithTimeout(ctx, servingCert.Timeout)
+	defer cancel()
+	for {
+		cert, err := readCertificate(servingCert.Path)
+		if err == nil {
+			return checkServingCertSANs(cert, servingCert.RequiredSANs)
+		}
+		slog.Info("kubelet serving certificate not issued yet", "error", err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("kubelet serving certificate not issued after %s, check that its CSR was approved: %w", servingCert.Timeout, err)
+		case <-time.After(servingCert.Interval):
+		}
+	}
+}
+
+func checkServingCertSANs(cert *x509.Certificate, requiredSANs []string) error {
+	for _, san := range requiredSANs {
+		if ip := net.ParseIP(san); ip != nil {
+			if !slices.ContainsFunc(cert.IPAddresses, ip.Equal) {
+				return fmt.Errorf("kubelet serving certificate isn't valid for IP address %s, its IP addresses are %v", san, cert.IPAddresses)
+			}
+		} else if !slices.Contains(cert.DNSNames, san) {
+			return fmt.Errorf("kubelet serving certificate isn't valid for %s, its DNS names are %v", san, cert.DNSNames)
+		}
+	}
+	return nil
+}
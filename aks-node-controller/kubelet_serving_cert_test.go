@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForKubeletServingCert(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "system:node:node1", Organization: []string{"system:nodes"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"node1"},
+		IPAddresses:  []net.IP{net.ParseIP("10.224.0.4")},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+
+	tests := []struct {
+		name         string
+		requiredSANs []string
+		issued       bool
+		wantErr      string
+	}{
+		{
+			name:         "issued",
+			requiredSANs: []string{"node1", "10.224.0.4"},
+			issued:       true,
+		},
+		{
+			name:    "not issued",
+			wantErr: "kubelet serving certificate not issued after 50ms, check that its CSR was approved",
+		},
+		{
+			name:         "missing DNS name",
+			requiredSANs: []string{"node1.contoso.com"},
+			issued:       true,
+			wantErr:      "kubelet serving certificate isn't valid for node1.contoso.com, its DNS names are [node1]",
+		},
+		{
+			name:         "missing IP address",
+			requiredSANs: []string{"10.224.0.5"},
+			issued:       true,
+			wantErr:      "kubelet serving certificate isn't valid for IP address 10.224.0.5, its IP addresses are [10.224.0.4]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "kubelet-server-current.pem")
+			if tt.issued {
+				require.NoError(t, os.WriteFile(path, certPEM, 0600))
+			}
+			err := waitForKubeletServingCert(context.Background(), kubeletServingCert{
+				Path:         path,
+				RequiredSANs: tt.requiredSANs,
+				Timeout:      50 * time.Millisecond,
+				Interval:     10 * time.Millisecond,
+			})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+const (
+	// nvmeDirectDiskModel is the model of the local NVMe disks of Azure VMs, remote NVMe data disks have another model.
+	nvmeDirectDiskModel = "Microsoft NVMe Direct Disk"
+	localDiskRAIDDevice = "/dev/md/aks-local"
+)
+
+var localDiskMountPoints = map[aksnodeconfigv1.LocalDiskMountTarget]string{
+	aksnodeconfigv1.LocalDiskMountTarget_LOCAL_DISK_MOUNT_TARGET_CONTAINERD: "/var/lib/containerd",
+	aksnodeconfigv1.LocalDiskMountTarget_LOCAL_DISK_MOUNT_TARGET_KUBELET:    "/var/lib/kubelet",
+}
+
+// localDiskPaths are the host paths used to discover and mount the local disks, overridden by tests.
+type localDiskPaths struct {
+	// SysBlockDir is /sys/block.
+	SysBlockDir string
+	// ResourceDiskLink is the udev link of the temporary resource disk.
+	ResourceDiskLink string
+	// MountsFile is /proc/mounts.
+	MountsFile string
+	// Fstab is /etc/fstab.
+	Fstab string
+	// Root is prepended to the mount points.
+	Root string
+}
+
+var defaultLocalDiskPaths = localDiskPaths{
+	SysBlockDir:      "/sys/block",
+	ResourceDiskLink: "/dev/disk/azure/resource",
+	MountsFile:       "/proc/mounts",
+	Fstab:            "/etc/fstab",
+	Root:             "/",
+}
+
+func validateLocalDiskConfig(config *aksnodeconfigv1.LocalDiskConfig) error {
+	if config.GetLayout() == aksnodeconfigv1.LocalDiskLayout_LOCAL_DISK_LAYOUT_UNSPECIFIED {
+		return nil
+	}
+	if config.GetMountTarget() == aksnodeconfigv1.LocalDiskMountTarget_LOCAL_DISK_MOUNT_TARGET_UNSPECIFIED {
+		return errors.New("mount target is required")
+	}
+	if fs := config.GetFilesystem(); fs != "" && fs != "ext4" && fs != "xfs" {
+		return fmt.Errorf("unsupported filesystem %q, it must be ext4 or xfs", fs)
+	}
+	for _, option := range config.GetMountOptions() {
+		if option == "" || strings.ContainsAny(option, ", \t\n") {
+			return fmt.Errorf("invalid mount option %q", option)
+		}
+	}
+	return nil
+}
+
+// discoverLocalDisks returns the local NVMe disks, and the temporary resource disk when includeTempDisk is set.
+func discoverLocalDisks(paths localDiskPaths, includeTempDisk bool) ([]string, error) {
+	entries, err := os.ReadDir(paths.SysBlockDir)
+	if err != nil {
+		return nil, fmt.Errorf("list block devices: %w", err)
+	}
+	var disks []string
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), "nvme") {
+			continue
+		}
+		model, err := os.ReadFile(filepath.Join(paths.SysBlockDir, entry.Name(), "device", "model"))
+		if err != nil {
+			continue
+		}
+		if strings.TrimSpace(string(model)) == nvmeDirectDiskModel {
+			disks = append(disks, "/dev/"+entry.Name())
+		}
+	}
+	sort.Strings(disks)
+	if includeTempDisk {
+		tempDisk, err := filepath.EvalSymlinks(paths.ResourceDiskLink)
+		if err != nil {
+			return nil, fmt.Errorf("resolve temporary disk %s: %w", paths.ResourceDiskLink, err)
+		}
+		disks = append(disks, tempDisk)
+	}
+	return disks, nil
+}
+
+// localDiskCommands returns the commands creating the local disk device and its filesystem, and the device to mount.
+// No filesystem is created for raw block devices.
+func localDiskCommands(config *aksnodeconfigv1.LocalDiskConfig, disks []string) ([][]string, string, error) {
+	if len(disks) == 0 {
+		return nil, "", errors.New("no local disk found")
+	}
+	var commands [][]string
+	device := disks[0]
+	if config.GetLayout() == aksnodeconfigv1.LocalDiskLayout_LOCAL_DISK_LAYOUT_RAID0 && len(disks) > 1 {
+		device = localDiskRAIDDevice
+		args := []string{"mdadm", "--create", device, "--level=0", fmt.Sprintf("--raid-devices=%d", len(disks)), "--run", "--force"}
+		commands = append(commands, append(args, disks...))
+	}
+	if config.GetMountTarget() == aksnodeconfigv1.LocalDiskMountTarget_LOCAL_DISK_MOUNT_TARGET_RAW_BLOCK {
+		return commands, device, nil
+	}
+	switch localDiskFilesystem(config) {
+	case "xfs":
+		commands = append(commands, []string{"mkfs.xfs", "-f", device})
+	default:
+		commands = append(commands, []string{"mkfs.ext4", "-F", "-E", "lazy_itable_init=1,lazy_journal_init=1", device})
+	}
+	return commands, device, nil
+}
+
+func localDiskFilesystem(config *aksnodeconfigv1.LocalDiskConfig) string {
+	if config.GetFilesystem() == "" {
+		return "ext4"
+	}
+	return config.GetFilesystem()
+}
+
+// provisionLocalDisks partitions, formats and mounts the local disks according to config. It is a no-op when the
+// mount point is already mounted, so that re-running provision doesn't wipe the disks.
+func (a *App) provisionLocalDisks(ctx context.Context, config *aksnodeconfigv1.LocalDiskConfig, paths localDiskPaths) error {
+	mountPoint := localDiskMountPoints[config.GetMountTarget()]
+	if mountPoint != "" {
+		mountPoint = filepath.Join(paths.Root, mountPoint)
+		mounted, err := isMounted(paths.MountsFile, mountPoint)
+		if err != nil {
+			return err
+		}
+		if mounted {
+			slog.Info("local disks are already mounted", "mountPoint", mountPoint)
+			return nil
+		}
+	}
+
+	disks, err := discoverLocalDisks(paths, config.GetIncludeTempDisk())
+	if err != nil {
+		return err
+	}
+	if config.GetIncludeTempDisk() {
+		// cloud-init mounts the temporary disk at /mnt, unmount it before reusing it.
+		tempMount := filepath.Join(paths.Root, "mnt")
+		mounted, err := isMounted(paths.MountsFile, tempMount)
+		if err != nil {
+			return err
+		}
+		if mounted {
+			if err := a.cmdRunner(exec.CommandContext(ctx, "umount", tempMount)); err != nil {
+				return fmt.Errorf("unmount temporary disk from %s: %w", tempMount, err)
+			}
+		}
+	}
+	commands, device, err := localDiskCommands(config, disks)
+	if err != nil {
+		return err
+	}
+	for _, args := range commands {
+		if err := a.cmdRunner(exec.CommandContext(ctx, args[0], args[1:]...)); err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+	}
+	if mountPoint == "" {
+		slog.Info("local disks left as raw block device", "device", device, "disks", disks)
+		return nil
+	}
+
+	if err := os.MkdirAll(mountPoint, 0755); err != nil {
+		return fmt.Errorf("create mount point %s: %w", mountPoint, err)
+	}
+	options := append([]string{"defaults", "nofail"}, config.GetMountOptions()...)
+	mountArgs := []string{"-t", localDiskFilesystem(config), "-o", strings.Join(options, ","), device, mountPoint}
+	if err := a.cmdRunner(exec.CommandContext(ctx, "mount", mountArgs...)); err != nil {
+		return fmt.Errorf("mount %s at %s: %w", device, mountPoint, err)
+	}
+	fstabEntry := fmt.Sprintf("%s %s %s %s 0 2\n", device, mountPoint, localDiskFilesystem(config), strings.Join(options, ","))
+	if err := appendFstab(paths.Fstab, mountPoint, fstabEntry); err != nil {
+		return err
+	}
+	slog.Info("local disks mounted", "device", device, "disks", disks, "mountPoint", mountPoint)
+	return nil
+}
+
+// isMounted returns whether mountPoint is listed in mountsFile.
+func isMounted(mountsFile, mountPoint string) (bool, error) {
+	data, err := os.ReadFile(mountsFile)
+	if err != nil {
+		return false, fmt.Errorf("read %s: %w", mountsFile, err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[1] == mountPoint {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// appendFstab adds entry to fstab unless mountPoint already has an entry.
+func appendFstab(fstab, mountPoint, entry string) error {
+	data, err := os.ReadFile(fstab)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read %s: %w", fstab, err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if fields := strings.Fields(line); len(fields) >= 2 && fields[1] == mountPoint {
+			return nil
+		}
+	}
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		data = append(data, '\n')
+	}
+	return writeFileAtomic(fstab, append(data, entry...), 0644)
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalDiskCommands(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     *aksnodeconfigv1.LocalDiskConfig
+		disks      []string
+		want       [][]string
+		wantDevice string
+		wantErr    string
+	}{
+		{
+			name: "raid0 of two disks with xfs",
+			config: &aksnodeconfigv1.LocalDiskConfig{
+				Layout:      aksnodeconfigv1.LocalDiskLayout_LOCAL_DISK_LAYOUT_RAID0,
+				MountTarget: aksnodeconfigv1.LocalDiskMountTarget_LOCAL_DISK_MOUNT_TARGET_CONTAINERD,
+				Filesystem:  "xfs",
+			},
+			disks: []string{"/dev/nvme0n1", "/dev/nvme1n1"},
+			want: [][]string{
+				{"mdadm", "--create", "/dev/md/aks-local", "--level=0", "--raid-devices=2", "--run", "--force", "/dev/nvme0n1", "/dev/nvme1n1"},
+				{"mkfs.xfs", "-f", "/dev/md/aks-local"},
+			},
+			wantDevice: "/dev/md/aks-local",
+		},
+		{
+			name: "raid0 of a single disk",
+			config: &aksnodeconfigv1.LocalDiskConfig{
+				Layout:      aksnodeconfigv1.LocalDiskLayout_LOCAL_DISK_LAYOUT_RAID0,
+				MountTarget: aksnodeconfigv1.LocalDiskMountTarget_LOCAL_DISK_MOUNT_TARGET_KUBELET,
+			},
+			disks:      []string{"/dev/nvme0n1"},
+			want:       [][]string{{"mkfs.ext4", "-F", "-E", "lazy_itable_init=1,lazy_journal_init=1", "/dev/nvme0n1"}},
+			wantDevice: "/dev/nvme0n1",
+		},
+		{
+			name: "raw block",
+			config: &aksnodeconfigv1.LocalDiskConfig{
+				Layout:      aksnodeconfigv1.LocalDiskLayout_LOCAL_DISK_LAYOUT_SINGLE,
+				MountTarget: aksnodeconfigv1.LocalDiskMountTarget_LOCAL_DISK_MOUNT_TARGET_RAW_BLOCK,
+			},
+			disks:      []string{"/dev/nvme0n1", "/dev/nvme1n1"},
+			wantDevice: "/dev/nvme0n1",
+		},
+		{
+			name:    "no disk",
+			config:  &aksnodeconfigv1.LocalDiskConfig{Layout: aksnodeconfigv1.LocalDiskLayout_LOCAL_DISK_LAYOUT_SINGLE},
+			wantErr: "no local disk found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, device, err := localDiskCommands(tt.config, tt.disks)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, commands)
+			assert.Equal(t, tt.wantDevice, device)
+		})
+	}
+}
+
+func TestApp_ProvisionLocalDisks(t *testing.T) {
+	dir := t.TempDir()
+	paths := localDiskPaths{
+		SysBlockDir:      filepath.Join(dir, "sys", "block"),
+		ResourceDiskLink: filepath.Join(dir, "resource"),
+		MountsFile:       filepath.Join(dir, "mounts"),
+		Fstab:            filepath.Join(dir, "fstab"),
+		Root:             filepath.Join(dir, "root"),
+	}
+	for disk, model := range map[string]string{"nvme0n1": nvmeDirectDiskModel, "nvme1n1": "Microsoft NVMe Disk"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(paths.SysBlockDir, disk, "device"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(paths.SysBlockDir, disk, "device", "model"), []byte(model+"\n"), 0644))
+	}
+	tempDisk := filepath.Join(dir, "sdb")
+	require.NoError(t, os.WriteFile(tempDisk, nil, 0644))
+	require.NoError(t, os.Symlink(tempDisk, paths.ResourceDiskLink))
+	require.NoError(t, os.WriteFile(paths.MountsFile, []byte("/dev/sdb1 "+filepath.Join(paths.Root, "mnt")+" ext4 rw 0 0\n"), 0644))
+
+	config := &aksnodeconfigv1.LocalDiskConfig{
+		Layout:          aksnodeconfigv1.LocalDiskLayout_LOCAL_DISK_LAYOUT_RAID0,
+		MountTarget:     aksnodeconfigv1.LocalDiskMountTarget_LOCAL_DISK_MOUNT_TARGET_CONTAINERD,
+		IncludeTempDisk: true,
+		MountOptions:    []string{"noatime"},
+	}
+	var commands []string
+	app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+		commands = append(commands, filepath.Base(cmd.Args[0]))
+		return nil
+	}}
+	require.NoError(t, app.provisionLocalDisks(context.Background(), config, paths))
+	assert.Equal(t, []string{"umount", "mdadm", "mkfs.ext4", "mount"}, commands)
+	mountPoint := filepath.Join(paths.Root, "var/lib/containerd")
+	assert.DirExists(t, mountPoint)
+	fstab, err := os.ReadFile(paths.Fstab)
+	require.NoError(t, err)
+	assert.Equal(t, "/dev/md/aks-local "+mountPoint+" ext4 defaults,nofail,noatime 0 2\n", string(fstab))
+
+	// the disks are mounted, provisioning again must not format them.
+	require.NoError(t, os.WriteFile(paths.MountsFile, []byte("/dev/md127 "+mountPoint+" ext4 rw 0 0\n"), 0644))
+	commands = nil
+	require.NoError(t, app.provisionLocalDisks(context.Background(), config, paths))
+	assert.Empty(t, commands)
+}
+
+func TestValidateLocalDiskConfig(t *testing.T) {
+	assert.NoError(t, validateLocalDiskConfig(nil))
+	assert.EqualError(t, validateLocalDiskConfig(&aksnodeconfigv1.LocalDiskConfig{
+		Layout: aksnodeconfigv1.LocalDiskLayout_LOCAL_DISK_LAYOUT_SINGLE,
+	}), "mount target is required")
+	assert.EqualError(t, validateLocalDiskConfig(&aksnodeconfigv1.LocalDiskConfig{
+		Layout:      aksnodeconfigv1.LocalDiskLayout_LOCAL_DISK_LAYOUT_SINGLE,
+		MountTarget: aksnodeconfigv1.LocalDiskMountTarget_LOCAL_DISK_MOUNT_TARGET_KUBELET,
+		Filesystem:  "btrfs",
+	}), `unsupported filesystem "btrfs", it must be ext4 or xfs`)
+	assert.EqualError(t, validateLocalDiskConfig(&aksnodeconfigv1.LocalDiskConfig{
+		Layout:       aksnodeconfigv1.LocalDiskLayout_LOCAL_DISK_LAYOUT_SINGLE,
+		MountTarget:  aksnodeconfigv1.LocalDiskMountTarget_LOCAL_DISK_MOUNT_TARGET_KUBELET,
+		MountOptions: []string{"noatime,exec"},
+	}), `invalid mount option "noatime,exec"`)
+}
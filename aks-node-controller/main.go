@@ -5,6 +5,10 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/telemetry"
 )
 
 func main() {
@@ -15,11 +19,14 @@ func main() {
 		os.Exit(1)
 	}
 
-	logger := slog.New(slog.NewJSONHandler(logFile, nil))
+	logger := slog.New(slog.NewJSONHandler(logFile, &slog.HandlerOptions{Level: logLevel}))
 	slog.SetDefault(logger)
 
-	app := App{cmdRunner: cmdRunner}
-	exitCode := app.Run(context.Background(), os.Args)
+	// systemd stops the controller with SIGTERM, cancel the context so the CSE process group is terminated as well.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	app := App{cmdRunner: cmdRunner, telemetry: &telemetry.GuestAgent{Dir: telemetry.GuestAgentEventsDir}}
+	exitCode := app.Run(ctx, os.Args)
+	stop()
 	_ = logFile.Close()
 	os.Exit(exitCode)
 }
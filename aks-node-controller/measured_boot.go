@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+)
+
+// measuredBootPaths are the host paths used to set up measured boot, overridden by tests.
+type measuredBootPaths struct {
+	// TPMDevice is the resource manager device of the vTPM.
+	TPMDevice string
+	// IMADir is the IMA directory of securityfs, it only exists when the kernel has IMA.
+	IMADir string
+	// PolicyFile is the IMA policy systemd loads on boot.
+	PolicyFile string
+}
+
+var defaultMeasuredBootPaths = measuredBootPaths{
+	TPMDevice:  "/dev/tpmrm0",
+	IMADir:     "/sys/kernel/security/ima",
+	PolicyFile: "/etc/ima/ima-policy",
+}
+
+// configureMeasuredBoot writes the IMA policy measuring what the node runs into the vTPM, which systemd loads on every
+// boot, and loads it right away so that CSE and the components it installs are measured. The kernel only accepts a
+// policy once unless it is built with CONFIG_IMA_WRITE_POLICY: when the node image already loaded one, the policy
+// only applies from the next boot.
+func configureMeasuredBoot(paths measuredBootPaths) error {
+	if _, err := os.Stat(paths.TPMDevice); err != nil {
+		return fmt.Errorf("the VM has no vTPM: %w", err)
+	}
+	if _, err := os.Stat(paths.IMADir); err != nil {
+		return fmt.Errorf("the kernel doesn't have IMA: %w", err)
+	}
+	policy := []byte(parser.IMAPolicy())
+	if err := writeFileAtomic(paths.PolicyFile, policy, 0644); err != nil {
+		return err
+	}
+
+	securityfsPolicy := filepath.Join(paths.IMADir, "policy")
+	f, err := os.OpenFile(securityfsPolicy, os.O_WRONLY, 0)
+	if err == nil {
+		_, err = f.Write(policy)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		slog.Warn("the IMA policy can't be loaded, it applies from the next boot", "error", err)
+		return nil
+	}
+	slog.Info("IMA policy loaded", "policyFile", paths.PolicyFile)
+	return nil
+}
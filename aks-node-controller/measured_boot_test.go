@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureMeasuredBoot(t *testing.T) {
+	newPaths := func(t *testing.T) measuredBootPaths {
+		dir := t.TempDir()
+		paths := measuredBootPaths{
+			TPMDevice:  filepath.Join(dir, "tpmrm0"),
+			IMADir:     filepath.Join(dir, "ima"),
+			PolicyFile: filepath.Join(dir, "etc", "ima", "ima-policy"),
+		}
+		require.NoError(t, os.WriteFile(paths.TPMDevice, nil, 0600))
+		require.NoError(t, os.MkdirAll(paths.IMADir, 0755))
+		return paths
+	}
+
+	t.Run("policy loaded", func(t *testing.T) {
+		paths := newPaths(t)
+		require.NoError(t, os.WriteFile(filepath.Join(paths.IMADir, "policy"), nil, 0600))
+		require.NoError(t, configureMeasuredBoot(paths))
+		for _, file := range []string{paths.PolicyFile, filepath.Join(paths.IMADir, "policy")} {
+			policy, err := os.ReadFile(file)
+			require.NoError(t, err)
+			assert.Equal(t, parser.IMAPolicy(), string(policy))
+		}
+	})
+
+	t.Run("policy already loaded by the node image", func(t *testing.T) {
+		paths := newPaths(t)
+		require.NoError(t, configureMeasuredBoot(paths))
+		assert.FileExists(t, paths.PolicyFile)
+	})
+
+	t.Run("no vTPM", func(t *testing.T) {
+		paths := newPaths(t)
+		require.NoError(t, os.Remove(paths.TPMDevice))
+		assert.ErrorContains(t, configureMeasuredBoot(paths), "the VM has no vTPM")
+		assert.NoFileExists(t, paths.PolicyFile)
+	})
+
+	t.Run("kernel without IMA", func(t *testing.T) {
+		paths := newPaths(t)
+		require.NoError(t, os.Remove(paths.IMADir))
+		assert.ErrorContains(t, configureMeasuredBoot(paths), "the kernel doesn't have IMA")
+	})
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+var (
+	// migProfileRegex matches a GPU instance profile ID or name, such as 9 or 3g.40gb.
+	migProfileRegex = regexp.MustCompile(`^(\d+|[1-7]g\.\d+gb(\+me)?)$`)
+	// migInstanceRegex matches a GPU instance row of nvidia-smi mig -lgi: GPU, name, profile ID, instance ID and
+	// placement.
+	migInstanceRegex = regexp.MustCompile(`^\|\s+(\d+)\s+MIG\s+(\S+)\s+(\d+)\s+\d+\s+\d+:\d+\s+\|$`)
+)
+
+// migInstance is a GPU instance of a MIG geometry.
+type migInstance struct {
+	Profile   string
+	ProfileID string
+}
+
+// supportsMIG returns whether the GPUs of the VM size, A100 and later, can be partitioned.
+func supportsMIG(vmSize string) bool {
+	vmSize = strings.ToLower(vmSize)
+	return strings.Contains(vmSize, "a100") || strings.Contains(vmSize, "h100") || strings.Contains(vmSize, "h200") ||
+		vmSize == "standard_nd96asr_v4"
+}
+
+func validateMIGProfiles(config *aksnodeconfigv1.Configuration) error {
+	gpuConfig := config.GetGpuConfig()
+	if len(gpuConfig.GetMigProfiles()) == 0 {
+		return nil
+	}
+	if gpuConfig.GetGpuInstanceProfile() != "" {
+		return errors.New("mig profiles can't be combined with gpu_instance_profile")
+	}
+	if !gpuConfig.GetEnableNvidia() {
+		return errors.New("mig profiles require enable_nvidia")
+	}
+	if !supportsMIG(config.GetVmSize()) {
+		return fmt.Errorf("VM size %q doesn't support MIG", config.GetVmSize())
+	}
+	for _, profile := range gpuConfig.GetMigProfiles() {
+		if !migProfileRegex.MatchString(profile) {
+			return fmt.Errorf("invalid MIG profile %q, it must be a GPU instance profile name such as 3g.40gb or a profile ID", profile)
+		}
+	}
+	return nil
+}
+
+// applyMIGProfiles enables MIG on every GPU and partitions them into the requested GPU instances, each with a
+// compute instance, then checks the resulting geometry. GPUs which already have the requested geometry are left
+// untouched so that running it again doesn't destroy instances in use.
+func (a *App) applyMIGProfiles(ctx context.Context, config *aksnodeconfigv1.Configuration) error {
+	profiles := config.GetGpuConfig().GetMigProfiles()
+	vmSize := config.GetVmSize()
+	migErr := func(err error) error {
+		return &GPUDriverError{Code: errGPUDriversStartFail, VMSize: vmSize, Err: err}
+	}
+
+	if err := a.runGPUCommand(ctx, nil, "nvidia-smi", "-mig", "1"); err != nil {
+		return migErr(fmt.Errorf("enable MIG: %w", err))
+	}
+	if geometry, err := a.migGeometry(ctx); err == nil && migGeometryMatches(geometry, profiles) {
+		slog.Info("MIG geometry already applied", "profiles", profiles)
+		return nil
+	}
+
+	// the instances of a previous geometry must be destroyed first, there are none on a fresh node.
+	if err := a.runGPUCommand(ctx, nil, "nvidia-smi", "mig", "-dci"); err != nil {
+		slog.Debug("no MIG compute instances destroyed", "error", err)
+	}
+	if err := a.runGPUCommand(ctx, nil, "nvidia-smi", "mig", "-dgi"); err != nil {
+		slog.Debug("no MIG GPU instances destroyed", "error", err)
+	}
+	if err := a.runGPUCommand(ctx, nil, "nvidia-smi", "mig", "-cgi", strings.Join(profiles, ","), "-C"); err != nil {
+		return migErr(fmt.Errorf("create MIG instances: %w", err))
+	}
+
+	geometry, err := a.migGeometry(ctx)
+	if err != nil {
+		return migErr(err)
+	}
+	if !migGeometryMatches(geometry, profiles) {
+		return migErr(fmt.Errorf("MIG geometry %v doesn't match the requested profiles %v", geometry, profiles))
+	}
+	slog.Info("MIG geometry applied", "profiles", profiles, "gpus", len(geometry))
+	return nil
+}
+
+// migGeometry returns the GPU instances of every GPU, keyed by GPU index.
+func (a *App) migGeometry(ctx context.Context) (map[string][]migInstance, error) {
+	var output bytes.Buffer
+	if err := a.runGPUCommand(ctx, &output, "nvidia-smi", "mig", "-lgi"); err != nil {
+		return nil, fmt.Errorf("list MIG GPU instances: %w", err)
+	}
+	geometry := map[string][]migInstance{}
+	for _, line := range strings.Split(output.String(), "\n") {
+		if match := migInstanceRegex.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			geometry[match[1]] = append(geometry[match[1]], migInstance{Profile: match[2], ProfileID: match[3]})
+		}
+	}
+	return geometry, nil
+}
+
+// migGeometryMatches returns whether every GPU has exactly the GPU instances of the profiles, in any order.
+func migGeometryMatches(geometry map[string][]migInstance, profiles []string) bool {
+	if len(geometry) == 0 {
+		return false
+	}
+	for _, instances := range geometry {
+		if len(instances) != len(profiles) {
+			return false
+		}
+		used := make([]bool, len(instances))
+		for _, profile := range profiles {
+			found := false
+			for i, instance := range instances {
+				if !used[i] && (instance.Profile == profile || instance.ProfileID == profile) {
+					used[i], found = true, true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// migListOutput returns the nvidia-smi mig -lgi output of GPU 0 partitioned into the instances.
+func migListOutput(instances ...migInstance) string {
+	var b strings.Builder
+	b.WriteString("+-------------------------------------------------------+\n")
+	b.WriteString("| GPU instances:                                        |\n")
+	b.WriteString("| GPU   Name             Profile  Instance   Placement  |\n")
+	b.WriteString("|                          ID       ID       Start:Size |\n")
+	b.WriteString("|=======================================================|\n")
+	for i, instance := range instances {
+		fmt.Fprintf(&b, "|   0  MIG %-12s %4s %8d %9d:4     |\n", instance.Profile, instance.ProfileID, i+1, i*4)
+	}
+	b.WriteString("+-------------------------------------------------------+\n")
+	return b.String()
+}
+
+func TestApp_applyMIGProfiles(t *testing.T) {
+	threeG := migInstance{Profile: "3g.40gb", ProfileID: "9"}
+	config := &aksnodeconfigv1.Configuration{
+		VmSize:    "Standard_NC24ads_A100_v4",
+		GpuConfig: &aksnodeconfigv1.GpuConfig{EnableNvidia: proto.Bool(true), MigProfiles: []string{"3g.40gb", "9"}},
+	}
+
+	tests := []struct {
+		name         string
+		current      []migInstance
+		created      []migInstance
+		wantCommands []string
+		wantErr      string
+	}{
+		{
+			name:         "partitioned",
+			created:      []migInstance{threeG, threeG},
+			wantCommands: []string{"nvidia-smi -mig 1", "nvidia-smi mig -lgi", "nvidia-smi mig -dci", "nvidia-smi mig -dgi", "nvidia-smi mig -cgi 3g.40gb,9 -C", "nvidia-smi mig -lgi"},
+		},
+		{
+			name:         "already partitioned",
+			current:      []migInstance{threeG, threeG},
+			wantCommands: []string{"nvidia-smi -mig 1", "nvidia-smi mig -lgi"},
+		},
+		{
+			name:         "repartitioned",
+			current:      []migInstance{{Profile: "7g.80gb", ProfileID: "0"}},
+			created:      []migInstance{threeG, threeG},
+			wantCommands: []string{"nvidia-smi -mig 1", "nvidia-smi mig -lgi", "nvidia-smi mig -dci", "nvidia-smi mig -dgi", "nvidia-smi mig -cgi 3g.40gb,9 -C", "nvidia-smi mig -lgi"},
+		},
+		{
+			name:         "geometry mismatch",
+			created:      []migInstance{threeG},
+			wantCommands: []string{"nvidia-smi -mig 1", "nvidia-smi mig -lgi", "nvidia-smi mig -dci", "nvidia-smi mig -dgi", "nvidia-smi mig -cgi 3g.40gb,9 -C", "nvidia-smi mig -lgi"},
+			wantErr:      "MIG geometry map[0:[{3g.40gb 9}]] doesn't match the requested profiles [3g.40gb 9]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := tt.current
+			var commands []string
+			app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+				command := strings.Join(cmd.Args, " ")
+				commands = append(commands, command)
+				switch command {
+				case "nvidia-smi mig -lgi":
+					_, _ = cmd.Stdout.Write([]byte(migListOutput(current...)))
+				case "nvidia-smi mig -dci", "nvidia-smi mig -dgi":
+					if len(current) == 0 {
+						return errors.New("no instances found")
+					}
+					current = nil
+				case "nvidia-smi mig -cgi 3g.40gb,9 -C":
+					current = tt.created
+				}
+				return nil
+			}}
+
+			err := app.applyMIGProfiles(context.Background(), config)
+			assert.Equal(t, tt.wantCommands, commands)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+			assert.Equal(t, errGPUDriversStartFail, errToExitCode(err))
+		})
+	}
+}
+
+func TestValidateMIGProfiles(t *testing.T) {
+	tests := []struct {
+		name      string
+		vmSize    string
+		gpuConfig *aksnodeconfigv1.GpuConfig
+		wantErr   string
+	}{
+		{
+			name:      "no mig profiles",
+			vmSize:    "Standard_NC6s_v3",
+			gpuConfig: &aksnodeconfigv1.GpuConfig{GpuInstanceProfile: "MIG1g"},
+		},
+		{
+			name:      "h100",
+			vmSize:    "Standard_ND96isr_H100_v5",
+			gpuConfig: &aksnodeconfigv1.GpuConfig{EnableNvidia: proto.Bool(true), MigProfiles: []string{"1g.10gb", "1g.10gb+me", "19"}},
+		},
+		{
+			name:      "gpu instance profile",
+			vmSize:    "Standard_ND96isr_H100_v5",
+			gpuConfig: &aksnodeconfigv1.GpuConfig{EnableNvidia: proto.Bool(true), GpuInstanceProfile: "MIG1g", MigProfiles: []string{"19"}},
+			wantErr:   "mig profiles can't be combined with gpu_instance_profile",
+		},
+		{
+			name:      "nvidia disabled",
+			vmSize:    "Standard_ND96isr_H100_v5",
+			gpuConfig: &aksnodeconfigv1.GpuConfig{MigProfiles: []string{"19"}},
+			wantErr:   "mig profiles require enable_nvidia",
+		},
+		{
+			name:      "gpu without mig",
+			vmSize:    "Standard_NC6s_v3",
+			gpuConfig: &aksnodeconfigv1.GpuConfig{EnableNvidia: proto.Bool(true), MigProfiles: []string{"19"}},
+			wantErr:   `VM size "Standard_NC6s_v3" doesn't support MIG`,
+		},
+		{
+			name:      "invalid profile",
+			vmSize:    "Standard_ND96asr_v4",
+			gpuConfig: &aksnodeconfigv1.GpuConfig{EnableNvidia: proto.Bool(true), MigProfiles: []string{"MIG1g"}},
+			wantErr:   `invalid MIG profile "MIG1g", it must be a GPU instance profile name such as 3g.40gb or a profile ID`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMIGProfiles(&aksnodeconfigv1.Configuration{VmSize: tt.vmSize, GpuConfig: tt.gpuConfig})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
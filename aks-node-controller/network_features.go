@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// vfDrivers are the drivers of the virtual functions Azure exposes to VMs with accelerated networking.
+var vfDrivers = map[string]bool{
+	"mlx4_core": true,
+	"mlx5_core": true,
+	"mana":      true,
+}
+
+// NetworkFeatures records the requested and negotiated state of the NIC features of the node.
+type NetworkFeatures struct {
+	AcceleratedNetworking NICFeature `json:"AcceleratedNetworking"`
+	IPForwarding          NICFeature `json:"IPForwarding"`
+	InfiniBand            NICFeature `json:"InfiniBand"`
+}
+
+// NICFeature is the state of a NIC feature.
+type NICFeature struct {
+	Requested bool `json:"Requested"`
+	// Active is the state observed on the node.
+	Active bool `json:"Active"`
+	// Detail describes how the state was observed.
+	Detail string `json:"Detail,omitempty"`
+}
+
+// collectNetworkFeatures inspects the network interfaces of the host, rooted at root. Accelerated networking is active
+// when a virtual function is bound to one of the Azure VF drivers. The NIC-level IP forwarding setting isn't visible
+// from the guest, the kernel forwarding setting CSE configures is recorded instead. InfiniBand is active when an
+// InfiniBand device is registered.
+func collectNetworkFeatures(root string, networkConfig *aksnodeconfigv1.NetworkConfig) NetworkFeatures {
+	features := NetworkFeatures{
+		AcceleratedNetworking: NICFeature{Requested: networkConfig.GetEnableAcceleratedNetworking()},
+		IPForwarding: NICFeature{
+			Requested: parser.GetEnableIPForwarding(networkConfig),
+			Active:    readTrimmed(root, "proc/sys/net/ipv4/ip_forward") == "1",
+			Detail:    "net.ipv4.ip_forward",
+		},
+	}
+
+	netDir := filepath.Join(root, "sys/class/net")
+	entries, _ := os.ReadDir(netDir)
+	var vfs []string
+	for _, entry := range entries {
+		driver, err := os.Readlink(filepath.Join(netDir, entry.Name(), "device", "driver"))
+		if err != nil {
+			continue
+		}
+		if name := filepath.Base(driver); vfDrivers[name] {
+			vfs = append(vfs, fmt.Sprintf("%s (%s)", entry.Name(), name))
+		}
+	}
+	sort.Strings(vfs)
+	if len(vfs) > 0 {
+		features.AcceleratedNetworking.Active = true
+		features.AcceleratedNetworking.Detail = fmt.Sprintf("virtual functions: %v", vfs)
+	}
+
+	features.InfiniBand.Requested = networkConfig.GetEnableInfiniband()
+	ibEntries, _ := os.ReadDir(filepath.Join(root, "sys/class/infiniband"))
+	var ibDevices []string
+	for _, entry := range ibEntries {
+		ibDevices = append(ibDevices, entry.Name())
+	}
+	if len(ibDevices) > 0 {
+		features.InfiniBand.Active = true
+		features.InfiniBand.Detail = fmt.Sprintf("devices: %v", ibDevices)
+	}
+	return features
+}
+
+// recordNetworkFeatures writes the network features report and warns about features which weren't negotiated.
+func recordNetworkFeatures(path string, features NetworkFeatures) error {
+	if features.AcceleratedNetworking.Requested && !features.AcceleratedNetworking.Active {
+		slog.Warn("accelerated networking was requested but no virtual function is bound, traffic uses the synthetic NIC")
+	}
+	if features.IPForwarding.Requested && !features.IPForwarding.Active {
+		slog.Warn("IP forwarding was requested but is disabled in the kernel")
+	}
+	if features.InfiniBand.Requested && !features.InfiniBand.Active {
+		slog.Warn("InfiniBand was requested but no InfiniBand device is registered")
+	}
+	data, err := json.Marshal(features)
+	if err != nil {
+		return fmt.Errorf("marshal network features: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCollectNetworkFeatures(t *testing.T) {
+	root := t.TempDir()
+	for nic, driver := range map[string]string{"eth0": "hv_netvsc", "enP1234s1": "mlx5_core"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "sys/class/net", nic, "device"), 0755))
+		require.NoError(t, os.Symlink(filepath.Join("../../../bus/drivers", driver), filepath.Join(root, "sys/class/net", nic, "device", "driver")))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "proc/sys/net/ipv4"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "proc/sys/net/ipv4/ip_forward"), []byte("1\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "sys/class/infiniband/mlx5_ib0"), 0755))
+
+	features := collectNetworkFeatures(root, &aksnodeconfigv1.NetworkConfig{
+		NetworkPlugin:               aksnodeconfigv1.NetworkPlugin_NETWORK_PLUGIN_KUBENET,
+		EnableAcceleratedNetworking: proto.Bool(true),
+		EnableInfiniband:            true,
+	})
+	assert.Equal(t, NICFeature{Requested: true, Active: true, Detail: "virtual functions: [enP1234s1 (mlx5_core)]"}, features.AcceleratedNetworking)
+	assert.Equal(t, NICFeature{Requested: true, Active: true, Detail: "net.ipv4.ip_forward"}, features.IPForwarding)
+	assert.Equal(t, NICFeature{Requested: true, Active: true, Detail: "devices: [mlx5_ib0]"}, features.InfiniBand)
+
+	features = collectNetworkFeatures(t.TempDir(), &aksnodeconfigv1.NetworkConfig{EnableAcceleratedNetworking: proto.Bool(true), EnableInfiniband: true})
+	assert.False(t, features.AcceleratedNetworking.Active)
+	assert.Equal(t, NICFeature{Requested: true}, features.InfiniBand)
+	assert.False(t, features.IPForwarding.Requested)
+
+	path := filepath.Join(t.TempDir(), "aks", "network-features.json")
+	require.NoError(t, recordNetworkFeatures(path, features))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var recorded NetworkFeatures
+	require.NoError(t, json.Unmarshal(data, &recorded))
+	assert.Equal(t, features, recorded)
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+const (
+	nodeRegistrationTimeout      = 5 * time.Minute
+	nodeRegistrationPollInterval = 5 * time.Second
+)
+
+// nodeAnnotations are the annotations applied to the Node object once kubelet registered it.
+type nodeAnnotations struct {
+	APIServerURL string
+	NodeName     string
+	Annotations  map[string]string
+	// Root is prepended to the kubelet client certificate and the cluster CA, it is "/" on a node.
+	Root     string
+	Timeout  time.Duration
+	Interval time.Duration
+}
+
+// annotateNode waits for kubelet to register the node and merges the annotations into the Node object. kubelet
+// can't register annotations, and the node authorizer lets a node update its own Node object with the kubelet
+// client certificate, which only exists once kubelet bootstrapped.
+func annotateNode(ctx context.Context, target nodeAnnotations) error {
+	ctx, cancel := context.WithTimeout(ctx, target.Timeout)
+	defer cancel()
+	patch, err := json.Marshal(map[string]any{"metadata": map[string]any{"annotations": target.Annotations}})
+	if err != nil {
+		return fmt.Errorf("marshal node annotations: %w", err)
+	}
+	url := target.APIServerURL + "/api/v1/nodes/" + target.NodeName
+	var lastErr error
+	for {
+		err = patchNode(ctx, target.Root, url, patch)
+		if err == nil {
+			slog.Info("node annotations applied", "node", target.NodeName, "count", len(target.Annotations))
+			return nil
+		}
+		// the error of the request interrupted by the timeout doesn't tell why the node isn't registered.
+		if lastErr == nil || ctx.Err() == nil {
+			lastErr = err
+		}
+		slog.Info("waiting for the node to register", "node", target.NodeName, "error", err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for node %s to register: %w", target.NodeName, lastErr)
+		case <-time.After(target.Interval):
+		}
+	}
+}
+
+func patchNode(ctx context.Context, root, url string, patch []byte) error {
+	client, err := kubeletClient(root)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(patch))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, data)
+	}
+	return nil
+}
+
+// kubeletClient returns an API server client authenticated with the kubelet client certificate.
+func kubeletClient(root string) (*http.Client, error) {
+	certFile := filepath.Join(root, kubeletPKIDir, "kubelet-client-current.pem")
+	cert, err := tls.LoadX509KeyPair(certFile, certFile)
+	if err != nil {
+		return nil, fmt.Errorf("load kubelet client certificate: %w", err)
+	}
+	client, err := apiServerClient(filepath.Join(root, kubernetesCACertFile))
+	if err != nil {
+		return nil, err
+	}
+	client.Transport.(*http.Transport).TLSClientConfig.Certificates = []tls.Certificate{cert}
+	return client, nil
+}
+
+// applyNodeAnnotations annotates the node of config, named after the hostname like kubelet does.
+func (a *App) applyNodeAnnotations(ctx context.Context, config *aksnodeconfigv1.Configuration) error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("get hostname: %w", err)
+	}
+	return annotateNode(ctx, nodeAnnotations{
+		APIServerURL: "https://" + config.GetApiServerConfig().GetApiServerName(),
+		NodeName:     strings.ToLower(hostname),
+		Annotations:  config.GetKubeletConfig().GetNodeAnnotations(),
+		Root:         "/",
+		Timeout:      nodeRegistrationTimeout,
+		Interval:     nodeRegistrationPollInterval,
+	})
+}
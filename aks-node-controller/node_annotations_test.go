@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotateNode(t *testing.T) {
+	var patches []string
+	registered := false
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/v1/nodes/node1" ||
+			r.Header.Get("Content-Type") != "application/merge-patch+json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// the node registers after the first attempt.
+		if !registered {
+			registered = true
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		patches = append(patches, string(body))
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	root := t.TempDir()
+	writeKubeletClientCert(t, root)
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(kubernetesCACertFile)), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, kubernetesCACertFile), ca, 0644))
+
+	target := nodeAnnotations{
+		APIServerURL: server.URL,
+		NodeName:     "node1",
+		Annotations:  map[string]string{"example.com/owner": "team-a"},
+		Root:         root,
+		Timeout:      time.Minute,
+		Interval:     time.Millisecond,
+	}
+	require.NoError(t, annotateNode(context.Background(), target))
+	assert.Equal(t, []string{`{"metadata":{"annotations":{"example.com/owner":"team-a"}}}`}, patches)
+
+	t.Run("node never registers", func(t *testing.T) {
+		target := target
+		target.NodeName = "node2"
+		target.Timeout = 50 * time.Millisecond
+		err := annotateNode(context.Background(), target)
+		assert.ErrorContains(t, err, "waiting for node node2 to register")
+		assert.ErrorContains(t, err, "400 Bad Request")
+	})
+}
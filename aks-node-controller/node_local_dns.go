@@ -0,0 +1,36 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// setupNodeLocalDNS installs and starts the unit of the NodeLocal DNSCache interface, so that the address kubelet
+// hands to pods as their DNS server exists before CSE starts kubelet.
+func (a *App) setupNodeLocalDNS(ctx context.Context, config *aksnodeconfigv1.Configuration, systemdDir string) error {
+	if err := a.installUnit(ctx, systemdDir, parser.NodeLocalDNSUnit, parser.NodeLocalDNSUnitContent(config)); err != nil {
+		return err
+	}
+	slog.Info("node local DNS configured", "address", parser.NodeLocalDNSIP(config))
+	return nil
+}
+
+// installUnit writes the unit into systemdDir, then enables and starts it.
+func (a *App) installUnit(ctx context.Context, systemdDir, name, content string) error {
+	if err := writeFileAtomic(filepath.Join(systemdDir, name), []byte(content), 0644); err != nil {
+		return err
+	}
+	if err := a.cmdRunner(exec.CommandContext(ctx, "systemctl", "daemon-reload")); err != nil {
+		return fmt.Errorf("systemctl daemon-reload: %w", err)
+	}
+	if err := a.cmdRunner(exec.CommandContext(ctx, "systemctl", "enable", "--now", name)); err != nil {
+		return fmt.Errorf("enable %s: %w", name, err)
+	}
+	return nil
+}
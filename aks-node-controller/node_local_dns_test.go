@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_setupNodeLocalDNS(t *testing.T) {
+	config := &aksnodeconfigv1.Configuration{
+		NetworkConfig: &aksnodeconfigv1.NetworkConfig{NodeLocalDnsConfig: &aksnodeconfigv1.NodeLocalDnsConfig{Enabled: true}},
+	}
+	systemdDir := t.TempDir()
+	var commands []string
+	app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+		commands = append(commands, strings.Join(cmd.Args, " "))
+		return nil
+	}}
+	require.NoError(t, app.setupNodeLocalDNS(context.Background(), config, systemdDir))
+	assert.Equal(t, []string{"systemctl daemon-reload", "systemctl enable --now aks-node-local-dns.service"}, commands)
+	unit, err := os.ReadFile(filepath.Join(systemdDir, parser.NodeLocalDNSUnit))
+	require.NoError(t, err)
+	assert.Equal(t, parser.NodeLocalDNSUnitContent(config), string(unit))
+
+	app.cmdRunner = func(cmd *exec.Cmd) error {
+		if cmd.Args[1] == "enable" {
+			return errors.New("exit status 1")
+		}
+		return nil
+	}
+	err = app.setupNodeLocalDNS(context.Background(), config, systemdDir)
+	assert.EqualError(t, err, "enable aks-node-local-dns.service: exit status 1")
+}
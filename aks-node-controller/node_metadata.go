@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/nodeconfigutils"
+)
+
+const (
+	imdsComputeEndpoint   = "http://169.254.169.254/metadata/instance/compute"
+	imdsComputeAPIVersion = "2021-02-01"
+	imdsComputeTimeout    = 5 * time.Second
+	// unknownVersion is stamped when a value can't be determined.
+	unknownVersion = "unknown"
+)
+
+// NodeMetadata identifies what a node was provisioned from, support asks for it first when debugging a node.
+type NodeMetadata struct {
+	VHDVersion        string    `json:"vhdVersion"`
+	AgentBakerVersion string    `json:"agentBakerVersion"`
+	ControllerVersion string    `json:"controllerVersion"`
+	ConfigHash        string    `json:"configHash"`
+	ProvisionedAt     time.Time `json:"provisionedAt"`
+}
+
+// collectNodeMetadata returns the metadata of the node provisioned with config. A version which can't be determined is
+// stamped as unknown, the metadata is returned along with the error.
+func (a *App) collectNodeMetadata(ctx context.Context, config *aksnodeconfigv1.Configuration, provisionedAt time.Time) (NodeMetadata, error) {
+	var errs []error
+	hash, err := nodeconfigutils.ConfigurationHash(config)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	vhdVersion, err := a.vhdVersion(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("get VHD version: %w", err))
+	}
+	versions := readBuildVersions()
+	return NodeMetadata{
+		VHDVersion:        orUnknown(vhdVersion),
+		AgentBakerVersion: orUnknown(versions.AgentBaker),
+		ControllerVersion: orUnknown(versions.Controller),
+		ConfigHash:        orUnknown(hash),
+		ProvisionedAt:     provisionedAt.UTC(),
+	}, errors.Join(errs...)
+}
+
+// vhdVersion returns the version of the image the VM was created from. The version of a Shared Image Gallery image
+// is the last segment of its ID, marketplace images have it in the version field.
+func (a *App) vhdVersion(ctx context.Context) (string, error) {
+	endpoint := a.imdsComputeEndpoint
+	if endpoint == "" {
+		endpoint = imdsComputeEndpoint
+	}
+	ctx, cancel := context.WithTimeout(ctx, imdsComputeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?api-version="+imdsComputeAPIVersion, nil)
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Metadata", "true")
+	client := a.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	var compute struct {
+		StorageProfile struct {
+			ImageReference struct {
+				ID      string `json:"id"`
+				Version string `json:"version"`
+			} `json:"imageReference"`
+		} `json:"storageProfile"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&compute); err != nil {
+		return "", fmt.Errorf("decode compute metadata: %w", err)
+	}
+	image := compute.StorageProfile.ImageReference
+	if image.Version != "" {
+		return image.Version, nil
+	}
+	if strings.Contains(strings.ToLower(image.ID), "/versions/") {
+		return path.Base(image.ID), nil
+	}
+	return "", nil
+}
+
+// writeNodeMetadata writes the metadata as JSON to metadataPath and as a banner to motdPath, replacing the previous
+// ones.
+func writeNodeMetadata(metadataPath, motdPath string, metadata NodeMetadata) error {
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal node metadata: %w", err)
+	}
+	if err := writeFileAtomic(metadataPath, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return writeFileAtomic(motdPath, []byte(nodeMetadataMOTD(metadata)), 0644)
+}
+
+func nodeMetadataMOTD(metadata NodeMetadata) string {
+	return strings.Join([]string{
+		"This node is managed by Azure Kubernetes Service (AKS).",
+		"",
+		"  VHD version:        " + metadata.VHDVersion,
+		"  AgentBaker version: " + metadata.AgentBakerVersion,
+		"  Controller version: " + metadata.ControllerVersion,
+		"  Config hash:        " + metadata.ConfigHash,
+		"  Provisioned at:     " + metadata.ProvisionedAt.Format(time.RFC3339),
+		"",
+	}, "\n")
+}
+
+func orUnknown(version string) string {
+	if version == "" {
+		return unknownVersion
+	}
+	return version
+}
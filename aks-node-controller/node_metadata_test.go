@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/nodeconfigutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_vhdVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "shared image gallery",
+			status: http.StatusOK,
+			body:   `{"storageProfile":{"imageReference":{"id":"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/galleries/AKSUbuntu/images/2204gen2containerd/versions/202410.09.0"}}}`,
+			want:   "202410.09.0",
+		},
+		{
+			name:   "marketplace",
+			status: http.StatusOK,
+			body:   `{"storageProfile":{"imageReference":{"offer":"aks","version":"2024.10.09"}}}`,
+			want:   "2024.10.09",
+		},
+		{
+			name:   "custom image",
+			status: http.StatusOK,
+			body:   `{"storageProfile":{"imageReference":{"id":"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/images/custom"}}}`,
+		},
+		{
+			name:    "IMDS error",
+			status:  http.StatusInternalServerError,
+			wantErr: "unexpected status 500 Internal Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "true", r.Header.Get("Metadata"))
+				assert.Equal(t, imdsComputeAPIVersion, r.URL.Query().Get("api-version"))
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+			app := &App{imdsComputeEndpoint: server.URL}
+
+			got, err := app.vhdVersion(context.Background())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApp_collectNodeMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	config := &aksnodeconfigv1.Configuration{Version: "v0", KubernetesVersion: "1.31.0"}
+	provisionedAt := time.Date(2024, 10, 9, 12, 0, 0, 0, time.FixedZone("PDT", -7*3600))
+
+	metadata, err := (&App{imdsComputeEndpoint: server.URL}).collectNodeMetadata(context.Background(), config, provisionedAt)
+	assert.EqualError(t, err, "get VHD version: unexpected status 404 Not Found")
+	hash, hashErr := nodeconfigutils.ConfigurationHash(config)
+	require.NoError(t, hashErr)
+	assert.Equal(t, unknownVersion, metadata.VHDVersion)
+	assert.Equal(t, hash, metadata.ConfigHash)
+	assert.Equal(t, provisionedAt.UTC(), metadata.ProvisionedAt)
+}
+
+func TestWriteNodeMetadata(t *testing.T) {
+	dir := t.TempDir()
+	metadataPath, motdPath := filepath.Join(dir, "aks-node-metadata.json"), filepath.Join(dir, "motd")
+	metadata := NodeMetadata{
+		VHDVersion:        "202410.09.0",
+		AgentBakerVersion: "v0.20241009.0",
+		ControllerVersion: "v0.1.0",
+		ConfigHash:        "0123abcd",
+		ProvisionedAt:     time.Date(2024, 10, 9, 19, 0, 0, 0, time.UTC),
+	}
+
+	require.NoError(t, writeNodeMetadata(metadataPath, motdPath, metadata))
+	data, err := os.ReadFile(metadataPath)
+	require.NoError(t, err)
+	var written NodeMetadata
+	require.NoError(t, json.Unmarshal(data, &written))
+	assert.Equal(t, metadata, written)
+	motd, err := os.ReadFile(motdPath)
+	require.NoError(t, err)
+	assert.Equal(t, `This node is managed by Azure Kubernetes Service (AKS).
+
+  VHD version:        202410.09.0
+  AgentBaker version: v0.20241009.0
+  Controller version: v0.1.0
+  Config hash:        0123abcd
+  Provisioned at:     2024-10-09T19:00:00Z
+`, string(motd))
+}
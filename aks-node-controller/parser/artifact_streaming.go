@@ -0,0 +1,23 @@
+package parser
+
+import (
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// ArtifactStreamingEnabled returns whether images are streamed by the overlaybd snapshotter, enabled by either the
+// containerd config or the legacy top-level field.
+func ArtifactStreamingEnabled(config *aksnodeconfigv1.Configuration) bool {
+	return config.GetContainerdConfig().GetEnableArtifactStreaming() || config.GetEnableArtifactStreaming()
+}
+
+// WithoutArtifactStreaming returns a copy of the configuration with artifact streaming disabled, for node images
+// which don't ship the overlaybd snapshotter.
+func WithoutArtifactStreaming(config *aksnodeconfigv1.Configuration) *aksnodeconfigv1.Configuration {
+	config = proto.Clone(config).(*aksnodeconfigv1.Configuration)
+	config.EnableArtifactStreaming = false
+	if config.GetContainerdConfig() != nil {
+		config.ContainerdConfig.EnableArtifactStreaming = false
+	}
+	return config
+}
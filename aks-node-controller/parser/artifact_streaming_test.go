@@ -0,0 +1,26 @@
+package parser
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArtifactStreaming(t *testing.T) {
+	config := &aksnodeconfigv1.Configuration{
+		ContainerdConfig: &aksnodeconfigv1.ContainerdConfig{EnableArtifactStreaming: true},
+	}
+	assert.True(t, ArtifactStreamingEnabled(config))
+	assert.True(t, ArtifactStreamingEnabled(&aksnodeconfigv1.Configuration{EnableArtifactStreaming: true}))
+	assert.False(t, ArtifactStreamingEnabled(&aksnodeconfigv1.Configuration{}))
+
+	templateConfig := containerdTemplateConfig(config)
+	assert.True(t, templateConfig.GetEnableArtifactStreaming(), "the template only knows the top-level field")
+	assert.False(t, config.GetEnableArtifactStreaming(), "the configuration isn't modified")
+
+	disabled := WithoutArtifactStreaming(templateConfig)
+	assert.False(t, ArtifactStreamingEnabled(disabled))
+	assert.True(t, ArtifactStreamingEnabled(templateConfig))
+	assert.False(t, ArtifactStreamingEnabled(WithoutArtifactStreaming(&aksnodeconfigv1.Configuration{EnableArtifactStreaming: true})))
+}
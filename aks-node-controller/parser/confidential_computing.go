@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+)
+
+// ConfidentialComputingType returns the confidential computing technology of the VM size of a Linux node.
+func ConfidentialComputingType(config *aksnodeconfigv1.Configuration) datamodel.ConfidentialComputingType {
+	if IsWindows(config) {
+		return datamodel.ConfidentialComputingNone
+	}
+	return datamodel.GetConfidentialComputingType(config.GetVmSize())
+}
+
+// HasConfidentialComputing returns whether provisioning sets up the devices the attestation and SGX daemonsets use.
+func HasConfidentialComputing(config *aksnodeconfigv1.Configuration) bool {
+	return ConfidentialComputingType(config) != datamodel.ConfidentialComputingNone
+}
+
+// ConfidentialComputingGroup returns the group owning the devices which give access to attestation: the SGX
+// provisioning key of the quote generation, or the vTPM holding the attestation report of confidential VMs.
+func ConfidentialComputingGroup(config *aksnodeconfigv1.Configuration) string {
+	if ConfidentialComputingType(config) == datamodel.ConfidentialComputingSGX {
+		return "sgx_prv"
+	}
+	return "tss"
+}
+
+// ConfidentialComputingKernelModules returns the kernel modules exposing the attestation reports of the guest, the SGX
+// driver is built into the kernel.
+func ConfidentialComputingKernelModules(config *aksnodeconfigv1.Configuration) []string {
+	switch ConfidentialComputingType(config) {
+	case datamodel.ConfidentialComputingSEVSNP:
+		return []string{"sev-guest"}
+	case datamodel.ConfidentialComputingTDX:
+		return []string{"tdx_guest"}
+	}
+	return nil
+}
+
+// ConfidentialComputingModulesLoadContent returns the modules-load.d file loading the attestation modules on boot.
+func ConfidentialComputingModulesLoadContent(config *aksnodeconfigv1.Configuration) string {
+	return strings.Join(ConfidentialComputingKernelModules(config), "\n") + "\n"
+}
+
+// ConfidentialComputingUdevRules returns the udev rules giving the attestation group access to the devices. Any process
+// may create SGX enclaves, the /dev/sgx links are the paths of the SGX SDKs predating the in-kernel driver.
+func ConfidentialComputingUdevRules(config *aksnodeconfigv1.Configuration) string {
+	group := ConfidentialComputingGroup(config)
+	var rules []string
+	switch ConfidentialComputingType(config) {
+	case datamodel.ConfidentialComputingSGX:
+		rules = []string{
+			`SUBSYSTEM=="misc", KERNEL=="sgx_enclave", MODE="0666", SYMLINK+="sgx/enclave"`,
+			`SUBSYSTEM=="misc", KERNEL=="sgx_provision", GROUP="` + group + `", MODE="0660", SYMLINK+="sgx/provision"`,
+		}
+	case datamodel.ConfidentialComputingSEVSNP:
+		rules = []string{
+			`KERNEL=="tpmrm[0-9]*", SUBSYSTEM=="tpmrm", GROUP="` + group + `", MODE="0660"`,
+			`SUBSYSTEM=="misc", KERNEL=="sev-guest", GROUP="` + group + `", MODE="0660"`,
+		}
+	case datamodel.ConfidentialComputingTDX:
+		rules = []string{
+			`KERNEL=="tpmrm[0-9]*", SUBSYSTEM=="tpmrm", GROUP="` + group + `", MODE="0660"`,
+			`SUBSYSTEM=="misc", KERNEL=="tdx_guest", GROUP="` + group + `", MODE="0660"`,
+		}
+	}
+	return strings.Join(rules, "\n") + "\n"
+}
@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfidentialComputing(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *aksnodeconfigv1.Configuration
+		ccType      datamodel.ConfidentialComputingType
+		group       string
+		modules     []string
+		deviceRules []string
+	}{
+		{
+			name:   "general purpose size",
+			config: &aksnodeconfigv1.Configuration{VmSize: "Standard_D4s_v3"},
+		},
+		{
+			name:        "SGX",
+			config:      &aksnodeconfigv1.Configuration{VmSize: "Standard_DC4ds_v3"},
+			ccType:      datamodel.ConfidentialComputingSGX,
+			group:       "sgx_prv",
+			deviceRules: []string{`KERNEL=="sgx_enclave", MODE="0666"`, `KERNEL=="sgx_provision", GROUP="sgx_prv", MODE="0660"`},
+		},
+		{
+			name:        "SEV-SNP",
+			config:      &aksnodeconfigv1.Configuration{VmSize: "Standard_EC8as_v5"},
+			ccType:      datamodel.ConfidentialComputingSEVSNP,
+			group:       "tss",
+			modules:     []string{"sev-guest"},
+			deviceRules: []string{`SUBSYSTEM=="tpmrm", GROUP="tss"`, `KERNEL=="sev-guest", GROUP="tss"`},
+		},
+		{
+			name:        "TDX",
+			config:      &aksnodeconfigv1.Configuration{VmSize: "Standard_DC8es_v5"},
+			ccType:      datamodel.ConfidentialComputingTDX,
+			group:       "tss",
+			modules:     []string{"tdx_guest"},
+			deviceRules: []string{`SUBSYSTEM=="tpmrm", GROUP="tss"`, `KERNEL=="tdx_guest", GROUP="tss"`},
+		},
+		{
+			name: "Windows",
+			config: &aksnodeconfigv1.Configuration{
+				VmSize:        "Standard_DC4as_v5",
+				WindowsConfig: &aksnodeconfigv1.WindowsConfig{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.ccType, ConfidentialComputingType(tt.config))
+			assert.Equal(t, tt.ccType != datamodel.ConfidentialComputingNone, HasConfidentialComputing(tt.config))
+			assert.Equal(t, tt.modules, ConfidentialComputingKernelModules(tt.config))
+			if tt.group != "" {
+				assert.Equal(t, tt.group, ConfidentialComputingGroup(tt.config))
+			}
+			rules := ConfidentialComputingUdevRules(tt.config)
+			for _, rule := range tt.deviceRules {
+				assert.Contains(t, rules, rule)
+			}
+		})
+	}
+}
+
+func TestConfidentialComputingCSEVariable(t *testing.T) {
+	config := &aksnodeconfigv1.Configuration{VmSize: "Standard_DC4as_v5"}
+	assert.Equal(t, "sev-snp", getCSEEnv(config)["CONFIDENTIAL_COMPUTING_TYPE"])
+}
@@ -42,3 +42,6 @@ const (
 	defaultNetIpv4NeighDefaultGcThresh2 = 8192
 	defaultNetIpv4NeighDefaultGcThresh3 = 16384
 )
+
+// KubeletConfigDropInDir is the kubelet --config-dir, drop-in files are merged over the kubelet config file.
+const KubeletConfigDropInDir = "/etc/kubernetes/kubelet.conf.d"
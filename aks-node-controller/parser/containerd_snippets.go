@@ -0,0 +1,347 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// bareKeyRegex matches a TOML key which doesn't need quotes.
+var bareKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateContainerdConfig checks that the containerd config can be generated, which fails when a config snippet
+// isn't valid or conflicts with the generated config or an earlier snippet.
+func ValidateContainerdConfig(config *aksnodeconfigv1.Configuration) error {
+	_, err := containerdConfigFromAKSNodeConfig(config)
+	return err
+}
+
+// mergeContainerdConfigSnippets merges the snippets into the generated containerd config, in order. The generated
+// config is kept as is, keys are added at the end of the table they belong to and new tables at the end.
+func mergeContainerdConfigSnippets(base string, snippets []string) (string, error) {
+	for i, snippet := range snippets {
+		merged, err := mergeTOML(base, snippet)
+		if err != nil {
+			return "", fmt.Errorf("containerd config snippet %d: %w", i, err)
+		}
+		base = merged
+	}
+	return base, nil
+}
+
+// tomlEntry is a key/value pair of a TOML document, or a table header when key is empty.
+type tomlEntry struct {
+	table []string
+	key   []string
+	value string
+	// line is the index of the last line of the entry.
+	line int
+}
+
+func (e tomlEntry) path() []string {
+	return append(append([]string{}, e.table...), e.key...)
+}
+
+// mergeTOML merges snippet into base. Only the TOML subset the containerd config uses is supported: tables, dotted
+// keys and single or multi-line values, but not arrays of tables and multi-line strings.
+func mergeTOML(base, snippet string) (string, error) {
+	lines := strings.Split(base, "\n")
+	baseEntries, err := parseTOML(lines)
+	if err != nil {
+		return "", fmt.Errorf("generated config: %w", err)
+	}
+	snippetEntries, err := parseTOML(strings.Split(snippet, "\n"))
+	if err != nil {
+		return "", err
+	}
+
+	values := map[string]string{}
+	// tables are all the tables of the document, including the ones implied by dotted keys and headers.
+	tables := map[string]bool{"": true}
+	// sectionEnd is the index of the last line of every table section, the root table ends before the first header.
+	sectionEnd := map[string]int{"": -1}
+	addEntry := func(e tomlEntry) {
+		path := e.path()
+		for i := range path {
+			tables[tomlPathKey(path[:i])] = true
+		}
+		if e.key == nil {
+			tables[tomlPathKey(path)] = true
+		} else {
+			values[tomlPathKey(path)] = e.value
+		}
+	}
+	for _, e := range baseEntries {
+		addEntry(e)
+		sectionEnd[tomlPathKey(e.table)] = e.line
+	}
+
+	added := map[int][]string{}
+	var newTables []string
+	newTableLines := map[string][]string{}
+	for _, e := range snippetEntries {
+		path := e.path()
+		for i := 1; i < len(path); i++ {
+			if _, ok := values[tomlPathKey(path[:i])]; ok {
+				return "", fmt.Errorf("%s is a value, not a table", formatTOMLKey(path[:i]))
+			}
+		}
+		if e.key != nil {
+			if tables[tomlPathKey(path)] {
+				return "", fmt.Errorf("%s is a table, not a value", formatTOMLKey(path))
+			}
+			if existing, ok := values[tomlPathKey(path)]; ok {
+				if normalizeTOMLValue(existing) != normalizeTOMLValue(e.value) {
+					return "", fmt.Errorf("%s is already set to %s", formatTOMLKey(path), existing)
+				}
+				continue
+			}
+		}
+
+		if e.key != nil {
+			// a dotted key is added to the deepest table of its path with a section, a table defined by a header
+			// can't be extended by a dotted key from its parent.
+			for i := len(path) - 1; i > len(e.table); i-- {
+				_, inBase := sectionEnd[tomlPathKey(path[:i])]
+				_, inSnippet := newTableLines[tomlPathKey(path[:i])]
+				if inBase || inSnippet {
+					e = tomlEntry{table: path[:i], key: path[i:], value: e.value}
+					break
+				}
+			}
+		}
+		table := tomlPathKey(e.table)
+		end, exists := sectionEnd[table]
+		if !exists {
+			if _, ok := newTableLines[table]; !ok {
+				newTables = append(newTables, table)
+				newTableLines[table] = []string{"[" + formatTOMLKey(e.table) + "]"}
+			}
+		}
+		if e.key != nil {
+			line := formatTOMLKey(e.key) + " = " + e.value
+			if exists {
+				added[end] = append(added[end], line)
+			} else {
+				newTableLines[table] = append(newTableLines[table], line)
+			}
+		}
+		addEntry(e)
+	}
+
+	var b strings.Builder
+	writeLines := func(lines []string, indent string) {
+		for _, line := range lines {
+			b.WriteString(indent + line + "\n")
+		}
+	}
+	writeLines(added[-1], "")
+	// the trailing newline of base is written back after the last line.
+	trimmed := strings.TrimSuffix(base, "\n")
+	if trimmed != "" {
+		for i, line := range strings.Split(trimmed, "\n") {
+			b.WriteString(line + "\n")
+			// added keys are indented like the last line of their table.
+			writeLines(added[i], line[:len(line)-len(strings.TrimLeft(line, " \t"))])
+		}
+	}
+	for _, table := range newTables {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		writeLines(newTableLines[table], "")
+	}
+	return b.String(), nil
+}
+
+// parseTOML returns the table headers and key/value pairs of the document.
+func parseTOML(lines []string) ([]tomlEntry, error) {
+	var entries []tomlEntry
+	var table []string
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(stripTOMLComment(lines[i]))
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "[["):
+			return nil, fmt.Errorf("line %d: arrays of tables are not supported", i+1)
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: invalid table header %q", i+1, line)
+			}
+			path, err := parseTOMLKey(line[1 : len(line)-1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			table = path
+			entries = append(entries, tomlEntry{table: table, line: i})
+			continue
+		}
+
+		rawKey, value, found := cutTOMLUnquoted(line, '=')
+		if !found {
+			return nil, fmt.Errorf("line %d: expected a key/value pair, got %q", i+1, line)
+		}
+		key, err := parseTOMLKey(rawKey)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, `"""`) || strings.HasPrefix(value, "'''") {
+			return nil, fmt.Errorf("line %d: multi-line strings are not supported", i+1)
+		}
+		start := i
+		// arrays and inline tables may span several lines.
+		for depth := tomlBracketDepth(value); depth > 0; depth += tomlBracketDepth(lines[i]) {
+			if i++; i == len(lines) {
+				return nil, fmt.Errorf("line %d: unterminated value of %s", start+1, formatTOMLKey(key))
+			}
+			value += "\n" + strings.TrimRight(stripTOMLComment(lines[i]), " \t")
+		}
+		if value == "" {
+			return nil, fmt.Errorf("line %d: %s has no value", start+1, formatTOMLKey(key))
+		}
+		entries = append(entries, tomlEntry{table: table, key: key, value: value, line: i})
+	}
+	return entries, nil
+}
+
+// parseTOMLKey parses a dotted key, whose parts are bare or quoted.
+func parseTOMLKey(raw string) ([]string, error) {
+	var path []string
+	rest := strings.TrimSpace(raw)
+	for {
+		var part string
+		switch {
+		case strings.HasPrefix(rest, `"`), strings.HasPrefix(rest, "'"):
+			end := closingTOMLQuote(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("invalid key %q", raw)
+			}
+			part, rest = rest[:end+1], rest[end+1:]
+			if part[0] == '\'' {
+				part = part[1 : len(part)-1]
+			} else {
+				unquoted, err := strconv.Unquote(part)
+				if err != nil {
+					return nil, fmt.Errorf("invalid key %q", raw)
+				}
+				part = unquoted
+			}
+		default:
+			part, rest, _ = strings.Cut(rest, ".")
+			part = strings.TrimSpace(part)
+			if !bareKeyRegex.MatchString(part) {
+				return nil, fmt.Errorf("invalid key %q", raw)
+			}
+			rest = "." + rest
+			if rest == "." {
+				rest = ""
+			}
+		}
+		path = append(path, part)
+		rest = strings.TrimSpace(rest)
+		if rest == "" {
+			return path, nil
+		}
+		if !strings.HasPrefix(rest, ".") {
+			return nil, fmt.Errorf("invalid key %q", raw)
+		}
+		rest = strings.TrimSpace(rest[1:])
+	}
+}
+
+// closingTOMLQuote returns the index of the quote closing the string s starts with, or -1.
+func closingTOMLQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[0] == '"' && s[i] == '\\':
+			i++
+		case s[i] == s[0]:
+			return i
+		}
+	}
+	return -1
+}
+
+// cutTOMLUnquoted slices s around the first sep which isn't in a string.
+func cutTOMLUnquoted(s string, sep byte) (before, after string, found bool) {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"', '\'':
+			end := closingTOMLQuote(s[i:])
+			if end < 0 {
+				return s, "", false
+			}
+			i += end
+		case sep:
+			return s[:i], s[i+1:], true
+		}
+	}
+	return s, "", false
+}
+
+func stripTOMLComment(line string) string {
+	before, _, _ := cutTOMLUnquoted(line, '#')
+	return before
+}
+
+// tomlBracketDepth returns the number of brackets and braces the line opens and doesn't close, outside strings.
+func tomlBracketDepth(line string) int {
+	depth := 0
+	line = stripTOMLComment(line)
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"', '\'':
+			if end := closingTOMLQuote(line[i:]); end > 0 {
+				i += end
+			}
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+		}
+	}
+	return depth
+}
+
+// normalizeTOMLValue removes the whitespace outside strings, so that equal values compare equal.
+func normalizeTOMLValue(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '"', '\'':
+			end := closingTOMLQuote(value[i:])
+			if end < 0 {
+				end = len(value) - i - 1
+			}
+			b.WriteString(value[i : i+end+1])
+			i += end
+		case ' ', '\t', '\n', '\r':
+		default:
+			b.WriteByte(c)
+		}
+	}
+	// a trailing comma of a multi-line array doesn't change the value.
+	return strings.NewReplacer(",]", "]", ",}", "}").Replace(b.String())
+}
+
+func tomlPathKey(path []string) string {
+	return strings.Join(path, "\x00")
+}
+
+// formatTOMLKey returns the dotted key of the path, quoting the parts which aren't bare keys.
+func formatTOMLKey(path []string) string {
+	parts := make([]string, len(path))
+	for i, part := range path {
+		if bareKeyRegex.MatchString(part) {
+			parts[i] = part
+		} else {
+			parts[i] = strconv.Quote(part)
+		}
+	}
+	return strings.Join(parts, ".")
+}
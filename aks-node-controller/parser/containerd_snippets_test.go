@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeContainerdConfigSnippets(t *testing.T) {
+	base := `version = 2
+oom_score = 0
+
+[plugins."io.containerd.grpc.v1.cri"]
+  sandbox_image = "mcr.microsoft.com/oss/kubernetes/pause:3.6"
+  [plugins."io.containerd.grpc.v1.cri".containerd]
+    default_runtime_name = "runc"
+    [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+      runtime_type = "io.containerd.runc.v2"
+
+[metrics]
+  address = "0.0.0.0:10257"
+`
+	tests := []struct {
+		name     string
+		snippets []string
+		want     string
+		wantErr  string
+	}{
+		{
+			name: "no snippets",
+			want: base,
+		},
+		{
+			name: "keys and tables added",
+			snippets: []string{
+				`# debug settings
+[debug]
+  level = "debug"
+
+[plugins."io.containerd.grpc.v1.cri".containerd]
+  snapshotter = "stargz" # lazy pulling
+  runtimes.runc.options.SystemdCgroup = true
+`,
+				`oom_score = 0
+[proxy_plugins.stargz]
+  type = "snapshot"
+  address = "/run/containerd-stargz-grpc/containerd-stargz-grpc.sock"
+[metrics]
+  grpc_histogram = false
+`,
+			},
+			want: `version = 2
+oom_score = 0
+
+[plugins."io.containerd.grpc.v1.cri"]
+  sandbox_image = "mcr.microsoft.com/oss/kubernetes/pause:3.6"
+  [plugins."io.containerd.grpc.v1.cri".containerd]
+    default_runtime_name = "runc"
+    snapshotter = "stargz"
+    [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc]
+      runtime_type = "io.containerd.runc.v2"
+      options.SystemdCgroup = true
+
+[metrics]
+  address = "0.0.0.0:10257"
+  grpc_histogram = false
+
+[debug]
+level = "debug"
+
+[proxy_plugins.stargz]
+type = "snapshot"
+address = "/run/containerd-stargz-grpc/containerd-stargz-grpc.sock"
+`,
+		},
+		{
+			name: "multi-line array",
+			snippets: []string{`[plugins."io.containerd.grpc.v1.cri".registry]
+  config_path = [
+    "/etc/containerd/certs.d", # mirrors
+    "/etc/containerd/extra.d",
+  ]
+`},
+			want: base + `
+[plugins."io.containerd.grpc.v1.cri".registry]
+config_path = [
+    "/etc/containerd/certs.d",
+    "/etc/containerd/extra.d",
+  ]
+`,
+		},
+		{
+			name:     "equal value with other whitespace",
+			snippets: []string{`[metrics]` + "\n" + `address="0.0.0.0:10257"`},
+			want:     base,
+		},
+		{
+			name:     "conflicting value",
+			snippets: []string{`[plugins."io.containerd.grpc.v1.cri".containerd]` + "\n" + `default_runtime_name = "kata"`},
+			wantErr:  `containerd config snippet 0: plugins."io.containerd.grpc.v1.cri".containerd.default_runtime_name is already set to "runc"`,
+		},
+		{
+			name:     "conflict between snippets",
+			snippets: []string{"[debug]\nlevel = \"debug\"", "[debug]\nlevel = \"info\""},
+			wantErr:  `containerd config snippet 1: debug.level is already set to "debug"`,
+		},
+		{
+			name:     "value replaced by a table",
+			snippets: []string{"[metrics.address]\nport = 10257"},
+			wantErr:  "containerd config snippet 0: metrics.address is a value, not a table",
+		},
+		{
+			name:     "table replaced by a value",
+			snippets: []string{`plugins = "none"`},
+			wantErr:  "containerd config snippet 0: plugins is a table, not a value",
+		},
+		{
+			name:     "array of tables",
+			snippets: []string{"[[plugins.list]]\nname = \"a\""},
+			wantErr:  "containerd config snippet 0: line 1: arrays of tables are not supported",
+		},
+		{
+			name:     "invalid line",
+			snippets: []string{"[debug]\nlevel"},
+			wantErr:  `containerd config snippet 0: line 2: expected a key/value pair, got "level"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeContainerdConfigSnippets(base, tt.snippets)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
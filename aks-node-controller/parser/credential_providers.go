@@ -0,0 +1,173 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+const (
+	// CredentialProviderConfigFile is the kubelet CredentialProviderConfig rendered from credential_providers. It
+	// isn't the file CSE writes for the default ACR credential provider.
+	CredentialProviderConfigFile = "/etc/kubernetes/aks-credential-provider-config.yaml"
+	// CredentialProviderBinDir is where CSE installs the ACR credential provider and the other plugins are installed.
+	CredentialProviderBinDir = "/var/lib/kubelet/credential-provider"
+	// CredentialProviderCloudConfigDir holds the cloud config files of the cross-tenant ACR providers.
+	CredentialProviderCloudConfigDir = "/etc/kubernetes/credential-provider"
+	// ACRCredentialProviderName is the binary of the ACR credential provider.
+	ACRCredentialProviderName = "acr-credential-provider"
+
+	acrCloudConfigFile                = "/etc/kubernetes/azure.json"
+	defaultCredentialProviderCacheTTL = "10m"
+)
+
+var (
+	credentialProviderNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][-a-zA-Z0-9_.]*$`)
+	// acrMatchImages are the registries of the Azure clouds.
+	acrMatchImages = []string{"*.azurecr.io", "*.azurecr.cn", "*.azurecr.de", "*.azurecr.us"}
+)
+
+// HasCredentialProviders returns whether the kubelet credential providers are configured by aks-node-controller.
+func HasCredentialProviders(config *aksnodeconfigv1.Configuration) bool {
+	return len(config.GetCredentialProviders().GetProviders()) > 0
+}
+
+// ValidateCredentialProviders checks that every provider is complete and that the kubelet credential provider flags
+// aren't also set.
+func ValidateCredentialProviders(config *aksnodeconfigv1.Configuration) error {
+	if !HasCredentialProviders(config) {
+		return nil
+	}
+	for _, flag := range []string{"--image-credential-provider-config", "--image-credential-provider-bin-dir"} {
+		if _, ok := config.GetKubeletConfig().GetKubeletFlags()[flag]; ok {
+			return fmt.Errorf("credential providers can't be combined with %s", flag)
+		}
+	}
+	names := map[string]bool{}
+	for _, provider := range config.GetCredentialProviders().GetProviders() {
+		name := CredentialProviderName(provider)
+		if !credentialProviderNameRegex.MatchString(name) {
+			return fmt.Errorf("invalid credential provider name %q", name)
+		}
+		if names[name] {
+			return fmt.Errorf("duplicate credential provider %q", name)
+		}
+		names[name] = true
+		if duration := provider.GetDefaultCacheDuration(); duration != "" {
+			if _, err := time.ParseDuration(duration); err != nil {
+				return fmt.Errorf("credential provider %q: invalid default cache duration %q", name, duration)
+			}
+		}
+
+		switch provider.GetType() {
+		case aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_ACR:
+		case aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_ACR_CROSS_TENANT:
+			if provider.GetTenantId() == "" || provider.GetClientId() == "" {
+				return fmt.Errorf("credential provider %q: cross-tenant ACR providers require a tenant ID and a client ID", name)
+			}
+			if len(provider.GetMatchImages()) == 0 {
+				return fmt.Errorf("credential provider %q: cross-tenant ACR providers require match images", name)
+			}
+		case aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_CUSTOM:
+			if provider.GetName() == "" || provider.GetDownloadUrl() == "" {
+				return fmt.Errorf("credential provider %q: custom providers require a name and a download URL", name)
+			}
+			if len(provider.GetMatchImages()) == 0 {
+				return fmt.Errorf("credential provider %q: custom providers require match images", name)
+			}
+		default:
+			return fmt.Errorf("credential provider %q: credential provider type is required", name)
+		}
+	}
+	return nil
+}
+
+// CredentialProviderName returns the name of the provider, which is also the name of its binary.
+func CredentialProviderName(provider *aksnodeconfigv1.CredentialProvider) string {
+	if provider.GetName() == "" && provider.GetType() != aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_CUSTOM {
+		return ACRCredentialProviderName
+	}
+	return provider.GetName()
+}
+
+// CrossTenantCloudConfigFile returns the cloud config file the cross-tenant ACR provider authenticates with.
+func CrossTenantCloudConfigFile(provider *aksnodeconfigv1.CredentialProvider) string {
+	return filepath.Join(CredentialProviderCloudConfigDir, CredentialProviderName(provider)+".json")
+}
+
+// CrossTenantCloudConfig returns the cloud config of the cross-tenant ACR provider: the user-assigned managed identity
+// of the other tenant.
+func CrossTenantCloudConfig(provider *aksnodeconfigv1.CredentialProvider) (string, error) {
+	data, err := json.MarshalIndent(map[string]any{
+		"tenantId":                    provider.GetTenantId(),
+		"useManagedIdentityExtension": true,
+		"userAssignedIdentityID":      provider.GetClientId(),
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// CredentialProviderConfig returns the kubelet CredentialProviderConfig of the credential providers.
+func CredentialProviderConfig(config *aksnodeconfigv1.Configuration) string {
+	lines := []string{
+		"apiVersion: kubelet.config.k8s.io/v1",
+		"kind: CredentialProviderConfig",
+		"providers:",
+	}
+	for _, provider := range config.GetCredentialProviders().GetProviders() {
+		matchImages := provider.GetMatchImages()
+		args := provider.GetArgs()
+		switch provider.GetType() {
+		case aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_ACR:
+			if len(matchImages) == 0 {
+				matchImages = acrMatchImages
+			}
+			if len(args) == 0 {
+				args = []string{acrCloudConfigFile}
+			}
+		case aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_ACR_CROSS_TENANT:
+			if len(args) == 0 {
+				args = []string{CrossTenantCloudConfigFile(provider)}
+			}
+		}
+		cacheDuration := provider.GetDefaultCacheDuration()
+		if cacheDuration == "" {
+			cacheDuration = defaultCredentialProviderCacheTTL
+		}
+
+		lines = append(lines,
+			fmt.Sprintf("- name: %q", CredentialProviderName(provider)),
+			"  apiVersion: credentialprovider.kubelet.k8s.io/v1",
+			"  matchImages:")
+		for _, image := range matchImages {
+			lines = append(lines, fmt.Sprintf("  - %q", image))
+		}
+		lines = append(lines, fmt.Sprintf("  defaultCacheDuration: %q", cacheDuration))
+		if len(args) > 0 {
+			lines = append(lines, "  args:")
+			for _, arg := range args {
+				lines = append(lines, fmt.Sprintf("  - %q", arg))
+			}
+		}
+		if len(provider.GetEnv()) > 0 {
+			lines = append(lines, "  env:")
+			names := make([]string, 0, len(provider.GetEnv()))
+			for name := range provider.GetEnv() {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				lines = append(lines, fmt.Sprintf("  - name: %q", name), fmt.Sprintf("    value: %q", provider.GetEnv()[name]))
+			}
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,164 @@
+package parser
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCredentialProviders(t *testing.T) {
+	acr := &aksnodeconfigv1.CredentialProvider{Type: aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_ACR}
+	crossTenant := &aksnodeconfigv1.CredentialProvider{
+		Type:        aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_ACR_CROSS_TENANT,
+		Name:        "acr-cross-tenant",
+		MatchImages: []string{"contoso.azurecr.io"},
+		TenantId:    "tenant",
+		ClientId:    "client",
+	}
+	custom := &aksnodeconfigv1.CredentialProvider{
+		Type:        aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_CUSTOM,
+		Name:        "ecr-credential-provider",
+		DownloadUrl: "https://example.com/ecr-credential-provider",
+		MatchImages: []string{"*.dkr.ecr.*.amazonaws.com"},
+	}
+	providers := func(providers ...*aksnodeconfigv1.CredentialProvider) *aksnodeconfigv1.Configuration {
+		return &aksnodeconfigv1.Configuration{CredentialProviders: &aksnodeconfigv1.CredentialProviders{Providers: providers}}
+	}
+	tests := []struct {
+		name    string
+		config  *aksnodeconfigv1.Configuration
+		wantErr string
+	}{
+		{
+			name:   "no providers",
+			config: &aksnodeconfigv1.Configuration{},
+		},
+		{
+			name:   "acr, cross-tenant and custom providers",
+			config: providers(acr, crossTenant, custom),
+		},
+		{
+			name: "kubelet flags",
+			config: &aksnodeconfigv1.Configuration{
+				CredentialProviders: providers(acr).CredentialProviders,
+				KubeletConfig: &aksnodeconfigv1.KubeletConfig{
+					KubeletFlags: map[string]string{"--image-credential-provider-config": "/var/lib/kubelet/credential-provider-config.yaml"},
+				},
+			},
+			wantErr: "credential providers can't be combined with --image-credential-provider-config",
+		},
+		{
+			name:    "duplicate provider",
+			config:  providers(acr, &aksnodeconfigv1.CredentialProvider{Type: crossTenant.Type, TenantId: "tenant", ClientId: "client"}),
+			wantErr: `duplicate credential provider "acr-credential-provider"`,
+		},
+		{
+			name:    "invalid name",
+			config:  providers(&aksnodeconfigv1.CredentialProvider{Type: acr.Type, Name: "../acr"}),
+			wantErr: `invalid credential provider name "../acr"`,
+		},
+		{
+			name:    "invalid cache duration",
+			config:  providers(&aksnodeconfigv1.CredentialProvider{Type: acr.Type, DefaultCacheDuration: "10"}),
+			wantErr: `credential provider "acr-credential-provider": invalid default cache duration "10"`,
+		},
+		{
+			name: "cross-tenant without identity",
+			config: providers(&aksnodeconfigv1.CredentialProvider{
+				Type:        crossTenant.Type,
+				MatchImages: crossTenant.MatchImages,
+				TenantId:    "tenant",
+			}),
+			wantErr: `credential provider "acr-credential-provider": cross-tenant ACR providers require a tenant ID and a client ID`,
+		},
+		{
+			name:    "custom without download URL",
+			config:  providers(&aksnodeconfigv1.CredentialProvider{Type: custom.Type, Name: custom.Name, MatchImages: custom.MatchImages}),
+			wantErr: `credential provider "ecr-credential-provider": custom providers require a name and a download URL`,
+		},
+		{
+			name:    "custom without match images",
+			config:  providers(&aksnodeconfigv1.CredentialProvider{Type: custom.Type, Name: custom.Name, DownloadUrl: custom.DownloadUrl}),
+			wantErr: `credential provider "ecr-credential-provider": custom providers require match images`,
+		},
+		{
+			name:    "no type",
+			config:  providers(&aksnodeconfigv1.CredentialProvider{Name: "plugin"}),
+			wantErr: `credential provider "plugin": credential provider type is required`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCredentialProviders(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCredentialProviderConfig(t *testing.T) {
+	config := &aksnodeconfigv1.Configuration{
+		CredentialProviders: &aksnodeconfigv1.CredentialProviders{
+			Providers: []*aksnodeconfigv1.CredentialProvider{
+				{Type: aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_ACR},
+				{
+					Type:        aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_ACR_CROSS_TENANT,
+					Name:        "acr-cross-tenant",
+					MatchImages: []string{"contoso.azurecr.io"},
+					TenantId:    "tenant",
+					ClientId:    "client",
+				},
+				{
+					Type:                 aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_CUSTOM,
+					Name:                 "ecr-credential-provider",
+					DownloadUrl:          "https://example.com/ecr-credential-provider",
+					MatchImages:          []string{"*.dkr.ecr.*.amazonaws.com"},
+					DefaultCacheDuration: "12h",
+					Args:                 []string{"--v=2"},
+					Env:                  map[string]string{"AWS_REGION": "us-west-2", "AWS_PROFILE": "default"},
+				},
+			},
+		},
+	}
+	assert.Equal(t, `apiVersion: kubelet.config.k8s.io/v1
+kind: CredentialProviderConfig
+providers:
+- name: "acr-credential-provider"
+  apiVersion: credentialprovider.kubelet.k8s.io/v1
+  matchImages:
+  - "*.azurecr.io"
+  - "*.azurecr.cn"
+  - "*.azurecr.de"
+  - "*.azurecr.us"
+  defaultCacheDuration: "10m"
+  args:
+  - "/etc/kubernetes/azure.json"
+- name: "acr-cross-tenant"
+  apiVersion: credentialprovider.kubelet.k8s.io/v1
+  matchImages:
+  - "contoso.azurecr.io"
+  defaultCacheDuration: "10m"
+  args:
+  - "/etc/kubernetes/credential-provider/acr-cross-tenant.json"
+- name: "ecr-credential-provider"
+  apiVersion: credentialprovider.kubelet.k8s.io/v1
+  matchImages:
+  - "*.dkr.ecr.*.amazonaws.com"
+  defaultCacheDuration: "12h"
+  args:
+  - "--v=2"
+  env:
+  - name: "AWS_PROFILE"
+    value: "default"
+  - name: "AWS_REGION"
+    value: "us-west-2"
+`, CredentialProviderConfig(config))
+
+	cloudConfig, err := CrossTenantCloudConfig(config.GetCredentialProviders().GetProviders()[1])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"tenantId": "tenant", "useManagedIdentityExtension": true, "userAssignedIdentityID": "client"}`, cloudConfig)
+}
@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// DualStackUnit enables IPv6 forwarding and masquerades the traffic of the secondary CIDRs on every boot.
+const DualStackUnit = "aks-dual-stack.service"
+
+// IPFamilies returns the IP families of the node, the primary family first.
+func IPFamilies(config *aksnodeconfigv1.Configuration) []aksnodeconfigv1.IPFamily {
+	if families := config.GetNetworkConfig().GetIpFamilies(); len(families) > 0 {
+		return families
+	}
+	if config.GetIpv6DualStackEnabled() {
+		return []aksnodeconfigv1.IPFamily{aksnodeconfigv1.IPFamily_IP_FAMILY_IPV4, aksnodeconfigv1.IPFamily_IP_FAMILY_IPV6}
+	}
+	return []aksnodeconfigv1.IPFamily{aksnodeconfigv1.IPFamily_IP_FAMILY_IPV4}
+}
+
+// DualStackEnabled returns whether the node has both an IPv4 and an IPv6 address.
+func DualStackEnabled(config *aksnodeconfigv1.Configuration) bool {
+	return len(IPFamilies(config)) == 2
+}
+
+// HasIPFamilies returns whether the IP families are set explicitly, provisioning then sets kubelet --node-ip and
+// the IPv6 forwarding up itself.
+func HasIPFamilies(config *aksnodeconfigv1.Configuration) bool {
+	return len(config.GetNetworkConfig().GetIpFamilies()) > 0
+}
+
+// ValidateIPFamilies checks the IP families and that the secondary CIDRs belong to the secondary family.
+func ValidateIPFamilies(config *aksnodeconfigv1.Configuration) error {
+	networkConfig := config.GetNetworkConfig()
+	families := networkConfig.GetIpFamilies()
+	if len(families) == 0 {
+		if len(networkConfig.GetSecondaryCidrs()) > 0 {
+			return errors.New("secondary CIDRs require ip_families")
+		}
+		return nil
+	}
+	if len(families) > 2 || (len(families) == 2 && families[0] == families[1]) {
+		return fmt.Errorf("ip families %v must be a single family or one of each family", families)
+	}
+	for _, family := range families {
+		if _, ok := aksnodeconfigv1.IPFamily_name[int32(family)]; !ok || family == aksnodeconfigv1.IPFamily_IP_FAMILY_UNSPECIFIED {
+			return fmt.Errorf("invalid ip family %v", family)
+		}
+	}
+	if config.GetIpv6DualStackEnabled() && len(families) != 2 {
+		return errors.New("ipv6_dual_stack_enabled requires both ip families")
+	}
+	if _, ok := config.GetKubeletConfig().GetKubeletFlags()["--node-ip"]; ok {
+		return errors.New("ip families can't be combined with --node-ip")
+	}
+	for _, cidr := range networkConfig.GetSecondaryCidrs() {
+		if len(families) != 2 {
+			return errors.New("secondary CIDRs require a dual-stack node")
+		}
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil || prefix.Masked() != prefix {
+			return fmt.Errorf("invalid secondary CIDR %q", cidr)
+		}
+		if prefix.Addr().Is6() != (families[1] == aksnodeconfigv1.IPFamily_IP_FAMILY_IPV6) {
+			return fmt.Errorf("secondary CIDR %s isn't of the secondary ip family %v", cidr, families[1])
+		}
+	}
+	return nil
+}
+
+// WithNodeIPs returns a copy of the configuration whose kubelet flags set --node-ip to the addresses of the node, in
+// the order of its IP families.
+func WithNodeIPs(config *aksnodeconfigv1.Configuration, nodeIPs []string) *aksnodeconfigv1.Configuration {
+	config = proto.Clone(config).(*aksnodeconfigv1.Configuration)
+	if config.KubeletConfig == nil {
+		config.KubeletConfig = &aksnodeconfigv1.KubeletConfig{}
+	}
+	if config.KubeletConfig.KubeletFlags == nil {
+		config.KubeletConfig.KubeletFlags = map[string]string{}
+	}
+	config.KubeletConfig.KubeletFlags["--node-ip"] = strings.Join(nodeIPs, ",")
+	return config
+}
+
+// DualStackUnitContent returns the systemd unit enabling IPv6 forwarding, which pod traffic of a dual-stack node
+// needs, and masquerading the traffic of the secondary CIDRs leaving them.
+func DualStackUnitContent(config *aksnodeconfigv1.Configuration) string {
+	lines := []string{
+		"[Unit]",
+		"Description=AKS dual-stack networking",
+		"After=network-online.target",
+		"Before=kubelet.service",
+		"",
+		"[Service]",
+		"Type=oneshot",
+		"RemainAfterExit=yes",
+		"ExecStart=/sbin/sysctl -w net.ipv4.ip_forward=1",
+		"ExecStart=/sbin/sysctl -w net.ipv6.conf.all.forwarding=1",
+	}
+	for _, cidr := range config.GetNetworkConfig().GetSecondaryCidrs() {
+		tables := "iptables"
+		if netip.MustParsePrefix(cidr).Addr().Is6() {
+			tables = "ip6tables"
+		}
+		rule := fmt.Sprintf("-s %[1]s ! -d %[1]s -j MASQUERADE", cidr)
+		// the rule is only appended when it doesn't exist, so that restarting the unit doesn't duplicate it.
+		lines = append(lines, fmt.Sprintf("ExecStart=/bin/sh -c '%[1]s -w -t nat -C POSTROUTING %[2]s || %[1]s -w -t nat -A POSTROUTING %[2]s'",
+			tables, rule))
+	}
+	lines = append(lines,
+		"",
+		"[Install]",
+		"WantedBy=multi-user.target")
+	return strings.Join(lines, "\n") + "\n"
+}
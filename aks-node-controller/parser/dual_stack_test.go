@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateIPFamilies(t *testing.T) {
+	ipv4, ipv6 := aksnodeconfigv1.IPFamily_IP_FAMILY_IPV4, aksnodeconfigv1.IPFamily_IP_FAMILY_IPV6
+	tests := []struct {
+		name    string
+		config  *aksnodeconfigv1.Configuration
+		wantErr string
+	}{
+		{
+			name:   "not set",
+			config: &aksnodeconfigv1.Configuration{Ipv6DualStackEnabled: true},
+		},
+		{
+			name: "dual-stack",
+			config: &aksnodeconfigv1.Configuration{
+				Ipv6DualStackEnabled: true,
+				NetworkConfig: &aksnodeconfigv1.NetworkConfig{
+					IpFamilies:     []aksnodeconfigv1.IPFamily{ipv4, ipv6},
+					SecondaryCidrs: []string{"fd00:10:244::/64"},
+				},
+			},
+		},
+		{
+			name:   "IPv6 only",
+			config: &aksnodeconfigv1.Configuration{NetworkConfig: &aksnodeconfigv1.NetworkConfig{IpFamilies: []aksnodeconfigv1.IPFamily{ipv6}}},
+		},
+		{
+			name:    "same family twice",
+			config:  &aksnodeconfigv1.Configuration{NetworkConfig: &aksnodeconfigv1.NetworkConfig{IpFamilies: []aksnodeconfigv1.IPFamily{ipv4, ipv4}}},
+			wantErr: "ip families [IP_FAMILY_IPV4 IP_FAMILY_IPV4] must be a single family or one of each family",
+		},
+		{
+			name:    "unspecified family",
+			config:  &aksnodeconfigv1.Configuration{NetworkConfig: &aksnodeconfigv1.NetworkConfig{IpFamilies: []aksnodeconfigv1.IPFamily{0}}},
+			wantErr: "invalid ip family IP_FAMILY_UNSPECIFIED",
+		},
+		{
+			name: "single family with the legacy dual-stack flag",
+			config: &aksnodeconfigv1.Configuration{
+				Ipv6DualStackEnabled: true,
+				NetworkConfig:        &aksnodeconfigv1.NetworkConfig{IpFamilies: []aksnodeconfigv1.IPFamily{ipv4}},
+			},
+			wantErr: "ipv6_dual_stack_enabled requires both ip families",
+		},
+		{
+			name: "node IP flag",
+			config: &aksnodeconfigv1.Configuration{
+				NetworkConfig: &aksnodeconfigv1.NetworkConfig{IpFamilies: []aksnodeconfigv1.IPFamily{ipv4}},
+				KubeletConfig: &aksnodeconfigv1.KubeletConfig{KubeletFlags: map[string]string{"--node-ip": "10.224.0.4"}},
+			},
+			wantErr: "ip families can't be combined with --node-ip",
+		},
+		{
+			name:    "secondary CIDRs without ip families",
+			config:  &aksnodeconfigv1.Configuration{NetworkConfig: &aksnodeconfigv1.NetworkConfig{SecondaryCidrs: []string{"fd00:10:244::/64"}}},
+			wantErr: "secondary CIDRs require ip_families",
+		},
+		{
+			name: "secondary CIDRs of a single-stack node",
+			config: &aksnodeconfigv1.Configuration{NetworkConfig: &aksnodeconfigv1.NetworkConfig{
+				IpFamilies:     []aksnodeconfigv1.IPFamily{ipv4},
+				SecondaryCidrs: []string{"fd00:10:244::/64"},
+			}},
+			wantErr: "secondary CIDRs require a dual-stack node",
+		},
+		{
+			name: "secondary CIDR of the primary family",
+			config: &aksnodeconfigv1.Configuration{NetworkConfig: &aksnodeconfigv1.NetworkConfig{
+				IpFamilies:     []aksnodeconfigv1.IPFamily{ipv4, ipv6},
+				SecondaryCidrs: []string{"10.244.0.0/24"},
+			}},
+			wantErr: "secondary CIDR 10.244.0.0/24 isn't of the secondary ip family IP_FAMILY_IPV6",
+		},
+		{
+			name: "invalid secondary CIDR",
+			config: &aksnodeconfigv1.Configuration{NetworkConfig: &aksnodeconfigv1.NetworkConfig{
+				IpFamilies:     []aksnodeconfigv1.IPFamily{ipv4, ipv6},
+				SecondaryCidrs: []string{"fd00:10:244::1/64"},
+			}},
+			wantErr: `invalid secondary CIDR "fd00:10:244::1/64"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIPFamilies(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDualStack(t *testing.T) {
+	assert.False(t, DualStackEnabled(&aksnodeconfigv1.Configuration{}))
+	assert.True(t, DualStackEnabled(&aksnodeconfigv1.Configuration{Ipv6DualStackEnabled: true}))
+
+	config := &aksnodeconfigv1.Configuration{
+		NetworkConfig: &aksnodeconfigv1.NetworkConfig{
+			IpFamilies:     []aksnodeconfigv1.IPFamily{aksnodeconfigv1.IPFamily_IP_FAMILY_IPV6, aksnodeconfigv1.IPFamily_IP_FAMILY_IPV4},
+			SecondaryCidrs: []string{"10.244.0.0/24"},
+		},
+	}
+	assert.True(t, DualStackEnabled(config))
+	assert.Equal(t, "fd00::4,10.224.0.4", kubeletFlags(WithNodeIPs(config, []string{"fd00::4", "10.224.0.4"}))["--node-ip"])
+	assert.Nil(t, config.GetKubeletConfig(), "the configuration isn't modified")
+	assert.Equal(t, `[Unit]
+Description=AKS dual-stack networking
+After=network-online.target
+Before=kubelet.service
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=/sbin/sysctl -w net.ipv4.ip_forward=1
+ExecStart=/sbin/sysctl -w net.ipv6.conf.all.forwarding=1
+ExecStart=/bin/sh -c 'iptables -w -t nat -C POSTROUTING -s 10.244.0.0/24 ! -d 10.244.0.0/24 -j MASQUERADE || iptables -w -t nat -A POSTROUTING -s 10.244.0.0/24 ! -d 10.244.0.0/24 -j MASQUERADE'
+
+[Install]
+WantedBy=multi-user.target
+`, DualStackUnitContent(config))
+}
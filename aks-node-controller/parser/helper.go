@@ -27,6 +27,7 @@ import (
 	"bytes"
 	_ "embed"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
 	"sort"
@@ -37,6 +38,8 @@ import (
 	"github.com/Azure/agentbaker/aks-node-controller/helpers"
 	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
 	"github.com/Azure/agentbaker/pkg/agent"
+	"github.com/Azure/agentbaker/pkg/agent/kubeversion"
+	"google.golang.org/protobuf/proto"
 )
 
 var (
@@ -158,11 +161,12 @@ func containerdConfigFromAKSNodeConfig(aksnodeconfig *aksnodeconfigv1.Configurat
 	}
 
 	var buffer bytes.Buffer
-	if err := containerdConfigTemplate.Execute(&buffer, aksnodeconfig); err != nil {
+	if err := containerdConfigTemplate.Execute(&buffer, containerdTemplateConfig(aksnodeconfig)); err != nil {
 		return "", fmt.Errorf("error executing containerd config template for AKSNodeConfig: %w", err)
 	}
+	buffer.WriteString(runtimeClassesContainerdConfig(aksnodeconfig.GetRuntimeClasses()))
 
-	return buffer.String(), nil
+	return mergeContainerdConfigSnippets(buffer.String(), aksnodeconfig.GetContainerdConfig().GetConfigSnippets())
 }
 
 func getIsMIGNode(gpuInstanceProfile string) bool {
@@ -242,6 +246,22 @@ func getDHCPV6ConfigFilepath() string {
 //gocyclo:ignore
 //nolint:funlen,gocognit,cyclop // This function is long because it has to handle all the sysctl values.
 func getSysctlContent(s *aksnodeconfigv1.SysctlConfig) string {
+	return base64.StdEncoding.EncodeToString([]byte(createSortedKeyValuePairs(getSysctls(s), "\n")))
+}
+
+// getCustomLinuxOsSysctlContent returns the sysctl content of the custom Linux OS config, including the sysctls of
+// the legacy kernel modules hardening.
+func getCustomLinuxOsSysctlContent(osConfig *aksnodeconfigv1.CustomLinuxOsConfig) string {
+	m := getSysctls(osConfig.GetSysctlConfig())
+	if osConfig.GetDisableLegacyKernelModules() {
+		for key, value := range legacyKernelModuleSysctls {
+			m[key] = value
+		}
+	}
+	return base64.StdEncoding.EncodeToString([]byte(createSortedKeyValuePairs(m, "\n")))
+}
+
+func getSysctls(s *aksnodeconfigv1.SysctlConfig) map[string]interface{} {
 	// This is a partial workaround to this upstream Kubernetes issue:
 	// https://github.com/kubernetes/kubernetes/issues/41916#issuecomment-312428731
 
@@ -388,7 +408,11 @@ func getSysctlContent(s *aksnodeconfigv1.SysctlConfig) string {
 		m["vm.vfs_cache_pressure"] = s.GetVmVfsCachePressure()
 	}
 
-	return base64.StdEncoding.EncodeToString([]byte(createSortedKeyValuePairs(m, "\n")))
+	for key, value := range s.GetAdditionalSysctls() {
+		m[key] = value
+	}
+
+	return m
 }
 
 func getShouldConfigContainerdUlimits(u *aksnodeconfigv1.UlimitConfig) bool {
@@ -614,6 +638,90 @@ func getHasDataDir(kubeletConfig *aksnodeconfigv1.KubeletConfig) bool {
 	return kubeletConfig.GetContainerDataDir() != ""
 }
 
+// getKubeletConfigFileContent returns the base64 encoded kubelet config file. When the config file is enabled without
+// content, it is generated from the kubelet flags, which are then left out of the command line.
+func getKubeletConfigFileContent(config *aksnodeconfigv1.Configuration) string {
+	kubeletConfig := config.GetKubeletConfig()
+	if !generatesKubeletConfigFile(kubeletConfig) {
+		if KubeletServingCertRotationEnabled(config) {
+			// a content which can't be decoded is rejected by ValidateKubeletServingCert.
+			if content, err := kubeletConfigFileWithServerTLSBootstrap(kubeletConfig.GetKubeletConfigFileContent()); err == nil {
+				return content
+			}
+		}
+		return kubeletConfig.GetKubeletConfigFileContent()
+	}
+	content := agent.GetKubeletConfigFileContent(kubeletFlags(config), nil)
+	return base64.StdEncoding.EncodeToString([]byte(content))
+}
+
+func generatesKubeletConfigFile(kubeletConfig *aksnodeconfigv1.KubeletConfig) bool {
+	return kubeletConfig.GetEnableKubeletConfigFile() && kubeletConfig.GetKubeletConfigFileContent() == ""
+}
+
+// getKubeletFlags returns the kubelet command line. Flags translated into a generated config file are omitted,
+// so that flags deprecated in newer Kubernetes versions don't reach kubelet.
+func getKubeletFlags(config *aksnodeconfigv1.Configuration) string {
+	kubeletConfig := config.GetKubeletConfig()
+	generated := generatesKubeletConfigFile(kubeletConfig)
+	flags := map[string]string{}
+	for flag, value := range kubeletFlags(config) {
+		if generated && agent.TranslatedKubeletConfigFlags[flag] {
+			continue
+		}
+		flags[flag] = value
+	}
+	if len(KubeletConfigDropIns(config)) > 0 {
+		flags["--config-dir"] = KubeletConfigDropInDir
+	}
+	if taints := getRegisterWithTaints(kubeletConfig); taints != "" {
+		flags["--register-with-taints"] = taints
+	}
+	if HasCredentialProviders(config) {
+		flags["--image-credential-provider-config"] = CredentialProviderConfigFile
+		flags["--image-credential-provider-bin-dir"] = CredentialProviderBinDir
+	}
+	return createSortedKeyValuePairs(flags, " ")
+}
+
+// ValidateKubeletConfigDropIns checks the kubelet config drop-in file names and that kubelet supports --config-dir.
+func ValidateKubeletConfigDropIns(config *aksnodeconfigv1.Configuration) error {
+	dropIns := config.GetKubeletConfig().GetKubeletConfigDropIns()
+	if len(dropIns) == 0 {
+		return nil
+	}
+	supported, err := kubeversion.GreaterOrEqual(config.GetKubernetesVersion(), "1.30.0")
+	if err != nil {
+		return fmt.Errorf("kubelet config drop-ins: %w", err)
+	}
+	if !supported {
+		return fmt.Errorf("kubelet config drop-ins require Kubernetes 1.30 or later, got %q", config.GetKubernetesVersion())
+	}
+	for name := range dropIns {
+		if !strings.HasSuffix(name, ".conf") || strings.ContainsAny(name, "/\\") {
+			return fmt.Errorf("invalid kubelet config drop-in file name %q, it must end with .conf", name)
+		}
+	}
+	return nil
+}
+
+// GetEnableIPForwarding returns whether the NIC forwards traffic, which defaults to true with kubenet as pod traffic
+// is routed to the node.
+func GetEnableIPForwarding(networkConfig *aksnodeconfigv1.NetworkConfig) bool {
+	if networkConfig != nil && networkConfig.EnableIpForwarding != nil {
+		return networkConfig.GetEnableIpForwarding()
+	}
+	return networkConfig.GetNetworkPlugin() == aksnodeconfigv1.NetworkPlugin_NETWORK_PLUGIN_KUBENET
+}
+
+// ValidateNetworkConfig checks the NIC features requested by the network config.
+func ValidateNetworkConfig(networkConfig *aksnodeconfigv1.NetworkConfig) error {
+	if networkConfig.GetNetworkPlugin() == aksnodeconfigv1.NetworkPlugin_NETWORK_PLUGIN_KUBENET && !GetEnableIPForwarding(networkConfig) {
+		return errors.New("kubenet requires IP forwarding on the NIC")
+	}
+	return nil
+}
+
 func getHasKubeletDiskType(kubeletConfig *aksnodeconfigv1.KubeletConfig) bool {
 	return kubeletConfig.GetKubeletDiskType() == aksnodeconfigv1.KubeletDisk_KUBELET_DISK_TEMP_DISK
 }
@@ -632,3 +740,24 @@ func getEnableNvidia(config *aksnodeconfigv1.Configuration) bool {
 	}
 	return false
 }
+
+// containerdTemplateConfig returns the configuration the containerd config template is executed with, which only
+// knows the legacy top-level artifact streaming field and kube_binary_config.pod_infra_container_image_url.
+func containerdTemplateConfig(config *aksnodeconfigv1.Configuration) *aksnodeconfigv1.Configuration {
+	streaming := ArtifactStreamingEnabled(config) && !config.GetEnableArtifactStreaming()
+	sandboxImage := SandboxImage(config) != config.GetKubeBinaryConfig().GetPodInfraContainerImageUrl()
+	if !streaming && !sandboxImage {
+		return config
+	}
+	config = proto.Clone(config).(*aksnodeconfigv1.Configuration)
+	if streaming {
+		config.EnableArtifactStreaming = true
+	}
+	if sandboxImage {
+		if config.KubeBinaryConfig == nil {
+			config.KubeBinaryConfig = &aksnodeconfigv1.KubeBinaryConfig{}
+		}
+		config.KubeBinaryConfig.PodInfraContainerImageUrl = SandboxImage(config)
+	}
+	return config
+}
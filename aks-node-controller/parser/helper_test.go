@@ -19,6 +19,7 @@ package parser
 import (
 	"encoding/base64"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/Azure/agentbaker/aks-node-controller/helpers"
@@ -69,6 +70,25 @@ net.ipv4.neigh.default.gc_thresh1=4096
 net.ipv4.neigh.default.gc_thresh2=8192
 net.ipv4.neigh.default.gc_thresh3=16384
 net.ipv4.tcp_max_syn_backlog=9999
+net.ipv4.tcp_retries2=8`)),
+		},
+		{
+			name: "SysctlConfig with additional sysctls",
+			args: args{
+				s: &aksnodeconfigv1.SysctlConfig{
+					AdditionalSysctls: map[string]string{"net.core.busy_poll": "50", "fs.inotify.max_user_instances": "8192"},
+				},
+			},
+			want: base64.StdEncoding.EncodeToString(
+				[]byte(`fs.inotify.max_user_instances=8192
+net.core.busy_poll=50
+net.core.message_burst=80
+net.core.message_cost=40
+net.core.somaxconn=16384
+net.ipv4.neigh.default.gc_thresh1=4096
+net.ipv4.neigh.default.gc_thresh2=8192
+net.ipv4.neigh.default.gc_thresh3=16384
+net.ipv4.tcp_max_syn_backlog=16384
 net.ipv4.tcp_retries2=8`)),
 		},
 	}
@@ -1012,3 +1032,214 @@ func Test_getIsSgxEnabledSKU(t *testing.T) {
 		})
 	}
 }
+
+func Test_getKubeletFlags(t *testing.T) {
+	flags := map[string]string{
+		"--node-labels":         "a=b",
+		"--max-pods":            "30",
+		"--cloud-provider":      "external",
+		"--rotate-certificates": "true",
+	}
+	tests := []struct {
+		name                string
+		kubeletConfig       *aksnodeconfigv1.KubeletConfig
+		swapConfig          *aksnodeconfigv1.SwapConfig
+		credentialProviders *aksnodeconfigv1.CredentialProviders
+		nodeLocalDNS        *aksnodeconfigv1.NodeLocalDnsConfig
+		want                string
+	}{
+		{
+			name:          "Config file disabled",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{KubeletFlags: flags},
+			want:          "--cloud-provider=external --max-pods=30 --node-labels=a=b --rotate-certificates=true",
+		},
+		{
+			name:          "Generated config file",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{KubeletFlags: flags, EnableKubeletConfigFile: true},
+			want:          "--cloud-provider=external --node-labels=a=b",
+		},
+		{
+			name: "Provided config file content",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{
+				KubeletFlags:             flags,
+				EnableKubeletConfigFile:  true,
+				KubeletConfigFileContent: "Y29udGVudA==",
+			},
+			want: "--cloud-provider=external --max-pods=30 --node-labels=a=b --rotate-certificates=true",
+		},
+		{
+			name: "Drop-ins",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{
+				KubeletFlags:         map[string]string{"--node-labels": "a=b"},
+				KubeletConfigDropIns: map[string]string{"10-max-pods.conf": "maxPods: 50"},
+			},
+			want: "--config-dir=/etc/kubernetes/kubelet.conf.d --node-labels=a=b",
+		},
+		{
+			name:          "Swap drop-in",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{KubeletFlags: map[string]string{"--node-labels": "a=b"}},
+			swapConfig:    &aksnodeconfigv1.SwapConfig{Backend: aksnodeconfigv1.SwapBackend_SWAP_BACKEND_ZRAM, SizeMb: 1024},
+			want:          "--config-dir=/etc/kubernetes/kubelet.conf.d --node-labels=a=b",
+		},
+		{
+			name: "Taints",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{
+				KubeletFlags:  map[string]string{"--max-pods": "30"},
+				Taints:        []*aksnodeconfigv1.Taint{{Key: "sku", Value: "gpu", Effect: "NoSchedule"}},
+				StartupTaints: []*aksnodeconfigv1.Taint{{Key: "example.com/initializing", Effect: "NoExecute"}},
+			},
+			want: "--max-pods=30 --register-with-taints=example.com/initializing:NoExecute,sku=gpu:NoSchedule",
+		},
+		{
+			name:          "Credential providers",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{KubeletFlags: map[string]string{"--max-pods": "30"}},
+			credentialProviders: &aksnodeconfigv1.CredentialProviders{
+				Providers: []*aksnodeconfigv1.CredentialProvider{{Type: aksnodeconfigv1.CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_ACR}},
+			},
+			want: "--image-credential-provider-bin-dir=/var/lib/kubelet/credential-provider --image-credential-provider-config=/etc/kubernetes/aks-credential-provider-config.yaml --max-pods=30",
+		},
+		{
+			name:          "Node local DNS",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{KubeletFlags: map[string]string{"--cluster-dns": "10.0.0.10"}},
+			nodeLocalDNS:  &aksnodeconfigv1.NodeLocalDnsConfig{Enabled: true, LocalIp: "169.254.25.10"},
+			want:          "--cluster-dns=169.254.25.10",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &aksnodeconfigv1.Configuration{
+				KubeletConfig:       tt.kubeletConfig,
+				CustomLinuxOsConfig: &aksnodeconfigv1.CustomLinuxOsConfig{SwapConfig: tt.swapConfig},
+				CredentialProviders: tt.credentialProviders,
+				NetworkConfig:       &aksnodeconfigv1.NetworkConfig{NodeLocalDnsConfig: tt.nodeLocalDNS},
+			}
+			if got := getKubeletFlags(config); got != tt.want {
+				t.Errorf("getKubeletFlags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getKubeletConfigFileContent(t *testing.T) {
+	kubeletConfig := &aksnodeconfigv1.KubeletConfig{
+		KubeletFlags:            map[string]string{"--max-pods": "30", "--node-labels": "a=b", "--cluster-dns": "10.0.0.10"},
+		EnableKubeletConfigFile: true,
+	}
+	config := &aksnodeconfigv1.Configuration{KubeletConfig: kubeletConfig}
+	decoded, err := base64.StdEncoding.DecodeString(getKubeletConfigFileContent(config))
+	if err != nil {
+		t.Fatalf("getKubeletConfigFileContent() is not base64: %v", err)
+	}
+	if !strings.Contains(string(decoded), `"kind": "KubeletConfiguration"`) || !strings.Contains(string(decoded), `"maxPods": 30`) {
+		t.Errorf("getKubeletConfigFileContent() = %s, want a KubeletConfiguration with maxPods 30", decoded)
+	}
+
+	config.NetworkConfig = &aksnodeconfigv1.NetworkConfig{NodeLocalDnsConfig: &aksnodeconfigv1.NodeLocalDnsConfig{Enabled: true}}
+	decoded, err = base64.StdEncoding.DecodeString(getKubeletConfigFileContent(config))
+	if err != nil {
+		t.Fatalf("getKubeletConfigFileContent() is not base64: %v", err)
+	}
+	if !strings.Contains(string(decoded), `"169.254.20.10"`) || strings.Contains(string(decoded), "10.0.0.10") {
+		t.Errorf("getKubeletConfigFileContent() = %s, want the node local DNS address as cluster DNS", decoded)
+	}
+
+	kubeletConfig.KubeletConfigFileContent = "Y29udGVudA=="
+	if got := getKubeletConfigFileContent(config); got != "Y29udGVudA==" {
+		t.Errorf("getKubeletConfigFileContent() = %v, want the provided content", got)
+	}
+}
+
+func TestValidateKubeletConfigDropIns(t *testing.T) {
+	tests := []struct {
+		name              string
+		kubernetesVersion string
+		dropIns           map[string]string
+		wantErr           bool
+	}{
+		{
+			name:              "No drop-ins",
+			kubernetesVersion: "1.29.0",
+		},
+		{
+			name:              "Valid drop-in",
+			kubernetesVersion: "1.30.2",
+			dropIns:           map[string]string{"10-max-pods.conf": "maxPods: 50"},
+		},
+		{
+			name:              "Kubernetes version too old",
+			kubernetesVersion: "1.29.5",
+			dropIns:           map[string]string{"10-max-pods.conf": "maxPods: 50"},
+			wantErr:           true,
+		},
+		{
+			name:              "Missing .conf suffix",
+			kubernetesVersion: "1.30.2",
+			dropIns:           map[string]string{"max-pods.yaml": "maxPods: 50"},
+			wantErr:           true,
+		},
+		{
+			name:              "Path in name",
+			kubernetesVersion: "1.30.2",
+			dropIns:           map[string]string{"../kubelet.conf": "maxPods: 50"},
+			wantErr:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &aksnodeconfigv1.Configuration{
+				KubernetesVersion: tt.kubernetesVersion,
+				KubeletConfig:     &aksnodeconfigv1.KubeletConfig{KubeletConfigDropIns: tt.dropIns},
+			}
+			if err := ValidateKubeletConfigDropIns(config); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateKubeletConfigDropIns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateNetworkConfig(t *testing.T) {
+	tests := []struct {
+		name             string
+		networkConfig    *aksnodeconfigv1.NetworkConfig
+		wantIPForwarding bool
+		wantErr          bool
+	}{
+		{
+			name:             "Nil network config",
+			networkConfig:    nil,
+			wantIPForwarding: false,
+		},
+		{
+			name:             "Kubenet defaults to IP forwarding",
+			networkConfig:    &aksnodeconfigv1.NetworkConfig{NetworkPlugin: aksnodeconfigv1.NetworkPlugin_NETWORK_PLUGIN_KUBENET},
+			wantIPForwarding: true,
+		},
+		{
+			name: "Kubenet without IP forwarding",
+			networkConfig: &aksnodeconfigv1.NetworkConfig{
+				NetworkPlugin:      aksnodeconfigv1.NetworkPlugin_NETWORK_PLUGIN_KUBENET,
+				EnableIpForwarding: ToPtr(false),
+			},
+			wantIPForwarding: false,
+			wantErr:          true,
+		},
+		{
+			name: "Azure CNI with IP forwarding",
+			networkConfig: &aksnodeconfigv1.NetworkConfig{
+				NetworkPlugin:      aksnodeconfigv1.NetworkPlugin_NETWORK_PLUGIN_AZURE,
+				EnableIpForwarding: ToPtr(true),
+			},
+			wantIPForwarding: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetEnableIPForwarding(tt.networkConfig); got != tt.wantIPForwarding {
+				t.Errorf("GetEnableIPForwarding() = %v, want %v", got, tt.wantIPForwarding)
+			}
+			if err := ValidateNetworkConfig(tt.networkConfig); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateNetworkConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
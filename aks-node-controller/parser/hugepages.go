@@ -0,0 +1,158 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// HugepagesUnit allocates the hugepages of a runtime allocation on every boot, before kubelet starts.
+const HugepagesUnit = "aks-hugepages.service"
+
+// memoryUnits are the multipliers of the kubelet memory quantity suffixes.
+var memoryUnits = map[string]int64{
+	"":   1,
+	"k":  1000,
+	"M":  1000 * 1000,
+	"G":  1000 * 1000 * 1000,
+	"T":  1000 * 1000 * 1000 * 1000,
+	"Ki": 1 << 10,
+	"Mi": 1 << 20,
+	"Gi": 1 << 30,
+	"Ti": 1 << 40,
+}
+
+// HasHugepagesConfig returns whether provisioning preallocates hugepages.
+func HasHugepagesConfig(config *aksnodeconfigv1.Configuration) bool {
+	return config.GetCustomLinuxOsConfig().GetHugepagesConfig().GetCount() > 0
+}
+
+// HugepagesBootAllocation returns whether the hugepages are reserved by the kernel command line.
+func HugepagesBootAllocation(config *aksnodeconfigv1.Configuration) bool {
+	return config.GetCustomLinuxOsConfig().GetHugepagesConfig().GetAllocation() == aksnodeconfigv1.HugepagesAllocation_HUGEPAGES_ALLOCATION_BOOT
+}
+
+// HugepageSizeKB returns the size of the hugepages in kB, the unit of /sys/kernel/mm/hugepages.
+func HugepageSizeKB(config *aksnodeconfigv1.Configuration) int64 {
+	if config.GetCustomLinuxOsConfig().GetHugepagesConfig().GetPageSize() == aksnodeconfigv1.HugepageSize_HUGEPAGE_SIZE_1GI {
+		return 1 << 20
+	}
+	return 2 << 10
+}
+
+// HugepagesBytes returns the memory the hugepages take, which is no longer available to regular allocations.
+func HugepagesBytes(config *aksnodeconfigv1.Configuration) int64 {
+	return int64(config.GetCustomLinuxOsConfig().GetHugepagesConfig().GetCount()) * HugepageSizeKB(config) << 10
+}
+
+// ValidateHugepagesConfig checks the hugepages config.
+func ValidateHugepagesConfig(config *aksnodeconfigv1.Configuration) error {
+	hugepagesConfig := config.GetCustomLinuxOsConfig().GetHugepagesConfig()
+	if hugepagesConfig.GetCount() < 0 {
+		return errors.New("hugepages count can't be negative")
+	}
+	if _, ok := aksnodeconfigv1.HugepageSize_name[int32(hugepagesConfig.GetPageSize())]; !ok {
+		return fmt.Errorf("unknown hugepage size %d", hugepagesConfig.GetPageSize())
+	}
+	if _, ok := aksnodeconfigv1.HugepagesAllocation_name[int32(hugepagesConfig.GetAllocation())]; !ok {
+		return fmt.Errorf("unknown hugepages allocation %d", hugepagesConfig.GetAllocation())
+	}
+	_, err := reservedMemoryBytes(config, 0)
+	return err
+}
+
+// ValidateHugepagesFit checks that the memory of the node, memTotal bytes, holds the hugepages and still leaves the
+// memory kubelet reserves for the system, itself and the hard eviction threshold.
+func ValidateHugepagesFit(config *aksnodeconfigv1.Configuration, memTotal int64) error {
+	reserved, err := reservedMemoryBytes(config, memTotal)
+	if err != nil {
+		return err
+	}
+	if hugepages := HugepagesBytes(config); hugepages+reserved >= memTotal {
+		return fmt.Errorf("%d hugepages of %dkB (%dMi) and the %dMi kubelet reserves don't fit in the %dMi of the node",
+			config.GetCustomLinuxOsConfig().GetHugepagesConfig().GetCount(), HugepageSizeKB(config), hugepages>>20, reserved>>20, memTotal>>20)
+	}
+	return nil
+}
+
+// HugepagesKernelArgs returns the kernel command line arguments reserving the hugepages at boot.
+func HugepagesKernelArgs(config *aksnodeconfigv1.Configuration) string {
+	size := "2M"
+	if HugepageSizeKB(config) == 1<<20 {
+		size = "1G"
+	}
+	return fmt.Sprintf("hugepagesz=%s hugepages=%d", size, config.GetCustomLinuxOsConfig().GetHugepagesConfig().GetCount())
+}
+
+// HugepagesGrubDropIn returns the /etc/default/grub.d drop-in adding the hugepages arguments to the kernel command line.
+func HugepagesGrubDropIn(config *aksnodeconfigv1.Configuration) string {
+	return fmt.Sprintf("GRUB_CMDLINE_LINUX_DEFAULT=\"$GRUB_CMDLINE_LINUX_DEFAULT %s\"\n", HugepagesKernelArgs(config))
+}
+
+// HugepagesUnitContent returns the systemd unit allocating the hugepages on every boot.
+func HugepagesUnitContent(config *aksnodeconfigv1.Configuration) string {
+	return strings.Join([]string{
+		"[Unit]",
+		"Description=AKS hugepages",
+		"Before=kubelet.service",
+		"",
+		"[Service]",
+		"Type=oneshot",
+		"RemainAfterExit=yes",
+		fmt.Sprintf("ExecStart=/bin/sh -c 'echo %d > /sys/kernel/mm/hugepages/hugepages-%dkB/nr_hugepages'",
+			config.GetCustomLinuxOsConfig().GetHugepagesConfig().GetCount(), HugepageSizeKB(config)),
+		"",
+		"[Install]",
+		"WantedBy=multi-user.target",
+	}, "\n") + "\n"
+}
+
+// reservedMemoryBytes returns the memory the kubelet flags reserve: --kube-reserved, --system-reserved and the
+// memory.available threshold of --eviction-hard, a percentage of memTotal.
+func reservedMemoryBytes(config *aksnodeconfigv1.Configuration, memTotal int64) (int64, error) {
+	flags := config.GetKubeletConfig().GetKubeletFlags()
+	var reserved int64
+	for _, flag := range []string{"--kube-reserved", "--system-reserved"} {
+		for _, pair := range strings.Split(flags[flag], ",") {
+			if name, value, _ := strings.Cut(pair, "="); name == "memory" {
+				bytes, err := parseMemoryQuantity(value)
+				if err != nil {
+					return 0, fmt.Errorf("invalid memory of %s: %w", flag, err)
+				}
+				reserved += bytes
+			}
+		}
+	}
+	for _, signal := range strings.Split(flags["--eviction-hard"], ",") {
+		if name, value, _ := strings.Cut(signal, "<"); name == "memory.available" {
+			if percent, ok := strings.CutSuffix(value, "%"); ok {
+				p, err := strconv.ParseFloat(percent, 64)
+				if err != nil {
+					return 0, fmt.Errorf("invalid memory.available of --eviction-hard %q", value)
+				}
+				reserved += int64(float64(memTotal) * p / 100)
+				continue
+			}
+			bytes, err := parseMemoryQuantity(value)
+			if err != nil {
+				return 0, fmt.Errorf("invalid memory.available of --eviction-hard: %w", err)
+			}
+			reserved += bytes
+		}
+	}
+	return reserved, nil
+}
+
+// parseMemoryQuantity parses a kubelet memory quantity such as 750Mi or 1G into bytes.
+func parseMemoryQuantity(quantity string) (int64, error) {
+	number := strings.TrimRight(quantity, "kMGTi")
+	multiplier, ok := memoryUnits[quantity[len(number):]]
+	value, err := strconv.ParseInt(number, 10, 64)
+	if !ok || err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid memory quantity %q", quantity)
+	}
+	return value * multiplier, nil
+}
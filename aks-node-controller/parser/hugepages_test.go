@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func hugepagesConfig(size aksnodeconfigv1.HugepageSize, count int32, flags map[string]string) *aksnodeconfigv1.Configuration {
+	return &aksnodeconfigv1.Configuration{
+		CustomLinuxOsConfig: &aksnodeconfigv1.CustomLinuxOsConfig{
+			HugepagesConfig: &aksnodeconfigv1.HugepagesConfig{PageSize: size, Count: count},
+		},
+		KubeletConfig: &aksnodeconfigv1.KubeletConfig{KubeletFlags: flags},
+	}
+}
+
+func TestValidateHugepagesFit(t *testing.T) {
+	reserved := map[string]string{
+		"--kube-reserved":   "cpu=100m,memory=1638Mi",
+		"--system-reserved": "memory=512Mi",
+		"--eviction-hard":   "memory.available<750Mi,nodefs.available<10%",
+	}
+	memTotal := int64(8 << 30)
+	tests := []struct {
+		name    string
+		config  *aksnodeconfigv1.Configuration
+		wantErr string
+	}{
+		{
+			name:   "2Mi pages fit",
+			config: hugepagesConfig(aksnodeconfigv1.HugepageSize_HUGEPAGE_SIZE_UNSPECIFIED, 1024, reserved),
+		},
+		{
+			name:    "1Gi pages don't fit with the kubelet reservations",
+			config:  hugepagesConfig(aksnodeconfigv1.HugepageSize_HUGEPAGE_SIZE_1GI, 6, reserved),
+			wantErr: "6 hugepages of 1048576kB (6144Mi) and the 2900Mi kubelet reserves don't fit in the 8192Mi of the node",
+		},
+		{
+			name:    "percentage eviction threshold",
+			config:  hugepagesConfig(aksnodeconfigv1.HugepageSize_HUGEPAGE_SIZE_1GI, 8, map[string]string{"--eviction-hard": "memory.available<5%"}),
+			wantErr: "8 hugepages of 1048576kB (8192Mi) and the 409Mi kubelet reserves don't fit in the 8192Mi of the node",
+		},
+		{
+			name:    "invalid reservation",
+			config:  hugepagesConfig(aksnodeconfigv1.HugepageSize_HUGEPAGE_SIZE_2MI, 1, map[string]string{"--kube-reserved": "memory=1.5Gi"}),
+			wantErr: `invalid memory of --kube-reserved: invalid memory quantity "1.5Gi"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHugepagesFit(tt.config, memTotal)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateHugepagesConfig(t *testing.T) {
+	assert.NoError(t, ValidateHugepagesConfig(&aksnodeconfigv1.Configuration{}))
+	assert.EqualError(t, ValidateHugepagesConfig(hugepagesConfig(aksnodeconfigv1.HugepageSize_HUGEPAGE_SIZE_2MI, -1, nil)),
+		"hugepages count can't be negative")
+	assert.EqualError(t, ValidateHugepagesConfig(hugepagesConfig(7, 1, nil)), "unknown hugepage size 7")
+}
+
+func TestHugepagesKernelArgs(t *testing.T) {
+	config := hugepagesConfig(aksnodeconfigv1.HugepageSize_HUGEPAGE_SIZE_1GI, 4, nil)
+	assert.Equal(t, "hugepagesz=1G hugepages=4", HugepagesKernelArgs(config))
+	assert.Equal(t, "GRUB_CMDLINE_LINUX_DEFAULT=\"$GRUB_CMDLINE_LINUX_DEFAULT hugepagesz=1G hugepages=4\"\n", HugepagesGrubDropIn(config))
+	assert.Contains(t, HugepagesUnitContent(config), "ExecStart=/bin/sh -c 'echo 4 > /sys/kernel/mm/hugepages/hugepages-1048576kB/nr_hugepages'")
+	assert.Equal(t, "hugepagesz=2M hugepages=512", HugepagesKernelArgs(hugepagesConfig(aksnodeconfigv1.HugepageSize_HUGEPAGE_SIZE_2MI, 512, nil)))
+}
@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+)
+
+// infiniBandKernelModules are the modules of the MOFED drivers the RDMA workloads use on top of the mlx5 driver:
+// IP over InfiniBand, the user space MAD access of the subnet manager queries and the RDMA connection manager.
+var infiniBandKernelModules = []string{"ib_ipoib", "ib_umad", "rdma_ucm"}
+
+// HasInfiniBand returns whether provisioning sets up the InfiniBand NIC of the node.
+func HasInfiniBand(config *aksnodeconfigv1.Configuration) bool {
+	return config.GetNetworkConfig().GetEnableInfiniband() && !IsWindows(config)
+}
+
+// ValidateInfiniBand checks that InfiniBand is only requested on Linux nodes whose VM size has an InfiniBand NIC.
+func ValidateInfiniBand(config *aksnodeconfigv1.Configuration) error {
+	networkConfig := config.GetNetworkConfig()
+	if !networkConfig.GetEnableInfiniband() {
+		if networkConfig.GetMofedPackageUrl() != "" {
+			return errors.New("mofed package URL requires enable_infiniband")
+		}
+		return nil
+	}
+	if IsWindows(config) {
+		return errors.New("InfiniBand isn't supported on Windows nodes")
+	}
+	if !datamodel.IsRDMACapableSKU(config.GetVmSize()) {
+		return fmt.Errorf("VM size %q doesn't have an InfiniBand NIC", config.GetVmSize())
+	}
+	if url := networkConfig.GetMofedPackageUrl(); url != "" && !strings.HasSuffix(url, ".tgz") && !strings.HasSuffix(url, ".tar.gz") {
+		return fmt.Errorf("mofed package URL %q must be a tar.gz archive", url)
+	}
+	return nil
+}
+
+// InfiniBandKernelModules returns the kernel modules loaded on InfiniBand nodes.
+func InfiniBandKernelModules(config *aksnodeconfigv1.Configuration) []string {
+	if !HasInfiniBand(config) {
+		return nil
+	}
+	return infiniBandKernelModules
+}
+
+// InfiniBandModulesLoadContent returns the modules-load.d file loading the InfiniBand modules on boot.
+func InfiniBandModulesLoadContent(config *aksnodeconfigv1.Configuration) string {
+	return strings.Join(InfiniBandKernelModules(config), "\n") + "\n"
+}
@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateInfiniBand(t *testing.T) {
+	config := func(vmSize string, enabled bool, mofedPackageURL string) *aksnodeconfigv1.Configuration {
+		return &aksnodeconfigv1.Configuration{
+			VmSize:        vmSize,
+			NetworkConfig: &aksnodeconfigv1.NetworkConfig{EnableInfiniband: enabled, MofedPackageUrl: mofedPackageURL},
+		}
+	}
+	assert.NoError(t, ValidateInfiniBand(&aksnodeconfigv1.Configuration{}))
+	assert.NoError(t, ValidateInfiniBand(config("Standard_HB120rs_v3", true, "")))
+	assert.NoError(t, ValidateInfiniBand(config("Standard_ND96isr_H100_v5", true, "https://example.com/MLNX_OFED_LINUX-23.10.tgz")))
+	assert.EqualError(t, ValidateInfiniBand(config("Standard_HB120rs_v3", false, "https://example.com/mofed.tgz")),
+		"mofed package URL requires enable_infiniband")
+	assert.EqualError(t, ValidateInfiniBand(config("Standard_NC24ads_A100_v4", true, "")),
+		`VM size "Standard_NC24ads_A100_v4" doesn't have an InfiniBand NIC`)
+	assert.EqualError(t, ValidateInfiniBand(config("Standard_HB120rs_v3", true, "https://example.com/mofed.iso")),
+		`mofed package URL "https://example.com/mofed.iso" must be a tar.gz archive`)
+
+	windows := config("Standard_HB120rs_v3", true, "")
+	windows.WindowsConfig = &aksnodeconfigv1.WindowsConfig{}
+	assert.EqualError(t, ValidateInfiniBand(windows), "InfiniBand isn't supported on Windows nodes")
+}
+
+func TestInfiniBandKernelModules(t *testing.T) {
+	config := &aksnodeconfigv1.Configuration{
+		VmSize:        "Standard_HB120rs_v3",
+		NetworkConfig: &aksnodeconfigv1.NetworkConfig{EnableInfiniband: true},
+	}
+	assert.True(t, HasInfiniBand(config))
+	assert.Equal(t, []string{"ib_ipoib", "ib_umad", "rdma_ucm"}, InfiniBandKernelModules(config))
+	assert.Equal(t, "ib_ipoib\nib_umad\nrdma_ucm\n", InfiniBandModulesLoadContent(config))
+
+	assert.False(t, HasInfiniBand(&aksnodeconfigv1.Configuration{VmSize: "Standard_HB120rs_v3"}))
+	assert.Empty(t, InfiniBandKernelModules(&aksnodeconfigv1.Configuration{}))
+}
@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// KubeletServingCertRotationEnabled returns whether kubelet requests its serving certificate from the API server,
+// enabled by the serving cert config or the legacy --rotate-server-certificates flag.
+func KubeletServingCertRotationEnabled(config *aksnodeconfigv1.Configuration) bool {
+	kubeletConfig := config.GetKubeletConfig()
+	return kubeletConfig.GetServingCertConfig().GetServerTlsBootstrap() || kubeletConfig.GetKubeletFlags()["--rotate-server-certificates"] == "true"
+}
+
+// ValidateKubeletServingCert checks the required SANs of the kubelet serving certificate, which are only checked on a
+// certificate issued by the API server, and that a provided kubelet config file can enable serverTLSBootstrap.
+func ValidateKubeletServingCert(config *aksnodeconfigv1.Configuration) error {
+	servingCertConfig := config.GetKubeletConfig().GetServingCertConfig()
+	if len(servingCertConfig.GetRequiredSans()) > 0 && !KubeletServingCertRotationEnabled(config) {
+		return errors.New("required SANs need server_tls_bootstrap, the self-signed certificate isn't checked")
+	}
+	for _, san := range servingCertConfig.GetRequiredSans() {
+		if net.ParseIP(san) == nil && !dnsSubdomainRegex.MatchString(san) {
+			return fmt.Errorf("invalid required SAN %q, it must be a DNS name or an IP address", san)
+		}
+	}
+	if KubeletServingCertRotationEnabled(config) {
+		if _, err := kubeletConfigFileWithServerTLSBootstrap(config.GetKubeletConfig().GetKubeletConfigFileContent()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kubeletServingCertFlags applies the serving certificate rotation to the kubelet flags: the self-signed certificate
+// flags take precedence over --rotate-server-certificates and are removed.
+func kubeletServingCertFlags(config *aksnodeconfigv1.Configuration, flags map[string]string) {
+	if !KubeletServingCertRotationEnabled(config) {
+		return
+	}
+	flags["--rotate-server-certificates"] = "true"
+	delete(flags, "--tls-cert-file")
+	delete(flags, "--tls-private-key-file")
+}
+
+// kubeletConfigFileWithServerTLSBootstrap returns the base64 encoded kubelet config file content with serverTLSBootstrap
+// enabled and the self-signed certificate files removed. An empty content is returned as is.
+func kubeletConfigFileWithServerTLSBootstrap(content string) (string, error) {
+	if content == "" {
+		return "", nil
+	}
+	data, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		return "", fmt.Errorf("decode kubelet config file content: %w", err)
+	}
+	var kubeletConfigFile map[string]any
+	if err := json.Unmarshal(data, &kubeletConfigFile); err != nil {
+		return "", fmt.Errorf("enable serverTLSBootstrap in the kubelet config file: %w", err)
+	}
+	kubeletConfigFile["serverTLSBootstrap"] = true
+	delete(kubeletConfigFile, "tlsCertFile")
+	delete(kubeletConfigFile, "tlsPrivateKeyFile")
+	data, err = json.MarshalIndent(kubeletConfigFile, "", "    ")
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
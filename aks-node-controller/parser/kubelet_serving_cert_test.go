@@ -0,0 +1,87 @@
+package parser
+
+import (
+	"encoding/base64"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateKubeletServingCert(t *testing.T) {
+	tests := []struct {
+		name          string
+		kubeletConfig *aksnodeconfigv1.KubeletConfig
+		wantErr       string
+	}{
+		{
+			name: "not enabled",
+		},
+		{
+			name: "required SANs",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{
+				ServingCertConfig: &aksnodeconfigv1.KubeletServingCertConfig{ServerTlsBootstrap: true, RequiredSans: []string{"node1", "10.224.0.4", "fd00::4"}},
+			},
+		},
+		{
+			name: "legacy flag",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{
+				KubeletFlags:      map[string]string{"--rotate-server-certificates": "true"},
+				ServingCertConfig: &aksnodeconfigv1.KubeletServingCertConfig{RequiredSans: []string{"node1"}},
+			},
+		},
+		{
+			name: "required SANs without server TLS bootstrap",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{
+				ServingCertConfig: &aksnodeconfigv1.KubeletServingCertConfig{RequiredSans: []string{"node1"}},
+			},
+			wantErr: "required SANs need server_tls_bootstrap, the self-signed certificate isn't checked",
+		},
+		{
+			name: "invalid SAN",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{
+				ServingCertConfig: &aksnodeconfigv1.KubeletServingCertConfig{ServerTlsBootstrap: true, RequiredSans: []string{"Node_1"}},
+			},
+			wantErr: `invalid required SAN "Node_1", it must be a DNS name or an IP address`,
+		},
+		{
+			name: "invalid kubelet config file",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{
+				KubeletConfigFileContent: base64.StdEncoding.EncodeToString([]byte("kind: KubeletConfiguration")),
+				ServingCertConfig:        &aksnodeconfigv1.KubeletServingCertConfig{ServerTlsBootstrap: true},
+			},
+			wantErr: "enable serverTLSBootstrap in the kubelet config file: invalid character 'k' looking for beginning of value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateKubeletServingCert(&aksnodeconfigv1.Configuration{KubeletConfig: tt.kubeletConfig})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKubeletServingCertRotation(t *testing.T) {
+	config := &aksnodeconfigv1.Configuration{
+		KubeletConfig: &aksnodeconfigv1.KubeletConfig{
+			KubeletFlags: map[string]string{
+				"--tls-cert-file":        "/etc/kubernetes/certs/kubeletserver.crt",
+				"--tls-private-key-file": "/etc/kubernetes/certs/kubeletserver.key",
+				"--max-pods":             "30",
+			},
+			ServingCertConfig: &aksnodeconfigv1.KubeletServingCertConfig{ServerTlsBootstrap: true},
+		},
+	}
+	assert.Equal(t, "--max-pods=30 --rotate-server-certificates=true", getKubeletFlags(config))
+
+	config.KubeletConfig.KubeletConfigFileContent = base64.StdEncoding.EncodeToString([]byte(
+		`{"kind": "KubeletConfiguration", "tlsCertFile": "/etc/kubernetes/certs/kubeletserver.crt", "tlsPrivateKeyFile": "/etc/kubernetes/certs/kubeletserver.key"}`))
+	content, err := base64.StdEncoding.DecodeString(getKubeletConfigFileContent(config))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"kind": "KubeletConfiguration", "serverTLSBootstrap": true}`, string(content))
+}
@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"errors"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// imaPolicy measures the executables, the executable mappings of the libraries and the kernel modules into the vTPM,
+// without appraising them. The pseudo filesystems and the overlay filesystems of the container layers aren't
+// measured, the log of a node running pods would grow with every container.
+const imaPolicy = `dont_measure fsmagic=0x9fa0
+dont_measure fsmagic=0x62656572
+dont_measure fsmagic=0x64626720
+dont_measure fsmagic=0x1021994
+dont_measure fsmagic=0x1cd1
+dont_measure fsmagic=0x42494e4d
+dont_measure fsmagic=0x73636673
+dont_measure fsmagic=0xf97cff8c
+dont_measure fsmagic=0x27e0eb
+dont_measure fsmagic=0x63677270
+dont_measure fsmagic=0x6e736673
+dont_measure fsmagic=0x794c7630
+measure func=BPRM_CHECK mask=MAY_EXEC
+measure func=FILE_MMAP mask=MAY_EXEC
+measure func=MODULE_CHECK
+`
+
+// HasMeasuredBoot returns whether provisioning loads the IMA policy measuring what the node runs into the vTPM.
+func HasMeasuredBoot(config *aksnodeconfigv1.Configuration) bool {
+	return config.GetVmSecurityConfig().GetEnableMeasuredBoot() && !IsWindows(config)
+}
+
+// ValidateVMSecurityConfig checks that measured boot has a vTPM to measure into.
+func ValidateVMSecurityConfig(config *aksnodeconfigv1.Configuration) error {
+	vmSecurityConfig := config.GetVmSecurityConfig()
+	if !vmSecurityConfig.GetEnableMeasuredBoot() {
+		return nil
+	}
+	if IsWindows(config) {
+		return errors.New("measured boot isn't supported on Windows nodes")
+	}
+	if !vmSecurityConfig.GetVtpmEnabled() {
+		return errors.New("measured boot requires vtpm_enabled")
+	}
+	return nil
+}
+
+// IMAPolicy returns the IMA policy of measured boot.
+func IMAPolicy() string {
+	return imaPolicy
+}
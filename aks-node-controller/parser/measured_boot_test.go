@@ -0,0 +1,35 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateVMSecurityConfig(t *testing.T) {
+	config := func(vtpm, measuredBoot bool) *aksnodeconfigv1.Configuration {
+		return &aksnodeconfigv1.Configuration{
+			VmSecurityConfig: &aksnodeconfigv1.VmSecurityConfig{SecureBootEnabled: true, VtpmEnabled: vtpm, EnableMeasuredBoot: measuredBoot},
+		}
+	}
+	assert.NoError(t, ValidateVMSecurityConfig(&aksnodeconfigv1.Configuration{}))
+	assert.NoError(t, ValidateVMSecurityConfig(config(false, false)))
+	assert.NoError(t, ValidateVMSecurityConfig(config(true, true)))
+	assert.True(t, HasMeasuredBoot(config(true, true)))
+	assert.EqualError(t, ValidateVMSecurityConfig(config(false, true)), "measured boot requires vtpm_enabled")
+
+	windows := config(true, true)
+	windows.WindowsConfig = &aksnodeconfigv1.WindowsConfig{}
+	assert.EqualError(t, ValidateVMSecurityConfig(windows), "measured boot isn't supported on Windows nodes")
+	assert.False(t, HasMeasuredBoot(windows))
+}
+
+func TestIMAPolicy(t *testing.T) {
+	for _, rule := range strings.Split(strings.TrimSuffix(IMAPolicy(), "\n"), "\n") {
+		// the kernel rejects the whole policy on a rule it can't parse.
+		assert.Regexp(t, `^(dont_measure fsmagic=0x[0-9a-f]+|measure func=[A-Z_]+( mask=MAY_EXEC)?)$`, rule)
+	}
+	assert.Contains(t, IMAPolicy(), "measure func=BPRM_CHECK mask=MAY_EXEC\n")
+}
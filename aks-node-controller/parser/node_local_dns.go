@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+const (
+	// NodeLocalDNSUnit sets up the interface and the iptables rules of the NodeLocal DNSCache on every boot.
+	NodeLocalDNSUnit = "aks-node-local-dns.service"
+
+	nodeLocalDNSInterface = "nodelocaldns"
+	defaultNodeLocalDNSIP = "169.254.20.10"
+)
+
+var linkLocalPrefix = netip.MustParsePrefix("169.254.0.0/16")
+
+// NodeLocalDNSEnabled returns whether provisioning sets up the NodeLocal DNSCache.
+func NodeLocalDNSEnabled(config *aksnodeconfigv1.Configuration) bool {
+	return config.GetNetworkConfig().GetNodeLocalDnsConfig().GetEnabled()
+}
+
+// NodeLocalDNSIP returns the address the NodeLocal DNSCache listens on, which pods use as their DNS server.
+func NodeLocalDNSIP(config *aksnodeconfigv1.Configuration) string {
+	if ip := config.GetNetworkConfig().GetNodeLocalDnsConfig().GetLocalIp(); ip != "" {
+		return ip
+	}
+	return defaultNodeLocalDNSIP
+}
+
+// ValidateNodeLocalDNS checks that the NodeLocal DNSCache listens on a link-local IPv4 address, which is never routed
+// off the node.
+func ValidateNodeLocalDNS(config *aksnodeconfigv1.Configuration) error {
+	if !NodeLocalDNSEnabled(config) {
+		return nil
+	}
+	ip, err := netip.ParseAddr(NodeLocalDNSIP(config))
+	if err != nil || !linkLocalPrefix.Contains(ip) {
+		return fmt.Errorf("node local DNS address %q must be a link-local IPv4 address", NodeLocalDNSIP(config))
+	}
+	return nil
+}
+
+// kubeletFlags returns the kubelet flags of the configuration with the ones overridden by other fields applied.
+func kubeletFlags(config *aksnodeconfigv1.Configuration) map[string]string {
+	flags := map[string]string{}
+	for flag, value := range config.GetKubeletConfig().GetKubeletFlags() {
+		flags[flag] = value
+	}
+	if NodeLocalDNSEnabled(config) {
+		flags["--cluster-dns"] = NodeLocalDNSIP(config)
+	}
+	kubeletServingCertFlags(config, flags)
+	resourceManagerFlags(config, flags)
+	if _, ok := flags["--pod-infra-container-image"]; ok {
+		// kubelet excludes the image from its garbage collection.
+		flags["--pod-infra-container-image"] = SandboxImage(config)
+	}
+	return flags
+}
+
+// NodeLocalDNSUnitContent returns the systemd unit creating the dummy interface of the NodeLocal DNSCache and the
+// iptables rules which exempt its traffic from connection tracking, the rules the node-local-dns DaemonSet sets up in
+// iptables mode.
+func NodeLocalDNSUnitContent(config *aksnodeconfigv1.Configuration) string {
+	ip := NodeLocalDNSIP(config)
+	lines := []string{
+		"[Unit]",
+		"Description=AKS NodeLocal DNSCache interface",
+		"After=network-online.target",
+		"Before=kubelet.service",
+		"",
+		"[Service]",
+		"Type=oneshot",
+		"RemainAfterExit=yes",
+		fmt.Sprintf("ExecStart=-/sbin/ip link add %s type dummy", nodeLocalDNSInterface),
+		fmt.Sprintf("ExecStart=/sbin/ip link set %s up", nodeLocalDNSInterface),
+		fmt.Sprintf("ExecStart=/sbin/ip addr replace %s/32 dev %s", ip, nodeLocalDNSInterface),
+	}
+	for _, protocol := range []string{"udp", "tcp"} {
+		for _, rule := range []struct{ table, chain, match string }{
+			{"raw", "PREROUTING", fmt.Sprintf("-d %s -p %s --dport 53 -j NOTRACK", ip, protocol)},
+			{"raw", "OUTPUT", fmt.Sprintf("-d %s -p %s --dport 53 -j NOTRACK", ip, protocol)},
+			{"raw", "OUTPUT", fmt.Sprintf("-s %s -p %s --sport 53 -j NOTRACK", ip, protocol)},
+			{"filter", "INPUT", fmt.Sprintf("-d %s -p %s --dport 53 -j ACCEPT", ip, protocol)},
+			{"filter", "OUTPUT", fmt.Sprintf("-s %s -p %s --sport 53 -j ACCEPT", ip, protocol)},
+		} {
+			// the rule is only inserted when it doesn't exist, so that restarting the unit doesn't duplicate it.
+			lines = append(lines, fmt.Sprintf("ExecStart=/bin/sh -c 'iptables -w -t %[1]s -C %[2]s %[3]s || iptables -w -t %[1]s -I %[2]s %[3]s'",
+				rule.table, rule.chain, rule.match))
+		}
+	}
+	lines = append(lines, fmt.Sprintf("ExecStop=/sbin/ip link del %s", nodeLocalDNSInterface),
+		"",
+		"[Install]",
+		"WantedBy=multi-user.target")
+	return strings.Join(lines, "\n") + "\n"
+}
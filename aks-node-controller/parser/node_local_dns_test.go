@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateNodeLocalDNS(t *testing.T) {
+	config := func(localIP string) *aksnodeconfigv1.Configuration {
+		return &aksnodeconfigv1.Configuration{
+			NetworkConfig: &aksnodeconfigv1.NetworkConfig{
+				NodeLocalDnsConfig: &aksnodeconfigv1.NodeLocalDnsConfig{Enabled: true, LocalIp: localIP},
+			},
+		}
+	}
+	assert.NoError(t, ValidateNodeLocalDNS(&aksnodeconfigv1.Configuration{}))
+	assert.NoError(t, ValidateNodeLocalDNS(config("")))
+	assert.NoError(t, ValidateNodeLocalDNS(config("169.254.25.10")))
+	assert.EqualError(t, ValidateNodeLocalDNS(config("10.0.0.10")), `node local DNS address "10.0.0.10" must be a link-local IPv4 address`)
+	assert.EqualError(t, ValidateNodeLocalDNS(config("fe80::10")), `node local DNS address "fe80::10" must be a link-local IPv4 address`)
+}
+
+func TestNodeLocalDNSUnitContent(t *testing.T) {
+	unit := NodeLocalDNSUnitContent(&aksnodeconfigv1.Configuration{
+		NetworkConfig: &aksnodeconfigv1.NetworkConfig{NodeLocalDnsConfig: &aksnodeconfigv1.NodeLocalDnsConfig{Enabled: true}},
+	})
+	assert.Contains(t, unit, "Before=kubelet.service\n")
+	assert.Contains(t, unit, "ExecStart=-/sbin/ip link add nodelocaldns type dummy\n")
+	assert.Contains(t, unit, "ExecStart=/sbin/ip addr replace 169.254.20.10/32 dev nodelocaldns\n")
+	assert.Contains(t, unit, "ExecStart=/bin/sh -c 'iptables -w -t raw -C PREROUTING -d 169.254.20.10 -p udp --dport 53 -j NOTRACK || "+
+		"iptables -w -t raw -I PREROUTING -d 169.254.20.10 -p udp --dport 53 -j NOTRACK'\n")
+	assert.Contains(t, unit, "ExecStart=/bin/sh -c 'iptables -w -t filter -C OUTPUT -s 169.254.20.10 -p tcp --sport 53 -j ACCEPT || "+
+		"iptables -w -t filter -I OUTPUT -s 169.254.20.10 -p tcp --sport 53 -j ACCEPT'\n")
+	assert.Equal(t, 20, strings.Count(unit, "iptables -w -t"), "5 rules checked and inserted for udp and tcp")
+	assert.Contains(t, unit, "WantedBy=multi-user.target\n")
+}
@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// maxAnnotationsSize is the limit of the API server on the total size of the annotations of an object.
+const maxAnnotationsSize = 256 * 1024
+
+var (
+	qualifiedNameRegex = regexp.MustCompile(`^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$`)
+	dnsSubdomainRegex  = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// taintEffects are the effects kubelet accepts in --register-with-taints.
+var taintEffects = map[string]bool{
+	"NoSchedule":       true,
+	"PreferNoSchedule": true,
+	"NoExecute":        true,
+}
+
+// ValidateNodeMetadata checks the syntax of the node labels, taints and annotations, and that they aren't also passed
+// through the raw kubelet flags.
+func ValidateNodeMetadata(config *aksnodeconfigv1.Configuration) error {
+	kubeletConfig := config.GetKubeletConfig()
+	flags := kubeletConfig.GetKubeletFlags()
+	if _, ok := flags["--node-labels"]; ok && len(kubeletConfig.GetKubeletNodeLabels()) > 0 {
+		return fmt.Errorf("node labels can't be set both in kubelet_node_labels and with --node-labels")
+	}
+	if _, ok := flags["--register-with-taints"]; ok && len(nodeTaints(kubeletConfig)) > 0 {
+		return fmt.Errorf("node taints can't be set both in taints and with --register-with-taints")
+	}
+
+	for key, value := range kubeletConfig.GetKubeletNodeLabels() {
+		if err := validateQualifiedName(key); err != nil {
+			return fmt.Errorf("invalid node label key %q: %w", key, err)
+		}
+		if err := validateLabelValue(value); err != nil {
+			return fmt.Errorf("invalid value of node label %q: %w", key, err)
+		}
+	}
+	for _, taint := range nodeTaints(kubeletConfig) {
+		if err := validateQualifiedName(taint.GetKey()); err != nil {
+			return fmt.Errorf("invalid node taint key %q: %w", taint.GetKey(), err)
+		}
+		if err := validateLabelValue(taint.GetValue()); err != nil {
+			return fmt.Errorf("invalid value of node taint %q: %w", taint.GetKey(), err)
+		}
+		if !taintEffects[taint.GetEffect()] {
+			return fmt.Errorf("invalid effect %q of node taint %q, it must be NoSchedule, PreferNoSchedule or NoExecute", taint.GetEffect(), taint.GetKey())
+		}
+	}
+	size := 0
+	for key, value := range kubeletConfig.GetNodeAnnotations() {
+		if err := validateQualifiedName(key); err != nil {
+			return fmt.Errorf("invalid node annotation key %q: %w", key, err)
+		}
+		size += len(key) + len(value)
+	}
+	if size > maxAnnotationsSize {
+		return fmt.Errorf("node annotations are %d bytes, they must not exceed %d bytes", size, maxAnnotationsSize)
+	}
+	return nil
+}
+
+// validateQualifiedName checks a label, taint or annotation key: a name of at most 63 characters, optionally
+// prefixed by a DNS subdomain and a slash.
+func validateQualifiedName(key string) error {
+	name := key
+	if prefix, suffix, ok := strings.Cut(key, "/"); ok {
+		if len(prefix) == 0 || len(prefix) > 253 || !dnsSubdomainRegex.MatchString(prefix) {
+			return fmt.Errorf("prefix %q must be a DNS subdomain", prefix)
+		}
+		name = suffix
+	}
+	if len(name) == 0 || len(name) > 63 || !qualifiedNameRegex.MatchString(name) {
+		return fmt.Errorf("name %q must be at most 63 alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric character", name)
+	}
+	return nil
+}
+
+func validateLabelValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > 63 || !qualifiedNameRegex.MatchString(value) {
+		return fmt.Errorf("value %q must be at most 63 alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric character", value)
+	}
+	return nil
+}
+
+func nodeTaints(kubeletConfig *aksnodeconfigv1.KubeletConfig) []*aksnodeconfigv1.Taint {
+	return append(append([]*aksnodeconfigv1.Taint{}, kubeletConfig.GetTaints()...), kubeletConfig.GetStartupTaints()...)
+}
+
+// getRegisterWithTaints returns the value of kubelet --register-with-taints, key=value:effect separated by commas.
+func getRegisterWithTaints(kubeletConfig *aksnodeconfigv1.KubeletConfig) string {
+	var taints []string
+	for _, taint := range nodeTaints(kubeletConfig) {
+		if taint.GetValue() == "" {
+			taints = append(taints, taint.GetKey()+":"+taint.GetEffect())
+		} else {
+			taints = append(taints, taint.GetKey()+"="+taint.GetValue()+":"+taint.GetEffect())
+		}
+	}
+	sort.Strings(taints)
+	return strings.Join(taints, ",")
+}
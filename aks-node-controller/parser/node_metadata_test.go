@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateNodeMetadata(t *testing.T) {
+	tests := []struct {
+		name          string
+		kubeletConfig *aksnodeconfigv1.KubeletConfig
+		wantErr       string
+	}{
+		{
+			name: "valid",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{
+				KubeletNodeLabels: map[string]string{"kubernetes.azure.com/agentpool": "nodepool1", "team": ""},
+				Taints:            []*aksnodeconfigv1.Taint{{Key: "sku", Value: "gpu", Effect: "NoSchedule"}},
+				StartupTaints:     []*aksnodeconfigv1.Taint{{Key: "example.com/initializing", Effect: "NoExecute"}},
+				NodeAnnotations:   map[string]string{"example.com/owner": "team a, see https://example.com"},
+			},
+		},
+		{
+			name: "labels in kubelet flags",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{
+				KubeletFlags:      map[string]string{"--node-labels": "a=b"},
+				KubeletNodeLabels: map[string]string{"c": "d"},
+			},
+			wantErr: "node labels can't be set both in kubelet_node_labels and with --node-labels",
+		},
+		{
+			name: "taints in kubelet flags",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{
+				KubeletFlags:  map[string]string{"--register-with-taints": "a=b:NoSchedule"},
+				StartupTaints: []*aksnodeconfigv1.Taint{{Key: "c", Effect: "NoSchedule"}},
+			},
+			wantErr: "node taints can't be set both in taints and with --register-with-taints",
+		},
+		{
+			name:          "invalid label prefix",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{KubeletNodeLabels: map[string]string{"Example.com/team": "a"}},
+			wantErr:       `invalid node label key "Example.com/team": prefix "Example.com" must be a DNS subdomain`,
+		},
+		{
+			name:          "invalid label name",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{KubeletNodeLabels: map[string]string{"team-": "a"}},
+			wantErr:       `invalid node label key "team-": name "team-" must be at most 63 alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric character`,
+		},
+		{
+			name:          "label name too long",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{KubeletNodeLabels: map[string]string{strings.Repeat("a", 64): "a"}},
+			wantErr:       `invalid node label key "` + strings.Repeat("a", 64) + `": name "` + strings.Repeat("a", 64) + `" must be at most 63 alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric character`,
+		},
+		{
+			name:          "invalid label value",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{KubeletNodeLabels: map[string]string{"team": "a,b"}},
+			wantErr:       `invalid value of node label "team": value "a,b" must be at most 63 alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric character`,
+		},
+		{
+			name:          "invalid taint effect",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{Taints: []*aksnodeconfigv1.Taint{{Key: "sku", Value: "gpu", Effect: "NoRun"}}},
+			wantErr:       `invalid effect "NoRun" of node taint "sku", it must be NoSchedule, PreferNoSchedule or NoExecute`,
+		},
+		{
+			name:          "invalid annotation key",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{NodeAnnotations: map[string]string{"a/b/c": ""}},
+			wantErr:       `invalid node annotation key "a/b/c": name "b/c" must be at most 63 alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric character`,
+		},
+		{
+			name:          "annotations too large",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{NodeAnnotations: map[string]string{"a": strings.Repeat("a", maxAnnotationsSize)}},
+			wantErr:       "node annotations are 262145 bytes, they must not exceed 262144 bytes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateNodeMetadata(&aksnodeconfigv1.Configuration{KubeletConfig: tt.kubeletConfig})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
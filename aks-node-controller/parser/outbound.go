@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// OutboundBlocked returns whether the node has no outbound access, so that every bootstrap artifact comes from a
+// private endpoint.
+func OutboundBlocked(config *aksnodeconfigv1.Configuration) bool {
+	return config.GetOutboundType() == aksnodeconfigv1.OutboundType_OUTBOUND_TYPE_NONE
+}
+
+// ValidateOutboundType checks that a node without outbound access has a registry to pull the bootstrap images from.
+func ValidateOutboundType(config *aksnodeconfigv1.Configuration) error {
+	outboundType := config.GetOutboundType()
+	if _, ok := aksnodeconfigv1.OutboundType_name[int32(outboundType)]; !ok {
+		return fmt.Errorf("unknown outbound type %d", outboundType)
+	}
+	if OutboundBlocked(config) && config.GetBootstrapProfileContainerRegistryServer() == "" {
+		return errors.New("outbound type none requires a bootstrap profile container registry server")
+	}
+	return nil
+}
+
+// outboundCommand returns the command CSE probes outbound connectivity with, which is empty when the node doesn't
+// reach the internet through the load balancer: the probes would fail behind a firewall or without outbound access.
+func outboundCommand(config *aksnodeconfigv1.Configuration) string {
+	switch config.GetOutboundType() {
+	case aksnodeconfigv1.OutboundType_OUTBOUND_TYPE_USER_DEFINED_ROUTING, aksnodeconfigv1.OutboundType_OUTBOUND_TYPE_NONE:
+		return ""
+	default:
+		return config.GetOutboundCommand()
+	}
+}
+
+// KubeBinaryURL returns the URL the kubernetes binaries are downloaded from: the private one on nodes without
+// outbound access, otherwise the custom one and then the default one.
+func KubeBinaryURL(config *aksnodeconfigv1.Configuration) string {
+	kubeBinaryConfig := config.GetKubeBinaryConfig()
+	if OutboundBlocked(config) && kubeBinaryConfig.GetPrivateKubeBinaryUrl() != "" {
+		return kubeBinaryConfig.GetPrivateKubeBinaryUrl()
+	}
+	if kubeBinaryConfig.GetCustomKubeBinaryUrl() != "" {
+		return kubeBinaryConfig.GetCustomKubeBinaryUrl()
+	}
+	return kubeBinaryConfig.GetKubeBinaryUrl()
+}
+
+// BootstrapImage returns the reference image is pulled with. On nodes without outbound access, MCR images are pulled
+// from the bootstrap profile container registry, which mirrors MCR.
+func BootstrapImage(config *aksnodeconfigv1.Configuration, image string) string {
+	server := strings.TrimSuffix(config.GetBootstrapProfileContainerRegistryServer(), "/")
+	if !OutboundBlocked(config) || server == "" {
+		return image
+	}
+	if repository, ok := strings.CutPrefix(image, MCRRegistry+"/"); ok {
+		return server + "/" + repository
+	}
+	return image
+}
@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboundType(t *testing.T) {
+	const checkCommand = "curl -v --insecure --proxy-insecure https://mcr.microsoft.com/v2/"
+	kubeBinaryConfig := &aksnodeconfigv1.KubeBinaryConfig{
+		KubeBinaryUrl:        "https://acs-mirror.azureedge.net/kubernetes/v1.30.0/binaries/kubernetes-node-linux-amd64.tar.gz",
+		PrivateKubeBinaryUrl: "https://private.blob.core.windows.net/kubernetes/v1.30.0/kubernetes-node-linux-amd64.tar.gz",
+	}
+	tests := []struct {
+		name              string
+		outboundType      aksnodeconfigv1.OutboundType
+		wantCommand       string
+		wantBlocked       bool
+		wantKubeBinaryURL string
+		wantImage         string
+		wantErr           string
+	}{
+		{
+			name:              "unspecified",
+			wantCommand:       checkCommand,
+			wantKubeBinaryURL: kubeBinaryConfig.KubeBinaryUrl,
+			wantImage:         "mcr.microsoft.com/aks/aks-gpu-cuda:550",
+		},
+		{
+			name:              "load balancer",
+			outboundType:      aksnodeconfigv1.OutboundType_OUTBOUND_TYPE_LOAD_BALANCER,
+			wantCommand:       checkCommand,
+			wantKubeBinaryURL: kubeBinaryConfig.KubeBinaryUrl,
+			wantImage:         "mcr.microsoft.com/aks/aks-gpu-cuda:550",
+		},
+		{
+			name:              "user defined routing",
+			outboundType:      aksnodeconfigv1.OutboundType_OUTBOUND_TYPE_USER_DEFINED_ROUTING,
+			wantKubeBinaryURL: kubeBinaryConfig.KubeBinaryUrl,
+			wantImage:         "mcr.microsoft.com/aks/aks-gpu-cuda:550",
+		},
+		{
+			name:              "none",
+			outboundType:      aksnodeconfigv1.OutboundType_OUTBOUND_TYPE_NONE,
+			wantBlocked:       true,
+			wantKubeBinaryURL: kubeBinaryConfig.PrivateKubeBinaryUrl,
+			wantImage:         "bootstrap.azurecr.io/mcr/aks/aks-gpu-cuda:550",
+		},
+		{
+			name:         "unknown",
+			outboundType: aksnodeconfigv1.OutboundType(42),
+			wantCommand:  checkCommand,
+			wantErr:      "unknown outbound type 42",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &aksnodeconfigv1.Configuration{
+				OutboundType:                            tt.outboundType,
+				OutboundCommand:                         checkCommand,
+				KubeBinaryConfig:                        kubeBinaryConfig,
+				BootstrapProfileContainerRegistryServer: "bootstrap.azurecr.io/mcr/",
+			}
+			assert.Equal(t, tt.wantCommand, outboundCommand(config))
+			if tt.wantErr != "" {
+				assert.EqualError(t, ValidateOutboundType(config), tt.wantErr)
+				return
+			}
+			assert.NoError(t, ValidateOutboundType(config))
+			assert.Equal(t, tt.wantBlocked, OutboundBlocked(config))
+			assert.Equal(t, tt.wantKubeBinaryURL, KubeBinaryURL(config))
+			assert.Equal(t, tt.wantImage, BootstrapImage(config, "mcr.microsoft.com/aks/aks-gpu-cuda:550"))
+		})
+	}
+
+	t.Run("none without bootstrap registry", func(t *testing.T) {
+		config := &aksnodeconfigv1.Configuration{OutboundType: aksnodeconfigv1.OutboundType_OUTBOUND_TYPE_NONE}
+		assert.EqualError(t, ValidateOutboundType(config), "outbound type none requires a bootstrap profile container registry server")
+	})
+}
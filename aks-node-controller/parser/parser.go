@@ -70,6 +70,9 @@ func getCSEEnv(config *aksnodeconfigv1.Configuration) map[string]string {
 		"PRIMARY_SCALE_SET":                              config.GetClusterConfig().GetPrimaryScaleSet(),
 		"SERVICE_PRINCIPAL_CLIENT_ID":                    config.GetAuthConfig().GetServicePrincipalId(),
 		"NETWORK_PLUGIN":                                 getStringFromNetworkPluginType(config.GetNetworkConfig().GetNetworkPlugin()),
+		"ENABLE_ACCELERATED_NETWORKING":                  fmt.Sprintf("%v", config.GetNetworkConfig().GetEnableAcceleratedNetworking()),
+		"ENABLE_IP_FORWARDING":                           fmt.Sprintf("%v", GetEnableIPForwarding(config.GetNetworkConfig())),
+		"ENABLE_INFINIBAND":                              fmt.Sprintf("%v", HasInfiniBand(config)),
 		"VNET_CNI_PLUGINS_URL":                           config.GetNetworkConfig().GetVnetCniPluginsUrl(),
 		"LOAD_BALANCER_DISABLE_OUTBOUND_SNAT":            fmt.Sprintf("%v", config.GetClusterConfig().GetLoadBalancerConfig().GetDisableOutboundSnat()),
 		"USE_MANAGED_IDENTITY_EXTENSION":                 fmt.Sprintf("%v", config.GetAuthConfig().GetUseManagedIdentityExtension()),
@@ -83,6 +86,7 @@ func getCSEEnv(config *aksnodeconfigv1.Configuration) map[string]string {
 		"IS_VHD":                                         fmt.Sprintf("%v", getIsVHD(config.IsVhd)),
 		"GPU_NODE":                                       fmt.Sprintf("%v", getEnableNvidia(config)),
 		"SGX_NODE":                                       fmt.Sprintf("%v", getIsSgxEnabledSKU(config.GetVmSize())),
+		"CONFIDENTIAL_COMPUTING_TYPE":                    string(ConfidentialComputingType(config)),
 		"MIG_NODE":                                       fmt.Sprintf("%v", getIsMIGNode(config.GetGpuConfig().GetGpuInstanceProfile())),
 		"CONFIG_GPU_DRIVER_IF_NEEDED":                    fmt.Sprintf("%v", config.GetGpuConfig().GetConfigGpuDriver()),
 		"ENABLE_GPU_DEVICE_PLUGIN_IF_NEEDED":             fmt.Sprintf("%v", config.GetGpuConfig().GetGpuDevicePlugin()),
@@ -93,7 +97,7 @@ func getCSEEnv(config *aksnodeconfigv1.Configuration) map[string]string {
 		"RUNC_VERSION":                                   config.GetRuncConfig().GetRuncVersion(),
 		"RUNC_PACKAGE_URL":                               config.GetRuncConfig().GetRuncPackageUrl(),
 		"ENABLE_HOSTS_CONFIG_AGENT":                      fmt.Sprintf("%v", config.GetEnableHostsConfigAgent()),
-		"DISABLE_SSH":                                    fmt.Sprintf("%v", getDisableSSH(config)),
+		"DISABLE_SSH":                                    fmt.Sprintf("%v", SSHDisabled(config)),
 		"TELEPORT_ENABLED":                               fmt.Sprintf("%v", config.GetTeleportConfig().GetStatus()),
 		"SHOULD_CONFIGURE_HTTP_PROXY":                    fmt.Sprintf("%v", getShouldConfigureHTTPProxy(config.GetHttpProxyConfig())),
 		"SHOULD_CONFIGURE_HTTP_PROXY_CA":                 fmt.Sprintf("%v", getShouldConfigureHTTPProxyCA(config.GetHttpProxyConfig())),
@@ -102,8 +106,9 @@ func getCSEEnv(config *aksnodeconfigv1.Configuration) map[string]string {
 		"CUSTOM_CA_TRUST_COUNT":                          fmt.Sprintf("%v", len(config.GetCustomCaCerts())),
 		"IS_KRUSTLET":                                    fmt.Sprintf("%v", getIsKrustlet(config.GetWorkloadRuntime())),
 		"GPU_NEEDS_FABRIC_MANAGER":                       fmt.Sprintf("%v", getGPUNeedsFabricManager(config.GetVmSize())),
-		"IPV6_DUAL_STACK_ENABLED":                        fmt.Sprintf("%v", config.GetIpv6DualStackEnabled()),
-		"OUTBOUND_COMMAND":                               config.GetOutboundCommand(),
+		"IPV6_DUAL_STACK_ENABLED":                        fmt.Sprintf("%v", DualStackEnabled(config)),
+		"OUTBOUND_COMMAND":                               outboundCommand(config),
+		"BLOCK_OUTBOUND_NETWORK":                         fmt.Sprintf("%v", OutboundBlocked(config)),
 		"ENABLE_UNATTENDED_UPGRADES":                     fmt.Sprintf("%v", config.GetEnableUnattendedUpgrade()),
 		"ENSURE_NO_DUPE_PROMISCUOUS_BRIDGE":              fmt.Sprintf("%v", getEnsureNoDupePromiscuousBridge(config.GetNetworkConfig())),
 		"SHOULD_CONFIG_SWAP_FILE":                        fmt.Sprintf("%v", getEnableSwapConfig(config.GetCustomLinuxOsConfig())),
@@ -138,7 +143,8 @@ func getCSEEnv(config *aksnodeconfigv1.Configuration) map[string]string {
 		"KUBELET_CLIENT_CONTENT":                         config.GetKubeletConfig().GetKubeletClientKey(),
 		"KUBELET_CLIENT_CERT_CONTENT":                    config.GetKubeletConfig().GetKubeletClientCertContent(),
 		"KUBELET_CONFIG_FILE_ENABLED":                    fmt.Sprintf("%v", config.GetKubeletConfig().GetEnableKubeletConfigFile()),
-		"KUBELET_CONFIG_FILE_CONTENT":                    config.GetKubeletConfig().GetKubeletConfigFileContent(),
+		"KUBELET_CONFIG_FILE_CONTENT":                    getKubeletConfigFileContent(config),
+		"ENABLE_KUBELET_SERVING_CERTIFICATE_ROTATION":    fmt.Sprintf("%v", KubeletServingCertRotationEnabled(config)),
 		"SWAP_FILE_SIZE_MB":                              fmt.Sprintf("%v", config.GetCustomLinuxOsConfig().GetSwapFileSize()),
 		"GPU_DRIVER_VERSION":                             getGpuDriverVersion(config.GetVmSize()),
 		"GPU_IMAGE_SHA":                                  getGpuImageSha(config.GetVmSize()),
@@ -150,7 +156,7 @@ func getCSEEnv(config *aksnodeconfigv1.Configuration) map[string]string {
 		"HAS_KUBELET_DISK_TYPE":                          fmt.Sprintf("%v", getHasKubeletDiskType(config.GetKubeletConfig())),
 		"NEEDS_CGROUPV2":                                 fmt.Sprintf("%v", config.GetNeedsCgroupv2()),
 		"TLS_BOOTSTRAP_TOKEN":                            getTLSBootstrapToken(config.GetBootstrappingConfig()),
-		"KUBELET_FLAGS":                                  createSortedKeyValuePairs(config.GetKubeletConfig().GetKubeletFlags(), " "),
+		"KUBELET_FLAGS":                                  getKubeletFlags(config),
 		"NETWORK_POLICY":                                 getStringFromNetworkPolicyType(config.GetNetworkConfig().GetNetworkPolicy()),
 		"KUBELET_NODE_LABELS":                            createSortedKeyValuePairs(config.GetKubeletConfig().GetKubeletNodeLabels(), ","),
 		"AZURE_ENVIRONMENT_FILEPATH":                     getAzureEnvironmentFilepath(config),
@@ -158,17 +164,22 @@ func getCSEEnv(config *aksnodeconfigv1.Configuration) map[string]string {
 		"KUBENET_TEMPLATE":                               getKubenetTemplate(),
 		"CONTAINERD_CONFIG_CONTENT":                      getContainerdConfig(config),
 		"IS_KATA":                                        fmt.Sprintf("%v", config.GetIsKata()),
-		"ARTIFACT_STREAMING_ENABLED":                     fmt.Sprintf("%v", config.GetEnableArtifactStreaming()),
-		"SYSCTL_CONTENT":                                 getSysctlContent(config.GetCustomLinuxOsConfig().GetSysctlConfig()),
+		"ARTIFACT_STREAMING_ENABLED":                     fmt.Sprintf("%v", ArtifactStreamingEnabled(config)),
+		"SYSCTL_CONTENT":                                 getCustomLinuxOsSysctlContent(config.GetCustomLinuxOsConfig()),
 		"PRIVATE_EGRESS_PROXY_ADDRESS":                   config.GetPrivateEgressProxyAddress(),
 		"BOOTSTRAP_PROFILE_CONTAINER_REGISTRY_SERVER":    config.GetBootstrapProfileContainerRegistryServer(),
 		"ENABLE_IMDS_RESTRICTION":                        fmt.Sprintf("%v", config.GetImdsRestrictionConfig().GetEnableImdsRestriction()),
 		"INSERT_IMDS_RESTRICTION_RULE_TO_MANGLE_TABLE":   fmt.Sprintf("%v", config.GetImdsRestrictionConfig().GetInsertImdsRestrictionRuleToMangleTable()),
+		"DOWNLOAD_IDENTITY_CLIENT_ID":                    config.GetAuthConfig().GetDownloadIdentityClientId(),
 	}
 
 	for i, cert := range config.CustomCaCerts {
 		env[fmt.Sprintf("CUSTOM_CA_CERT_%d", i)] = cert
 	}
+	if config.GetDebugConfig().GetTraceScripts() {
+		// bash enables the options listed in SHELLOPTS at startup, which traces every script without editing the templates.
+		env["SHELLOPTS"] = "xtrace"
+	}
 	return env
 }
 
@@ -181,7 +192,15 @@ func mapToEnviron(input map[string]string) []string {
 	return env
 }
 
+// CSEEnvironment returns the sorted KEY=value environment CSE runs with.
+func CSEEnvironment(config *aksnodeconfigv1.Configuration) []string {
+	return mapToEnviron(getCSEEnv(config))
+}
+
 func BuildCSECmd(ctx context.Context, config *aksnodeconfigv1.Configuration) (*exec.Cmd, error) {
+	if IsWindows(config) {
+		return BuildWindowsCSECmd(ctx, config)
+	}
 	triggerBootstrapScript, err := executeBootstrapTemplate(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute the template: %w", err)
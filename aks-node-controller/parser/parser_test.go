@@ -331,7 +331,7 @@ oom_score = 0
 				KubeletConfig: &aksnodeconfigv1.KubeletConfig{
 					EnableKubeletConfigFile: false,
 					KubeletFlags:            helpers.GetKubeletConfigFlag(kubeletConfig, cs, agentPool, false),
-					KubeletNodeLabels:       helpers.GetKubeletNodeLabels(agentPool),
+					KubeletNodeLabels:       helpers.GetKubeletNodeLabels(&datamodel.NodeBootstrappingConfiguration{AgentPoolProfile: agentPool}),
 				},
 				CustomCloudConfig: &aksnodeconfigv1.CustomCloudConfig{},
 			}
@@ -429,6 +429,14 @@ func environToMap(env []string) map[string]string {
 	return envMap
 }
 
+func TestGetCSEEnv_TraceScripts(t *testing.T) {
+	env := getCSEEnv(&aksnodeconfigv1.Configuration{})
+	assert.NotContains(t, env, "SHELLOPTS")
+
+	env = getCSEEnv(&aksnodeconfigv1.Configuration{DebugConfig: &aksnodeconfigv1.DebugConfig{TraceScripts: true}})
+	assert.Equal(t, "xtrace", env["SHELLOPTS"])
+}
+
 func TestContractCompatibilityHandledByProtobuf(t *testing.T) {
 	t.Run("with unexpected new fields in json should be ignored", func(t *testing.T) {
 		// The unexpected fields will natively be ignored when unmarshalling the json to the contract object.
@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// MCRRegistry is the registry redirected to the bootstrap profile container registry server.
+const MCRRegistry = "mcr.microsoft.com"
+
+// ContainerdHostDirs returns the content of the containerd host directories (<config_path>/<registry>/) of the
+// registry mirrors, keyed by registry and file name. A registry without files has no mirror.
+// A registry_mirrors entry for mcr.microsoft.com replaces the bootstrap profile container registry server.
+func ContainerdHostDirs(config *aksnodeconfigv1.Configuration) (map[string]map[string]string, error) {
+	dirs := map[string]map[string]string{MCRRegistry: nil}
+	if server := config.GetBootstrapProfileContainerRegistryServer(); server != "" {
+		host, prefix, _ := strings.Cut(strings.TrimSuffix(server, "/"), "/")
+		dirs[MCRRegistry] = map[string]string{
+			"hosts.toml": hostsTOMLEntry(&aksnodeconfigv1.RegistryMirrorEndpoint{Url: "https://" + host, RewritePrefix: prefix}, ""),
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, mirror := range config.GetRegistryMirrors() {
+		registry := mirror.GetRegistry()
+		switch {
+		case registry == "" || strings.ContainsAny(registry, "/\\") || registry == "." || registry == "..":
+			return nil, fmt.Errorf("invalid registry mirror registry %q", registry)
+		case seen[registry]:
+			return nil, fmt.Errorf("duplicate registry mirror for %s", registry)
+		case len(mirror.GetEndpoints()) == 0:
+			return nil, fmt.Errorf("registry mirror for %s has no endpoint", registry)
+		}
+		seen[registry] = true
+
+		files := map[string]string{}
+		hosts := make([]string, 0, len(mirror.GetEndpoints()))
+		for i, endpoint := range mirror.GetEndpoints() {
+			u, err := url.Parse(endpoint.GetUrl())
+			if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+				return nil, fmt.Errorf("registry mirror for %s: invalid endpoint url %q", registry, endpoint.GetUrl())
+			}
+			caFile := ""
+			if endpoint.GetCaBundle() != "" {
+				// containerd resolves relative paths against the host directory.
+				caFile = fmt.Sprintf("ca-%d.crt", i)
+				files[caFile] = endpoint.GetCaBundle()
+			}
+			hosts = append(hosts, hostsTOMLEntry(endpoint, caFile))
+		}
+		files["hosts.toml"] = strings.Join(hosts, "\n")
+		dirs[registry] = files
+	}
+	return dirs, nil
+}
+
+// hostsTOMLEntry renders a pull-only host. A rewrite prefix is rendered as an overridden path, containerd then
+// doesn't add /v2 itself.
+func hostsTOMLEntry(endpoint *aksnodeconfigv1.RegistryMirrorEndpoint, caFile string) string {
+	var b strings.Builder
+	host := strings.TrimSuffix(endpoint.GetUrl(), "/")
+	prefix := strings.Trim(endpoint.GetRewritePrefix(), "/")
+	if prefix != "" {
+		host += "/v2/" + prefix
+	}
+	fmt.Fprintf(&b, "[host.%q]\n  capabilities = [\"pull\", \"resolve\"]\n", host)
+	if prefix != "" {
+		b.WriteString("  override_path = true\n")
+	}
+	if caFile != "" {
+		fmt.Fprintf(&b, "  ca = %q\n", caFile)
+	}
+	if endpoint.GetSkipVerify() {
+		b.WriteString("  skip_verify = true\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerdHostDirs(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *aksnodeconfigv1.Configuration
+		want    map[string]map[string]string
+		wantErr string
+	}{
+		{
+			name:   "no mirror",
+			config: &aksnodeconfigv1.Configuration{},
+			want:   map[string]map[string]string{MCRRegistry: nil},
+		},
+		{
+			name: "registry mirror replaces the bootstrap profile registry",
+			config: &aksnodeconfigv1.Configuration{
+				BootstrapProfileContainerRegistryServer: "bootstrap.azurecr.io",
+				RegistryMirrors: []*aksnodeconfigv1.RegistryMirror{{
+					Registry:  MCRRegistry,
+					Endpoints: []*aksnodeconfigv1.RegistryMirrorEndpoint{{Url: "https://mirror.azurecr.io", RewritePrefix: "/mcr/"}},
+				}},
+			},
+			want: map[string]map[string]string{
+				MCRRegistry: {"hosts.toml": "[host.\"https://mirror.azurecr.io/v2/mcr\"]\n  capabilities = [\"pull\", \"resolve\"]\n  override_path = true\n"},
+			},
+		},
+		{
+			name: "duplicate registry",
+			config: &aksnodeconfigv1.Configuration{RegistryMirrors: []*aksnodeconfigv1.RegistryMirror{
+				{Registry: "docker.io", Endpoints: []*aksnodeconfigv1.RegistryMirrorEndpoint{{Url: "https://a.com"}}},
+				{Registry: "docker.io", Endpoints: []*aksnodeconfigv1.RegistryMirrorEndpoint{{Url: "https://b.com"}}},
+			}},
+			wantErr: "duplicate registry mirror for docker.io",
+		},
+		{
+			name: "registry with path separator",
+			config: &aksnodeconfigv1.Configuration{RegistryMirrors: []*aksnodeconfigv1.RegistryMirror{
+				{Registry: "../etc", Endpoints: []*aksnodeconfigv1.RegistryMirrorEndpoint{{Url: "https://a.com"}}},
+			}},
+			wantErr: `invalid registry mirror registry "../etc"`,
+		},
+		{
+			name: "no endpoint",
+			config: &aksnodeconfigv1.Configuration{RegistryMirrors: []*aksnodeconfigv1.RegistryMirror{
+				{Registry: "docker.io"},
+			}},
+			wantErr: "registry mirror for docker.io has no endpoint",
+		},
+		{
+			name: "endpoint without scheme",
+			config: &aksnodeconfigv1.Configuration{RegistryMirrors: []*aksnodeconfigv1.RegistryMirror{
+				{Registry: "docker.io", Endpoints: []*aksnodeconfigv1.RegistryMirrorEndpoint{{Url: "mirror.contoso.com"}}},
+			}},
+			wantErr: `registry mirror for docker.io: invalid endpoint url "mirror.contoso.com"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ContainerdHostDirs(tt.config)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+var (
+	cpuManagerPolicies = map[aksnodeconfigv1.CpuManagerPolicy]string{
+		aksnodeconfigv1.CpuManagerPolicy_CPU_MANAGER_POLICY_NONE:   "none",
+		aksnodeconfigv1.CpuManagerPolicy_CPU_MANAGER_POLICY_STATIC: "static",
+	}
+	topologyManagerPolicies = map[aksnodeconfigv1.TopologyManagerPolicy]string{
+		aksnodeconfigv1.TopologyManagerPolicy_TOPOLOGY_MANAGER_POLICY_NONE:             "none",
+		aksnodeconfigv1.TopologyManagerPolicy_TOPOLOGY_MANAGER_POLICY_BEST_EFFORT:      "best-effort",
+		aksnodeconfigv1.TopologyManagerPolicy_TOPOLOGY_MANAGER_POLICY_RESTRICTED:       "restricted",
+		aksnodeconfigv1.TopologyManagerPolicy_TOPOLOGY_MANAGER_POLICY_SINGLE_NUMA_NODE: "single-numa-node",
+	}
+	memoryManagerPolicies = map[aksnodeconfigv1.MemoryManagerPolicy]string{
+		aksnodeconfigv1.MemoryManagerPolicy_MEMORY_MANAGER_POLICY_NONE:   "None",
+		aksnodeconfigv1.MemoryManagerPolicy_MEMORY_MANAGER_POLICY_STATIC: "Static",
+	}
+)
+
+// ValidateResourceManagerPolicies checks the CPU, topology and memory manager policies against each other and the
+// kubelet flags they replace.
+func ValidateResourceManagerPolicies(config *aksnodeconfigv1.Configuration) error {
+	kubeletConfig := config.GetKubeletConfig()
+	flags := kubeletConfig.GetKubeletFlags()
+	cpuPolicy, cpuOK := cpuManagerPolicies[kubeletConfig.GetCpuManagerPolicy()]
+	topologyPolicy, topologyOK := topologyManagerPolicies[kubeletConfig.GetTopologyManagerPolicy()]
+	memoryPolicy, memoryOK := memoryManagerPolicies[kubeletConfig.GetMemoryManagerPolicy()]
+	for _, field := range []struct {
+		set     bool
+		unknown bool
+		name    string
+		flag    string
+	}{
+		{cpuOK, !cpuOK && kubeletConfig.GetCpuManagerPolicy() != 0, "cpu manager policy", "--cpu-manager-policy"},
+		{topologyOK, !topologyOK && kubeletConfig.GetTopologyManagerPolicy() != 0, "topology manager policy", "--topology-manager-policy"},
+		{memoryOK, !memoryOK && kubeletConfig.GetMemoryManagerPolicy() != 0, "memory manager policy", "--memory-manager-policy"},
+		{kubeletConfig.GetReservedSystemCpus() != "", false, "reserved system CPUs", "--reserved-cpus"},
+	} {
+		if field.unknown {
+			return fmt.Errorf("unknown %s", field.name)
+		}
+		if _, ok := flags[field.flag]; ok && field.set {
+			return fmt.Errorf("%s can't be combined with %s", field.name, field.flag)
+		}
+	}
+
+	if reservedCPUs := kubeletConfig.GetReservedSystemCpus(); reservedCPUs != "" {
+		if err := validateCPUSet(reservedCPUs); err != nil {
+			return err
+		}
+	}
+	if cpuPolicy == "static" && kubeletConfig.GetReservedSystemCpus() == "" {
+		return errors.New("the static cpu manager policy requires reserved system CPUs")
+	}
+	if topologyOK && topologyPolicy != "none" && cpuPolicy != "static" && memoryPolicy != "Static" {
+		return fmt.Errorf("the %s topology manager policy requires the static cpu or memory manager policy", topologyPolicy)
+	}
+	if memoryPolicy == "Static" {
+		if _, ok := flags["--reserved-memory"]; ok {
+			return nil
+		}
+		if memoryEvictionThresholdIsPercentage(flags["--eviction-hard"]) {
+			return errors.New("the static memory manager policy requires --reserved-memory with a percentage memory.available eviction threshold")
+		}
+		reserved, err := reservedMemoryBytes(config, 0)
+		if err != nil {
+			return err
+		}
+		if reserved == 0 {
+			return errors.New("the static memory manager policy requires reserved memory")
+		}
+	}
+	return nil
+}
+
+// resourceManagerFlags applies the resource manager policies to the kubelet flags. kubelet requires the memory
+// reserved by the static memory manager policy to match the reserved memory and the hard eviction threshold, it is
+// reserved on NUMA node 0 unless --reserved-memory is set.
+func resourceManagerFlags(config *aksnodeconfigv1.Configuration, flags map[string]string) {
+	kubeletConfig := config.GetKubeletConfig()
+	if policy, ok := cpuManagerPolicies[kubeletConfig.GetCpuManagerPolicy()]; ok {
+		flags["--cpu-manager-policy"] = policy
+	}
+	if policy, ok := topologyManagerPolicies[kubeletConfig.GetTopologyManagerPolicy()]; ok {
+		flags["--topology-manager-policy"] = policy
+	}
+	if reservedCPUs := kubeletConfig.GetReservedSystemCpus(); reservedCPUs != "" {
+		flags["--reserved-cpus"] = reservedCPUs
+	}
+	if policy, ok := memoryManagerPolicies[kubeletConfig.GetMemoryManagerPolicy()]; ok {
+		flags["--memory-manager-policy"] = policy
+		if _, set := flags["--reserved-memory"]; policy == "Static" && !set {
+			// ValidateResourceManagerPolicies rejects reservations which can't be computed.
+			if reserved, err := reservedMemoryBytes(config, 0); err == nil {
+				flags["--reserved-memory"] = fmt.Sprintf("0:memory=%dKi", reserved>>10)
+			}
+		}
+	}
+}
+
+// memoryEvictionThresholdIsPercentage returns whether the memory.available threshold of the eviction flag is a
+// percentage of the node memory, which is only known on the node.
+func memoryEvictionThresholdIsPercentage(eviction string) bool {
+	for _, signal := range strings.Split(eviction, ",") {
+		if name, value, _ := strings.Cut(signal, "<"); name == "memory.available" {
+			return strings.HasSuffix(value, "%")
+		}
+	}
+	return false
+}
+
+// validateCPUSet checks a cpuset of CPU IDs and ranges, such as 0-1,8.
+func validateCPUSet(cpuset string) error {
+	for _, part := range strings.Split(cpuset, ",") {
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(first)
+		if err != nil || start < 0 {
+			return fmt.Errorf("invalid reserved system CPUs %q, it must be a cpuset such as 0-1,8", cpuset)
+		}
+		if isRange {
+			if end, err := strconv.Atoi(last); err != nil || end < start {
+				return fmt.Errorf("invalid reserved system CPUs %q, it must be a cpuset such as 0-1,8", cpuset)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package parser
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateResourceManagerPolicies(t *testing.T) {
+	static := aksnodeconfigv1.CpuManagerPolicy_CPU_MANAGER_POLICY_STATIC
+	tests := []struct {
+		name          string
+		kubeletConfig *aksnodeconfigv1.KubeletConfig
+		wantErr       string
+	}{
+		{
+			name:          "not set",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{KubeletFlags: map[string]string{"--cpu-manager-policy": "static"}},
+		},
+		{
+			name: "static CPU manager with single NUMA node topology",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{
+				CpuManagerPolicy:      static,
+				TopologyManagerPolicy: aksnodeconfigv1.TopologyManagerPolicy_TOPOLOGY_MANAGER_POLICY_SINGLE_NUMA_NODE,
+				ReservedSystemCpus:    "0-1,8",
+			},
+		},
+		{
+			name:          "static CPU manager without reserved CPUs",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{CpuManagerPolicy: static},
+			wantErr:       "the static cpu manager policy requires reserved system CPUs",
+		},
+		{
+			name:          "invalid cpuset",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{CpuManagerPolicy: static, ReservedSystemCpus: "3-1"},
+			wantErr:       `invalid reserved system CPUs "3-1", it must be a cpuset such as 0-1,8`,
+		},
+		{
+			name: "policy and flag",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{
+				CpuManagerPolicy:   static,
+				ReservedSystemCpus: "0",
+				KubeletFlags:       map[string]string{"--cpu-manager-policy": "none"},
+			},
+			wantErr: "cpu manager policy can't be combined with --cpu-manager-policy",
+		},
+		{
+			name:          "topology manager without hint providers",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{TopologyManagerPolicy: aksnodeconfigv1.TopologyManagerPolicy_TOPOLOGY_MANAGER_POLICY_RESTRICTED},
+			wantErr:       "the restricted topology manager policy requires the static cpu or memory manager policy",
+		},
+		{
+			name:          "unknown memory manager policy",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{MemoryManagerPolicy: 9},
+			wantErr:       "unknown memory manager policy",
+		},
+		{
+			name: "static memory manager with a percentage eviction threshold",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{
+				MemoryManagerPolicy: aksnodeconfigv1.MemoryManagerPolicy_MEMORY_MANAGER_POLICY_STATIC,
+				KubeletFlags:        map[string]string{"--kube-reserved": "memory=1Gi", "--eviction-hard": "memory.available<5%"},
+			},
+			wantErr: "the static memory manager policy requires --reserved-memory with a percentage memory.available eviction threshold",
+		},
+		{
+			name:          "static memory manager without reserved memory",
+			kubeletConfig: &aksnodeconfigv1.KubeletConfig{MemoryManagerPolicy: aksnodeconfigv1.MemoryManagerPolicy_MEMORY_MANAGER_POLICY_STATIC},
+			wantErr:       "the static memory manager policy requires reserved memory",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateResourceManagerPolicies(&aksnodeconfigv1.Configuration{KubeletConfig: tt.kubeletConfig})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResourceManagerFlags(t *testing.T) {
+	config := &aksnodeconfigv1.Configuration{KubeletConfig: &aksnodeconfigv1.KubeletConfig{
+		CpuManagerPolicy:      aksnodeconfigv1.CpuManagerPolicy_CPU_MANAGER_POLICY_STATIC,
+		TopologyManagerPolicy: aksnodeconfigv1.TopologyManagerPolicy_TOPOLOGY_MANAGER_POLICY_BEST_EFFORT,
+		MemoryManagerPolicy:   aksnodeconfigv1.MemoryManagerPolicy_MEMORY_MANAGER_POLICY_STATIC,
+		ReservedSystemCpus:    "0-1",
+		KubeletFlags: map[string]string{
+			"--kube-reserved": "cpu=100m,memory=1638Mi",
+			"--eviction-hard": "memory.available<750Mi,nodefs.available<10%",
+		},
+	}}
+	assert.NoError(t, ValidateResourceManagerPolicies(config))
+
+	flags := kubeletFlags(config)
+	assert.Equal(t, "static", flags["--cpu-manager-policy"])
+	assert.Equal(t, "best-effort", flags["--topology-manager-policy"])
+	assert.Equal(t, "Static", flags["--memory-manager-policy"])
+	assert.Equal(t, "0-1", flags["--reserved-cpus"])
+	assert.Equal(t, "0:memory=2445312Ki", flags["--reserved-memory"])
+}
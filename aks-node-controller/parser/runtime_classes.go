@@ -0,0 +1,134 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// kataConfidentialConfigPath is the kata configuration of the confidential runtime, the kata runtime defaults to the
+// configuration of the kata-containers package.
+const kataConfidentialConfigPath = "/opt/confidential-containers/share/defaults/kata-containers/configuration-clh-snp.toml"
+
+var (
+	runtimeHandlerRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+	// vmSizeRegex splits a VM size such as standard_d4ads_v5 into its family, its capabilities and its version.
+	vmSizeRegex = regexp.MustCompile(`^standard_([a-z]+)\d+([a-z_-]*)_v(\d+)$`)
+)
+
+// reservedRuntimeHandlers are the runtime handlers of the containerd config template.
+var reservedRuntimeHandlers = map[string]bool{
+	"runc":                     true,
+	"untrusted":                true,
+	"nvidia-container-runtime": true,
+}
+
+// ValidateRuntimeClasses checks that the runtime classes have unique handler names and that the VM size can run
+// them: kata needs nested virtualization and confidential kata needs a confidential VM size.
+func ValidateRuntimeClasses(config *aksnodeconfigv1.Configuration) error {
+	if len(config.GetRuntimeClasses()) == 0 {
+		return nil
+	}
+	if config.GetIsKata() {
+		return errors.New("runtime classes can't be combined with is_kata")
+	}
+	handlers := map[string]bool{}
+	for _, runtimeClass := range config.GetRuntimeClasses() {
+		handler := runtimeClass.GetHandler()
+		if !runtimeHandlerRegex.MatchString(handler) {
+			return fmt.Errorf("invalid runtime handler name %q", handler)
+		}
+		if reservedRuntimeHandlers[handler] {
+			return fmt.Errorf("runtime handler %q is reserved", handler)
+		}
+		if handlers[handler] {
+			return fmt.Errorf("duplicate runtime handler %q", handler)
+		}
+		handlers[handler] = true
+
+		switch runtimeClass.GetType() {
+		case aksnodeconfigv1.RuntimeClassType_RUNTIME_CLASS_TYPE_KATA:
+			if !supportsNestedVirtualization(config.GetVmSize()) {
+				return fmt.Errorf("runtime handler %q: VM size %q doesn't support nested virtualization", handler, config.GetVmSize())
+			}
+		case aksnodeconfigv1.RuntimeClassType_RUNTIME_CLASS_TYPE_KATA_CONFIDENTIAL:
+			if !isConfidentialVMSize(config.GetVmSize()) {
+				return fmt.Errorf("runtime handler %q: VM size %q isn't a confidential VM size", handler, config.GetVmSize())
+			}
+		default:
+			return fmt.Errorf("runtime handler %q: runtime class type is required", handler)
+		}
+	}
+	return nil
+}
+
+// supportsNestedVirtualization returns whether the VM size supports nested virtualization: the D and E families
+// from v3, the F family from v2, excluding the Arm64 sizes.
+func supportsNestedVirtualization(vmSize string) bool {
+	match := vmSizeRegex.FindStringSubmatch(strings.ToLower(vmSize))
+	if match == nil {
+		return false
+	}
+	family, capabilities := match[1], match[2]
+	version, _ := strconv.Atoi(match[3])
+	if strings.Contains(capabilities, "p") {
+		return false
+	}
+	switch family {
+	case "d", "e", "dc", "ec":
+		return version >= 3
+	case "f":
+		return version >= 2
+	}
+	return false
+}
+
+// isConfidentialVMSize returns whether the VM size is one of the AMD SEV-SNP sizes for confidential child VMs, such
+// as Standard_DC4as_cc_v5.
+func isConfidentialVMSize(vmSize string) bool {
+	match := vmSizeRegex.FindStringSubmatch(strings.ToLower(vmSize))
+	return match != nil && (match[1] == "dc" || match[1] == "ec") && strings.HasSuffix(match[2], "_cc")
+}
+
+// runtimeClassesContainerdConfig returns the containerd runtime handler stanzas of the runtime classes, appended to
+// the containerd config.
+func runtimeClassesContainerdConfig(runtimeClasses []*aksnodeconfigv1.RuntimeClass) string {
+	var b strings.Builder
+	tardev := false
+	for _, runtimeClass := range runtimeClasses {
+		runtime := fmt.Sprintf("\n[plugins.\"io.containerd.grpc.v1.cri\".containerd.runtimes.%s]\n", runtimeClass.GetHandler())
+		options := fmt.Sprintf("[plugins.\"io.containerd.grpc.v1.cri\".containerd.runtimes.%s.options]\n", runtimeClass.GetHandler())
+		switch runtimeClass.GetType() {
+		case aksnodeconfigv1.RuntimeClassType_RUNTIME_CLASS_TYPE_KATA:
+			b.WriteString(runtime)
+			b.WriteString("  runtime_type = \"io.containerd.kata.v2\"\n")
+			if runtimeClass.GetConfigPath() != "" {
+				b.WriteString(options)
+				fmt.Fprintf(&b, "  ConfigPath = %q\n", runtimeClass.GetConfigPath())
+			}
+		case aksnodeconfigv1.RuntimeClassType_RUNTIME_CLASS_TYPE_KATA_CONFIDENTIAL:
+			b.WriteString(runtime)
+			b.WriteString("  snapshotter = \"tardev\"\n")
+			b.WriteString("  runtime_type = \"io.containerd.kata-cc.v2\"\n")
+			b.WriteString("  privileged_without_host_devices = true\n")
+			b.WriteString("  pod_annotations = [\"io.katacontainers.*\"]\n")
+			b.WriteString(options)
+			configPath := runtimeClass.GetConfigPath()
+			if configPath == "" {
+				configPath = kataConfidentialConfigPath
+			}
+			fmt.Fprintf(&b, "  ConfigPath = %q\n", configPath)
+			tardev = true
+		}
+	}
+	if tardev {
+		b.WriteString("\n[proxy_plugins.tardev]\n")
+		b.WriteString("  type = \"snapshot\"\n")
+		b.WriteString("  address = \"/run/containerd/tardev-snapshotter.sock\"\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRuntimeClasses(t *testing.T) {
+	kata := &aksnodeconfigv1.RuntimeClass{Handler: "kata-vm", Type: aksnodeconfigv1.RuntimeClassType_RUNTIME_CLASS_TYPE_KATA}
+	confidential := &aksnodeconfigv1.RuntimeClass{Handler: "kata-cc-vm", Type: aksnodeconfigv1.RuntimeClassType_RUNTIME_CLASS_TYPE_KATA_CONFIDENTIAL}
+	tests := []struct {
+		name    string
+		config  *aksnodeconfigv1.Configuration
+		wantErr string
+	}{
+		{
+			name:   "no runtime classes",
+			config: &aksnodeconfigv1.Configuration{VmSize: "Standard_A2_v2"},
+		},
+		{
+			name:   "kata",
+			config: &aksnodeconfigv1.Configuration{VmSize: "Standard_D4s_v3", RuntimeClasses: []*aksnodeconfigv1.RuntimeClass{kata}},
+		},
+		{
+			name:   "kata on F v2",
+			config: &aksnodeconfigv1.Configuration{VmSize: "Standard_F8s_v2", RuntimeClasses: []*aksnodeconfigv1.RuntimeClass{kata}},
+		},
+		{
+			name: "confidential",
+			config: &aksnodeconfigv1.Configuration{
+				VmSize:         "Standard_DC4as_cc_v5",
+				RuntimeClasses: []*aksnodeconfigv1.RuntimeClass{kata, confidential},
+			},
+		},
+		{
+			name:    "no nested virtualization",
+			config:  &aksnodeconfigv1.Configuration{VmSize: "Standard_D4_v2", RuntimeClasses: []*aksnodeconfigv1.RuntimeClass{kata}},
+			wantErr: `runtime handler "kata-vm": VM size "Standard_D4_v2" doesn't support nested virtualization`,
+		},
+		{
+			name:    "arm64 VM size",
+			config:  &aksnodeconfigv1.Configuration{VmSize: "Standard_D4pds_v5", RuntimeClasses: []*aksnodeconfigv1.RuntimeClass{kata}},
+			wantErr: `runtime handler "kata-vm": VM size "Standard_D4pds_v5" doesn't support nested virtualization`,
+		},
+		{
+			name:    "confidential on non confidential VM size",
+			config:  &aksnodeconfigv1.Configuration{VmSize: "Standard_D4as_v5", RuntimeClasses: []*aksnodeconfigv1.RuntimeClass{confidential}},
+			wantErr: `runtime handler "kata-cc-vm": VM size "Standard_D4as_v5" isn't a confidential VM size`,
+		},
+		{
+			name: "is_kata",
+			config: &aksnodeconfigv1.Configuration{
+				VmSize:         "Standard_D4s_v3",
+				IsKata:         true,
+				RuntimeClasses: []*aksnodeconfigv1.RuntimeClass{kata},
+			},
+			wantErr: "runtime classes can't be combined with is_kata",
+		},
+		{
+			name: "invalid handler",
+			config: &aksnodeconfigv1.Configuration{
+				VmSize:         "Standard_D4s_v3",
+				RuntimeClasses: []*aksnodeconfigv1.RuntimeClass{{Handler: "Kata_VM", Type: kata.Type}},
+			},
+			wantErr: `invalid runtime handler name "Kata_VM"`,
+		},
+		{
+			name: "reserved handler",
+			config: &aksnodeconfigv1.Configuration{
+				VmSize:         "Standard_D4s_v3",
+				RuntimeClasses: []*aksnodeconfigv1.RuntimeClass{{Handler: "runc", Type: kata.Type}},
+			},
+			wantErr: `runtime handler "runc" is reserved`,
+		},
+		{
+			name:    "duplicate handler",
+			config:  &aksnodeconfigv1.Configuration{VmSize: "Standard_D4s_v3", RuntimeClasses: []*aksnodeconfigv1.RuntimeClass{kata, kata}},
+			wantErr: `duplicate runtime handler "kata-vm"`,
+		},
+		{
+			name: "no type",
+			config: &aksnodeconfigv1.Configuration{
+				VmSize:         "Standard_D4s_v3",
+				RuntimeClasses: []*aksnodeconfigv1.RuntimeClass{{Handler: "kata-vm"}},
+			},
+			wantErr: `runtime handler "kata-vm": runtime class type is required`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRuntimeClasses(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuntimeClassesContainerdConfig(t *testing.T) {
+	assert.Empty(t, runtimeClassesContainerdConfig(nil))
+
+	config := runtimeClassesContainerdConfig([]*aksnodeconfigv1.RuntimeClass{
+		{Handler: "kata-vm", Type: aksnodeconfigv1.RuntimeClassType_RUNTIME_CLASS_TYPE_KATA},
+		{Handler: "kata-qemu", Type: aksnodeconfigv1.RuntimeClassType_RUNTIME_CLASS_TYPE_KATA, ConfigPath: "/etc/kata/qemu.toml"},
+		{Handler: "kata-cc-vm", Type: aksnodeconfigv1.RuntimeClassType_RUNTIME_CLASS_TYPE_KATA_CONFIDENTIAL},
+	})
+	assert.Equal(t, `
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.kata-vm]
+  runtime_type = "io.containerd.kata.v2"
+
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.kata-qemu]
+  runtime_type = "io.containerd.kata.v2"
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.kata-qemu.options]
+  ConfigPath = "/etc/kata/qemu.toml"
+
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.kata-cc-vm]
+  snapshotter = "tardev"
+  runtime_type = "io.containerd.kata-cc.v2"
+  privileged_without_host_devices = true
+  pod_annotations = ["io.katacontainers.*"]
+[plugins."io.containerd.grpc.v1.cri".containerd.runtimes.kata-cc-vm.options]
+  ConfigPath = "/opt/confidential-containers/share/defaults/kata-containers/configuration-clh-snp.toml"
+
+[proxy_plugins.tardev]
+  type = "snapshot"
+  address = "/run/containerd/tardev-snapshotter.sock"
+`, config)
+}
@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+var (
+	imageDigestRegex = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+	// imageReferenceRegex matches a repository with an optional tag and digest, the domain and path components
+	// aren't checked further.
+	imageReferenceRegex = regexp.MustCompile(`^[a-z0-9]+([._:/-][a-zA-Z0-9]+)*(:[\w][\w.-]{0,127})?(@sha256:[a-f0-9]{64})?$`)
+)
+
+// SandboxImage returns the pod sandbox image reference, pinned to its digest when one is set. The sandbox image of the
+// containerd config takes precedence over kube_binary_config.pod_infra_container_image_url.
+func SandboxImage(config *aksnodeconfigv1.Configuration) string {
+	sandboxImage := config.GetContainerdConfig().GetSandboxImage()
+	if sandboxImage.GetImage() == "" {
+		return config.GetKubeBinaryConfig().GetPodInfraContainerImageUrl()
+	}
+	if sandboxImage.GetDigest() == "" {
+		return sandboxImage.GetImage()
+	}
+	return sandboxImage.GetImage() + "@" + sandboxImage.GetDigest()
+}
+
+// ValidateSandboxImage checks the sandbox image reference and digest.
+func ValidateSandboxImage(config *aksnodeconfigv1.Configuration) error {
+	sandboxImage := config.GetContainerdConfig().GetSandboxImage()
+	if sandboxImage == nil {
+		return nil
+	}
+	if sandboxImage.GetImage() == "" {
+		return errors.New("sandbox image is required")
+	}
+	if sandboxImage.GetDigest() != "" {
+		if !imageDigestRegex.MatchString(sandboxImage.GetDigest()) {
+			return fmt.Errorf("invalid sandbox image digest %q, it must be sha256:<64 hex characters>", sandboxImage.GetDigest())
+		}
+		if strings.Contains(sandboxImage.GetImage(), "@") {
+			return fmt.Errorf("sandbox image %q already has a digest", sandboxImage.GetImage())
+		}
+	}
+	if !imageReferenceRegex.MatchString(SandboxImage(config)) {
+		return fmt.Errorf("invalid sandbox image %q", SandboxImage(config))
+	}
+	return nil
+}
+
+// PinnedImageName returns the name containerd stores the image reference under: references with a digest are stored
+// without their tag.
+func PinnedImageName(reference string) string {
+	name, digest, found := strings.Cut(reference, "@")
+	if !found {
+		return reference
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	return name + "@" + digest
+}
@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxImage(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	tests := []struct {
+		name      string
+		sandbox   *aksnodeconfigv1.SandboxImageConfig
+		want      string
+		wantFlag  string
+		wantErr   string
+		wantPinAs string
+	}{
+		{
+			name:      "pod infra container image",
+			want:      "mcr.microsoft.com/oss/kubernetes/pause:3.6",
+			wantFlag:  "mcr.microsoft.com/oss/kubernetes/pause:3.6",
+			wantPinAs: "mcr.microsoft.com/oss/kubernetes/pause:3.6",
+		},
+		{
+			name:      "sandbox image",
+			sandbox:   &aksnodeconfigv1.SandboxImageConfig{Image: "registry.contoso.com:5000/pause:3.10"},
+			want:      "registry.contoso.com:5000/pause:3.10",
+			wantFlag:  "registry.contoso.com:5000/pause:3.10",
+			wantPinAs: "registry.contoso.com:5000/pause:3.10",
+		},
+		{
+			name:      "pinned to a digest",
+			sandbox:   &aksnodeconfigv1.SandboxImageConfig{Image: "registry.contoso.com:5000/pause:3.10", Digest: digest},
+			want:      "registry.contoso.com:5000/pause:3.10@" + digest,
+			wantFlag:  "registry.contoso.com:5000/pause:3.10@" + digest,
+			wantPinAs: "registry.contoso.com:5000/pause@" + digest,
+		},
+		{
+			name:    "no image",
+			sandbox: &aksnodeconfigv1.SandboxImageConfig{Digest: digest},
+			wantErr: "sandbox image is required",
+		},
+		{
+			name:    "invalid digest",
+			sandbox: &aksnodeconfigv1.SandboxImageConfig{Image: "mcr.microsoft.com/oss/kubernetes/pause:3.6", Digest: "sha256:abc"},
+			wantErr: `invalid sandbox image digest "sha256:abc", it must be sha256:<64 hex characters>`,
+		},
+		{
+			name:    "digest twice",
+			sandbox: &aksnodeconfigv1.SandboxImageConfig{Image: "mcr.microsoft.com/oss/kubernetes/pause@" + digest, Digest: digest},
+			wantErr: `sandbox image "mcr.microsoft.com/oss/kubernetes/pause@` + digest + `" already has a digest`,
+		},
+		{
+			name:    "invalid image",
+			sandbox: &aksnodeconfigv1.SandboxImageConfig{Image: "mcr.microsoft.com/oss/kubernetes/pause 3.6"},
+			wantErr: `invalid sandbox image "mcr.microsoft.com/oss/kubernetes/pause 3.6"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &aksnodeconfigv1.Configuration{
+				KubeBinaryConfig: &aksnodeconfigv1.KubeBinaryConfig{PodInfraContainerImageUrl: "mcr.microsoft.com/oss/kubernetes/pause:3.6"},
+				ContainerdConfig: &aksnodeconfigv1.ContainerdConfig{SandboxImage: tt.sandbox},
+				KubeletConfig: &aksnodeconfigv1.KubeletConfig{
+					KubeletFlags: map[string]string{"--pod-infra-container-image": "mcr.microsoft.com/oss/kubernetes/pause:3.6"},
+				},
+			}
+			if tt.wantErr != "" {
+				assert.EqualError(t, ValidateSandboxImage(config), tt.wantErr)
+				return
+			}
+			assert.NoError(t, ValidateSandboxImage(config))
+			assert.Equal(t, tt.want, SandboxImage(config))
+			assert.Equal(t, tt.want, containerdTemplateConfig(config).GetKubeBinaryConfig().GetPodInfraContainerImageUrl())
+			assert.Equal(t, tt.wantFlag, kubeletFlags(config)["--pod-infra-container-image"])
+			assert.Equal(t, tt.wantPinAs, PinnedImageName(SandboxImage(config)))
+			assert.Equal(t, "mcr.microsoft.com/oss/kubernetes/pause:3.6", config.GetKubeBinaryConfig().GetPodInfraContainerImageUrl(),
+				"the configuration isn't modified")
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+const (
+	// SSHDConfigDropIn is the sshd_config drop-in of the SSH access policy. sshd uses the first value of a setting, the
+	// drop-ins are included before the settings of sshd_config.
+	SSHDConfigDropIn = "/etc/ssh/sshd_config.d/50-aks-node-controller.conf"
+	// AADCertHandler validates the certificates of the Microsoft Entra ID users, it is installed by aadsshlogin.
+	AADCertHandler = "/usr/sbin/aad_certhandler"
+)
+
+// SSHDisabled returns whether sshd is stopped, by the SSH access policy or the legacy enable_ssh field.
+func SSHDisabled(config *aksnodeconfigv1.Configuration) bool {
+	return config.GetSshConfig().GetMode() == aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_DISABLED || getDisableSSH(config)
+}
+
+// HasSSHDConfigDropIn returns whether provisioning writes the sshd_config drop-in of the SSH access policy.
+func HasSSHDConfigDropIn(config *aksnodeconfigv1.Configuration) bool {
+	switch config.GetSshConfig().GetMode() {
+	case aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_PUBLIC_KEY, aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_ENTRA_ID:
+		return true
+	case aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_UNSPECIFIED:
+		return len(config.GetSshConfig().GetAllowedCidrs()) > 0 && !SSHDisabled(config)
+	default:
+		return false
+	}
+}
+
+// ValidateSSHConfig checks that the SSH access policy doesn't contradict enable_ssh and the allowed CIDRs.
+func ValidateSSHConfig(config *aksnodeconfigv1.Configuration) error {
+	sshConfig := config.GetSshConfig()
+	mode := sshConfig.GetMode()
+	if _, ok := aksnodeconfigv1.SshAccessMode_name[int32(mode)]; !ok {
+		return fmt.Errorf("unknown SSH access mode %d", mode)
+	}
+	if mode != aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_UNSPECIFIED && config.EnableSsh != nil &&
+		config.GetEnableSsh() == (mode == aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_DISABLED) {
+		return fmt.Errorf("SSH access mode %v contradicts enable_ssh=%v", mode, config.GetEnableSsh())
+	}
+	if len(sshConfig.GetAllowedCidrs()) > 0 && SSHDisabled(config) {
+		return errors.New("allowed CIDRs can't be set when SSH is disabled")
+	}
+	for _, cidr := range sshConfig.GetAllowedCidrs() {
+		if prefix, err := netip.ParsePrefix(cidr); err != nil || prefix.Masked() != prefix {
+			return fmt.Errorf("invalid allowed CIDR %q", cidr)
+		}
+	}
+	return nil
+}
+
+// SSHDConfigDropInContent returns the sshd_config drop-in of the SSH access policy.
+func SSHDConfigDropInContent(config *aksnodeconfigv1.Configuration) string {
+	sshConfig := config.GetSshConfig()
+	lines := []string{"# Generated by aks-node-controller"}
+	switch sshConfig.GetMode() {
+	case aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_PUBLIC_KEY:
+		lines = append(lines,
+			"PasswordAuthentication no",
+			"KbdInteractiveAuthentication no",
+			"PermitRootLogin no")
+	case aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_ENTRA_ID:
+		lines = append(lines,
+			"PasswordAuthentication no",
+			"KbdInteractiveAuthentication no",
+			"PermitRootLogin no",
+			fmt.Sprintf("AuthorizedKeysCommand %s %%u %%k", AADCertHandler),
+			"AuthorizedKeysCommandUser root")
+	}
+	if cidrs := sshConfig.GetAllowedCidrs(); len(cidrs) > 0 {
+		users := make([]string, len(cidrs))
+		for i, cidr := range cidrs {
+			users[i] = "*@" + cidr
+		}
+		lines = append(lines, "AllowUsers "+strings.Join(users, " "))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
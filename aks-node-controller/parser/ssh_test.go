@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestValidateSSHConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *aksnodeconfigv1.Configuration
+		wantErr string
+	}{
+		{
+			name:   "not set",
+			config: &aksnodeconfigv1.Configuration{EnableSsh: proto.Bool(false)},
+		},
+		{
+			name: "public key with allowed CIDRs",
+			config: &aksnodeconfigv1.Configuration{SshConfig: &aksnodeconfigv1.SshConfig{
+				Mode:         aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_PUBLIC_KEY,
+				AllowedCidrs: []string{"10.0.0.0/8", "fd00::/8"},
+			}},
+		},
+		{
+			name: "disabled with enable_ssh",
+			config: &aksnodeconfigv1.Configuration{
+				EnableSsh: proto.Bool(true),
+				SshConfig: &aksnodeconfigv1.SshConfig{Mode: aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_DISABLED},
+			},
+			wantErr: "SSH access mode SSH_ACCESS_MODE_DISABLED contradicts enable_ssh=true",
+		},
+		{
+			name: "entra ID without enable_ssh",
+			config: &aksnodeconfigv1.Configuration{
+				EnableSsh: proto.Bool(false),
+				SshConfig: &aksnodeconfigv1.SshConfig{Mode: aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_ENTRA_ID},
+			},
+			wantErr: "SSH access mode SSH_ACCESS_MODE_ENTRA_ID contradicts enable_ssh=false",
+		},
+		{
+			name: "allowed CIDRs of a disabled sshd",
+			config: &aksnodeconfigv1.Configuration{
+				EnableSsh: proto.Bool(false),
+				SshConfig: &aksnodeconfigv1.SshConfig{AllowedCidrs: []string{"10.0.0.0/8"}},
+			},
+			wantErr: "allowed CIDRs can't be set when SSH is disabled",
+		},
+		{
+			name:    "host bits set",
+			config:  &aksnodeconfigv1.Configuration{SshConfig: &aksnodeconfigv1.SshConfig{AllowedCidrs: []string{"10.0.0.1/8"}}},
+			wantErr: `invalid allowed CIDR "10.0.0.1/8"`,
+		},
+		{
+			name:    "unknown mode",
+			config:  &aksnodeconfigv1.Configuration{SshConfig: &aksnodeconfigv1.SshConfig{Mode: 42}},
+			wantErr: "unknown SSH access mode 42",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSSHConfig(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSSHDisabled(t *testing.T) {
+	assert.False(t, SSHDisabled(&aksnodeconfigv1.Configuration{}))
+	assert.True(t, SSHDisabled(&aksnodeconfigv1.Configuration{EnableSsh: proto.Bool(false)}))
+	assert.True(t, SSHDisabled(&aksnodeconfigv1.Configuration{
+		SshConfig: &aksnodeconfigv1.SshConfig{Mode: aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_DISABLED},
+	}))
+}
+
+func TestSSHDConfigDropInContent(t *testing.T) {
+	config := &aksnodeconfigv1.Configuration{SshConfig: &aksnodeconfigv1.SshConfig{
+		Mode:         aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_ENTRA_ID,
+		AllowedCidrs: []string{"10.0.0.0/8", "192.168.1.0/24"},
+	}}
+	assert.True(t, HasSSHDConfigDropIn(config))
+	assert.Equal(t, `# Generated by aks-node-controller
+PasswordAuthentication no
+KbdInteractiveAuthentication no
+PermitRootLogin no
+AuthorizedKeysCommand /usr/sbin/aad_certhandler %u %k
+AuthorizedKeysCommandUser root
+AllowUsers *@10.0.0.0/8 *@192.168.1.0/24
+`, SSHDConfigDropInContent(config))
+
+	assert.False(t, HasSSHDConfigDropIn(&aksnodeconfigv1.Configuration{}))
+}
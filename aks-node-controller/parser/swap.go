@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/pkg/agent/kubeversion"
+)
+
+// SwapKubeletConfigDropIn is the kubelet config drop-in letting pods use the swap of swap_config.
+const SwapKubeletConfigDropIn = "50-aks-swap.conf"
+
+// swapBehaviors are the kubelet memorySwap.swapBehavior values.
+var swapBehaviors = map[aksnodeconfigv1.SwapBehavior]string{
+	aksnodeconfigv1.SwapBehavior_SWAP_BEHAVIOR_UNSPECIFIED:  "LimitedSwap",
+	aksnodeconfigv1.SwapBehavior_SWAP_BEHAVIOR_LIMITED_SWAP: "LimitedSwap",
+	aksnodeconfigv1.SwapBehavior_SWAP_BEHAVIOR_NO_SWAP:      "NoSwap",
+}
+
+// HasSwapConfig returns whether aks-node-controller configures the swap of the node.
+func HasSwapConfig(config *aksnodeconfigv1.Configuration) bool {
+	return config.GetCustomLinuxOsConfig().GetSwapConfig().GetBackend() != aksnodeconfigv1.SwapBackend_SWAP_BACKEND_UNSPECIFIED
+}
+
+// ValidateSwapConfig checks that the swap config can be applied. The NodeSwap feature is enabled by default from
+// Kubernetes 1.30, which also reads the kubelet config drop-in directory, and pods can only be limited in their swap
+// usage with cgroup v2.
+func ValidateSwapConfig(config *aksnodeconfigv1.Configuration) error {
+	if !HasSwapConfig(config) {
+		return nil
+	}
+	osConfig := config.GetCustomLinuxOsConfig()
+	if osConfig.GetSwapConfig().GetSizeMb() <= 0 {
+		return errors.New("swap size must be positive")
+	}
+	if osConfig.GetEnableSwapConfig() {
+		return errors.New("swap config can't be combined with enable_swap_config")
+	}
+	supported, err := kubeversion.GreaterOrEqual(config.GetKubernetesVersion(), "1.30.0")
+	if err != nil {
+		return fmt.Errorf("swap: %w", err)
+	}
+	if !supported {
+		return fmt.Errorf("swap requires Kubernetes 1.30 or later, got %q", config.GetKubernetesVersion())
+	}
+	if config.NeedsCgroupv2 != nil && !config.GetNeedsCgroupv2() {
+		return errors.New("swap requires cgroup v2")
+	}
+	if failSwapOn, ok := config.GetKubeletConfig().GetKubeletFlags()["--fail-swap-on"]; ok && failSwapOn != "false" {
+		return fmt.Errorf("swap requires --fail-swap-on=false, got %q", failSwapOn)
+	}
+	if _, ok := config.GetKubeletConfig().GetKubeletConfigDropIns()[SwapKubeletConfigDropIn]; ok {
+		return fmt.Errorf("kubelet config drop-in %s is reserved for the swap config", SwapKubeletConfigDropIn)
+	}
+	return nil
+}
+
+// KubeletConfigDropIns returns the kubelet config drop-in files keyed by file name: the ones of the kubelet config
+// and the ones generated from the rest of the configuration.
+func KubeletConfigDropIns(config *aksnodeconfigv1.Configuration) map[string]string {
+	dropIns := map[string]string{}
+	for name, content := range config.GetKubeletConfig().GetKubeletConfigDropIns() {
+		dropIns[name] = content
+	}
+	if HasSwapConfig(config) {
+		dropIns[SwapKubeletConfigDropIn] = swapKubeletConfig(config.GetCustomLinuxOsConfig().GetSwapConfig())
+	}
+	return dropIns
+}
+
+func swapKubeletConfig(swapConfig *aksnodeconfigv1.SwapConfig) string {
+	return strings.Join([]string{
+		"apiVersion: kubelet.config.k8s.io/v1beta1",
+		"kind: KubeletConfiguration",
+		"failSwapOn: false",
+		"memorySwap:",
+		"  swapBehavior: " + swapBehaviors[swapConfig.GetKubeletSwapBehavior()],
+	}, "\n") + "\n"
+}
@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSwapConfig(t *testing.T) {
+	swap := func(size int32) *aksnodeconfigv1.CustomLinuxOsConfig {
+		return &aksnodeconfigv1.CustomLinuxOsConfig{
+			SwapConfig: &aksnodeconfigv1.SwapConfig{Backend: aksnodeconfigv1.SwapBackend_SWAP_BACKEND_FILE, SizeMb: size},
+		}
+	}
+	tests := []struct {
+		name    string
+		config  *aksnodeconfigv1.Configuration
+		wantErr string
+	}{
+		{
+			name:   "no swap",
+			config: &aksnodeconfigv1.Configuration{KubernetesVersion: "1.29.0"},
+		},
+		{
+			name:   "swap file",
+			config: &aksnodeconfigv1.Configuration{KubernetesVersion: "1.30.0", CustomLinuxOsConfig: swap(2048)},
+		},
+		{
+			name:    "no size",
+			config:  &aksnodeconfigv1.Configuration{KubernetesVersion: "1.30.0", CustomLinuxOsConfig: swap(0)},
+			wantErr: "swap size must be positive",
+		},
+		{
+			name:    "old kubernetes version",
+			config:  &aksnodeconfigv1.Configuration{KubernetesVersion: "1.29.5", CustomLinuxOsConfig: swap(2048)},
+			wantErr: `swap requires Kubernetes 1.30 or later, got "1.29.5"`,
+		},
+		{
+			name: "cgroup v1",
+			config: &aksnodeconfigv1.Configuration{
+				KubernetesVersion:   "1.30.0",
+				CustomLinuxOsConfig: swap(2048),
+				NeedsCgroupv2:       ToPtr(false),
+			},
+			wantErr: "swap requires cgroup v2",
+		},
+		{
+			name: "legacy swap file",
+			config: &aksnodeconfigv1.Configuration{
+				KubernetesVersion: "1.30.0",
+				CustomLinuxOsConfig: &aksnodeconfigv1.CustomLinuxOsConfig{
+					EnableSwapConfig: true,
+					SwapFileSize:     1024,
+					SwapConfig:       swap(2048).SwapConfig,
+				},
+			},
+			wantErr: "swap config can't be combined with enable_swap_config",
+		},
+		{
+			name: "fail swap on",
+			config: &aksnodeconfigv1.Configuration{
+				KubernetesVersion:   "1.30.0",
+				CustomLinuxOsConfig: swap(2048),
+				KubeletConfig:       &aksnodeconfigv1.KubeletConfig{KubeletFlags: map[string]string{"--fail-swap-on": "true"}},
+			},
+			wantErr: `swap requires --fail-swap-on=false, got "true"`,
+		},
+		{
+			name: "reserved drop-in",
+			config: &aksnodeconfigv1.Configuration{
+				KubernetesVersion:   "1.30.0",
+				CustomLinuxOsConfig: swap(2048),
+				KubeletConfig:       &aksnodeconfigv1.KubeletConfig{KubeletConfigDropIns: map[string]string{SwapKubeletConfigDropIn: ""}},
+			},
+			wantErr: "kubelet config drop-in 50-aks-swap.conf is reserved for the swap config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSwapConfig(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKubeletConfigDropIns(t *testing.T) {
+	config := &aksnodeconfigv1.Configuration{
+		KubeletConfig: &aksnodeconfigv1.KubeletConfig{KubeletConfigDropIns: map[string]string{"10-max-pods.conf": "maxPods: 50"}},
+	}
+	assert.Equal(t, map[string]string{"10-max-pods.conf": "maxPods: 50"}, KubeletConfigDropIns(config))
+
+	config.CustomLinuxOsConfig = &aksnodeconfigv1.CustomLinuxOsConfig{
+		SwapConfig: &aksnodeconfigv1.SwapConfig{
+			Backend:             aksnodeconfigv1.SwapBackend_SWAP_BACKEND_ZRAM,
+			SizeMb:              1024,
+			KubeletSwapBehavior: aksnodeconfigv1.SwapBehavior_SWAP_BEHAVIOR_NO_SWAP,
+		},
+	}
+	assert.Equal(t, map[string]string{
+		"10-max-pods.conf": "maxPods: 50",
+		SwapKubeletConfigDropIn: `apiVersion: kubelet.config.k8s.io/v1beta1
+kind: KubeletConfiguration
+failSwapOn: false
+memorySwap:
+  swapBehavior: NoSwap
+`,
+	}, KubeletConfigDropIns(config))
+}
@@ -0,0 +1,152 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// allowedSysctlPrefixes are the sysctl namespaces which can be tuned through additional_sysctls.
+var allowedSysctlPrefixes = []string{
+	"net.core.",
+	"net.ipv4.",
+	"net.ipv6.",
+	"net.netfilter.",
+	"fs.inotify.",
+	"vm.",
+}
+
+// typedSysctlKeys are the sysctls which are set by a dedicated SysctlConfig field or defaulted by AKS.
+var typedSysctlKeys = map[string]bool{
+	"net.core.message_burst":             true,
+	"net.core.message_cost":              true,
+	"net.core.somaxconn":                 true,
+	"net.core.netdev_max_backlog":        true,
+	"net.core.rmem_default":              true,
+	"net.core.rmem_max":                  true,
+	"net.core.wmem_default":              true,
+	"net.core.wmem_max":                  true,
+	"net.core.optmem_max":                true,
+	"net.ipv4.tcp_retries2":              true,
+	"net.ipv4.tcp_max_syn_backlog":       true,
+	"net.ipv4.tcp_max_tw_buckets":        true,
+	"net.ipv4.tcp_fin_timeout":           true,
+	"net.ipv4.tcp_keepalive_time":        true,
+	"net.ipv4.tcp_keepalive_probes":      true,
+	"net.ipv4.tcp_keepalive_intvl":       true,
+	"net.ipv4.tcp_tw_reuse":              true,
+	"net.ipv4.ip_local_port_range":       true,
+	"net.ipv4.ip_local_reserved_ports":   true,
+	"net.ipv4.neigh.default.gc_thresh1":  true,
+	"net.ipv4.neigh.default.gc_thresh2":  true,
+	"net.ipv4.neigh.default.gc_thresh3":  true,
+	"net.netfilter.nf_conntrack_max":     true,
+	"net.netfilter.nf_conntrack_buckets": true,
+	"fs.inotify.max_user_watches":        true,
+	"vm.max_map_count":                   true,
+	"vm.swappiness":                      true,
+	"vm.vfs_cache_pressure":              true,
+}
+
+// legacyKernelModules are the filesystem and network protocol modules which are not loaded on demand with
+// disable_legacy_kernel_modules, following the CIS benchmark.
+var legacyKernelModules = []string{
+	"cramfs",
+	"freevxfs",
+	"jffs2",
+	"hfs",
+	"hfsplus",
+	"dccp",
+	"sctp",
+	"rds",
+	"tipc",
+}
+
+// legacyKernelModuleSysctls are set with disable_legacy_kernel_modules, tty line disciplines are kernel modules
+// which unprivileged users can otherwise have loaded by opening a tty.
+var legacyKernelModuleSysctls = map[string]interface{}{
+	"dev.tty.ldisc_autoload": 0,
+}
+
+var (
+	sysctlKeyRegex         = regexp.MustCompile(`^[a-z0-9_]+(\.[a-zA-Z0-9_-]+)+$`)
+	kernelModuleNameRegex  = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+	kernelModuleParamRegex = regexp.MustCompile(`^[a-zA-Z0-9_]+=\S+$`)
+)
+
+// ValidateCustomLinuxOsConfig checks the additional sysctls and kernel modules of the custom Linux OS config.
+func ValidateCustomLinuxOsConfig(config *aksnodeconfigv1.Configuration) error {
+	osConfig := config.GetCustomLinuxOsConfig()
+	for key, value := range osConfig.GetSysctlConfig().GetAdditionalSysctls() {
+		switch {
+		case !sysctlKeyRegex.MatchString(key) || !hasAllowedSysctlPrefix(key):
+			return fmt.Errorf("sysctl %q is not allowed, allowed prefixes are %s", key, strings.Join(allowedSysctlPrefixes, ", "))
+		case typedSysctlKeys[key]:
+			return fmt.Errorf("sysctl %q must be set through its SysctlConfig field", key)
+		case value == "" || strings.ContainsAny(value, "\n\r="):
+			return fmt.Errorf("invalid value %q of sysctl %q", value, key)
+		}
+	}
+	seen := map[string]bool{}
+	for _, module := range osConfig.GetKernelModules() {
+		name := module.GetName()
+		if !kernelModuleNameRegex.MatchString(name) {
+			return fmt.Errorf("invalid kernel module name %q", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate kernel module %s", name)
+		}
+		seen[name] = true
+		if osConfig.GetDisableLegacyKernelModules() && slices.Contains(legacyKernelModules, name) {
+			return fmt.Errorf("kernel module %s is disabled by disable_legacy_kernel_modules", name)
+		}
+		for _, param := range module.GetParameters() {
+			if !kernelModuleParamRegex.MatchString(param) {
+				return fmt.Errorf("invalid parameter %q of kernel module %s, it must be key=value", param, name)
+			}
+		}
+	}
+	return nil
+}
+
+func hasAllowedSysctlPrefix(key string) bool {
+	for _, prefix := range allowedSysctlPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// KernelModulesLoadContent returns the /etc/modules-load.d file loading the kernel modules, one name per line.
+func KernelModulesLoadContent(config *aksnodeconfigv1.Configuration) string {
+	var b strings.Builder
+	for _, module := range config.GetCustomLinuxOsConfig().GetKernelModules() {
+		b.WriteString(module.GetName() + "\n")
+	}
+	return b.String()
+}
+
+// KernelModulesOptionsContent returns the /etc/modprobe.d file with the parameters of the kernel modules.
+func KernelModulesOptionsContent(config *aksnodeconfigv1.Configuration) string {
+	var b strings.Builder
+	for _, module := range config.GetCustomLinuxOsConfig().GetKernelModules() {
+		if len(module.GetParameters()) > 0 {
+			fmt.Fprintf(&b, "options %s %s\n", module.GetName(), strings.Join(module.GetParameters(), " "))
+		}
+	}
+	return b.String()
+}
+
+// LegacyKernelModulesBlacklistContent returns the /etc/modprobe.d file preventing the legacy kernel modules from
+// being loaded, explicitly or on demand.
+func LegacyKernelModulesBlacklistContent() string {
+	var b strings.Builder
+	for _, module := range legacyKernelModules {
+		fmt.Fprintf(&b, "install %s /bin/false\nblacklist %s\n", module, module)
+	}
+	return b.String()
+}
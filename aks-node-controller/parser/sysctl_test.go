@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"encoding/base64"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCustomLinuxOsConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		sysctls map[string]string
+		modules []*aksnodeconfigv1.KernelModule
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			sysctls: map[string]string{"net.core.busy_poll": "50", "fs.inotify.max_user_instances": "8192"},
+			modules: []*aksnodeconfigv1.KernelModule{{Name: "nf_conntrack", Parameters: []string{"hashsize=262144"}}},
+		},
+		{
+			name:    "key outside of the allowed namespaces",
+			sysctls: map[string]string{"kernel.panic": "10"},
+			wantErr: `sysctl "kernel.panic" is not allowed, allowed prefixes are net.core., net.ipv4., net.ipv6., net.netfilter., fs.inotify., vm.`,
+		},
+		{
+			name:    "key with a dedicated field",
+			sysctls: map[string]string{"net.core.somaxconn": "4096"},
+			wantErr: `sysctl "net.core.somaxconn" must be set through its SysctlConfig field`,
+		},
+		{
+			name:    "value injecting another sysctl",
+			sysctls: map[string]string{"net.core.busy_poll": "50\nkernel.panic=10"},
+			wantErr: `invalid value "50\nkernel.panic=10" of sysctl "net.core.busy_poll"`,
+		},
+		{
+			name:    "invalid module name",
+			modules: []*aksnodeconfigv1.KernelModule{{Name: "../nf_conntrack"}},
+			wantErr: `invalid kernel module name "../nf_conntrack"`,
+		},
+		{
+			name:    "duplicate module",
+			modules: []*aksnodeconfigv1.KernelModule{{Name: "br_netfilter"}, {Name: "br_netfilter"}},
+			wantErr: "duplicate kernel module br_netfilter",
+		},
+		{
+			name:    "invalid module parameter",
+			modules: []*aksnodeconfigv1.KernelModule{{Name: "nf_conntrack", Parameters: []string{"hashsize"}}},
+			wantErr: `invalid parameter "hashsize" of kernel module nf_conntrack, it must be key=value`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &aksnodeconfigv1.Configuration{CustomLinuxOsConfig: &aksnodeconfigv1.CustomLinuxOsConfig{
+				SysctlConfig:  &aksnodeconfigv1.SysctlConfig{AdditionalSysctls: tt.sysctls},
+				KernelModules: tt.modules,
+			}}
+			err := ValidateCustomLinuxOsConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestKernelModulesContent(t *testing.T) {
+	config := &aksnodeconfigv1.Configuration{CustomLinuxOsConfig: &aksnodeconfigv1.CustomLinuxOsConfig{
+		KernelModules: []*aksnodeconfigv1.KernelModule{
+			{Name: "nf_conntrack", Parameters: []string{"hashsize=262144", "expect_hashsize=1024"}},
+			{Name: "br_netfilter"},
+		},
+	}}
+	assert.Equal(t, "nf_conntrack\nbr_netfilter\n", KernelModulesLoadContent(config))
+	assert.Equal(t, "options nf_conntrack hashsize=262144 expect_hashsize=1024\n", KernelModulesOptionsContent(config))
+}
+
+func TestLegacyKernelModules(t *testing.T) {
+	osConfig := &aksnodeconfigv1.CustomLinuxOsConfig{DisableLegacyKernelModules: true}
+	content := LegacyKernelModulesBlacklistContent()
+	assert.Contains(t, content, "install cramfs /bin/false\nblacklist cramfs\n")
+	assert.Contains(t, content, "install sctp /bin/false\nblacklist sctp\n")
+
+	sysctls, err := base64.StdEncoding.DecodeString(getCustomLinuxOsSysctlContent(osConfig))
+	assert.NoError(t, err)
+	assert.Contains(t, string(sysctls), "dev.tty.ldisc_autoload=0\n")
+	sysctls, err = base64.StdEncoding.DecodeString(getCustomLinuxOsSysctlContent(nil))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(sysctls), "dev.tty.ldisc_autoload")
+
+	osConfig.KernelModules = []*aksnodeconfigv1.KernelModule{{Name: "sctp"}}
+	assert.EqualError(t, ValidateCustomLinuxOsConfig(&aksnodeconfigv1.Configuration{CustomLinuxOsConfig: osConfig}),
+		"kernel module sctp is disabled by disable_legacy_kernel_modules")
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// ptpHypervDevice is the PTP clock of the Hyper-V host, which Azure VMs synchronize with by default.
+const ptpHypervDevice = "/dev/ptp_hyperv"
+
+// HasTimeSyncConfig returns whether provisioning replaces the chrony configuration of the node image.
+func HasTimeSyncConfig(config *aksnodeconfigv1.Configuration) bool {
+	return config.GetTimeSyncConfig() != nil
+}
+
+// ValidateTimeSync checks the NTP servers and the maximum distance, and that chrony has at least one source.
+func ValidateTimeSync(config *aksnodeconfigv1.Configuration) error {
+	timeSync := config.GetTimeSyncConfig()
+	if timeSync == nil {
+		return nil
+	}
+	for _, server := range timeSync.GetNtpServers() {
+		if net.ParseIP(server) == nil && !dnsSubdomainRegex.MatchString(server) {
+			return fmt.Errorf("invalid NTP server %q, it must be a host name or an IP address", server)
+		}
+	}
+	if timeSync.GetMaxDistance() < 0 {
+		return fmt.Errorf("invalid max distance %v, it must not be negative", timeSync.GetMaxDistance())
+	}
+	if !usePTPDevice(timeSync) && len(timeSync.GetNtpServers()) == 0 {
+		return errors.New("time sync needs NTP servers when the PTP device isn't used")
+	}
+	return nil
+}
+
+func usePTPDevice(timeSync *aksnodeconfigv1.TimeSyncConfig) bool {
+	return timeSync.UsePtpDevice == nil || timeSync.GetUsePtpDevice()
+}
+
+// ChronyConfig returns the chrony configuration of the time sync config. The clock is stepped whenever it is off by
+// more than a second, a VM resumed from a host maintenance can be far off.
+func ChronyConfig(config *aksnodeconfigv1.Configuration) string {
+	timeSync := config.GetTimeSyncConfig()
+	lines := []string{
+		"# Generated by aks-node-controller",
+		"driftfile /var/lib/chrony/chrony.drift",
+		"makestep 1.0 -1",
+		"rtcsync",
+	}
+	if usePTPDevice(timeSync) {
+		lines = append(lines, fmt.Sprintf("refclock PHC %s poll 3 dpoll -2 offset 0 stratum 2", ptpHypervDevice))
+	}
+	for _, server := range timeSync.GetNtpServers() {
+		lines = append(lines, fmt.Sprintf("server %s iburst", server))
+	}
+	if timeSync.GetMaxDistance() > 0 {
+		lines = append(lines, "maxdistance "+strconv.FormatFloat(timeSync.GetMaxDistance(), 'f', -1, 64))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestValidateTimeSync(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeSync *aksnodeconfigv1.TimeSyncConfig
+		wantErr  string
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:     "PTP device and NTP servers",
+			timeSync: &aksnodeconfigv1.TimeSyncConfig{NtpServers: []string{"time.contoso.com", "10.0.0.1"}, MaxDistance: 16},
+		},
+		{
+			name:     "invalid server",
+			timeSync: &aksnodeconfigv1.TimeSyncConfig{NtpServers: []string{"time server"}},
+			wantErr:  `invalid NTP server "time server", it must be a host name or an IP address`,
+		},
+		{
+			name:     "negative max distance",
+			timeSync: &aksnodeconfigv1.TimeSyncConfig{MaxDistance: -1},
+			wantErr:  "invalid max distance -1, it must not be negative",
+		},
+		{
+			name:     "no source",
+			timeSync: &aksnodeconfigv1.TimeSyncConfig{UsePtpDevice: proto.Bool(false)},
+			wantErr:  "time sync needs NTP servers when the PTP device isn't used",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTimeSync(&aksnodeconfigv1.Configuration{TimeSyncConfig: tt.timeSync})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChronyConfig(t *testing.T) {
+	config := &aksnodeconfigv1.Configuration{
+		TimeSyncConfig: &aksnodeconfigv1.TimeSyncConfig{NtpServers: []string{"time.contoso.com"}, MaxDistance: 1.5},
+	}
+	assert.Equal(t, `# Generated by aks-node-controller
+driftfile /var/lib/chrony/chrony.drift
+makestep 1.0 -1
+rtcsync
+refclock PHC /dev/ptp_hyperv poll 3 dpoll -2 offset 0 stratum 2
+server time.contoso.com iburst
+maxdistance 1.5
+`, ChronyConfig(config))
+
+	config.TimeSyncConfig.UsePtpDevice = proto.Bool(false)
+	config.TimeSyncConfig.MaxDistance = 0
+	assert.NotContains(t, ChronyConfig(config), "refclock")
+	assert.NotContains(t, ChronyConfig(config), "maxdistance")
+}
@@ -0,0 +1,250 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+const (
+	// WindowsCSEScript is the entry point of the Windows CSE scripts, which the node image ships.
+	WindowsCSEScript = `C:\AzureData\CustomDataSetupScript.ps1`
+	// WindowsCSEResultFile is where the Windows CSE scripts write their exit code and output.
+	WindowsCSEResultFile = `C:\AzureData\CustomDataSetupScript.log`
+
+	defaultWindowsNetworkName = "azure"
+)
+
+// windowsKubeletFlagOverrides are the kubelet flags which don't apply to Windows, where kubelet neither manages cgroups
+// nor reads a resolv.conf.
+var windowsKubeletFlagOverrides = map[string]string{
+	"--cgroups-per-qos":          "false",
+	"--enforce-node-allocatable": `""`,
+	"--resolv-conf":              `""`,
+}
+
+// IsWindows returns whether the configuration provisions a Windows node.
+func IsWindows(config *aksnodeconfigv1.Configuration) bool {
+	return config.GetWindowsConfig() != nil
+}
+
+// ValidateWindowsConfig checks the package URLs, kube-proxy flags and HNS network of a Windows node, and that no Linux
+// only setting is set.
+func ValidateWindowsConfig(config *aksnodeconfigv1.Configuration) error {
+	windows := config.GetWindowsConfig()
+	if windows == nil {
+		return nil
+	}
+	switch {
+	case config.GetCustomLinuxOsConfig() != nil:
+		return errors.New("custom linux os config isn't supported on Windows nodes")
+	case config.GetTimeSyncConfig() != nil:
+		return errors.New("time sync config isn't supported on Windows nodes")
+	case config.GetSshConfig() != nil:
+		return errors.New("ssh config isn't supported on Windows nodes")
+	case config.GetLocalDiskConfig() != nil:
+		return errors.New("local disk config isn't supported on Windows nodes")
+	}
+	if windows.GetKubernetesPackageUrl() == "" {
+		return errors.New("kubernetes package URL is required")
+	}
+	for name, packageURL := range map[string]string{
+		"CSE scripts package": windows.GetCseScriptsPackageUrl(),
+		"kubernetes package":  windows.GetKubernetesPackageUrl(),
+		"credential provider": windows.GetCredentialProviderUrl(),
+		"containerd package":  windows.GetContainerdPackageUrl(),
+		"CSI proxy":           windows.GetCsiProxyUrl(),
+	} {
+		if packageURL == "" {
+			continue
+		}
+		if u, err := url.Parse(packageURL); err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("invalid %s URL %q, it must be an https URL", name, packageURL)
+		}
+	}
+	if windows.GetEnableCsiProxy() && windows.GetCsiProxyUrl() == "" {
+		return errors.New("CSI proxy URL is required when the CSI proxy is enabled")
+	}
+	for flag := range windows.GetKubeProxyFlags() {
+		if !strings.HasPrefix(flag, "--") {
+			return fmt.Errorf("invalid kube-proxy flag %q, it must start with --", flag)
+		}
+	}
+	for _, cidr := range windows.GetHnsConfig().GetOutboundNatExceptions() {
+		if _, err := netip.ParsePrefix(cidr); err != nil {
+			return fmt.Errorf("invalid outbound NAT exception %q, it must be a CIDR", cidr)
+		}
+	}
+	if err := ValidateWindowsContainerdConfig(config); err != nil {
+		return fmt.Errorf("invalid containerd config: %w", err)
+	}
+	if err := ValidateWindowsHardeningProfile(config); err != nil {
+		return err
+	}
+	if err := ValidateGmsaConfig(config); err != nil {
+		return err
+	}
+	return ValidateWindowsUpdateConfig(config)
+}
+
+// WindowsKubeletArgs returns the kubelet flags of a Windows node, sorted by name.
+func WindowsKubeletArgs(config *aksnodeconfigv1.Configuration) []string {
+	flags := kubeletFlags(config)
+	for flag, value := range windowsKubeletFlagOverrides {
+		flags[flag] = value
+	}
+	if labels := config.GetKubeletConfig().GetKubeletNodeLabels(); len(labels) > 0 {
+		flags["--node-labels"] = createSortedKeyValuePairs(labels, ",")
+	}
+	gmsaKubeletFlags(config, flags)
+	return sortedFlags(flags)
+}
+
+// WindowsKubeProxyArgs returns the kube-proxy flags of a Windows node, sorted by name. kube-proxy runs in kernelspace
+// mode against the HNS network of the node.
+func WindowsKubeProxyArgs(config *aksnodeconfigv1.Configuration) []string {
+	flags := map[string]string{
+		"--proxy-mode":   "kernelspace",
+		"--network-name": WindowsNetworkName(config),
+	}
+	if WindowsNetworkMode(config) == aksnodeconfigv1.WindowsNetworkMode_WINDOWS_NETWORK_MODE_OVERLAY {
+		// the source VIP of the overlay network is set up by the CSE scripts once the network exists.
+		flags["--feature-gates"] = "WinOverlay=true"
+	}
+	for flag, value := range config.GetWindowsConfig().GetKubeProxyFlags() {
+		flags[flag] = value
+	}
+	return sortedFlags(flags)
+}
+
+func sortedFlags(flags map[string]string) []string {
+	args := make([]string, 0, len(flags))
+	for flag, value := range flags {
+		args = append(args, flag+"="+value)
+	}
+	sort.Strings(args)
+	return args
+}
+
+// WindowsNetworkName returns the name of the HNS network of the node.
+func WindowsNetworkName(config *aksnodeconfigv1.Configuration) string {
+	if name := config.GetWindowsConfig().GetHnsConfig().GetNetworkName(); name != "" {
+		return name
+	}
+	return defaultWindowsNetworkName
+}
+
+// WindowsNetworkMode returns the mode of the HNS network of the node, L2Bridge unless overlay is set.
+func WindowsNetworkMode(config *aksnodeconfigv1.Configuration) aksnodeconfigv1.WindowsNetworkMode {
+	if mode := config.GetWindowsConfig().GetHnsConfig().GetNetworkMode(); mode != aksnodeconfigv1.WindowsNetworkMode_WINDOWS_NETWORK_MODE_UNSPECIFIED {
+		return mode
+	}
+	return aksnodeconfigv1.WindowsNetworkMode_WINDOWS_NETWORK_MODE_L2BRIDGE
+}
+
+func getStringFromWindowsNetworkMode(mode aksnodeconfigv1.WindowsNetworkMode) string {
+	if mode == aksnodeconfigv1.WindowsNetworkMode_WINDOWS_NETWORK_MODE_OVERLAY {
+		return "Overlay"
+	}
+	return "L2Bridge"
+}
+
+// WindowsHNSNetworkParameters returns the parameters of the HNS network the Windows CSE scripts create.
+func WindowsHNSNetworkParameters(config *aksnodeconfigv1.Configuration) map[string]string {
+	hns := config.GetWindowsConfig().GetHnsConfig()
+	return map[string]string{
+		"NetworkName":                 WindowsNetworkName(config),
+		"NetworkMode":                 getStringFromWindowsNetworkMode(WindowsNetworkMode(config)),
+		"NetworkPlugin":               getStringFromNetworkPluginType(config.GetNetworkConfig().GetNetworkPlugin()),
+		"OutboundNatExceptions":       getStringifiedStringArray(hns.GetOutboundNatExceptions(), ","),
+		"IsDisableWindowsOutboundNat": fmt.Sprintf("%v", hns.GetDisableOutboundNat()),
+		"IsSkipCleanupNetwork":        fmt.Sprintf("%v", hns.GetSkipCleanupNetwork()),
+	}
+}
+
+// windowsCSEParameters returns the parameters of the Windows CSE scripts. Secrets aren't passed as parameters, which
+// any process of the node can list, but in the environment of windowsCSEEnv.
+func windowsCSEParameters(config *aksnodeconfigv1.Configuration) map[string]string {
+	windows := config.GetWindowsConfig()
+	params := map[string]string{
+		"Location":                    config.GetClusterConfig().GetLocation(),
+		"TenantId":                    config.GetAuthConfig().GetTenantId(),
+		"SubscriptionId":              config.GetAuthConfig().GetSubscriptionId(),
+		"ResourceGroup":               config.GetClusterConfig().GetResourceGroup(),
+		"VmType":                      getStringFromVMType(config.GetClusterConfig().GetVmType()),
+		"SubnetName":                  config.GetClusterConfig().GetClusterNetworkConfig().GetSubnet(),
+		"NsgName":                     config.GetClusterConfig().GetClusterNetworkConfig().GetSecurityGroupName(),
+		"VNetName":                    config.GetClusterConfig().GetClusterNetworkConfig().GetVnetName(),
+		"RouteTableName":              config.GetClusterConfig().GetClusterNetworkConfig().GetRouteTable(),
+		"PrimaryAvailabilitySetName":  config.GetClusterConfig().GetPrimaryAvailabilitySet(),
+		"PrimaryScaleSetName":         config.GetClusterConfig().GetPrimaryScaleSet(),
+		"UseManagedIdentityExtension": fmt.Sprintf("%v", config.GetAuthConfig().GetUseManagedIdentityExtension()),
+		"UserAssignedClientID":        config.GetAuthConfig().GetAssignedIdentityId(),
+		"UseInstanceMetadata":         fmt.Sprintf("%v", config.GetClusterConfig().GetUseInstanceMetadata()),
+		"LoadBalancerSku":             getStringFromLoadBalancerSkuType(config.GetClusterConfig().GetLoadBalancerConfig().GetLoadBalancerSku()),
+		"TargetEnvironment":           getTargetEnvironment(config),
+		"APIServerName":               config.GetApiServerConfig().GetApiServerName(),
+		"KubernetesVersion":           config.GetKubernetesVersion(),
+		"KubeBinariesPackageURL":      windows.GetKubernetesPackageUrl(),
+		"CSEScriptsPackageURL":        windows.GetCseScriptsPackageUrl(),
+		"CredentialProviderURL":       windows.GetCredentialProviderUrl(),
+		"ContainerdURL":               windows.GetContainerdPackageUrl(),
+		"PauseImage":                  SandboxImage(config),
+		"AlwaysPullWindowsPauseImage": fmt.Sprintf("%v", windows.GetAlwaysPullPauseImage()),
+		"EnableCSIProxy":              fmt.Sprintf("%v", windows.GetEnableCsiProxy()),
+		"CSIProxyURL":                 windows.GetCsiProxyUrl(),
+		"EnableTLSBootstrapping":      fmt.Sprintf("%v", getEnableTLSBootstrap(config.GetBootstrappingConfig())),
+		"KubeletArgs":                 strings.Join(WindowsKubeletArgs(config), " "),
+		"KubeProxyArgs":               strings.Join(WindowsKubeProxyArgs(config), " "),
+		"CSEResultFilePath":           WindowsCSEResultFile,
+		"EnableGmsa":                  fmt.Sprintf("%v", GmsaEnabled(config)),
+		"GmsaSetupScript":             getGmsaSetupScript(config),
+		"ContainerdConfigContent":     getWindowsContainerdConfig(config),
+		"WindowsUpdateMode":           getStringFromWindowsUpdateMode(windows.GetUpdateConfig().GetMode()),
+		"WindowsUpdateSetupScript":    getWindowsUpdateSetupScript(config),
+	}
+	for name, value := range WindowsHNSNetworkParameters(config) {
+		params[name] = value
+	}
+	return params
+}
+
+func windowsCSEEnv(config *aksnodeconfigv1.Configuration) map[string]string {
+	return map[string]string{
+		"TLS_BOOTSTRAP_TOKEN":            getTLSBootstrapToken(config.GetBootstrappingConfig()),
+		"SERVICE_PRINCIPAL_FILE_CONTENT": getServicePrincipalFileContent(config.GetAuthConfig()),
+		"KUBELET_CLIENT_CONTENT":         config.GetKubeletConfig().GetKubeletClientKey(),
+		"KUBELET_CLIENT_CERT_CONTENT":    config.GetKubeletConfig().GetKubeletClientCertContent(),
+		"KUBE_CA_CRT":                    config.GetKubernetesCaCert(),
+	}
+}
+
+// BuildWindowsCSECmd returns the PowerShell command running the Windows CSE scripts of the node image. Every parameter
+// is a separate argument, so that PowerShell doesn't parse the values.
+func BuildWindowsCSECmd(ctx context.Context, config *aksnodeconfigv1.Configuration) (*exec.Cmd, error) {
+	if !IsWindows(config) {
+		return nil, errors.New("the configuration isn't for a Windows node")
+	}
+	params := windowsCSEParameters(config)
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names) // produce deterministic output
+	args := []string{"-NoProfile", "-NonInteractive", "-ExecutionPolicy", "Unrestricted", "-File", WindowsCSEScript}
+	for _, name := range names {
+		args = append(args, "-"+name, params[name])
+	}
+	cmd := exec.CommandContext(ctx, "powershell.exe", args...)
+	cmd.Env = append(os.Environ(), mapToEnviron(windowsCSEEnv(config))...)
+	sort.Strings(cmd.Env)
+	return cmd, nil
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/pkg/agent/windowscontainerd"
+)
+
+const (
+	// WindowsContainerdCertsDir is the config_path of the containerd registry configuration on Windows nodes.
+	WindowsContainerdCertsDir = `C:\ProgramData\containerd\certs.d`
+
+	defaultWindowsCNIBinDir  = `c:\k\azurecni\bin`
+	defaultWindowsCNIConfDir = `c:\k\azurecni\netconf`
+)
+
+// HasWindowsContainerdConfig returns whether provisioning replaces the containerd configuration of the Windows node
+// image.
+func HasWindowsContainerdConfig(config *aksnodeconfigv1.Configuration) bool {
+	return config.GetWindowsConfig().GetContainerdConfig() != nil
+}
+
+func windowsContainerdOptions(config *aksnodeconfigv1.Configuration) windowscontainerd.Options {
+	containerd := config.GetWindowsConfig().GetContainerdConfig()
+	options := windowscontainerd.Options{
+		Build:      windowscontainerd.Build(containerd.GetWindowsBuild()),
+		PauseImage: SandboxImage(config),
+		CNIBinDir:  containerd.GetCniBinDir(),
+		CNIConfDir: containerd.GetCniConfDir(),
+	}
+	if options.CNIBinDir == "" {
+		options.CNIBinDir = defaultWindowsCNIBinDir
+	}
+	if options.CNIConfDir == "" {
+		options.CNIConfDir = defaultWindowsCNIConfDir
+	}
+	if containerd.GetDefaultSandboxIsolation() == aksnodeconfigv1.WindowsSandboxIsolation_WINDOWS_SANDBOX_ISOLATION_HYPERV {
+		options.DefaultSandboxIsolation = windowscontainerd.IsolationHyperV
+	}
+	for _, build := range containerd.GetHypervRuntimeHandlerBuilds() {
+		options.RuntimeHandlers = append(options.RuntimeHandlers, windowscontainerd.Build(build))
+	}
+	return options
+}
+
+// ValidateWindowsContainerdConfig checks that the containerd configuration of the Windows node can be built: the
+// Windows build is known and can run the guest builds of the Hyper-V runtime handlers.
+func ValidateWindowsContainerdConfig(config *aksnodeconfigv1.Configuration) error {
+	if !HasWindowsContainerdConfig(config) {
+		return nil
+	}
+	if config.GetWindowsConfig().GetContainerdConfig().GetWindowsBuild() == "" {
+		return errors.New("windows build is required")
+	}
+	if _, err := windowscontainerd.New(windowsContainerdOptions(config)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// WindowsContainerdConfig returns the containerd config.toml of the Windows node.
+func WindowsContainerdConfig(config *aksnodeconfigv1.Configuration) (string, error) {
+	containerdConfig, err := windowscontainerd.New(windowsContainerdOptions(config))
+	if err != nil {
+		return "", fmt.Errorf("build windows containerd config: %w", err)
+	}
+	return string(containerdConfig.MarshalTOML()), nil
+}
+
+// getWindowsContainerdConfig returns the base64 encoded containerd config.toml of the Windows node, and an empty string
+// when the configuration of the node image is kept.
+func getWindowsContainerdConfig(config *aksnodeconfigv1.Configuration) string {
+	if !HasWindowsContainerdConfig(config) {
+		return ""
+	}
+	containerdConfig, err := WindowsContainerdConfig(config)
+	if err != nil {
+		// ValidateWindowsContainerdConfig rejects the configurations which can't be built before CSE runs.
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString([]byte(containerdConfig))
+}
@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWindowsContainerdConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		containerd *aksnodeconfigv1.WindowsContainerdConfig
+		wantErr    string
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name: "hyperv",
+			containerd: &aksnodeconfigv1.WindowsContainerdConfig{
+				WindowsBuild:               "20348",
+				DefaultSandboxIsolation:    aksnodeconfigv1.WindowsSandboxIsolation_WINDOWS_SANDBOX_ISOLATION_HYPERV,
+				HypervRuntimeHandlerBuilds: []string{"17763"},
+			},
+		},
+		{
+			name:       "no build",
+			containerd: &aksnodeconfigv1.WindowsContainerdConfig{},
+			wantErr:    "invalid containerd config: windows build is required",
+		},
+		{
+			name:       "unknown build",
+			containerd: &aksnodeconfigv1.WindowsContainerdConfig{WindowsBuild: "26100"},
+			wantErr:    `invalid containerd config: unsupported Windows build "26100"`,
+		},
+		{
+			name:       "newer guest",
+			containerd: &aksnodeconfigv1.WindowsContainerdConfig{WindowsBuild: "17763", HypervRuntimeHandlerBuilds: []string{"20348"}},
+			wantErr:    "invalid containerd config: build 17763 can't run build 20348 with Hyper-V isolation",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := windowsTestConfig()
+			config.WindowsConfig.ContainerdConfig = tt.containerd
+			err := ValidateWindowsConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWindowsContainerdConfig(t *testing.T) {
+	config := windowsTestConfig()
+	config.KubeBinaryConfig = &aksnodeconfigv1.KubeBinaryConfig{PodInfraContainerImageUrl: "mcr.microsoft.com/oss/kubernetes/pause:3.9"}
+	config.WindowsConfig.ContainerdConfig = &aksnodeconfigv1.WindowsContainerdConfig{
+		WindowsBuild:            "20348",
+		DefaultSandboxIsolation: aksnodeconfigv1.WindowsSandboxIsolation_WINDOWS_SANDBOX_ISOLATION_HYPERV,
+		CniConfDir:              `c:\k\cni\config`,
+	}
+	containerdConfig, err := WindowsContainerdConfig(config)
+	require.NoError(t, err)
+	assert.Contains(t, containerdConfig, `default_runtime_name = "runhcs-wcow-hypervisor-20348"`)
+	assert.Contains(t, containerdConfig, `sandbox_image = "mcr.microsoft.com/oss/kubernetes/pause:3.9-windows-10.0.20348-amd64"`)
+	assert.Contains(t, containerdConfig, `bin_dir = "c:\\k\\azurecni\\bin"`)
+	assert.Contains(t, containerdConfig, `conf_dir = "c:\\k\\cni\\config"`)
+
+	assert.NotEmpty(t, windowsCSEParameters(config)["ContainerdConfigContent"])
+	config.WindowsConfig.ContainerdConfig = nil
+	assert.Empty(t, windowsCSEParameters(config)["ContainerdConfigContent"])
+}
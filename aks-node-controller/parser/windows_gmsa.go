@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/pkg/agent"
+)
+
+const (
+	// defaultGmsaPluginCLSID is the COM class ID of the AKS CCG plugin, which retrieves the gMSA credentials from Azure
+	// Key Vault.
+	defaultGmsaPluginCLSID = "{CCC2A336-D7F3-4818-A213-272B7924213E}"
+	// gmsaPluginDir is where the CCG plugin package is extracted.
+	gmsaPluginDir = `C:\k\gmsa`
+
+	ccgCOMClassesKey = `HKLM:\SYSTEM\CurrentControlSet\Control\CCG\COMClasses`
+	tcpipParamsKey   = `HKLM:\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters`
+)
+
+var clsidRegex = regexp.MustCompile(`^\{[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}\}$`)
+
+// WindowsRegistryValue is a value the Windows CSE scripts write into the registry. A value without a name only creates
+// its key.
+type WindowsRegistryValue struct {
+	Key   string
+	Name  string
+	Type  string
+	Value string
+}
+
+// GmsaEnabled returns whether provisioning sets up gMSA support through the CCG plugin.
+func GmsaEnabled(config *aksnodeconfigv1.Configuration) bool {
+	return config.GetWindowsConfig().GetGmsaConfig().GetEnabled()
+}
+
+func gmsaPluginCLSID(config *aksnodeconfigv1.Configuration) string {
+	if clsid := config.GetWindowsConfig().GetGmsaConfig().GetPluginClsid(); clsid != "" {
+		return clsid
+	}
+	return defaultGmsaPluginCLSID
+}
+
+// ValidateGmsaConfig checks the CCG plugin package and class ID, the domain name and its DNS servers.
+func ValidateGmsaConfig(config *aksnodeconfigv1.Configuration) error {
+	if !GmsaEnabled(config) {
+		return nil
+	}
+	gmsa := config.GetWindowsConfig().GetGmsaConfig()
+	if u, err := url.Parse(gmsa.GetPluginPackageUrl()); err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("invalid gMSA plugin package URL %q, it must be an https URL", gmsa.GetPluginPackageUrl())
+	}
+	if !clsidRegex.MatchString(gmsaPluginCLSID(config)) {
+		return fmt.Errorf("invalid gMSA plugin CLSID %q, it must be a GUID in braces", gmsa.GetPluginClsid())
+	}
+	if domain := gmsa.GetRootDomainName(); domain != "" && !dnsSubdomainRegex.MatchString(strings.ToLower(domain)) {
+		return fmt.Errorf("invalid gMSA root domain name %q", domain)
+	}
+	for _, server := range gmsa.GetDnsServers() {
+		if net.ParseIP(server) == nil {
+			return fmt.Errorf("invalid gMSA DNS server %q, it must be an IP address", server)
+		}
+	}
+	if len(gmsa.GetDnsServers()) > 0 && gmsa.GetRootDomainName() == "" {
+		return errors.New("gMSA DNS servers require the root domain name")
+	}
+	return nil
+}
+
+// GmsaRegistryValues returns the registry values gMSA needs: the registration of the CCG plugin, which containers.exe
+// only loads when its class is listed under the CCG COM classes, and the domain in the DNS suffix search list.
+func GmsaRegistryValues(config *aksnodeconfigv1.Configuration) []WindowsRegistryValue {
+	if !GmsaEnabled(config) {
+		return nil
+	}
+	values := []WindowsRegistryValue{{Key: ccgCOMClassesKey + `\` + gmsaPluginCLSID(config)}}
+	if domain := config.GetWindowsConfig().GetGmsaConfig().GetRootDomainName(); domain != "" {
+		values = append(values, WindowsRegistryValue{Key: tcpipParamsKey, Name: "SearchList", Type: "String", Value: domain})
+	}
+	return values
+}
+
+// GmsaSetupScript returns the PowerShell script the Windows CSE scripts run before starting kubelet: it installs and
+// registers the CCG plugin, writes the registry values and points the node at the domain DNS servers.
+func GmsaSetupScript(config *aksnodeconfigv1.Configuration) string {
+	if !GmsaEnabled(config) {
+		return ""
+	}
+	gmsa := config.GetWindowsConfig().GetGmsaConfig()
+	lines := []string{
+		`$ErrorActionPreference = "Stop"`,
+		fmt.Sprintf("New-Item -ItemType Directory -Force -Path %s | Out-Null", psQuote(gmsaPluginDir)),
+		fmt.Sprintf("Invoke-WebRequest -UseBasicParsing -Uri %s -OutFile %s", psQuote(gmsa.GetPluginPackageUrl()), psQuote(gmsaPluginDir+`\gmsa.zip`)),
+		fmt.Sprintf("Expand-Archive -Force -Path %s -DestinationPath %s", psQuote(gmsaPluginDir+`\gmsa.zip`), psQuote(gmsaPluginDir)),
+		fmt.Sprintf("Get-ChildItem -Path %s -Filter *.dll | ForEach-Object { Start-Process -Wait -FilePath regsvr32.exe -ArgumentList '/s', $_.FullName }",
+			psQuote(gmsaPluginDir)),
+	}
+	lines = append(lines, registryValueStatements(GmsaRegistryValues(config))...)
+	if servers := gmsa.GetDnsServers(); len(servers) > 0 {
+		quoted := make([]string, 0, len(servers))
+		for _, server := range servers {
+			quoted = append(quoted, psQuote(server))
+		}
+		lines = append(lines, fmt.Sprintf("Get-NetAdapter -Physical | Set-DnsClientServerAddress -ServerAddresses @(%s)", strings.Join(quoted, ", ")))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// getGmsaSetupScript returns the base64 encoded gMSA setup script.
+func getGmsaSetupScript(config *aksnodeconfigv1.Configuration) string {
+	return base64.StdEncoding.EncodeToString([]byte(GmsaSetupScript(config)))
+}
+
+// registryValueStatements returns the PowerShell statements writing the registry values.
+func registryValueStatements(values []WindowsRegistryValue) []string {
+	var lines []string
+	for _, value := range values {
+		lines = append(lines, fmt.Sprintf("New-Item -Force -Path %s | Out-Null", psQuote(value.Key)))
+		if value.Name != "" {
+			lines = append(lines, fmt.Sprintf("New-ItemProperty -Force -Path %s -Name %s -PropertyType %s -Value %s | Out-Null",
+				psQuote(value.Key), psQuote(value.Name), value.Type, psQuote(value.Value)))
+		}
+	}
+	return lines
+}
+
+// psQuote returns s as a PowerShell single-quoted string, in which only single quotes need escaping.
+func psQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// gmsaKubeletFlags enables the WindowsGMSA feature gate on Kubernetes versions where it isn't GA yet.
+func gmsaKubeletFlags(config *aksnodeconfigv1.Configuration, flags map[string]string) {
+	if !GmsaEnabled(config) || agent.IsKubernetesVersionGe(config.GetKubernetesVersion(), "1.18.0") {
+		return
+	}
+	if gates := flags["--feature-gates"]; gates != "" {
+		if !strings.Contains(gates, "WindowsGMSA=") {
+			flags["--feature-gates"] = gates + ",WindowsGMSA=true"
+		}
+		return
+	}
+	flags["--feature-gates"] = "WindowsGMSA=true"
+}
@@ -0,0 +1,98 @@
+package parser
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func gmsaTestConfig() *aksnodeconfigv1.Configuration {
+	config := windowsTestConfig()
+	config.WindowsConfig.GmsaConfig = &aksnodeconfigv1.WindowsGmsaConfig{
+		Enabled:          true,
+		PluginPackageUrl: "https://packages.aks.azure.com/ccgakvplugin/v1.1.5/binaries/windows-gmsa-ccgakvplugin-v1.1.5.zip",
+		RootDomainName:   "Contoso.com",
+		DnsServers:       []string{"10.0.0.4", "10.0.0.5"},
+	}
+	return config
+}
+
+func TestValidateGmsaConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*aksnodeconfigv1.WindowsGmsaConfig)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*aksnodeconfigv1.WindowsGmsaConfig) {},
+		},
+		{
+			name:    "no plugin package",
+			mutate:  func(g *aksnodeconfigv1.WindowsGmsaConfig) { g.PluginPackageUrl = "" },
+			wantErr: `invalid gMSA plugin package URL "", it must be an https URL`,
+		},
+		{
+			name:    "invalid CLSID",
+			mutate:  func(g *aksnodeconfigv1.WindowsGmsaConfig) { g.PluginClsid = "CCC2A336-D7F3-4818-A213-272B7924213E" },
+			wantErr: `invalid gMSA plugin CLSID "CCC2A336-D7F3-4818-A213-272B7924213E", it must be a GUID in braces`,
+		},
+		{
+			name:    "invalid domain",
+			mutate:  func(g *aksnodeconfigv1.WindowsGmsaConfig) { g.RootDomainName = "contoso_com" },
+			wantErr: `invalid gMSA root domain name "contoso_com"`,
+		},
+		{
+			name:    "invalid DNS server",
+			mutate:  func(g *aksnodeconfigv1.WindowsGmsaConfig) { g.DnsServers = []string{"dc1.contoso.com"} },
+			wantErr: `invalid gMSA DNS server "dc1.contoso.com", it must be an IP address`,
+		},
+		{
+			name:    "DNS servers without domain",
+			mutate:  func(g *aksnodeconfigv1.WindowsGmsaConfig) { g.RootDomainName = "" },
+			wantErr: "gMSA DNS servers require the root domain name",
+		},
+		{
+			name:   "disabled",
+			mutate: func(g *aksnodeconfigv1.WindowsGmsaConfig) { g.Enabled = false; g.PluginPackageUrl = "" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := gmsaTestConfig()
+			tt.mutate(config.WindowsConfig.GmsaConfig)
+			err := ValidateWindowsConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGmsaSetupScript(t *testing.T) {
+	assert.Empty(t, GmsaSetupScript(windowsTestConfig()))
+	assert.Equal(t, `$ErrorActionPreference = "Stop"
+New-Item -ItemType Directory -Force -Path 'C:\k\gmsa' | Out-Null
+Invoke-WebRequest -UseBasicParsing -Uri 'https://packages.aks.azure.com/ccgakvplugin/v1.1.5/binaries/windows-gmsa-ccgakvplugin-v1.1.5.zip' -OutFile 'C:\k\gmsa\gmsa.zip'
+Expand-Archive -Force -Path 'C:\k\gmsa\gmsa.zip' -DestinationPath 'C:\k\gmsa'
+Get-ChildItem -Path 'C:\k\gmsa' -Filter *.dll | ForEach-Object { Start-Process -Wait -FilePath regsvr32.exe -ArgumentList '/s', $_.FullName }
+New-Item -Force -Path 'HKLM:\SYSTEM\CurrentControlSet\Control\CCG\COMClasses\{CCC2A336-D7F3-4818-A213-272B7924213E}' | Out-Null
+New-Item -Force -Path 'HKLM:\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters' | Out-Null
+New-ItemProperty -Force -Path 'HKLM:\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters' -Name 'SearchList' -PropertyType String -Value 'Contoso.com' | Out-Null
+Get-NetAdapter -Physical | Set-DnsClientServerAddress -ServerAddresses @('10.0.0.4', '10.0.0.5')
+`, GmsaSetupScript(gmsaTestConfig()))
+}
+
+func TestGmsaKubeletFlags(t *testing.T) {
+	config := gmsaTestConfig()
+	assert.NotContains(t, WindowsKubeletArgs(config), "--feature-gates=WindowsGMSA=true")
+
+	config.KubernetesVersion = "1.17.3"
+	assert.Contains(t, WindowsKubeletArgs(config), "--feature-gates=WindowsGMSA=true")
+
+	config.KubeletConfig.KubeletFlags["--feature-gates"] = "RotateKubeletServerCertificate=true"
+	assert.Contains(t, WindowsKubeletArgs(config), "--feature-gates=RotateKubeletServerCertificate=true,WindowsGMSA=true")
+}
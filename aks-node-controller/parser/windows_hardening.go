@@ -0,0 +1,185 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+const (
+	// WindowsHardeningAKSBaseline disables SMB1 and the legacy TLS versions, audits logons and excludes the container
+	// paths from Defender scans.
+	WindowsHardeningAKSBaseline = "aks-baseline"
+	// WindowsHardeningCISLevel1 adds the CIS Windows Server level 1 audit policy, SMB encryption and drops the CBC
+	// cipher suites on top of the AKS baseline.
+	WindowsHardeningCISLevel1 = "cis-level1"
+)
+
+// WindowsHardeningSetting is a setting of a Windows security baseline, as reported in the hardening inventory.
+type WindowsHardeningSetting struct {
+	Category string `json:"category"`
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	// command is the PowerShell statement applying the setting.
+	command string
+}
+
+func auditPolicy(subcategory string, success, failure bool) WindowsHardeningSetting {
+	enable := map[bool]string{true: "enable", false: "disable"}
+	return WindowsHardeningSetting{
+		Category: "AuditPolicy",
+		Name:     subcategory,
+		Value:    fmt.Sprintf("success:%s,failure:%s", enable[success], enable[failure]),
+		command: fmt.Sprintf("auditpol.exe /set /subcategory:%q /success:%s /failure:%s; if ($LASTEXITCODE -ne 0) { throw 'auditpol failed' }",
+			subcategory, enable[success], enable[failure]),
+	}
+}
+
+func smbSetting(cmdlet, parameter string) WindowsHardeningSetting {
+	value := "$true"
+	if parameter == "EnableSMB1Protocol" {
+		value = "$false"
+	}
+	return WindowsHardeningSetting{
+		Category: "SMB",
+		Name:     strings.TrimPrefix(cmdlet, "Set-") + "." + parameter,
+		Value:    strings.TrimPrefix(value, "$"),
+		command:  fmt.Sprintf("%s -%s %s -Force -Confirm:$false", cmdlet, parameter, value),
+	}
+}
+
+func tlsProtocolDisabled(protocol string) WindowsHardeningSetting {
+	key := `HKLM:\SYSTEM\CurrentControlSet\Control\SecurityProviders\SCHANNEL\Protocols\` + protocol
+	var lines []string
+	for _, side := range []string{"Server", "Client"} {
+		lines = append(lines,
+			fmt.Sprintf("New-Item -Force -Path %s | Out-Null", psQuote(key+`\`+side)),
+			fmt.Sprintf("New-ItemProperty -Force -Path %s -Name Enabled -PropertyType DWord -Value 0 | Out-Null", psQuote(key+`\`+side)),
+			fmt.Sprintf("New-ItemProperty -Force -Path %s -Name DisabledByDefault -PropertyType DWord -Value 1 | Out-Null", psQuote(key+`\`+side)))
+	}
+	return WindowsHardeningSetting{Category: "TLS", Name: protocol, Value: "disabled", command: strings.Join(lines, "\n")}
+}
+
+func tlsCipherSuiteDisabled(suite string) WindowsHardeningSetting {
+	return WindowsHardeningSetting{
+		Category: "TLS",
+		Name:     suite,
+		Value:    "disabled",
+		// the cmdlet fails for suites which are already disabled.
+		command: fmt.Sprintf("Disable-TlsCipherSuite -Name %s -ErrorAction SilentlyContinue", psQuote(suite)),
+	}
+}
+
+func defenderExclusion(kind, value string) WindowsHardeningSetting {
+	return WindowsHardeningSetting{
+		Category: "Defender",
+		Name:     "Exclusion" + kind,
+		Value:    value,
+		command:  fmt.Sprintf("Add-MpPreference -Exclusion%s %s", kind, psQuote(value)),
+	}
+}
+
+// defenderExclusions excludes the containerd state, the container layers and the CNI binaries from real-time scans,
+// which otherwise slow down image pulls and pod starts and can lock the files of running containers.
+func defenderExclusions(config *aksnodeconfigv1.Configuration) []WindowsHardeningSetting {
+	paths := []string{`C:\ProgramData\containerd`, `C:\k`}
+	if HasWindowsContainerdConfig(config) {
+		options := windowsContainerdOptions(config)
+		paths = append(paths, options.CNIBinDir, options.CNIConfDir)
+	}
+	settings := make([]WindowsHardeningSetting, 0, len(paths)+3)
+	for _, path := range paths {
+		settings = append(settings, defenderExclusion("Path", path))
+	}
+	for _, process := range []string{"containerd.exe", "containerd-shim-runhcs-v1.exe", "kubelet.exe"} {
+		settings = append(settings, defenderExclusion("Process", process))
+	}
+	return settings
+}
+
+func aksBaselineSettings() []WindowsHardeningSetting {
+	return []WindowsHardeningSetting{
+		auditPolicy("Logon", true, true),
+		auditPolicy("Account Lockout", false, true),
+		auditPolicy("Security Group Management", true, false),
+		smbSetting("Set-SmbServerConfiguration", "EnableSMB1Protocol"),
+		smbSetting("Set-SmbServerConfiguration", "RequireSecuritySignature"),
+		smbSetting("Set-SmbClientConfiguration", "RequireSecuritySignature"),
+		tlsProtocolDisabled("TLS 1.0"),
+		tlsProtocolDisabled("TLS 1.1"),
+		tlsCipherSuiteDisabled("TLS_RSA_WITH_3DES_EDE_CBC_SHA"),
+	}
+}
+
+func cisLevel1Settings() []WindowsHardeningSetting {
+	return append(aksBaselineSettings(),
+		auditPolicy("Process Creation", true, false),
+		auditPolicy("Sensitive Privilege Use", true, true),
+		auditPolicy("Audit Policy Change", true, false),
+		smbSetting("Set-SmbServerConfiguration", "EncryptData"),
+		tlsCipherSuiteDisabled("TLS_RSA_WITH_AES_128_CBC_SHA"),
+		tlsCipherSuiteDisabled("TLS_RSA_WITH_AES_256_CBC_SHA"),
+		tlsCipherSuiteDisabled("TLS_RSA_WITH_AES_128_CBC_SHA256"),
+		tlsCipherSuiteDisabled("TLS_RSA_WITH_AES_256_CBC_SHA256"),
+	)
+}
+
+var windowsHardeningProfiles = map[string]func() []WindowsHardeningSetting{
+	WindowsHardeningAKSBaseline: aksBaselineSettings,
+	WindowsHardeningCISLevel1:   cisLevel1Settings,
+}
+
+// HasWindowsHardeningProfile returns whether provisioning applies a Windows security baseline.
+func HasWindowsHardeningProfile(config *aksnodeconfigv1.Configuration) bool {
+	return IsWindows(config) && config.GetWindowsConfig().GetHardeningProfile() != ""
+}
+
+// ValidateWindowsHardeningProfile checks that the hardening profile is a known Windows security baseline.
+func ValidateWindowsHardeningProfile(config *aksnodeconfigv1.Configuration) error {
+	if !HasWindowsHardeningProfile(config) {
+		return nil
+	}
+	profile := config.GetWindowsConfig().GetHardeningProfile()
+	if _, ok := windowsHardeningProfiles[profile]; !ok {
+		names := make([]string, 0, len(windowsHardeningProfiles))
+		for name := range windowsHardeningProfiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown hardening profile %q, it must be one of %s", profile, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// WindowsHardeningSettings returns the settings of the hardening profile of the node, in the order they are applied.
+func WindowsHardeningSettings(config *aksnodeconfigv1.Configuration) []WindowsHardeningSetting {
+	settings, ok := windowsHardeningProfiles[config.GetWindowsConfig().GetHardeningProfile()]
+	if !ok {
+		return nil
+	}
+	return append(settings(), defenderExclusions(config)...)
+}
+
+// WindowsHardeningScript returns the PowerShell script applying the hardening profile, which stops at the first
+// setting that fails.
+func WindowsHardeningScript(config *aksnodeconfigv1.Configuration) string {
+	lines := []string{`$ErrorActionPreference = "Stop"`}
+	for _, setting := range WindowsHardeningSettings(config) {
+		lines = append(lines, setting.command)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// BuildWindowsHardeningCmd returns the PowerShell command applying the hardening profile of the node.
+func BuildWindowsHardeningCmd(ctx context.Context, config *aksnodeconfigv1.Configuration) (*exec.Cmd, error) {
+	if !HasWindowsHardeningProfile(config) {
+		return nil, errors.New("the configuration has no Windows hardening profile")
+	}
+	return exec.CommandContext(ctx, "powershell.exe", "-NoProfile", "-NonInteractive", "-ExecutionPolicy", "Unrestricted",
+		"-Command", WindowsHardeningScript(config)), nil
+}
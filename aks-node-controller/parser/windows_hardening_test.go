@@ -0,0 +1,43 @@
+package parser
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateWindowsHardeningProfile(t *testing.T) {
+	config := windowsTestConfig()
+	assert.NoError(t, ValidateWindowsConfig(config))
+
+	config.WindowsConfig.HardeningProfile = WindowsHardeningCISLevel1
+	assert.NoError(t, ValidateWindowsConfig(config))
+
+	config.WindowsConfig.HardeningProfile = "stig"
+	assert.EqualError(t, ValidateWindowsConfig(config), `unknown hardening profile "stig", it must be one of aks-baseline, cis-level1`)
+}
+
+func TestWindowsHardeningSettings(t *testing.T) {
+	config := windowsTestConfig()
+	assert.Empty(t, WindowsHardeningSettings(config))
+	assert.False(t, HasWindowsHardeningProfile(config))
+
+	config.WindowsConfig.HardeningProfile = WindowsHardeningAKSBaseline
+	config.WindowsConfig.ContainerdConfig = &aksnodeconfigv1.WindowsContainerdConfig{WindowsBuild: "20348", CniBinDir: `c:\cni\bin`}
+	baseline := WindowsHardeningSettings(config)
+	assert.Contains(t, baseline, WindowsHardeningSetting{Category: "AuditPolicy", Name: "Logon", Value: "success:enable,failure:enable",
+		command: `auditpol.exe /set /subcategory:"Logon" /success:enable /failure:enable; if ($LASTEXITCODE -ne 0) { throw 'auditpol failed' }`})
+	assert.Contains(t, baseline, WindowsHardeningSetting{Category: "Defender", Name: "ExclusionPath", Value: `c:\cni\bin`,
+		command: `Add-MpPreference -ExclusionPath 'c:\cni\bin'`})
+
+	config.WindowsConfig.HardeningProfile = WindowsHardeningCISLevel1
+	cis := WindowsHardeningSettings(config)
+	assert.Subset(t, cis, baseline)
+	assert.Contains(t, cis, WindowsHardeningSetting{Category: "SMB", Name: "SmbServerConfiguration.EncryptData", Value: "true",
+		command: "Set-SmbServerConfiguration -EncryptData $true -Force -Confirm:$false"})
+
+	script := WindowsHardeningScript(config)
+	assert.Contains(t, script, `New-ItemProperty -Force -Path 'HKLM:\SYSTEM\CurrentControlSet\Control\SecurityProviders\SCHANNEL\Protocols\TLS 1.0\Server' -Name Enabled -PropertyType DWord -Value 0 | Out-Null`)
+	assert.Contains(t, script, "Disable-TlsCipherSuite -Name 'TLS_RSA_WITH_AES_128_CBC_SHA' -ErrorAction SilentlyContinue")
+}
@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func windowsTestConfig() *aksnodeconfigv1.Configuration {
+	return &aksnodeconfigv1.Configuration{
+		KubernetesVersion: "1.30.0",
+		KubeletConfig: &aksnodeconfigv1.KubeletConfig{
+			KubeletFlags:      map[string]string{"--max-pods": "30"},
+			KubeletNodeLabels: map[string]string{"kubernetes.azure.com/role": "agent"},
+		},
+		BootstrappingConfig: &aksnodeconfigv1.BootstrappingConfig{TlsBootstrappingToken: proto.String("secret-token")},
+		WindowsConfig: &aksnodeconfigv1.WindowsConfig{
+			KubernetesPackageUrl: "https://packages.aks.azure.com/kubernetes/v1.30.0/windowszip/v1.30.0-1int.zip",
+			HnsConfig: &aksnodeconfigv1.WindowsHnsConfig{
+				OutboundNatExceptions: []string{"10.0.0.0/8"},
+			},
+		},
+	}
+}
+
+func TestValidateWindowsConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*aksnodeconfigv1.Configuration)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*aksnodeconfigv1.Configuration) {},
+		},
+		{
+			name: "linux os config",
+			mutate: func(c *aksnodeconfigv1.Configuration) {
+				c.CustomLinuxOsConfig = &aksnodeconfigv1.CustomLinuxOsConfig{}
+			},
+			wantErr: "custom linux os config isn't supported on Windows nodes",
+		},
+		{
+			name:    "no kubernetes package",
+			mutate:  func(c *aksnodeconfigv1.Configuration) { c.WindowsConfig.KubernetesPackageUrl = "" },
+			wantErr: "kubernetes package URL is required",
+		},
+		{
+			name: "http package",
+			mutate: func(c *aksnodeconfigv1.Configuration) {
+				c.WindowsConfig.ContainerdPackageUrl = "http://contoso.com/containerd.zip"
+			},
+			wantErr: `invalid containerd package URL "http://contoso.com/containerd.zip", it must be an https URL`,
+		},
+		{
+			name:    "CSI proxy without URL",
+			mutate:  func(c *aksnodeconfigv1.Configuration) { c.WindowsConfig.EnableCsiProxy = true },
+			wantErr: "CSI proxy URL is required when the CSI proxy is enabled",
+		},
+		{
+			name:    "kube-proxy flag without dashes",
+			mutate:  func(c *aksnodeconfigv1.Configuration) { c.WindowsConfig.KubeProxyFlags = map[string]string{"v": "3"} },
+			wantErr: `invalid kube-proxy flag "v", it must start with --`,
+		},
+		{
+			name: "invalid outbound NAT exception",
+			mutate: func(c *aksnodeconfigv1.Configuration) {
+				c.WindowsConfig.HnsConfig.OutboundNatExceptions = []string{"10.0.0.1"}
+			},
+			wantErr: `invalid outbound NAT exception "10.0.0.1", it must be a CIDR`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := windowsTestConfig()
+			tt.mutate(config)
+			err := ValidateWindowsConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+	assert.NoError(t, ValidateWindowsConfig(&aksnodeconfigv1.Configuration{CustomLinuxOsConfig: &aksnodeconfigv1.CustomLinuxOsConfig{}}))
+}
+
+func TestWindowsKubeletArgs(t *testing.T) {
+	assert.Equal(t, []string{
+		"--cgroups-per-qos=false",
+		`--enforce-node-allocatable=""`,
+		"--max-pods=30",
+		"--node-labels=kubernetes.azure.com/role=agent",
+		`--resolv-conf=""`,
+	}, WindowsKubeletArgs(windowsTestConfig()))
+}
+
+func TestWindowsKubeProxyArgs(t *testing.T) {
+	config := windowsTestConfig()
+	assert.Equal(t, []string{"--network-name=azure", "--proxy-mode=kernelspace"}, WindowsKubeProxyArgs(config))
+
+	config.WindowsConfig.HnsConfig.NetworkMode = aksnodeconfigv1.WindowsNetworkMode_WINDOWS_NETWORK_MODE_OVERLAY
+	config.WindowsConfig.HnsConfig.NetworkName = "azure-overlay"
+	config.WindowsConfig.KubeProxyFlags = map[string]string{"--v": "3"}
+	assert.Equal(t, []string{"--feature-gates=WinOverlay=true", "--network-name=azure-overlay", "--proxy-mode=kernelspace", "--v=3"},
+		WindowsKubeProxyArgs(config))
+}
+
+func TestWindowsHNSNetworkParameters(t *testing.T) {
+	config := windowsTestConfig()
+	config.NetworkConfig = &aksnodeconfigv1.NetworkConfig{NetworkPlugin: aksnodeconfigv1.NetworkPlugin_NETWORK_PLUGIN_AZURE}
+	config.WindowsConfig.HnsConfig.DisableOutboundNat = true
+	assert.Equal(t, map[string]string{
+		"NetworkName":                 "azure",
+		"NetworkMode":                 "L2Bridge",
+		"NetworkPlugin":               "azure",
+		"OutboundNatExceptions":       "10.0.0.0/8",
+		"IsDisableWindowsOutboundNat": "true",
+		"IsSkipCleanupNetwork":        "false",
+	}, WindowsHNSNetworkParameters(config))
+}
+
+func TestBuildCSECmdWindows(t *testing.T) {
+	cmd, err := BuildCSECmd(context.Background(), windowsTestConfig())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"-NoProfile", "-NonInteractive", "-ExecutionPolicy", "Unrestricted", "-File", WindowsCSEScript}, cmd.Args[1:7])
+	params := map[string]string{}
+	for i := 7; i+1 < len(cmd.Args); i += 2 {
+		params[cmd.Args[i]] = cmd.Args[i+1]
+	}
+	assert.Equal(t, "https://packages.aks.azure.com/kubernetes/v1.30.0/windowszip/v1.30.0-1int.zip", params["-KubeBinariesPackageURL"])
+	assert.Equal(t, "L2Bridge", params["-NetworkMode"])
+	assert.Equal(t, "--network-name=azure --proxy-mode=kernelspace", params["-KubeProxyArgs"])
+	// the bootstrap token is passed in the environment, not on the command line.
+	assert.NotContains(t, cmd.Args, "secret-token")
+	assert.Contains(t, cmd.Env, "TLS_BOOTSTRAP_TOKEN=secret-token")
+
+	_, err = BuildWindowsCSECmd(context.Background(), &aksnodeconfigv1.Configuration{})
+	assert.EqualError(t, err, "the configuration isn't for a Windows node")
+}
@@ -0,0 +1,129 @@
+package parser
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+const (
+	windowsUpdatePolicyKey   = `HKLM:\SOFTWARE\Policies\Microsoft\Windows\WindowsUpdate`
+	windowsUpdateAUPolicyKey = windowsUpdatePolicyKey + `\AU`
+
+	// auOptionsScheduledInstall downloads the updates automatically and installs them at the scheduled time.
+	auOptionsScheduledInstall = "4"
+	// defaultWindowsUpdateStartHour is the hour Windows Update installs updates at when the policy doesn't set one.
+	defaultWindowsUpdateStartHour = 3
+)
+
+// HasWindowsUpdateConfig returns whether provisioning replaces the Windows Update settings of the node image.
+func HasWindowsUpdateConfig(config *aksnodeconfigv1.Configuration) bool {
+	return IsWindows(config) &&
+		config.GetWindowsConfig().GetUpdateConfig().GetMode() != aksnodeconfigv1.WindowsUpdateMode_WINDOWS_UPDATE_MODE_UNSPECIFIED
+}
+
+// scheduledInstallDay returns the ScheduledInstallDay policy value of the day, 0 for every day and 1 to 7 from Sunday.
+func scheduledInstallDay(day string) (int, error) {
+	if day == "" {
+		return 0, nil
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(day, d.String()) {
+			return int(d) + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid maintenance window day %q, it must be the English name of a day", day)
+}
+
+// ValidateWindowsUpdateConfig checks that the maintenance window is a known day and hour and that only the security-only
+// mode sets one.
+func ValidateWindowsUpdateConfig(config *aksnodeconfigv1.Configuration) error {
+	update := config.GetWindowsConfig().GetUpdateConfig()
+	window := update.GetMaintenanceWindow()
+	if window == nil {
+		return nil
+	}
+	if update.GetMode() != aksnodeconfigv1.WindowsUpdateMode_WINDOWS_UPDATE_MODE_SECURITY_ONLY {
+		return errors.New("a Windows Update maintenance window requires the security-only update mode")
+	}
+	if _, err := scheduledInstallDay(window.GetDayOfWeek()); err != nil {
+		return err
+	}
+	if window.GetStartHour() > 23 {
+		return fmt.Errorf("invalid maintenance window start hour %d, it must be between 0 and 23", window.GetStartHour())
+	}
+	return nil
+}
+
+// WindowsUpdateRegistryValues returns the Windows Update policy values of the node. Disabling turns automatic updates
+// off; security-only installs the quality updates at the maintenance window and defers the feature updates for the
+// longest period Windows Update allows and the driver updates, which node image upgrades ship.
+func WindowsUpdateRegistryValues(config *aksnodeconfigv1.Configuration) []WindowsRegistryValue {
+	if !HasWindowsUpdateConfig(config) {
+		return nil
+	}
+	dword := func(key, name, value string) WindowsRegistryValue {
+		return WindowsRegistryValue{Key: key, Name: name, Type: "DWord", Value: value}
+	}
+	update := config.GetWindowsConfig().GetUpdateConfig()
+	if update.GetMode() == aksnodeconfigv1.WindowsUpdateMode_WINDOWS_UPDATE_MODE_DISABLED {
+		return []WindowsRegistryValue{dword(windowsUpdateAUPolicyKey, "NoAutoUpdate", "1")}
+	}
+	// ValidateWindowsUpdateConfig rejects the unknown days before CSE runs.
+	day, _ := scheduledInstallDay(update.GetMaintenanceWindow().GetDayOfWeek())
+	hour := uint32(defaultWindowsUpdateStartHour)
+	if update.GetMaintenanceWindow() != nil {
+		hour = update.GetMaintenanceWindow().GetStartHour()
+	}
+	return []WindowsRegistryValue{
+		dword(windowsUpdatePolicyKey, "DeferFeatureUpdates", "1"),
+		dword(windowsUpdatePolicyKey, "DeferFeatureUpdatesPeriodInDays", "365"),
+		dword(windowsUpdatePolicyKey, "ExcludeWUDriversInQualityUpdate", "1"),
+		dword(windowsUpdateAUPolicyKey, "NoAutoUpdate", "0"),
+		dword(windowsUpdateAUPolicyKey, "AUOptions", auOptionsScheduledInstall),
+		dword(windowsUpdateAUPolicyKey, "ScheduledInstallDay", strconv.Itoa(day)),
+		dword(windowsUpdateAUPolicyKey, "ScheduledInstallTime", strconv.Itoa(int(hour))),
+	}
+}
+
+// WindowsUpdateSetupScript returns the PowerShell script the Windows CSE scripts run to apply the Windows Update
+// policy, which also sets the startup type of the Windows Update service.
+func WindowsUpdateSetupScript(config *aksnodeconfigv1.Configuration) string {
+	if !HasWindowsUpdateConfig(config) {
+		return ""
+	}
+	startupType := "Manual"
+	if config.GetWindowsConfig().GetUpdateConfig().GetMode() == aksnodeconfigv1.WindowsUpdateMode_WINDOWS_UPDATE_MODE_DISABLED {
+		startupType = "Disabled"
+	}
+	lines := []string{`$ErrorActionPreference = "Stop"`}
+	lines = append(lines, registryValueStatements(WindowsUpdateRegistryValues(config))...)
+	lines = append(lines, fmt.Sprintf("Set-Service -Name wuauserv -StartupType %s", startupType))
+	if startupType == "Disabled" {
+		lines = append(lines, "Stop-Service -Name wuauserv -Force")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// getWindowsUpdateSetupScript returns the base64 encoded Windows Update setup script.
+func getWindowsUpdateSetupScript(config *aksnodeconfigv1.Configuration) string {
+	return base64.StdEncoding.EncodeToString([]byte(WindowsUpdateSetupScript(config)))
+}
+
+// getStringFromWindowsUpdateMode returns the Windows Update mode passed to the Windows CSE scripts, empty when the node
+// image settings are kept.
+func getStringFromWindowsUpdateMode(mode aksnodeconfigv1.WindowsUpdateMode) string {
+	switch mode {
+	case aksnodeconfigv1.WindowsUpdateMode_WINDOWS_UPDATE_MODE_DISABLED:
+		return "disabled"
+	case aksnodeconfigv1.WindowsUpdateMode_WINDOWS_UPDATE_MODE_SECURITY_ONLY:
+		return "security-only"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"encoding/base64"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateWindowsUpdateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  *aksnodeconfigv1.WindowsUpdateConfig
+		wantErr string
+	}{
+		{
+			name: "not set",
+		},
+		{
+			name:   "disabled",
+			update: &aksnodeconfigv1.WindowsUpdateConfig{Mode: aksnodeconfigv1.WindowsUpdateMode_WINDOWS_UPDATE_MODE_DISABLED},
+		},
+		{
+			name: "security only in a maintenance window",
+			update: &aksnodeconfigv1.WindowsUpdateConfig{
+				Mode:              aksnodeconfigv1.WindowsUpdateMode_WINDOWS_UPDATE_MODE_SECURITY_ONLY,
+				MaintenanceWindow: &aksnodeconfigv1.WindowsMaintenanceWindow{DayOfWeek: "saturday", StartHour: 2},
+			},
+		},
+		{
+			name: "maintenance window when disabled",
+			update: &aksnodeconfigv1.WindowsUpdateConfig{
+				Mode:              aksnodeconfigv1.WindowsUpdateMode_WINDOWS_UPDATE_MODE_DISABLED,
+				MaintenanceWindow: &aksnodeconfigv1.WindowsMaintenanceWindow{},
+			},
+			wantErr: "a Windows Update maintenance window requires the security-only update mode",
+		},
+		{
+			name: "unknown day",
+			update: &aksnodeconfigv1.WindowsUpdateConfig{
+				Mode:              aksnodeconfigv1.WindowsUpdateMode_WINDOWS_UPDATE_MODE_SECURITY_ONLY,
+				MaintenanceWindow: &aksnodeconfigv1.WindowsMaintenanceWindow{DayOfWeek: "Sat"},
+			},
+			wantErr: `invalid maintenance window day "Sat", it must be the English name of a day`,
+		},
+		{
+			name: "invalid hour",
+			update: &aksnodeconfigv1.WindowsUpdateConfig{
+				Mode:              aksnodeconfigv1.WindowsUpdateMode_WINDOWS_UPDATE_MODE_SECURITY_ONLY,
+				MaintenanceWindow: &aksnodeconfigv1.WindowsMaintenanceWindow{StartHour: 24},
+			},
+			wantErr: "invalid maintenance window start hour 24, it must be between 0 and 23",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := windowsTestConfig()
+			config.WindowsConfig.UpdateConfig = tt.update
+			err := ValidateWindowsConfig(config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWindowsUpdateRegistryValues(t *testing.T) {
+	config := windowsTestConfig()
+	assert.Empty(t, WindowsUpdateRegistryValues(config))
+	assert.Empty(t, windowsCSEParameters(config)["WindowsUpdateMode"])
+
+	config.WindowsConfig.UpdateConfig = &aksnodeconfigv1.WindowsUpdateConfig{Mode: aksnodeconfigv1.WindowsUpdateMode_WINDOWS_UPDATE_MODE_DISABLED}
+	assert.Equal(t, []WindowsRegistryValue{
+		{Key: `HKLM:\SOFTWARE\Policies\Microsoft\Windows\WindowsUpdate\AU`, Name: "NoAutoUpdate", Type: "DWord", Value: "1"},
+	}, WindowsUpdateRegistryValues(config))
+	assert.Contains(t, WindowsUpdateSetupScript(config), "Set-Service -Name wuauserv -StartupType Disabled")
+
+	config.WindowsConfig.UpdateConfig = &aksnodeconfigv1.WindowsUpdateConfig{
+		Mode:              aksnodeconfigv1.WindowsUpdateMode_WINDOWS_UPDATE_MODE_SECURITY_ONLY,
+		MaintenanceWindow: &aksnodeconfigv1.WindowsMaintenanceWindow{DayOfWeek: "Saturday", StartHour: 2},
+	}
+	values := WindowsUpdateRegistryValues(config)
+	assert.Contains(t, values, WindowsRegistryValue{Key: `HKLM:\SOFTWARE\Policies\Microsoft\Windows\WindowsUpdate`, Name: "DeferFeatureUpdates", Type: "DWord", Value: "1"})
+	assert.Contains(t, values, WindowsRegistryValue{Key: `HKLM:\SOFTWARE\Policies\Microsoft\Windows\WindowsUpdate\AU`, Name: "ScheduledInstallDay", Type: "DWord", Value: "7"})
+	assert.Contains(t, values, WindowsRegistryValue{Key: `HKLM:\SOFTWARE\Policies\Microsoft\Windows\WindowsUpdate\AU`, Name: "ScheduledInstallTime", Type: "DWord", Value: "2"})
+
+	params := windowsCSEParameters(config)
+	assert.Equal(t, "security-only", params["WindowsUpdateMode"])
+	script, err := base64.StdEncoding.DecodeString(params["WindowsUpdateSetupScript"])
+	assert.NoError(t, err)
+	assert.Contains(t, string(script), "-Name 'AUOptions' -PropertyType DWord -Value '4'")
+	assert.Contains(t, string(script), "Set-Service -Name wuauserv -StartupType Manual")
+}
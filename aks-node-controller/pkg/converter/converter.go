@@ -0,0 +1,430 @@
+// Package converter maps the legacy datamodel.NodeBootstrappingConfiguration to an aksnodeconfig v1 configuration and
+// back, so that integrators moving to aks-node-controller can generate both from the same input and diff what the
+// nodes get during a staged rollout.
+//
+// The mapping covers the fields both models share. Fields only the legacy model has, such as the OS image and SIG
+// configs, are dropped, and fields only aksnodeconfig v1 has are left unset by the conversion from the legacy model.
+package converter
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Azure/agentbaker/aks-node-controller/helpers"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/pkg/agent"
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"google.golang.org/protobuf/proto"
+)
+
+const configVersion = "v0"
+
+// routeTableRegex matches the route table name the legacy model derives from the cluster ID.
+var routeTableRegex = regexp.MustCompile(`^(aks|k8s)-agentpool-(.+)-routetable$`)
+
+var outboundTypes = map[string]aksnodeconfigv1.OutboundType{
+	"loadbalancer":              aksnodeconfigv1.OutboundType_OUTBOUND_TYPE_LOAD_BALANCER,
+	"userdefinedrouting":        aksnodeconfigv1.OutboundType_OUTBOUND_TYPE_USER_DEFINED_ROUTING,
+	datamodel.OutboundTypeNone:  aksnodeconfigv1.OutboundType_OUTBOUND_TYPE_NONE,
+	datamodel.OutboundTypeBlock: aksnodeconfigv1.OutboundType_OUTBOUND_TYPE_NONE,
+}
+
+// FromNodeBootstrappingConfiguration returns the aksnodeconfig v1 configuration of nbc, which isn't modified.
+func FromNodeBootstrappingConfiguration(nbc *datamodel.NodeBootstrappingConfiguration) (*aksnodeconfigv1.Configuration, error) {
+	if nbc == nil || nbc.ContainerService == nil || nbc.ContainerService.Properties == nil {
+		return nil, errors.New("node bootstrapping configuration has no container service properties")
+	}
+	if nbc.AgentPoolProfile == nil {
+		return nil, errors.New("node bootstrapping configuration has no agent pool profile")
+	}
+	if nbc.CloudSpecConfig == nil {
+		return nil, errors.New("node bootstrapping configuration has no cloud spec config")
+	}
+	if nbc.ContainerService.Properties.OrchestratorProfile == nil {
+		return nil, errors.New("node bootstrapping configuration has no orchestrator profile")
+	}
+	// the getters of the properties derive and store the cluster ID, they are called on a copy.
+	properties := *nbc.ContainerService.Properties
+	cs := *nbc.ContainerService
+	cs.Properties = &properties
+	agentPool := nbc.AgentPoolProfile
+	kubernetesConfig := properties.OrchestratorProfile.KubernetesConfig
+	if kubernetesConfig == nil {
+		kubernetesConfig = &datamodel.KubernetesConfig{}
+	}
+	certificateProfile := properties.CertificateProfile
+	if certificateProfile == nil {
+		certificateProfile = &datamodel.CertificateProfile{}
+	}
+
+	kubeletFlags := map[string]string{}
+	for flag, value := range nbc.KubeletConfig {
+		kubeletFlags[flag] = value
+	}
+	helpers.ValidateAndSetLinuxKubeletFlags(kubeletFlags, &cs, agentPool)
+
+	config := &aksnodeconfigv1.Configuration{
+		Version:           configVersion,
+		VmSize:            agentPool.VMSize,
+		KubernetesVersion: properties.OrchestratorProfile.OrchestratorVersion,
+		ClusterConfig: &aksnodeconfigv1.ClusterConfig{
+			Location:      cs.Location,
+			ResourceGroup: nbc.ResourceGroupName,
+			VmType:        aksnodeconfigv1.VmType_VM_TYPE_STANDARD,
+			ClusterNetworkConfig: &aksnodeconfigv1.ClusterNetworkConfig{
+				SecurityGroupName: properties.GetNSGName(),
+				VnetName:          properties.GetVirtualNetworkName(),
+				VnetResourceGroup: properties.GetVNetResourceGroupName(),
+				Subnet:            properties.GetSubnetName(),
+				RouteTable:        properties.GetRouteTableName(),
+			},
+			LoadBalancerConfig: &aksnodeconfigv1.LoadBalancerConfig{
+				LoadBalancerSku:                       helpers.GetLoadBalancerSKU(kubernetesConfig.LoadBalancerSku),
+				ExcludeMasterFromStandardLoadBalancer: kubernetesConfig.ExcludeMasterFromStandardLB,
+			},
+			PrimaryAvailabilitySet: properties.GetPrimaryAvailabilitySetName(),
+			PrimaryScaleSet:        nbc.PrimaryScaleSetName,
+			UseInstanceMetadata:    kubernetesConfig.UseInstanceMetadata != nil && *kubernetesConfig.UseInstanceMetadata,
+		},
+		AuthConfig: &aksnodeconfigv1.AuthConfig{
+			TenantId:           nbc.TenantID,
+			SubscriptionId:     nbc.SubscriptionID,
+			AssignedIdentityId: nbc.UserAssignedIdentityClientID,
+		},
+		NetworkConfig: &aksnodeconfigv1.NetworkConfig{
+			NetworkPlugin:     helpers.GetNetworkPluginType(kubernetesConfig.NetworkPlugin),
+			NetworkPolicy:     helpers.GetNetworkPolicyType(kubernetesConfig.NetworkPolicy),
+			CniPluginsUrl:     nbc.CloudSpecConfig.KubernetesSpecConfig.CNIPluginsDownloadURL,
+			VnetCniPluginsUrl: kubernetesConfig.AzureCNIURLLinux,
+		},
+		GpuConfig: &aksnodeconfigv1.GpuConfig{
+			EnableNvidia:       proto.Bool(nbc.EnableNvidia),
+			ConfigGpuDriver:    nbc.ConfigGPUDriverIfNeeded,
+			GpuDevicePlugin:    nbc.EnableGPUDevicePluginIfNeeded,
+			GpuInstanceProfile: nbc.GPUInstanceProfile,
+		},
+		ContainerdConfig: &aksnodeconfigv1.ContainerdConfig{
+			ContainerdDownloadUrlBase: nbc.CloudSpecConfig.KubernetesSpecConfig.ContainerdDownloadURLBase,
+			ContainerdVersion:         nbc.ContainerdVersion,
+			ContainerdPackageUrl:      nbc.ContainerdPackageURL,
+		},
+		RuncConfig: &aksnodeconfigv1.RuncConfig{
+			RuncVersion:    nbc.RuncVersion,
+			RuncPackageUrl: nbc.RuncPackageURL,
+		},
+		TeleportConfig: &aksnodeconfigv1.TeleportConfig{
+			Status:                     nbc.EnableACRTeleportPlugin,
+			TeleportdPluginDownloadUrl: nbc.TeleportdPluginURL,
+		},
+		KubeletConfig: &aksnodeconfigv1.KubeletConfig{
+			KubeletClientKey:         base64.StdEncoding.EncodeToString([]byte(certificateProfile.ClientPrivateKey)),
+			KubeletClientCertContent: base64.StdEncoding.EncodeToString([]byte(certificateProfile.ClientCertificate)),
+			EnableKubeletConfigFile:  agent.IsKubeletConfigFileEnabled(&cs, agentPool, nbc.EnableKubeletConfigFile),
+			KubeletConfigFileContent: base64.StdEncoding.EncodeToString([]byte(agent.GetKubeletConfigFileContent(kubeletFlags, agentPool.CustomKubeletConfig))),
+			KubeletFlags:             helpers.GetKubeletConfigFlag(kubeletFlags, &cs, agentPool, nbc.EnableKubeletConfigFile),
+			KubeletNodeLabels:        helpers.GetKubeletNodeLabels(nbc),
+		},
+		BootstrappingConfig:        bootstrappingConfig(nbc),
+		KubernetesCaCert:           base64.StdEncoding.EncodeToString([]byte(certificateProfile.CaCertificate)),
+		KubeProxyUrl:               kubernetesConfig.CustomKubeProxyImage,
+		EnableUnattendedUpgrade:    !nbc.DisableUnattendedUpgrades,
+		OutboundCommand:            helpers.GetOutBoundCmd(nbc),
+		OutboundType:               outboundTypes[strings.ToLower(nbc.OutboundType)],
+		AzurePrivateRegistryServer: kubernetesConfig.PrivateAzureRegistryServer,
+		EnableArtifactStreaming:    nbc.EnableArtifactStreaming,
+		DisableCustomData:          nbc.DisableCustomData,
+		ImdsRestrictionConfig: &aksnodeconfigv1.ImdsRestrictionConfig{
+			EnableImdsRestriction:                  nbc.EnableIMDSRestriction,
+			InsertImdsRestrictionRuleToMangleTable: nbc.InsertIMDSRestrictionRuleToMangleTable,
+		},
+	}
+	if nbc.Version != "" {
+		config.Version = nbc.Version
+	}
+	if nbc.PrimaryScaleSetName != "" {
+		config.ClusterConfig.VmType = aksnodeconfigv1.VmType_VM_TYPE_VMSS
+	}
+	if kubernetesConfig.MaximumLoadBalancerRuleCount != 0 {
+		config.ClusterConfig.LoadBalancerConfig.MaxLoadBalancerRuleCount = proto.Int32(int32(kubernetesConfig.MaximumLoadBalancerRuleCount))
+	}
+	if properties.HostedMasterProfile != nil {
+		config.ApiServerConfig = &aksnodeconfigv1.ApiServerConfig{ApiServerName: properties.HostedMasterProfile.FQDN}
+	}
+	if sp := properties.ServicePrincipalProfile; sp != nil {
+		config.AuthConfig.ServicePrincipalId = sp.ClientID
+		config.AuthConfig.ServicePrincipalSecret = sp.Secret
+	}
+	if properties.LinuxProfile != nil {
+		config.LinuxAdminUsername = properties.LinuxProfile.AdminUsername
+	}
+	if nbc.K8sComponents != nil {
+		config.KubeBinaryConfig = &aksnodeconfigv1.KubeBinaryConfig{
+			CustomKubeBinaryUrl:        kubernetesConfig.CustomKubeBinaryURL,
+			PrivateKubeBinaryUrl:       nbc.K8sComponents.LinuxPrivatePackageURL,
+			PodInfraContainerImageUrl:  nbc.K8sComponents.PodInfraContainerImageURL,
+			LinuxCredentialProviderUrl: nbc.K8sComponents.LinuxCredentialProviderURL,
+		}
+	}
+	if proxy := nbc.HTTPProxyConfig; proxy != nil {
+		config.HttpProxyConfig = &aksnodeconfigv1.HttpProxyConfig{
+			HttpProxy:      derefString(proxy.HTTPProxy),
+			HttpsProxy:     derefString(proxy.HTTPSProxy),
+			ProxyTrustedCa: derefString(proxy.TrustedCA),
+			NoProxyEntries: derefStrings(proxy.NoProxy),
+		}
+	}
+	if nbc.CustomCATrustConfig != nil {
+		config.CustomCaCerts = append([]string(nil), nbc.CustomCATrustConfig.CustomCATrustCerts...)
+	}
+	switch nbc.SSHStatus {
+	case datamodel.SSHOn:
+		config.EnableSsh = proto.Bool(true)
+	case datamodel.SSHOff:
+		config.EnableSsh = proto.Bool(false)
+	}
+	return config, nil
+}
+
+func bootstrappingConfig(nbc *datamodel.NodeBootstrappingConfiguration) *aksnodeconfigv1.BootstrappingConfig {
+	if nbc.EnableSecureTLSBootstrapping {
+		config := &aksnodeconfigv1.BootstrappingConfig{
+			BootstrappingAuthMethod: aksnodeconfigv1.BootstrappingAuthMethod_BOOTSTRAPPING_AUTH_METHOD_SECURE_TLS_BOOTSTRAPPING,
+			// the bootstrap token is the fallback when secure TLS bootstrapping fails.
+			TlsBootstrappingToken: nbc.KubeletClientTLSBootstrapToken,
+		}
+		if nbc.CustomSecureTLSBootstrapAADServerAppID != "" {
+			config.CustomAadResource = proto.String(nbc.CustomSecureTLSBootstrapAADServerAppID)
+		}
+		return config
+	}
+	if nbc.KubeletClientTLSBootstrapToken != nil {
+		return &aksnodeconfigv1.BootstrappingConfig{
+			BootstrappingAuthMethod: aksnodeconfigv1.BootstrappingAuthMethod_BOOTSTRAPPING_AUTH_METHOD_BOOTSTRAP_TOKEN,
+			TlsBootstrappingToken:   nbc.KubeletClientTLSBootstrapToken,
+		}
+	}
+	return &aksnodeconfigv1.BootstrappingConfig{}
+}
+
+// ToNodeBootstrappingConfiguration returns the legacy node bootstrapping configuration of config. The network
+// security group and route table names are derived from the cluster ID in the legacy model, they are only kept when
+// they follow its naming.
+func ToNodeBootstrappingConfiguration(config *aksnodeconfigv1.Configuration) (*datamodel.NodeBootstrappingConfiguration, error) {
+	if config == nil {
+		return nil, errors.New("configuration is nil")
+	}
+	clusterConfig := config.GetClusterConfig()
+	networkConfig := clusterConfig.GetClusterNetworkConfig()
+	kubeletConfig := config.GetKubeletConfig()
+
+	caCert, err := decodeBase64("kubernetes_ca_cert", config.GetKubernetesCaCert())
+	if err != nil {
+		return nil, err
+	}
+	clientKey, err := decodeBase64("kubelet_config.kubelet_client_key", kubeletConfig.GetKubeletClientKey())
+	if err != nil {
+		return nil, err
+	}
+	clientCert, err := decodeBase64("kubelet_config.kubelet_client_cert_content", kubeletConfig.GetKubeletClientCertContent())
+	if err != nil {
+		return nil, err
+	}
+
+	agentPool := &datamodel.AgentPoolProfile{
+		VMSize:           config.GetVmSize(),
+		CustomNodeLabels: map[string]string{},
+		KubernetesConfig: &datamodel.KubernetesConfig{ContainerRuntime: datamodel.Containerd},
+	}
+	if networkConfig.GetVnetName() != "" && networkConfig.GetSubnet() != "" {
+		agentPool.VnetSubnetID = fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s/subnets/%s",
+			config.GetAuthConfig().GetSubscriptionId(), networkConfig.GetVnetResourceGroup(), networkConfig.GetVnetName(), networkConfig.GetSubnet())
+	}
+	kubernetesConfig := &datamodel.KubernetesConfig{
+		NetworkPlugin:                networkPluginName(config.GetNetworkConfig().GetNetworkPlugin()),
+		NetworkPolicy:                networkPolicyName(config.GetNetworkConfig().GetNetworkPolicy()),
+		LoadBalancerSku:              loadBalancerSKUName(clusterConfig.GetLoadBalancerConfig().GetLoadBalancerSku()),
+		ExcludeMasterFromStandardLB:  clusterConfig.GetLoadBalancerConfig().ExcludeMasterFromStandardLoadBalancer,
+		MaximumLoadBalancerRuleCount: int(clusterConfig.GetLoadBalancerConfig().GetMaxLoadBalancerRuleCount()),
+		UseInstanceMetadata:          proto.Bool(clusterConfig.GetUseInstanceMetadata()),
+		AzureCNIURLLinux:             config.GetNetworkConfig().GetVnetCniPluginsUrl(),
+		CustomKubeBinaryURL:          config.GetKubeBinaryConfig().GetCustomKubeBinaryUrl(),
+		CustomKubeProxyImage:         config.GetKubeProxyUrl(),
+		PrivateAzureRegistryServer:   config.GetAzurePrivateRegistryServer(),
+	}
+	properties := &datamodel.Properties{
+		OrchestratorProfile: &datamodel.OrchestratorProfile{
+			OrchestratorType:    datamodel.Kubernetes,
+			OrchestratorVersion: config.GetKubernetesVersion(),
+			KubernetesConfig:    kubernetesConfig,
+		},
+		AgentPoolProfiles:   []*datamodel.AgentPoolProfile{agentPool},
+		LinuxProfile:        &datamodel.LinuxProfile{AdminUsername: config.GetLinuxAdminUsername()},
+		HostedMasterProfile: &datamodel.HostedMasterProfile{FQDN: config.GetApiServerConfig().GetApiServerName()},
+		ServicePrincipalProfile: &datamodel.ServicePrincipalProfile{
+			ClientID: config.GetAuthConfig().GetServicePrincipalId(),
+			Secret:   config.GetAuthConfig().GetServicePrincipalSecret(),
+		},
+		CertificateProfile: &datamodel.CertificateProfile{
+			CaCertificate:     caCert,
+			ClientCertificate: clientCert,
+			ClientPrivateKey:  clientKey,
+		},
+	}
+	if match := routeTableRegex.FindStringSubmatch(networkConfig.GetRouteTable()); match != nil {
+		properties.ClusterID = match[2]
+	}
+	for label, value := range kubeletConfig.GetKubeletNodeLabels() {
+		agentPool.CustomNodeLabels[label] = value
+	}
+
+	nbc := &datamodel.NodeBootstrappingConfiguration{
+		ContainerService: &datamodel.ContainerService{Location: clusterConfig.GetLocation(), Properties: properties},
+		CloudSpecConfig: &datamodel.AzureEnvironmentSpecConfig{
+			KubernetesSpecConfig: datamodel.KubernetesSpecConfig{
+				CNIPluginsDownloadURL:     config.GetNetworkConfig().GetCniPluginsUrl(),
+				ContainerdDownloadURLBase: config.GetContainerdConfig().GetContainerdDownloadUrlBase(),
+			},
+		},
+		K8sComponents: &datamodel.K8sComponents{
+			PodInfraContainerImageURL:  config.GetKubeBinaryConfig().GetPodInfraContainerImageUrl(),
+			LinuxPrivatePackageURL:     config.GetKubeBinaryConfig().GetPrivateKubeBinaryUrl(),
+			LinuxCredentialProviderURL: config.GetKubeBinaryConfig().GetLinuxCredentialProviderUrl(),
+		},
+		AgentPoolProfile:                       agentPool,
+		TenantID:                               config.GetAuthConfig().GetTenantId(),
+		SubscriptionID:                         config.GetAuthConfig().GetSubscriptionId(),
+		ResourceGroupName:                      clusterConfig.GetResourceGroup(),
+		UserAssignedIdentityClientID:           config.GetAuthConfig().GetAssignedIdentityId(),
+		ConfigGPUDriverIfNeeded:                config.GetGpuConfig().GetConfigGpuDriver(),
+		EnableGPUDevicePluginIfNeeded:          config.GetGpuConfig().GetGpuDevicePlugin(),
+		EnableNvidia:                           config.GetGpuConfig().GetEnableNvidia(),
+		GPUInstanceProfile:                     config.GetGpuConfig().GetGpuInstanceProfile(),
+		EnableKubeletConfigFile:                kubeletConfig.GetEnableKubeletConfigFile(),
+		EnableACRTeleportPlugin:                config.GetTeleportConfig().GetStatus(),
+		TeleportdPluginURL:                     config.GetTeleportConfig().GetTeleportdPluginDownloadUrl(),
+		EnableArtifactStreaming:                config.GetEnableArtifactStreaming(),
+		ContainerdVersion:                      config.GetContainerdConfig().GetContainerdVersion(),
+		ContainerdPackageURL:                   config.GetContainerdConfig().GetContainerdPackageUrl(),
+		RuncVersion:                            config.GetRuncConfig().GetRuncVersion(),
+		RuncPackageURL:                         config.GetRuncConfig().GetRuncPackageUrl(),
+		KubeletClientTLSBootstrapToken:         config.GetBootstrappingConfig().TlsBootstrappingToken,
+		EnableSecureTLSBootstrapping:           config.GetBootstrappingConfig().GetBootstrappingAuthMethod() == aksnodeconfigv1.BootstrappingAuthMethod_BOOTSTRAPPING_AUTH_METHOD_SECURE_TLS_BOOTSTRAPPING,
+		CustomSecureTLSBootstrapAADServerAppID: config.GetBootstrappingConfig().GetCustomAadResource(),
+		KubeletConfig:                          copyMap(kubeletConfig.GetKubeletFlags()),
+		PrimaryScaleSetName:                    clusterConfig.GetPrimaryScaleSet(),
+		DisableUnattendedUpgrades:              !config.GetEnableUnattendedUpgrade(),
+		DisableCustomData:                      config.GetDisableCustomData(),
+		OutboundType:                           outboundTypeName(config.GetOutboundType()),
+		EnableIMDSRestriction:                  config.GetImdsRestrictionConfig().GetEnableImdsRestriction(),
+		InsertIMDSRestrictionRuleToMangleTable: config.GetImdsRestrictionConfig().GetInsertImdsRestrictionRuleToMangleTable(),
+		Version:                                config.GetVersion(),
+	}
+	if proxy := config.GetHttpProxyConfig(); proxy != nil {
+		noProxy := append([]string(nil), proxy.GetNoProxyEntries()...)
+		nbc.HTTPProxyConfig = &datamodel.HTTPProxyConfig{
+			HTTPProxy:  optionalString(proxy.GetHttpProxy()),
+			HTTPSProxy: optionalString(proxy.GetHttpsProxy()),
+			TrustedCA:  optionalString(proxy.GetProxyTrustedCa()),
+			NoProxy:    &noProxy,
+		}
+	}
+	if len(config.GetCustomCaCerts()) > 0 {
+		nbc.CustomCATrustConfig = &datamodel.CustomCATrustConfig{CustomCATrustCerts: append([]string(nil), config.GetCustomCaCerts()...)}
+	}
+	if config.EnableSsh != nil {
+		nbc.SSHStatus = datamodel.SSHOff
+		if config.GetEnableSsh() {
+			nbc.SSHStatus = datamodel.SSHOn
+		}
+	}
+	return nbc, nil
+}
+
+func networkPluginName(plugin aksnodeconfigv1.NetworkPlugin) string {
+	switch plugin {
+	case aksnodeconfigv1.NetworkPlugin_NETWORK_PLUGIN_AZURE:
+		return "azure"
+	case aksnodeconfigv1.NetworkPlugin_NETWORK_PLUGIN_KUBENET:
+		return "kubenet"
+	default:
+		return ""
+	}
+}
+
+func networkPolicyName(policy aksnodeconfigv1.NetworkPolicy) string {
+	switch policy {
+	case aksnodeconfigv1.NetworkPolicy_NETWORK_POLICY_AZURE:
+		return "azure"
+	case aksnodeconfigv1.NetworkPolicy_NETWORK_POLICY_CALICO:
+		return "calico"
+	default:
+		return ""
+	}
+}
+
+func loadBalancerSKUName(sku aksnodeconfigv1.LoadBalancerSku) string {
+	switch sku {
+	case aksnodeconfigv1.LoadBalancerSku_LOAD_BALANCER_SKU_STANDARD:
+		return "Standard"
+	case aksnodeconfigv1.LoadBalancerSku_LOAD_BALANCER_SKU_BASIC:
+		return "Basic"
+	default:
+		return ""
+	}
+}
+
+func outboundTypeName(outboundType aksnodeconfigv1.OutboundType) string {
+	switch outboundType {
+	case aksnodeconfigv1.OutboundType_OUTBOUND_TYPE_LOAD_BALANCER:
+		return "loadBalancer"
+	case aksnodeconfigv1.OutboundType_OUTBOUND_TYPE_USER_DEFINED_ROUTING:
+		return "userDefinedRouting"
+	case aksnodeconfigv1.OutboundType_OUTBOUND_TYPE_NONE:
+		return datamodel.OutboundTypeNone
+	default:
+		return ""
+	}
+}
+
+func decodeBase64(field, value string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", fmt.Errorf("decode %s: %w", field, err)
+	}
+	return string(data), nil
+}
+
+func copyMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	copied := make(map[string]string, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func derefStrings(s *[]string) []string {
+	if s == nil {
+		return nil
+	}
+	return append([]string(nil), *s...)
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
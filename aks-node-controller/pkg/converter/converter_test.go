@@ -0,0 +1,158 @@
+package converter
+
+import (
+	"encoding/base64"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func nodeBootstrappingConfiguration() *datamodel.NodeBootstrappingConfiguration {
+	token := "07401b.f395accd246ae52d"
+	httpProxy := "http://proxy.example.com:8080"
+	noProxy := []string{"localhost", "10.0.0.0/16"}
+	agentPool := &datamodel.AgentPoolProfile{
+		Name:                "nodepool1",
+		VMSize:              "Standard_D2s_v3",
+		OSType:              datamodel.Linux,
+		Distro:              datamodel.AKSUbuntuContainerd2204,
+		AvailabilityProfile: datamodel.VirtualMachineScaleSets,
+		CustomNodeLabels:    map[string]string{"team": "infra"},
+		KubernetesConfig:    &datamodel.KubernetesConfig{ContainerRuntime: datamodel.Containerd},
+	}
+	return &datamodel.NodeBootstrappingConfiguration{
+		ContainerService: &datamodel.ContainerService{
+			Location: "eastus",
+			Properties: &datamodel.Properties{
+				OrchestratorProfile: &datamodel.OrchestratorProfile{
+					OrchestratorType:    datamodel.Kubernetes,
+					OrchestratorVersion: "1.30.3",
+					KubernetesConfig: &datamodel.KubernetesConfig{
+						NetworkPlugin:    "azure",
+						NetworkPolicy:    "calico",
+						LoadBalancerSku:  "Standard",
+						AzureCNIURLLinux: "https://acs-mirror.azureedge.net/azure-cni/v1.5.32/binaries/azure-vnet-cni-linux-amd64-v1.5.32.tgz",
+					},
+				},
+				AgentPoolProfiles:       []*datamodel.AgentPoolProfile{agentPool},
+				HostedMasterProfile:     &datamodel.HostedMasterProfile{FQDN: "mycluster.hcp.eastus.azmk8s.io"},
+				LinuxProfile:            &datamodel.LinuxProfile{AdminUsername: "azureuser"},
+				CertificateProfile:      &datamodel.CertificateProfile{CaCertificate: "ca cert", ClientCertificate: "client cert", ClientPrivateKey: "client key"},
+				ServicePrincipalProfile: &datamodel.ServicePrincipalProfile{ClientID: "msi", Secret: "secret"},
+			},
+		},
+		CloudSpecConfig: &datamodel.AzureEnvironmentSpecConfig{
+			CloudName: datamodel.AzurePublicCloud,
+			KubernetesSpecConfig: datamodel.KubernetesSpecConfig{
+				CNIPluginsDownloadURL:     "https://acs-mirror.azureedge.net/cni/cni-plugins-amd64-v0.7.6.tgz",
+				ContainerdDownloadURLBase: "https://storage.googleapis.com/cri-containerd-release/",
+			},
+		},
+		K8sComponents: &datamodel.K8sComponents{
+			PodInfraContainerImageURL: "mcr.microsoft.com/oss/kubernetes/pause:3.6",
+			LinuxPrivatePackageURL:    "https://example.blob.core.windows.net/kubernetes/v1.30.3.tar.gz",
+		},
+		AgentPoolProfile:               agentPool,
+		TenantID:                       "tenant",
+		SubscriptionID:                 "subscription",
+		ResourceGroupName:              "MC_rg_mycluster_eastus",
+		UserAssignedIdentityClientID:   "identity",
+		PrimaryScaleSetName:            "aks-nodepool1-12345678-vmss",
+		KubeletClientTLSBootstrapToken: &token,
+		KubeletConfig: map[string]string{
+			"--cluster-dns":    "10.0.0.10",
+			"--max-pods":       "30",
+			"--cloud-provider": "external",
+		},
+		OutboundType:        "userDefinedRouting",
+		SSHStatus:           datamodel.SSHOff,
+		HTTPProxyConfig:     &datamodel.HTTPProxyConfig{HTTPProxy: &httpProxy, NoProxy: &noProxy},
+		CustomCATrustConfig: &datamodel.CustomCATrustConfig{CustomCATrustCerts: []string{"custom ca"}},
+	}
+}
+
+func TestFromNodeBootstrappingConfiguration(t *testing.T) {
+	nbc := nodeBootstrappingConfiguration()
+
+	config, err := FromNodeBootstrappingConfiguration(nbc)
+	require.NoError(t, err)
+	// the conversion doesn't modify its input.
+	assert.Equal(t, nodeBootstrappingConfiguration(), nbc)
+
+	assert.Equal(t, "v0", config.GetVersion())
+	assert.Equal(t, "1.30.3", config.GetKubernetesVersion())
+	assert.Equal(t, aksnodeconfigv1.VmType_VM_TYPE_VMSS, config.GetClusterConfig().GetVmType())
+	assert.Equal(t, "aks-agentpool-"+nbc.ContainerService.Properties.GetClusterID()+"-routetable",
+		config.GetClusterConfig().GetClusterNetworkConfig().GetRouteTable())
+	assert.Equal(t, aksnodeconfigv1.NetworkPlugin_NETWORK_PLUGIN_AZURE, config.GetNetworkConfig().GetNetworkPlugin())
+	assert.Equal(t, aksnodeconfigv1.NetworkPolicy_NETWORK_POLICY_CALICO, config.GetNetworkConfig().GetNetworkPolicy())
+	assert.Equal(t, aksnodeconfigv1.BootstrappingAuthMethod_BOOTSTRAPPING_AUTH_METHOD_BOOTSTRAP_TOKEN,
+		config.GetBootstrappingConfig().GetBootstrappingAuthMethod())
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("ca cert")), config.GetKubernetesCaCert())
+	assert.Equal(t, aksnodeconfigv1.OutboundType_OUTBOUND_TYPE_USER_DEFINED_ROUTING, config.GetOutboundType())
+	assert.Equal(t, "30", config.GetKubeletConfig().GetKubeletFlags()["--max-pods"])
+	assert.Equal(t, "infra", config.GetKubeletConfig().GetKubeletNodeLabels()["team"])
+	assert.Equal(t, proto.Bool(false), config.EnableSsh)
+	assert.Equal(t, "http://proxy.example.com:8080", config.GetHttpProxyConfig().GetHttpProxy())
+	assert.Equal(t, []string{"localhost", "10.0.0.0/16"}, config.GetHttpProxyConfig().GetNoProxyEntries())
+	assert.Equal(t, []string{"custom ca"}, config.GetCustomCaCerts())
+}
+
+func TestFromNodeBootstrappingConfiguration_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(nbc *datamodel.NodeBootstrappingConfiguration)
+		wantErr string
+	}{
+		{
+			name:    "no container service",
+			mutate:  func(nbc *datamodel.NodeBootstrappingConfiguration) { nbc.ContainerService = nil },
+			wantErr: "node bootstrapping configuration has no container service properties",
+		},
+		{
+			name:    "no agent pool profile",
+			mutate:  func(nbc *datamodel.NodeBootstrappingConfiguration) { nbc.AgentPoolProfile = nil },
+			wantErr: "node bootstrapping configuration has no agent pool profile",
+		},
+		{
+			name:    "no cloud spec config",
+			mutate:  func(nbc *datamodel.NodeBootstrappingConfiguration) { nbc.CloudSpecConfig = nil },
+			wantErr: "node bootstrapping configuration has no cloud spec config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nbc := nodeBootstrappingConfiguration()
+			tt.mutate(nbc)
+			_, err := FromNodeBootstrappingConfiguration(nbc)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestToNodeBootstrappingConfiguration(t *testing.T) {
+	config, err := FromNodeBootstrappingConfiguration(nodeBootstrappingConfiguration())
+	require.NoError(t, err)
+
+	nbc, err := ToNodeBootstrappingConfiguration(config)
+	require.NoError(t, err)
+	assert.Equal(t, "ca cert", nbc.ContainerService.Properties.CertificateProfile.CaCertificate)
+	assert.Equal(t, "mycluster.hcp.eastus.azmk8s.io", nbc.ContainerService.Properties.HostedMasterProfile.FQDN)
+	assert.Equal(t, datamodel.SSHOff, nbc.SSHStatus)
+	assert.Equal(t, "userDefinedRouting", nbc.OutboundType)
+
+	// converting back gives the same configuration, so both flows can be diffed.
+	roundTripped, err := FromNodeBootstrappingConfiguration(nbc)
+	require.NoError(t, err)
+	assert.Equal(t, protojson.Format(config), protojson.Format(roundTripped))
+}
+
+func TestToNodeBootstrappingConfiguration_InvalidBase64(t *testing.T) {
+	_, err := ToNodeBootstrappingConfiguration(&aksnodeconfigv1.Configuration{KubernetesCaCert: "not base64!"})
+	assert.ErrorContains(t, err, "decode kubernetes_ca_cert")
+}
@@ -31,6 +31,16 @@ type ContainerdConfig struct {
 	ContainerdVersion string `protobuf:"bytes,2,opt,name=containerd_version,json=containerdVersion,proto3" json:"containerd_version,omitempty"`
 	// The URL for downloading the containerd package.
 	ContainerdPackageUrl string `protobuf:"bytes,3,opt,name=containerd_package_url,json=containerdPackageUrl,proto3" json:"containerd_package_url,omitempty"`
+	// Whether images are streamed from ACR by the overlaybd snapshotter instead of being pulled before the containers
+	// start. Only node images shipping the snapshotter support it, images are pulled on the others. Supersedes
+	// Configuration.enable_artifact_streaming.
+	EnableArtifactStreaming bool `protobuf:"varint,4,opt,name=enable_artifact_streaming,json=enableArtifactStreaming,proto3" json:"enable_artifact_streaming,omitempty"`
+	// TOML fragments merged into the generated config.toml, in order, for settings such as custom snapshotters or debug
+	// options. A fragment may add keys and tables but not change a key already set to a different value.
+	ConfigSnippets []string `protobuf:"bytes,5,rep,name=config_snippets,json=configSnippets,proto3" json:"config_snippets,omitempty"`
+	// Pod sandbox (pause) image, replacing kube_binary_config.pod_infra_container_image_url. It is pinned in containerd so
+	// that image garbage collection never removes it.
+	SandboxImage *SandboxImageConfig `protobuf:"bytes,6,opt,name=sandbox_image,json=sandboxImage,proto3" json:"sandbox_image,omitempty"`
 }
 
 func (x *ContainerdConfig) Reset() {
@@ -84,13 +94,89 @@ func (x *ContainerdConfig) GetContainerdPackageUrl() string {
 	return ""
 }
 
+func (x *ContainerdConfig) GetEnableArtifactStreaming() bool {
+	if x != nil {
+		return x.EnableArtifactStreaming
+	}
+	return false
+}
+
+func (x *ContainerdConfig) GetConfigSnippets() []string {
+	if x != nil {
+		return x.ConfigSnippets
+	}
+	return nil
+}
+
+func (x *ContainerdConfig) GetSandboxImage() *SandboxImageConfig {
+	if x != nil {
+		return x.SandboxImage
+	}
+	return nil
+}
+
+type SandboxImageConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Image reference, such as mcr.microsoft.com/oss/kubernetes/pause:3.6.
+	Image string `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// Digest the image is pinned to, such as sha256:<64 hex characters>. The image is pulled by digest when set.
+	Digest string `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (x *SandboxImageConfig) Reset() {
+	*x = SandboxImageConfig{}
+	mi := &file_aksnodeconfig_v1_containerd_config_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SandboxImageConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SandboxImageConfig) ProtoMessage() {}
+
+func (x *SandboxImageConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_containerd_config_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SandboxImageConfig.ProtoReflect.Descriptor instead.
+func (*SandboxImageConfig) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_containerd_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SandboxImageConfig) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *SandboxImageConfig) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
 var File_aksnodeconfig_v1_containerd_config_proto protoreflect.FileDescriptor
 
 var file_aksnodeconfig_v1_containerd_config_proto_rawDesc = []byte{
 	0x0a, 0x28, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
 	0x76, 0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x5f, 0x63, 0x6f,
 	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x61, 0x6b, 0x73, 0x6e,
-	0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x22, 0xb8, 0x01, 0x0a,
+	0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x22, 0xe8, 0x02, 0x0a,
 	0x10, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69,
 	0x67, 0x12, 0x3f, 0x0a, 0x1c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x5f,
 	0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x75, 0x72, 0x6c, 0x5f, 0x62, 0x61, 0x73,
@@ -102,13 +188,28 @@ var file_aksnodeconfig_v1_containerd_config_proto_rawDesc = []byte{
 	0x6e, 0x12, 0x34, 0x0a, 0x16, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x5f,
 	0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x50, 0x61, 0x63,
-	0x6b, 0x61, 0x67, 0x65, 0x55, 0x72, 0x6c, 0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x67, 0x65, 0x6e,
-	0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b, 0x73, 0x2d, 0x6e, 0x6f, 0x64, 0x65, 0x2d,
-	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67,
-	0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6b, 0x61, 0x67, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x3a, 0x0a, 0x19, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x5f, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x69, 0x6e, 0x67, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x73, 0x6e,
+	0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x53, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x73, 0x12, 0x49, 0x0a, 0x0d,
+	0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x49, 0x6d,
+	0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x73, 0x61, 0x6e, 0x64, 0x62,
+	0x6f, 0x78, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x22, 0x42, 0x0a, 0x12, 0x53, 0x61, 0x6e, 0x64, 0x62,
+	0x6f, 0x78, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x14, 0x0a,
+	0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d,
+	0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x42, 0x5a, 0x5a, 0x58, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x2f,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b, 0x73, 0x2d, 0x6e,
+	0x6f, 0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -123,16 +224,18 @@ func file_aksnodeconfig_v1_containerd_config_proto_rawDescGZIP() []byte {
 	return file_aksnodeconfig_v1_containerd_config_proto_rawDescData
 }
 
-var file_aksnodeconfig_v1_containerd_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_aksnodeconfig_v1_containerd_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_aksnodeconfig_v1_containerd_config_proto_goTypes = []any{
-	(*ContainerdConfig)(nil), // 0: aksnodeconfig.v1.ContainerdConfig
+	(*ContainerdConfig)(nil),   // 0: aksnodeconfig.v1.ContainerdConfig
+	(*SandboxImageConfig)(nil), // 1: aksnodeconfig.v1.SandboxImageConfig
 }
 var file_aksnodeconfig_v1_containerd_config_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: aksnodeconfig.v1.ContainerdConfig.sandbox_image:type_name -> aksnodeconfig.v1.SandboxImageConfig
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_aksnodeconfig_v1_containerd_config_proto_init() }
@@ -146,7 +249,7 @@ func file_aksnodeconfig_v1_containerd_config_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_aksnodeconfig_v1_containerd_config_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,358 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: aksnodeconfig/v1/credential_provider_config.proto
+
+package aksnodeconfigv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CredentialProviderType int32
+
+const (
+	CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_UNSPECIFIED CredentialProviderType = 0
+	// The ACR credential provider authenticating with the kubelet identity.
+	CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_ACR CredentialProviderType = 1
+	// The ACR credential provider authenticating with an identity of another tenant.
+	CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_ACR_CROSS_TENANT CredentialProviderType = 2
+	// A custom exec plugin.
+	CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_CUSTOM CredentialProviderType = 3
+)
+
+// Enum value maps for CredentialProviderType.
+var (
+	CredentialProviderType_name = map[int32]string{
+		0: "CREDENTIAL_PROVIDER_TYPE_UNSPECIFIED",
+		1: "CREDENTIAL_PROVIDER_TYPE_ACR",
+		2: "CREDENTIAL_PROVIDER_TYPE_ACR_CROSS_TENANT",
+		3: "CREDENTIAL_PROVIDER_TYPE_CUSTOM",
+	}
+	CredentialProviderType_value = map[string]int32{
+		"CREDENTIAL_PROVIDER_TYPE_UNSPECIFIED":      0,
+		"CREDENTIAL_PROVIDER_TYPE_ACR":              1,
+		"CREDENTIAL_PROVIDER_TYPE_ACR_CROSS_TENANT": 2,
+		"CREDENTIAL_PROVIDER_TYPE_CUSTOM":           3,
+	}
+)
+
+func (x CredentialProviderType) Enum() *CredentialProviderType {
+	p := new(CredentialProviderType)
+	*p = x
+	return p
+}
+
+func (x CredentialProviderType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CredentialProviderType) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_credential_provider_config_proto_enumTypes[0].Descriptor()
+}
+
+func (CredentialProviderType) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_credential_provider_config_proto_enumTypes[0]
+}
+
+func (x CredentialProviderType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CredentialProviderType.Descriptor instead.
+func (CredentialProviderType) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_credential_provider_config_proto_rawDescGZIP(), []int{0}
+}
+
+type CredentialProviders struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The providers of the kubelet CredentialProviderConfig.
+	Providers []*CredentialProvider `protobuf:"bytes,1,rep,name=providers,proto3" json:"providers,omitempty"`
+}
+
+func (x *CredentialProviders) Reset() {
+	*x = CredentialProviders{}
+	mi := &file_aksnodeconfig_v1_credential_provider_config_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CredentialProviders) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredentialProviders) ProtoMessage() {}
+
+func (x *CredentialProviders) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_credential_provider_config_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredentialProviders.ProtoReflect.Descriptor instead.
+func (*CredentialProviders) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_credential_provider_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CredentialProviders) GetProviders() []*CredentialProvider {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+type CredentialProvider struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type CredentialProviderType `protobuf:"varint,1,opt,name=type,proto3,enum=aksnodeconfig.v1.CredentialProviderType" json:"type,omitempty"`
+	// Name of the plugin binary, which is also the name of the provider. Defaults to acr-credential-provider for the ACR
+	// providers.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// URL of the plugin binary, or of a tar.gz archive containing it. Required for custom plugins, the ACR providers use
+	// the binary installed from kube_binary_config.linux_credential_provider_url.
+	DownloadUrl string `protobuf:"bytes,3,opt,name=download_url,json=downloadUrl,proto3" json:"download_url,omitempty"`
+	// Images matched by the provider, such as "*.azurecr.io". Defaults to the ACR registries for the ACR providers.
+	MatchImages []string `protobuf:"bytes,4,rep,name=match_images,json=matchImages,proto3" json:"match_images,omitempty"`
+	// How long kubelet caches the credentials when the plugin doesn't set it, such as "10m". Defaults to "10m".
+	DefaultCacheDuration string `protobuf:"bytes,5,opt,name=default_cache_duration,json=defaultCacheDuration,proto3" json:"default_cache_duration,omitempty"`
+	// Arguments of the exec plugin. Defaults to the cloud config file for the ACR providers.
+	Args []string `protobuf:"bytes,6,rep,name=args,proto3" json:"args,omitempty"`
+	// Environment variables of the exec plugin.
+	Env map[string]string `protobuf:"bytes,7,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Tenant of the identity of the cross-tenant ACR provider.
+	TenantId string `protobuf:"bytes,8,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// Client ID of the user-assigned managed identity of the cross-tenant ACR provider.
+	ClientId string `protobuf:"bytes,9,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+}
+
+func (x *CredentialProvider) Reset() {
+	*x = CredentialProvider{}
+	mi := &file_aksnodeconfig_v1_credential_provider_config_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CredentialProvider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredentialProvider) ProtoMessage() {}
+
+func (x *CredentialProvider) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_credential_provider_config_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredentialProvider.ProtoReflect.Descriptor instead.
+func (*CredentialProvider) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_credential_provider_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CredentialProvider) GetType() CredentialProviderType {
+	if x != nil {
+		return x.Type
+	}
+	return CredentialProviderType_CREDENTIAL_PROVIDER_TYPE_UNSPECIFIED
+}
+
+func (x *CredentialProvider) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CredentialProvider) GetDownloadUrl() string {
+	if x != nil {
+		return x.DownloadUrl
+	}
+	return ""
+}
+
+func (x *CredentialProvider) GetMatchImages() []string {
+	if x != nil {
+		return x.MatchImages
+	}
+	return nil
+}
+
+func (x *CredentialProvider) GetDefaultCacheDuration() string {
+	if x != nil {
+		return x.DefaultCacheDuration
+	}
+	return ""
+}
+
+func (x *CredentialProvider) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *CredentialProvider) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *CredentialProvider) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *CredentialProvider) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+var File_aksnodeconfig_v1_credential_provider_config_proto protoreflect.FileDescriptor
+
+var file_aksnodeconfig_v1_credential_provider_config_proto_rawDesc = []byte{
+	0x0a, 0x31, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x76, 0x31, 0x2f, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x70, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x10, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x76, 0x31, 0x22, 0x59, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x12, 0x42, 0x0a, 0x09,
+	0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x50, 0x72, 0x6f,
+	0x76, 0x69, 0x64, 0x65, 0x72, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73,
+	0x22, 0xa9, 0x03, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x50,
+	0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x3c, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x6f, 0x77,
+	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x55, 0x72, 0x6c, 0x12, 0x21, 0x0a, 0x0c,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12,
+	0x34, 0x0a, 0x16, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65,
+	0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x14, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x3f, 0x0a, 0x03, 0x65, 0x6e, 0x76,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x45, 0x6e, 0x76,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x65,
+	0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74,
+	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x49, 0x64, 0x1a, 0x36, 0x0a, 0x08, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0xb8, 0x01, 0x0a,
+	0x16, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x76, 0x69,
+	0x64, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x28, 0x0a, 0x24, 0x43, 0x52, 0x45, 0x44, 0x45,
+	0x4e, 0x54, 0x49, 0x41, 0x4c, 0x5f, 0x50, 0x52, 0x4f, 0x56, 0x49, 0x44, 0x45, 0x52, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x52, 0x45, 0x44, 0x45, 0x4e, 0x54, 0x49, 0x41, 0x4c, 0x5f,
+	0x50, 0x52, 0x4f, 0x56, 0x49, 0x44, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x43,
+	0x52, 0x10, 0x01, 0x12, 0x2d, 0x0a, 0x29, 0x43, 0x52, 0x45, 0x44, 0x45, 0x4e, 0x54, 0x49, 0x41,
+	0x4c, 0x5f, 0x50, 0x52, 0x4f, 0x56, 0x49, 0x44, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x41, 0x43, 0x52, 0x5f, 0x43, 0x52, 0x4f, 0x53, 0x53, 0x5f, 0x54, 0x45, 0x4e, 0x41, 0x4e, 0x54,
+	0x10, 0x02, 0x12, 0x23, 0x0a, 0x1f, 0x43, 0x52, 0x45, 0x44, 0x45, 0x4e, 0x54, 0x49, 0x41, 0x4c,
+	0x5f, 0x50, 0x52, 0x4f, 0x56, 0x49, 0x44, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43,
+	0x55, 0x53, 0x54, 0x4f, 0x4d, 0x10, 0x03, 0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b, 0x73, 0x2d, 0x6e, 0x6f, 0x64, 0x65, 0x2d,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67,
+	0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_aksnodeconfig_v1_credential_provider_config_proto_rawDescOnce sync.Once
+	file_aksnodeconfig_v1_credential_provider_config_proto_rawDescData = file_aksnodeconfig_v1_credential_provider_config_proto_rawDesc
+)
+
+func file_aksnodeconfig_v1_credential_provider_config_proto_rawDescGZIP() []byte {
+	file_aksnodeconfig_v1_credential_provider_config_proto_rawDescOnce.Do(func() {
+		file_aksnodeconfig_v1_credential_provider_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_aksnodeconfig_v1_credential_provider_config_proto_rawDescData)
+	})
+	return file_aksnodeconfig_v1_credential_provider_config_proto_rawDescData
+}
+
+var file_aksnodeconfig_v1_credential_provider_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_aksnodeconfig_v1_credential_provider_config_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_aksnodeconfig_v1_credential_provider_config_proto_goTypes = []any{
+	(CredentialProviderType)(0), // 0: aksnodeconfig.v1.CredentialProviderType
+	(*CredentialProviders)(nil), // 1: aksnodeconfig.v1.CredentialProviders
+	(*CredentialProvider)(nil),  // 2: aksnodeconfig.v1.CredentialProvider
+	nil,                         // 3: aksnodeconfig.v1.CredentialProvider.EnvEntry
+}
+var file_aksnodeconfig_v1_credential_provider_config_proto_depIdxs = []int32{
+	2, // 0: aksnodeconfig.v1.CredentialProviders.providers:type_name -> aksnodeconfig.v1.CredentialProvider
+	0, // 1: aksnodeconfig.v1.CredentialProvider.type:type_name -> aksnodeconfig.v1.CredentialProviderType
+	3, // 2: aksnodeconfig.v1.CredentialProvider.env:type_name -> aksnodeconfig.v1.CredentialProvider.EnvEntry
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_aksnodeconfig_v1_credential_provider_config_proto_init() }
+func file_aksnodeconfig_v1_credential_provider_config_proto_init() {
+	if File_aksnodeconfig_v1_credential_provider_config_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aksnodeconfig_v1_credential_provider_config_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aksnodeconfig_v1_credential_provider_config_proto_goTypes,
+		DependencyIndexes: file_aksnodeconfig_v1_credential_provider_config_proto_depIdxs,
+		EnumInfos:         file_aksnodeconfig_v1_credential_provider_config_proto_enumTypes,
+		MessageInfos:      file_aksnodeconfig_v1_credential_provider_config_proto_msgTypes,
+	}.Build()
+	File_aksnodeconfig_v1_credential_provider_config_proto = out.File
+	file_aksnodeconfig_v1_credential_provider_config_proto_rawDesc = nil
+	file_aksnodeconfig_v1_credential_provider_config_proto_goTypes = nil
+	file_aksnodeconfig_v1_credential_provider_config_proto_depIdxs = nil
+}
@@ -40,6 +40,15 @@ type CustomLinuxOsConfig struct {
 	// Valid values are "always", "madvise" and "never"
 	// If it's unset or set to empty string, it will use the default value in the VHD "madvise"
 	TransparentDefrag string `protobuf:"bytes,6,opt,name=transparent_defrag,json=transparentDefrag,proto3" json:"transparent_defrag,omitempty"`
+	// Kernel modules loaded on boot through /etc/modules-load.d, in addition to the ones loaded by the VHD
+	KernelModules []*KernelModule `protobuf:"bytes,7,rep,name=kernel_modules,json=kernelModules,proto3" json:"kernel_modules,omitempty"`
+	// Prevent the kernel from loading legacy filesystem and network protocol modules (cramfs, hfs, dccp, sctp, ...)
+	// on demand, following the CIS benchmark. Independent of the other hardening options.
+	DisableLegacyKernelModules bool `protobuf:"varint,8,opt,name=disable_legacy_kernel_modules,json=disableLegacyKernelModules,proto3" json:"disable_legacy_kernel_modules,omitempty"`
+	// Swap configured by aks-node-controller and used by pods, replaces enable_swap_config and swap_file_size
+	SwapConfig *SwapConfig `protobuf:"bytes,9,opt,name=swap_config,json=swapConfig,proto3" json:"swap_config,omitempty"`
+	// Hugepages preallocated for pods
+	HugepagesConfig *HugepagesConfig `protobuf:"bytes,10,opt,name=hugepages_config,json=hugepagesConfig,proto3" json:"hugepages_config,omitempty"`
 }
 
 func (x *CustomLinuxOsConfig) Reset() {
@@ -114,6 +123,89 @@ func (x *CustomLinuxOsConfig) GetTransparentDefrag() string {
 	return ""
 }
 
+func (x *CustomLinuxOsConfig) GetKernelModules() []*KernelModule {
+	if x != nil {
+		return x.KernelModules
+	}
+	return nil
+}
+
+func (x *CustomLinuxOsConfig) GetDisableLegacyKernelModules() bool {
+	if x != nil {
+		return x.DisableLegacyKernelModules
+	}
+	return false
+}
+
+func (x *CustomLinuxOsConfig) GetSwapConfig() *SwapConfig {
+	if x != nil {
+		return x.SwapConfig
+	}
+	return nil
+}
+
+func (x *CustomLinuxOsConfig) GetHugepagesConfig() *HugepagesConfig {
+	if x != nil {
+		return x.HugepagesConfig
+	}
+	return nil
+}
+
+type KernelModule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the kernel module, e.g. "nf_conntrack"
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Module parameters written to /etc/modprobe.d as "key=value"
+	Parameters []string `protobuf:"bytes,2,rep,name=parameters,proto3" json:"parameters,omitempty"`
+}
+
+func (x *KernelModule) Reset() {
+	*x = KernelModule{}
+	mi := &file_aksnodeconfig_v1_custom_linux_os_config_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KernelModule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KernelModule) ProtoMessage() {}
+
+func (x *KernelModule) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_custom_linux_os_config_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KernelModule.ProtoReflect.Descriptor instead.
+func (*KernelModule) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_custom_linux_os_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *KernelModule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *KernelModule) GetParameters() []string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
 type SysctlConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -148,11 +240,14 @@ type SysctlConfig struct {
 	VmMaxMapCount                  *int32  `protobuf:"varint,26,opt,name=vm_max_map_count,json=vmMaxMapCount,proto3,oneof" json:"vm_max_map_count,omitempty"`
 	VmSwappiness                   *int32  `protobuf:"varint,27,opt,name=vm_swappiness,json=vmSwappiness,proto3,oneof" json:"vm_swappiness,omitempty"`
 	VmVfsCachePressure             *int32  `protobuf:"varint,28,opt,name=vm_vfs_cache_pressure,json=vmVfsCachePressure,proto3,oneof" json:"vm_vfs_cache_pressure,omitempty"`
+	// Sysctls without a dedicated field keyed by name, e.g. "net.core.busy_poll". Only net.core, net.ipv4, net.ipv6,
+	// net.netfilter, fs.inotify and vm keys are allowed, keys with a dedicated field must use that field.
+	AdditionalSysctls map[string]string `protobuf:"bytes,29,rep,name=additional_sysctls,json=additionalSysctls,proto3" json:"additional_sysctls,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *SysctlConfig) Reset() {
 	*x = SysctlConfig{}
-	mi := &file_aksnodeconfig_v1_custom_linux_os_config_proto_msgTypes[1]
+	mi := &file_aksnodeconfig_v1_custom_linux_os_config_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -164,7 +259,7 @@ func (x *SysctlConfig) String() string {
 func (*SysctlConfig) ProtoMessage() {}
 
 func (x *SysctlConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_aksnodeconfig_v1_custom_linux_os_config_proto_msgTypes[1]
+	mi := &file_aksnodeconfig_v1_custom_linux_os_config_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -177,7 +272,7 @@ func (x *SysctlConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SysctlConfig.ProtoReflect.Descriptor instead.
 func (*SysctlConfig) Descriptor() ([]byte, []int) {
-	return file_aksnodeconfig_v1_custom_linux_os_config_proto_rawDescGZIP(), []int{1}
+	return file_aksnodeconfig_v1_custom_linux_os_config_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *SysctlConfig) GetNetCoreSomaxconn() int32 {
@@ -376,6 +471,13 @@ func (x *SysctlConfig) GetVmVfsCachePressure() int32 {
 	return 0
 }
 
+func (x *SysctlConfig) GetAdditionalSysctls() map[string]string {
+	if x != nil {
+		return x.AdditionalSysctls
+	}
+	return nil
+}
+
 type UlimitConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -388,7 +490,7 @@ type UlimitConfig struct {
 
 func (x *UlimitConfig) Reset() {
 	*x = UlimitConfig{}
-	mi := &file_aksnodeconfig_v1_custom_linux_os_config_proto_msgTypes[2]
+	mi := &file_aksnodeconfig_v1_custom_linux_os_config_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -400,7 +502,7 @@ func (x *UlimitConfig) String() string {
 func (*UlimitConfig) ProtoMessage() {}
 
 func (x *UlimitConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_aksnodeconfig_v1_custom_linux_os_config_proto_msgTypes[2]
+	mi := &file_aksnodeconfig_v1_custom_linux_os_config_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -413,7 +515,7 @@ func (x *UlimitConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UlimitConfig.ProtoReflect.Descriptor instead.
 func (*UlimitConfig) Descriptor() ([]byte, []int) {
-	return file_aksnodeconfig_v1_custom_linux_os_config_proto_rawDescGZIP(), []int{2}
+	return file_aksnodeconfig_v1_custom_linux_os_config_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *UlimitConfig) GetNoFile() string {
@@ -437,197 +539,235 @@ var file_aksnodeconfig_v1_custom_linux_os_config_proto_rawDesc = []byte{
 	0x76, 0x31, 0x2f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x5f, 0x6c, 0x69, 0x6e, 0x75, 0x78, 0x5f,
 	0x6f, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
 	0x10, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76,
-	0x31, 0x22, 0xe4, 0x02, 0x0a, 0x13, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x4c, 0x69, 0x6e, 0x75,
-	0x78, 0x4f, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x43, 0x0a, 0x0d, 0x73, 0x79, 0x73,
-	0x63, 0x74, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1e, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x73, 0x63, 0x74, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x52, 0x0c, 0x73, 0x79, 0x73, 0x63, 0x74, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x43,
-	0x0a, 0x0d, 0x75, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x75, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x12, 0x2c, 0x0a, 0x12, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x73, 0x77,
-	0x61, 0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x10, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x77, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73,
-	0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x77, 0x61, 0x70, 0x46,
-	0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x40, 0x0a, 0x1c, 0x74, 0x72, 0x61, 0x6e, 0x73,
-	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x75, 0x67, 0x65, 0x70, 0x61, 0x67, 0x65, 0x5f,
-	0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x1a, 0x74,
-	0x72, 0x61, 0x6e, 0x73, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x75, 0x67, 0x65, 0x70, 0x61,
-	0x67, 0x65, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x74, 0x72, 0x61,
-	0x6e, 0x73, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x65, 0x66, 0x72, 0x61, 0x67, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x61, 0x72, 0x65,
-	0x6e, 0x74, 0x44, 0x65, 0x66, 0x72, 0x61, 0x67, 0x22, 0x9d, 0x13, 0x0a, 0x0c, 0x53, 0x79, 0x73,
-	0x63, 0x74, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x31, 0x0a, 0x12, 0x6e, 0x65, 0x74,
-	0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x73, 0x6f, 0x6d, 0x61, 0x78, 0x63, 0x6f, 0x6e, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x10, 0x6e, 0x65, 0x74, 0x43, 0x6f, 0x72, 0x65,
-	0x53, 0x6f, 0x6d, 0x61, 0x78, 0x63, 0x6f, 0x6e, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x41, 0x0a, 0x1b,
-	0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x6e, 0x65, 0x74, 0x64, 0x65, 0x76, 0x5f,
-	0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x05, 0x48, 0x01, 0x52, 0x17, 0x6e, 0x65, 0x74, 0x43, 0x6f, 0x72, 0x65, 0x4e, 0x65, 0x74, 0x64,
-	0x65, 0x76, 0x4d, 0x61, 0x78, 0x42, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67, 0x88, 0x01, 0x01, 0x12,
-	0x36, 0x0a, 0x15, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x72, 0x6d, 0x65, 0x6d,
-	0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x48, 0x02,
-	0x52, 0x12, 0x6e, 0x65, 0x74, 0x43, 0x6f, 0x72, 0x65, 0x52, 0x6d, 0x65, 0x6d, 0x44, 0x65, 0x66,
+	0x31, 0x1a, 0x27, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2f, 0x76, 0x31, 0x2f, 0x68, 0x75, 0x67, 0x65, 0x70, 0x61, 0x67, 0x65, 0x73, 0x5f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x22, 0x61, 0x6b, 0x73, 0x6e,
+	0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x2f, 0x73, 0x77, 0x61,
+	0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xfb,
+	0x04, 0x0a, 0x13, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x4c, 0x69, 0x6e, 0x75, 0x78, 0x4f, 0x73,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x43, 0x0a, 0x0d, 0x73, 0x79, 0x73, 0x63, 0x74, 0x6c,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x79, 0x73, 0x63, 0x74, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x73,
+	0x79, 0x73, 0x63, 0x74, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x43, 0x0a, 0x0d, 0x75,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x0c, 0x75, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x12, 0x2c, 0x0a, 0x12, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x53, 0x77, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x24,
+	0x0a, 0x0e, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x77, 0x61, 0x70, 0x46, 0x69, 0x6c, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x40, 0x0a, 0x1c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x5f, 0x68, 0x75, 0x67, 0x65, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x75, 0x70,
+	0x70, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x1a, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x75, 0x67, 0x65, 0x70, 0x61, 0x67, 0x65, 0x53,
+	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x65, 0x66, 0x72, 0x61, 0x67, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x44,
+	0x65, 0x66, 0x72, 0x61, 0x67, 0x12, 0x45, 0x0a, 0x0e, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x5f,
+	0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x0d, 0x6b,
+	0x65, 0x72, 0x6e, 0x65, 0x6c, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x41, 0x0a, 0x1d,
+	0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x5f, 0x6b,
+	0x65, 0x72, 0x6e, 0x65, 0x6c, 0x5f, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x1a, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x4c, 0x65, 0x67, 0x61,
+	0x63, 0x79, 0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12,
+	0x3d, 0x0a, 0x0b, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x0a, 0x73, 0x77, 0x61, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x4c,
+	0x0a, 0x10, 0x68, 0x75, 0x67, 0x65, 0x70, 0x61, 0x67, 0x65, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f,
+	0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x75, 0x67, 0x65,
+	0x70, 0x61, 0x67, 0x65, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0f, 0x68, 0x75, 0x67,
+	0x65, 0x70, 0x61, 0x67, 0x65, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x42, 0x0a, 0x0c,
+	0x4b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x22, 0xc9, 0x14, 0x0a, 0x0c, 0x53, 0x79, 0x73, 0x63, 0x74, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x31, 0x0a, 0x12, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x73, 0x6f,
+	0x6d, 0x61, 0x78, 0x63, 0x6f, 0x6e, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52,
+	0x10, 0x6e, 0x65, 0x74, 0x43, 0x6f, 0x72, 0x65, 0x53, 0x6f, 0x6d, 0x61, 0x78, 0x63, 0x6f, 0x6e,
+	0x6e, 0x88, 0x01, 0x01, 0x12, 0x41, 0x0a, 0x1b, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65,
+	0x5f, 0x6e, 0x65, 0x74, 0x64, 0x65, 0x76, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x63, 0x6b,
+	0x6c, 0x6f, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x17, 0x6e, 0x65, 0x74,
+	0x43, 0x6f, 0x72, 0x65, 0x4e, 0x65, 0x74, 0x64, 0x65, 0x76, 0x4d, 0x61, 0x78, 0x42, 0x61, 0x63,
+	0x6b, 0x6c, 0x6f, 0x67, 0x88, 0x01, 0x01, 0x12, 0x36, 0x0a, 0x15, 0x6e, 0x65, 0x74, 0x5f, 0x63,
+	0x6f, 0x72, 0x65, 0x5f, 0x72, 0x6d, 0x65, 0x6d, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x48, 0x02, 0x52, 0x12, 0x6e, 0x65, 0x74, 0x43, 0x6f, 0x72,
+	0x65, 0x52, 0x6d, 0x65, 0x6d, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x88, 0x01, 0x01, 0x12,
+	0x2e, 0x0a, 0x11, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x72, 0x6d, 0x65, 0x6d,
+	0x5f, 0x6d, 0x61, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x03, 0x52, 0x0e, 0x6e, 0x65,
+	0x74, 0x43, 0x6f, 0x72, 0x65, 0x52, 0x6d, 0x65, 0x6d, 0x4d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12,
+	0x36, 0x0a, 0x15, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x77, 0x6d, 0x65, 0x6d,
+	0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x48, 0x04,
+	0x52, 0x12, 0x6e, 0x65, 0x74, 0x43, 0x6f, 0x72, 0x65, 0x57, 0x6d, 0x65, 0x6d, 0x44, 0x65, 0x66,
 	0x61, 0x75, 0x6c, 0x74, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a, 0x11, 0x6e, 0x65, 0x74, 0x5f, 0x63,
-	0x6f, 0x72, 0x65, 0x5f, 0x72, 0x6d, 0x65, 0x6d, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x05, 0x48, 0x03, 0x52, 0x0e, 0x6e, 0x65, 0x74, 0x43, 0x6f, 0x72, 0x65, 0x52, 0x6d, 0x65,
-	0x6d, 0x4d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12, 0x36, 0x0a, 0x15, 0x6e, 0x65, 0x74, 0x5f, 0x63,
-	0x6f, 0x72, 0x65, 0x5f, 0x77, 0x6d, 0x65, 0x6d, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x48, 0x04, 0x52, 0x12, 0x6e, 0x65, 0x74, 0x43, 0x6f, 0x72,
-	0x65, 0x57, 0x6d, 0x65, 0x6d, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x88, 0x01, 0x01, 0x12,
-	0x2e, 0x0a, 0x11, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x77, 0x6d, 0x65, 0x6d,
-	0x5f, 0x6d, 0x61, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x48, 0x05, 0x52, 0x0e, 0x6e, 0x65,
-	0x74, 0x43, 0x6f, 0x72, 0x65, 0x57, 0x6d, 0x65, 0x6d, 0x4d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12,
-	0x32, 0x0a, 0x13, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x6d,
-	0x65, 0x6d, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x48, 0x06, 0x52, 0x10,
-	0x6e, 0x65, 0x74, 0x43, 0x6f, 0x72, 0x65, 0x4f, 0x70, 0x74, 0x6d, 0x65, 0x6d, 0x4d, 0x61, 0x78,
+	0x6f, 0x72, 0x65, 0x5f, 0x77, 0x6d, 0x65, 0x6d, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x48, 0x05, 0x52, 0x0e, 0x6e, 0x65, 0x74, 0x43, 0x6f, 0x72, 0x65, 0x57, 0x6d, 0x65,
+	0x6d, 0x4d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12, 0x32, 0x0a, 0x13, 0x6e, 0x65, 0x74, 0x5f, 0x63,
+	0x6f, 0x72, 0x65, 0x5f, 0x6f, 0x70, 0x74, 0x6d, 0x65, 0x6d, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x05, 0x48, 0x06, 0x52, 0x10, 0x6e, 0x65, 0x74, 0x43, 0x6f, 0x72, 0x65, 0x4f,
+	0x70, 0x74, 0x6d, 0x65, 0x6d, 0x4d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12, 0x42, 0x0a, 0x1c, 0x6e,
+	0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x6d, 0x61, 0x78, 0x5f,
+	0x73, 0x79, 0x6e, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x05, 0x48, 0x07, 0x52, 0x17, 0x6e, 0x65, 0x74, 0x49, 0x70, 0x76, 0x34, 0x54, 0x63, 0x70, 0x4d,
+	0x61, 0x78, 0x53, 0x79, 0x6e, 0x42, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67, 0x88, 0x01, 0x01, 0x12,
+	0x40, 0x0a, 0x1b, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f,
+	0x6d, 0x61, 0x78, 0x5f, 0x74, 0x77, 0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x05, 0x48, 0x08, 0x52, 0x16, 0x6e, 0x65, 0x74, 0x49, 0x70, 0x76, 0x34, 0x54,
+	0x63, 0x70, 0x4d, 0x61, 0x78, 0x54, 0x77, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x88, 0x01,
+	0x01, 0x12, 0x3b, 0x0a, 0x18, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63,
+	0x70, 0x5f, 0x66, 0x69, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x05, 0x48, 0x09, 0x52, 0x14, 0x6e, 0x65, 0x74, 0x49, 0x70, 0x76, 0x34, 0x54, 0x63,
+	0x70, 0x46, 0x69, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x88, 0x01, 0x01, 0x12, 0x41,
+	0x0a, 0x1b, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x6b,
+	0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x05, 0x48, 0x0a, 0x52, 0x17, 0x6e, 0x65, 0x74, 0x49, 0x70, 0x76, 0x34, 0x54, 0x63,
+	0x70, 0x4b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x88, 0x01,
+	0x01, 0x12, 0x45, 0x0a, 0x1d, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63,
+	0x70, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x62,
+	0x65, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x48, 0x0b, 0x52, 0x19, 0x6e, 0x65, 0x74, 0x49,
+	0x70, 0x76, 0x34, 0x54, 0x63, 0x70, 0x4b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x50,
+	0x72, 0x6f, 0x62, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x42, 0x0a, 0x1b, 0x6e, 0x65, 0x74, 0x5f,
+	0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76,
+	0x65, 0x5f, 0x69, 0x6e, 0x74, 0x76, 0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x48, 0x0c, 0x52,
+	0x18, 0x6e, 0x65, 0x74, 0x49, 0x70, 0x76, 0x34, 0x54, 0x63, 0x70, 0x6b, 0x65, 0x65, 0x70, 0x61,
+	0x6c, 0x69, 0x76, 0x65, 0x49, 0x6e, 0x74, 0x76, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a, 0x15,
+	0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x74, 0x77, 0x5f,
+	0x72, 0x65, 0x75, 0x73, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x48, 0x0d, 0x52, 0x11, 0x6e,
+	0x65, 0x74, 0x49, 0x70, 0x76, 0x34, 0x54, 0x63, 0x70, 0x54, 0x77, 0x52, 0x65, 0x75, 0x73, 0x65,
 	0x88, 0x01, 0x01, 0x12, 0x42, 0x0a, 0x1c, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f,
-	0x74, 0x63, 0x70, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x79, 0x6e, 0x5f, 0x62, 0x61, 0x63, 0x6b,
-	0x6c, 0x6f, 0x67, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x48, 0x07, 0x52, 0x17, 0x6e, 0x65, 0x74,
-	0x49, 0x70, 0x76, 0x34, 0x54, 0x63, 0x70, 0x4d, 0x61, 0x78, 0x53, 0x79, 0x6e, 0x42, 0x61, 0x63,
-	0x6b, 0x6c, 0x6f, 0x67, 0x88, 0x01, 0x01, 0x12, 0x40, 0x0a, 0x1b, 0x6e, 0x65, 0x74, 0x5f, 0x69,
-	0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x77, 0x5f, 0x62,
-	0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x48, 0x08, 0x52, 0x16,
-	0x6e, 0x65, 0x74, 0x49, 0x70, 0x76, 0x34, 0x54, 0x63, 0x70, 0x4d, 0x61, 0x78, 0x54, 0x77, 0x42,
-	0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x88, 0x01, 0x01, 0x12, 0x3b, 0x0a, 0x18, 0x6e, 0x65, 0x74,
-	0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x66, 0x69, 0x6e, 0x5f, 0x74, 0x69,
-	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x48, 0x09, 0x52, 0x14, 0x6e,
-	0x65, 0x74, 0x49, 0x70, 0x76, 0x34, 0x54, 0x63, 0x70, 0x46, 0x69, 0x6e, 0x54, 0x69, 0x6d, 0x65,
-	0x6f, 0x75, 0x74, 0x88, 0x01, 0x01, 0x12, 0x41, 0x0a, 0x1b, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70,
-	0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65,
-	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x48, 0x0a, 0x52, 0x17, 0x6e,
-	0x65, 0x74, 0x49, 0x70, 0x76, 0x34, 0x54, 0x63, 0x70, 0x4b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69,
-	0x76, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x45, 0x0a, 0x1d, 0x6e, 0x65, 0x74,
-	0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c,
-	0x69, 0x76, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05,
-	0x48, 0x0b, 0x52, 0x19, 0x6e, 0x65, 0x74, 0x49, 0x70, 0x76, 0x34, 0x54, 0x63, 0x70, 0x4b, 0x65,
-	0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x88, 0x01, 0x01,
-	0x12, 0x42, 0x0a, 0x1b, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70,
-	0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x76, 0x6c, 0x18,
-	0x0d, 0x20, 0x01, 0x28, 0x05, 0x48, 0x0c, 0x52, 0x18, 0x6e, 0x65, 0x74, 0x49, 0x70, 0x76, 0x34,
-	0x54, 0x63, 0x70, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x49, 0x6e, 0x74, 0x76,
-	0x6c, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a, 0x15, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34,
-	0x5f, 0x74, 0x63, 0x70, 0x5f, 0x74, 0x77, 0x5f, 0x72, 0x65, 0x75, 0x73, 0x65, 0x18, 0x0e, 0x20,
-	0x01, 0x28, 0x08, 0x48, 0x0d, 0x52, 0x11, 0x6e, 0x65, 0x74, 0x49, 0x70, 0x76, 0x34, 0x54, 0x63,
-	0x70, 0x54, 0x77, 0x52, 0x65, 0x75, 0x73, 0x65, 0x88, 0x01, 0x01, 0x12, 0x42, 0x0a, 0x1c, 0x6e,
-	0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x69, 0x70, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
-	0x5f, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x0e, 0x52, 0x17, 0x6e, 0x65, 0x74, 0x49, 0x70, 0x76, 0x34, 0x49, 0x70, 0x4c, 0x6f,
-	0x63, 0x61, 0x6c, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x88, 0x01, 0x01, 0x12,
-	0x4c, 0x0a, 0x21, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x6e, 0x65, 0x69, 0x67,
-	0x68, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x67, 0x63, 0x5f, 0x74, 0x68, 0x72,
-	0x65, 0x73, 0x68, 0x31, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x48, 0x0f, 0x52, 0x1c, 0x6e, 0x65,
-	0x74, 0x49, 0x70, 0x76, 0x34, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c,
-	0x74, 0x47, 0x63, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x31, 0x88, 0x01, 0x01, 0x12, 0x4c, 0x0a,
-	0x21, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x5f,
-	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x67, 0x63, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73,
-	0x68, 0x32, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x48, 0x10, 0x52, 0x1c, 0x6e, 0x65, 0x74, 0x49,
-	0x70, 0x76, 0x34, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x47,
-	0x63, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x32, 0x88, 0x01, 0x01, 0x12, 0x4c, 0x0a, 0x21, 0x6e,
-	0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x5f, 0x64, 0x65,
-	0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x67, 0x63, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x33,
-	0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x48, 0x11, 0x52, 0x1c, 0x6e, 0x65, 0x74, 0x49, 0x70, 0x76,
-	0x34, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x47, 0x63, 0x54,
-	0x68, 0x72, 0x65, 0x73, 0x68, 0x33, 0x88, 0x01, 0x01, 0x12, 0x47, 0x0a, 0x1e, 0x6e, 0x65, 0x74,
-	0x5f, 0x6e, 0x65, 0x74, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x66, 0x5f, 0x63, 0x6f,
-	0x6e, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x13, 0x20, 0x01, 0x28,
-	0x05, 0x48, 0x12, 0x52, 0x1a, 0x6e, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x66, 0x69, 0x6c, 0x74, 0x65,
-	0x72, 0x4e, 0x66, 0x43, 0x6f, 0x6e, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x4d, 0x61, 0x78, 0x88,
-	0x01, 0x01, 0x12, 0x4f, 0x0a, 0x22, 0x6e, 0x65, 0x74, 0x5f, 0x6e, 0x65, 0x74, 0x66, 0x69, 0x6c,
-	0x74, 0x65, 0x72, 0x5f, 0x6e, 0x66, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x6b,
-	0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x05, 0x48, 0x13,
-	0x52, 0x1e, 0x6e, 0x65, 0x74, 0x4e, 0x65, 0x74, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x4e, 0x66,
-	0x43, 0x6f, 0x6e, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73,
-	0x88, 0x01, 0x01, 0x12, 0x41, 0x0a, 0x1b, 0x66, 0x73, 0x5f, 0x69, 0x6e, 0x6f, 0x74, 0x69, 0x66,
-	0x79, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x77, 0x61, 0x74, 0x63, 0x68,
-	0x65, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x05, 0x48, 0x14, 0x52, 0x17, 0x66, 0x73, 0x49, 0x6e,
-	0x6f, 0x74, 0x69, 0x66, 0x79, 0x4d, 0x61, 0x78, 0x55, 0x73, 0x65, 0x72, 0x57, 0x61, 0x74, 0x63,
-	0x68, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0b, 0x66, 0x73, 0x5f, 0x66, 0x69, 0x6c,
-	0x65, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x16, 0x20, 0x01, 0x28, 0x05, 0x48, 0x15, 0x52, 0x09, 0x66,
-	0x73, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12, 0x26, 0x0a, 0x0d, 0x66,
-	0x73, 0x5f, 0x61, 0x69, 0x6f, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x6e, 0x72, 0x18, 0x17, 0x20, 0x01,
-	0x28, 0x05, 0x48, 0x16, 0x52, 0x0a, 0x66, 0x73, 0x41, 0x69, 0x6f, 0x4d, 0x61, 0x78, 0x4e, 0x72,
-	0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x0a, 0x66, 0x73, 0x5f, 0x6e, 0x72, 0x5f, 0x6f, 0x70, 0x65,
-	0x6e, 0x18, 0x18, 0x20, 0x01, 0x28, 0x05, 0x48, 0x17, 0x52, 0x08, 0x66, 0x73, 0x4e, 0x72, 0x4f,
-	0x70, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x31, 0x0a, 0x12, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c,
-	0x5f, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x19, 0x20, 0x01,
-	0x28, 0x05, 0x48, 0x18, 0x52, 0x10, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x54, 0x68, 0x72, 0x65,
-	0x61, 0x64, 0x73, 0x4d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x10, 0x76, 0x6d, 0x5f,
-	0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x61, 0x70, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x1a, 0x20,
-	0x01, 0x28, 0x05, 0x48, 0x19, 0x52, 0x0d, 0x76, 0x6d, 0x4d, 0x61, 0x78, 0x4d, 0x61, 0x70, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x28, 0x0a, 0x0d, 0x76, 0x6d, 0x5f, 0x73, 0x77,
-	0x61, 0x70, 0x70, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x05, 0x48, 0x1a,
-	0x52, 0x0c, 0x76, 0x6d, 0x53, 0x77, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x88, 0x01,
-	0x01, 0x12, 0x36, 0x0a, 0x15, 0x76, 0x6d, 0x5f, 0x76, 0x66, 0x73, 0x5f, 0x63, 0x61, 0x63, 0x68,
-	0x65, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x05,
-	0x48, 0x1b, 0x52, 0x12, 0x76, 0x6d, 0x56, 0x66, 0x73, 0x43, 0x61, 0x63, 0x68, 0x65, 0x50, 0x72,
-	0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x88, 0x01, 0x01, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x6e, 0x65,
-	0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x73, 0x6f, 0x6d, 0x61, 0x78, 0x63, 0x6f, 0x6e, 0x6e,
-	0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x6e, 0x65,
-	0x74, 0x64, 0x65, 0x76, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67,
-	0x42, 0x18, 0x0a, 0x16, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x72, 0x6d,
-	0x65, 0x6d, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x6e,
-	0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x72, 0x6d, 0x65, 0x6d, 0x5f, 0x6d, 0x61, 0x78,
-	0x42, 0x18, 0x0a, 0x16, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x77, 0x6d,
-	0x65, 0x6d, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x6e,
-	0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x77, 0x6d, 0x65, 0x6d, 0x5f, 0x6d, 0x61, 0x78,
-	0x42, 0x16, 0x0a, 0x14, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x6f, 0x70,
-	0x74, 0x6d, 0x65, 0x6d, 0x5f, 0x6d, 0x61, 0x78, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x6e, 0x65, 0x74,
-	0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x79,
-	0x6e, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67, 0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x6e, 0x65,
-	0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x74,
-	0x77, 0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x42, 0x1b, 0x0a, 0x19, 0x5f, 0x6e, 0x65,
-	0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x66, 0x69, 0x6e, 0x5f, 0x74,
-	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x69,
-	0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76,
-	0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x42, 0x20, 0x0a, 0x1e, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x69,
-	0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76,
-	0x65, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x6e, 0x65, 0x74,
-	0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69,
-	0x76, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x76, 0x6c, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x6e, 0x65, 0x74,
-	0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x74, 0x77, 0x5f, 0x72, 0x65, 0x75,
-	0x73, 0x65, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f,
 	0x69, 0x70, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x72, 0x61,
-	0x6e, 0x67, 0x65, 0x42, 0x24, 0x0a, 0x22, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34,
+	0x6e, 0x67, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x48, 0x0e, 0x52, 0x17, 0x6e, 0x65, 0x74,
+	0x49, 0x70, 0x76, 0x34, 0x49, 0x70, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x50, 0x6f, 0x72, 0x74, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x88, 0x01, 0x01, 0x12, 0x4c, 0x0a, 0x21, 0x6e, 0x65, 0x74, 0x5f, 0x69,
+	0x70, 0x76, 0x34, 0x5f, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c,
+	0x74, 0x5f, 0x67, 0x63, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x31, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x05, 0x48, 0x0f, 0x52, 0x1c, 0x6e, 0x65, 0x74, 0x49, 0x70, 0x76, 0x34, 0x4e, 0x65, 0x69,
+	0x67, 0x68, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x47, 0x63, 0x54, 0x68, 0x72, 0x65, 0x73,
+	0x68, 0x31, 0x88, 0x01, 0x01, 0x12, 0x4c, 0x0a, 0x21, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76,
+	0x34, 0x5f, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f,
+	0x67, 0x63, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x32, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05,
+	0x48, 0x10, 0x52, 0x1c, 0x6e, 0x65, 0x74, 0x49, 0x70, 0x76, 0x34, 0x4e, 0x65, 0x69, 0x67, 0x68,
+	0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x47, 0x63, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x32,
+	0x88, 0x01, 0x01, 0x12, 0x4c, 0x0a, 0x21, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f,
+	0x6e, 0x65, 0x69, 0x67, 0x68, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x67, 0x63,
+	0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x33, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x48, 0x11,
+	0x52, 0x1c, 0x6e, 0x65, 0x74, 0x49, 0x70, 0x76, 0x34, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x44, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x47, 0x63, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x33, 0x88, 0x01,
+	0x01, 0x12, 0x47, 0x0a, 0x1e, 0x6e, 0x65, 0x74, 0x5f, 0x6e, 0x65, 0x74, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x5f, 0x6e, 0x66, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x5f,
+	0x6d, 0x61, 0x78, 0x18, 0x13, 0x20, 0x01, 0x28, 0x05, 0x48, 0x12, 0x52, 0x1a, 0x6e, 0x65, 0x74,
+	0x4e, 0x65, 0x74, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x4e, 0x66, 0x43, 0x6f, 0x6e, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x6b, 0x4d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12, 0x4f, 0x0a, 0x22, 0x6e, 0x65,
+	0x74, 0x5f, 0x6e, 0x65, 0x74, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x66, 0x5f, 0x63,
+	0x6f, 0x6e, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x05, 0x48, 0x13, 0x52, 0x1e, 0x6e, 0x65, 0x74, 0x4e, 0x65, 0x74,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x4e, 0x66, 0x43, 0x6f, 0x6e, 0x6e, 0x74, 0x72, 0x61, 0x63,
+	0x6b, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x88, 0x01, 0x01, 0x12, 0x41, 0x0a, 0x1b, 0x66,
+	0x73, 0x5f, 0x69, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x77, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x05,
+	0x48, 0x14, 0x52, 0x17, 0x66, 0x73, 0x49, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x4d, 0x61, 0x78,
+	0x55, 0x73, 0x65, 0x72, 0x57, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x23,
+	0x0a, 0x0b, 0x66, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x16, 0x20,
+	0x01, 0x28, 0x05, 0x48, 0x15, 0x52, 0x09, 0x66, 0x73, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x61, 0x78,
+	0x88, 0x01, 0x01, 0x12, 0x26, 0x0a, 0x0d, 0x66, 0x73, 0x5f, 0x61, 0x69, 0x6f, 0x5f, 0x6d, 0x61,
+	0x78, 0x5f, 0x6e, 0x72, 0x18, 0x17, 0x20, 0x01, 0x28, 0x05, 0x48, 0x16, 0x52, 0x0a, 0x66, 0x73,
+	0x41, 0x69, 0x6f, 0x4d, 0x61, 0x78, 0x4e, 0x72, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x0a, 0x66,
+	0x73, 0x5f, 0x6e, 0x72, 0x5f, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x18, 0x20, 0x01, 0x28, 0x05, 0x48,
+	0x17, 0x52, 0x08, 0x66, 0x73, 0x4e, 0x72, 0x4f, 0x70, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x31,
+	0x0a, 0x12, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73,
+	0x5f, 0x6d, 0x61, 0x78, 0x18, 0x19, 0x20, 0x01, 0x28, 0x05, 0x48, 0x18, 0x52, 0x10, 0x6b, 0x65,
+	0x72, 0x6e, 0x65, 0x6c, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x4d, 0x61, 0x78, 0x88, 0x01,
+	0x01, 0x12, 0x2c, 0x0a, 0x10, 0x76, 0x6d, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x61, 0x70, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x05, 0x48, 0x19, 0x52, 0x0d, 0x76,
+	0x6d, 0x4d, 0x61, 0x78, 0x4d, 0x61, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12,
+	0x28, 0x0a, 0x0d, 0x76, 0x6d, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x65, 0x73, 0x73,
+	0x18, 0x1b, 0x20, 0x01, 0x28, 0x05, 0x48, 0x1a, 0x52, 0x0c, 0x76, 0x6d, 0x53, 0x77, 0x61, 0x70,
+	0x70, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x88, 0x01, 0x01, 0x12, 0x36, 0x0a, 0x15, 0x76, 0x6d, 0x5f,
+	0x76, 0x66, 0x73, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75,
+	0x72, 0x65, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x05, 0x48, 0x1b, 0x52, 0x12, 0x76, 0x6d, 0x56, 0x66,
+	0x73, 0x43, 0x61, 0x63, 0x68, 0x65, 0x50, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x88, 0x01,
+	0x01, 0x12, 0x64, 0x0a, 0x12, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f,
+	0x73, 0x79, 0x73, 0x63, 0x74, 0x6c, 0x73, 0x18, 0x1d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x35, 0x2e,
+	0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x79, 0x73, 0x63, 0x74, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x41, 0x64,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x79, 0x73, 0x63, 0x74, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x11, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x53, 0x79, 0x73, 0x63, 0x74, 0x6c, 0x73, 0x1a, 0x44, 0x0a, 0x16, 0x41, 0x64, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x79, 0x73, 0x63, 0x74, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x15, 0x0a,
+	0x13, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x73, 0x6f, 0x6d, 0x61, 0x78,
+	0x63, 0x6f, 0x6e, 0x6e, 0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72,
+	0x65, 0x5f, 0x6e, 0x65, 0x74, 0x64, 0x65, 0x76, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x63,
+	0x6b, 0x6c, 0x6f, 0x67, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72,
+	0x65, 0x5f, 0x72, 0x6d, 0x65, 0x6d, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x42, 0x14,
+	0x0a, 0x12, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x72, 0x6d, 0x65, 0x6d,
+	0x5f, 0x6d, 0x61, 0x78, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72,
+	0x65, 0x5f, 0x77, 0x6d, 0x65, 0x6d, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x42, 0x14,
+	0x0a, 0x12, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x77, 0x6d, 0x65, 0x6d,
+	0x5f, 0x6d, 0x61, 0x78, 0x42, 0x16, 0x0a, 0x14, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x72,
+	0x65, 0x5f, 0x6f, 0x70, 0x74, 0x6d, 0x65, 0x6d, 0x5f, 0x6d, 0x61, 0x78, 0x42, 0x1f, 0x0a, 0x1d,
+	0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x6d, 0x61,
+	0x78, 0x5f, 0x73, 0x79, 0x6e, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6c, 0x6f, 0x67, 0x42, 0x1e, 0x0a,
+	0x1c, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x6d,
+	0x61, 0x78, 0x5f, 0x74, 0x77, 0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x42, 0x1b, 0x0a,
+	0x19, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x66,
+	0x69, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x6e,
+	0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x6b, 0x65, 0x65, 0x70,
+	0x61, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x42, 0x20, 0x0a, 0x1e, 0x5f, 0x6e,
+	0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x6b, 0x65, 0x65, 0x70,
+	0x61, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x42, 0x1e, 0x0a, 0x1c,
+	0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x6b, 0x65, 0x65,
+	0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x69, 0x6e, 0x74, 0x76, 0x6c, 0x42, 0x18, 0x0a, 0x16,
+	0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x74, 0x77,
+	0x5f, 0x72, 0x65, 0x75, 0x73, 0x65, 0x42, 0x1f, 0x0a, 0x1d, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x69,
+	0x70, 0x76, 0x34, 0x5f, 0x69, 0x70, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x70, 0x6f, 0x72,
+	0x74, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x24, 0x0a, 0x22, 0x5f, 0x6e, 0x65, 0x74, 0x5f,
+	0x69, 0x70, 0x76, 0x34, 0x5f, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x5f, 0x67, 0x63, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x31, 0x42, 0x24, 0x0a,
+	0x22, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x6e, 0x65, 0x69, 0x67, 0x68,
+	0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x67, 0x63, 0x5f, 0x74, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x32, 0x42, 0x24, 0x0a, 0x22, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34,
 	0x5f, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x67,
-	0x63, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x31, 0x42, 0x24, 0x0a, 0x22, 0x5f, 0x6e, 0x65,
-	0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x5f, 0x64, 0x65, 0x66,
-	0x61, 0x75, 0x6c, 0x74, 0x5f, 0x67, 0x63, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x32, 0x42,
-	0x24, 0x0a, 0x22, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x70, 0x76, 0x34, 0x5f, 0x6e, 0x65, 0x69,
-	0x67, 0x68, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x67, 0x63, 0x5f, 0x74, 0x68,
-	0x72, 0x65, 0x73, 0x68, 0x33, 0x42, 0x21, 0x0a, 0x1f, 0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x6e, 0x65,
-	0x74, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x66, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x74,
-	0x72, 0x61, 0x63, 0x6b, 0x5f, 0x6d, 0x61, 0x78, 0x42, 0x25, 0x0a, 0x23, 0x5f, 0x6e, 0x65, 0x74,
-	0x5f, 0x6e, 0x65, 0x74, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x66, 0x5f, 0x63, 0x6f,
-	0x6e, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x5f, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x42,
-	0x1e, 0x0a, 0x1c, 0x5f, 0x66, 0x73, 0x5f, 0x69, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x5f, 0x6d,
-	0x61, 0x78, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x77, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x42,
-	0x0e, 0x0a, 0x0c, 0x5f, 0x66, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6d, 0x61, 0x78, 0x42,
-	0x10, 0x0a, 0x0e, 0x5f, 0x66, 0x73, 0x5f, 0x61, 0x69, 0x6f, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x6e,
-	0x72, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x66, 0x73, 0x5f, 0x6e, 0x72, 0x5f, 0x6f, 0x70, 0x65, 0x6e,
-	0x42, 0x15, 0x0a, 0x13, 0x5f, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x5f, 0x74, 0x68, 0x72, 0x65,
-	0x61, 0x64, 0x73, 0x5f, 0x6d, 0x61, 0x78, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x76, 0x6d, 0x5f, 0x6d,
-	0x61, 0x78, 0x5f, 0x6d, 0x61, 0x70, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x10, 0x0a, 0x0e,
-	0x5f, 0x76, 0x6d, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x65, 0x73, 0x73, 0x42, 0x18,
-	0x0a, 0x16, 0x5f, 0x76, 0x6d, 0x5f, 0x76, 0x66, 0x73, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f,
-	0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x22, 0x7f, 0x0a, 0x0c, 0x55, 0x6c, 0x69, 0x6d,
-	0x69, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1c, 0x0a, 0x07, 0x6e, 0x6f, 0x5f, 0x66,
-	0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x6e, 0x6f, 0x46,
-	0x69, 0x6c, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2f, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x6f,
-	0x63, 0x6b, 0x65, 0x64, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x01, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x4d, 0x65,
-	0x6d, 0x6f, 0x72, 0x79, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x6e, 0x6f, 0x5f, 0x66,
-	0x69, 0x6c, 0x65, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x6f, 0x63, 0x6b,
-	0x65, 0x64, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x67,
-	0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b, 0x73, 0x2d, 0x6e, 0x6f, 0x64,
-	0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67,
-	0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e,
-	0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x63, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x33, 0x42, 0x21, 0x0a, 0x1f, 0x5f, 0x6e, 0x65,
+	0x74, 0x5f, 0x6e, 0x65, 0x74, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x66, 0x5f, 0x63,
+	0x6f, 0x6e, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x5f, 0x6d, 0x61, 0x78, 0x42, 0x25, 0x0a, 0x23,
+	0x5f, 0x6e, 0x65, 0x74, 0x5f, 0x6e, 0x65, 0x74, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x6e,
+	0x66, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x5f, 0x62, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x42, 0x1e, 0x0a, 0x1c, 0x5f, 0x66, 0x73, 0x5f, 0x69, 0x6e, 0x6f, 0x74, 0x69,
+	0x66, 0x79, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x77, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x66, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x6d, 0x61, 0x78, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x66, 0x73, 0x5f, 0x61, 0x69, 0x6f, 0x5f, 0x6d,
+	0x61, 0x78, 0x5f, 0x6e, 0x72, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x66, 0x73, 0x5f, 0x6e, 0x72, 0x5f,
+	0x6f, 0x70, 0x65, 0x6e, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x5f,
+	0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x5f, 0x6d, 0x61, 0x78, 0x42, 0x13, 0x0a, 0x11, 0x5f,
+	0x76, 0x6d, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x6d, 0x61, 0x70, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x76, 0x6d, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x65,
+	0x73, 0x73, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x76, 0x6d, 0x5f, 0x76, 0x66, 0x73, 0x5f, 0x63, 0x61,
+	0x63, 0x68, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x73, 0x73, 0x75, 0x72, 0x65, 0x22, 0x7f, 0x0a, 0x0c,
+	0x55, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1c, 0x0a, 0x07,
+	0x6e, 0x6f, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x06, 0x6e, 0x6f, 0x46, 0x69, 0x6c, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2f, 0x0a, 0x11, 0x6d, 0x61,
+	0x78, 0x5f, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x4c, 0x6f, 0x63, 0x6b,
+	0x65, 0x64, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f,
+	0x6e, 0x6f, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x6d, 0x61, 0x78, 0x5f,
+	0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x5a, 0x5a,
+	0x58, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75, 0x72,
+	0x65, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b, 0x73,
+	0x2d, 0x6e, 0x6f, 0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64,
+	0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -642,20 +782,28 @@ func file_aksnodeconfig_v1_custom_linux_os_config_proto_rawDescGZIP() []byte {
 	return file_aksnodeconfig_v1_custom_linux_os_config_proto_rawDescData
 }
 
-var file_aksnodeconfig_v1_custom_linux_os_config_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_aksnodeconfig_v1_custom_linux_os_config_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_aksnodeconfig_v1_custom_linux_os_config_proto_goTypes = []any{
 	(*CustomLinuxOsConfig)(nil), // 0: aksnodeconfig.v1.CustomLinuxOsConfig
-	(*SysctlConfig)(nil),        // 1: aksnodeconfig.v1.SysctlConfig
-	(*UlimitConfig)(nil),        // 2: aksnodeconfig.v1.UlimitConfig
+	(*KernelModule)(nil),        // 1: aksnodeconfig.v1.KernelModule
+	(*SysctlConfig)(nil),        // 2: aksnodeconfig.v1.SysctlConfig
+	(*UlimitConfig)(nil),        // 3: aksnodeconfig.v1.UlimitConfig
+	nil,                         // 4: aksnodeconfig.v1.SysctlConfig.AdditionalSysctlsEntry
+	(*SwapConfig)(nil),          // 5: aksnodeconfig.v1.SwapConfig
+	(*HugepagesConfig)(nil),     // 6: aksnodeconfig.v1.HugepagesConfig
 }
 var file_aksnodeconfig_v1_custom_linux_os_config_proto_depIdxs = []int32{
-	1, // 0: aksnodeconfig.v1.CustomLinuxOsConfig.sysctl_config:type_name -> aksnodeconfig.v1.SysctlConfig
-	2, // 1: aksnodeconfig.v1.CustomLinuxOsConfig.ulimit_config:type_name -> aksnodeconfig.v1.UlimitConfig
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2, // 0: aksnodeconfig.v1.CustomLinuxOsConfig.sysctl_config:type_name -> aksnodeconfig.v1.SysctlConfig
+	3, // 1: aksnodeconfig.v1.CustomLinuxOsConfig.ulimit_config:type_name -> aksnodeconfig.v1.UlimitConfig
+	1, // 2: aksnodeconfig.v1.CustomLinuxOsConfig.kernel_modules:type_name -> aksnodeconfig.v1.KernelModule
+	5, // 3: aksnodeconfig.v1.CustomLinuxOsConfig.swap_config:type_name -> aksnodeconfig.v1.SwapConfig
+	6, // 4: aksnodeconfig.v1.CustomLinuxOsConfig.hugepages_config:type_name -> aksnodeconfig.v1.HugepagesConfig
+	4, // 5: aksnodeconfig.v1.SysctlConfig.additional_sysctls:type_name -> aksnodeconfig.v1.SysctlConfig.AdditionalSysctlsEntry
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_aksnodeconfig_v1_custom_linux_os_config_proto_init() }
@@ -663,15 +811,17 @@ func file_aksnodeconfig_v1_custom_linux_os_config_proto_init() {
 	if File_aksnodeconfig_v1_custom_linux_os_config_proto != nil {
 		return
 	}
-	file_aksnodeconfig_v1_custom_linux_os_config_proto_msgTypes[1].OneofWrappers = []any{}
+	file_aksnodeconfig_v1_hugepages_config_proto_init()
+	file_aksnodeconfig_v1_swap_config_proto_init()
 	file_aksnodeconfig_v1_custom_linux_os_config_proto_msgTypes[2].OneofWrappers = []any{}
+	file_aksnodeconfig_v1_custom_linux_os_config_proto_msgTypes[3].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_aksnodeconfig_v1_custom_linux_os_config_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,238 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: aksnodeconfig/v1/custom_script_config.proto
+
+package aksnodeconfigv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CustomScriptHook int32
+
+const (
+	CustomScriptHook_CUSTOM_SCRIPT_HOOK_UNSPECIFIED CustomScriptHook = 0
+	// Runs once the node network is online, before any AKS component is installed.
+	CustomScriptHook_CUSTOM_SCRIPT_HOOK_POST_NETWORK CustomScriptHook = 1
+	// Runs every time kubelet starts, before the kubelet process is started.
+	CustomScriptHook_CUSTOM_SCRIPT_HOOK_PRE_KUBELET CustomScriptHook = 2
+	// Runs every time kubelet starts, after the kubelet process is started.
+	CustomScriptHook_CUSTOM_SCRIPT_HOOK_POST_KUBELET CustomScriptHook = 3
+)
+
+// Enum value maps for CustomScriptHook.
+var (
+	CustomScriptHook_name = map[int32]string{
+		0: "CUSTOM_SCRIPT_HOOK_UNSPECIFIED",
+		1: "CUSTOM_SCRIPT_HOOK_POST_NETWORK",
+		2: "CUSTOM_SCRIPT_HOOK_PRE_KUBELET",
+		3: "CUSTOM_SCRIPT_HOOK_POST_KUBELET",
+	}
+	CustomScriptHook_value = map[string]int32{
+		"CUSTOM_SCRIPT_HOOK_UNSPECIFIED":  0,
+		"CUSTOM_SCRIPT_HOOK_POST_NETWORK": 1,
+		"CUSTOM_SCRIPT_HOOK_PRE_KUBELET":  2,
+		"CUSTOM_SCRIPT_HOOK_POST_KUBELET": 3,
+	}
+)
+
+func (x CustomScriptHook) Enum() *CustomScriptHook {
+	p := new(CustomScriptHook)
+	*p = x
+	return p
+}
+
+func (x CustomScriptHook) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CustomScriptHook) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_custom_script_config_proto_enumTypes[0].Descriptor()
+}
+
+func (CustomScriptHook) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_custom_script_config_proto_enumTypes[0]
+}
+
+func (x CustomScriptHook) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CustomScriptHook.Descriptor instead.
+func (CustomScriptHook) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_custom_script_config_proto_rawDescGZIP(), []int{0}
+}
+
+type CustomScript struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the script, unique in the configuration. The output of the script is written to /var/log/azure/aks/custom-scripts/<hook>-<name>.log.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Hook point the script is executed at. Scripts of the same hook run sequentially, in the order of the configuration.
+	Hook CustomScriptHook `protobuf:"varint,2,opt,name=hook,proto3,enum=aksnodeconfig.v1.CustomScriptHook" json:"hook,omitempty"`
+	// Content of the script, executed with /bin/bash.
+	Script string `protobuf:"bytes,3,opt,name=script,proto3" json:"script,omitempty"`
+	// Maximum duration of the script in seconds, the script is killed and the hook fails when it is exceeded. The default is 300.
+	TimeoutSeconds int32 `protobuf:"varint,4,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+}
+
+func (x *CustomScript) Reset() {
+	*x = CustomScript{}
+	mi := &file_aksnodeconfig_v1_custom_script_config_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomScript) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomScript) ProtoMessage() {}
+
+func (x *CustomScript) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_custom_script_config_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomScript.ProtoReflect.Descriptor instead.
+func (*CustomScript) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_custom_script_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CustomScript) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CustomScript) GetHook() CustomScriptHook {
+	if x != nil {
+		return x.Hook
+	}
+	return CustomScriptHook_CUSTOM_SCRIPT_HOOK_UNSPECIFIED
+}
+
+func (x *CustomScript) GetScript() string {
+	if x != nil {
+		return x.Script
+	}
+	return ""
+}
+
+func (x *CustomScript) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+var File_aksnodeconfig_v1_custom_script_config_proto protoreflect.FileDescriptor
+
+var file_aksnodeconfig_v1_custom_script_config_proto_rawDesc = []byte{
+	0x0a, 0x2b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x76, 0x31, 0x2f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x61,
+	0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x22,
+	0x9b, 0x01, 0x0a, 0x0c, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x36, 0x0a, 0x04, 0x68, 0x6f, 0x6f, 0x6b, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x22, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x48, 0x6f, 0x6f, 0x6b, 0x52, 0x04, 0x68, 0x6f, 0x6f, 0x6b, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f,
+	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x2a, 0xa4, 0x01,
+	0x0a, 0x10, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x48, 0x6f,
+	0x6f, 0x6b, 0x12, 0x22, 0x0a, 0x1e, 0x43, 0x55, 0x53, 0x54, 0x4f, 0x4d, 0x5f, 0x53, 0x43, 0x52,
+	0x49, 0x50, 0x54, 0x5f, 0x48, 0x4f, 0x4f, 0x4b, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x23, 0x0a, 0x1f, 0x43, 0x55, 0x53, 0x54, 0x4f, 0x4d,
+	0x5f, 0x53, 0x43, 0x52, 0x49, 0x50, 0x54, 0x5f, 0x48, 0x4f, 0x4f, 0x4b, 0x5f, 0x50, 0x4f, 0x53,
+	0x54, 0x5f, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x10, 0x01, 0x12, 0x22, 0x0a, 0x1e, 0x43,
+	0x55, 0x53, 0x54, 0x4f, 0x4d, 0x5f, 0x53, 0x43, 0x52, 0x49, 0x50, 0x54, 0x5f, 0x48, 0x4f, 0x4f,
+	0x4b, 0x5f, 0x50, 0x52, 0x45, 0x5f, 0x4b, 0x55, 0x42, 0x45, 0x4c, 0x45, 0x54, 0x10, 0x02, 0x12,
+	0x23, 0x0a, 0x1f, 0x43, 0x55, 0x53, 0x54, 0x4f, 0x4d, 0x5f, 0x53, 0x43, 0x52, 0x49, 0x50, 0x54,
+	0x5f, 0x48, 0x4f, 0x4f, 0x4b, 0x5f, 0x50, 0x4f, 0x53, 0x54, 0x5f, 0x4b, 0x55, 0x42, 0x45, 0x4c,
+	0x45, 0x54, 0x10, 0x03, 0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x61,
+	0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b, 0x73, 0x2d, 0x6e, 0x6f, 0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f,
+	0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31,
+	0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_aksnodeconfig_v1_custom_script_config_proto_rawDescOnce sync.Once
+	file_aksnodeconfig_v1_custom_script_config_proto_rawDescData = file_aksnodeconfig_v1_custom_script_config_proto_rawDesc
+)
+
+func file_aksnodeconfig_v1_custom_script_config_proto_rawDescGZIP() []byte {
+	file_aksnodeconfig_v1_custom_script_config_proto_rawDescOnce.Do(func() {
+		file_aksnodeconfig_v1_custom_script_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_aksnodeconfig_v1_custom_script_config_proto_rawDescData)
+	})
+	return file_aksnodeconfig_v1_custom_script_config_proto_rawDescData
+}
+
+var file_aksnodeconfig_v1_custom_script_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_aksnodeconfig_v1_custom_script_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_aksnodeconfig_v1_custom_script_config_proto_goTypes = []any{
+	(CustomScriptHook)(0), // 0: aksnodeconfig.v1.CustomScriptHook
+	(*CustomScript)(nil),  // 1: aksnodeconfig.v1.CustomScript
+}
+var file_aksnodeconfig_v1_custom_script_config_proto_depIdxs = []int32{
+	0, // 0: aksnodeconfig.v1.CustomScript.hook:type_name -> aksnodeconfig.v1.CustomScriptHook
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_aksnodeconfig_v1_custom_script_config_proto_init() }
+func file_aksnodeconfig_v1_custom_script_config_proto_init() {
+	if File_aksnodeconfig_v1_custom_script_config_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aksnodeconfig_v1_custom_script_config_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aksnodeconfig_v1_custom_script_config_proto_goTypes,
+		DependencyIndexes: file_aksnodeconfig_v1_custom_script_config_proto_depIdxs,
+		EnumInfos:         file_aksnodeconfig_v1_custom_script_config_proto_enumTypes,
+		MessageInfos:      file_aksnodeconfig_v1_custom_script_config_proto_msgTypes,
+	}.Build()
+	File_aksnodeconfig_v1_custom_script_config_proto = out.File
+	file_aksnodeconfig_v1_custom_script_config_proto_rawDesc = nil
+	file_aksnodeconfig_v1_custom_script_config_proto_goTypes = nil
+	file_aksnodeconfig_v1_custom_script_config_proto_depIdxs = nil
+}
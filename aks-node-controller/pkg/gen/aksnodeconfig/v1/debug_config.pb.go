@@ -0,0 +1,237 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: aksnodeconfig/v1/debug_config.proto
+
+package aksnodeconfigv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LogLevel int32
+
+const (
+	LogLevel_LOG_LEVEL_UNSPECIFIED LogLevel = 0
+	LogLevel_LOG_LEVEL_DEBUG       LogLevel = 1
+	LogLevel_LOG_LEVEL_INFO        LogLevel = 2
+	LogLevel_LOG_LEVEL_WARN        LogLevel = 3
+	LogLevel_LOG_LEVEL_ERROR       LogLevel = 4
+)
+
+// Enum value maps for LogLevel.
+var (
+	LogLevel_name = map[int32]string{
+		0: "LOG_LEVEL_UNSPECIFIED",
+		1: "LOG_LEVEL_DEBUG",
+		2: "LOG_LEVEL_INFO",
+		3: "LOG_LEVEL_WARN",
+		4: "LOG_LEVEL_ERROR",
+	}
+	LogLevel_value = map[string]int32{
+		"LOG_LEVEL_UNSPECIFIED": 0,
+		"LOG_LEVEL_DEBUG":       1,
+		"LOG_LEVEL_INFO":        2,
+		"LOG_LEVEL_WARN":        3,
+		"LOG_LEVEL_ERROR":       4,
+	}
+)
+
+func (x LogLevel) Enum() *LogLevel {
+	p := new(LogLevel)
+	*p = x
+	return p
+}
+
+func (x LogLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LogLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_debug_config_proto_enumTypes[0].Descriptor()
+}
+
+func (LogLevel) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_debug_config_proto_enumTypes[0]
+}
+
+func (x LogLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LogLevel.Descriptor instead.
+func (LogLevel) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_debug_config_proto_rawDescGZIP(), []int{0}
+}
+
+type DebugConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Log verbosity of the aks-node-controller phases which have no specific level. The default is info.
+	LogLevel LogLevel `protobuf:"varint,1,opt,name=log_level,json=logLevel,proto3,enum=aksnodeconfig.v1.LogLevel" json:"log_level,omitempty"`
+	// Log verbosity of individual aks-node-controller phases, keyed by phase name, e.g. "GPUDriver", "CSE" or "ContainerdReadiness".
+	PhaseLogLevels map[string]LogLevel `protobuf:"bytes,2,rep,name=phase_log_levels,json=phaseLogLevels,proto3" json:"phase_log_levels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=aksnodeconfig.v1.LogLevel"`
+	// Specifies whether every command run by the bootstrap scripts is traced, like with "set -x".
+	TraceScripts bool `protobuf:"varint,3,opt,name=trace_scripts,json=traceScripts,proto3" json:"trace_scripts,omitempty"`
+}
+
+func (x *DebugConfig) Reset() {
+	*x = DebugConfig{}
+	mi := &file_aksnodeconfig_v1_debug_config_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugConfig) ProtoMessage() {}
+
+func (x *DebugConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_debug_config_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugConfig.ProtoReflect.Descriptor instead.
+func (*DebugConfig) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_debug_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DebugConfig) GetLogLevel() LogLevel {
+	if x != nil {
+		return x.LogLevel
+	}
+	return LogLevel_LOG_LEVEL_UNSPECIFIED
+}
+
+func (x *DebugConfig) GetPhaseLogLevels() map[string]LogLevel {
+	if x != nil {
+		return x.PhaseLogLevels
+	}
+	return nil
+}
+
+func (x *DebugConfig) GetTraceScripts() bool {
+	if x != nil {
+		return x.TraceScripts
+	}
+	return false
+}
+
+var File_aksnodeconfig_v1_debug_config_proto protoreflect.FileDescriptor
+
+var file_aksnodeconfig_v1_debug_config_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x76, 0x31, 0x2f, 0x64, 0x65, 0x62, 0x75, 0x67, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x22, 0xa7, 0x02, 0x0a, 0x0b, 0x44, 0x65, 0x62, 0x75,
+	0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x37, 0x0a, 0x09, 0x6c, 0x6f, 0x67, 0x5f, 0x6c,
+	0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x61, 0x6b, 0x73,
+	0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f,
+	0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x12, 0x5b, 0x0a, 0x10, 0x70, 0x68, 0x61, 0x73, 0x65, 0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x61, 0x6b, 0x73,
+	0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
+	0x62, 0x75, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x4c,
+	0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x70,
+	0x68, 0x61, 0x73, 0x65, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x12, 0x23, 0x0a,
+	0x0d, 0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x63, 0x65, 0x53, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x73, 0x1a, 0x5d, 0x0a, 0x13, 0x50, 0x68, 0x61, 0x73, 0x65, 0x4c, 0x6f, 0x67, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x61, 0x6b, 0x73,
+	0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f,
+	0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x2a, 0x77, 0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x19, 0x0a,
+	0x15, 0x4c, 0x4f, 0x47, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x4c, 0x4f, 0x47, 0x5f,
+	0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x44, 0x45, 0x42, 0x55, 0x47, 0x10, 0x01, 0x12, 0x12, 0x0a,
+	0x0e, 0x4c, 0x4f, 0x47, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x49, 0x4e, 0x46, 0x4f, 0x10,
+	0x02, 0x12, 0x12, 0x0a, 0x0e, 0x4c, 0x4f, 0x47, 0x5f, 0x4c, 0x45, 0x56, 0x45, 0x4c, 0x5f, 0x57,
+	0x41, 0x52, 0x4e, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x4c, 0x4f, 0x47, 0x5f, 0x4c, 0x45, 0x56,
+	0x45, 0x4c, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x04, 0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b, 0x73, 0x2d, 0x6e, 0x6f,
+	0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_aksnodeconfig_v1_debug_config_proto_rawDescOnce sync.Once
+	file_aksnodeconfig_v1_debug_config_proto_rawDescData = file_aksnodeconfig_v1_debug_config_proto_rawDesc
+)
+
+func file_aksnodeconfig_v1_debug_config_proto_rawDescGZIP() []byte {
+	file_aksnodeconfig_v1_debug_config_proto_rawDescOnce.Do(func() {
+		file_aksnodeconfig_v1_debug_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_aksnodeconfig_v1_debug_config_proto_rawDescData)
+	})
+	return file_aksnodeconfig_v1_debug_config_proto_rawDescData
+}
+
+var file_aksnodeconfig_v1_debug_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_aksnodeconfig_v1_debug_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_aksnodeconfig_v1_debug_config_proto_goTypes = []any{
+	(LogLevel)(0),       // 0: aksnodeconfig.v1.LogLevel
+	(*DebugConfig)(nil), // 1: aksnodeconfig.v1.DebugConfig
+	nil,                 // 2: aksnodeconfig.v1.DebugConfig.PhaseLogLevelsEntry
+}
+var file_aksnodeconfig_v1_debug_config_proto_depIdxs = []int32{
+	0, // 0: aksnodeconfig.v1.DebugConfig.log_level:type_name -> aksnodeconfig.v1.LogLevel
+	2, // 1: aksnodeconfig.v1.DebugConfig.phase_log_levels:type_name -> aksnodeconfig.v1.DebugConfig.PhaseLogLevelsEntry
+	0, // 2: aksnodeconfig.v1.DebugConfig.PhaseLogLevelsEntry.value:type_name -> aksnodeconfig.v1.LogLevel
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_aksnodeconfig_v1_debug_config_proto_init() }
+func file_aksnodeconfig_v1_debug_config_proto_init() {
+	if File_aksnodeconfig_v1_debug_config_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aksnodeconfig_v1_debug_config_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aksnodeconfig_v1_debug_config_proto_goTypes,
+		DependencyIndexes: file_aksnodeconfig_v1_debug_config_proto_depIdxs,
+		EnumInfos:         file_aksnodeconfig_v1_debug_config_proto_enumTypes,
+		MessageInfos:      file_aksnodeconfig_v1_debug_config_proto_msgTypes,
+	}.Build()
+	File_aksnodeconfig_v1_debug_config_proto = out.File
+	file_aksnodeconfig_v1_debug_config_proto_rawDesc = nil
+	file_aksnodeconfig_v1_debug_config_proto_goTypes = nil
+	file_aksnodeconfig_v1_debug_config_proto_depIdxs = nil
+}
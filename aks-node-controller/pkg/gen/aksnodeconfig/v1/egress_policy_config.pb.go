@@ -0,0 +1,199 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: aksnodeconfig/v1/egress_policy_config.proto
+
+package aksnodeconfigv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EgressPolicyConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The destinations the node is allowed to contact. When empty, no egress lockdown is applied.
+	AllowedDestinations []*EgressRule `protobuf:"bytes,1,rep,name=allowed_destinations,json=allowedDestinations,proto3" json:"allowed_destinations,omitempty"`
+}
+
+func (x *EgressPolicyConfig) Reset() {
+	*x = EgressPolicyConfig{}
+	mi := &file_aksnodeconfig_v1_egress_policy_config_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EgressPolicyConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EgressPolicyConfig) ProtoMessage() {}
+
+func (x *EgressPolicyConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_egress_policy_config_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EgressPolicyConfig.ProtoReflect.Descriptor instead.
+func (*EgressPolicyConfig) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_egress_policy_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EgressPolicyConfig) GetAllowedDestinations() []*EgressRule {
+	if x != nil {
+		return x.AllowedDestinations
+	}
+	return nil
+}
+
+type EgressRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Fully qualified domain name of the destination. A leading "*." matches any subdomain.
+	Fqdn string `protobuf:"bytes,1,opt,name=fqdn,proto3" json:"fqdn,omitempty"`
+	// Destination ports allowed for the FQDN. When empty, any port is allowed.
+	Ports []int32 `protobuf:"varint,2,rep,packed,name=ports,proto3" json:"ports,omitempty"`
+}
+
+func (x *EgressRule) Reset() {
+	*x = EgressRule{}
+	mi := &file_aksnodeconfig_v1_egress_policy_config_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EgressRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EgressRule) ProtoMessage() {}
+
+func (x *EgressRule) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_egress_policy_config_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EgressRule.ProtoReflect.Descriptor instead.
+func (*EgressRule) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_egress_policy_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EgressRule) GetFqdn() string {
+	if x != nil {
+		return x.Fqdn
+	}
+	return ""
+}
+
+func (x *EgressRule) GetPorts() []int32 {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+var File_aksnodeconfig_v1_egress_policy_config_proto protoreflect.FileDescriptor
+
+var file_aksnodeconfig_v1_egress_policy_config_proto_rawDesc = []byte{
+	0x0a, 0x2b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x76, 0x31, 0x2f, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x61,
+	0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x22,
+	0x65, 0x0a, 0x12, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x4f, 0x0a, 0x14, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64,
+	0x5f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x75, 0x6c,
+	0x65, 0x52, 0x13, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x36, 0x0a, 0x0a, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73,
+	0x52, 0x75, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x71, 0x64, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x66, 0x71, 0x64, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x42, 0x5a,
+	0x5a, 0x58, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75,
+	0x72, 0x65, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b,
+	0x73, 0x2d, 0x6e, 0x6f, 0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64,
+	0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f,
+	0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_aksnodeconfig_v1_egress_policy_config_proto_rawDescOnce sync.Once
+	file_aksnodeconfig_v1_egress_policy_config_proto_rawDescData = file_aksnodeconfig_v1_egress_policy_config_proto_rawDesc
+)
+
+func file_aksnodeconfig_v1_egress_policy_config_proto_rawDescGZIP() []byte {
+	file_aksnodeconfig_v1_egress_policy_config_proto_rawDescOnce.Do(func() {
+		file_aksnodeconfig_v1_egress_policy_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_aksnodeconfig_v1_egress_policy_config_proto_rawDescData)
+	})
+	return file_aksnodeconfig_v1_egress_policy_config_proto_rawDescData
+}
+
+var file_aksnodeconfig_v1_egress_policy_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_aksnodeconfig_v1_egress_policy_config_proto_goTypes = []any{
+	(*EgressPolicyConfig)(nil), // 0: aksnodeconfig.v1.EgressPolicyConfig
+	(*EgressRule)(nil),         // 1: aksnodeconfig.v1.EgressRule
+}
+var file_aksnodeconfig_v1_egress_policy_config_proto_depIdxs = []int32{
+	1, // 0: aksnodeconfig.v1.EgressPolicyConfig.allowed_destinations:type_name -> aksnodeconfig.v1.EgressRule
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_aksnodeconfig_v1_egress_policy_config_proto_init() }
+func file_aksnodeconfig_v1_egress_policy_config_proto_init() {
+	if File_aksnodeconfig_v1_egress_policy_config_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aksnodeconfig_v1_egress_policy_config_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aksnodeconfig_v1_egress_policy_config_proto_goTypes,
+		DependencyIndexes: file_aksnodeconfig_v1_egress_policy_config_proto_depIdxs,
+		MessageInfos:      file_aksnodeconfig_v1_egress_policy_config_proto_msgTypes,
+	}.Build()
+	File_aksnodeconfig_v1_egress_policy_config_proto = out.File
+	file_aksnodeconfig_v1_egress_policy_config_proto_rawDesc = nil
+	file_aksnodeconfig_v1_egress_policy_config_proto_goTypes = nil
+	file_aksnodeconfig_v1_egress_policy_config_proto_depIdxs = nil
+}
@@ -0,0 +1,283 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: aksnodeconfig/v1/hugepages_config.proto
+
+package aksnodeconfigv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type HugepageSize int32
+
+const (
+	// Same as HUGEPAGE_SIZE_2MI.
+	HugepageSize_HUGEPAGE_SIZE_UNSPECIFIED HugepageSize = 0
+	HugepageSize_HUGEPAGE_SIZE_2MI         HugepageSize = 1
+	HugepageSize_HUGEPAGE_SIZE_1GI         HugepageSize = 2
+)
+
+// Enum value maps for HugepageSize.
+var (
+	HugepageSize_name = map[int32]string{
+		0: "HUGEPAGE_SIZE_UNSPECIFIED",
+		1: "HUGEPAGE_SIZE_2MI",
+		2: "HUGEPAGE_SIZE_1GI",
+	}
+	HugepageSize_value = map[string]int32{
+		"HUGEPAGE_SIZE_UNSPECIFIED": 0,
+		"HUGEPAGE_SIZE_2MI":         1,
+		"HUGEPAGE_SIZE_1GI":         2,
+	}
+)
+
+func (x HugepageSize) Enum() *HugepageSize {
+	p := new(HugepageSize)
+	*p = x
+	return p
+}
+
+func (x HugepageSize) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HugepageSize) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_hugepages_config_proto_enumTypes[0].Descriptor()
+}
+
+func (HugepageSize) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_hugepages_config_proto_enumTypes[0]
+}
+
+func (x HugepageSize) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HugepageSize.Descriptor instead.
+func (HugepageSize) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_hugepages_config_proto_rawDescGZIP(), []int{0}
+}
+
+type HugepagesAllocation int32
+
+const (
+	// Same as HUGEPAGES_ALLOCATION_RUNTIME.
+	HugepagesAllocation_HUGEPAGES_ALLOCATION_UNSPECIFIED HugepagesAllocation = 0
+	// The pages are allocated by a systemd unit on every boot, before kubelet starts. Allocating many 1Gi pages may
+	// fail once memory is fragmented.
+	HugepagesAllocation_HUGEPAGES_ALLOCATION_RUNTIME HugepagesAllocation = 1
+	// The pages are reserved by the kernel command line, the full count is guaranteed from the next boot on.
+	HugepagesAllocation_HUGEPAGES_ALLOCATION_BOOT HugepagesAllocation = 2
+)
+
+// Enum value maps for HugepagesAllocation.
+var (
+	HugepagesAllocation_name = map[int32]string{
+		0: "HUGEPAGES_ALLOCATION_UNSPECIFIED",
+		1: "HUGEPAGES_ALLOCATION_RUNTIME",
+		2: "HUGEPAGES_ALLOCATION_BOOT",
+	}
+	HugepagesAllocation_value = map[string]int32{
+		"HUGEPAGES_ALLOCATION_UNSPECIFIED": 0,
+		"HUGEPAGES_ALLOCATION_RUNTIME":     1,
+		"HUGEPAGES_ALLOCATION_BOOT":        2,
+	}
+)
+
+func (x HugepagesAllocation) Enum() *HugepagesAllocation {
+	p := new(HugepagesAllocation)
+	*p = x
+	return p
+}
+
+func (x HugepagesAllocation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HugepagesAllocation) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_hugepages_config_proto_enumTypes[1].Descriptor()
+}
+
+func (HugepagesAllocation) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_hugepages_config_proto_enumTypes[1]
+}
+
+func (x HugepagesAllocation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HugepagesAllocation.Descriptor instead.
+func (HugepagesAllocation) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_hugepages_config_proto_rawDescGZIP(), []int{1}
+}
+
+// Hugepages preallocated on the node, which kubelet advertises as hugepages-<size> resources and subtracts from the
+// allocatable memory.
+type HugepagesConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PageSize HugepageSize `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3,enum=aksnodeconfig.v1.HugepageSize" json:"page_size,omitempty"`
+	// Number of pages, no hugepages are configured when 0.
+	Count      int32               `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Allocation HugepagesAllocation `protobuf:"varint,3,opt,name=allocation,proto3,enum=aksnodeconfig.v1.HugepagesAllocation" json:"allocation,omitempty"`
+}
+
+func (x *HugepagesConfig) Reset() {
+	*x = HugepagesConfig{}
+	mi := &file_aksnodeconfig_v1_hugepages_config_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HugepagesConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HugepagesConfig) ProtoMessage() {}
+
+func (x *HugepagesConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_hugepages_config_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HugepagesConfig.ProtoReflect.Descriptor instead.
+func (*HugepagesConfig) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_hugepages_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HugepagesConfig) GetPageSize() HugepageSize {
+	if x != nil {
+		return x.PageSize
+	}
+	return HugepageSize_HUGEPAGE_SIZE_UNSPECIFIED
+}
+
+func (x *HugepagesConfig) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *HugepagesConfig) GetAllocation() HugepagesAllocation {
+	if x != nil {
+		return x.Allocation
+	}
+	return HugepagesAllocation_HUGEPAGES_ALLOCATION_UNSPECIFIED
+}
+
+var File_aksnodeconfig_v1_hugepages_config_proto protoreflect.FileDescriptor
+
+var file_aksnodeconfig_v1_hugepages_config_proto_rawDesc = []byte{
+	0x0a, 0x27, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x76, 0x31, 0x2f, 0x68, 0x75, 0x67, 0x65, 0x70, 0x61, 0x67, 0x65, 0x73, 0x5f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x61, 0x6b, 0x73, 0x6e, 0x6f,
+	0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x22, 0xab, 0x01, 0x0a, 0x0f,
+	0x48, 0x75, 0x67, 0x65, 0x70, 0x61, 0x67, 0x65, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x3b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x75, 0x67, 0x65, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69,
+	0x7a, 0x65, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x45, 0x0a, 0x0a, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x75, 0x67, 0x65, 0x70, 0x61,
+	0x67, 0x65, 0x73, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x61,
+	0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2a, 0x5b, 0x0a, 0x0c, 0x48, 0x75, 0x67,
+	0x65, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x19, 0x48, 0x55, 0x47,
+	0x45, 0x50, 0x41, 0x47, 0x45, 0x5f, 0x53, 0x49, 0x5a, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x48, 0x55, 0x47, 0x45,
+	0x50, 0x41, 0x47, 0x45, 0x5f, 0x53, 0x49, 0x5a, 0x45, 0x5f, 0x32, 0x4d, 0x49, 0x10, 0x01, 0x12,
+	0x15, 0x0a, 0x11, 0x48, 0x55, 0x47, 0x45, 0x50, 0x41, 0x47, 0x45, 0x5f, 0x53, 0x49, 0x5a, 0x45,
+	0x5f, 0x31, 0x47, 0x49, 0x10, 0x02, 0x2a, 0x7c, 0x0a, 0x13, 0x48, 0x75, 0x67, 0x65, 0x70, 0x61,
+	0x67, 0x65, 0x73, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a,
+	0x20, 0x48, 0x55, 0x47, 0x45, 0x50, 0x41, 0x47, 0x45, 0x53, 0x5f, 0x41, 0x4c, 0x4c, 0x4f, 0x43,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x20, 0x0a, 0x1c, 0x48, 0x55, 0x47, 0x45, 0x50, 0x41, 0x47, 0x45, 0x53,
+	0x5f, 0x41, 0x4c, 0x4c, 0x4f, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x55, 0x4e, 0x54,
+	0x49, 0x4d, 0x45, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x48, 0x55, 0x47, 0x45, 0x50, 0x41, 0x47,
+	0x45, 0x53, 0x5f, 0x41, 0x4c, 0x4c, 0x4f, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x42, 0x4f,
+	0x4f, 0x54, 0x10, 0x02, 0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x61,
+	0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b, 0x73, 0x2d, 0x6e, 0x6f, 0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f,
+	0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31,
+	0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_aksnodeconfig_v1_hugepages_config_proto_rawDescOnce sync.Once
+	file_aksnodeconfig_v1_hugepages_config_proto_rawDescData = file_aksnodeconfig_v1_hugepages_config_proto_rawDesc
+)
+
+func file_aksnodeconfig_v1_hugepages_config_proto_rawDescGZIP() []byte {
+	file_aksnodeconfig_v1_hugepages_config_proto_rawDescOnce.Do(func() {
+		file_aksnodeconfig_v1_hugepages_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_aksnodeconfig_v1_hugepages_config_proto_rawDescData)
+	})
+	return file_aksnodeconfig_v1_hugepages_config_proto_rawDescData
+}
+
+var file_aksnodeconfig_v1_hugepages_config_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_aksnodeconfig_v1_hugepages_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_aksnodeconfig_v1_hugepages_config_proto_goTypes = []any{
+	(HugepageSize)(0),        // 0: aksnodeconfig.v1.HugepageSize
+	(HugepagesAllocation)(0), // 1: aksnodeconfig.v1.HugepagesAllocation
+	(*HugepagesConfig)(nil),  // 2: aksnodeconfig.v1.HugepagesConfig
+}
+var file_aksnodeconfig_v1_hugepages_config_proto_depIdxs = []int32{
+	0, // 0: aksnodeconfig.v1.HugepagesConfig.page_size:type_name -> aksnodeconfig.v1.HugepageSize
+	1, // 1: aksnodeconfig.v1.HugepagesConfig.allocation:type_name -> aksnodeconfig.v1.HugepagesAllocation
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_aksnodeconfig_v1_hugepages_config_proto_init() }
+func file_aksnodeconfig_v1_hugepages_config_proto_init() {
+	if File_aksnodeconfig_v1_hugepages_config_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aksnodeconfig_v1_hugepages_config_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aksnodeconfig_v1_hugepages_config_proto_goTypes,
+		DependencyIndexes: file_aksnodeconfig_v1_hugepages_config_proto_depIdxs,
+		EnumInfos:         file_aksnodeconfig_v1_hugepages_config_proto_enumTypes,
+		MessageInfos:      file_aksnodeconfig_v1_hugepages_config_proto_msgTypes,
+	}.Build()
+	File_aksnodeconfig_v1_hugepages_config_proto = out.File
+	file_aksnodeconfig_v1_hugepages_config_proto_rawDesc = nil
+	file_aksnodeconfig_v1_hugepages_config_proto_goTypes = nil
+	file_aksnodeconfig_v1_hugepages_config_proto_depIdxs = nil
+}
@@ -74,13 +74,13 @@ type KubeletConfig struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// A list of taints to apply to the node.
+	// A list of taints to apply to the node, registered with the node by kubelet --register-with-taints.
 	Taints []*Taint `protobuf:"bytes,1,rep,name=taints,proto3" json:"taints,omitempty"`
 	// A map of kubelet flags to their values.
 	KubeletFlags map[string]string `protobuf:"bytes,2,rep,name=kubelet_flags,json=kubeletFlags,proto3" json:"kubelet_flags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// A map of node labels to their values.
 	KubeletNodeLabels map[string]string `protobuf:"bytes,3,rep,name=kubelet_node_labels,json=kubeletNodeLabels,proto3" json:"kubelet_node_labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// A list of taints to apply to the node at startup.
+	// A list of taints to apply to the node at startup, registered with the node along with taints.
 	StartupTaints []*Taint `protobuf:"bytes,4,rep,name=startup_taints,json=startupTaints,proto3" json:"startup_taints,omitempty"`
 	// The type of disk to use for the kubelet.
 	KubeletDiskType KubeletDisk `protobuf:"varint,5,opt,name=kubelet_disk_type,json=kubeletDiskType,proto3,enum=aksnodeconfig.v1.KubeletDisk" json:"kubelet_disk_type,omitempty"`
@@ -94,6 +94,24 @@ type KubeletConfig struct {
 	KubeletClientCertContent string `protobuf:"bytes,9,opt,name=kubelet_client_cert_content,json=kubeletClientCertContent,proto3" json:"kubelet_client_cert_content,omitempty"`
 	// The path used to mount docker images, emptyDir volumes, and kubelet data.
 	ContainerDataDir string `protobuf:"bytes,10,opt,name=container_data_dir,json=containerDataDir,proto3" json:"container_data_dir,omitempty"`
+	// Kubelet configuration drop-in files keyed by file name, which must end with ".conf". They are written to
+	// /etc/kubernetes/kubelet.conf.d and merged by kubelet over the config file. Requires Kubernetes 1.30 or later.
+	KubeletConfigDropIns map[string]string `protobuf:"bytes,11,rep,name=kubelet_config_drop_ins,json=kubeletConfigDropIns,proto3" json:"kubelet_config_drop_ins,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// A map of node annotations to their values. kubelet can't register annotations, they are applied to the Node object
+	// once the node registered.
+	NodeAnnotations map[string]string `protobuf:"bytes,12,rep,name=node_annotations,json=nodeAnnotations,proto3" json:"node_annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Serving certificate of kubelet, self-signed when not set.
+	ServingCertConfig *KubeletServingCertConfig `protobuf:"bytes,13,opt,name=serving_cert_config,json=servingCertConfig,proto3" json:"serving_cert_config,omitempty"`
+	// kubelet --cpu-manager-policy. The static policy requires reserved_system_cpus.
+	CpuManagerPolicy CpuManagerPolicy `protobuf:"varint,14,opt,name=cpu_manager_policy,json=cpuManagerPolicy,proto3,enum=aksnodeconfig.v1.CpuManagerPolicy" json:"cpu_manager_policy,omitempty"`
+	// kubelet --topology-manager-policy. A policy other than none requires the static CPU or memory manager policy,
+	// which provide its topology hints.
+	TopologyManagerPolicy TopologyManagerPolicy `protobuf:"varint,15,opt,name=topology_manager_policy,json=topologyManagerPolicy,proto3,enum=aksnodeconfig.v1.TopologyManagerPolicy" json:"topology_manager_policy,omitempty"`
+	// kubelet --memory-manager-policy. The static policy reserves the memory of --kube-reserved, --system-reserved and
+	// the hard eviction threshold on NUMA node 0 unless --reserved-memory is set.
+	MemoryManagerPolicy MemoryManagerPolicy `protobuf:"varint,16,opt,name=memory_manager_policy,json=memoryManagerPolicy,proto3,enum=aksnodeconfig.v1.MemoryManagerPolicy" json:"memory_manager_policy,omitempty"`
+	// CPUs reserved for the system and kubelet, kubelet --reserved-cpus, as a cpuset such as "0-1,8".
+	ReservedSystemCpus string `protobuf:"bytes,17,opt,name=reserved_system_cpus,json=reservedSystemCpus,proto3" json:"reserved_system_cpus,omitempty"`
 }
 
 func (x *KubeletConfig) Reset() {
@@ -196,18 +214,127 @@ func (x *KubeletConfig) GetContainerDataDir() string {
 	return ""
 }
 
+func (x *KubeletConfig) GetKubeletConfigDropIns() map[string]string {
+	if x != nil {
+		return x.KubeletConfigDropIns
+	}
+	return nil
+}
+
+func (x *KubeletConfig) GetNodeAnnotations() map[string]string {
+	if x != nil {
+		return x.NodeAnnotations
+	}
+	return nil
+}
+
+func (x *KubeletConfig) GetServingCertConfig() *KubeletServingCertConfig {
+	if x != nil {
+		return x.ServingCertConfig
+	}
+	return nil
+}
+
+func (x *KubeletConfig) GetCpuManagerPolicy() CpuManagerPolicy {
+	if x != nil {
+		return x.CpuManagerPolicy
+	}
+	return CpuManagerPolicy_CPU_MANAGER_POLICY_UNSPECIFIED
+}
+
+func (x *KubeletConfig) GetTopologyManagerPolicy() TopologyManagerPolicy {
+	if x != nil {
+		return x.TopologyManagerPolicy
+	}
+	return TopologyManagerPolicy_TOPOLOGY_MANAGER_POLICY_UNSPECIFIED
+}
+
+func (x *KubeletConfig) GetMemoryManagerPolicy() MemoryManagerPolicy {
+	if x != nil {
+		return x.MemoryManagerPolicy
+	}
+	return MemoryManagerPolicy_MEMORY_MANAGER_POLICY_UNSPECIFIED
+}
+
+func (x *KubeletConfig) GetReservedSystemCpus() string {
+	if x != nil {
+		return x.ReservedSystemCpus
+	}
+	return ""
+}
+
+type KubeletServingCertConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether kubelet requests its serving certificate from the API server with a CSR and rotates it before it expires,
+	// serverTLSBootstrap in the kubelet config file or --rotate-server-certificates. The CSR must be approved by an
+	// approver of the cluster.
+	ServerTlsBootstrap bool `protobuf:"varint,1,opt,name=server_tls_bootstrap,json=serverTlsBootstrap,proto3" json:"server_tls_bootstrap,omitempty"`
+	// DNS names and IP addresses the issued serving certificate must be valid for. Provisioning reports a certificate
+	// missing one of them.
+	RequiredSans []string `protobuf:"bytes,2,rep,name=required_sans,json=requiredSans,proto3" json:"required_sans,omitempty"`
+}
+
+func (x *KubeletServingCertConfig) Reset() {
+	*x = KubeletServingCertConfig{}
+	mi := &file_aksnodeconfig_v1_kubelet_config_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KubeletServingCertConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubeletServingCertConfig) ProtoMessage() {}
+
+func (x *KubeletServingCertConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_kubelet_config_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubeletServingCertConfig.ProtoReflect.Descriptor instead.
+func (*KubeletServingCertConfig) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_kubelet_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *KubeletServingCertConfig) GetServerTlsBootstrap() bool {
+	if x != nil {
+		return x.ServerTlsBootstrap
+	}
+	return false
+}
+
+func (x *KubeletServingCertConfig) GetRequiredSans() []string {
+	if x != nil {
+		return x.RequiredSans
+	}
+	return nil
+}
+
 type Taint struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key    string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// One of NoSchedule, PreferNoSchedule or NoExecute.
 	Effect string `protobuf:"bytes,2,opt,name=effect,proto3" json:"effect,omitempty"`
+	Value  string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
 }
 
 func (x *Taint) Reset() {
 	*x = Taint{}
-	mi := &file_aksnodeconfig_v1_kubelet_config_proto_msgTypes[1]
+	mi := &file_aksnodeconfig_v1_kubelet_config_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -219,7 +346,7 @@ func (x *Taint) String() string {
 func (*Taint) ProtoMessage() {}
 
 func (x *Taint) ProtoReflect() protoreflect.Message {
-	mi := &file_aksnodeconfig_v1_kubelet_config_proto_msgTypes[1]
+	mi := &file_aksnodeconfig_v1_kubelet_config_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -232,7 +359,7 @@ func (x *Taint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Taint.ProtoReflect.Descriptor instead.
 func (*Taint) Descriptor() ([]byte, []int) {
-	return file_aksnodeconfig_v1_kubelet_config_proto_rawDescGZIP(), []int{1}
+	return file_aksnodeconfig_v1_kubelet_config_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Taint) GetKey() string {
@@ -249,13 +376,23 @@ func (x *Taint) GetEffect() string {
 	return ""
 }
 
+func (x *Taint) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
 var File_aksnodeconfig_v1_kubelet_config_proto protoreflect.FileDescriptor
 
 var file_aksnodeconfig_v1_kubelet_config_proto_rawDesc = []byte{
 	0x0a, 0x25, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
 	0x76, 0x31, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x6c, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
 	0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x22, 0xa9, 0x06, 0x0a, 0x0d, 0x4b, 0x75,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x1a, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f,
+	0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa5, 0x0c, 0x0a, 0x0d, 0x4b, 0x75,
 	0x62, 0x65, 0x6c, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2f, 0x0a, 0x06, 0x74,
 	0x61, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x61, 0x6b,
 	0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54,
@@ -297,31 +434,88 @@ var file_aksnodeconfig_v1_kubelet_config_proto_rawDesc = []byte{
 	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x65, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
 	0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x64, 0x61,
 	0x74, 0x61, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x6f,
-	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x1a, 0x3f,
-	0x0a, 0x11, 0x4b, 0x75, 0x62, 0x65, 0x6c, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
-	0x44, 0x0a, 0x16, 0x4b, 0x75, 0x62, 0x65, 0x6c, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x61,
-	0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x31, 0x0a, 0x05, 0x54, 0x61, 0x69, 0x6e, 0x74, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x16, 0x0a, 0x06, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x2a, 0x61, 0x0a, 0x0b, 0x4b, 0x75, 0x62, 0x65,
-	0x6c, 0x65, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x12, 0x1c, 0x0a, 0x18, 0x4b, 0x55, 0x42, 0x45, 0x4c,
-	0x45, 0x54, 0x5f, 0x44, 0x49, 0x53, 0x4b, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
-	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x4b, 0x55, 0x42, 0x45, 0x4c, 0x45, 0x54,
-	0x5f, 0x44, 0x49, 0x53, 0x4b, 0x5f, 0x4f, 0x53, 0x5f, 0x44, 0x49, 0x53, 0x4b, 0x10, 0x01, 0x12,
-	0x1a, 0x0a, 0x16, 0x4b, 0x55, 0x42, 0x45, 0x4c, 0x45, 0x54, 0x5f, 0x44, 0x49, 0x53, 0x4b, 0x5f,
-	0x54, 0x45, 0x4d, 0x50, 0x5f, 0x44, 0x49, 0x53, 0x4b, 0x10, 0x02, 0x42, 0x5a, 0x5a, 0x58, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x2f,
-	0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b, 0x73, 0x2d, 0x6e,
-	0x6f, 0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x70,
-	0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x44, 0x69, 0x72, 0x12, 0x70,
+	0x0a, 0x17, 0x6b, 0x75, 0x62, 0x65, 0x6c, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x5f, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x69, 0x6e, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x39, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x4b, 0x75, 0x62, 0x65, 0x6c, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x4b, 0x75, 0x62, 0x65, 0x6c, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x72,
+	0x6f, 0x70, 0x49, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x14, 0x6b, 0x75, 0x62, 0x65,
+	0x6c, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x72, 0x6f, 0x70, 0x49, 0x6e, 0x73,
+	0x12, 0x5f, 0x0a, 0x10, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x61, 0x6b, 0x73,
+	0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x75,
+	0x62, 0x65, 0x6c, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
+	0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x0f, 0x6e, 0x6f, 0x64, 0x65, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x5a, 0x0a, 0x13, 0x73, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x65, 0x72,
+	0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a,
+	0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76,
+	0x31, 0x2e, 0x4b, 0x75, 0x62, 0x65, 0x6c, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x6e, 0x67,
+	0x43, 0x65, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x11, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x6e, 0x67, 0x43, 0x65, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x50, 0x0a,
+	0x12, 0x63, 0x70, 0x75, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x61, 0x6b, 0x73, 0x6e,
+	0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x70, 0x75,
+	0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x10, 0x63,
+	0x70, 0x75, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12,
+	0x5f, 0x0a, 0x17, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x5f, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x27, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x4d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x15, 0x74, 0x6f, 0x70, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x59, 0x0a, 0x15, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x72, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x25, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x13, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x30, 0x0a, 0x14, 0x72,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x63,
+	0x70, 0x75, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x72, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x64, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x70, 0x75, 0x73, 0x1a, 0x3f, 0x0a,
+	0x11, 0x4b, 0x75, 0x62, 0x65, 0x6c, 0x65, 0x74, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x44,
+	0x0a, 0x16, 0x4b, 0x75, 0x62, 0x65, 0x6c, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x47, 0x0a, 0x19, 0x4b, 0x75, 0x62, 0x65, 0x6c, 0x65, 0x74, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x72, 0x6f, 0x70, 0x49, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x42, 0x0a,
+	0x14, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x71, 0x0a, 0x18, 0x4b, 0x75, 0x62, 0x65, 0x6c, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x6e, 0x67, 0x43, 0x65, 0x72, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x30, 0x0a,
+	0x14, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x74, 0x6c, 0x73, 0x5f, 0x62, 0x6f, 0x6f, 0x74,
+	0x73, 0x74, 0x72, 0x61, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x54, 0x6c, 0x73, 0x42, 0x6f, 0x6f, 0x74, 0x73, 0x74, 0x72, 0x61, 0x70, 0x12,
+	0x23, 0x0a, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x61, 0x6e, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x53, 0x61, 0x6e, 0x73, 0x22, 0x47, 0x0a, 0x05, 0x54, 0x61, 0x69, 0x6e, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x2a, 0x61, 0x0a,
+	0x0b, 0x4b, 0x75, 0x62, 0x65, 0x6c, 0x65, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x12, 0x1c, 0x0a, 0x18,
+	0x4b, 0x55, 0x42, 0x45, 0x4c, 0x45, 0x54, 0x5f, 0x44, 0x49, 0x53, 0x4b, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x4b, 0x55,
+	0x42, 0x45, 0x4c, 0x45, 0x54, 0x5f, 0x44, 0x49, 0x53, 0x4b, 0x5f, 0x4f, 0x53, 0x5f, 0x44, 0x49,
+	0x53, 0x4b, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x4b, 0x55, 0x42, 0x45, 0x4c, 0x45, 0x54, 0x5f,
+	0x44, 0x49, 0x53, 0x4b, 0x5f, 0x54, 0x45, 0x4d, 0x50, 0x5f, 0x44, 0x49, 0x53, 0x4b, 0x10, 0x02,
+	0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41,
+	0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f,
+	0x61, 0x6b, 0x73, 0x2d, 0x6e, 0x6f, 0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e,
+	0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73,
+	0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -337,25 +531,37 @@ func file_aksnodeconfig_v1_kubelet_config_proto_rawDescGZIP() []byte {
 }
 
 var file_aksnodeconfig_v1_kubelet_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_aksnodeconfig_v1_kubelet_config_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_aksnodeconfig_v1_kubelet_config_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_aksnodeconfig_v1_kubelet_config_proto_goTypes = []any{
-	(KubeletDisk)(0),      // 0: aksnodeconfig.v1.KubeletDisk
-	(*KubeletConfig)(nil), // 1: aksnodeconfig.v1.KubeletConfig
-	(*Taint)(nil),         // 2: aksnodeconfig.v1.Taint
-	nil,                   // 3: aksnodeconfig.v1.KubeletConfig.KubeletFlagsEntry
-	nil,                   // 4: aksnodeconfig.v1.KubeletConfig.KubeletNodeLabelsEntry
+	(KubeletDisk)(0),                 // 0: aksnodeconfig.v1.KubeletDisk
+	(*KubeletConfig)(nil),            // 1: aksnodeconfig.v1.KubeletConfig
+	(*KubeletServingCertConfig)(nil), // 2: aksnodeconfig.v1.KubeletServingCertConfig
+	(*Taint)(nil),                    // 3: aksnodeconfig.v1.Taint
+	nil,                              // 4: aksnodeconfig.v1.KubeletConfig.KubeletFlagsEntry
+	nil,                              // 5: aksnodeconfig.v1.KubeletConfig.KubeletNodeLabelsEntry
+	nil,                              // 6: aksnodeconfig.v1.KubeletConfig.KubeletConfigDropInsEntry
+	nil,                              // 7: aksnodeconfig.v1.KubeletConfig.NodeAnnotationsEntry
+	(CpuManagerPolicy)(0),            // 8: aksnodeconfig.v1.CpuManagerPolicy
+	(TopologyManagerPolicy)(0),       // 9: aksnodeconfig.v1.TopologyManagerPolicy
+	(MemoryManagerPolicy)(0),         // 10: aksnodeconfig.v1.MemoryManagerPolicy
 }
 var file_aksnodeconfig_v1_kubelet_config_proto_depIdxs = []int32{
-	2, // 0: aksnodeconfig.v1.KubeletConfig.taints:type_name -> aksnodeconfig.v1.Taint
-	3, // 1: aksnodeconfig.v1.KubeletConfig.kubelet_flags:type_name -> aksnodeconfig.v1.KubeletConfig.KubeletFlagsEntry
-	4, // 2: aksnodeconfig.v1.KubeletConfig.kubelet_node_labels:type_name -> aksnodeconfig.v1.KubeletConfig.KubeletNodeLabelsEntry
-	2, // 3: aksnodeconfig.v1.KubeletConfig.startup_taints:type_name -> aksnodeconfig.v1.Taint
-	0, // 4: aksnodeconfig.v1.KubeletConfig.kubelet_disk_type:type_name -> aksnodeconfig.v1.KubeletDisk
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	3,  // 0: aksnodeconfig.v1.KubeletConfig.taints:type_name -> aksnodeconfig.v1.Taint
+	4,  // 1: aksnodeconfig.v1.KubeletConfig.kubelet_flags:type_name -> aksnodeconfig.v1.KubeletConfig.KubeletFlagsEntry
+	5,  // 2: aksnodeconfig.v1.KubeletConfig.kubelet_node_labels:type_name -> aksnodeconfig.v1.KubeletConfig.KubeletNodeLabelsEntry
+	3,  // 3: aksnodeconfig.v1.KubeletConfig.startup_taints:type_name -> aksnodeconfig.v1.Taint
+	0,  // 4: aksnodeconfig.v1.KubeletConfig.kubelet_disk_type:type_name -> aksnodeconfig.v1.KubeletDisk
+	6,  // 5: aksnodeconfig.v1.KubeletConfig.kubelet_config_drop_ins:type_name -> aksnodeconfig.v1.KubeletConfig.KubeletConfigDropInsEntry
+	7,  // 6: aksnodeconfig.v1.KubeletConfig.node_annotations:type_name -> aksnodeconfig.v1.KubeletConfig.NodeAnnotationsEntry
+	2,  // 7: aksnodeconfig.v1.KubeletConfig.serving_cert_config:type_name -> aksnodeconfig.v1.KubeletServingCertConfig
+	8,  // 8: aksnodeconfig.v1.KubeletConfig.cpu_manager_policy:type_name -> aksnodeconfig.v1.CpuManagerPolicy
+	9,  // 9: aksnodeconfig.v1.KubeletConfig.topology_manager_policy:type_name -> aksnodeconfig.v1.TopologyManagerPolicy
+	10, // 10: aksnodeconfig.v1.KubeletConfig.memory_manager_policy:type_name -> aksnodeconfig.v1.MemoryManagerPolicy
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_aksnodeconfig_v1_kubelet_config_proto_init() }
@@ -363,13 +569,14 @@ func file_aksnodeconfig_v1_kubelet_config_proto_init() {
 	if File_aksnodeconfig_v1_kubelet_config_proto != nil {
 		return
 	}
+	file_aksnodeconfig_v1_resource_manager_policy_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_aksnodeconfig_v1_kubelet_config_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   4,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
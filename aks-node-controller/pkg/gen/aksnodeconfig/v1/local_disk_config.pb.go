@@ -0,0 +1,314 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: aksnodeconfig/v1/local_disk_config.proto
+
+package aksnodeconfigv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LocalDiskLayout int32
+
+const (
+	// The local disks are left as configured by the VHD.
+	LocalDiskLayout_LOCAL_DISK_LAYOUT_UNSPECIFIED LocalDiskLayout = 0
+	// The first local disk is used on its own.
+	LocalDiskLayout_LOCAL_DISK_LAYOUT_SINGLE LocalDiskLayout = 1
+	// The local disks are striped into a RAID 0 array. A single disk is used on its own.
+	LocalDiskLayout_LOCAL_DISK_LAYOUT_RAID0 LocalDiskLayout = 2
+)
+
+// Enum value maps for LocalDiskLayout.
+var (
+	LocalDiskLayout_name = map[int32]string{
+		0: "LOCAL_DISK_LAYOUT_UNSPECIFIED",
+		1: "LOCAL_DISK_LAYOUT_SINGLE",
+		2: "LOCAL_DISK_LAYOUT_RAID0",
+	}
+	LocalDiskLayout_value = map[string]int32{
+		"LOCAL_DISK_LAYOUT_UNSPECIFIED": 0,
+		"LOCAL_DISK_LAYOUT_SINGLE":      1,
+		"LOCAL_DISK_LAYOUT_RAID0":       2,
+	}
+)
+
+func (x LocalDiskLayout) Enum() *LocalDiskLayout {
+	p := new(LocalDiskLayout)
+	*p = x
+	return p
+}
+
+func (x LocalDiskLayout) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LocalDiskLayout) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_local_disk_config_proto_enumTypes[0].Descriptor()
+}
+
+func (LocalDiskLayout) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_local_disk_config_proto_enumTypes[0]
+}
+
+func (x LocalDiskLayout) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LocalDiskLayout.Descriptor instead.
+func (LocalDiskLayout) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_local_disk_config_proto_rawDescGZIP(), []int{0}
+}
+
+type LocalDiskMountTarget int32
+
+const (
+	LocalDiskMountTarget_LOCAL_DISK_MOUNT_TARGET_UNSPECIFIED LocalDiskMountTarget = 0
+	// Mounted at /var/lib/containerd, container images and writable layers use the local disks.
+	LocalDiskMountTarget_LOCAL_DISK_MOUNT_TARGET_CONTAINERD LocalDiskMountTarget = 1
+	// Mounted at /var/lib/kubelet, pod ephemeral storage and emptyDir volumes use the local disks.
+	LocalDiskMountTarget_LOCAL_DISK_MOUNT_TARGET_KUBELET LocalDiskMountTarget = 2
+	// Not formatted nor mounted, the device is left to workloads as a raw block device.
+	LocalDiskMountTarget_LOCAL_DISK_MOUNT_TARGET_RAW_BLOCK LocalDiskMountTarget = 3
+)
+
+// Enum value maps for LocalDiskMountTarget.
+var (
+	LocalDiskMountTarget_name = map[int32]string{
+		0: "LOCAL_DISK_MOUNT_TARGET_UNSPECIFIED",
+		1: "LOCAL_DISK_MOUNT_TARGET_CONTAINERD",
+		2: "LOCAL_DISK_MOUNT_TARGET_KUBELET",
+		3: "LOCAL_DISK_MOUNT_TARGET_RAW_BLOCK",
+	}
+	LocalDiskMountTarget_value = map[string]int32{
+		"LOCAL_DISK_MOUNT_TARGET_UNSPECIFIED": 0,
+		"LOCAL_DISK_MOUNT_TARGET_CONTAINERD":  1,
+		"LOCAL_DISK_MOUNT_TARGET_KUBELET":     2,
+		"LOCAL_DISK_MOUNT_TARGET_RAW_BLOCK":   3,
+	}
+)
+
+func (x LocalDiskMountTarget) Enum() *LocalDiskMountTarget {
+	p := new(LocalDiskMountTarget)
+	*p = x
+	return p
+}
+
+func (x LocalDiskMountTarget) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LocalDiskMountTarget) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_local_disk_config_proto_enumTypes[1].Descriptor()
+}
+
+func (LocalDiskMountTarget) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_local_disk_config_proto_enumTypes[1]
+}
+
+func (x LocalDiskMountTarget) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LocalDiskMountTarget.Descriptor instead.
+func (LocalDiskMountTarget) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_local_disk_config_proto_rawDescGZIP(), []int{1}
+}
+
+type LocalDiskConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Layout      LocalDiskLayout      `protobuf:"varint,1,opt,name=layout,proto3,enum=aksnodeconfig.v1.LocalDiskLayout" json:"layout,omitempty"`
+	MountTarget LocalDiskMountTarget `protobuf:"varint,2,opt,name=mount_target,json=mountTarget,proto3,enum=aksnodeconfig.v1.LocalDiskMountTarget" json:"mount_target,omitempty"`
+	// Filesystem the device is formatted with, "ext4" (default) or "xfs". Ignored for raw block devices.
+	Filesystem string `protobuf:"bytes,3,opt,name=filesystem,proto3" json:"filesystem,omitempty"`
+	// Also use the temporary resource disk, which is unmounted from /mnt. Only local NVMe disks are used otherwise.
+	IncludeTempDisk bool `protobuf:"varint,4,opt,name=include_temp_disk,json=includeTempDisk,proto3" json:"include_temp_disk,omitempty"`
+	// Additional mount options, e.g. "noatime".
+	MountOptions []string `protobuf:"bytes,5,rep,name=mount_options,json=mountOptions,proto3" json:"mount_options,omitempty"`
+}
+
+func (x *LocalDiskConfig) Reset() {
+	*x = LocalDiskConfig{}
+	mi := &file_aksnodeconfig_v1_local_disk_config_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocalDiskConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocalDiskConfig) ProtoMessage() {}
+
+func (x *LocalDiskConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_local_disk_config_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocalDiskConfig.ProtoReflect.Descriptor instead.
+func (*LocalDiskConfig) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_local_disk_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LocalDiskConfig) GetLayout() LocalDiskLayout {
+	if x != nil {
+		return x.Layout
+	}
+	return LocalDiskLayout_LOCAL_DISK_LAYOUT_UNSPECIFIED
+}
+
+func (x *LocalDiskConfig) GetMountTarget() LocalDiskMountTarget {
+	if x != nil {
+		return x.MountTarget
+	}
+	return LocalDiskMountTarget_LOCAL_DISK_MOUNT_TARGET_UNSPECIFIED
+}
+
+func (x *LocalDiskConfig) GetFilesystem() string {
+	if x != nil {
+		return x.Filesystem
+	}
+	return ""
+}
+
+func (x *LocalDiskConfig) GetIncludeTempDisk() bool {
+	if x != nil {
+		return x.IncludeTempDisk
+	}
+	return false
+}
+
+func (x *LocalDiskConfig) GetMountOptions() []string {
+	if x != nil {
+		return x.MountOptions
+	}
+	return nil
+}
+
+var File_aksnodeconfig_v1_local_disk_config_proto protoreflect.FileDescriptor
+
+var file_aksnodeconfig_v1_local_disk_config_proto_rawDesc = []byte{
+	0x0a, 0x28, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x76, 0x31, 0x2f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x61, 0x6b, 0x73, 0x6e,
+	0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x22, 0x88, 0x02, 0x0a,
+	0x0f, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x44, 0x69, 0x73, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x12, 0x39, 0x0a, 0x06, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x21, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x44, 0x69, 0x73, 0x6b, 0x4c, 0x61, 0x79,
+	0x6f, 0x75, 0x74, 0x52, 0x06, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x49, 0x0a, 0x0c, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x26, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x44, 0x69, 0x73, 0x6b, 0x4d, 0x6f,
+	0x75, 0x6e, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x0b, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x2a, 0x0a, 0x11, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x5f, 0x64, 0x69, 0x73, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x54, 0x65, 0x6d, 0x70, 0x44, 0x69,
+	0x73, 0x6b, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2a, 0x6f, 0x0a, 0x0f, 0x4c, 0x6f, 0x63, 0x61, 0x6c,
+	0x44, 0x69, 0x73, 0x6b, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x21, 0x0a, 0x1d, 0x4c, 0x4f,
+	0x43, 0x41, 0x4c, 0x5f, 0x44, 0x49, 0x53, 0x4b, 0x5f, 0x4c, 0x41, 0x59, 0x4f, 0x55, 0x54, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1c, 0x0a,
+	0x18, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x5f, 0x44, 0x49, 0x53, 0x4b, 0x5f, 0x4c, 0x41, 0x59, 0x4f,
+	0x55, 0x54, 0x5f, 0x53, 0x49, 0x4e, 0x47, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x4c,
+	0x4f, 0x43, 0x41, 0x4c, 0x5f, 0x44, 0x49, 0x53, 0x4b, 0x5f, 0x4c, 0x41, 0x59, 0x4f, 0x55, 0x54,
+	0x5f, 0x52, 0x41, 0x49, 0x44, 0x30, 0x10, 0x02, 0x2a, 0xb3, 0x01, 0x0a, 0x14, 0x4c, 0x6f, 0x63,
+	0x61, 0x6c, 0x44, 0x69, 0x73, 0x6b, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x12, 0x27, 0x0a, 0x23, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x5f, 0x44, 0x49, 0x53, 0x4b, 0x5f,
+	0x4d, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x26, 0x0a, 0x22, 0x4c, 0x4f,
+	0x43, 0x41, 0x4c, 0x5f, 0x44, 0x49, 0x53, 0x4b, 0x5f, 0x4d, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x54,
+	0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e, 0x45, 0x52, 0x44,
+	0x10, 0x01, 0x12, 0x23, 0x0a, 0x1f, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x5f, 0x44, 0x49, 0x53, 0x4b,
+	0x5f, 0x4d, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x4b, 0x55,
+	0x42, 0x45, 0x4c, 0x45, 0x54, 0x10, 0x02, 0x12, 0x25, 0x0a, 0x21, 0x4c, 0x4f, 0x43, 0x41, 0x4c,
+	0x5f, 0x44, 0x49, 0x53, 0x4b, 0x5f, 0x4d, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x54, 0x41, 0x52, 0x47,
+	0x45, 0x54, 0x5f, 0x52, 0x41, 0x57, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x10, 0x03, 0x42, 0x5a,
+	0x5a, 0x58, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75,
+	0x72, 0x65, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b,
+	0x73, 0x2d, 0x6e, 0x6f, 0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64,
+	0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f,
+	0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_aksnodeconfig_v1_local_disk_config_proto_rawDescOnce sync.Once
+	file_aksnodeconfig_v1_local_disk_config_proto_rawDescData = file_aksnodeconfig_v1_local_disk_config_proto_rawDesc
+)
+
+func file_aksnodeconfig_v1_local_disk_config_proto_rawDescGZIP() []byte {
+	file_aksnodeconfig_v1_local_disk_config_proto_rawDescOnce.Do(func() {
+		file_aksnodeconfig_v1_local_disk_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_aksnodeconfig_v1_local_disk_config_proto_rawDescData)
+	})
+	return file_aksnodeconfig_v1_local_disk_config_proto_rawDescData
+}
+
+var file_aksnodeconfig_v1_local_disk_config_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_aksnodeconfig_v1_local_disk_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_aksnodeconfig_v1_local_disk_config_proto_goTypes = []any{
+	(LocalDiskLayout)(0),      // 0: aksnodeconfig.v1.LocalDiskLayout
+	(LocalDiskMountTarget)(0), // 1: aksnodeconfig.v1.LocalDiskMountTarget
+	(*LocalDiskConfig)(nil),   // 2: aksnodeconfig.v1.LocalDiskConfig
+}
+var file_aksnodeconfig_v1_local_disk_config_proto_depIdxs = []int32{
+	0, // 0: aksnodeconfig.v1.LocalDiskConfig.layout:type_name -> aksnodeconfig.v1.LocalDiskLayout
+	1, // 1: aksnodeconfig.v1.LocalDiskConfig.mount_target:type_name -> aksnodeconfig.v1.LocalDiskMountTarget
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_aksnodeconfig_v1_local_disk_config_proto_init() }
+func file_aksnodeconfig_v1_local_disk_config_proto_init() {
+	if File_aksnodeconfig_v1_local_disk_config_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aksnodeconfig_v1_local_disk_config_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aksnodeconfig_v1_local_disk_config_proto_goTypes,
+		DependencyIndexes: file_aksnodeconfig_v1_local_disk_config_proto_depIdxs,
+		EnumInfos:         file_aksnodeconfig_v1_local_disk_config_proto_enumTypes,
+		MessageInfos:      file_aksnodeconfig_v1_local_disk_config_proto_msgTypes,
+	}.Build()
+	File_aksnodeconfig_v1_local_disk_config_proto = out.File
+	file_aksnodeconfig_v1_local_disk_config_proto_rawDesc = nil
+	file_aksnodeconfig_v1_local_disk_config_proto_goTypes = nil
+	file_aksnodeconfig_v1_local_disk_config_proto_depIdxs = nil
+}
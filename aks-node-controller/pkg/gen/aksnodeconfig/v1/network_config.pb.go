@@ -124,6 +124,55 @@ func (NetworkPolicy) EnumDescriptor() ([]byte, []int) {
 	return file_aksnodeconfig_v1_network_config_proto_rawDescGZIP(), []int{1}
 }
 
+type IPFamily int32
+
+const (
+	IPFamily_IP_FAMILY_UNSPECIFIED IPFamily = 0
+	IPFamily_IP_FAMILY_IPV4        IPFamily = 1
+	IPFamily_IP_FAMILY_IPV6        IPFamily = 2
+)
+
+// Enum value maps for IPFamily.
+var (
+	IPFamily_name = map[int32]string{
+		0: "IP_FAMILY_UNSPECIFIED",
+		1: "IP_FAMILY_IPV4",
+		2: "IP_FAMILY_IPV6",
+	}
+	IPFamily_value = map[string]int32{
+		"IP_FAMILY_UNSPECIFIED": 0,
+		"IP_FAMILY_IPV4":        1,
+		"IP_FAMILY_IPV6":        2,
+	}
+)
+
+func (x IPFamily) Enum() *IPFamily {
+	p := new(IPFamily)
+	*p = x
+	return p
+}
+
+func (x IPFamily) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IPFamily) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_network_config_proto_enumTypes[2].Descriptor()
+}
+
+func (IPFamily) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_network_config_proto_enumTypes[2]
+}
+
+func (x IPFamily) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IPFamily.Descriptor instead.
+func (IPFamily) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_network_config_proto_rawDescGZIP(), []int{2}
+}
+
 type NetworkConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -139,6 +188,24 @@ type NetworkConfig struct {
 	VnetCniPluginsUrl string `protobuf:"bytes,3,opt,name=vnet_cni_plugins_url,json=vnetCniPluginsUrl,proto3" json:"vnet_cni_plugins_url,omitempty"`
 	// URL to the cni plugins tarball.
 	CniPluginsUrl string `protobuf:"bytes,4,opt,name=cni_plugins_url,json=cniPluginsUrl,proto3" json:"cni_plugins_url,omitempty"`
+	// Whether the NIC of the node has accelerated networking. Provisioning records whether a virtual function was negotiated.
+	EnableAcceleratedNetworking *bool `protobuf:"varint,5,opt,name=enable_accelerated_networking,json=enableAcceleratedNetworking,proto3,oneof" json:"enable_accelerated_networking,omitempty"`
+	// Whether the NIC of the node forwards traffic not addressed to it, required by kubenet. Defaults to true with kubenet.
+	EnableIpForwarding *bool `protobuf:"varint,6,opt,name=enable_ip_forwarding,json=enableIpForwarding,proto3,oneof" json:"enable_ip_forwarding,omitempty"`
+	// NodeLocal DNSCache, the node-local-dns DaemonSet serves the DNS queries of the pods on a link-local address.
+	NodeLocalDnsConfig *NodeLocalDnsConfig `protobuf:"bytes,7,opt,name=node_local_dns_config,json=nodeLocalDnsConfig,proto3" json:"node_local_dns_config,omitempty"`
+	// IP families of the node, the primary family first. Setting both makes the node dual-stack: kubelet --node-ip gets
+	// the address of each family of the primary interface and IPv6 forwarding is enabled. Defaults to IPv4, or to
+	// IPv4 and IPv6 with ipv6_dual_stack_enabled.
+	IpFamilies []IPFamily `protobuf:"varint,8,rep,packed,name=ip_families,json=ipFamilies,proto3,enum=aksnodeconfig.v1.IPFamily" json:"ip_families,omitempty"`
+	// CIDRs of the secondary IP family assigned to the pods of the node, such as the IPv6 pod CIDR with kubenet. Their
+	// traffic leaving the CIDRs is masqueraded with ip6tables or iptables, the Azure network only routes the node address.
+	SecondaryCidrs []string `protobuf:"bytes,9,rep,name=secondary_cidrs,json=secondaryCidrs,proto3" json:"secondary_cidrs,omitempty"`
+	// Whether provisioning sets up the InfiniBand NIC of the RDMA capable VM sizes, such as the HB, HC and ND series, exposed
+	// to the VM through SR-IOV. The MOFED drivers are installed when the node image doesn't ship them.
+	EnableInfiniband bool `protobuf:"varint,10,opt,name=enable_infiniband,json=enableInfiniband,proto3" json:"enable_infiniband,omitempty"`
+	// URL to the MOFED tarball installed when the node image doesn't ship the MOFED drivers.
+	MofedPackageUrl string `protobuf:"bytes,11,opt,name=mofed_package_url,json=mofedPackageUrl,proto3" json:"mofed_package_url,omitempty"`
 }
 
 func (x *NetworkConfig) Reset() {
@@ -199,13 +266,118 @@ func (x *NetworkConfig) GetCniPluginsUrl() string {
 	return ""
 }
 
+func (x *NetworkConfig) GetEnableAcceleratedNetworking() bool {
+	if x != nil && x.EnableAcceleratedNetworking != nil {
+		return *x.EnableAcceleratedNetworking
+	}
+	return false
+}
+
+func (x *NetworkConfig) GetEnableIpForwarding() bool {
+	if x != nil && x.EnableIpForwarding != nil {
+		return *x.EnableIpForwarding
+	}
+	return false
+}
+
+func (x *NetworkConfig) GetNodeLocalDnsConfig() *NodeLocalDnsConfig {
+	if x != nil {
+		return x.NodeLocalDnsConfig
+	}
+	return nil
+}
+
+func (x *NetworkConfig) GetIpFamilies() []IPFamily {
+	if x != nil {
+		return x.IpFamilies
+	}
+	return nil
+}
+
+func (x *NetworkConfig) GetSecondaryCidrs() []string {
+	if x != nil {
+		return x.SecondaryCidrs
+	}
+	return nil
+}
+
+func (x *NetworkConfig) GetEnableInfiniband() bool {
+	if x != nil {
+		return x.EnableInfiniband
+	}
+	return false
+}
+
+func (x *NetworkConfig) GetMofedPackageUrl() string {
+	if x != nil {
+		return x.MofedPackageUrl
+	}
+	return ""
+}
+
+type NodeLocalDnsConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether provisioning sets up the interface the cache listens on and points kubelet --cluster-dns at it, so that
+	// the pods created before the DaemonSet runs also resolve through the cache once it does.
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Link-local IPv4 address the cache listens on. Defaults to 169.254.20.10.
+	LocalIp string `protobuf:"bytes,2,opt,name=local_ip,json=localIp,proto3" json:"local_ip,omitempty"`
+}
+
+func (x *NodeLocalDnsConfig) Reset() {
+	*x = NodeLocalDnsConfig{}
+	mi := &file_aksnodeconfig_v1_network_config_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeLocalDnsConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeLocalDnsConfig) ProtoMessage() {}
+
+func (x *NodeLocalDnsConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_network_config_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeLocalDnsConfig.ProtoReflect.Descriptor instead.
+func (*NodeLocalDnsConfig) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_network_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NodeLocalDnsConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *NodeLocalDnsConfig) GetLocalIp() string {
+	if x != nil {
+		return x.LocalIp
+	}
+	return ""
+}
+
 var File_aksnodeconfig_v1_network_config_proto protoreflect.FileDescriptor
 
 var file_aksnodeconfig_v1_network_config_proto_rawDesc = []byte{
 	0x0a, 0x25, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
 	0x76, 0x31, 0x2f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
 	0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x22, 0xf8, 0x01, 0x0a, 0x0d, 0x4e, 0x65,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x22, 0xcb, 0x05, 0x0a, 0x0d, 0x4e, 0x65,
 	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x46, 0x0a, 0x0e, 0x6e,
 	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e,
@@ -221,29 +393,68 @@ var file_aksnodeconfig_v1_network_config_proto_rawDesc = []byte{
 	0x6e, 0x69, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x55, 0x72, 0x6c, 0x12, 0x26, 0x0a, 0x0f,
 	0x63, 0x6e, 0x69, 0x5f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x5f, 0x75, 0x72, 0x6c, 0x18,
 	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6e, 0x69, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e,
-	0x73, 0x55, 0x72, 0x6c, 0x2a, 0x7e, 0x0a, 0x0d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50,
-	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12, 0x1e, 0x0a, 0x1a, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b,
-	0x5f, 0x50, 0x4c, 0x55, 0x47, 0x49, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
-	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b,
-	0x5f, 0x50, 0x4c, 0x55, 0x47, 0x49, 0x4e, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x18,
-	0x0a, 0x14, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x50, 0x4c, 0x55, 0x47, 0x49, 0x4e,
-	0x5f, 0x41, 0x5a, 0x55, 0x52, 0x45, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x4e, 0x45, 0x54, 0x57,
-	0x4f, 0x52, 0x4b, 0x5f, 0x50, 0x4c, 0x55, 0x47, 0x49, 0x4e, 0x5f, 0x4b, 0x55, 0x42, 0x45, 0x4e,
-	0x45, 0x54, 0x10, 0x03, 0x2a, 0x7d, 0x0a, 0x0d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50,
-	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1e, 0x0a, 0x1a, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b,
-	0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
-	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b,
-	0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x18,
-	0x0a, 0x14, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59,
-	0x5f, 0x41, 0x5a, 0x55, 0x52, 0x45, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x4e, 0x45, 0x54, 0x57,
-	0x4f, 0x52, 0x4b, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x43, 0x41, 0x4c, 0x49, 0x43,
-	0x4f, 0x10, 0x03, 0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
-	0x6d, 0x2f, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b,
-	0x65, 0x72, 0x2f, 0x61, 0x6b, 0x73, 0x2d, 0x6e, 0x6f, 0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74,
-	0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61,
-	0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b,
-	0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x55, 0x72, 0x6c, 0x12, 0x47, 0x0a, 0x1d, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x61,
+	0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x1b, 0x65,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a,
+	0x14, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x69, 0x70, 0x5f, 0x66, 0x6f, 0x72, 0x77, 0x61,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x48, 0x01, 0x52, 0x12, 0x65,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x70, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x88, 0x01, 0x01, 0x12, 0x57, 0x0a, 0x15, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6c, 0x6f, 0x63,
+	0x61, 0x6c, 0x5f, 0x64, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x6f, 0x63, 0x61, 0x6c,
+	0x44, 0x6e, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x12, 0x6e, 0x6f, 0x64, 0x65, 0x4c,
+	0x6f, 0x63, 0x61, 0x6c, 0x44, 0x6e, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3b, 0x0a,
+	0x0b, 0x69, 0x70, 0x5f, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03,
+	0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x50, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x52, 0x0a,
+	0x69, 0x70, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x69, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x5f, 0x63, 0x69, 0x64, 0x72, 0x73, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x43, 0x69,
+	0x64, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x69, 0x6e,
+	0x66, 0x69, 0x6e, 0x69, 0x62, 0x61, 0x6e, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x69, 0x6e, 0x69, 0x62, 0x61, 0x6e, 0x64,
+	0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x6f, 0x66, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67,
+	0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x6d, 0x6f, 0x66,
+	0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x55, 0x72, 0x6c, 0x42, 0x20, 0x0a, 0x1e,
+	0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x42, 0x17,
+	0x0a, 0x15, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x69, 0x70, 0x5f, 0x66, 0x6f, 0x72,
+	0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x49, 0x0a, 0x12, 0x4e, 0x6f, 0x64, 0x65, 0x4c,
+	0x6f, 0x63, 0x61, 0x6c, 0x44, 0x6e, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x0a,
+	0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x5f, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x49, 0x70, 0x2a, 0x7e, 0x0a, 0x0d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x12, 0x1e, 0x0a, 0x1a, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x50,
+	0x4c, 0x55, 0x47, 0x49, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x50,
+	0x4c, 0x55, 0x47, 0x49, 0x4e, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14,
+	0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x50, 0x4c, 0x55, 0x47, 0x49, 0x4e, 0x5f, 0x41,
+	0x5a, 0x55, 0x52, 0x45, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52,
+	0x4b, 0x5f, 0x50, 0x4c, 0x55, 0x47, 0x49, 0x4e, 0x5f, 0x4b, 0x55, 0x42, 0x45, 0x4e, 0x45, 0x54,
+	0x10, 0x03, 0x2a, 0x7d, 0x0a, 0x0d, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x12, 0x1e, 0x0a, 0x1a, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x50,
+	0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
+	0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x50,
+	0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14,
+	0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x41,
+	0x5a, 0x55, 0x52, 0x45, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52,
+	0x4b, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x43, 0x41, 0x4c, 0x49, 0x43, 0x4f, 0x10,
+	0x03, 0x2a, 0x4d, 0x0a, 0x08, 0x49, 0x50, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x12, 0x19, 0x0a,
+	0x15, 0x49, 0x50, 0x5f, 0x46, 0x41, 0x4d, 0x49, 0x4c, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x50, 0x5f, 0x46,
+	0x41, 0x4d, 0x49, 0x4c, 0x59, 0x5f, 0x49, 0x50, 0x56, 0x34, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e,
+	0x49, 0x50, 0x5f, 0x46, 0x41, 0x4d, 0x49, 0x4c, 0x59, 0x5f, 0x49, 0x50, 0x56, 0x36, 0x10, 0x02,
+	0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41,
+	0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f,
+	0x61, 0x6b, 0x73, 0x2d, 0x6e, 0x6f, 0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e,
+	0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73,
+	0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -258,21 +469,25 @@ func file_aksnodeconfig_v1_network_config_proto_rawDescGZIP() []byte {
 	return file_aksnodeconfig_v1_network_config_proto_rawDescData
 }
 
-var file_aksnodeconfig_v1_network_config_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_aksnodeconfig_v1_network_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_aksnodeconfig_v1_network_config_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_aksnodeconfig_v1_network_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_aksnodeconfig_v1_network_config_proto_goTypes = []any{
-	(NetworkPlugin)(0),    // 0: aksnodeconfig.v1.NetworkPlugin
-	(NetworkPolicy)(0),    // 1: aksnodeconfig.v1.NetworkPolicy
-	(*NetworkConfig)(nil), // 2: aksnodeconfig.v1.NetworkConfig
+	(NetworkPlugin)(0),         // 0: aksnodeconfig.v1.NetworkPlugin
+	(NetworkPolicy)(0),         // 1: aksnodeconfig.v1.NetworkPolicy
+	(IPFamily)(0),              // 2: aksnodeconfig.v1.IPFamily
+	(*NetworkConfig)(nil),      // 3: aksnodeconfig.v1.NetworkConfig
+	(*NodeLocalDnsConfig)(nil), // 4: aksnodeconfig.v1.NodeLocalDnsConfig
 }
 var file_aksnodeconfig_v1_network_config_proto_depIdxs = []int32{
 	0, // 0: aksnodeconfig.v1.NetworkConfig.network_plugin:type_name -> aksnodeconfig.v1.NetworkPlugin
 	1, // 1: aksnodeconfig.v1.NetworkConfig.network_policy:type_name -> aksnodeconfig.v1.NetworkPolicy
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	4, // 2: aksnodeconfig.v1.NetworkConfig.node_local_dns_config:type_name -> aksnodeconfig.v1.NodeLocalDnsConfig
+	2, // 3: aksnodeconfig.v1.NetworkConfig.ip_families:type_name -> aksnodeconfig.v1.IPFamily
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_aksnodeconfig_v1_network_config_proto_init() }
@@ -280,13 +495,14 @@ func file_aksnodeconfig_v1_network_config_proto_init() {
 	if File_aksnodeconfig_v1_network_config_proto != nil {
 		return
 	}
+	file_aksnodeconfig_v1_network_config_proto_msgTypes[0].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_aksnodeconfig_v1_network_config_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   1,
+			NumEnums:      3,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,152 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: aksnodeconfig/v1/outbound_config.proto
+
+package aksnodeconfigv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OutboundType int32
+
+const (
+	// The outbound connectivity check runs when outbound_command is set.
+	OutboundType_OUTBOUND_TYPE_UNSPECIFIED OutboundType = 0
+	// Outbound traffic goes through the load balancer, the outbound connectivity check runs.
+	OutboundType_OUTBOUND_TYPE_LOAD_BALANCER OutboundType = 1
+	// Outbound traffic is routed by the user, through a firewall which may not allow the connectivity check probes.
+	// The check is skipped.
+	OutboundType_OUTBOUND_TYPE_USER_DEFINED_ROUTING OutboundType = 2
+	// The node has no outbound access. The check is skipped, images and binaries come from the bootstrap profile
+	// container registry and the private kube binary URL.
+	OutboundType_OUTBOUND_TYPE_NONE OutboundType = 3
+)
+
+// Enum value maps for OutboundType.
+var (
+	OutboundType_name = map[int32]string{
+		0: "OUTBOUND_TYPE_UNSPECIFIED",
+		1: "OUTBOUND_TYPE_LOAD_BALANCER",
+		2: "OUTBOUND_TYPE_USER_DEFINED_ROUTING",
+		3: "OUTBOUND_TYPE_NONE",
+	}
+	OutboundType_value = map[string]int32{
+		"OUTBOUND_TYPE_UNSPECIFIED":          0,
+		"OUTBOUND_TYPE_LOAD_BALANCER":        1,
+		"OUTBOUND_TYPE_USER_DEFINED_ROUTING": 2,
+		"OUTBOUND_TYPE_NONE":                 3,
+	}
+)
+
+func (x OutboundType) Enum() *OutboundType {
+	p := new(OutboundType)
+	*p = x
+	return p
+}
+
+func (x OutboundType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutboundType) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_outbound_config_proto_enumTypes[0].Descriptor()
+}
+
+func (OutboundType) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_outbound_config_proto_enumTypes[0]
+}
+
+func (x OutboundType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutboundType.Descriptor instead.
+func (OutboundType) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_outbound_config_proto_rawDescGZIP(), []int{0}
+}
+
+var File_aksnodeconfig_v1_outbound_config_proto protoreflect.FileDescriptor
+
+var file_aksnodeconfig_v1_outbound_config_proto_rawDesc = []byte{
+	0x0a, 0x26, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x76, 0x31, 0x2f, 0x6f, 0x75, 0x74, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64,
+	0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2a, 0x8e, 0x01, 0x0a, 0x0c, 0x4f,
+	0x75, 0x74, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x19, 0x4f,
+	0x55, 0x54, 0x42, 0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1f, 0x0a, 0x1b, 0x4f, 0x55,
+	0x54, 0x42, 0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x4f, 0x41, 0x44,
+	0x5f, 0x42, 0x41, 0x4c, 0x41, 0x4e, 0x43, 0x45, 0x52, 0x10, 0x01, 0x12, 0x26, 0x0a, 0x22, 0x4f,
+	0x55, 0x54, 0x42, 0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x53, 0x45,
+	0x52, 0x5f, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x5f, 0x52, 0x4f, 0x55, 0x54, 0x49, 0x4e,
+	0x47, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x4f, 0x55, 0x54, 0x42, 0x4f, 0x55, 0x4e, 0x44, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x03, 0x42, 0x5a, 0x5a, 0x58, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x2f,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b, 0x73, 0x2d, 0x6e,
+	0x6f, 0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_aksnodeconfig_v1_outbound_config_proto_rawDescOnce sync.Once
+	file_aksnodeconfig_v1_outbound_config_proto_rawDescData = file_aksnodeconfig_v1_outbound_config_proto_rawDesc
+)
+
+func file_aksnodeconfig_v1_outbound_config_proto_rawDescGZIP() []byte {
+	file_aksnodeconfig_v1_outbound_config_proto_rawDescOnce.Do(func() {
+		file_aksnodeconfig_v1_outbound_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_aksnodeconfig_v1_outbound_config_proto_rawDescData)
+	})
+	return file_aksnodeconfig_v1_outbound_config_proto_rawDescData
+}
+
+var file_aksnodeconfig_v1_outbound_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_aksnodeconfig_v1_outbound_config_proto_goTypes = []any{
+	(OutboundType)(0), // 0: aksnodeconfig.v1.OutboundType
+}
+var file_aksnodeconfig_v1_outbound_config_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_aksnodeconfig_v1_outbound_config_proto_init() }
+func file_aksnodeconfig_v1_outbound_config_proto_init() {
+	if File_aksnodeconfig_v1_outbound_config_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aksnodeconfig_v1_outbound_config_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aksnodeconfig_v1_outbound_config_proto_goTypes,
+		DependencyIndexes: file_aksnodeconfig_v1_outbound_config_proto_depIdxs,
+		EnumInfos:         file_aksnodeconfig_v1_outbound_config_proto_enumTypes,
+	}.Build()
+	File_aksnodeconfig_v1_outbound_config_proto = out.File
+	file_aksnodeconfig_v1_outbound_config_proto_rawDesc = nil
+	file_aksnodeconfig_v1_outbound_config_proto_goTypes = nil
+	file_aksnodeconfig_v1_outbound_config_proto_depIdxs = nil
+}
@@ -0,0 +1,232 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: aksnodeconfig/v1/registry_mirror_config.proto
+
+package aksnodeconfigv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RegistryMirror struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Registry the mirror applies to, e.g. "docker.io" or "mcr.microsoft.com". "_default" applies to every registry without its own mirror.
+	Registry string `protobuf:"bytes,1,opt,name=registry,proto3" json:"registry,omitempty"`
+	// Endpoints images of the registry are pulled from, tried in order. The registry itself is used when all of them fail.
+	Endpoints []*RegistryMirrorEndpoint `protobuf:"bytes,2,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+}
+
+func (x *RegistryMirror) Reset() {
+	*x = RegistryMirror{}
+	mi := &file_aksnodeconfig_v1_registry_mirror_config_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistryMirror) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistryMirror) ProtoMessage() {}
+
+func (x *RegistryMirror) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_registry_mirror_config_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistryMirror.ProtoReflect.Descriptor instead.
+func (*RegistryMirror) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_registry_mirror_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RegistryMirror) GetRegistry() string {
+	if x != nil {
+		return x.Registry
+	}
+	return ""
+}
+
+func (x *RegistryMirror) GetEndpoints() []*RegistryMirrorEndpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+type RegistryMirrorEndpoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// URL of the endpoint, e.g. "https://myacr.azurecr.io".
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Repository prefix the registry is mirrored under, e.g. "mcr" pulls "mcr.microsoft.com/oss/pause" from "<url>/mcr/oss/pause".
+	RewritePrefix string `protobuf:"bytes,2,opt,name=rewrite_prefix,json=rewritePrefix,proto3" json:"rewrite_prefix,omitempty"`
+	// PEM encoded CA bundle trusted for the endpoint in addition to the system CAs.
+	CaBundle string `protobuf:"bytes,3,opt,name=ca_bundle,json=caBundle,proto3" json:"ca_bundle,omitempty"`
+	// Specifies whether TLS certificate verification is skipped for the endpoint.
+	SkipVerify bool `protobuf:"varint,4,opt,name=skip_verify,json=skipVerify,proto3" json:"skip_verify,omitempty"`
+}
+
+func (x *RegistryMirrorEndpoint) Reset() {
+	*x = RegistryMirrorEndpoint{}
+	mi := &file_aksnodeconfig_v1_registry_mirror_config_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegistryMirrorEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegistryMirrorEndpoint) ProtoMessage() {}
+
+func (x *RegistryMirrorEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_registry_mirror_config_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegistryMirrorEndpoint.ProtoReflect.Descriptor instead.
+func (*RegistryMirrorEndpoint) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_registry_mirror_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RegistryMirrorEndpoint) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *RegistryMirrorEndpoint) GetRewritePrefix() string {
+	if x != nil {
+		return x.RewritePrefix
+	}
+	return ""
+}
+
+func (x *RegistryMirrorEndpoint) GetCaBundle() string {
+	if x != nil {
+		return x.CaBundle
+	}
+	return ""
+}
+
+func (x *RegistryMirrorEndpoint) GetSkipVerify() bool {
+	if x != nil {
+		return x.SkipVerify
+	}
+	return false
+}
+
+var File_aksnodeconfig_v1_registry_mirror_config_proto protoreflect.FileDescriptor
+
+var file_aksnodeconfig_v1_registry_mirror_config_proto_rawDesc = []byte{
+	0x0a, 0x2d, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x76, 0x31, 0x2f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x5f, 0x6d, 0x69, 0x72, 0x72,
+	0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x10, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76,
+	0x31, 0x22, 0x74, 0x0a, 0x0e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x4d, 0x69, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x12,
+	0x46, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x79, 0x4d, 0x69,
+	0x72, 0x72, 0x6f, 0x72, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x09, 0x65, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x8f, 0x01, 0x0a, 0x16, 0x52, 0x65, 0x67, 0x69,
+	0x73, 0x74, 0x72, 0x79, 0x4d, 0x69, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x75, 0x72, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f,
+	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65,
+	0x77, 0x72, 0x69, 0x74, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x1b, 0x0a, 0x09, 0x63,
+	0x61, 0x5f, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x63, 0x61, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6b, 0x69, 0x70,
+	0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73,
+	0x6b, 0x69, 0x70, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b, 0x73, 0x2d, 0x6e, 0x6f, 0x64,
+	0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67,
+	0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_aksnodeconfig_v1_registry_mirror_config_proto_rawDescOnce sync.Once
+	file_aksnodeconfig_v1_registry_mirror_config_proto_rawDescData = file_aksnodeconfig_v1_registry_mirror_config_proto_rawDesc
+)
+
+func file_aksnodeconfig_v1_registry_mirror_config_proto_rawDescGZIP() []byte {
+	file_aksnodeconfig_v1_registry_mirror_config_proto_rawDescOnce.Do(func() {
+		file_aksnodeconfig_v1_registry_mirror_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_aksnodeconfig_v1_registry_mirror_config_proto_rawDescData)
+	})
+	return file_aksnodeconfig_v1_registry_mirror_config_proto_rawDescData
+}
+
+var file_aksnodeconfig_v1_registry_mirror_config_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_aksnodeconfig_v1_registry_mirror_config_proto_goTypes = []any{
+	(*RegistryMirror)(nil),         // 0: aksnodeconfig.v1.RegistryMirror
+	(*RegistryMirrorEndpoint)(nil), // 1: aksnodeconfig.v1.RegistryMirrorEndpoint
+}
+var file_aksnodeconfig_v1_registry_mirror_config_proto_depIdxs = []int32{
+	1, // 0: aksnodeconfig.v1.RegistryMirror.endpoints:type_name -> aksnodeconfig.v1.RegistryMirrorEndpoint
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_aksnodeconfig_v1_registry_mirror_config_proto_init() }
+func file_aksnodeconfig_v1_registry_mirror_config_proto_init() {
+	if File_aksnodeconfig_v1_registry_mirror_config_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aksnodeconfig_v1_registry_mirror_config_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aksnodeconfig_v1_registry_mirror_config_proto_goTypes,
+		DependencyIndexes: file_aksnodeconfig_v1_registry_mirror_config_proto_depIdxs,
+		MessageInfos:      file_aksnodeconfig_v1_registry_mirror_config_proto_msgTypes,
+	}.Build()
+	File_aksnodeconfig_v1_registry_mirror_config_proto = out.File
+	file_aksnodeconfig_v1_registry_mirror_config_proto_rawDesc = nil
+	file_aksnodeconfig_v1_registry_mirror_config_proto_goTypes = nil
+	file_aksnodeconfig_v1_registry_mirror_config_proto_depIdxs = nil
+}
@@ -0,0 +1,275 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: aksnodeconfig/v1/resource_manager_policy.proto
+
+package aksnodeconfigv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CpuManagerPolicy int32
+
+const (
+	// The kubelet default, none, unless --cpu-manager-policy is set.
+	CpuManagerPolicy_CPU_MANAGER_POLICY_UNSPECIFIED CpuManagerPolicy = 0
+	CpuManagerPolicy_CPU_MANAGER_POLICY_NONE        CpuManagerPolicy = 1
+	// Guaranteed pods with integer CPU requests get exclusive CPUs.
+	CpuManagerPolicy_CPU_MANAGER_POLICY_STATIC CpuManagerPolicy = 2
+)
+
+// Enum value maps for CpuManagerPolicy.
+var (
+	CpuManagerPolicy_name = map[int32]string{
+		0: "CPU_MANAGER_POLICY_UNSPECIFIED",
+		1: "CPU_MANAGER_POLICY_NONE",
+		2: "CPU_MANAGER_POLICY_STATIC",
+	}
+	CpuManagerPolicy_value = map[string]int32{
+		"CPU_MANAGER_POLICY_UNSPECIFIED": 0,
+		"CPU_MANAGER_POLICY_NONE":        1,
+		"CPU_MANAGER_POLICY_STATIC":      2,
+	}
+)
+
+func (x CpuManagerPolicy) Enum() *CpuManagerPolicy {
+	p := new(CpuManagerPolicy)
+	*p = x
+	return p
+}
+
+func (x CpuManagerPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CpuManagerPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_resource_manager_policy_proto_enumTypes[0].Descriptor()
+}
+
+func (CpuManagerPolicy) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_resource_manager_policy_proto_enumTypes[0]
+}
+
+func (x CpuManagerPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CpuManagerPolicy.Descriptor instead.
+func (CpuManagerPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_resource_manager_policy_proto_rawDescGZIP(), []int{0}
+}
+
+type TopologyManagerPolicy int32
+
+const (
+	// The kubelet default, none, unless --topology-manager-policy is set.
+	TopologyManagerPolicy_TOPOLOGY_MANAGER_POLICY_UNSPECIFIED      TopologyManagerPolicy = 0
+	TopologyManagerPolicy_TOPOLOGY_MANAGER_POLICY_NONE             TopologyManagerPolicy = 1
+	TopologyManagerPolicy_TOPOLOGY_MANAGER_POLICY_BEST_EFFORT      TopologyManagerPolicy = 2
+	TopologyManagerPolicy_TOPOLOGY_MANAGER_POLICY_RESTRICTED       TopologyManagerPolicy = 3
+	TopologyManagerPolicy_TOPOLOGY_MANAGER_POLICY_SINGLE_NUMA_NODE TopologyManagerPolicy = 4
+)
+
+// Enum value maps for TopologyManagerPolicy.
+var (
+	TopologyManagerPolicy_name = map[int32]string{
+		0: "TOPOLOGY_MANAGER_POLICY_UNSPECIFIED",
+		1: "TOPOLOGY_MANAGER_POLICY_NONE",
+		2: "TOPOLOGY_MANAGER_POLICY_BEST_EFFORT",
+		3: "TOPOLOGY_MANAGER_POLICY_RESTRICTED",
+		4: "TOPOLOGY_MANAGER_POLICY_SINGLE_NUMA_NODE",
+	}
+	TopologyManagerPolicy_value = map[string]int32{
+		"TOPOLOGY_MANAGER_POLICY_UNSPECIFIED":      0,
+		"TOPOLOGY_MANAGER_POLICY_NONE":             1,
+		"TOPOLOGY_MANAGER_POLICY_BEST_EFFORT":      2,
+		"TOPOLOGY_MANAGER_POLICY_RESTRICTED":       3,
+		"TOPOLOGY_MANAGER_POLICY_SINGLE_NUMA_NODE": 4,
+	}
+)
+
+func (x TopologyManagerPolicy) Enum() *TopologyManagerPolicy {
+	p := new(TopologyManagerPolicy)
+	*p = x
+	return p
+}
+
+func (x TopologyManagerPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TopologyManagerPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_resource_manager_policy_proto_enumTypes[1].Descriptor()
+}
+
+func (TopologyManagerPolicy) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_resource_manager_policy_proto_enumTypes[1]
+}
+
+func (x TopologyManagerPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TopologyManagerPolicy.Descriptor instead.
+func (TopologyManagerPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_resource_manager_policy_proto_rawDescGZIP(), []int{1}
+}
+
+type MemoryManagerPolicy int32
+
+const (
+	// The kubelet default, None, unless --memory-manager-policy is set.
+	MemoryManagerPolicy_MEMORY_MANAGER_POLICY_UNSPECIFIED MemoryManagerPolicy = 0
+	MemoryManagerPolicy_MEMORY_MANAGER_POLICY_NONE        MemoryManagerPolicy = 1
+	// Guaranteed pods get memory from the NUMA nodes chosen by the topology manager.
+	MemoryManagerPolicy_MEMORY_MANAGER_POLICY_STATIC MemoryManagerPolicy = 2
+)
+
+// Enum value maps for MemoryManagerPolicy.
+var (
+	MemoryManagerPolicy_name = map[int32]string{
+		0: "MEMORY_MANAGER_POLICY_UNSPECIFIED",
+		1: "MEMORY_MANAGER_POLICY_NONE",
+		2: "MEMORY_MANAGER_POLICY_STATIC",
+	}
+	MemoryManagerPolicy_value = map[string]int32{
+		"MEMORY_MANAGER_POLICY_UNSPECIFIED": 0,
+		"MEMORY_MANAGER_POLICY_NONE":        1,
+		"MEMORY_MANAGER_POLICY_STATIC":      2,
+	}
+)
+
+func (x MemoryManagerPolicy) Enum() *MemoryManagerPolicy {
+	p := new(MemoryManagerPolicy)
+	*p = x
+	return p
+}
+
+func (x MemoryManagerPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MemoryManagerPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_resource_manager_policy_proto_enumTypes[2].Descriptor()
+}
+
+func (MemoryManagerPolicy) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_resource_manager_policy_proto_enumTypes[2]
+}
+
+func (x MemoryManagerPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MemoryManagerPolicy.Descriptor instead.
+func (MemoryManagerPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_resource_manager_policy_proto_rawDescGZIP(), []int{2}
+}
+
+var File_aksnodeconfig_v1_resource_manager_policy_proto protoreflect.FileDescriptor
+
+var file_aksnodeconfig_v1_resource_manager_policy_proto_rawDesc = []byte{
+	0x0a, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x76, 0x31, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x10, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x76, 0x31, 0x2a, 0x72, 0x0a, 0x10, 0x43, 0x70, 0x75, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x22, 0x0a, 0x1e, 0x43, 0x50, 0x55, 0x5f, 0x4d, 0x41,
+	0x4e, 0x41, 0x47, 0x45, 0x52, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x50,
+	0x55, 0x5f, 0x4d, 0x41, 0x4e, 0x41, 0x47, 0x45, 0x52, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59,
+	0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x43, 0x50, 0x55, 0x5f, 0x4d,
+	0x41, 0x4e, 0x41, 0x47, 0x45, 0x52, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x49, 0x43, 0x10, 0x02, 0x2a, 0xe1, 0x01, 0x0a, 0x15, 0x54, 0x6f, 0x70, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x27, 0x0a, 0x23, 0x54, 0x4f, 0x50, 0x4f, 0x4c, 0x4f, 0x47, 0x59, 0x5f, 0x4d, 0x41, 0x4e,
+	0x41, 0x47, 0x45, 0x52, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x20, 0x0a, 0x1c, 0x54, 0x4f, 0x50,
+	0x4f, 0x4c, 0x4f, 0x47, 0x59, 0x5f, 0x4d, 0x41, 0x4e, 0x41, 0x47, 0x45, 0x52, 0x5f, 0x50, 0x4f,
+	0x4c, 0x49, 0x43, 0x59, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x27, 0x0a, 0x23, 0x54,
+	0x4f, 0x50, 0x4f, 0x4c, 0x4f, 0x47, 0x59, 0x5f, 0x4d, 0x41, 0x4e, 0x41, 0x47, 0x45, 0x52, 0x5f,
+	0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x42, 0x45, 0x53, 0x54, 0x5f, 0x45, 0x46, 0x46, 0x4f,
+	0x52, 0x54, 0x10, 0x02, 0x12, 0x26, 0x0a, 0x22, 0x54, 0x4f, 0x50, 0x4f, 0x4c, 0x4f, 0x47, 0x59,
+	0x5f, 0x4d, 0x41, 0x4e, 0x41, 0x47, 0x45, 0x52, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f,
+	0x52, 0x45, 0x53, 0x54, 0x52, 0x49, 0x43, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x2c, 0x0a, 0x28,
+	0x54, 0x4f, 0x50, 0x4f, 0x4c, 0x4f, 0x47, 0x59, 0x5f, 0x4d, 0x41, 0x4e, 0x41, 0x47, 0x45, 0x52,
+	0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x53, 0x49, 0x4e, 0x47, 0x4c, 0x45, 0x5f, 0x4e,
+	0x55, 0x4d, 0x41, 0x5f, 0x4e, 0x4f, 0x44, 0x45, 0x10, 0x04, 0x2a, 0x7e, 0x0a, 0x13, 0x4d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x12, 0x25, 0x0a, 0x21, 0x4d, 0x45, 0x4d, 0x4f, 0x52, 0x59, 0x5f, 0x4d, 0x41, 0x4e, 0x41,
+	0x47, 0x45, 0x52, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x4d, 0x45, 0x4d, 0x4f,
+	0x52, 0x59, 0x5f, 0x4d, 0x41, 0x4e, 0x41, 0x47, 0x45, 0x52, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43,
+	0x59, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x4d, 0x45, 0x4d, 0x4f,
+	0x52, 0x59, 0x5f, 0x4d, 0x41, 0x4e, 0x41, 0x47, 0x45, 0x52, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43,
+	0x59, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x49, 0x43, 0x10, 0x02, 0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b, 0x73, 0x2d, 0x6e, 0x6f,
+	0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x70, 0x6b,
+	0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_aksnodeconfig_v1_resource_manager_policy_proto_rawDescOnce sync.Once
+	file_aksnodeconfig_v1_resource_manager_policy_proto_rawDescData = file_aksnodeconfig_v1_resource_manager_policy_proto_rawDesc
+)
+
+func file_aksnodeconfig_v1_resource_manager_policy_proto_rawDescGZIP() []byte {
+	file_aksnodeconfig_v1_resource_manager_policy_proto_rawDescOnce.Do(func() {
+		file_aksnodeconfig_v1_resource_manager_policy_proto_rawDescData = protoimpl.X.CompressGZIP(file_aksnodeconfig_v1_resource_manager_policy_proto_rawDescData)
+	})
+	return file_aksnodeconfig_v1_resource_manager_policy_proto_rawDescData
+}
+
+var file_aksnodeconfig_v1_resource_manager_policy_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_aksnodeconfig_v1_resource_manager_policy_proto_goTypes = []any{
+	(CpuManagerPolicy)(0),      // 0: aksnodeconfig.v1.CpuManagerPolicy
+	(TopologyManagerPolicy)(0), // 1: aksnodeconfig.v1.TopologyManagerPolicy
+	(MemoryManagerPolicy)(0),   // 2: aksnodeconfig.v1.MemoryManagerPolicy
+}
+var file_aksnodeconfig_v1_resource_manager_policy_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_aksnodeconfig_v1_resource_manager_policy_proto_init() }
+func file_aksnodeconfig_v1_resource_manager_policy_proto_init() {
+	if File_aksnodeconfig_v1_resource_manager_policy_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aksnodeconfig_v1_resource_manager_policy_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aksnodeconfig_v1_resource_manager_policy_proto_goTypes,
+		DependencyIndexes: file_aksnodeconfig_v1_resource_manager_policy_proto_depIdxs,
+		EnumInfos:         file_aksnodeconfig_v1_resource_manager_policy_proto_enumTypes,
+	}.Build()
+	File_aksnodeconfig_v1_resource_manager_policy_proto = out.File
+	file_aksnodeconfig_v1_resource_manager_policy_proto_rawDesc = nil
+	file_aksnodeconfig_v1_resource_manager_policy_proto_goTypes = nil
+	file_aksnodeconfig_v1_resource_manager_policy_proto_depIdxs = nil
+}
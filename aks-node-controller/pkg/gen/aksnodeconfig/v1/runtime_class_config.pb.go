@@ -0,0 +1,220 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: aksnodeconfig/v1/runtime_class_config.proto
+
+package aksnodeconfigv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RuntimeClassType int32
+
+const (
+	RuntimeClassType_RUNTIME_CLASS_TYPE_UNSPECIFIED RuntimeClassType = 0
+	// Kata containers, pods run in lightweight VMs. Requires a VM size supporting nested virtualization.
+	RuntimeClassType_RUNTIME_CLASS_TYPE_KATA RuntimeClassType = 1
+	// Confidential Kata containers, pods run in AMD SEV-SNP protected VMs. Requires a confidential VM size.
+	RuntimeClassType_RUNTIME_CLASS_TYPE_KATA_CONFIDENTIAL RuntimeClassType = 2
+)
+
+// Enum value maps for RuntimeClassType.
+var (
+	RuntimeClassType_name = map[int32]string{
+		0: "RUNTIME_CLASS_TYPE_UNSPECIFIED",
+		1: "RUNTIME_CLASS_TYPE_KATA",
+		2: "RUNTIME_CLASS_TYPE_KATA_CONFIDENTIAL",
+	}
+	RuntimeClassType_value = map[string]int32{
+		"RUNTIME_CLASS_TYPE_UNSPECIFIED":       0,
+		"RUNTIME_CLASS_TYPE_KATA":              1,
+		"RUNTIME_CLASS_TYPE_KATA_CONFIDENTIAL": 2,
+	}
+)
+
+func (x RuntimeClassType) Enum() *RuntimeClassType {
+	p := new(RuntimeClassType)
+	*p = x
+	return p
+}
+
+func (x RuntimeClassType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RuntimeClassType) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_runtime_class_config_proto_enumTypes[0].Descriptor()
+}
+
+func (RuntimeClassType) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_runtime_class_config_proto_enumTypes[0]
+}
+
+func (x RuntimeClassType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RuntimeClassType.Descriptor instead.
+func (RuntimeClassType) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_runtime_class_config_proto_rawDescGZIP(), []int{0}
+}
+
+type RuntimeClass struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the containerd runtime handler, referenced by the handler of the Kubernetes RuntimeClass.
+	Handler string           `protobuf:"bytes,1,opt,name=handler,proto3" json:"handler,omitempty"`
+	Type    RuntimeClassType `protobuf:"varint,2,opt,name=type,proto3,enum=aksnodeconfig.v1.RuntimeClassType" json:"type,omitempty"`
+	// Path of the kata configuration file, the default of the runtime type is used when empty.
+	ConfigPath string `protobuf:"bytes,3,opt,name=config_path,json=configPath,proto3" json:"config_path,omitempty"`
+}
+
+func (x *RuntimeClass) Reset() {
+	*x = RuntimeClass{}
+	mi := &file_aksnodeconfig_v1_runtime_class_config_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuntimeClass) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuntimeClass) ProtoMessage() {}
+
+func (x *RuntimeClass) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_runtime_class_config_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuntimeClass.ProtoReflect.Descriptor instead.
+func (*RuntimeClass) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_runtime_class_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RuntimeClass) GetHandler() string {
+	if x != nil {
+		return x.Handler
+	}
+	return ""
+}
+
+func (x *RuntimeClass) GetType() RuntimeClassType {
+	if x != nil {
+		return x.Type
+	}
+	return RuntimeClassType_RUNTIME_CLASS_TYPE_UNSPECIFIED
+}
+
+func (x *RuntimeClass) GetConfigPath() string {
+	if x != nil {
+		return x.ConfigPath
+	}
+	return ""
+}
+
+var File_aksnodeconfig_v1_runtime_class_config_proto protoreflect.FileDescriptor
+
+var file_aksnodeconfig_v1_runtime_class_config_proto_rawDesc = []byte{
+	0x0a, 0x2b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x76, 0x31, 0x2f, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73,
+	0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x61,
+	0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x22,
+	0x81, 0x01, 0x0a, 0x0c, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f,
+	0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x74,
+	0x69, 0x6d, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x50,
+	0x61, 0x74, 0x68, 0x2a, 0x7d, 0x0a, 0x10, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x43, 0x6c,
+	0x61, 0x73, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x22, 0x0a, 0x1e, 0x52, 0x55, 0x4e, 0x54, 0x49,
+	0x4d, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x52,
+	0x55, 0x4e, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x4b, 0x41, 0x54, 0x41, 0x10, 0x01, 0x12, 0x28, 0x0a, 0x24, 0x52, 0x55, 0x4e, 0x54,
+	0x49, 0x4d, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4b,
+	0x41, 0x54, 0x41, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x44, 0x45, 0x4e, 0x54, 0x49, 0x41, 0x4c,
+	0x10, 0x02, 0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65,
+	0x72, 0x2f, 0x61, 0x6b, 0x73, 0x2d, 0x6e, 0x6f, 0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b,
+	0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61,
+	0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_aksnodeconfig_v1_runtime_class_config_proto_rawDescOnce sync.Once
+	file_aksnodeconfig_v1_runtime_class_config_proto_rawDescData = file_aksnodeconfig_v1_runtime_class_config_proto_rawDesc
+)
+
+func file_aksnodeconfig_v1_runtime_class_config_proto_rawDescGZIP() []byte {
+	file_aksnodeconfig_v1_runtime_class_config_proto_rawDescOnce.Do(func() {
+		file_aksnodeconfig_v1_runtime_class_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_aksnodeconfig_v1_runtime_class_config_proto_rawDescData)
+	})
+	return file_aksnodeconfig_v1_runtime_class_config_proto_rawDescData
+}
+
+var file_aksnodeconfig_v1_runtime_class_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_aksnodeconfig_v1_runtime_class_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_aksnodeconfig_v1_runtime_class_config_proto_goTypes = []any{
+	(RuntimeClassType)(0), // 0: aksnodeconfig.v1.RuntimeClassType
+	(*RuntimeClass)(nil),  // 1: aksnodeconfig.v1.RuntimeClass
+}
+var file_aksnodeconfig_v1_runtime_class_config_proto_depIdxs = []int32{
+	0, // 0: aksnodeconfig.v1.RuntimeClass.type:type_name -> aksnodeconfig.v1.RuntimeClassType
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_aksnodeconfig_v1_runtime_class_config_proto_init() }
+func file_aksnodeconfig_v1_runtime_class_config_proto_init() {
+	if File_aksnodeconfig_v1_runtime_class_config_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aksnodeconfig_v1_runtime_class_config_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aksnodeconfig_v1_runtime_class_config_proto_goTypes,
+		DependencyIndexes: file_aksnodeconfig_v1_runtime_class_config_proto_depIdxs,
+		EnumInfos:         file_aksnodeconfig_v1_runtime_class_config_proto_enumTypes,
+		MessageInfos:      file_aksnodeconfig_v1_runtime_class_config_proto_msgTypes,
+	}.Build()
+	File_aksnodeconfig_v1_runtime_class_config_proto = out.File
+	file_aksnodeconfig_v1_runtime_class_config_proto_rawDesc = nil
+	file_aksnodeconfig_v1_runtime_class_config_proto_goTypes = nil
+	file_aksnodeconfig_v1_runtime_class_config_proto_depIdxs = nil
+}
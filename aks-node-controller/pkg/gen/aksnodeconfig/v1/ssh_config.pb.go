@@ -0,0 +1,215 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: aksnodeconfig/v1/ssh_config.proto
+
+package aksnodeconfigv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SshAccessMode int32
+
+const (
+	// enable_ssh decides whether sshd runs, with the sshd configuration of the node image.
+	SshAccessMode_SSH_ACCESS_MODE_UNSPECIFIED SshAccessMode = 0
+	// Only public key authentication, password and keyboard-interactive authentication are disabled.
+	SshAccessMode_SSH_ACCESS_MODE_PUBLIC_KEY SshAccessMode = 1
+	// Microsoft Entra ID (AAD) login with certificates issued for the users, through the aadsshlogin package of the
+	// node image. Public keys keep working.
+	SshAccessMode_SSH_ACCESS_MODE_ENTRA_ID SshAccessMode = 2
+	// sshd is stopped and disabled.
+	SshAccessMode_SSH_ACCESS_MODE_DISABLED SshAccessMode = 3
+)
+
+// Enum value maps for SshAccessMode.
+var (
+	SshAccessMode_name = map[int32]string{
+		0: "SSH_ACCESS_MODE_UNSPECIFIED",
+		1: "SSH_ACCESS_MODE_PUBLIC_KEY",
+		2: "SSH_ACCESS_MODE_ENTRA_ID",
+		3: "SSH_ACCESS_MODE_DISABLED",
+	}
+	SshAccessMode_value = map[string]int32{
+		"SSH_ACCESS_MODE_UNSPECIFIED": 0,
+		"SSH_ACCESS_MODE_PUBLIC_KEY":  1,
+		"SSH_ACCESS_MODE_ENTRA_ID":    2,
+		"SSH_ACCESS_MODE_DISABLED":    3,
+	}
+)
+
+func (x SshAccessMode) Enum() *SshAccessMode {
+	p := new(SshAccessMode)
+	*p = x
+	return p
+}
+
+func (x SshAccessMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SshAccessMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_ssh_config_proto_enumTypes[0].Descriptor()
+}
+
+func (SshAccessMode) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_ssh_config_proto_enumTypes[0]
+}
+
+func (x SshAccessMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SshAccessMode.Descriptor instead.
+func (SshAccessMode) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_ssh_config_proto_rawDescGZIP(), []int{0}
+}
+
+type SshConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mode SshAccessMode `protobuf:"varint,1,opt,name=mode,proto3,enum=aksnodeconfig.v1.SshAccessMode" json:"mode,omitempty"`
+	// Source CIDRs sshd accepts logins from, any source when empty.
+	AllowedCidrs []string `protobuf:"bytes,2,rep,name=allowed_cidrs,json=allowedCidrs,proto3" json:"allowed_cidrs,omitempty"`
+}
+
+func (x *SshConfig) Reset() {
+	*x = SshConfig{}
+	mi := &file_aksnodeconfig_v1_ssh_config_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SshConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SshConfig) ProtoMessage() {}
+
+func (x *SshConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_ssh_config_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SshConfig.ProtoReflect.Descriptor instead.
+func (*SshConfig) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_ssh_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SshConfig) GetMode() SshAccessMode {
+	if x != nil {
+		return x.Mode
+	}
+	return SshAccessMode_SSH_ACCESS_MODE_UNSPECIFIED
+}
+
+func (x *SshConfig) GetAllowedCidrs() []string {
+	if x != nil {
+		return x.AllowedCidrs
+	}
+	return nil
+}
+
+var File_aksnodeconfig_v1_ssh_config_proto protoreflect.FileDescriptor
+
+var file_aksnodeconfig_v1_ssh_config_proto_rawDesc = []byte{
+	0x0a, 0x21, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x76, 0x31, 0x2f, 0x73, 0x73, 0x68, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x10, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x76, 0x31, 0x22, 0x65, 0x0a, 0x09, 0x53, 0x73, 0x68, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x33, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1f, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x73, 0x68, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
+	0x65, 0x64, 0x5f, 0x63, 0x69, 0x64, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x43, 0x69, 0x64, 0x72, 0x73, 0x2a, 0x8c, 0x01, 0x0a,
+	0x0d, 0x53, 0x73, 0x68, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1f,
+	0x0a, 0x1b, 0x53, 0x53, 0x48, 0x5f, 0x41, 0x43, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x4d, 0x4f, 0x44,
+	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x1e, 0x0a, 0x1a, 0x53, 0x53, 0x48, 0x5f, 0x41, 0x43, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x4d, 0x4f,
+	0x44, 0x45, 0x5f, 0x50, 0x55, 0x42, 0x4c, 0x49, 0x43, 0x5f, 0x4b, 0x45, 0x59, 0x10, 0x01, 0x12,
+	0x1c, 0x0a, 0x18, 0x53, 0x53, 0x48, 0x5f, 0x41, 0x43, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x4d, 0x4f,
+	0x44, 0x45, 0x5f, 0x45, 0x4e, 0x54, 0x52, 0x41, 0x5f, 0x49, 0x44, 0x10, 0x02, 0x12, 0x1c, 0x0a,
+	0x18, 0x53, 0x53, 0x48, 0x5f, 0x41, 0x43, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
+	0x5f, 0x44, 0x49, 0x53, 0x41, 0x42, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x42, 0x5a, 0x5a, 0x58, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x2f,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b, 0x73, 0x2d, 0x6e,
+	0x6f, 0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_aksnodeconfig_v1_ssh_config_proto_rawDescOnce sync.Once
+	file_aksnodeconfig_v1_ssh_config_proto_rawDescData = file_aksnodeconfig_v1_ssh_config_proto_rawDesc
+)
+
+func file_aksnodeconfig_v1_ssh_config_proto_rawDescGZIP() []byte {
+	file_aksnodeconfig_v1_ssh_config_proto_rawDescOnce.Do(func() {
+		file_aksnodeconfig_v1_ssh_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_aksnodeconfig_v1_ssh_config_proto_rawDescData)
+	})
+	return file_aksnodeconfig_v1_ssh_config_proto_rawDescData
+}
+
+var file_aksnodeconfig_v1_ssh_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_aksnodeconfig_v1_ssh_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_aksnodeconfig_v1_ssh_config_proto_goTypes = []any{
+	(SshAccessMode)(0), // 0: aksnodeconfig.v1.SshAccessMode
+	(*SshConfig)(nil),  // 1: aksnodeconfig.v1.SshConfig
+}
+var file_aksnodeconfig_v1_ssh_config_proto_depIdxs = []int32{
+	0, // 0: aksnodeconfig.v1.SshConfig.mode:type_name -> aksnodeconfig.v1.SshAccessMode
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_aksnodeconfig_v1_ssh_config_proto_init() }
+func file_aksnodeconfig_v1_ssh_config_proto_init() {
+	if File_aksnodeconfig_v1_ssh_config_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aksnodeconfig_v1_ssh_config_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aksnodeconfig_v1_ssh_config_proto_goTypes,
+		DependencyIndexes: file_aksnodeconfig_v1_ssh_config_proto_depIdxs,
+		EnumInfos:         file_aksnodeconfig_v1_ssh_config_proto_enumTypes,
+		MessageInfos:      file_aksnodeconfig_v1_ssh_config_proto_msgTypes,
+	}.Build()
+	File_aksnodeconfig_v1_ssh_config_proto = out.File
+	file_aksnodeconfig_v1_ssh_config_proto_rawDesc = nil
+	file_aksnodeconfig_v1_ssh_config_proto_goTypes = nil
+	file_aksnodeconfig_v1_ssh_config_proto_depIdxs = nil
+}
@@ -0,0 +1,283 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: aksnodeconfig/v1/swap_config.proto
+
+package aksnodeconfigv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SwapBackend int32
+
+const (
+	// No swap is configured by aks-node-controller.
+	SwapBackend_SWAP_BACKEND_UNSPECIFIED SwapBackend = 0
+	// A swap file on the OS disk.
+	SwapBackend_SWAP_BACKEND_FILE SwapBackend = 1
+	// A compressed swap device in memory.
+	SwapBackend_SWAP_BACKEND_ZRAM SwapBackend = 2
+)
+
+// Enum value maps for SwapBackend.
+var (
+	SwapBackend_name = map[int32]string{
+		0: "SWAP_BACKEND_UNSPECIFIED",
+		1: "SWAP_BACKEND_FILE",
+		2: "SWAP_BACKEND_ZRAM",
+	}
+	SwapBackend_value = map[string]int32{
+		"SWAP_BACKEND_UNSPECIFIED": 0,
+		"SWAP_BACKEND_FILE":        1,
+		"SWAP_BACKEND_ZRAM":        2,
+	}
+)
+
+func (x SwapBackend) Enum() *SwapBackend {
+	p := new(SwapBackend)
+	*p = x
+	return p
+}
+
+func (x SwapBackend) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SwapBackend) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_swap_config_proto_enumTypes[0].Descriptor()
+}
+
+func (SwapBackend) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_swap_config_proto_enumTypes[0]
+}
+
+func (x SwapBackend) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SwapBackend.Descriptor instead.
+func (SwapBackend) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_swap_config_proto_rawDescGZIP(), []int{0}
+}
+
+type SwapBehavior int32
+
+const (
+	// Same as SWAP_BEHAVIOR_LIMITED_SWAP.
+	SwapBehavior_SWAP_BEHAVIOR_UNSPECIFIED SwapBehavior = 0
+	// The node has swap but pods don't use it.
+	SwapBehavior_SWAP_BEHAVIOR_NO_SWAP SwapBehavior = 1
+	// Burstable pods can use swap in proportion to their memory request.
+	SwapBehavior_SWAP_BEHAVIOR_LIMITED_SWAP SwapBehavior = 2
+)
+
+// Enum value maps for SwapBehavior.
+var (
+	SwapBehavior_name = map[int32]string{
+		0: "SWAP_BEHAVIOR_UNSPECIFIED",
+		1: "SWAP_BEHAVIOR_NO_SWAP",
+		2: "SWAP_BEHAVIOR_LIMITED_SWAP",
+	}
+	SwapBehavior_value = map[string]int32{
+		"SWAP_BEHAVIOR_UNSPECIFIED":  0,
+		"SWAP_BEHAVIOR_NO_SWAP":      1,
+		"SWAP_BEHAVIOR_LIMITED_SWAP": 2,
+	}
+)
+
+func (x SwapBehavior) Enum() *SwapBehavior {
+	p := new(SwapBehavior)
+	*p = x
+	return p
+}
+
+func (x SwapBehavior) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SwapBehavior) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_swap_config_proto_enumTypes[1].Descriptor()
+}
+
+func (SwapBehavior) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_swap_config_proto_enumTypes[1]
+}
+
+func (x SwapBehavior) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SwapBehavior.Descriptor instead.
+func (SwapBehavior) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_swap_config_proto_rawDescGZIP(), []int{1}
+}
+
+// Swap of the node and its use by pods through the kubelet NodeSwap feature. Requires Kubernetes 1.30 or later and
+// cgroup v2.
+type SwapConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Backend SwapBackend `protobuf:"varint,1,opt,name=backend,proto3,enum=aksnodeconfig.v1.SwapBackend" json:"backend,omitempty"`
+	// Size of the swap file or zram device in MB.
+	SizeMb              int32        `protobuf:"varint,2,opt,name=size_mb,json=sizeMb,proto3" json:"size_mb,omitempty"`
+	KubeletSwapBehavior SwapBehavior `protobuf:"varint,3,opt,name=kubelet_swap_behavior,json=kubeletSwapBehavior,proto3,enum=aksnodeconfig.v1.SwapBehavior" json:"kubelet_swap_behavior,omitempty"`
+}
+
+func (x *SwapConfig) Reset() {
+	*x = SwapConfig{}
+	mi := &file_aksnodeconfig_v1_swap_config_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwapConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapConfig) ProtoMessage() {}
+
+func (x *SwapConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_swap_config_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapConfig.ProtoReflect.Descriptor instead.
+func (*SwapConfig) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_swap_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SwapConfig) GetBackend() SwapBackend {
+	if x != nil {
+		return x.Backend
+	}
+	return SwapBackend_SWAP_BACKEND_UNSPECIFIED
+}
+
+func (x *SwapConfig) GetSizeMb() int32 {
+	if x != nil {
+		return x.SizeMb
+	}
+	return 0
+}
+
+func (x *SwapConfig) GetKubeletSwapBehavior() SwapBehavior {
+	if x != nil {
+		return x.KubeletSwapBehavior
+	}
+	return SwapBehavior_SWAP_BEHAVIOR_UNSPECIFIED
+}
+
+var File_aksnodeconfig_v1_swap_config_proto protoreflect.FileDescriptor
+
+var file_aksnodeconfig_v1_swap_config_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x76, 0x31, 0x2f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x22, 0xb2, 0x01, 0x0a, 0x0a, 0x53, 0x77, 0x61, 0x70, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x37, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x42, 0x61,
+	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12, 0x17,
+	0x0a, 0x07, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x6d, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x73, 0x69, 0x7a, 0x65, 0x4d, 0x62, 0x12, 0x52, 0x0a, 0x15, 0x6b, 0x75, 0x62, 0x65, 0x6c,
+	0x65, 0x74, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x42, 0x65,
+	0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x52, 0x13, 0x6b, 0x75, 0x62, 0x65, 0x6c, 0x65, 0x74, 0x53,
+	0x77, 0x61, 0x70, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2a, 0x59, 0x0a, 0x0b, 0x53,
+	0x77, 0x61, 0x70, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x57,
+	0x41, 0x50, 0x5f, 0x42, 0x41, 0x43, 0x4b, 0x45, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x57, 0x41, 0x50,
+	0x5f, 0x42, 0x41, 0x43, 0x4b, 0x45, 0x4e, 0x44, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x01, 0x12,
+	0x15, 0x0a, 0x11, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x42, 0x41, 0x43, 0x4b, 0x45, 0x4e, 0x44, 0x5f,
+	0x5a, 0x52, 0x41, 0x4d, 0x10, 0x02, 0x2a, 0x68, 0x0a, 0x0c, 0x53, 0x77, 0x61, 0x70, 0x42, 0x65,
+	0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x19, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x42,
+	0x45, 0x48, 0x41, 0x56, 0x49, 0x4f, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x42, 0x45,
+	0x48, 0x41, 0x56, 0x49, 0x4f, 0x52, 0x5f, 0x4e, 0x4f, 0x5f, 0x53, 0x57, 0x41, 0x50, 0x10, 0x01,
+	0x12, 0x1e, 0x0a, 0x1a, 0x53, 0x57, 0x41, 0x50, 0x5f, 0x42, 0x45, 0x48, 0x41, 0x56, 0x49, 0x4f,
+	0x52, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x45, 0x44, 0x5f, 0x53, 0x57, 0x41, 0x50, 0x10, 0x02,
+	0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41,
+	0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f,
+	0x61, 0x6b, 0x73, 0x2d, 0x6e, 0x6f, 0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e,
+	0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73,
+	0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_aksnodeconfig_v1_swap_config_proto_rawDescOnce sync.Once
+	file_aksnodeconfig_v1_swap_config_proto_rawDescData = file_aksnodeconfig_v1_swap_config_proto_rawDesc
+)
+
+func file_aksnodeconfig_v1_swap_config_proto_rawDescGZIP() []byte {
+	file_aksnodeconfig_v1_swap_config_proto_rawDescOnce.Do(func() {
+		file_aksnodeconfig_v1_swap_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_aksnodeconfig_v1_swap_config_proto_rawDescData)
+	})
+	return file_aksnodeconfig_v1_swap_config_proto_rawDescData
+}
+
+var file_aksnodeconfig_v1_swap_config_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_aksnodeconfig_v1_swap_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_aksnodeconfig_v1_swap_config_proto_goTypes = []any{
+	(SwapBackend)(0),   // 0: aksnodeconfig.v1.SwapBackend
+	(SwapBehavior)(0),  // 1: aksnodeconfig.v1.SwapBehavior
+	(*SwapConfig)(nil), // 2: aksnodeconfig.v1.SwapConfig
+}
+var file_aksnodeconfig_v1_swap_config_proto_depIdxs = []int32{
+	0, // 0: aksnodeconfig.v1.SwapConfig.backend:type_name -> aksnodeconfig.v1.SwapBackend
+	1, // 1: aksnodeconfig.v1.SwapConfig.kubelet_swap_behavior:type_name -> aksnodeconfig.v1.SwapBehavior
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_aksnodeconfig_v1_swap_config_proto_init() }
+func file_aksnodeconfig_v1_swap_config_proto_init() {
+	if File_aksnodeconfig_v1_swap_config_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aksnodeconfig_v1_swap_config_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aksnodeconfig_v1_swap_config_proto_goTypes,
+		DependencyIndexes: file_aksnodeconfig_v1_swap_config_proto_depIdxs,
+		EnumInfos:         file_aksnodeconfig_v1_swap_config_proto_enumTypes,
+		MessageInfos:      file_aksnodeconfig_v1_swap_config_proto_msgTypes,
+	}.Build()
+	File_aksnodeconfig_v1_swap_config_proto = out.File
+	file_aksnodeconfig_v1_swap_config_proto_rawDesc = nil
+	file_aksnodeconfig_v1_swap_config_proto_goTypes = nil
+	file_aksnodeconfig_v1_swap_config_proto_depIdxs = nil
+}
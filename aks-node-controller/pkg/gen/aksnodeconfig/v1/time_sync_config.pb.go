@@ -0,0 +1,160 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: aksnodeconfig/v1/time_sync_config.proto
+
+package aksnodeconfigv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TimeSyncConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// NTP servers chrony synchronizes with, as host names or IP addresses.
+	NtpServers []string `protobuf:"bytes,1,rep,name=ntp_servers,json=ntpServers,proto3" json:"ntp_servers,omitempty"`
+	// Maximum root distance in seconds of the sources chrony accepts, chrony's default of 3 seconds when not set.
+	MaxDistance float64 `protobuf:"fixed64,2,opt,name=max_distance,json=maxDistance,proto3" json:"max_distance,omitempty"`
+	// Whether chrony uses the Hyper-V PTP clock /dev/ptp_hyperv as a reference clock. Defaults to true.
+	UsePtpDevice *bool `protobuf:"varint,3,opt,name=use_ptp_device,json=usePtpDevice,proto3,oneof" json:"use_ptp_device,omitempty"`
+}
+
+func (x *TimeSyncConfig) Reset() {
+	*x = TimeSyncConfig{}
+	mi := &file_aksnodeconfig_v1_time_sync_config_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeSyncConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeSyncConfig) ProtoMessage() {}
+
+func (x *TimeSyncConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_time_sync_config_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeSyncConfig.ProtoReflect.Descriptor instead.
+func (*TimeSyncConfig) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_time_sync_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TimeSyncConfig) GetNtpServers() []string {
+	if x != nil {
+		return x.NtpServers
+	}
+	return nil
+}
+
+func (x *TimeSyncConfig) GetMaxDistance() float64 {
+	if x != nil {
+		return x.MaxDistance
+	}
+	return 0
+}
+
+func (x *TimeSyncConfig) GetUsePtpDevice() bool {
+	if x != nil && x.UsePtpDevice != nil {
+		return *x.UsePtpDevice
+	}
+	return false
+}
+
+var File_aksnodeconfig_v1_time_sync_config_proto protoreflect.FileDescriptor
+
+var file_aksnodeconfig_v1_time_sync_config_proto_rawDesc = []byte{
+	0x0a, 0x27, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x76, 0x31, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x61, 0x6b, 0x73, 0x6e, 0x6f,
+	0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x22, 0x92, 0x01, 0x0a, 0x0e,
+	0x54, 0x69, 0x6d, 0x65, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6e, 0x74, 0x70, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x74, 0x70, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x12, 0x29, 0x0a, 0x0e, 0x75, 0x73, 0x65, 0x5f, 0x70, 0x74, 0x70, 0x5f, 0x64, 0x65,
+	0x76, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0c, 0x75, 0x73,
+	0x65, 0x50, 0x74, 0x70, 0x44, 0x65, 0x76, 0x69, 0x63, 0x65, 0x88, 0x01, 0x01, 0x42, 0x11, 0x0a,
+	0x0f, 0x5f, 0x75, 0x73, 0x65, 0x5f, 0x70, 0x74, 0x70, 0x5f, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65,
+	0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41,
+	0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f,
+	0x61, 0x6b, 0x73, 0x2d, 0x6e, 0x6f, 0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x6c, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e,
+	0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73,
+	0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_aksnodeconfig_v1_time_sync_config_proto_rawDescOnce sync.Once
+	file_aksnodeconfig_v1_time_sync_config_proto_rawDescData = file_aksnodeconfig_v1_time_sync_config_proto_rawDesc
+)
+
+func file_aksnodeconfig_v1_time_sync_config_proto_rawDescGZIP() []byte {
+	file_aksnodeconfig_v1_time_sync_config_proto_rawDescOnce.Do(func() {
+		file_aksnodeconfig_v1_time_sync_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_aksnodeconfig_v1_time_sync_config_proto_rawDescData)
+	})
+	return file_aksnodeconfig_v1_time_sync_config_proto_rawDescData
+}
+
+var file_aksnodeconfig_v1_time_sync_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_aksnodeconfig_v1_time_sync_config_proto_goTypes = []any{
+	(*TimeSyncConfig)(nil), // 0: aksnodeconfig.v1.TimeSyncConfig
+}
+var file_aksnodeconfig_v1_time_sync_config_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_aksnodeconfig_v1_time_sync_config_proto_init() }
+func file_aksnodeconfig_v1_time_sync_config_proto_init() {
+	if File_aksnodeconfig_v1_time_sync_config_proto != nil {
+		return
+	}
+	file_aksnodeconfig_v1_time_sync_config_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aksnodeconfig_v1_time_sync_config_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aksnodeconfig_v1_time_sync_config_proto_goTypes,
+		DependencyIndexes: file_aksnodeconfig_v1_time_sync_config_proto_depIdxs,
+		MessageInfos:      file_aksnodeconfig_v1_time_sync_config_proto_msgTypes,
+	}.Build()
+	File_aksnodeconfig_v1_time_sync_config_proto = out.File
+	file_aksnodeconfig_v1_time_sync_config_proto_rawDesc = nil
+	file_aksnodeconfig_v1_time_sync_config_proto_goTypes = nil
+	file_aksnodeconfig_v1_time_sync_config_proto_depIdxs = nil
+}
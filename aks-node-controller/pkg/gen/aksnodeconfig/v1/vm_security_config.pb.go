@@ -0,0 +1,160 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: aksnodeconfig/v1/vm_security_config.proto
+
+package aksnodeconfigv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type VmSecurityConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Whether the VM boots with UEFI secure boot, only signed boot loaders, kernels and kernel modules load.
+	SecureBootEnabled bool `protobuf:"varint,1,opt,name=secure_boot_enabled,json=secureBootEnabled,proto3" json:"secure_boot_enabled,omitempty"`
+	// Whether the VM has a virtual TPM, which holds the measurements of the boot.
+	VtpmEnabled bool `protobuf:"varint,2,opt,name=vtpm_enabled,json=vtpmEnabled,proto3" json:"vtpm_enabled,omitempty"`
+	// Whether provisioning loads an IMA policy measuring the executables, libraries and kernel modules the node runs into
+	// the vTPM, so that attestation daemons can verify the node. Requires vtpm_enabled.
+	EnableMeasuredBoot bool `protobuf:"varint,3,opt,name=enable_measured_boot,json=enableMeasuredBoot,proto3" json:"enable_measured_boot,omitempty"`
+}
+
+func (x *VmSecurityConfig) Reset() {
+	*x = VmSecurityConfig{}
+	mi := &file_aksnodeconfig_v1_vm_security_config_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VmSecurityConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VmSecurityConfig) ProtoMessage() {}
+
+func (x *VmSecurityConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_vm_security_config_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VmSecurityConfig.ProtoReflect.Descriptor instead.
+func (*VmSecurityConfig) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_vm_security_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VmSecurityConfig) GetSecureBootEnabled() bool {
+	if x != nil {
+		return x.SecureBootEnabled
+	}
+	return false
+}
+
+func (x *VmSecurityConfig) GetVtpmEnabled() bool {
+	if x != nil {
+		return x.VtpmEnabled
+	}
+	return false
+}
+
+func (x *VmSecurityConfig) GetEnableMeasuredBoot() bool {
+	if x != nil {
+		return x.EnableMeasuredBoot
+	}
+	return false
+}
+
+var File_aksnodeconfig_v1_vm_security_config_proto protoreflect.FileDescriptor
+
+var file_aksnodeconfig_v1_vm_security_config_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x76, 0x31, 0x2f, 0x76, 0x6d, 0x5f, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x61, 0x6b, 0x73,
+	0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x22, 0x97, 0x01,
+	0x0a, 0x10, 0x56, 0x6d, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x5f, 0x62, 0x6f, 0x6f,
+	0x74, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x11, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x42, 0x6f, 0x6f, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x74, 0x70, 0x6d, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x76, 0x74, 0x70, 0x6d, 0x45, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f,
+	0x6d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x64, 0x5f, 0x62, 0x6f, 0x6f, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x12, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x65, 0x61, 0x73, 0x75,
+	0x72, 0x65, 0x64, 0x42, 0x6f, 0x6f, 0x74, 0x42, 0x5a, 0x5a, 0x58, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75, 0x72, 0x65, 0x2f, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b, 0x73, 0x2d, 0x6e, 0x6f, 0x64, 0x65, 0x2d,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67,
+	0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_aksnodeconfig_v1_vm_security_config_proto_rawDescOnce sync.Once
+	file_aksnodeconfig_v1_vm_security_config_proto_rawDescData = file_aksnodeconfig_v1_vm_security_config_proto_rawDesc
+)
+
+func file_aksnodeconfig_v1_vm_security_config_proto_rawDescGZIP() []byte {
+	file_aksnodeconfig_v1_vm_security_config_proto_rawDescOnce.Do(func() {
+		file_aksnodeconfig_v1_vm_security_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_aksnodeconfig_v1_vm_security_config_proto_rawDescData)
+	})
+	return file_aksnodeconfig_v1_vm_security_config_proto_rawDescData
+}
+
+var file_aksnodeconfig_v1_vm_security_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_aksnodeconfig_v1_vm_security_config_proto_goTypes = []any{
+	(*VmSecurityConfig)(nil), // 0: aksnodeconfig.v1.VmSecurityConfig
+}
+var file_aksnodeconfig_v1_vm_security_config_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_aksnodeconfig_v1_vm_security_config_proto_init() }
+func file_aksnodeconfig_v1_vm_security_config_proto_init() {
+	if File_aksnodeconfig_v1_vm_security_config_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aksnodeconfig_v1_vm_security_config_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aksnodeconfig_v1_vm_security_config_proto_goTypes,
+		DependencyIndexes: file_aksnodeconfig_v1_vm_security_config_proto_depIdxs,
+		MessageInfos:      file_aksnodeconfig_v1_vm_security_config_proto_msgTypes,
+	}.Build()
+	File_aksnodeconfig_v1_vm_security_config_proto = out.File
+	file_aksnodeconfig_v1_vm_security_config_proto_rawDesc = nil
+	file_aksnodeconfig_v1_vm_security_config_proto_goTypes = nil
+	file_aksnodeconfig_v1_vm_security_config_proto_depIdxs = nil
+}
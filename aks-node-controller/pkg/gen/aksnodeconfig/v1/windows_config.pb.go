@@ -0,0 +1,917 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        v5.28.3
+// source: aksnodeconfig/v1/windows_config.proto
+
+package aksnodeconfigv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WindowsNetworkMode int32
+
+const (
+	// The network mode of the network plugin, L2Bridge for Azure CNI and kubenet.
+	WindowsNetworkMode_WINDOWS_NETWORK_MODE_UNSPECIFIED WindowsNetworkMode = 0
+	WindowsNetworkMode_WINDOWS_NETWORK_MODE_L2BRIDGE    WindowsNetworkMode = 1
+	// Overlay networking, for Azure CNI overlay.
+	WindowsNetworkMode_WINDOWS_NETWORK_MODE_OVERLAY WindowsNetworkMode = 2
+)
+
+// Enum value maps for WindowsNetworkMode.
+var (
+	WindowsNetworkMode_name = map[int32]string{
+		0: "WINDOWS_NETWORK_MODE_UNSPECIFIED",
+		1: "WINDOWS_NETWORK_MODE_L2BRIDGE",
+		2: "WINDOWS_NETWORK_MODE_OVERLAY",
+	}
+	WindowsNetworkMode_value = map[string]int32{
+		"WINDOWS_NETWORK_MODE_UNSPECIFIED": 0,
+		"WINDOWS_NETWORK_MODE_L2BRIDGE":    1,
+		"WINDOWS_NETWORK_MODE_OVERLAY":     2,
+	}
+)
+
+func (x WindowsNetworkMode) Enum() *WindowsNetworkMode {
+	p := new(WindowsNetworkMode)
+	*p = x
+	return p
+}
+
+func (x WindowsNetworkMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WindowsNetworkMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_windows_config_proto_enumTypes[0].Descriptor()
+}
+
+func (WindowsNetworkMode) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_windows_config_proto_enumTypes[0]
+}
+
+func (x WindowsNetworkMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WindowsNetworkMode.Descriptor instead.
+func (WindowsNetworkMode) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_windows_config_proto_rawDescGZIP(), []int{0}
+}
+
+type WindowsSandboxIsolation int32
+
+const (
+	// Process isolation.
+	WindowsSandboxIsolation_WINDOWS_SANDBOX_ISOLATION_UNSPECIFIED WindowsSandboxIsolation = 0
+	// Pods are process-isolated containers sharing the kernel of the node.
+	WindowsSandboxIsolation_WINDOWS_SANDBOX_ISOLATION_PROCESS WindowsSandboxIsolation = 1
+	// Pods run in Hyper-V utility VMs.
+	WindowsSandboxIsolation_WINDOWS_SANDBOX_ISOLATION_HYPERV WindowsSandboxIsolation = 2
+)
+
+// Enum value maps for WindowsSandboxIsolation.
+var (
+	WindowsSandboxIsolation_name = map[int32]string{
+		0: "WINDOWS_SANDBOX_ISOLATION_UNSPECIFIED",
+		1: "WINDOWS_SANDBOX_ISOLATION_PROCESS",
+		2: "WINDOWS_SANDBOX_ISOLATION_HYPERV",
+	}
+	WindowsSandboxIsolation_value = map[string]int32{
+		"WINDOWS_SANDBOX_ISOLATION_UNSPECIFIED": 0,
+		"WINDOWS_SANDBOX_ISOLATION_PROCESS":     1,
+		"WINDOWS_SANDBOX_ISOLATION_HYPERV":      2,
+	}
+)
+
+func (x WindowsSandboxIsolation) Enum() *WindowsSandboxIsolation {
+	p := new(WindowsSandboxIsolation)
+	*p = x
+	return p
+}
+
+func (x WindowsSandboxIsolation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WindowsSandboxIsolation) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_windows_config_proto_enumTypes[1].Descriptor()
+}
+
+func (WindowsSandboxIsolation) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_windows_config_proto_enumTypes[1]
+}
+
+func (x WindowsSandboxIsolation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WindowsSandboxIsolation.Descriptor instead.
+func (WindowsSandboxIsolation) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_windows_config_proto_rawDescGZIP(), []int{1}
+}
+
+type WindowsUpdateMode int32
+
+const (
+	// The Windows Update settings of the node image are kept.
+	WindowsUpdateMode_WINDOWS_UPDATE_MODE_UNSPECIFIED WindowsUpdateMode = 0
+	// Windows Update installs no update, the node is patched by node image upgrades.
+	WindowsUpdateMode_WINDOWS_UPDATE_MODE_DISABLED WindowsUpdateMode = 1
+	// Windows Update only installs the monthly quality updates, feature updates and drivers are deferred.
+	WindowsUpdateMode_WINDOWS_UPDATE_MODE_SECURITY_ONLY WindowsUpdateMode = 2
+)
+
+// Enum value maps for WindowsUpdateMode.
+var (
+	WindowsUpdateMode_name = map[int32]string{
+		0: "WINDOWS_UPDATE_MODE_UNSPECIFIED",
+		1: "WINDOWS_UPDATE_MODE_DISABLED",
+		2: "WINDOWS_UPDATE_MODE_SECURITY_ONLY",
+	}
+	WindowsUpdateMode_value = map[string]int32{
+		"WINDOWS_UPDATE_MODE_UNSPECIFIED":   0,
+		"WINDOWS_UPDATE_MODE_DISABLED":      1,
+		"WINDOWS_UPDATE_MODE_SECURITY_ONLY": 2,
+	}
+)
+
+func (x WindowsUpdateMode) Enum() *WindowsUpdateMode {
+	p := new(WindowsUpdateMode)
+	*p = x
+	return p
+}
+
+func (x WindowsUpdateMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WindowsUpdateMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_aksnodeconfig_v1_windows_config_proto_enumTypes[2].Descriptor()
+}
+
+func (WindowsUpdateMode) Type() protoreflect.EnumType {
+	return &file_aksnodeconfig_v1_windows_config_proto_enumTypes[2]
+}
+
+func (x WindowsUpdateMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WindowsUpdateMode.Descriptor instead.
+func (WindowsUpdateMode) EnumDescriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_windows_config_proto_rawDescGZIP(), []int{2}
+}
+
+type WindowsConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// URL of the zip package with the Windows CSE scripts.
+	CseScriptsPackageUrl string `protobuf:"bytes,1,opt,name=cse_scripts_package_url,json=cseScriptsPackageUrl,proto3" json:"cse_scripts_package_url,omitempty"`
+	// URL of the zip package with the Windows kubelet, kube-proxy and kubectl binaries.
+	KubernetesPackageUrl string `protobuf:"bytes,2,opt,name=kubernetes_package_url,json=kubernetesPackageUrl,proto3" json:"kubernetes_package_url,omitempty"`
+	// URL of the zip package with the Windows image credential provider.
+	CredentialProviderUrl string `protobuf:"bytes,3,opt,name=credential_provider_url,json=credentialProviderUrl,proto3" json:"credential_provider_url,omitempty"`
+	// URL of the Windows containerd package.
+	ContainerdPackageUrl string `protobuf:"bytes,4,opt,name=containerd_package_url,json=containerdPackageUrl,proto3" json:"containerd_package_url,omitempty"`
+	// Whether the pause image is pulled on every provisioning even when the node image already has it.
+	AlwaysPullPauseImage bool `protobuf:"varint,5,opt,name=always_pull_pause_image,json=alwaysPullPauseImage,proto3" json:"always_pull_pause_image,omitempty"`
+	// Whether the CSI proxy runs on the node, for the CSI node plugins of Windows.
+	EnableCsiProxy bool `protobuf:"varint,6,opt,name=enable_csi_proxy,json=enableCsiProxy,proto3" json:"enable_csi_proxy,omitempty"`
+	// URL of the CSI proxy binary.
+	CsiProxyUrl string `protobuf:"bytes,7,opt,name=csi_proxy_url,json=csiProxyUrl,proto3" json:"csi_proxy_url,omitempty"`
+	// Command line flags of kube-proxy, with the leading dashes.
+	KubeProxyFlags map[string]string `protobuf:"bytes,8,rep,name=kube_proxy_flags,json=kubeProxyFlags,proto3" json:"kube_proxy_flags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// HNS network kubelet and kube-proxy use.
+	HnsConfig *WindowsHnsConfig `protobuf:"bytes,9,opt,name=hns_config,json=hnsConfig,proto3" json:"hns_config,omitempty"`
+	// Group Managed Service Accounts through the Container Credential Guard (CCG) plugin, for containers of nodes which
+	// aren't domain joined.
+	GmsaConfig *WindowsGmsaConfig `protobuf:"bytes,10,opt,name=gmsa_config,json=gmsaConfig,proto3" json:"gmsa_config,omitempty"`
+	// containerd settings of the node. The containerd configuration of the node image is kept when not set.
+	ContainerdConfig *WindowsContainerdConfig `protobuf:"bytes,11,opt,name=containerd_config,json=containerdConfig,proto3" json:"containerd_config,omitempty"`
+	// Name of the Windows security baseline applied before CSE runs, aks-baseline or cis-level1. No baseline is applied
+	// when not set.
+	HardeningProfile string `protobuf:"bytes,12,opt,name=hardening_profile,json=hardeningProfile,proto3" json:"hardening_profile,omitempty"`
+	// Windows Update behavior of the node, the settings of the node image are kept when not set.
+	UpdateConfig *WindowsUpdateConfig `protobuf:"bytes,13,opt,name=update_config,json=updateConfig,proto3" json:"update_config,omitempty"`
+}
+
+func (x *WindowsConfig) Reset() {
+	*x = WindowsConfig{}
+	mi := &file_aksnodeconfig_v1_windows_config_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WindowsConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WindowsConfig) ProtoMessage() {}
+
+func (x *WindowsConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_windows_config_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WindowsConfig.ProtoReflect.Descriptor instead.
+func (*WindowsConfig) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_windows_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WindowsConfig) GetCseScriptsPackageUrl() string {
+	if x != nil {
+		return x.CseScriptsPackageUrl
+	}
+	return ""
+}
+
+func (x *WindowsConfig) GetKubernetesPackageUrl() string {
+	if x != nil {
+		return x.KubernetesPackageUrl
+	}
+	return ""
+}
+
+func (x *WindowsConfig) GetCredentialProviderUrl() string {
+	if x != nil {
+		return x.CredentialProviderUrl
+	}
+	return ""
+}
+
+func (x *WindowsConfig) GetContainerdPackageUrl() string {
+	if x != nil {
+		return x.ContainerdPackageUrl
+	}
+	return ""
+}
+
+func (x *WindowsConfig) GetAlwaysPullPauseImage() bool {
+	if x != nil {
+		return x.AlwaysPullPauseImage
+	}
+	return false
+}
+
+func (x *WindowsConfig) GetEnableCsiProxy() bool {
+	if x != nil {
+		return x.EnableCsiProxy
+	}
+	return false
+}
+
+func (x *WindowsConfig) GetCsiProxyUrl() string {
+	if x != nil {
+		return x.CsiProxyUrl
+	}
+	return ""
+}
+
+func (x *WindowsConfig) GetKubeProxyFlags() map[string]string {
+	if x != nil {
+		return x.KubeProxyFlags
+	}
+	return nil
+}
+
+func (x *WindowsConfig) GetHnsConfig() *WindowsHnsConfig {
+	if x != nil {
+		return x.HnsConfig
+	}
+	return nil
+}
+
+func (x *WindowsConfig) GetGmsaConfig() *WindowsGmsaConfig {
+	if x != nil {
+		return x.GmsaConfig
+	}
+	return nil
+}
+
+func (x *WindowsConfig) GetContainerdConfig() *WindowsContainerdConfig {
+	if x != nil {
+		return x.ContainerdConfig
+	}
+	return nil
+}
+
+func (x *WindowsConfig) GetHardeningProfile() string {
+	if x != nil {
+		return x.HardeningProfile
+	}
+	return ""
+}
+
+func (x *WindowsConfig) GetUpdateConfig() *WindowsUpdateConfig {
+	if x != nil {
+		return x.UpdateConfig
+	}
+	return nil
+}
+
+type WindowsHnsConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NetworkMode WindowsNetworkMode `protobuf:"varint,1,opt,name=network_mode,json=networkMode,proto3,enum=aksnodeconfig.v1.WindowsNetworkMode" json:"network_mode,omitempty"`
+	// Name of the HNS network, "azure" when not set.
+	NetworkName string `protobuf:"bytes,2,opt,name=network_name,json=networkName,proto3" json:"network_name,omitempty"`
+	// Destination CIDRs whose traffic from pods isn't SNATed to the node address, on top of the cluster CIDRs.
+	OutboundNatExceptions []string `protobuf:"bytes,3,rep,name=outbound_nat_exceptions,json=outboundNatExceptions,proto3" json:"outbound_nat_exceptions,omitempty"`
+	// Whether the outbound NAT of pods is disabled, for clusters with a NAT gateway.
+	DisableOutboundNat bool `protobuf:"varint,4,opt,name=disable_outbound_nat,json=disableOutboundNat,proto3" json:"disable_outbound_nat,omitempty"`
+	// Whether the HNS networks and endpoints left by a previous boot are kept instead of being cleaned up.
+	SkipCleanupNetwork bool `protobuf:"varint,5,opt,name=skip_cleanup_network,json=skipCleanupNetwork,proto3" json:"skip_cleanup_network,omitempty"`
+}
+
+func (x *WindowsHnsConfig) Reset() {
+	*x = WindowsHnsConfig{}
+	mi := &file_aksnodeconfig_v1_windows_config_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WindowsHnsConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WindowsHnsConfig) ProtoMessage() {}
+
+func (x *WindowsHnsConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_windows_config_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WindowsHnsConfig.ProtoReflect.Descriptor instead.
+func (*WindowsHnsConfig) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_windows_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WindowsHnsConfig) GetNetworkMode() WindowsNetworkMode {
+	if x != nil {
+		return x.NetworkMode
+	}
+	return WindowsNetworkMode_WINDOWS_NETWORK_MODE_UNSPECIFIED
+}
+
+func (x *WindowsHnsConfig) GetNetworkName() string {
+	if x != nil {
+		return x.NetworkName
+	}
+	return ""
+}
+
+func (x *WindowsHnsConfig) GetOutboundNatExceptions() []string {
+	if x != nil {
+		return x.OutboundNatExceptions
+	}
+	return nil
+}
+
+func (x *WindowsHnsConfig) GetDisableOutboundNat() bool {
+	if x != nil {
+		return x.DisableOutboundNat
+	}
+	return false
+}
+
+func (x *WindowsHnsConfig) GetSkipCleanupNetwork() bool {
+	if x != nil {
+		return x.SkipCleanupNetwork
+	}
+	return false
+}
+
+type WindowsGmsaConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// URL of the zip package with the CCG plugin DLL.
+	PluginPackageUrl string `protobuf:"bytes,2,opt,name=plugin_package_url,json=pluginPackageUrl,proto3" json:"plugin_package_url,omitempty"`
+	// COM class ID of the CCG plugin which the credential specs reference, the AKS Key Vault plugin when not set.
+	PluginClsid string `protobuf:"bytes,3,opt,name=plugin_clsid,json=pluginClsid,proto3" json:"plugin_clsid,omitempty"`
+	// DNS name of the Active Directory domain, added to the DNS suffix search list of the node.
+	RootDomainName string `protobuf:"bytes,4,opt,name=root_domain_name,json=rootDomainName,proto3" json:"root_domain_name,omitempty"`
+	// Domain controller DNS servers, which resolve the domain.
+	DnsServers []string `protobuf:"bytes,5,rep,name=dns_servers,json=dnsServers,proto3" json:"dns_servers,omitempty"`
+}
+
+func (x *WindowsGmsaConfig) Reset() {
+	*x = WindowsGmsaConfig{}
+	mi := &file_aksnodeconfig_v1_windows_config_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WindowsGmsaConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WindowsGmsaConfig) ProtoMessage() {}
+
+func (x *WindowsGmsaConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_windows_config_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WindowsGmsaConfig.ProtoReflect.Descriptor instead.
+func (*WindowsGmsaConfig) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_windows_config_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WindowsGmsaConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *WindowsGmsaConfig) GetPluginPackageUrl() string {
+	if x != nil {
+		return x.PluginPackageUrl
+	}
+	return ""
+}
+
+func (x *WindowsGmsaConfig) GetPluginClsid() string {
+	if x != nil {
+		return x.PluginClsid
+	}
+	return ""
+}
+
+func (x *WindowsGmsaConfig) GetRootDomainName() string {
+	if x != nil {
+		return x.RootDomainName
+	}
+	return ""
+}
+
+func (x *WindowsGmsaConfig) GetDnsServers() []string {
+	if x != nil {
+		return x.DnsServers
+	}
+	return nil
+}
+
+type WindowsContainerdConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Windows Server build of the node image, such as 20348 for Windows Server 2022, whose containerd defaults apply.
+	WindowsBuild string `protobuf:"bytes,1,opt,name=windows_build,json=windowsBuild,proto3" json:"windows_build,omitempty"`
+	// Isolation of the pods which don't select a runtime handler.
+	DefaultSandboxIsolation WindowsSandboxIsolation `protobuf:"varint,2,opt,name=default_sandbox_isolation,json=defaultSandboxIsolation,proto3,enum=aksnodeconfig.v1.WindowsSandboxIsolation" json:"default_sandbox_isolation,omitempty"`
+	// Windows Server builds of the guests a Hyper-V runtime handler runhcs-wcow-hypervisor-<build> is added for.
+	HypervRuntimeHandlerBuilds []string `protobuf:"bytes,3,rep,name=hyperv_runtime_handler_builds,json=hypervRuntimeHandlerBuilds,proto3" json:"hyperv_runtime_handler_builds,omitempty"`
+	// Directory of the CNI plugin binaries, c:\k\azurecni\bin when not set.
+	CniBinDir string `protobuf:"bytes,4,opt,name=cni_bin_dir,json=cniBinDir,proto3" json:"cni_bin_dir,omitempty"`
+	// Directory of the CNI network configuration, c:\k\azurecni\netconf when not set.
+	CniConfDir string `protobuf:"bytes,5,opt,name=cni_conf_dir,json=cniConfDir,proto3" json:"cni_conf_dir,omitempty"`
+}
+
+func (x *WindowsContainerdConfig) Reset() {
+	*x = WindowsContainerdConfig{}
+	mi := &file_aksnodeconfig_v1_windows_config_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WindowsContainerdConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WindowsContainerdConfig) ProtoMessage() {}
+
+func (x *WindowsContainerdConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_windows_config_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WindowsContainerdConfig.ProtoReflect.Descriptor instead.
+func (*WindowsContainerdConfig) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_windows_config_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WindowsContainerdConfig) GetWindowsBuild() string {
+	if x != nil {
+		return x.WindowsBuild
+	}
+	return ""
+}
+
+func (x *WindowsContainerdConfig) GetDefaultSandboxIsolation() WindowsSandboxIsolation {
+	if x != nil {
+		return x.DefaultSandboxIsolation
+	}
+	return WindowsSandboxIsolation_WINDOWS_SANDBOX_ISOLATION_UNSPECIFIED
+}
+
+func (x *WindowsContainerdConfig) GetHypervRuntimeHandlerBuilds() []string {
+	if x != nil {
+		return x.HypervRuntimeHandlerBuilds
+	}
+	return nil
+}
+
+func (x *WindowsContainerdConfig) GetCniBinDir() string {
+	if x != nil {
+		return x.CniBinDir
+	}
+	return ""
+}
+
+func (x *WindowsContainerdConfig) GetCniConfDir() string {
+	if x != nil {
+		return x.CniConfDir
+	}
+	return ""
+}
+
+type WindowsUpdateConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mode WindowsUpdateMode `protobuf:"varint,1,opt,name=mode,proto3,enum=aksnodeconfig.v1.WindowsUpdateMode" json:"mode,omitempty"`
+	// When the security updates are installed, the node image schedule is kept when not set. Only valid with the
+	// security-only mode.
+	MaintenanceWindow *WindowsMaintenanceWindow `protobuf:"bytes,2,opt,name=maintenance_window,json=maintenanceWindow,proto3" json:"maintenance_window,omitempty"`
+}
+
+func (x *WindowsUpdateConfig) Reset() {
+	*x = WindowsUpdateConfig{}
+	mi := &file_aksnodeconfig_v1_windows_config_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WindowsUpdateConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WindowsUpdateConfig) ProtoMessage() {}
+
+func (x *WindowsUpdateConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_windows_config_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WindowsUpdateConfig.ProtoReflect.Descriptor instead.
+func (*WindowsUpdateConfig) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_windows_config_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WindowsUpdateConfig) GetMode() WindowsUpdateMode {
+	if x != nil {
+		return x.Mode
+	}
+	return WindowsUpdateMode_WINDOWS_UPDATE_MODE_UNSPECIFIED
+}
+
+func (x *WindowsUpdateConfig) GetMaintenanceWindow() *WindowsMaintenanceWindow {
+	if x != nil {
+		return x.MaintenanceWindow
+	}
+	return nil
+}
+
+type WindowsMaintenanceWindow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// English name of the day updates are installed on, such as Sunday. Updates are installed every day when not set.
+	DayOfWeek string `protobuf:"bytes,1,opt,name=day_of_week,json=dayOfWeek,proto3" json:"day_of_week,omitempty"`
+	// Hour of the day, from 0 to 23 in the time zone of the node, updates start being installed at.
+	StartHour uint32 `protobuf:"varint,2,opt,name=start_hour,json=startHour,proto3" json:"start_hour,omitempty"`
+}
+
+func (x *WindowsMaintenanceWindow) Reset() {
+	*x = WindowsMaintenanceWindow{}
+	mi := &file_aksnodeconfig_v1_windows_config_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WindowsMaintenanceWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WindowsMaintenanceWindow) ProtoMessage() {}
+
+func (x *WindowsMaintenanceWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_aksnodeconfig_v1_windows_config_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WindowsMaintenanceWindow.ProtoReflect.Descriptor instead.
+func (*WindowsMaintenanceWindow) Descriptor() ([]byte, []int) {
+	return file_aksnodeconfig_v1_windows_config_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WindowsMaintenanceWindow) GetDayOfWeek() string {
+	if x != nil {
+		return x.DayOfWeek
+	}
+	return ""
+}
+
+func (x *WindowsMaintenanceWindow) GetStartHour() uint32 {
+	if x != nil {
+		return x.StartHour
+	}
+	return 0
+}
+
+var File_aksnodeconfig_v1_windows_config_proto protoreflect.FileDescriptor
+
+var file_aksnodeconfig_v1_windows_config_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f,
+	0x76, 0x31, 0x2f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x22, 0xeb, 0x06, 0x0a, 0x0d, 0x57, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x35, 0x0a, 0x17, 0x63,
+	0x73, 0x65, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61,
+	0x67, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x63, 0x73,
+	0x65, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x73, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x55,
+	0x72, 0x6c, 0x12, 0x34, 0x0a, 0x16, 0x6b, 0x75, 0x62, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x65, 0x73,
+	0x5f, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x14, 0x6b, 0x75, 0x62, 0x65, 0x72, 0x6e, 0x65, 0x74, 0x65, 0x73, 0x50, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x36, 0x0a, 0x17, 0x63, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x5f,
+	0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x63, 0x72, 0x65, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x55, 0x72, 0x6c,
+	0x12, 0x34, 0x0a, 0x16, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x5f, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x14, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x50, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x35, 0x0a, 0x17, 0x61, 0x6c, 0x77, 0x61, 0x79, 0x73,
+	0x5f, 0x70, 0x75, 0x6c, 0x6c, 0x5f, 0x70, 0x61, 0x75, 0x73, 0x65, 0x5f, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x61, 0x6c, 0x77, 0x61, 0x79, 0x73, 0x50,
+	0x75, 0x6c, 0x6c, 0x50, 0x61, 0x75, 0x73, 0x65, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x28, 0x0a,
+	0x10, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x63, 0x73, 0x69, 0x5f, 0x70, 0x72, 0x6f, 0x78,
+	0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x43,
+	0x73, 0x69, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x12, 0x22, 0x0a, 0x0d, 0x63, 0x73, 0x69, 0x5f, 0x70,
+	0x72, 0x6f, 0x78, 0x79, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x63, 0x73, 0x69, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x55, 0x72, 0x6c, 0x12, 0x5d, 0x0a, 0x10, 0x6b,
+	0x75, 0x62, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18,
+	0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4b, 0x75, 0x62, 0x65, 0x50, 0x72, 0x6f, 0x78, 0x79,
+	0x46, 0x6c, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x6b, 0x75, 0x62, 0x65,
+	0x50, 0x72, 0x6f, 0x78, 0x79, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x41, 0x0a, 0x0a, 0x68, 0x6e,
+	0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76,
+	0x31, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x48, 0x6e, 0x73, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x52, 0x09, 0x68, 0x6e, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x44, 0x0a,
+	0x0b, 0x67, 0x6d, 0x73, 0x61, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x47, 0x6d, 0x73,
+	0x61, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0a, 0x67, 0x6d, 0x73, 0x61, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x56, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x64, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29,
+	0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76,
+	0x31, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2b, 0x0a, 0x11, 0x68,
+	0x61, 0x72, 0x64, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x68, 0x61, 0x72, 0x64, 0x65, 0x6e, 0x69, 0x6e,
+	0x67, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x4a, 0x0a, 0x0d, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x25, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x0c, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x1a, 0x41, 0x0a, 0x13, 0x4b, 0x75, 0x62, 0x65, 0x50, 0x72, 0x6f, 0x78,
+	0x79, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x9a, 0x02, 0x0a, 0x10, 0x57, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x73, 0x48, 0x6e, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x47, 0x0a, 0x0c,
+	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x24, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0b, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x36, 0x0a, 0x17, 0x6f, 0x75, 0x74, 0x62,
+	0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x6e, 0x61, 0x74, 0x5f, 0x65, 0x78, 0x63, 0x65, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x15, 0x6f, 0x75, 0x74, 0x62, 0x6f,
+	0x75, 0x6e, 0x64, 0x4e, 0x61, 0x74, 0x45, 0x78, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x30, 0x0a, 0x14, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6f, 0x75, 0x74, 0x62,
+	0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x6e, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12,
+	0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x4f, 0x75, 0x74, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x4e,
+	0x61, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x63, 0x6c, 0x65, 0x61, 0x6e,
+	0x75, 0x70, 0x5f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x12, 0x73, 0x6b, 0x69, 0x70, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x4e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x22, 0xc9, 0x01, 0x0a, 0x11, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73,
+	0x47, 0x6d, 0x73, 0x61, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x5f, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x55,
+	0x72, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x5f, 0x63, 0x6c, 0x73,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x43, 0x6c, 0x73, 0x69, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x64, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0e, 0x72, 0x6f, 0x6f, 0x74, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x1f, 0x0a, 0x0b, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73,
+	0x22, 0xaa, 0x02, 0x0a, 0x17, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x23, 0x0a, 0x0d,
+	0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x42, 0x75, 0x69, 0x6c,
+	0x64, 0x12, 0x65, 0x0a, 0x19, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x73, 0x61, 0x6e,
+	0x64, 0x62, 0x6f, 0x78, 0x5f, 0x69, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x53,
+	0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x49, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x17, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x49,
+	0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x41, 0x0a, 0x1d, 0x68, 0x79, 0x70, 0x65,
+	0x72, 0x76, 0x5f, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x6c,
+	0x65, 0x72, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x1a, 0x68, 0x79, 0x70, 0x65, 0x72, 0x76, 0x52, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x48, 0x61,
+	0x6e, 0x64, 0x6c, 0x65, 0x72, 0x42, 0x75, 0x69, 0x6c, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x63,
+	0x6e, 0x69, 0x5f, 0x62, 0x69, 0x6e, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x63, 0x6e, 0x69, 0x42, 0x69, 0x6e, 0x44, 0x69, 0x72, 0x12, 0x20, 0x0a, 0x0c, 0x63,
+	0x6e, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x63, 0x6e, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x44, 0x69, 0x72, 0x22, 0xa9, 0x01,
+	0x0a, 0x13, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x37, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x59,
+	0x0a, 0x12, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x77, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x61, 0x6b, 0x73,
+	0x6e, 0x6f, 0x64, 0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x73, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x52, 0x11, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61,
+	0x6e, 0x63, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x22, 0x59, 0x0a, 0x18, 0x57, 0x69, 0x6e,
+	0x64, 0x6f, 0x77, 0x73, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x57,
+	0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x1e, 0x0a, 0x0b, 0x64, 0x61, 0x79, 0x5f, 0x6f, 0x66, 0x5f,
+	0x77, 0x65, 0x65, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x61, 0x79, 0x4f,
+	0x66, 0x57, 0x65, 0x65, 0x6b, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x68,
+	0x6f, 0x75, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x48, 0x6f, 0x75, 0x72, 0x2a, 0x7f, 0x0a, 0x12, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x4e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x20, 0x57, 0x49,
+	0x4e, 0x44, 0x4f, 0x57, 0x53, 0x5f, 0x4e, 0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x4d, 0x4f,
+	0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x21, 0x0a, 0x1d, 0x57, 0x49, 0x4e, 0x44, 0x4f, 0x57, 0x53, 0x5f, 0x4e, 0x45, 0x54, 0x57,
+	0x4f, 0x52, 0x4b, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x4c, 0x32, 0x42, 0x52, 0x49, 0x44, 0x47,
+	0x45, 0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x57, 0x49, 0x4e, 0x44, 0x4f, 0x57, 0x53, 0x5f, 0x4e,
+	0x45, 0x54, 0x57, 0x4f, 0x52, 0x4b, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x4f, 0x56, 0x45, 0x52,
+	0x4c, 0x41, 0x59, 0x10, 0x02, 0x2a, 0x91, 0x01, 0x0a, 0x17, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x73, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x49, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x29, 0x0a, 0x25, 0x57, 0x49, 0x4e, 0x44, 0x4f, 0x57, 0x53, 0x5f, 0x53, 0x41, 0x4e,
+	0x44, 0x42, 0x4f, 0x58, 0x5f, 0x49, 0x53, 0x4f, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x25, 0x0a, 0x21,
+	0x57, 0x49, 0x4e, 0x44, 0x4f, 0x57, 0x53, 0x5f, 0x53, 0x41, 0x4e, 0x44, 0x42, 0x4f, 0x58, 0x5f,
+	0x49, 0x53, 0x4f, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53,
+	0x53, 0x10, 0x01, 0x12, 0x24, 0x0a, 0x20, 0x57, 0x49, 0x4e, 0x44, 0x4f, 0x57, 0x53, 0x5f, 0x53,
+	0x41, 0x4e, 0x44, 0x42, 0x4f, 0x58, 0x5f, 0x49, 0x53, 0x4f, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x48, 0x59, 0x50, 0x45, 0x52, 0x56, 0x10, 0x02, 0x2a, 0x81, 0x01, 0x0a, 0x11, 0x57, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12,
+	0x23, 0x0a, 0x1f, 0x57, 0x49, 0x4e, 0x44, 0x4f, 0x57, 0x53, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54,
+	0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x20, 0x0a, 0x1c, 0x57, 0x49, 0x4e, 0x44, 0x4f, 0x57, 0x53, 0x5f,
+	0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x44, 0x49, 0x53, 0x41,
+	0x42, 0x4c, 0x45, 0x44, 0x10, 0x01, 0x12, 0x25, 0x0a, 0x21, 0x57, 0x49, 0x4e, 0x44, 0x4f, 0x57,
+	0x53, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x53, 0x45,
+	0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x02, 0x42, 0x5a, 0x5a,
+	0x58, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x41, 0x7a, 0x75, 0x72,
+	0x65, 0x2f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x62, 0x61, 0x6b, 0x65, 0x72, 0x2f, 0x61, 0x6b, 0x73,
+	0x2d, 0x6e, 0x6f, 0x64, 0x65, 0x2d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64, 0x65,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6b, 0x73, 0x6e, 0x6f, 0x64,
+	0x65, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_aksnodeconfig_v1_windows_config_proto_rawDescOnce sync.Once
+	file_aksnodeconfig_v1_windows_config_proto_rawDescData = file_aksnodeconfig_v1_windows_config_proto_rawDesc
+)
+
+func file_aksnodeconfig_v1_windows_config_proto_rawDescGZIP() []byte {
+	file_aksnodeconfig_v1_windows_config_proto_rawDescOnce.Do(func() {
+		file_aksnodeconfig_v1_windows_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_aksnodeconfig_v1_windows_config_proto_rawDescData)
+	})
+	return file_aksnodeconfig_v1_windows_config_proto_rawDescData
+}
+
+var file_aksnodeconfig_v1_windows_config_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_aksnodeconfig_v1_windows_config_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_aksnodeconfig_v1_windows_config_proto_goTypes = []any{
+	(WindowsNetworkMode)(0),          // 0: aksnodeconfig.v1.WindowsNetworkMode
+	(WindowsSandboxIsolation)(0),     // 1: aksnodeconfig.v1.WindowsSandboxIsolation
+	(WindowsUpdateMode)(0),           // 2: aksnodeconfig.v1.WindowsUpdateMode
+	(*WindowsConfig)(nil),            // 3: aksnodeconfig.v1.WindowsConfig
+	(*WindowsHnsConfig)(nil),         // 4: aksnodeconfig.v1.WindowsHnsConfig
+	(*WindowsGmsaConfig)(nil),        // 5: aksnodeconfig.v1.WindowsGmsaConfig
+	(*WindowsContainerdConfig)(nil),  // 6: aksnodeconfig.v1.WindowsContainerdConfig
+	(*WindowsUpdateConfig)(nil),      // 7: aksnodeconfig.v1.WindowsUpdateConfig
+	(*WindowsMaintenanceWindow)(nil), // 8: aksnodeconfig.v1.WindowsMaintenanceWindow
+	nil,                              // 9: aksnodeconfig.v1.WindowsConfig.KubeProxyFlagsEntry
+}
+var file_aksnodeconfig_v1_windows_config_proto_depIdxs = []int32{
+	9, // 0: aksnodeconfig.v1.WindowsConfig.kube_proxy_flags:type_name -> aksnodeconfig.v1.WindowsConfig.KubeProxyFlagsEntry
+	4, // 1: aksnodeconfig.v1.WindowsConfig.hns_config:type_name -> aksnodeconfig.v1.WindowsHnsConfig
+	5, // 2: aksnodeconfig.v1.WindowsConfig.gmsa_config:type_name -> aksnodeconfig.v1.WindowsGmsaConfig
+	6, // 3: aksnodeconfig.v1.WindowsConfig.containerd_config:type_name -> aksnodeconfig.v1.WindowsContainerdConfig
+	7, // 4: aksnodeconfig.v1.WindowsConfig.update_config:type_name -> aksnodeconfig.v1.WindowsUpdateConfig
+	0, // 5: aksnodeconfig.v1.WindowsHnsConfig.network_mode:type_name -> aksnodeconfig.v1.WindowsNetworkMode
+	1, // 6: aksnodeconfig.v1.WindowsContainerdConfig.default_sandbox_isolation:type_name -> aksnodeconfig.v1.WindowsSandboxIsolation
+	2, // 7: aksnodeconfig.v1.WindowsUpdateConfig.mode:type_name -> aksnodeconfig.v1.WindowsUpdateMode
+	8, // 8: aksnodeconfig.v1.WindowsUpdateConfig.maintenance_window:type_name -> aksnodeconfig.v1.WindowsMaintenanceWindow
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_aksnodeconfig_v1_windows_config_proto_init() }
+func file_aksnodeconfig_v1_windows_config_proto_init() {
+	if File_aksnodeconfig_v1_windows_config_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aksnodeconfig_v1_windows_config_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_aksnodeconfig_v1_windows_config_proto_goTypes,
+		DependencyIndexes: file_aksnodeconfig_v1_windows_config_proto_depIdxs,
+		EnumInfos:         file_aksnodeconfig_v1_windows_config_proto_enumTypes,
+		MessageInfos:      file_aksnodeconfig_v1_windows_config_proto_msgTypes,
+	}.Build()
+	File_aksnodeconfig_v1_windows_config_proto = out.File
+	file_aksnodeconfig_v1_windows_config_proto_rawDesc = nil
+	file_aksnodeconfig_v1_windows_config_proto_goTypes = nil
+	file_aksnodeconfig_v1_windows_config_proto_depIdxs = nil
+}
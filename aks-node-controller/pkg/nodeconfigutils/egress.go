@@ -0,0 +1,175 @@
+package nodeconfigutils
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+const (
+	defaultHTTPPort  = 80
+	defaultHTTPSPort = 443
+)
+
+// EgressViolation describes a destination the node would contact that is not allowed by the egress policy.
+type EgressViolation struct {
+	// Field is the configuration field the destination was read from.
+	Field string
+	// Destination is the raw value of the field.
+	Destination string
+	Host        string
+	Port        int
+	// Reason is set when the destination could not be parsed.
+	Reason string
+}
+
+func (v EgressViolation) String() string {
+	if v.Reason != "" {
+		return fmt.Sprintf("%s: %q %s", v.Field, v.Destination, v.Reason)
+	}
+	return fmt.Sprintf("%s: %s:%d is not allowed by the egress policy", v.Field, v.Host, v.Port)
+}
+
+// EgressPolicyError is returned by ValidateEgress when one or more destinations violate the egress policy.
+type EgressPolicyError struct {
+	Violations []EgressViolation
+}
+
+func (e *EgressPolicyError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.String())
+	}
+	return fmt.Sprintf("egress policy violations: %s", strings.Join(msgs, "; "))
+}
+
+type egressDestination struct {
+	field string
+	value string
+}
+
+// egressDestinations returns every remote destination referenced by the configuration
+// which the node will contact during provisioning.
+func egressDestinations(cfg *aksnodeconfigv1.Configuration) []egressDestination {
+	candidates := []egressDestination{
+		{"api_server_config.api_server_name", cfg.GetApiServerConfig().GetApiServerName()},
+		{"kube_binary_config.kube_binary_url", cfg.GetKubeBinaryConfig().GetKubeBinaryUrl()},
+		{"kube_binary_config.custom_kube_binary_url", cfg.GetKubeBinaryConfig().GetCustomKubeBinaryUrl()},
+		{"kube_binary_config.private_kube_binary_url", cfg.GetKubeBinaryConfig().GetPrivateKubeBinaryUrl()},
+		{"kube_binary_config.pod_infra_container_image_url", cfg.GetKubeBinaryConfig().GetPodInfraContainerImageUrl()},
+		{"kube_binary_config.linux_credential_provider_url", cfg.GetKubeBinaryConfig().GetLinuxCredentialProviderUrl()},
+		{"kube_proxy_url", cfg.GetKubeProxyUrl()},
+		{"network_config.vnet_cni_plugins_url", cfg.GetNetworkConfig().GetVnetCniPluginsUrl()},
+		{"network_config.cni_plugins_url", cfg.GetNetworkConfig().GetCniPluginsUrl()},
+		{"containerd_config.containerd_download_url_base", cfg.GetContainerdConfig().GetContainerdDownloadUrlBase()},
+		{"containerd_config.containerd_package_url", cfg.GetContainerdConfig().GetContainerdPackageUrl()},
+		{"containerd_config.sandbox_image.image", cfg.GetContainerdConfig().GetSandboxImage().GetImage()},
+		{"runc_config.runc_package_url", cfg.GetRuncConfig().GetRuncPackageUrl()},
+		{"teleport_config.teleportd_plugin_download_url", cfg.GetTeleportConfig().GetTeleportdPluginDownloadUrl()},
+		{"custom_cloud_config.repo_depot_endpoint", cfg.GetCustomCloudConfig().GetRepoDepotEndpoint()},
+		{"http_proxy_config.http_proxy", cfg.GetHttpProxyConfig().GetHttpProxy()},
+		{"http_proxy_config.https_proxy", cfg.GetHttpProxyConfig().GetHttpsProxy()},
+		{"azure_private_registry_server", cfg.GetAzurePrivateRegistryServer()},
+		{"bootstrap_profile_container_registry_server", cfg.GetBootstrapProfileContainerRegistryServer()},
+	}
+
+	destinations := make([]egressDestination, 0, len(candidates))
+	for _, c := range candidates {
+		if c.value != "" {
+			destinations = append(destinations, c)
+		}
+	}
+	return destinations
+}
+
+// parseEgressDestination extracts the host and port from a URL, an image reference or a bare host name.
+// Destinations without a scheme are assumed to be contacted over HTTPS.
+func parseEgressDestination(value string) (string, int, error) {
+	if strings.Contains(value, "://") {
+		u, err := url.Parse(value)
+		if err != nil {
+			return "", 0, err
+		}
+		if u.Hostname() == "" {
+			return "", 0, fmt.Errorf("has no host")
+		}
+		port := defaultHTTPSPort
+		if strings.EqualFold(u.Scheme, "http") {
+			port = defaultHTTPPort
+		}
+		if u.Port() != "" {
+			if port, err = strconv.Atoi(u.Port()); err != nil {
+				return "", 0, err
+			}
+		}
+		return strings.ToLower(u.Hostname()), port, nil
+	}
+
+	host, _, _ := strings.Cut(value, "/")
+	if h, p, err := net.SplitHostPort(host); err == nil {
+		port, err := strconv.Atoi(p)
+		if err != nil {
+			return "", 0, err
+		}
+		return strings.ToLower(h), port, nil
+	}
+	return strings.ToLower(host), defaultHTTPSPort, nil
+}
+
+func egressRuleAllows(rule *aksnodeconfigv1.EgressRule, host string, port int) bool {
+	fqdn := strings.ToLower(rule.GetFqdn())
+	if suffix, ok := strings.CutPrefix(fqdn, "*."); ok {
+		if !strings.HasSuffix(host, "."+suffix) {
+			return false
+		}
+	} else if fqdn != host {
+		return false
+	}
+
+	if len(rule.GetPorts()) == 0 {
+		return true
+	}
+	for _, p := range rule.GetPorts() {
+		if int(p) == port {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateEgress checks every destination referenced by the configuration against the declared egress policy.
+// It returns an *EgressPolicyError listing all violations, or nil when no egress policy is declared.
+func ValidateEgress(cfg *aksnodeconfigv1.Configuration) error {
+	rules := cfg.GetEgressPolicyConfig().GetAllowedDestinations()
+	if len(rules) == 0 {
+		return nil
+	}
+
+	var violations []EgressViolation
+	for _, d := range egressDestinations(cfg) {
+		host, port, err := parseEgressDestination(d.value)
+		if err != nil {
+			violations = append(violations, EgressViolation{Field: d.field, Destination: d.value, Reason: err.Error()})
+			continue
+		}
+		allowed := false
+		for _, rule := range rules {
+			if egressRuleAllows(rule, host, port) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			violations = append(violations, EgressViolation{Field: d.field, Destination: d.value, Host: host, Port: port})
+		}
+	}
+
+	if len(violations) > 0 {
+		return &EgressPolicyError{Violations: violations}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package nodeconfigutils
+
+import (
+	"errors"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEgress(t *testing.T) {
+	baseConfig := func() *aksnodeconfigv1.Configuration {
+		return &aksnodeconfigv1.Configuration{
+			ApiServerConfig: &aksnodeconfigv1.ApiServerConfig{
+				ApiServerName: "mycluster.hcp.eastus.azmk8s.io",
+			},
+			KubeBinaryConfig: &aksnodeconfigv1.KubeBinaryConfig{
+				KubeBinaryUrl:             "https://acs-mirror.azureedge.net/kubernetes/v1.30.0/binaries/kubernetes-node-linux-amd64.tar.gz",
+				PodInfraContainerImageUrl: "mcr.microsoft.com/oss/kubernetes/pause:3.6",
+			},
+		}
+	}
+	tests := []struct {
+		name           string
+		policy         *aksnodeconfigv1.EgressPolicyConfig
+		mutate         func(*aksnodeconfigv1.Configuration)
+		wantViolations []EgressViolation
+	}{
+		{
+			name:   "no policy declared",
+			policy: nil,
+		},
+		{
+			name: "all destinations allowed",
+			policy: &aksnodeconfigv1.EgressPolicyConfig{
+				AllowedDestinations: []*aksnodeconfigv1.EgressRule{
+					{Fqdn: "*.azmk8s.io", Ports: []int32{443}},
+					{Fqdn: "acs-mirror.azureedge.net"},
+					{Fqdn: "mcr.microsoft.com", Ports: []int32{443}},
+				},
+			},
+		},
+		{
+			name: "destination not allowed",
+			policy: &aksnodeconfigv1.EgressPolicyConfig{
+				AllowedDestinations: []*aksnodeconfigv1.EgressRule{
+					{Fqdn: "*.azmk8s.io"},
+					{Fqdn: "mcr.microsoft.com"},
+				},
+			},
+			wantViolations: []EgressViolation{
+				{
+					Field:       "kube_binary_config.kube_binary_url",
+					Destination: "https://acs-mirror.azureedge.net/kubernetes/v1.30.0/binaries/kubernetes-node-linux-amd64.tar.gz",
+					Host:        "acs-mirror.azureedge.net",
+					Port:        443,
+				},
+			},
+		},
+		{
+			name: "port not allowed",
+			policy: &aksnodeconfigv1.EgressPolicyConfig{
+				AllowedDestinations: []*aksnodeconfigv1.EgressRule{
+					{Fqdn: "*.azmk8s.io"},
+					{Fqdn: "acs-mirror.azureedge.net"},
+					{Fqdn: "mcr.microsoft.com"},
+					{Fqdn: "proxy.contoso.com", Ports: []int32{443}},
+				},
+			},
+			mutate: func(cfg *aksnodeconfigv1.Configuration) {
+				cfg.HttpProxyConfig = &aksnodeconfigv1.HttpProxyConfig{HttpProxy: "http://proxy.contoso.com:3128"}
+			},
+			wantViolations: []EgressViolation{
+				{
+					Field:       "http_proxy_config.http_proxy",
+					Destination: "http://proxy.contoso.com:3128",
+					Host:        "proxy.contoso.com",
+					Port:        3128,
+				},
+			},
+		},
+		{
+			name: "wildcard does not match apex domain",
+			policy: &aksnodeconfigv1.EgressPolicyConfig{
+				AllowedDestinations: []*aksnodeconfigv1.EgressRule{
+					{Fqdn: "*.azmk8s.io"},
+					{Fqdn: "*.azureedge.net"},
+					{Fqdn: "*.microsoft.com"},
+					{Fqdn: "*.azurecr.io"},
+				},
+			},
+			mutate: func(cfg *aksnodeconfigv1.Configuration) {
+				cfg.AzurePrivateRegistryServer = "azurecr.io/private"
+			},
+			wantViolations: []EgressViolation{
+				{
+					Field:       "azure_private_registry_server",
+					Destination: "azurecr.io/private",
+					Host:        "azurecr.io",
+					Port:        443,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := baseConfig()
+			cfg.EgressPolicyConfig = tt.policy
+			if tt.mutate != nil {
+				tt.mutate(cfg)
+			}
+			err := ValidateEgress(cfg)
+			if len(tt.wantViolations) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var policyErr *EgressPolicyError
+			require.True(t, errors.As(err, &policyErr))
+			assert.Equal(t, tt.wantViolations, policyErr.Violations)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package nodeconfigutils
+
+import (
+	"fmt"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// ClusterOverride describes the per-cluster changes applied on top of a base configuration.
+type ClusterOverride struct {
+	// Name identifies the cluster in the report, it must be unique within a single call.
+	Name string
+	// Configuration is merged into a copy of the base configuration using proto.Merge semantics:
+	// populated scalar and message fields replace the base values, repeated fields are appended and map entries are overwritten.
+	Configuration *aksnodeconfigv1.Configuration
+}
+
+// ClusterPayload is the bootstrap payload generated for a single cluster.
+type ClusterPayload struct {
+	Name       string
+	CustomData string
+	CSE        string
+	// Cached is true when the payload was reused from another cluster with an identical effective configuration.
+	Cached bool
+	Err    error
+}
+
+// FleetReport is the combined result of generating payloads for many clusters.
+type FleetReport struct {
+	// Payloads are listed in the same order as the overrides passed to GenerateFleetPayloads.
+	Payloads  []ClusterPayload
+	Succeeded int
+	Failed    int
+	CacheHits int
+}
+
+// Errors returns the generation errors keyed by cluster name.
+func (r *FleetReport) Errors() map[string]error {
+	errs := map[string]error{}
+	for _, p := range r.Payloads {
+		if p.Err != nil {
+			errs[p.Name] = p.Err
+		}
+	}
+	return errs
+}
+
+type fleetCacheEntry struct {
+	customData string
+	err        error
+}
+
+// GenerateFleetPayloads produces bootstrap payloads for many clusters in one call.
+// Each cluster's effective configuration is the base configuration merged with its override.
+// Clusters that end up with an identical effective configuration share the same generated payload.
+// A failure for one cluster is recorded in the report and doesn't stop generation for the others.
+func GenerateFleetPayloads(base *aksnodeconfigv1.Configuration, overrides []ClusterOverride) (*FleetReport, error) {
+	seen := map[string]bool{}
+	for _, o := range overrides {
+		if o.Name == "" {
+			return nil, fmt.Errorf("cluster override name is required")
+		}
+		if seen[o.Name] {
+			return nil, fmt.Errorf("duplicate cluster override name %q", o.Name)
+		}
+		seen[o.Name] = true
+	}
+
+	report := &FleetReport{Payloads: make([]ClusterPayload, 0, len(overrides))}
+	cache := map[string]fleetCacheEntry{}
+	for _, o := range overrides {
+		payload := ClusterPayload{Name: o.Name, CSE: CSE}
+
+		cfg := proto.Clone(base).(*aksnodeconfigv1.Configuration)
+		if o.Configuration != nil {
+			proto.Merge(cfg, o.Configuration)
+		}
+
+		key, err := configurationCacheKey(cfg)
+		if err != nil {
+			payload.Err = err
+		} else if entry, ok := cache[key]; ok {
+			payload.CustomData, payload.Err, payload.Cached = entry.customData, entry.err, true
+			report.CacheHits++
+		} else {
+			payload.CustomData, payload.Err = generateValidatedCustomData(cfg)
+			cache[key] = fleetCacheEntry{customData: payload.CustomData, err: payload.Err}
+		}
+
+		if payload.Err != nil {
+			payload.CustomData = ""
+			report.Failed++
+		} else {
+			report.Succeeded++
+		}
+		report.Payloads = append(report.Payloads, payload)
+	}
+	return report, nil
+}
+
+func generateValidatedCustomData(cfg *aksnodeconfigv1.Configuration) (string, error) {
+	if err := Validate(cfg); err != nil {
+		return "", fmt.Errorf("invalid configuration: %w", err)
+	}
+	customData, err := CustomData(cfg)
+	if err != nil {
+		return "", fmt.Errorf("generate custom data: %w", err)
+	}
+	return customData, nil
+}
+
+func configurationCacheKey(cfg *aksnodeconfigv1.Configuration) (string, error) {
+	return ConfigurationHash(cfg)
+}
@@ -0,0 +1,113 @@
+package nodeconfigutils
+
+import (
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fleetBaseConfig() *aksnodeconfigv1.Configuration {
+	return &aksnodeconfigv1.Configuration{
+		Version: "v0",
+		AuthConfig: &aksnodeconfigv1.AuthConfig{
+			SubscriptionId: "subscription",
+		},
+		ClusterConfig: &aksnodeconfigv1.ClusterConfig{
+			ResourceGroup: "rg",
+			Location:      "eastus",
+			ClusterNetworkConfig: &aksnodeconfigv1.ClusterNetworkConfig{
+				VnetName:   "vnet",
+				RouteTable: "rt",
+			},
+		},
+		ApiServerConfig: &aksnodeconfigv1.ApiServerConfig{
+			ApiServerName: "base.hcp.eastus.azmk8s.io",
+		},
+	}
+}
+
+func TestGenerateFleetPayloads(t *testing.T) {
+	base := fleetBaseConfig()
+	overrides := []ClusterOverride{
+		{
+			Name: "cluster-a",
+			Configuration: &aksnodeconfigv1.Configuration{
+				ApiServerConfig: &aksnodeconfigv1.ApiServerConfig{ApiServerName: "a.hcp.eastus.azmk8s.io"},
+			},
+		},
+		{
+			Name: "cluster-b",
+			Configuration: &aksnodeconfigv1.Configuration{
+				ClusterConfig: &aksnodeconfigv1.ClusterConfig{Location: "westus"},
+			},
+		},
+		{
+			Name: "cluster-a-copy",
+			Configuration: &aksnodeconfigv1.Configuration{
+				ApiServerConfig: &aksnodeconfigv1.ApiServerConfig{ApiServerName: "a.hcp.eastus.azmk8s.io"},
+			},
+		},
+		{
+			Name: "cluster-invalid",
+			Configuration: &aksnodeconfigv1.Configuration{
+				Version: "v0",
+				AuthConfig: &aksnodeconfigv1.AuthConfig{
+					TenantId: "tenant",
+				},
+				EgressPolicyConfig: &aksnodeconfigv1.EgressPolicyConfig{
+					AllowedDestinations: []*aksnodeconfigv1.EgressRule{{Fqdn: "mcr.microsoft.com"}},
+				},
+			},
+		},
+	}
+
+	report, err := GenerateFleetPayloads(base, overrides)
+	require.NoError(t, err)
+	require.Len(t, report.Payloads, 4)
+	assert.Equal(t, 3, report.Succeeded)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 1, report.CacheHits)
+
+	a, b, aCopy, invalid := report.Payloads[0], report.Payloads[1], report.Payloads[2], report.Payloads[3]
+	assert.Equal(t, "cluster-a", a.Name)
+	assert.NotEmpty(t, a.CustomData)
+	assert.Equal(t, CSE, a.CSE)
+	assert.False(t, a.Cached)
+	assert.NotEqual(t, a.CustomData, b.CustomData)
+	assert.Equal(t, a.CustomData, aCopy.CustomData)
+	assert.True(t, aCopy.Cached)
+	assert.Error(t, invalid.Err)
+	assert.Empty(t, invalid.CustomData)
+	assert.Equal(t, map[string]error{"cluster-invalid": invalid.Err}, report.Errors())
+
+	// the base configuration must not be modified by the overrides.
+	assert.Equal(t, "base.hcp.eastus.azmk8s.io", base.GetApiServerConfig().GetApiServerName())
+	assert.Nil(t, base.GetEgressPolicyConfig())
+}
+
+func TestGenerateFleetPayloads_InvalidOverrides(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides []ClusterOverride
+		errString string
+	}{
+		{
+			name:      "missing name",
+			overrides: []ClusterOverride{{}},
+			errString: "cluster override name is required",
+		},
+		{
+			name:      "duplicate name",
+			overrides: []ClusterOverride{{Name: "a"}, {Name: "a"}},
+			errString: `duplicate cluster override name "a"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GenerateFleetPayloads(fleetBaseConfig(), tt.overrides)
+			assert.EqualError(t, err, tt.errString)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package nodeconfigutils
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ProvisionStatusSchemaVersion is the version of the provision.json schema written by this version of aks-node-controller.
+// provision.json documents without a SchemaVersion were written before the schema was versioned and are treated as "v0".
+const ProvisionStatusSchemaVersion = "v1"
+
+// ProvisionStatusJSONSchema is the JSON schema describing ProvisionStatus.
+//
+//go:embed provision_status.schema.json
+var ProvisionStatusJSONSchema []byte
+
+// ProvisionStatus is the content of provision.json. It is returned by provision-wait and surfaced through the CSE output.
+type ProvisionStatus struct {
+	// SchemaVersion is the version of this schema, empty for documents written before versioning.
+	SchemaVersion string `json:"SchemaVersion,omitempty"`
+	// ExitCode of the provisioning, "0" means success.
+	ExitCode string `json:"ExitCode"`
+	// Output is the tail of the provisioning log.
+	Output string `json:"Output"`
+	// Error is a short description of the failure, empty on success.
+	Error string `json:"Error"`
+	// ExecDuration is the provisioning duration in seconds.
+	ExecDuration            string            `json:"ExecDuration"`
+	KernelStartTime         string            `json:"KernelStartTime,omitempty"`
+	CloudInitLocalStartTime string            `json:"CloudInitLocalStartTime,omitempty"`
+	CloudInitStartTime      string            `json:"CloudInitStartTime,omitempty"`
+	CloudFinalStartTime     string            `json:"CloudFinalStartTime,omitempty"`
+	NetworkdStartTime       string            `json:"NetworkdStartTime,omitempty"`
+	CSEStartTime            string            `json:"CSEStartTime,omitempty"`
+	GuestAgentStartTime     string            `json:"GuestAgentStartTime,omitempty"`
+	SystemdSummary          string            `json:"SystemdSummary,omitempty"`
+	BootDatapoints          map[string]string `json:"BootDatapoints,omitempty"`
+	// Phases records the individual provisioning phases in execution order.
+	Phases []ProvisionPhase `json:"Phases,omitempty"`
+	// VHD describes the node image the node was provisioned from.
+	VHD *VHDMetadata `json:"VHD,omitempty"`
+}
+
+// ProvisionPhase is a single step of the provisioning process.
+type ProvisionPhase struct {
+	Name      string    `json:"Name"`
+	StartTime time.Time `json:"StartTime"`
+	EndTime   time.Time `json:"EndTime"`
+	// ExitCode of the phase, only meaningful once EndTime is set. EndTime is the zero time while the phase is running.
+	ExitCode int    `json:"ExitCode"`
+	Error    string `json:"Error,omitempty"`
+}
+
+// VHDMetadata describes the node image.
+type VHDMetadata struct {
+	ImageVersion string `json:"ImageVersion,omitempty"`
+	Distro       string `json:"Distro,omitempty"`
+	BuildID      string `json:"BuildID,omitempty"`
+}
+
+// Succeeded returns true if provisioning completed successfully.
+func (s *ProvisionStatus) Succeeded() bool {
+	return s.ExitCode == "0"
+}
+
+// Validate checks the status against the provision.json schema.
+func (s *ProvisionStatus) Validate() error {
+	switch s.SchemaVersion {
+	case "", ProvisionStatusSchemaVersion:
+	default:
+		return fmt.Errorf("unsupported provision status schema version %q", s.SchemaVersion)
+	}
+	if s.ExitCode == "" {
+		return errors.New("provision status ExitCode is required")
+	}
+	if _, err := strconv.Atoi(s.ExitCode); err != nil {
+		return fmt.Errorf("provision status ExitCode %q is not an integer", s.ExitCode)
+	}
+	if s.ExecDuration != "" {
+		if _, err := strconv.Atoi(s.ExecDuration); err != nil {
+			return fmt.Errorf("provision status ExecDuration %q is not an integer", s.ExecDuration)
+		}
+	}
+	for i, p := range s.Phases {
+		if p.Name == "" {
+			return fmt.Errorf("provision status phase %d has no Name", i)
+		}
+		if p.StartTime.IsZero() {
+			return fmt.Errorf("provision status phase %q has no StartTime", p.Name)
+		}
+		if !p.EndTime.IsZero() && p.EndTime.Before(p.StartTime) {
+			return fmt.Errorf("provision status phase %q ends before it starts", p.Name)
+		}
+	}
+	return nil
+}
+
+// ParseProvisionStatus unmarshals and validates the content of provision.json.
+func ParseProvisionStatus(data []byte) (*ProvisionStatus, error) {
+	status := &ProvisionStatus{}
+	if err := json.Unmarshal(data, status); err != nil {
+		return nil, fmt.Errorf("unmarshal provision status: %w", err)
+	}
+	if err := status.Validate(); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
@@ -0,0 +1,109 @@
+package nodeconfigutils
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProvisionStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name: "legacy provision.json without schema version",
+			data: `{"ExitCode":"0","Output":"done","Error":"","ExecDuration":"42","KernelStartTime":"Mon 2024-01-01 00:00:00 UTC"}`,
+		},
+		{
+			name: "v1 provision.json with phases and VHD metadata",
+			data: `{"SchemaVersion":"v1","ExitCode":"50","Output":"","Error":"","ExecDuration":"10",
+				"Phases":[{"Name":"download","StartTime":"2024-01-01T00:00:00Z","EndTime":"2024-01-01T00:00:05Z","ExitCode":50}],
+				"VHD":{"ImageVersion":"202401.01.0","Distro":"ubuntu2204"}}`,
+		},
+		{
+			name:    "unknown schema version",
+			data:    `{"SchemaVersion":"v2","ExitCode":"0"}`,
+			wantErr: `unsupported provision status schema version "v2"`,
+		},
+		{
+			name:    "missing exit code",
+			data:    `{"Output":"done"}`,
+			wantErr: "ExitCode is required",
+		},
+		{
+			name:    "non-integer exit code",
+			data:    `{"ExitCode":"failed"}`,
+			wantErr: `ExitCode "failed" is not an integer`,
+		},
+		{
+			name:    "non-integer duration",
+			data:    `{"ExitCode":"0","ExecDuration":"1m"}`,
+			wantErr: `ExecDuration "1m" is not an integer`,
+		},
+		{
+			name:    "phase without start time",
+			data:    `{"ExitCode":"0","Phases":[{"Name":"download"}]}`,
+			wantErr: `phase "download" has no StartTime`,
+		},
+		{
+			name:    "phase ending before it starts",
+			data:    `{"ExitCode":"0","Phases":[{"Name":"download","StartTime":"2024-01-01T00:00:05Z","EndTime":"2024-01-01T00:00:00Z"}]}`,
+			wantErr: `phase "download" ends before it starts`,
+		},
+		{
+			name:    "invalid json",
+			data:    `hello world`,
+			wantErr: "unmarshal provision status",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := ParseProvisionStatus([]byte(tt.data))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, status)
+		})
+	}
+}
+
+func TestProvisionStatusJSONSchemaMatchesStruct(t *testing.T) {
+	var schema struct {
+		Properties map[string]struct {
+			Items *struct {
+				Properties map[string]any `json:"properties"`
+			} `json:"items"`
+			Properties map[string]any `json:"properties"`
+		} `json:"properties"`
+	}
+	require.NoError(t, json.Unmarshal(ProvisionStatusJSONSchema, &schema))
+
+	assert.ElementsMatch(t, jsonFieldNames(reflect.TypeOf(ProvisionStatus{})), keys(schema.Properties))
+	require.NotNil(t, schema.Properties["Phases"].Items)
+	assert.ElementsMatch(t, jsonFieldNames(reflect.TypeOf(ProvisionPhase{})), keys(schema.Properties["Phases"].Items.Properties))
+	assert.ElementsMatch(t, jsonFieldNames(reflect.TypeOf(VHDMetadata{})), keys(schema.Properties["VHD"].Properties))
+}
+
+func jsonFieldNames(typ reflect.Type) []string {
+	names := []string{}
+	for i := 0; i < typ.NumField(); i++ {
+		names = append(names, strings.Split(typ.Field(i).Tag.Get("json"), ",")[0])
+	}
+	return names
+}
+
+func keys[V any](m map[string]V) []string {
+	result := []string{}
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
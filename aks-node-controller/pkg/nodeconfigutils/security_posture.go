@@ -0,0 +1,65 @@
+package nodeconfigutils
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// SecurityPostureSchemaVersion is the version of the security posture report written by this version of aks-node-controller.
+const SecurityPostureSchemaVersion = "v1"
+
+// SecurityPostureReport describes the security relevant state of a node at the end of provisioning.
+// It is written next to provision.json and is used as compliance evidence.
+type SecurityPostureReport struct {
+	SchemaVersion string    `json:"SchemaVersion"`
+	GeneratedAt   time.Time `json:"GeneratedAt"`
+	// HardeningProfile lists the hardening features enabled by the node configuration.
+	HardeningProfile HardeningProfile `json:"HardeningProfile"`
+	// FIPSEnabled is true when the kernel runs in FIPS mode.
+	FIPSEnabled bool `json:"FIPSEnabled"`
+	// SELinux is one of "enforcing", "permissive" or "disabled".
+	SELinux string `json:"SELinux"`
+	// AppArmor is either "enabled" or "disabled".
+	AppArmor string     `json:"AppArmor"`
+	SSH      SSHPosture `json:"SSH"`
+	// OpenPorts are the sockets listening on the node, sorted by protocol and port.
+	OpenPorts []ListeningPort `json:"OpenPorts"`
+}
+
+// HardeningProfile is the set of hardening options requested in the node configuration.
+type HardeningProfile struct {
+	IMDSRestriction   bool `json:"IMDSRestriction"`
+	EgressPolicy      bool `json:"EgressPolicy"`
+	UnattendedUpgrade bool `json:"UnattendedUpgrade"`
+	CustomCACerts     bool `json:"CustomCACerts"`
+	// LegacyKernelModulesDisabled is true when legacy filesystem and network protocol modules can't be loaded.
+	LegacyKernelModulesDisabled bool `json:"LegacyKernelModulesDisabled"`
+}
+
+// SSHPosture is the state of SSH access to the node.
+type SSHPosture struct {
+	// Enabled is the SSH setting of the node configuration.
+	Enabled bool `json:"Enabled"`
+	// Listening is true when something listens on the SSH port.
+	Listening bool `json:"Listening"`
+}
+
+// ListeningPort is a listening socket.
+type ListeningPort struct {
+	Protocol string `json:"Protocol"`
+	Address  string `json:"Address"`
+	Port     int    `json:"Port"`
+}
+
+// ParseSecurityPostureReport unmarshals a security posture report.
+func ParseSecurityPostureReport(data []byte) (*SecurityPostureReport, error) {
+	report := &SecurityPostureReport{}
+	if err := json.Unmarshal(data, report); err != nil {
+		return nil, fmt.Errorf("unmarshal security posture report: %w", err)
+	}
+	if report.SchemaVersion != SecurityPostureSchemaVersion {
+		return nil, fmt.Errorf("unsupported security posture report schema version %q", report.SchemaVersion)
+	}
+	return report, nil
+}
@@ -1,23 +1,23 @@
 package nodeconfigutils
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 
 	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/validation"
+	"github.com/Azure/agentbaker/pkg/agent/cloudinit"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 const (
-	cloudConfigTemplate = `#cloud-config
-write_files:
-- path: /opt/azure/containers/aks-node-controller-config.json
-  permissions: "0755"
-  owner: root
-  content: !!binary |
-   %s`
-	CSE = "/opt/azure/containers/aks-node-controller provision-wait"
+	// configPath is the path custom data writes the configuration to, aks-node-controller reads it from there.
+	configPath = "/opt/azure/containers/aks-node-controller-config.json"
+	CSE        = "/opt/azure/containers/aks-node-controller provision-wait"
 )
 
 func CustomData(cfg *aksnodeconfigv1.Configuration) (string, error) {
@@ -25,8 +25,16 @@ func CustomData(cfg *aksnodeconfigv1.Configuration) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal nbc, error: %w", err)
 	}
-	encodedNBCJson := base64.StdEncoding.EncodeToString(nbcJSON)
-	customDataYAML := fmt.Sprintf(cloudConfigTemplate, encodedNBCJson)
+	customDataYAML, err := cloudinit.Render([]cloudinit.WriteFile{{
+		Path:     configPath,
+		Owner:    "root",
+		Mode:     0755,
+		Encoding: cloudinit.EncodingBase64,
+		Content:  nbcJSON,
+	}})
+	if err != nil {
+		return "", err
+	}
 	return base64.StdEncoding.EncodeToString([]byte(customDataYAML)), nil
 }
 
@@ -46,21 +54,21 @@ func UnmarshalConfigurationV1(data []byte) (*aksnodeconfigv1.Configuration, erro
 	return cfg, err
 }
 
-func Validate(cfg *aksnodeconfigv1.Configuration) error {
-	requiredStrings := map[string]string{
-		"version":                                           cfg.GetVersion(),
-		"auth_config.subscription_id":                       cfg.GetAuthConfig().GetSubscriptionId(),
-		"cluster_config.resource_group":                     cfg.GetClusterConfig().GetResourceGroup(),
-		"cluster_config.location":                           cfg.GetClusterConfig().GetLocation(),
-		"cluster_config.cluster_network_config.vnet_name":   cfg.GetClusterConfig().GetClusterNetworkConfig().GetVnetName(),
-		"cluster_config.cluster_network_config.route_table": cfg.GetClusterConfig().GetClusterNetworkConfig().GetRouteTable(),
-		"api_server_config.api_server_name":                 cfg.GetApiServerConfig().GetApiServerName(),
+// ConfigurationHash returns the hex encoded sha256 of the deterministic binary encoding of cfg,
+// two configurations have the same hash when they are semantically equal.
+func ConfigurationHash(cfg *aksnodeconfigv1.Configuration) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(cfg)
+	if err != nil {
+		return "", fmt.Errorf("marshal configuration: %w", err)
 	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
 
-	for field, value := range requiredStrings {
-		if value == "" {
-			return fmt.Errorf("required field %v is missing", field)
-		}
+// Validate checks the fields of the configuration and its egress policy.
+func Validate(cfg *aksnodeconfigv1.Configuration) error {
+	if err := validation.Validate(cfg); err != nil {
+		return err
 	}
-	return nil
+	return ValidateEgress(cfg)
 }
@@ -0,0 +1,43 @@
+package nodeconfigutils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestCustomData(t *testing.T) {
+	cfg := fleetBaseConfig()
+	customData, err := CustomData(cfg)
+	require.NoError(t, err)
+	customDataYAML, err := base64.StdEncoding.DecodeString(customData)
+	require.NoError(t, err)
+	assert.Regexp(t, "^#cloud-config\n", string(customDataYAML))
+
+	var cloudConfig struct {
+		WriteFiles []struct {
+			Path        string `yaml:"path"`
+			Permissions string `yaml:"permissions"`
+			Owner       string `yaml:"owner"`
+			Encoding    string `yaml:"encoding"`
+			Content     string `yaml:"content"`
+		} `yaml:"write_files"`
+	}
+	require.NoError(t, yaml.Unmarshal(customDataYAML, &cloudConfig))
+	require.Len(t, cloudConfig.WriteFiles, 1)
+	file := cloudConfig.WriteFiles[0]
+	assert.Equal(t, "/opt/azure/containers/aks-node-controller-config.json", file.Path)
+	assert.Equal(t, "0755", file.Permissions)
+	assert.Equal(t, "root", file.Owner)
+	assert.Equal(t, "b64", file.Encoding)
+
+	content, err := base64.StdEncoding.DecodeString(file.Content)
+	require.NoError(t, err)
+	want, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(want), string(content))
+}
@@ -0,0 +1,156 @@
+// Package telemetry exports provisioning events in the schema used by the AKS provisioning telemetry pipelines,
+// so that self-hosted consumers can run the same analytics as AKS-managed fleets.
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Event is a single provisioning phase. Its JSON form matches the columns of the provisioning events table.
+type Event struct {
+	Timestamp   time.Time `json:"Timestamp"`
+	OperationID string    `json:"OperationId"`
+	Phase       string    `json:"Phase"`
+	// DurationMs is the duration of the phase in milliseconds.
+	DurationMs int64 `json:"DurationMs"`
+	// ErrorCode is the exit code of the phase, 0 on success.
+	ErrorCode int    `json:"ErrorCode"`
+	Message   string `json:"Message,omitempty"`
+	// ErrorBudgetImpact is true for failures which count against the provisioning error budget.
+	// Failures caused by an interrupted provisioning, e.g. the node being deleted, don't.
+	ErrorBudgetImpact bool `json:"ErrorBudgetImpact"`
+}
+
+// NewEvent returns the event of a phase which started at start and ended now.
+// A non-zero errorCode counts against the error budget unless cancelled is true.
+func NewEvent(operationID, phase string, start time.Time, errorCode int, message string, cancelled bool) Event {
+	return Event{
+		Timestamp:         start.UTC(),
+		OperationID:       operationID,
+		Phase:             phase,
+		DurationMs:        time.Since(start).Milliseconds(),
+		ErrorCode:         errorCode,
+		Message:           message,
+		ErrorBudgetImpact: errorCode != 0 && !cancelled,
+	}
+}
+
+// Exporter sends provisioning events to a telemetry sink.
+type Exporter interface {
+	Export(ctx context.Context, event Event) error
+}
+
+// NewOperationID returns a random identifier correlating all the events of one provisioning.
+func NewOperationID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Multi exports every event to all exporters.
+type Multi []Exporter
+
+// Export implements Exporter.
+func (m Multi) Export(ctx context.Context, event Event) error {
+	var errs []error
+	for _, e := range m {
+		if err := e.Export(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// JSONLines appends events to a file, one JSON object per line, which can be ingested as-is with the multijson format.
+type JSONLines struct {
+	Path string
+	mu   sync.Mutex
+}
+
+// Export implements Exporter.
+func (j *JSONLines) Export(_ context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err = os.MkdirAll(filepath.Dir(j.Path), 0755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", j.Path, err)
+	}
+	f, err := os.OpenFile(j.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", j.Path, err)
+	}
+	if _, err = f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("write %s: %w", j.Path, err)
+	}
+	return f.Close()
+}
+
+// GuestAgentEventsDir is the directory the Azure guest agent collects extension events from.
+const GuestAgentEventsDir = "/var/log/azure/Microsoft.Azure.Extensions.CustomScript/events"
+
+// guestAgentEventVersion is the event version used by the CSE scripts.
+const guestAgentEventVersion = "1.23"
+
+// GuestAgent writes events to the guest agent events directory, one file per event, the same way CSE reports its events.
+type GuestAgent struct {
+	Dir string
+}
+
+type guestAgentEvent struct {
+	Timestamp   string
+	OperationID string `json:"OperationId"`
+	Version     string
+	TaskName    string
+	EventLevel  string
+	Message     string
+	EventPid    string
+	EventTid    string
+}
+
+// Export implements Exporter.
+func (g *GuestAgent) Export(_ context.Context, event Event) error {
+	message, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+	level := "Informational"
+	if event.ErrorCode != 0 {
+		level = "Error"
+	}
+	data, err := json.Marshal(guestAgentEvent{
+		Timestamp:   event.Timestamp.Format("2006-01-02 15:04:05.000"),
+		OperationID: event.OperationID,
+		Version:     guestAgentEventVersion,
+		TaskName:    "AKS.AKSNodeController." + event.Phase,
+		EventLevel:  level,
+		Message:     string(message),
+		EventPid:    "0",
+		EventTid:    "0",
+	})
+	if err != nil {
+		return fmt.Errorf("marshal guest agent event: %w", err)
+	}
+	if err = os.MkdirAll(g.Dir, 0755); err != nil {
+		return fmt.Errorf("create %s: %w", g.Dir, err)
+	}
+	// the guest agent picks up every file of the directory, the name only needs to be unique.
+	name := strconv.FormatInt(time.Now().UnixNano(), 10) + ".json"
+	if err = os.WriteFile(filepath.Join(g.Dir, name), data, 0644); err != nil {
+		return fmt.Errorf("write event: %w", err)
+	}
+	return nil
+}
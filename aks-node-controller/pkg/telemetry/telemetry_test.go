@@ -0,0 +1,73 @@
+package telemetry
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEvent(t *testing.T) {
+	start := time.Now().Add(-2 * time.Second)
+
+	succeeded := NewEvent("op", "CSE", start, 0, "", false)
+	assert.Equal(t, "op", succeeded.OperationID)
+	assert.GreaterOrEqual(t, succeeded.DurationMs, int64(2000))
+	assert.False(t, succeeded.ErrorBudgetImpact)
+
+	failed := NewEvent("op", "CSE", start, 50, "outbound connectivity failed", false)
+	assert.True(t, failed.ErrorBudgetImpact)
+
+	cancelled := NewEvent("op", "CSE", start, 143, "", true)
+	assert.False(t, cancelled.ErrorBudgetImpact)
+}
+
+func TestJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events", "provision.jsonl")
+	exporter := &JSONLines{Path: path}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, exporter.Export(context.Background(), Event{Timestamp: start, OperationID: "op", Phase: "CSE", DurationMs: 1500}))
+	require.NoError(t, exporter.Export(context.Background(), Event{Timestamp: start, OperationID: "op", Phase: "Provision", ErrorCode: 1, ErrorBudgetImpact: true}))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	lines := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	assert.Equal(t, []string{
+		`{"Timestamp":"2024-01-01T00:00:00Z","OperationId":"op","Phase":"CSE","DurationMs":1500,"ErrorCode":0,"ErrorBudgetImpact":false}`,
+		`{"Timestamp":"2024-01-01T00:00:00Z","OperationId":"op","Phase":"Provision","DurationMs":0,"ErrorCode":1,"ErrorBudgetImpact":true}`,
+	}, lines)
+}
+
+func TestGuestAgent(t *testing.T) {
+	dir := t.TempDir()
+	exporter := Multi{&GuestAgent{Dir: dir}}
+	event := Event{Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), OperationID: "op", Phase: "ContainerdReadiness", ErrorCode: 1}
+	require.NoError(t, exporter.Export(context.Background(), event))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	data, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	require.NoError(t, err)
+
+	var got map[string]string
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, "2024-01-01 00:00:00.000", got["Timestamp"])
+	assert.Equal(t, "op", got["OperationId"])
+	assert.Equal(t, "AKS.AKSNodeController.ContainerdReadiness", got["TaskName"])
+	assert.Equal(t, "Error", got["EventLevel"])
+	var message Event
+	require.NoError(t, json.Unmarshal([]byte(got["Message"]), &message))
+	assert.Equal(t, event, message)
+}
@@ -0,0 +1,163 @@
+// Package validation checks an aksnodeconfig v1 configuration: required fields, enum ranges and cross-field rules.
+// It is shared by the code generating configurations and by aks-node-controller consuming them, so that a
+// configuration rejected on the node is already rejected where it's generated.
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/pkg/agent/kubeversion"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FieldError is an invalid field of the configuration.
+type FieldError struct {
+	// Path is the proto path of the field, such as kubelet_config.cpu_manager_policy or network_config.ip_families[1].
+	Path    string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// Errors are all the invalid fields of a configuration.
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// requiredFields are the string fields every configuration must set, in the order they are reported.
+var requiredFields = []struct {
+	path  string
+	value func(cfg *aksnodeconfigv1.Configuration) string
+}{
+	{"version", (*aksnodeconfigv1.Configuration).GetVersion},
+	{"auth_config.subscription_id", func(cfg *aksnodeconfigv1.Configuration) string { return cfg.GetAuthConfig().GetSubscriptionId() }},
+	{"cluster_config.resource_group", func(cfg *aksnodeconfigv1.Configuration) string { return cfg.GetClusterConfig().GetResourceGroup() }},
+	{"cluster_config.location", func(cfg *aksnodeconfigv1.Configuration) string { return cfg.GetClusterConfig().GetLocation() }},
+	{"cluster_config.cluster_network_config.vnet_name", func(cfg *aksnodeconfigv1.Configuration) string {
+		return cfg.GetClusterConfig().GetClusterNetworkConfig().GetVnetName()
+	}},
+	{"cluster_config.cluster_network_config.route_table", func(cfg *aksnodeconfigv1.Configuration) string {
+		return cfg.GetClusterConfig().GetClusterNetworkConfig().GetRouteTable()
+	}},
+	{"api_server_config.api_server_name", func(cfg *aksnodeconfigv1.Configuration) string { return cfg.GetApiServerConfig().GetApiServerName() }},
+}
+
+// Validate returns the Errors of every invalid field of cfg, or nil.
+func Validate(cfg *aksnodeconfigv1.Configuration) error {
+	var errs Errors
+	for _, field := range requiredFields {
+		if field.value(cfg) == "" {
+			errs = append(errs, &FieldError{Path: field.path, Message: "required field is missing"})
+		}
+	}
+	enumErrs := validateEnums(cfg.ProtoReflect(), "")
+	// fields are ranged over in an unspecified order.
+	sort.Slice(enumErrs, func(i, j int) bool { return enumErrs[i].Path < enumErrs[j].Path })
+	errs = append(errs, enumErrs...)
+	// the version is compared to the minimum versions of features, a version which doesn't parse would fail them all.
+	if version := cfg.GetKubernetesVersion(); version != "" {
+		if _, err := kubeversion.ParseLenient(version); err != nil {
+			errs = append(errs, &FieldError{Path: "kubernetes_version", Message: err.Error()})
+		}
+	}
+	errs = append(errs, validateCrossFields(cfg)...)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// validateEnums reports the enum fields of m, and of the messages it contains, set to a value their enum doesn't
+// define. Such values are accepted by the JSON and binary decoders when they are numbers.
+func validateEnums(m protoreflect.Message, prefix string) Errors {
+	var errs Errors
+	check := func(path string, enum protoreflect.EnumDescriptor, n protoreflect.EnumNumber) {
+		if enum.Values().ByNumber(n) == nil {
+			errs = append(errs, &FieldError{Path: path, Message: fmt.Sprintf("unknown %s value %d", enum.Name(), n)})
+		}
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		path := prefix + string(fd.Name())
+		switch {
+		case fd.IsList():
+			for i := 0; i < v.List().Len(); i++ {
+				elementPath := fmt.Sprintf("%s[%d]", path, i)
+				switch fd.Kind() {
+				case protoreflect.EnumKind:
+					check(elementPath, fd.Enum(), v.List().Get(i).Enum())
+				case protoreflect.MessageKind:
+					errs = append(errs, validateEnums(v.List().Get(i).Message(), elementPath+".")...)
+				}
+			}
+		case fd.IsMap():
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				v.Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+					errs = append(errs, validateEnums(value.Message(), fmt.Sprintf("%s[%q].", path, key.String()))...)
+					return true
+				})
+			}
+		case fd.Kind() == protoreflect.EnumKind:
+			check(path, fd.Enum(), v.Enum())
+		case fd.Kind() == protoreflect.MessageKind:
+			errs = append(errs, validateEnums(v.Message(), path+".")...)
+		}
+		return true
+	})
+	return errs
+}
+
+// validateCrossFields reports the fields which are only valid along with other fields.
+func validateCrossFields(cfg *aksnodeconfigv1.Configuration) Errors {
+	var errs Errors
+	bootstrapping := cfg.GetBootstrappingConfig()
+	if bootstrapping.GetBootstrappingAuthMethod() == aksnodeconfigv1.BootstrappingAuthMethod_BOOTSTRAPPING_AUTH_METHOD_BOOTSTRAP_TOKEN &&
+		bootstrapping.GetTlsBootstrappingToken() == "" {
+		errs = append(errs, &FieldError{
+			Path:    "bootstrapping_config.tls_bootstrapping_token",
+			Message: "required by the bootstrap token auth method",
+		})
+	}
+	kubeletConfig := cfg.GetKubeletConfig()
+	if kubeletConfig.GetKubeletClientCertContent() != "" && kubeletConfig.GetKubeletClientKey() == "" {
+		errs = append(errs, &FieldError{
+			Path:    "kubelet_config.kubelet_client_key",
+			Message: "required along with kubelet_config.kubelet_client_cert_content",
+		})
+	}
+	if cfg.EnableSsh != nil && cfg.GetEnableSsh() &&
+		cfg.GetSshConfig().GetMode() == aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_DISABLED {
+		errs = append(errs, &FieldError{Path: "ssh_config.mode", Message: "disables SSH, which contradicts enable_ssh"})
+	}
+	if cfg.GetNetworkConfig().GetMofedPackageUrl() != "" && !cfg.GetNetworkConfig().GetEnableInfiniband() {
+		errs = append(errs, &FieldError{
+			Path:    "network_config.mofed_package_url",
+			Message: "requires network_config.enable_infiniband",
+		})
+	}
+	if cfg.GetVmSecurityConfig().GetEnableMeasuredBoot() && !cfg.GetVmSecurityConfig().GetVtpmEnabled() {
+		errs = append(errs, &FieldError{
+			Path:    "vm_security_config.enable_measured_boot",
+			Message: "requires vm_security_config.vtpm_enabled",
+		})
+	}
+	return errs
+}
@@ -0,0 +1,108 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func validConfig() *aksnodeconfigv1.Configuration {
+	return &aksnodeconfigv1.Configuration{
+		Version:    "v0",
+		AuthConfig: &aksnodeconfigv1.AuthConfig{SubscriptionId: "sub"},
+		ClusterConfig: &aksnodeconfigv1.ClusterConfig{
+			ResourceGroup:        "rg",
+			Location:             "eastus",
+			ClusterNetworkConfig: &aksnodeconfigv1.ClusterNetworkConfig{VnetName: "vnet", RouteTable: "rt"},
+		},
+		ApiServerConfig: &aksnodeconfigv1.ApiServerConfig{ApiServerName: "mycluster.hcp.eastus.azmk8s.io"},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *aksnodeconfigv1.Configuration)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			mutate: func(*aksnodeconfigv1.Configuration) {},
+		},
+		{
+			name: "missing required fields",
+			mutate: func(cfg *aksnodeconfigv1.Configuration) {
+				cfg.Version = ""
+				cfg.ClusterConfig.ClusterNetworkConfig = nil
+			},
+			wantErr: "version: required field is missing; " +
+				"cluster_config.cluster_network_config.vnet_name: required field is missing; " +
+				"cluster_config.cluster_network_config.route_table: required field is missing",
+		},
+		{
+			name: "unknown enum values",
+			mutate: func(cfg *aksnodeconfigv1.Configuration) {
+				cfg.KubeletConfig = &aksnodeconfigv1.KubeletConfig{
+					CpuManagerPolicy: 7,
+					Taints:           []*aksnodeconfigv1.Taint{{Key: "key"}},
+				}
+				cfg.NetworkConfig = &aksnodeconfigv1.NetworkConfig{
+					IpFamilies: []aksnodeconfigv1.IPFamily{aksnodeconfigv1.IPFamily_IP_FAMILY_IPV4, 5},
+				}
+			},
+			wantErr: "kubelet_config.cpu_manager_policy: unknown CpuManagerPolicy value 7; " +
+				"network_config.ip_families[1]: unknown IPFamily value 5",
+		},
+		{
+			name:   "lenient Kubernetes version",
+			mutate: func(cfg *aksnodeconfigv1.Configuration) { cfg.KubernetesVersion = "v1.30" },
+		},
+		{
+			name:    "invalid Kubernetes version",
+			mutate:  func(cfg *aksnodeconfigv1.Configuration) { cfg.KubernetesVersion = "1.30.x" },
+			wantErr: `kubernetes_version: invalid version "1.30.x": Invalid character(s) found in patch number "x"`,
+		},
+		{
+			name: "cross-field rules",
+			mutate: func(cfg *aksnodeconfigv1.Configuration) {
+				cfg.BootstrappingConfig = &aksnodeconfigv1.BootstrappingConfig{
+					BootstrappingAuthMethod: aksnodeconfigv1.BootstrappingAuthMethod_BOOTSTRAPPING_AUTH_METHOD_BOOTSTRAP_TOKEN,
+				}
+				cfg.EnableSsh = proto.Bool(true)
+				cfg.SshConfig = &aksnodeconfigv1.SshConfig{Mode: aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_DISABLED}
+				cfg.NetworkConfig = &aksnodeconfigv1.NetworkConfig{MofedPackageUrl: "https://example.com/mofed.tgz"}
+				cfg.VmSecurityConfig = &aksnodeconfigv1.VmSecurityConfig{SecureBootEnabled: true, EnableMeasuredBoot: true}
+			},
+			wantErr: "bootstrapping_config.tls_bootstrapping_token: required by the bootstrap token auth method; " +
+				"ssh_config.mode: disables SSH, which contradicts enable_ssh; " +
+				"network_config.mofed_package_url: requires network_config.enable_infiniband; " +
+				"vm_security_config.enable_measured_boot: requires vm_security_config.vtpm_enabled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.mutate(cfg)
+			err := Validate(cfg)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestErrors_Unwrap(t *testing.T) {
+	cfg := validConfig()
+	cfg.ApiServerConfig = nil
+	err := Validate(cfg)
+
+	var fieldErr *FieldError
+	require.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "api_server_config.api_server_name", fieldErr.Path)
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/nodeconfigutils"
+)
+
+const defaultPrefetchConcurrency = 4
+
+// Download directories used by CSE, an artifact which is already present there is not downloaded again during provisioning.
+const (
+	k8sDownloadsDir                = "opt/kubernetes/downloads"
+	cniDownloadsDir                = "opt/cni/downloads"
+	credentialProviderDownloadsDir = "opt/credentialprovider/downloads"
+)
+
+type PrefetchFlags struct {
+	ProvisionConfig string
+	// Concurrency is the maximum number of artifacts fetched in parallel.
+	Concurrency int
+	// Root is prepended to the download directories, it is "/" on a node.
+	Root string
+}
+
+type prefetchArtifact struct {
+	// image is set for container images, which are pulled into the containerd k8s.io namespace.
+	image string
+	// url and dir are set for binaries, which are downloaded into dir.
+	url string
+	dir string
+}
+
+func (p prefetchArtifact) String() string {
+	if p.image != "" {
+		return p.image
+	}
+	return p.url
+}
+
+// Prefetch pulls the container images, binaries and credential provider plugins referenced by the configuration
+// onto disk, so that provisioning and the first pods don't wait for downloads.
+func (a *App) Prefetch(ctx context.Context, flags PrefetchFlags) error {
+	inputJSON, err := os.ReadFile(flags.ProvisionConfig)
+	if err != nil {
+		return fmt.Errorf("open provision file %s: %w", flags.ProvisionConfig, err)
+	}
+	config, err := nodeconfigutils.UnmarshalConfigurationV1(inputJSON)
+	if err != nil {
+		return fmt.Errorf("unmarshal provision config: %w", err)
+	}
+
+	concurrency := flags.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultPrefetchConcurrency
+	}
+	artifacts := prefetchArtifacts(config, flags.Root)
+	if len(config.GetTrustedCaCertificates()) > 0 {
+		// ctr pulls use the system trust store.
+		if err := validateTrustedCACertificates(config.GetTrustedCaCertificates()); err != nil {
+			return fmt.Errorf("invalid trusted CA certificates: %w", err)
+		}
+		if err := a.installTrustedCACertificates(ctx, config.GetTrustedCaCertificates(), nodeTrustStore(flags.Root)); err != nil {
+			return fmt.Errorf("install trusted CA certificates: %w", err)
+		}
+	}
+	d, err := a.newDownloader(config)
+	if err != nil {
+		return err
+	}
+
+	var (
+		mu    sync.Mutex
+		done  int
+		errs  []error
+		wg    sync.WaitGroup
+		slots = make(chan struct{}, concurrency)
+	)
+	for _, artifact := range artifacts {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(artifact prefetchArtifact) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			fetchErr := a.prefetch(ctx, artifact, d)
+
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			if fetchErr != nil {
+				errs = append(errs, fmt.Errorf("prefetch %s: %w", artifact, fetchErr))
+				slog.Error("prefetch failed", "artifact", artifact.String(), "progress", fmt.Sprintf("%d/%d", done, len(artifacts)), "error", fetchErr)
+				return
+			}
+			slog.Info("prefetched", "artifact", artifact.String(), "progress", fmt.Sprintf("%d/%d", done, len(artifacts)))
+		}(artifact)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// prefetchArtifacts returns the artifacts referenced by the configuration, duplicates are removed.
+func prefetchArtifacts(config *aksnodeconfigv1.Configuration, root string) []prefetchArtifact {
+	candidates := []prefetchArtifact{
+		{image: parser.SandboxImage(config)},
+		{image: config.GetKubeProxyUrl()},
+		{url: parser.KubeBinaryURL(config), dir: filepath.Join(root, k8sDownloadsDir)},
+		{url: config.GetKubeBinaryConfig().GetLinuxCredentialProviderUrl(), dir: filepath.Join(root, credentialProviderDownloadsDir)},
+		{url: config.GetNetworkConfig().GetVnetCniPluginsUrl(), dir: filepath.Join(root, cniDownloadsDir)},
+		{url: config.GetNetworkConfig().GetCniPluginsUrl(), dir: filepath.Join(root, cniDownloadsDir)},
+	}
+
+	artifacts := []prefetchArtifact{}
+	seen := map[prefetchArtifact]bool{}
+	for _, c := range candidates {
+		if (c.image == "" && c.url == "") || seen[c] {
+			continue
+		}
+		seen[c] = true
+		artifacts = append(artifacts, c)
+	}
+	return artifacts
+}
+
+// downloader holds what fetching artifacts needs besides the artifact itself.
+type downloader struct {
+	// client downloads files, through the configured proxy.
+	client *http.Client
+	// identity authenticates the fetches when it isn't nil.
+	identity *managedIdentity
+	// env is the environment of the ctr pulls, it carries the proxy settings.
+	env []string
+}
+
+// newDownloader returns the downloader of config, which trusts its CA certificates and goes through its proxy.
+func (a *App) newDownloader(config *aksnodeconfigv1.Configuration) (downloader, error) {
+	client, err := trustedClient(a.httpClient(), config.GetTrustedCaCertificates())
+	if err != nil {
+		return downloader{}, fmt.Errorf("trust CA certificates: %w", err)
+	}
+	client, err = proxyClient(client, config.GetHttpProxyConfig())
+	if err != nil {
+		return downloader{}, fmt.Errorf("configure http proxy: %w", err)
+	}
+	return downloader{
+		client:   client,
+		identity: newManagedIdentity(config.GetAuthConfig().GetDownloadIdentityClientId(), a.imdsEndpoint, client),
+		env:      append(os.Environ(), proxyEnv(config.GetHttpProxyConfig())...),
+	}, nil
+}
+
+// prefetch fetches a single artifact.
+func (a *App) prefetch(ctx context.Context, artifact prefetchArtifact, d downloader) error {
+	if artifact.image != "" {
+		args := []string{"--namespace", "k8s.io", "image", "pull"}
+		credentials, err := d.identity.registryCredentials(ctx, artifact.image)
+		if err != nil {
+			return err
+		}
+		if credentials != "" {
+			args = append(args, "--user", credentials)
+		}
+		cmd := exec.CommandContext(ctx, "ctr", append(args, artifact.image)...)
+		cmd.Env = d.env
+		cmd.Stdout = io.Discard
+		cmd.Stderr = os.Stderr
+		return a.cmdRunner(cmd)
+	}
+	return downloadFile(ctx, artifact.url, artifact.dir, d)
+}
+
+// downloadFile downloads rawURL into dir, keeping the file name of the URL. Existing files are kept.
+// Azure storage blobs are downloaded with the credentials of the downloader identity when it isn't nil.
+func downloadFile(ctx context.Context, rawURL, dir string, d downloader) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("parse url: %w", err)
+	}
+	name := path.Base(u.Path)
+	if name == "/" || name == "." {
+		return fmt.Errorf("url %s has no file name", rawURL)
+	}
+	dest := filepath.Join(dir, name)
+	if _, err = os.Stat(dest); err == nil {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	if err = d.identity.authorizeDownload(req); err != nil {
+		return err
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create %s: %w", dir, err)
+	}
+	tmpFile, err := os.CreateTemp(dir, "."+name+"-*")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name()) // no-op once the file has been renamed
+	if _, err = io.Copy(tmpFile, resp.Body); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("write %s: %w", tmpFile.Name(), err)
+	}
+	if err = tmpFile.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", tmpFile.Name(), err)
+	}
+	if err = os.Rename(tmpFile.Name(), dest); err != nil {
+		return fmt.Errorf("rename to %s: %w", dest, err)
+	}
+	return nil
+}
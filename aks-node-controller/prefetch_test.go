@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/nodeconfigutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_Prefetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing.tgz" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("content of " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	writeConfig := func(t *testing.T, config *aksnodeconfigv1.Configuration) string {
+		data, err := nodeconfigutils.MarshalConfigurationV1(config)
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "config.json")
+		require.NoError(t, os.WriteFile(path, data, 0644))
+		return path
+	}
+
+	t.Run("images and binaries are fetched", func(t *testing.T) {
+		root := t.TempDir()
+		var mu sync.Mutex
+		pulled := []string{}
+		app := &App{
+			client: server.Client(),
+			cmdRunner: func(cmd *exec.Cmd) error {
+				mu.Lock()
+				defer mu.Unlock()
+				pulled = append(pulled, cmd.Args[len(cmd.Args)-1])
+				return nil
+			},
+		}
+		configPath := writeConfig(t, &aksnodeconfigv1.Configuration{
+			Version: "v0",
+			KubeBinaryConfig: &aksnodeconfigv1.KubeBinaryConfig{
+				KubeBinaryUrl:              server.URL + "/kubernetes-node-linux-amd64.tar.gz",
+				PodInfraContainerImageUrl:  "mcr.microsoft.com/oss/kubernetes/pause:3.6",
+				LinuxCredentialProviderUrl: server.URL + "/azure-acr-credential-provider.tar.gz",
+			},
+			KubeProxyUrl: "mcr.microsoft.com/oss/kubernetes/kube-proxy:v1.30.0",
+			NetworkConfig: &aksnodeconfigv1.NetworkConfig{
+				VnetCniPluginsUrl: server.URL + "/azure-vnet-cni.tgz",
+				CniPluginsUrl:     server.URL + "/azure-vnet-cni.tgz",
+			},
+		})
+
+		err := app.Prefetch(context.Background(), PrefetchFlags{ProvisionConfig: configPath, Concurrency: 2, Root: root})
+		require.NoError(t, err)
+
+		sort.Strings(pulled)
+		assert.Equal(t, []string{"mcr.microsoft.com/oss/kubernetes/kube-proxy:v1.30.0", "mcr.microsoft.com/oss/kubernetes/pause:3.6"}, pulled)
+		for _, file := range []string{
+			"opt/kubernetes/downloads/kubernetes-node-linux-amd64.tar.gz",
+			"opt/credentialprovider/downloads/azure-acr-credential-provider.tar.gz",
+			"opt/cni/downloads/azure-vnet-cni.tgz",
+		} {
+			data, err := os.ReadFile(filepath.Join(root, file))
+			require.NoError(t, err)
+			assert.Equal(t, "content of /"+filepath.Base(file), string(data))
+		}
+	})
+
+	t.Run("artifacts are fetched through the http proxy", func(t *testing.T) {
+		var proxied []string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied = append(proxied, r.URL.String())
+			_, _ = w.Write([]byte("content of " + r.URL.Path))
+		}))
+		defer proxy.Close()
+		root := t.TempDir()
+		var env []string
+		app := &App{
+			client: &http.Client{},
+			cmdRunner: func(cmd *exec.Cmd) error {
+				env = cmd.Env
+				return nil
+			},
+		}
+		configPath := writeConfig(t, &aksnodeconfigv1.Configuration{
+			Version: "v0",
+			KubeBinaryConfig: &aksnodeconfigv1.KubeBinaryConfig{
+				KubeBinaryUrl:             "http://artifacts.contoso.com/kubernetes-node-linux-amd64.tar.gz",
+				PodInfraContainerImageUrl: "mcr.microsoft.com/oss/kubernetes/pause:3.6",
+			},
+			HttpProxyConfig: &aksnodeconfigv1.HttpProxyConfig{HttpProxy: proxy.URL},
+		})
+
+		err := app.Prefetch(context.Background(), PrefetchFlags{ProvisionConfig: configPath, Root: root})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"http://artifacts.contoso.com/kubernetes-node-linux-amd64.tar.gz"}, proxied)
+		assert.FileExists(t, filepath.Join(root, "opt/kubernetes/downloads/kubernetes-node-linux-amd64.tar.gz"))
+		assert.Contains(t, env, "HTTPS_PROXY="+proxy.URL)
+	})
+
+	t.Run("failures are reported after all artifacts are processed", func(t *testing.T) {
+		root := t.TempDir()
+		app := &App{client: server.Client(), cmdRunner: func(cmd *exec.Cmd) error { return nil }}
+		configPath := writeConfig(t, &aksnodeconfigv1.Configuration{
+			Version: "v0",
+			KubeBinaryConfig: &aksnodeconfigv1.KubeBinaryConfig{
+				KubeBinaryUrl: server.URL + "/kubernetes-node-linux-amd64.tar.gz",
+			},
+			NetworkConfig: &aksnodeconfigv1.NetworkConfig{
+				CniPluginsUrl: server.URL + "/missing.tgz",
+			},
+		})
+
+		err := app.Prefetch(context.Background(), PrefetchFlags{ProvisionConfig: configPath, Root: root})
+		assert.ErrorContains(t, err, "missing.tgz: unexpected status 404")
+		assert.FileExists(t, filepath.Join(root, "opt/kubernetes/downloads/kubernetes-node-linux-amd64.tar.gz"))
+		assert.NoFileExists(t, filepath.Join(root, "opt/cni/downloads/missing.tgz"))
+	})
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/nodeconfigutils"
+)
+
+const (
+	// provisionCancelledError is recorded in provision.json when provisioning is interrupted by a signal.
+	provisionCancelledError = "cancelled"
+	// cseCancelGracePeriod is how long the CSE process group is given to exit after SIGTERM before it is killed.
+	cseCancelGracePeriod = 10 * time.Second
+)
+
+func provisionCancelled(status *nodeconfigutils.ProvisionStatus) bool {
+	return status.Error == provisionCancelledError
+}
+
+// writeCancelledProvisionStatus records a terminal "cancelled" state in provision.json.
+// provision.complete is intentionally not created so that a restarted controller can provision again.
+func writeCancelledProvisionStatus(path string, output string, duration time.Duration) error {
+	return writeProvisionStatus(path, 128+int(syscall.SIGTERM), output, provisionCancelledError, duration)
+}
+
+// writeProvisionStatus overwrites provision.json with a status written by the controller instead of CSE.
+func writeProvisionStatus(path string, exitCode int, output, errMsg string, duration time.Duration) error {
+	status := nodeconfigutils.ProvisionStatus{
+		SchemaVersion: nodeconfigutils.ProvisionStatusSchemaVersion,
+		ExitCode:      strconv.Itoa(exitCode),
+		Output:        output,
+		Error:         errMsg,
+		ExecDuration:  strconv.Itoa(int(duration.Seconds())),
+	}
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("marshal provision status: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+// failProvision replaces the provision status written by CSE, if any, with a failure detected by the controller.
+// provision.complete is recreated after provision.json is updated so that provision-wait reports the failure.
+func failProvision(statusFiles ProvisionStatusFiles, exitCode int, output string, duration time.Duration, failure error) error {
+	if err := os.Remove(statusFiles.ProvisionCompleteFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove %s: %w", statusFiles.ProvisionCompleteFile, err)
+	}
+	if err := writeProvisionStatus(statusFiles.ProvisionJSONFile, exitCode, output, failure.Error(), duration); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(statusFiles.ProvisionCompleteFile), 0755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", statusFiles.ProvisionCompleteFile, err)
+	}
+	f, err := os.Create(statusFiles.ProvisionCompleteFile)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", statusFiles.ProvisionCompleteFile, err)
+	}
+	return f.Close()
+}
+
+// recordProvisionFailure records a provisioning failure detected by the controller for provision-wait, as cancelled
+// when ctx is done, and returns the error to fail provisioning with.
+func recordProvisionFailure(ctx context.Context, statusFiles ProvisionStatusFiles, duration time.Duration, failure error) error {
+	if ctx.Err() != nil {
+		if err := writeCancelledProvisionStatus(statusFiles.ProvisionJSONFile, "", duration); err != nil {
+			slog.Error("failed to record cancelled provision status", "error", err)
+		}
+		return fmt.Errorf("provisioning cancelled: %w", ctx.Err())
+	}
+	if err := failProvision(statusFiles, errToExitCode(failure), "", duration, failure); err != nil {
+		slog.Error("failed to record provisioning failure", "error", err)
+	}
+	return failure
+}
+
+// markProvisionInProgress creates the provision in progress marker, provision-wait doesn't report provision.complete
+// until it is removed.
+func markProvisionInProgress(statusFiles ProvisionStatusFiles) error {
+	if statusFiles.ProvisionInProgressFile == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(statusFiles.ProvisionInProgressFile), 0755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", statusFiles.ProvisionInProgressFile, err)
+	}
+	f, err := os.Create(statusFiles.ProvisionInProgressFile)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", statusFiles.ProvisionInProgressFile, err)
+	}
+	return f.Close()
+}
+
+// clearProvisionInProgress removes the provision in progress marker once the controller no longer updates the
+// provision status.
+func clearProvisionInProgress(statusFiles ProvisionStatusFiles) error {
+	if statusFiles.ProvisionInProgressFile == "" {
+		return nil
+	}
+	if err := os.Remove(statusFiles.ProvisionInProgressFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove %s: %w", statusFiles.ProvisionInProgressFile, err)
+	}
+	return nil
+}
+
+// provisionComplete reports whether provision.complete exists and the controller no longer provisions the node.
+func provisionComplete(statusFiles ProvisionStatusFiles) bool {
+	if _, err := os.Stat(statusFiles.ProvisionCompleteFile); err != nil {
+		return false
+	}
+	if statusFiles.ProvisionInProgressFile == "" {
+		return true
+	}
+	_, err := os.Stat(statusFiles.ProvisionInProgressFile)
+	return errors.Is(err, os.ErrNotExist)
+}
+
+// clearCancelledProvisionStatus removes provision.json left behind by a cancelled run,
+// so that provision-wait doesn't report a stale result once provisioning is restarted.
+func clearCancelledProvisionStatus(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	status, err := nodeconfigutils.ParseProvisionStatus(data)
+	if err != nil || !provisionCancelled(status) {
+		return nil //nolint:nilerr // provision.json which isn't ours to clear is left untouched
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("remove %s: %w", path, err)
+	}
+	return nil
+}
+
+// provisionPhases are the phases the controller timed, in execution order.
+type provisionPhases []nodeconfigutils.ProvisionPhase
+
+func (p *provisionPhases) add(name string, start, end time.Time, err error) {
+	*p = append(*p, nodeconfigutils.ProvisionPhase{
+		Name:      name,
+		StartTime: start.UTC(),
+		EndTime:   end.UTC(),
+		ExitCode:  errToExitCode(err),
+		Error:     errorMessage(err),
+	})
+}
+
+// recordProvisionDetails adds the phases and the node image to provision.json. The fields CSE wrote are kept as they
+// are, nothing is recorded when there is no provision.json.
+func recordProvisionDetails(path string, phases provisionPhases, vhd *nodeconfigutils.VHDMetadata) error {
+	if len(phases) == 0 && vhd == nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	status := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return fmt.Errorf("unmarshal %s: %w", path, err)
+	}
+	if len(phases) > 0 {
+		if status["Phases"], err = json.Marshal(phases); err != nil {
+			return fmt.Errorf("marshal provisioning phases: %w", err)
+		}
+	}
+	if vhd != nil {
+		if status["VHD"], err = json.Marshal(vhd); err != nil {
+			return fmt.Errorf("marshal VHD metadata: %w", err)
+		}
+	}
+	if data, err = json.Marshal(status); err != nil {
+		return fmt.Errorf("marshal provision status: %w", err)
+	}
+	return writeFileAtomic(path, data, 0644)
+}
+
+// readProvisionStatus reads provision.json and validates it against the provision status schema.
+// The raw content is returned so that provision-wait prints exactly what was written by CSE.
+func readProvisionStatus(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read provision.json: %w", err)
+	}
+	if _, err := nodeconfigutils.ParseProvisionStatus(data); err != nil {
+		return "", fmt.Errorf("invalid provision.json: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/nodeconfigutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCancelledProvisionStatus(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aks", "provision.json")
+
+	err := writeCancelledProvisionStatus(path, "partial output", 3*time.Second)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	status, err := nodeconfigutils.ParseProvisionStatus(data)
+	require.NoError(t, err)
+	assert.Equal(t, &nodeconfigutils.ProvisionStatus{
+		SchemaVersion: "v1",
+		ExitCode:      "143",
+		Output:        "partial output",
+		Error:         "cancelled",
+		ExecDuration:  "3",
+	}, status)
+	assert.True(t, provisionCancelled(status))
+}
+
+func TestClearCancelledProvisionStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantExists bool
+	}{
+		{
+			name:       "missing provision.json",
+			wantExists: false,
+		},
+		{
+			name:       "cancelled provision.json is removed",
+			content:    `{"ExitCode":"143","Output":"","Error":"cancelled","ExecDuration":"1"}`,
+			wantExists: false,
+		},
+		{
+			name:       "completed provision.json is kept",
+			content:    `{"ExitCode":"0","Output":"done","Error":"","ExecDuration":"42"}`,
+			wantExists: true,
+		},
+		{
+			name:       "unparsable provision.json is kept",
+			content:    `not json`,
+			wantExists: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "provision.json")
+			if tt.content != "" {
+				require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+			}
+
+			err := clearCancelledProvisionStatus(path)
+			assert.NoError(t, err)
+			_, statErr := os.Stat(path)
+			assert.Equal(t, tt.wantExists, statErr == nil)
+		})
+	}
+}
+
+func TestRecordProvisionDetails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "provision.json")
+	require.NoError(t, recordProvisionDetails(path, provisionPhases{{Name: "CSE"}}, nil))
+	assert.NoFileExists(t, path)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"ExitCode":"0","Output":"done","Error":"","ExecDuration":"42","CSEStartTime":"now"}`), 0644))
+	start := time.Date(2024, 10, 9, 12, 0, 0, 0, time.UTC)
+	var phases provisionPhases
+	phases.add("CSE", start, start.Add(time.Minute), nil)
+	phases.add("ContainerdReadiness", start.Add(time.Minute), start.Add(2*time.Minute), errors.New("containerd not ready"))
+	require.NoError(t, recordProvisionDetails(path, phases, &nodeconfigutils.VHDMetadata{ImageVersion: "202410.09.0"}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	status, err := nodeconfigutils.ParseProvisionStatus(data)
+	require.NoError(t, err)
+	assert.Equal(t, &nodeconfigutils.ProvisionStatus{
+		ExitCode:     "0",
+		Output:       "done",
+		ExecDuration: "42",
+		CSEStartTime: "now",
+		Phases: []nodeconfigutils.ProvisionPhase{
+			{Name: "CSE", StartTime: start, EndTime: start.Add(time.Minute)},
+			{Name: "ContainerdReadiness", StartTime: start.Add(time.Minute), EndTime: start.Add(2 * time.Minute), ExitCode: 1, Error: "containerd not ready"},
+		},
+		VHD: &nodeconfigutils.VHDMetadata{ImageVersion: "202410.09.0"},
+	}, status)
+}
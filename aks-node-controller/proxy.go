@@ -0,0 +1,138 @@
+package main
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// alwaysNoProxy are the Azure platform endpoints, which are only reachable from the node itself.
+var alwaysNoProxy = []string{"169.254.169.254", "168.63.129.16"}
+
+func hasProxy(proxyConfig *aksnodeconfigv1.HttpProxyConfig) bool {
+	return proxyConfig.GetHttpProxy() != "" || proxyConfig.GetHttpsProxy() != ""
+}
+
+// proxyClient returns a copy of client which sends requests through the proxy of proxyConfig and trusts the proxy
+// CA, or client itself when no proxy is configured.
+func proxyClient(client *http.Client, proxyConfig *aksnodeconfigv1.HttpProxyConfig) (*http.Client, error) {
+	if !hasProxy(proxyConfig) {
+		return client, nil
+	}
+	transport := clientTransport(client)
+	proxy, err := proxyFunc(proxyConfig)
+	if err != nil {
+		return nil, err
+	}
+	transport.Proxy = proxy
+	if proxyConfig.GetProxyTrustedCa() != "" {
+		pool, err := proxyCertPool(transport, proxyConfig.GetProxyTrustedCa())
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+	proxied := *client
+	proxied.Transport = transport
+	return &proxied, nil
+}
+
+// proxyCertPool returns the root CAs of transport with the proxy CA added. The CA is base64 encoded PEM, as passed
+// to CSE.
+func proxyCertPool(transport *http.Transport, encodedCA string) (*x509.CertPool, error) {
+	ca, err := base64.StdEncoding.DecodeString(encodedCA)
+	if err != nil {
+		return nil, fmt.Errorf("decode proxy trusted CA: %w", err)
+	}
+	pool, err := addRootCAs(transport, ca)
+	if err != nil {
+		return nil, fmt.Errorf("proxy trusted CA: %w", err)
+	}
+	return pool, nil
+}
+
+// proxyFunc returns the http.Transport Proxy function of proxyConfig. https requests use the https proxy, falling
+// back to the http proxy like CSE does.
+func proxyFunc(proxyConfig *aksnodeconfigv1.HttpProxyConfig) (func(*http.Request) (*url.URL, error), error) {
+	parse := func(raw string) (*url.URL, error) {
+		if raw == "" {
+			return nil, nil
+		}
+		u, err := url.Parse(raw)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy url %q", raw)
+		}
+		return u, nil
+	}
+	httpProxy, err := parse(proxyConfig.GetHttpProxy())
+	if err != nil {
+		return nil, err
+	}
+	httpsProxy, err := parse(proxyConfig.GetHttpsProxy())
+	if err != nil {
+		return nil, err
+	}
+	if httpsProxy == nil {
+		httpsProxy = httpProxy
+	}
+	noProxy := append(append([]string{}, alwaysNoProxy...), proxyConfig.GetNoProxyEntries()...)
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(noProxy, req.URL.Hostname()) {
+			return nil, nil
+		}
+		if req.URL.Scheme == "https" {
+			return httpsProxy, nil
+		}
+		return httpProxy, nil
+	}, nil
+}
+
+// bypassProxy returns whether host matches one of the no_proxy entries: "*", an IP, a CIDR, a domain which also
+// matches its subdomains, or a ".domain" which only matches subdomains.
+func bypassProxy(noProxy []string, host string) bool {
+	host = strings.ToLower(host)
+	ip := net.ParseIP(host)
+	for _, entry := range noProxy {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+			continue
+		case entry == "*":
+			return true
+		case ip != nil && strings.Contains(entry, "/"):
+			if _, cidr, err := net.ParseCIDR(entry); err == nil && cidr.Contains(ip) {
+				return true
+			}
+		case strings.HasPrefix(entry, "."):
+			if strings.HasSuffix(host, entry) {
+				return true
+			}
+		case host == entry || strings.HasSuffix(host, "."+entry):
+			return true
+		}
+	}
+	return false
+}
+
+// proxyEnv returns the proxy environment variables of the commands run by aks-node-controller, such as ctr pulls.
+func proxyEnv(proxyConfig *aksnodeconfigv1.HttpProxyConfig) []string {
+	if !hasProxy(proxyConfig) {
+		return nil
+	}
+	httpsProxy := proxyConfig.GetHttpsProxy()
+	if httpsProxy == "" {
+		httpsProxy = proxyConfig.GetHttpProxy()
+	}
+	noProxy := strings.Join(append(append([]string{}, alwaysNoProxy...), proxyConfig.GetNoProxyEntries()...), ",")
+	var env []string
+	if proxyConfig.GetHttpProxy() != "" {
+		env = append(env, "HTTP_PROXY="+proxyConfig.GetHttpProxy(), "http_proxy="+proxyConfig.GetHttpProxy())
+	}
+	return append(env, "HTTPS_PROXY="+httpsProxy, "https_proxy="+httpsProxy, "NO_PROXY="+noProxy, "no_proxy="+noProxy)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBypassProxy(t *testing.T) {
+	noProxy := []string{"localhost", ".svc", "10.0.0.0/8", "Contoso.com", " 192.168.1.1 "}
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{host: "localhost", want: true},
+		{host: "kubernetes.default.svc", want: true},
+		{host: "svc", want: false},
+		{host: "10.1.2.3", want: true},
+		{host: "11.1.2.3", want: false},
+		{host: "contoso.com", want: true},
+		{host: "packages.contoso.com", want: true},
+		{host: "notcontoso.com", want: false},
+		{host: "192.168.1.1", want: true},
+		{host: "mcr.microsoft.com", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			assert.Equal(t, tt.want, bypassProxy(noProxy, tt.host))
+		})
+	}
+	assert.True(t, bypassProxy([]string{"*"}, "mcr.microsoft.com"))
+}
+
+func TestProxyClient(t *testing.T) {
+	t.Run("client is unchanged without proxy", func(t *testing.T) {
+		client := &http.Client{}
+		proxied, err := proxyClient(client, &aksnodeconfigv1.HttpProxyConfig{NoProxyEntries: []string{"localhost"}})
+		require.NoError(t, err)
+		assert.Same(t, client, proxied)
+	})
+
+	t.Run("requests go through the proxy except no_proxy and platform endpoints", func(t *testing.T) {
+		proxied, err := proxyClient(&http.Client{}, &aksnodeconfigv1.HttpProxyConfig{
+			HttpProxy:      "http://proxy.contoso.com:3128",
+			NoProxyEntries: []string{".contoso.com"},
+		})
+		require.NoError(t, err)
+		proxy := proxied.Transport.(*http.Transport).Proxy
+		for rawURL, want := range map[string]string{
+			"https://mcr.microsoft.com/v2/":               "http://proxy.contoso.com:3128",
+			"http://packages.microsoft.com/":              "http://proxy.contoso.com:3128",
+			"https://packages.contoso.com/":               "",
+			"http://169.254.169.254/metadata/identity":    "",
+			"http://168.63.129.16/machine?comp=goalstate": "",
+		} {
+			req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+			require.NoError(t, err)
+			proxyURL, err := proxy(req)
+			require.NoError(t, err)
+			if want == "" {
+				assert.Nil(t, proxyURL, rawURL)
+			} else {
+				assert.Equal(t, want, proxyURL.String(), rawURL)
+			}
+		}
+	})
+
+	t.Run("https requests use the https proxy", func(t *testing.T) {
+		proxied, err := proxyClient(&http.Client{}, &aksnodeconfigv1.HttpProxyConfig{
+			HttpProxy:  "http://proxy.contoso.com:3128",
+			HttpsProxy: "http://secure-proxy.contoso.com:3129",
+		})
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodGet, "https://mcr.microsoft.com/v2/", nil)
+		require.NoError(t, err)
+		proxyURL, err := proxied.Transport.(*http.Transport).Proxy(req)
+		require.NoError(t, err)
+		assert.Equal(t, "http://secure-proxy.contoso.com:3129", proxyURL.String())
+	})
+
+	t.Run("proxy trusted CA is trusted", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("ok"))
+		}))
+		defer server.Close()
+		ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+		proxied, err := proxyClient(&http.Client{}, &aksnodeconfigv1.HttpProxyConfig{
+			HttpsProxy:     "http://proxy.contoso.com:3128",
+			NoProxyEntries: []string{"127.0.0.1"},
+			ProxyTrustedCa: base64.StdEncoding.EncodeToString(ca),
+		})
+		require.NoError(t, err)
+		resp, err := proxied.Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("invalid settings are rejected", func(t *testing.T) {
+		_, err := proxyClient(&http.Client{}, &aksnodeconfigv1.HttpProxyConfig{HttpProxy: "proxy"})
+		assert.ErrorContains(t, err, `invalid proxy url "proxy"`)
+		_, err = proxyClient(&http.Client{}, &aksnodeconfigv1.HttpProxyConfig{
+			HttpProxy:      "http://proxy.contoso.com:3128",
+			ProxyTrustedCa: base64.StdEncoding.EncodeToString([]byte("not a certificate")),
+		})
+		assert.ErrorContains(t, err, "proxy trusted CA: no PEM certificate found")
+	})
+}
+
+func TestProxyEnv(t *testing.T) {
+	assert.Empty(t, proxyEnv(nil))
+	assert.Equal(t, []string{
+		"HTTP_PROXY=http://proxy.contoso.com:3128",
+		"http_proxy=http://proxy.contoso.com:3128",
+		"HTTPS_PROXY=http://proxy.contoso.com:3128",
+		"https_proxy=http://proxy.contoso.com:3128",
+		"NO_PROXY=169.254.169.254,168.63.129.16,localhost",
+		"no_proxy=169.254.169.254,168.63.129.16,localhost",
+	}, proxyEnv(&aksnodeconfigv1.HttpProxyConfig{HttpProxy: "http://proxy.contoso.com:3128", NoProxyEntries: []string{"localhost"}}))
+}
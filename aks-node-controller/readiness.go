@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os/exec"
+	"time"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+)
+
+const (
+	containerdRuntimeEndpoint   = "unix:///run/containerd/containerd.sock"
+	containerdReadinessTimeout  = 2 * time.Minute
+	containerdReadinessInterval = 2 * time.Second
+	// containerdNotReadyExitCode is recorded in provision.json when CSE succeeded but containerd isn't usable.
+	containerdNotReadyExitCode = 1
+)
+
+type containerdReadiness struct {
+	// SandboxImage must be present in the k8s.io namespace, kubelet can't create any pod without it.
+	SandboxImage string
+	Timeout      time.Duration
+	Interval     time.Duration
+}
+
+// waitForContainerdReady polls containerd until its CRI API responds and the sandbox image is present,
+// so that provisioning isn't reported as complete while kubelet would start against a half-configured runtime.
+func (a *App) waitForContainerdReady(ctx context.Context, readiness containerdReadiness) error {
+	ctx, cancel := context.WithTimeout(ctx, readiness.Timeout)
+	defer cancel()
+	for {
+		err := a.checkContainerdReady(ctx, readiness.SandboxImage)
+		if err == nil {
+			return nil
+		}
+		slog.Info("containerd is not ready yet", "error", err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("containerd not ready after %s: %w", readiness.Timeout, err)
+		case <-time.After(readiness.Interval):
+		}
+	}
+}
+
+func (a *App) checkContainerdReady(ctx context.Context, sandboxImage string) error {
+	if err := a.runCrictl(ctx, "info"); err != nil {
+		return fmt.Errorf("CRI API not responding: %w", err)
+	}
+	if sandboxImage == "" {
+		return nil
+	}
+	if err := a.runCrictl(ctx, "inspecti", "-q", sandboxImage); err != nil {
+		return fmt.Errorf("sandbox image %s not present: %w", sandboxImage, err)
+	}
+	return nil
+}
+
+// pinImage labels the image as pinned in the k8s.io namespace, so that neither kubelet nor containerd garbage collect
+// it. containerd stores images pulled by digest without their tag.
+func (a *App) pinImage(ctx context.Context, image string) error {
+	cmd := exec.CommandContext(ctx, "ctr", "--namespace", "k8s.io", "images", "label", parser.PinnedImageName(image), "io.cri-containerd.pinned=pinned")
+	cmd.Stdout = io.Discard
+	if err := a.cmdRunner(cmd); err != nil {
+		return fmt.Errorf("pin image %s: %w", image, err)
+	}
+	return nil
+}
+
+func (a *App) runCrictl(ctx context.Context, args ...string) error {
+	cmd := exec.CommandContext(ctx, "crictl", append([]string{"--runtime-endpoint", containerdRuntimeEndpoint}, args...)...)
+	cmd.Stdout = io.Discard
+	cmd.Stderr = io.Discard
+	slog.Debug("running crictl", "args", cmd.Args)
+	return a.cmdRunner(cmd)
+}
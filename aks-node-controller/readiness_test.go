@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/nodeconfigutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_WaitForContainerdReady(t *testing.T) {
+	readiness := containerdReadiness{
+		SandboxImage: "mcr.microsoft.com/oss/kubernetes/pause:3.6",
+		Timeout:      200 * time.Millisecond,
+		Interval:     time.Millisecond,
+	}
+
+	t.Run("ready once the CRI API responds and the sandbox image is present", func(t *testing.T) {
+		infoCalls := 0
+		commands := [][]string{}
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			commands = append(commands, cmd.Args[3:])
+			if cmd.Args[3] == "info" {
+				infoCalls++
+				if infoCalls < 3 {
+					return errors.New("connection refused")
+				}
+			}
+			return nil
+		}}
+
+		require.NoError(t, app.waitForContainerdReady(context.Background(), readiness))
+		assert.Equal(t, 3, infoCalls)
+		assert.Equal(t, []string{"inspecti", "-q", "mcr.microsoft.com/oss/kubernetes/pause:3.6"}, commands[len(commands)-1])
+	})
+
+	t.Run("missing sandbox image", func(t *testing.T) {
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			if cmd.Args[3] == "inspecti" {
+				return &ExitError{Code: 1}
+			}
+			return nil
+		}}
+
+		err := app.waitForContainerdReady(context.Background(), readiness)
+		assert.ErrorContains(t, err, "sandbox image mcr.microsoft.com/oss/kubernetes/pause:3.6 not present")
+	})
+}
+
+func TestApp_PinImage(t *testing.T) {
+	var args []string
+	app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+		args = cmd.Args
+		return nil
+	}}
+	digest := "sha256:" + strings.Repeat("a", 64)
+	require.NoError(t, app.pinImage(context.Background(), "mcr.microsoft.com/oss/kubernetes/pause:3.6@"+digest))
+	assert.Equal(t, []string{"ctr", "--namespace", "k8s.io", "images", "label", "mcr.microsoft.com/oss/kubernetes/pause@" + digest, "io.cri-containerd.pinned=pinned"}, args)
+
+	app.cmdRunner = func(cmd *exec.Cmd) error { return errors.New("image not found") }
+	err := app.pinImage(context.Background(), "mcr.microsoft.com/oss/kubernetes/pause:3.6")
+	assert.EqualError(t, err, "pin image mcr.microsoft.com/oss/kubernetes/pause:3.6: image not found")
+}
+
+func TestApp_ProvisionSkipsContainerdReadinessOnWindows(t *testing.T) {
+	paths := testProvisionPaths(t)
+	configPath := testProvisionConfig(t, func(config *aksnodeconfigv1.Configuration) {
+		config.KubeBinaryConfig = &aksnodeconfigv1.KubeBinaryConfig{PodInfraContainerImageUrl: "mcr.microsoft.com/oss/kubernetes/pause:3.6"}
+		config.WindowsConfig = &aksnodeconfigv1.WindowsConfig{KubernetesPackageUrl: "https://acs-mirror.azureedge.net/kubernetes/v1.30.0/windowszip/v1.30.0-1int.zip"}
+	})
+	var commands []string
+	app := &App{
+		imdsComputeEndpoint: "http://127.0.0.1:0",
+		cmdRunner: func(cmd *exec.Cmd) error {
+			commands = append(commands, filepath.Base(cmd.Args[0]))
+			return nil
+		},
+	}
+
+	require.NoError(t, app.provision(context.Background(), ProvisionFlags{ProvisionConfig: configPath}, paths))
+	assert.Equal(t, []string{"powershell.exe"}, commands)
+}
+
+func TestFailProvision(t *testing.T) {
+	dir := t.TempDir()
+	statusFiles := ProvisionStatusFiles{
+		ProvisionJSONFile:     filepath.Join(dir, "provision.json"),
+		ProvisionCompleteFile: filepath.Join(dir, "provision.complete"),
+	}
+	require.NoError(t, os.WriteFile(statusFiles.ProvisionJSONFile, []byte(`{"ExitCode":"0","Output":"","Error":"","ExecDuration":"1"}`), 0644))
+	require.NoError(t, os.WriteFile(statusFiles.ProvisionCompleteFile, nil, 0644))
+
+	err := failProvision(statusFiles, containerdNotReadyExitCode, "output", time.Second, errors.New("containerd not ready after 2m0s"))
+	require.NoError(t, err)
+
+	assert.FileExists(t, statusFiles.ProvisionCompleteFile)
+	data, err := os.ReadFile(statusFiles.ProvisionJSONFile)
+	require.NoError(t, err)
+	status, err := nodeconfigutils.ParseProvisionStatus(data)
+	require.NoError(t, err)
+	assert.False(t, status.Succeeded())
+	assert.Equal(t, "containerd not ready after 2m0s", status.Error)
+}
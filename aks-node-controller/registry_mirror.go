@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/nodeconfigutils"
+)
+
+// managedRegistriesFile lists the registries whose host directory is owned by aks-node-controller,
+// so that mirrors removed from the configuration are removed from the node as well.
+const managedRegistriesFile = ".aks-node-controller-registries.json"
+
+type RegistryMirrorFlags struct {
+	ProvisionConfig string
+	// CertsDir is the containerd registry config_path, hosts.toml files are written to <CertsDir>/<registry>/hosts.toml.
+	CertsDir string
+}
+
+// UpdateRegistryMirrors re-renders the containerd hosts.toml files from the configuration.
+// containerd reads hosts.toml from config_path on every pull, so the new mirrors are used without restarting containerd.
+// A registry without mirror gets its hosts.toml removed, which falls back to pulling from the upstream registry.
+func (a *App) UpdateRegistryMirrors(_ context.Context, flags RegistryMirrorFlags) error {
+	inputJSON, err := os.ReadFile(flags.ProvisionConfig)
+	if err != nil {
+		return fmt.Errorf("open provision file %s: %w", flags.ProvisionConfig, err)
+	}
+	config, err := nodeconfigutils.UnmarshalConfigurationV1(inputJSON)
+	if err != nil {
+		return fmt.Errorf("unmarshal provision config: %w", err)
+	}
+	return applyRegistryMirrors(config, flags.CertsDir)
+}
+
+// nodeContainerdCertsDir returns the config_path of the containerd registry configuration of the node.
+func nodeContainerdCertsDir(config *aksnodeconfigv1.Configuration) string {
+	if parser.IsWindows(config) {
+		return parser.WindowsContainerdCertsDir
+	}
+	return containerdCertsDir
+}
+
+// applyRegistryMirrors writes the host directories of the configured registry mirrors under certsDir and removes
+// the ones of registries which no longer have a mirror.
+func applyRegistryMirrors(config *aksnodeconfigv1.Configuration, certsDir string) error {
+	dirs, err := parser.ContainerdHostDirs(config)
+	if err != nil {
+		return fmt.Errorf("render registry mirrors: %w", err)
+	}
+	previous, err := readManagedRegistries(certsDir)
+	if err != nil {
+		return err
+	}
+	for _, registry := range previous {
+		if _, ok := dirs[registry]; !ok {
+			dirs[registry] = nil
+		}
+	}
+
+	var managed []string
+	for registry, files := range dirs {
+		dir := filepath.Join(certsDir, registry)
+		if len(files) == 0 {
+			if err := os.RemoveAll(dir); err != nil {
+				return fmt.Errorf("remove %s: %w", dir, err)
+			}
+			slog.Info("registry mirror removed", "registry", registry)
+			continue
+		}
+		if err := writeHostDir(dir, files); err != nil {
+			return err
+		}
+		managed = append(managed, registry)
+		slog.Info("registry mirror updated", "registry", registry, "path", dir)
+	}
+	sort.Strings(managed)
+	data, err := json.Marshal(managed)
+	if err != nil {
+		return fmt.Errorf("marshal managed registries: %w", err)
+	}
+	return writeFileAtomic(filepath.Join(certsDir, managedRegistriesFile), data, 0644)
+}
+
+// writeHostDir writes files into dir and removes any other file of dir, which belonged to a previous mirror.
+func writeHostDir(dir string, files map[string]string) error {
+	for name, content := range files {
+		if err := writeFileAtomic(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if _, ok := files[entry.Name()]; ok {
+			continue
+		}
+		if err = os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return fmt.Errorf("remove %s: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
+
+func readManagedRegistries(certsDir string) ([]string, error) {
+	path := filepath.Join(certsDir, managedRegistriesFile)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	var registries []string
+	if err = json.Unmarshal(data, &registries); err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", path, err)
+	}
+	return registries, nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it, readers never see a partial file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", path, err)
+	}
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name()) // no-op once the file has been renamed
+	if _, err = tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("write %s: %w", tmpFile.Name(), err)
+	}
+	if err = tmpFile.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", tmpFile.Name(), err)
+	}
+	if err = os.Chmod(tmpFile.Name(), perm); err != nil {
+		return fmt.Errorf("chmod %s: %w", tmpFile.Name(), err)
+	}
+	if err = os.Rename(tmpFile.Name(), path); err != nil {
+		return fmt.Errorf("rename to %s: %w", path, err)
+	}
+	return nil
+}
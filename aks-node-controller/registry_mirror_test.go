@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/nodeconfigutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_UpdateRegistryMirrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		registryServer string
+		existing       string
+		wantHostsTOML  string
+	}{
+		{
+			name:           "mirror is added",
+			registryServer: "testserver.azurecr.io",
+			wantHostsTOML:  "[host.\"https://testserver.azurecr.io\"]\n  capabilities = [\"pull\", \"resolve\"]\n",
+		},
+		{
+			name:           "mirror with repository prefix replaces the previous one",
+			registryServer: "failover.azurecr.io/mcr",
+			existing:       "[host.\"https://testserver.azurecr.io\"]\n  capabilities = [\"pull\", \"resolve\"]\n",
+			wantHostsTOML:  "[host.\"https://failover.azurecr.io/v2/mcr\"]\n  capabilities = [\"pull\", \"resolve\"]\n  override_path = true\n",
+		},
+		{
+			name:     "mirror is removed",
+			existing: "[host.\"https://testserver.azurecr.io\"]\n  capabilities = [\"pull\", \"resolve\"]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certsDir := t.TempDir()
+			hostsTOML := filepath.Join(certsDir, "mcr.microsoft.com", "hosts.toml")
+			if tt.existing != "" {
+				require.NoError(t, os.MkdirAll(filepath.Dir(hostsTOML), 0755))
+				require.NoError(t, os.WriteFile(hostsTOML, []byte(tt.existing), 0644))
+			}
+			data, err := nodeconfigutils.MarshalConfigurationV1(&aksnodeconfigv1.Configuration{
+				Version:                                 "v0",
+				BootstrapProfileContainerRegistryServer: tt.registryServer,
+			})
+			require.NoError(t, err)
+			configPath := filepath.Join(t.TempDir(), "config.json")
+			require.NoError(t, os.WriteFile(configPath, data, 0644))
+
+			app := &App{}
+			require.NoError(t, app.UpdateRegistryMirrors(context.Background(), RegistryMirrorFlags{ProvisionConfig: configPath, CertsDir: certsDir}))
+
+			content, err := os.ReadFile(hostsTOML)
+			if tt.wantHostsTOML == "" {
+				assert.True(t, os.IsNotExist(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHostsTOML, string(content))
+			entries, err := os.ReadDir(filepath.Dir(hostsTOML))
+			require.NoError(t, err)
+			assert.Len(t, entries, 1, "temporary files should be cleaned up")
+		})
+	}
+}
+
+func TestApp_UpdateRegistryMirrors_RegistryMirrors(t *testing.T) {
+	certsDir := t.TempDir()
+	writeConfig := func(t *testing.T, mirrors ...*aksnodeconfigv1.RegistryMirror) string {
+		data, err := nodeconfigutils.MarshalConfigurationV1(&aksnodeconfigv1.Configuration{Version: "v0", RegistryMirrors: mirrors})
+		require.NoError(t, err)
+		configPath := filepath.Join(t.TempDir(), "config.json")
+		require.NoError(t, os.WriteFile(configPath, data, 0644))
+		return configPath
+	}
+	app := &App{}
+
+	configPath := writeConfig(t, &aksnodeconfigv1.RegistryMirror{
+		Registry: "docker.io",
+		Endpoints: []*aksnodeconfigv1.RegistryMirrorEndpoint{
+			{Url: "https://mirror.contoso.com", RewritePrefix: "dockerhub", CaBundle: "-----BEGIN CERTIFICATE-----"},
+			{Url: "http://10.0.0.4:5000", SkipVerify: true},
+		},
+	})
+	require.NoError(t, app.UpdateRegistryMirrors(context.Background(), RegistryMirrorFlags{ProvisionConfig: configPath, CertsDir: certsDir}))
+
+	hostsTOML, err := os.ReadFile(filepath.Join(certsDir, "docker.io", "hosts.toml"))
+	require.NoError(t, err)
+	assert.Equal(t, `[host."https://mirror.contoso.com/v2/dockerhub"]
+  capabilities = ["pull", "resolve"]
+  override_path = true
+  ca = "ca-0.crt"
+
+[host."http://10.0.0.4:5000"]
+  capabilities = ["pull", "resolve"]
+  skip_verify = true
+`, string(hostsTOML))
+	ca, err := os.ReadFile(filepath.Join(certsDir, "docker.io", "ca-0.crt"))
+	require.NoError(t, err)
+	assert.Equal(t, "-----BEGIN CERTIFICATE-----", string(ca))
+
+	// the mirror is removed from the configuration, its host directory is removed from the node.
+	configPath = writeConfig(t)
+	require.NoError(t, app.UpdateRegistryMirrors(context.Background(), RegistryMirrorFlags{ProvisionConfig: configPath, CertsDir: certsDir}))
+	assert.NoDirExists(t, filepath.Join(certsDir, "docker.io"))
+}
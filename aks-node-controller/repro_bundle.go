@@ -0,0 +1,282 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/nodeconfigutils"
+	"github.com/Azure/agentbaker/pkg/agent"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const redacted = "[REDACTED]"
+
+// secretFields are the configuration fields removed from reproduction bundles.
+var secretFields = map[protoreflect.Name]bool{
+	"service_principal_secret": true,
+	"tls_bootstrapping_token":  true,
+	"realm_password":           true,
+	"kubelet_client_key":       true,
+}
+
+// reproBundleLogs are the node logs added to reproduction bundles with --include-logs.
+var reproBundleLogs = []string{
+	logFile,
+	clusterProvisionLogFile,
+	provisionJSONFilePath,
+	securityPostureFilePath,
+	customScriptsLogDir,
+}
+
+type ReproBundleFlags struct {
+	ProvisionConfig string
+	// Output is the path of the .tar.gz bundle.
+	Output      string
+	IncludeLogs bool
+	// LogPaths are the files and directories added with IncludeLogs.
+	LogPaths []string
+	// LogCommands are the commands whose output is added with IncludeLogs.
+	LogCommands []LogCommand
+}
+
+// ReproBundleManifest describes the content of a reproduction bundle.
+type ReproBundleManifest struct {
+	CreatedAt          time.Time `json:"createdAt"`
+	ControllerVersion  string    `json:"controllerVersion"`
+	AgentBakerVersion  string    `json:"agentBakerVersion"`
+	Revision           string    `json:"revision,omitempty"`
+	GoVersion          string    `json:"goVersion"`
+	TemplateBundleHash string    `json:"templateBundleHash,omitempty"`
+	Files              []string  `json:"files"`
+	// Errors lists the artifacts which couldn't be generated or collected.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ReproBundle packages the redacted effective configuration, the versions of the controller and the artifacts
+// generated from the configuration, and optionally the provisioning logs of the node, into one .tar.gz to attach
+// to provisioning bug reports.
+func (a *App) ReproBundle(ctx context.Context, flags ReproBundleFlags) error {
+	inputJSON, err := os.ReadFile(flags.ProvisionConfig)
+	if err != nil {
+		return fmt.Errorf("open provision file %s: %w", flags.ProvisionConfig, err)
+	}
+	config, err := nodeconfigutils.UnmarshalConfigurationV1(inputJSON)
+	if err != nil {
+		return fmt.Errorf("unmarshal provision config: %w", err)
+	}
+	redactedConfig, secrets := redactConfig(config)
+
+	files := map[string][]byte{}
+	manifest := newReproBundleManifest()
+	addArtifact := func(name string, generate func() ([]byte, error)) {
+		data, err := generate()
+		if err != nil {
+			manifest.Errors = append(manifest.Errors, fmt.Sprintf("%s: %s", name, err))
+			return
+		}
+		files[name] = data
+	}
+	addArtifact("config.json", func() ([]byte, error) {
+		return nodeconfigutils.MarshalConfigurationV1(redactedConfig)
+	})
+	addArtifact("artifacts/cse-env", func() ([]byte, error) {
+		return []byte(strings.Join(parser.CSEEnvironment(redactedConfig), "\n") + "\n"), nil
+	})
+	if parser.IsWindows(redactedConfig) {
+		addArtifact("artifacts/cse-cmd.ps1", func() ([]byte, error) {
+			cmd, err := parser.BuildCSECmd(context.Background(), redactedConfig)
+			if err != nil {
+				return nil, err
+			}
+			return []byte(strings.Join(cmd.Args, " ") + "\n"), nil
+		})
+	} else {
+		addArtifact("artifacts/cse-cmd.sh", func() ([]byte, error) {
+			cmd, err := parser.BuildCSECmd(context.Background(), redactedConfig)
+			if err != nil {
+				return nil, err
+			}
+			return []byte(cmd.Args[len(cmd.Args)-1] + "\n"), nil
+		})
+	}
+	addArtifact("artifacts/custom-data", func() ([]byte, error) {
+		customData, err := nodeconfigutils.CustomData(redactedConfig)
+		return []byte(customData), err
+	})
+	if flags.IncludeLogs {
+		for _, path := range flags.LogPaths {
+			if err := collectLogs(path, files, secrets); err != nil {
+				manifest.Errors = append(manifest.Errors, fmt.Sprintf("%s: %s", path, err))
+			}
+		}
+		for _, command := range flags.LogCommands {
+			output, err := a.runLogCommand(ctx, command)
+			if err != nil {
+				manifest.Errors = append(manifest.Errors, fmt.Sprintf("%s: %s", command.Name, err))
+				continue
+			}
+			files["logs/commands/"+command.Name] = scrubSecrets(output, secrets)
+		}
+	}
+
+	for name := range files {
+		manifest.Files = append(manifest.Files, name)
+	}
+	sort.Strings(manifest.Files)
+	files["manifest.json"], err = json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+	if err := writeTarGz(flags.Output, files); err != nil {
+		return fmt.Errorf("write reproduction bundle: %w", err)
+	}
+	slog.Info("reproduction bundle written", "path", flags.Output, "files", len(files), "errors", len(manifest.Errors))
+	return nil
+}
+
+func newReproBundleManifest() *ReproBundleManifest {
+	versions := readBuildVersions()
+	manifest := &ReproBundleManifest{
+		CreatedAt:         time.Now().UTC(),
+		ControllerVersion: versions.Controller,
+		AgentBakerVersion: versions.AgentBaker,
+		Revision:          versions.Revision,
+		GoVersion:         runtime.Version(),
+	}
+	if bundle, err := agent.CurrentTemplateBundle(); err == nil {
+		manifest.TemplateBundleHash = bundle.Hash
+	} else {
+		manifest.Errors = append(manifest.Errors, fmt.Sprintf("template bundle: %s", err))
+	}
+	return manifest
+}
+
+// buildVersions are the versions the controller binary was built from.
+type buildVersions struct {
+	Controller string
+	AgentBaker string
+	Revision   string
+}
+
+func readBuildVersions() buildVersions {
+	var versions buildVersions
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return versions
+	}
+	versions.Controller = info.Main.Version
+	for _, dep := range info.Deps {
+		if dep.Path == "github.com/Azure/agentbaker" {
+			versions.AgentBaker = dep.Version
+		}
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			versions.Revision = setting.Value
+		}
+	}
+	return versions
+}
+
+// redactConfig returns a copy of config without secrets and the removed secret values.
+func redactConfig(config *aksnodeconfigv1.Configuration) (*aksnodeconfigv1.Configuration, []string) {
+	redactedConfig := proto.Clone(config).(*aksnodeconfigv1.Configuration)
+	var secrets []string
+	var redact func(m protoreflect.Message)
+	redact = func(m protoreflect.Message) {
+		m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			switch {
+			case secretFields[fd.Name()] && fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap():
+				if v.String() != "" {
+					secrets = append(secrets, v.String())
+					m.Set(fd, protoreflect.ValueOfString(redacted))
+				}
+			case fd.Kind() == protoreflect.MessageKind && fd.IsList():
+				for i := 0; i < v.List().Len(); i++ {
+					redact(v.List().Get(i).Message())
+				}
+			case fd.Kind() == protoreflect.MessageKind && !fd.IsMap():
+				redact(v.Message())
+			}
+			return true
+		})
+	}
+	redact(redactedConfig.ProtoReflect())
+	return redactedConfig, secrets
+}
+
+// scrubSecrets replaces the secret values, as is and base64 encoded, in logs.
+func scrubSecrets(data []byte, secrets []string) []byte {
+	text := string(data)
+	for _, secret := range secrets {
+		text = strings.ReplaceAll(text, secret, redacted)
+		text = strings.ReplaceAll(text, base64.StdEncoding.EncodeToString([]byte(secret)), redacted)
+	}
+	return []byte(text)
+}
+
+// collectLogs adds the file, or the files of the directory, at path to files under logs/.
+func collectLogs(path string, files map[string][]byte, secrets []string) error {
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		// the volume name of Windows paths is dropped, C:\k\kubelet.log is added as logs/k/kubelet.log.
+		files[filepath.ToSlash(filepath.Join("logs", strings.TrimPrefix(p, filepath.VolumeName(p))))] = scrubSecrets(data, secrets)
+		return nil
+	})
+}
+
+func writeTarGz(path string, files map[string][]byte) (err error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, f.Close())
+	}()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		header := &tar.Header{Name: name, Mode: 0600, Size: int64(len(files[name])), ModTime: time.Now()}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
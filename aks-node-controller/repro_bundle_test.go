@@ -0,0 +1,93 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactConfig(t *testing.T) {
+	config := &aksnodeconfigv1.Configuration{
+		Version:    "v0",
+		AuthConfig: &aksnodeconfigv1.AuthConfig{ServicePrincipalId: "sp-id", ServicePrincipalSecret: "sp-secret"},
+		KubeletConfig: &aksnodeconfigv1.KubeletConfig{
+			KubeletClientKey: "client-key",
+		},
+	}
+	redactedConfig, secrets := redactConfig(config)
+	assert.Equal(t, "sp-id", redactedConfig.GetAuthConfig().GetServicePrincipalId())
+	assert.Equal(t, redacted, redactedConfig.GetAuthConfig().GetServicePrincipalSecret())
+	assert.Equal(t, redacted, redactedConfig.GetKubeletConfig().GetKubeletClientKey())
+	assert.ElementsMatch(t, []string{"sp-secret", "client-key"}, secrets)
+	assert.Equal(t, "sp-secret", config.GetAuthConfig().GetServicePrincipalSecret(), "the original config must not change")
+}
+
+func TestScrubSecrets(t *testing.T) {
+	log := "secret=sp-secret content=" + base64.StdEncoding.EncodeToString([]byte("sp-secret"))
+	assert.Equal(t, "secret=[REDACTED] content=[REDACTED]", string(scrubSecrets([]byte(log), []string{"sp-secret"})))
+}
+
+func TestApp_ReproBundle(t *testing.T) {
+	dir := t.TempDir()
+	config := &aksnodeconfigv1.Configuration{
+		Version:    "v0",
+		AuthConfig: &aksnodeconfigv1.AuthConfig{ServicePrincipalSecret: "sp-secret"},
+	}
+	flags := ReproBundleFlags{
+		ProvisionConfig: filepath.Join(dir, "config.json"),
+		Output:          filepath.Join(dir, "bundle.tar.gz"),
+		IncludeLogs:     true,
+		LogPaths:        []string{filepath.Join(dir, "logs"), filepath.Join(dir, "missing.log")},
+	}
+	require.NoError(t, writeProvisionedConfig(flags.ProvisionConfig, config))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "logs"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "logs", "provision.log"), []byte("using sp-secret"), 0644))
+
+	app := &App{}
+	require.NoError(t, app.ReproBundle(context.Background(), flags))
+
+	files := readTarGz(t, flags.Output)
+	assert.Contains(t, files, "config.json")
+	assert.Contains(t, files, "artifacts/cse-env")
+	assert.Contains(t, files, "artifacts/custom-data")
+	assert.Equal(t, "using [REDACTED]", files[filepath.ToSlash(filepath.Join("logs", dir, "logs", "provision.log"))])
+	for name, content := range files {
+		assert.NotContains(t, content, "sp-secret", name)
+	}
+
+	var manifest ReproBundleManifest
+	require.NoError(t, json.Unmarshal([]byte(files["manifest.json"]), &manifest))
+	assert.NotEmpty(t, manifest.GoVersion)
+	assert.Contains(t, manifest.Files, "config.json")
+	assert.Contains(t, manifest.Errors[len(manifest.Errors)-1], "missing.log")
+}
+
+func readTarGz(t *testing.T, path string) map[string]string {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	files := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(data)
+	}
+}
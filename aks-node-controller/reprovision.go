@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/nodeconfigutils"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Policies applied by provision when the node has already been provisioned with a different configuration.
+// Re-running provision with the configuration the node was provisioned with is always a no-op.
+const (
+	// reprovisionFail fails without changing the node.
+	reprovisionFail = "fail"
+	// reprovisionSkip leaves the node as it is and succeeds.
+	reprovisionSkip = "skip"
+	// reprovisionReconfigure applies the changes which can be applied in place and fails if any other field changed.
+	reprovisionReconfigure = "reconfigure"
+)
+
+// reconfigurableFields are the configuration fields which can change on a provisioned node without running CSE again.
+var reconfigurableFields = []string{
+	"bootstrap_profile_container_registry_server",
+	"custom_scripts",
+	"debug_config",
+	"registry_mirrors",
+}
+
+// ReprovisionError is returned when provision is re-run with a configuration which can't be applied to the node.
+type ReprovisionError struct {
+	Policy string
+	// Fields are the top-level configuration fields which differ from the provisioned configuration.
+	Fields []string
+}
+
+func (e *ReprovisionError) Error() string {
+	if e.Policy == reprovisionReconfigure {
+		return fmt.Sprintf("node is already provisioned and these configuration fields can't be changed in place: %s", strings.Join(e.Fields, ", "))
+	}
+	return fmt.Sprintf("node is already provisioned with a different configuration (changed fields: %s), use --on-reprovision=%s or %s to re-run provision",
+		strings.Join(e.Fields, ", "), reprovisionSkip, reprovisionReconfigure)
+}
+
+func validReprovisionPolicy(policy string) bool {
+	return policy == reprovisionFail || policy == reprovisionSkip || policy == reprovisionReconfigure
+}
+
+// guardReprovision checks the configuration the node was provisioned with and applies the reprovision policy.
+// It returns true when provisioning has been handled and CSE must not run again.
+func (a *App) guardReprovision(ctx context.Context, config *aksnodeconfigv1.Configuration, flags ProvisionFlags) (bool, error) {
+	previous, err := readProvisionedConfig(flags.ProvisionedConfigFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return true, err
+	}
+	previousHash, err := nodeconfigutils.ConfigurationHash(previous)
+	if err != nil {
+		return true, err
+	}
+	hash, err := nodeconfigutils.ConfigurationHash(config)
+	if err != nil {
+		return true, err
+	}
+	if previousHash == hash {
+		slog.Info("node is already provisioned with this configuration, nothing to do", "configHash", hash)
+		return true, nil
+	}
+
+	fields := changedFields(previous, config)
+	slog.Info("node is already provisioned with a different configuration", "policy", flags.OnReprovision,
+		"previousConfigHash", previousHash, "configHash", hash, "changedFields", fields)
+	switch flags.OnReprovision {
+	case reprovisionSkip:
+		return true, nil
+	case reprovisionReconfigure:
+		var fixed []string
+		for _, field := range fields {
+			if !slices.Contains(reconfigurableFields, field) {
+				fixed = append(fixed, field)
+			}
+		}
+		if len(fixed) > 0 {
+			return true, &ReprovisionError{Policy: flags.OnReprovision, Fields: fixed}
+		}
+		if err = a.reconfigure(ctx, config, fields, flags); err != nil {
+			return true, fmt.Errorf("reconfigure node: %w", err)
+		}
+		return true, writeProvisionedConfig(flags.ProvisionedConfigFile, config)
+	default:
+		return true, &ReprovisionError{Policy: flags.OnReprovision, Fields: fields}
+	}
+}
+
+// reconfigure applies the changed reconfigurable fields to the provisioned node.
+func (a *App) reconfigure(_ context.Context, config *aksnodeconfigv1.Configuration, fields []string, flags ProvisionFlags) error {
+	if slices.Contains(fields, "bootstrap_profile_container_registry_server") || slices.Contains(fields, "registry_mirrors") {
+		if err := applyRegistryMirrors(config, nodeContainerdCertsDir(config)); err != nil {
+			return err
+		}
+	}
+	if slices.Contains(fields, "custom_scripts") {
+		if err := a.installKubeletHooks(config.GetCustomScripts(), kubeletHooksDropInPath, flags.ProvisionConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changedFields returns the names of the top-level fields which differ between two configurations.
+func changedFields(previous, current *aksnodeconfigv1.Configuration) []string {
+	var changed []string
+	fields := current.ProtoReflect().Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if !proto.Equal(onlyField(previous, field.Name()), onlyField(current, field.Name())) {
+			changed = append(changed, string(field.Name()))
+		}
+	}
+	return changed
+}
+
+// onlyField returns a copy of config where every field but name is cleared.
+func onlyField(config *aksnodeconfigv1.Configuration, name protoreflect.Name) *aksnodeconfigv1.Configuration {
+	result := &aksnodeconfigv1.Configuration{}
+	field := config.ProtoReflect().Descriptor().Fields().ByName(name)
+	if config.ProtoReflect().Has(field) {
+		result.ProtoReflect().Set(field, config.ProtoReflect().Get(field))
+	}
+	return result
+}
+
+// readProvisionedConfig returns the configuration recorded by the last successful provisioning.
+func readProvisionedConfig(path string) (*aksnodeconfigv1.Configuration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config, err := nodeconfigutils.UnmarshalConfigurationV1(data)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal provisioned config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// writeProvisionedConfig records the configuration of a successful provisioning, it may contain secrets.
+func writeProvisionedConfig(path string, config *aksnodeconfigv1.Configuration) error {
+	data, err := nodeconfigutils.MarshalConfigurationV1(config)
+	if err != nil {
+		return fmt.Errorf("marshal provisioned config: %w", err)
+	}
+	return writeFileAtomic(path, data, 0600)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangedFields(t *testing.T) {
+	previous := &aksnodeconfigv1.Configuration{
+		Version:           "v0",
+		KubernetesVersion: "1.30.0",
+		DebugConfig:       &aksnodeconfigv1.DebugConfig{TraceScripts: true},
+	}
+	current := &aksnodeconfigv1.Configuration{
+		Version:           "v0",
+		KubernetesVersion: "1.31.0",
+		CustomCaCerts:     []string{"cert"},
+	}
+	assert.Equal(t, []string{"kubernetes_version", "custom_ca_certs", "debug_config"}, changedFields(previous, current))
+	assert.Empty(t, changedFields(previous, previous))
+}
+
+func TestApp_GuardReprovision(t *testing.T) {
+	provisioned := &aksnodeconfigv1.Configuration{Version: "v0", KubernetesVersion: "1.30.0"}
+
+	setup := func(t *testing.T, current *aksnodeconfigv1.Configuration, policy string) ProvisionFlags {
+		dir := t.TempDir()
+		flags := ProvisionFlags{
+			ProvisionConfig:       filepath.Join(dir, "config.json"),
+			OnReprovision:         policy,
+			ProvisionedConfigFile: filepath.Join(dir, "provisioned-config.json"),
+		}
+		require.NoError(t, writeProvisionedConfig(flags.ProvisionedConfigFile, provisioned))
+		require.NoError(t, writeProvisionedConfig(flags.ProvisionConfig, current))
+		return flags
+	}
+	app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+		t.Fatalf("unexpected command %v", cmd.Args)
+		return nil
+	}}
+
+	t.Run("first provisioning", func(t *testing.T) {
+		flags := ProvisionFlags{OnReprovision: reprovisionFail, ProvisionedConfigFile: filepath.Join(t.TempDir(), "provisioned-config.json")}
+		handled, err := app.guardReprovision(context.Background(), provisioned, flags)
+		assert.NoError(t, err)
+		assert.False(t, handled)
+	})
+
+	t.Run("same configuration is a no-op", func(t *testing.T) {
+		flags := setup(t, provisioned, reprovisionFail)
+		handled, err := app.guardReprovision(context.Background(), provisioned, flags)
+		assert.NoError(t, err)
+		assert.True(t, handled)
+	})
+
+	t.Run("different configuration fails", func(t *testing.T) {
+		current := &aksnodeconfigv1.Configuration{Version: "v0", KubernetesVersion: "1.31.0"}
+		flags := setup(t, current, reprovisionFail)
+		handled, err := app.guardReprovision(context.Background(), current, flags)
+		assert.True(t, handled)
+		var reprovisionErr *ReprovisionError
+		require.ErrorAs(t, err, &reprovisionErr)
+		assert.Equal(t, []string{"kubernetes_version"}, reprovisionErr.Fields)
+	})
+
+	t.Run("different configuration is skipped", func(t *testing.T) {
+		current := &aksnodeconfigv1.Configuration{Version: "v0", KubernetesVersion: "1.31.0"}
+		flags := setup(t, current, reprovisionSkip)
+		handled, err := app.guardReprovision(context.Background(), current, flags)
+		assert.NoError(t, err)
+		assert.True(t, handled)
+	})
+
+	t.Run("reconfigurable changes are applied in place", func(t *testing.T) {
+		current := &aksnodeconfigv1.Configuration{
+			Version:           "v0",
+			KubernetesVersion: "1.30.0",
+			DebugConfig:       &aksnodeconfigv1.DebugConfig{TraceScripts: true},
+		}
+		flags := setup(t, current, reprovisionReconfigure)
+		handled, err := app.guardReprovision(context.Background(), current, flags)
+		assert.NoError(t, err)
+		assert.True(t, handled)
+		recorded, err := readProvisionedConfig(flags.ProvisionedConfigFile)
+		require.NoError(t, err)
+		assert.True(t, recorded.GetDebugConfig().GetTraceScripts())
+		info, err := os.Stat(flags.ProvisionedConfigFile)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("reconfigure fails on fields which need a new provisioning", func(t *testing.T) {
+		current := &aksnodeconfigv1.Configuration{
+			Version:           "v0",
+			KubernetesVersion: "1.31.0",
+			DebugConfig:       &aksnodeconfigv1.DebugConfig{TraceScripts: true},
+		}
+		flags := setup(t, current, reprovisionReconfigure)
+		handled, err := app.guardReprovision(context.Background(), current, flags)
+		assert.True(t, handled)
+		assert.EqualError(t, err, "node is already provisioned and these configuration fields can't be changed in place: kubernetes_version")
+	})
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+const (
+	kubeletClientCertSignerName = "kubernetes.io/kube-apiserver-client-kubelet"
+	csrPollInterval             = 2 * time.Second
+	csrApprovalTimeout          = 5 * time.Minute
+	// kubelet client certificates are rotated once less than this fraction of their lifetime remains.
+	kubeletCertRenewFraction = 0.3
+)
+
+type RotateKubeletCertFlags struct {
+	APIServerURL string
+	NodeName     string
+	// Interval is the time between two rotation checks, the check runs once when it is zero.
+	Interval time.Duration
+	// PKIDir contains the kubelet client certificates, kubelet reads kubelet-client-current.pem from it.
+	PKIDir string
+	// StateFile records the outcome of the last rotation.
+	StateFile string
+	// CACertFile is the cluster CA used to verify the API server.
+	CACertFile string
+	// BootstrapClient is the secure TLS bootstrap client-go credential plugin used to obtain a bootstrap token.
+	BootstrapClient string
+}
+
+// kubeletCertRotationState is persisted to the state file after every successful rotation.
+type kubeletCertRotationState struct {
+	LastRotation      time.Time `json:"lastRotation"`
+	CertificateExpiry time.Time `json:"certificateExpiry"`
+	CSRName           string    `json:"csrName"`
+}
+
+// RotateKubeletCert keeps the kubelet client certificate fresh. Once the current certificate is close to expiry,
+// a bootstrap token is obtained through secure TLS bootstrapping and used to get a new certificate signed by the cluster.
+func (a *App) RotateKubeletCert(ctx context.Context, flags RotateKubeletCertFlags) error {
+	for {
+		err := a.rotateKubeletCertIfNeeded(ctx, flags)
+		if flags.Interval == 0 {
+			return err
+		}
+		if err != nil {
+			// keep running, the next attempt may succeed once the API server is reachable again.
+			slog.Error("kubelet client certificate rotation failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(flags.Interval):
+		}
+	}
+}
+
+func (a *App) rotateKubeletCertIfNeeded(ctx context.Context, flags RotateKubeletCertFlags) error {
+	currentPath := filepath.Join(flags.PKIDir, "kubelet-client-current.pem")
+	if cert, err := readCertificate(currentPath); err == nil && !kubeletCertNeedsRotation(cert, time.Now()) {
+		slog.Info("kubelet client certificate is still valid", "notAfter", cert.NotAfter)
+		return nil
+	}
+
+	token, err := a.bootstrapToken(ctx, flags.BootstrapClient)
+	if err != nil {
+		return fmt.Errorf("get bootstrap token: %w", err)
+	}
+	client, err := apiServerClient(flags.CACertFile)
+	if err != nil {
+		return err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("generate private key: %w", err)
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   "system:node:" + flags.NodeName,
+			Organization: []string{"system:nodes"},
+		},
+	}, key)
+	if err != nil {
+		return fmt.Errorf("create certificate request: %w", err)
+	}
+
+	csrName, err := submitCSR(ctx, client, flags.APIServerURL, token, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}))
+	if err != nil {
+		return err
+	}
+	certPEM, err := waitForCertificate(ctx, client, flags.APIServerURL, token, csrName)
+	if err != nil {
+		return err
+	}
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return fmt.Errorf("parse issued certificate: %w", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("marshal private key: %w", err)
+	}
+	bundle := append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})...)
+	if err = installKubeletCert(flags.PKIDir, bundle, time.Now()); err != nil {
+		return err
+	}
+
+	state := kubeletCertRotationState{LastRotation: time.Now().UTC(), CertificateExpiry: cert.NotAfter.UTC(), CSRName: csrName}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal rotation state: %w", err)
+	}
+	if err = os.WriteFile(flags.StateFile, data, 0600); err != nil {
+		return fmt.Errorf("write rotation state: %w", err)
+	}
+	slog.Info("kubelet client certificate rotated", "csr", csrName, "notAfter", cert.NotAfter)
+	return nil
+}
+
+func kubeletCertNeedsRotation(cert *x509.Certificate, now time.Time) bool {
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return cert.NotAfter.Sub(now) < time.Duration(float64(lifetime)*kubeletCertRenewFraction)
+}
+
+// bootstrapToken runs the client-go credential plugin and returns the token from its ExecCredential output.
+func (a *App) bootstrapToken(ctx context.Context, plugin string) (string, error) {
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, plugin)
+	cmd.Env = append(os.Environ(), `KUBERNETES_EXEC_INFO={"apiVersion":"client.authentication.k8s.io/v1","kind":"ExecCredential","spec":{"interactive":false}}`)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := a.cmdRunner(cmd); err != nil {
+		return "", fmt.Errorf("run %s: %w", plugin, err)
+	}
+	var credential struct {
+		Status struct {
+			Token string `json:"token"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &credential); err != nil {
+		return "", fmt.Errorf("parse ExecCredential: %w", err)
+	}
+	if credential.Status.Token == "" {
+		return "", errors.New("ExecCredential has no token")
+	}
+	return credential.Status.Token, nil
+}
+
+func apiServerClient(caCertFile string) (*http.Client, error) {
+	caCert, err := os.ReadFile(caCertFile)
+	if err != nil {
+		return nil, fmt.Errorf("read CA certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("no certificates found in %s", caCertFile)
+	}
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+		},
+	}, nil
+}
+
+type certificateSigningRequest struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name         string `json:"name,omitempty"`
+		GenerateName string `json:"generateName,omitempty"`
+	} `json:"metadata"`
+	Spec struct {
+		Request    []byte   `json:"request"`
+		SignerName string   `json:"signerName"`
+		Usages     []string `json:"usages"`
+	} `json:"spec"`
+	Status struct {
+		Certificate []byte `json:"certificate,omitempty"`
+		Conditions  []struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"conditions,omitempty"`
+	} `json:"status"`
+}
+
+func submitCSR(ctx context.Context, client *http.Client, apiServerURL, token string, csrPEM []byte) (string, error) {
+	csr := certificateSigningRequest{APIVersion: "certificates.k8s.io/v1", Kind: "CertificateSigningRequest"}
+	csr.Metadata.GenerateName = "node-csr-"
+	csr.Spec.Request = csrPEM
+	csr.Spec.SignerName = kubeletClientCertSignerName
+	csr.Spec.Usages = []string{"digital signature", "client auth"}
+	body, err := json.Marshal(csr)
+	if err != nil {
+		return "", fmt.Errorf("marshal CertificateSigningRequest: %w", err)
+	}
+
+	var created certificateSigningRequest
+	if err = doAPIServerRequest(ctx, client, http.MethodPost, apiServerURL+"/apis/certificates.k8s.io/v1/certificatesigningrequests", token, body, &created); err != nil {
+		return "", fmt.Errorf("create CertificateSigningRequest: %w", err)
+	}
+	return created.Metadata.Name, nil
+}
+
+func waitForCertificate(ctx context.Context, client *http.Client, apiServerURL, token, csrName string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, csrApprovalTimeout)
+	defer cancel()
+	url := apiServerURL + "/apis/certificates.k8s.io/v1/certificatesigningrequests/" + csrName
+	for {
+		var csr certificateSigningRequest
+		if err := doAPIServerRequest(ctx, client, http.MethodGet, url, token, nil, &csr); err != nil {
+			return nil, fmt.Errorf("get CertificateSigningRequest %s: %w", csrName, err)
+		}
+		for _, c := range csr.Status.Conditions {
+			if c.Type == "Denied" || c.Type == "Failed" {
+				return nil, fmt.Errorf("CertificateSigningRequest %s %s: %s", csrName, c.Type, c.Message)
+			}
+		}
+		if len(csr.Status.Certificate) > 0 {
+			return csr.Status.Certificate, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for CertificateSigningRequest %s to be issued: %w", csrName, ctx.Err())
+		case <-time.After(csrPollInterval):
+		}
+	}
+}
+
+func doAPIServerRequest(ctx context.Context, client *http.Client, method, url, token string, body []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, data)
+	}
+	return json.Unmarshal(data, out)
+}
+
+// installKubeletCert writes the certificate and key bundle and atomically points kubelet-client-current.pem at it,
+// the same layout kubelet uses for its own certificate rotation.
+func installKubeletCert(pkiDir string, bundle []byte, now time.Time) error {
+	if err := os.MkdirAll(pkiDir, 0755); err != nil {
+		return fmt.Errorf("create %s: %w", pkiDir, err)
+	}
+	name := fmt.Sprintf("kubelet-client-%s.pem", now.UTC().Format("2006-01-02-15-04-05"))
+	if err := os.WriteFile(filepath.Join(pkiDir, name), bundle, 0600); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	tmpLink := filepath.Join(pkiDir, ".kubelet-client-current.pem.tmp")
+	_ = os.Remove(tmpLink)
+	if err := os.Symlink(name, tmpLink); err != nil {
+		return fmt.Errorf("create symlink: %w", err)
+	}
+	if err := os.Rename(tmpLink, filepath.Join(pkiDir, "kubelet-client-current.pem")); err != nil {
+		return fmt.Errorf("update kubelet-client-current.pem: %w", err)
+	}
+	return nil
+}
+
+func readCertificate(path string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseCertificate(data)
+}
+
+// parseCertificate returns the first certificate of a PEM bundle.
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("no certificate found")
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCSRServer signs every submitted CertificateSigningRequest with a self-signed CA.
+func fakeCSRServer(t *testing.T, wantToken string) *httptest.Server {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	csrs := map[string]certificateSigningRequest{}
+
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+wantToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			var csr certificateSigningRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&csr))
+			assert.Equal(t, kubeletClientCertSignerName, csr.Spec.SignerName)
+			block, _ := pem.Decode(csr.Spec.Request)
+			req, err := x509.ParseCertificateRequest(block.Bytes)
+			require.NoError(t, err)
+			assert.Equal(t, "system:node:node1", req.Subject.CommonName)
+			certDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+				SerialNumber: big.NewInt(2),
+				Subject:      req.Subject,
+				NotBefore:    time.Now().Add(-time.Minute),
+				NotAfter:     time.Now().Add(time.Hour),
+				ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			}, caTemplate, req.PublicKey, caKey)
+			require.NoError(t, err)
+			csr.Metadata.Name = "node-csr-abc"
+			csr.Status.Certificate = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+			csrs[csr.Metadata.Name] = csr
+			w.WriteHeader(http.StatusCreated)
+			require.NoError(t, json.NewEncoder(w).Encode(csr))
+		case http.MethodGet:
+			csr, ok := csrs[filepath.Base(r.URL.Path)]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(csr))
+		}
+	}))
+}
+
+func TestApp_RotateKubeletCert(t *testing.T) {
+	server := fakeCSRServer(t, "bootstrap-token")
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+	flags := RotateKubeletCertFlags{
+		APIServerURL:    server.URL,
+		NodeName:        "node1",
+		PKIDir:          filepath.Join(dir, "pki"),
+		StateFile:       filepath.Join(dir, "state.json"),
+		CACertFile:      caFile,
+		BootstrapClient: "tls-bootstrap-client",
+	}
+
+	pluginRuns := 0
+	app := &App{
+		cmdRunner: func(cmd *exec.Cmd) error {
+			pluginRuns++
+			_, err := cmd.Stdout.Write([]byte(`{"kind":"ExecCredential","status":{"token":"bootstrap-token"}}`))
+			return err
+		},
+	}
+
+	require.NoError(t, app.RotateKubeletCert(context.Background(), flags))
+	assert.Equal(t, 1, pluginRuns)
+
+	cert, err := readCertificate(filepath.Join(flags.PKIDir, "kubelet-client-current.pem"))
+	require.NoError(t, err)
+	assert.Equal(t, "system:node:node1", cert.Subject.CommonName)
+
+	data, err := os.ReadFile(flags.StateFile)
+	require.NoError(t, err)
+	var state kubeletCertRotationState
+	require.NoError(t, json.Unmarshal(data, &state))
+	assert.Equal(t, "node-csr-abc", state.CSRName)
+	assert.Equal(t, cert.NotAfter.UTC(), state.CertificateExpiry)
+
+	// the certificate is fresh, so the second run doesn't rotate it again.
+	require.NoError(t, app.RotateKubeletCert(context.Background(), flags))
+	assert.Equal(t, 1, pluginRuns)
+}
+
+func TestApp_RotateKubeletCert_InvalidToken(t *testing.T) {
+	server := fakeCSRServer(t, "bootstrap-token")
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+	app := &App{
+		cmdRunner: func(cmd *exec.Cmd) error {
+			_, err := cmd.Stdout.Write([]byte(`{"kind":"ExecCredential","status":{"token":"wrong-token"}}`))
+			return err
+		},
+	}
+
+	err := app.RotateKubeletCert(context.Background(), RotateKubeletCertFlags{
+		APIServerURL: server.URL,
+		NodeName:     "node1",
+		PKIDir:       filepath.Join(dir, "pki"),
+		StateFile:    filepath.Join(dir, "state.json"),
+		CACertFile:   caFile,
+	})
+	assert.ErrorContains(t, err, "401 Unauthorized")
+	_, err = os.Stat(filepath.Join(dir, "pki", "kubelet-client-current.pem"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestKubeletCertNeedsRotation(t *testing.T) {
+	now := time.Now()
+	cert := &x509.Certificate{NotBefore: now.Add(-70 * time.Hour), NotAfter: now.Add(30 * time.Hour)}
+	assert.False(t, kubeletCertNeedsRotation(cert, now.Add(-time.Hour)))
+	assert.True(t, kubeletCertNeedsRotation(cert, now.Add(time.Hour)))
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/nodeconfigutils"
+)
+
+const sshPort = 22
+
+// socket states in /proc/net/{tcp,udp}, see include/net/tcp_states.h.
+const (
+	tcpListenState = "0A"
+	udpUnconnState = "07"
+)
+
+// collectSecurityPosture inspects the host, rooted at root, and the node configuration.
+func collectSecurityPosture(root string, config *aksnodeconfigv1.Configuration, now time.Time) (*nodeconfigutils.SecurityPostureReport, error) {
+	report := &nodeconfigutils.SecurityPostureReport{
+		SchemaVersion: nodeconfigutils.SecurityPostureSchemaVersion,
+		GeneratedAt:   now.UTC(),
+		HardeningProfile: nodeconfigutils.HardeningProfile{
+			IMDSRestriction:             config.GetImdsRestrictionConfig().GetEnableImdsRestriction(),
+			EgressPolicy:                len(config.GetEgressPolicyConfig().GetAllowedDestinations()) > 0,
+			UnattendedUpgrade:           config.GetEnableUnattendedUpgrade(),
+			CustomCACerts:               len(config.GetCustomCaCerts()) > 0,
+			LegacyKernelModulesDisabled: config.GetCustomLinuxOsConfig().GetDisableLegacyKernelModules(),
+		},
+		FIPSEnabled: readTrimmed(root, "proc/sys/crypto/fips_enabled") == "1",
+		SELinux:     selinuxMode(root),
+		AppArmor:    "disabled",
+		// SSH stays enabled unless the configuration explicitly disables it.
+		SSH: nodeconfigutils.SSHPosture{Enabled: !parser.SSHDisabled(config)},
+	}
+	if readTrimmed(root, "sys/module/apparmor/parameters/enabled") == "Y" {
+		report.AppArmor = "enabled"
+	}
+
+	ports, err := listeningPorts(root)
+	if err != nil {
+		return nil, err
+	}
+	report.OpenPorts = ports
+	for _, p := range ports {
+		if p.Protocol == "tcp" && p.Port == sshPort {
+			report.SSH.Listening = true
+		}
+	}
+	return report, nil
+}
+
+func selinuxMode(root string) string {
+	switch readTrimmed(root, "sys/fs/selinux/enforce") {
+	case "1":
+		return "enforcing"
+	case "0":
+		return "permissive"
+	default:
+		return "disabled"
+	}
+}
+
+func readTrimmed(root, path string) string {
+	data, err := os.ReadFile(filepath.Join(root, path))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// listeningPorts returns the listening TCP and bound UDP sockets from /proc/net.
+func listeningPorts(root string) ([]nodeconfigutils.ListeningPort, error) {
+	ports := []nodeconfigutils.ListeningPort{}
+	seen := map[nodeconfigutils.ListeningPort]bool{}
+	for _, table := range []struct{ file, protocol, state string }{
+		{"tcp", "tcp", tcpListenState},
+		{"tcp6", "tcp", tcpListenState},
+		{"udp", "udp", udpUnconnState},
+		{"udp6", "udp", udpUnconnState},
+	} {
+		entries, err := readSocketTable(filepath.Join(root, "proc/net", table.file), table.state)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			p := nodeconfigutils.ListeningPort{Protocol: table.protocol, Address: e.Addr().String(), Port: int(e.Port())}
+			if !seen[p] {
+				seen[p] = true
+				ports = append(ports, p)
+			}
+		}
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Protocol != ports[j].Protocol {
+			return ports[i].Protocol < ports[j].Protocol
+		}
+		if ports[i].Port != ports[j].Port {
+			return ports[i].Port < ports[j].Port
+		}
+		return ports[i].Address < ports[j].Address
+	})
+	return ports, nil
+}
+
+func readSocketTable(path, state string) ([]netip.AddrPort, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		// e.g. IPv6 is disabled.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var entries []netip.AddrPort
+	scanner := bufio.NewScanner(f)
+	scanner.Scan() // header
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || fields[3] != state {
+			continue
+		}
+		addrPort, err := parseProcNetAddr(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+		entries = append(entries, addrPort)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// parseProcNetAddr parses an address such as "0100007F:0016". The kernel prints the address as host-endian 32-bit words,
+// which are little-endian on both amd64 and arm64.
+func parseProcNetAddr(s string) (netip.AddrPort, error) {
+	addrHex, portHex, ok := strings.Cut(s, ":")
+	if !ok {
+		return netip.AddrPort{}, fmt.Errorf("invalid address %q", s)
+	}
+	raw, err := hex.DecodeString(addrHex)
+	if err != nil || (len(raw) != 4 && len(raw) != 16) {
+		return netip.AddrPort{}, fmt.Errorf("invalid address %q", s)
+	}
+	for i := 0; i < len(raw); i += 4 {
+		raw[i], raw[i+1], raw[i+2], raw[i+3] = raw[i+3], raw[i+2], raw[i+1], raw[i]
+	}
+	port, err := strconv.ParseUint(portHex, 16, 16)
+	if err != nil {
+		return netip.AddrPort{}, fmt.Errorf("invalid port in %q: %w", s, err)
+	}
+	addr, _ := netip.AddrFromSlice(raw)
+	return netip.AddrPortFrom(addr.Unmap(), uint16(port)), nil
+}
+
+func writeSecurityPostureReport(path string, report *nodeconfigutils.SecurityPostureReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("marshal security posture report: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+// readSecurityPostureReport returns the security posture report written at the end of provisioning.
+func readSecurityPostureReport(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read security posture report: %w", err)
+	}
+	if _, err := nodeconfigutils.ParseSecurityPostureReport(data); err != nil {
+		return "", fmt.Errorf("invalid security posture report: %w", err)
+	}
+	return string(data), nil
+}
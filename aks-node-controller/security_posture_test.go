@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/Azure/agentbaker/aks-node-controller/pkg/nodeconfigutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const procNetTCP = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:0016 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 19432 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:2710 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 21854 1 0000000000000000 100 0 0 10 0
+   2: 0400E00A:0016 0500E00A:D2F0 01 00000000:00000000 02:0009F2A1 00000000     0        0 31245 4 0000000000000000 20 4 30 10 -1
+`
+
+const procNetTCP6 = `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000000000000000000000000000:0016 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 19434 1 0000000000000000 100 0 0 10 0
+   1: 00000000000000000000000000000000:27D8 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 22735 1 0000000000000000 100 0 0 10 0
+`
+
+const procNetUDP = `   sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops
+  100: 3500007F:0035 00000000:0000 07 00000000:00000000 00:00000000 00000000   101        0 18523 2 0000000000000000 0
+`
+
+func writeHostFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(path)), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, path), []byte(content), 0644))
+}
+
+func TestCollectSecurityPosture(t *testing.T) {
+	root := t.TempDir()
+	writeHostFile(t, root, "proc/sys/crypto/fips_enabled", "1\n")
+	writeHostFile(t, root, "sys/module/apparmor/parameters/enabled", "Y\n")
+	writeHostFile(t, root, "proc/net/tcp", procNetTCP)
+	writeHostFile(t, root, "proc/net/tcp6", procNetTCP6)
+	writeHostFile(t, root, "proc/net/udp", procNetUDP)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	enableSSH := false
+	config := &aksnodeconfigv1.Configuration{
+		EnableSsh:             &enableSSH,
+		ImdsRestrictionConfig: &aksnodeconfigv1.ImdsRestrictionConfig{EnableImdsRestriction: true},
+	}
+	report, err := collectSecurityPosture(root, config, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, &nodeconfigutils.SecurityPostureReport{
+		SchemaVersion:    "v1",
+		GeneratedAt:      now,
+		HardeningProfile: nodeconfigutils.HardeningProfile{IMDSRestriction: true},
+		FIPSEnabled:      true,
+		SELinux:          "disabled",
+		AppArmor:         "enabled",
+		SSH:              nodeconfigutils.SSHPosture{Enabled: false, Listening: true},
+		OpenPorts: []nodeconfigutils.ListeningPort{
+			{Protocol: "tcp", Address: "0.0.0.0", Port: 22},
+			{Protocol: "tcp", Address: "::", Port: 22},
+			{Protocol: "tcp", Address: "127.0.0.1", Port: 10000},
+			{Protocol: "tcp", Address: "::", Port: 10200},
+			{Protocol: "udp", Address: "127.0.0.53", Port: 53},
+		},
+	}, report)
+}
+
+func TestSecurityPostureReportRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "aks", "security-posture.json")
+	report, err := collectSecurityPosture(t.TempDir(), &aksnodeconfigv1.Configuration{}, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, "disabled", report.AppArmor)
+	assert.Equal(t, nodeconfigutils.SSHPosture{Enabled: true, Listening: false}, report.SSH)
+
+	require.NoError(t, writeSecurityPostureReport(path, report))
+	data, err := readSecurityPostureReport(path)
+	require.NoError(t, err)
+	assert.Contains(t, data, `"SchemaVersion":"v1"`)
+
+	_, err = readSecurityPostureReport(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "failed to read security posture report")
+}
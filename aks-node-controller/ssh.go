@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// sshdIncludeDropIns makes sshd read the drop-ins before the settings of sshd_config, older images don't include them.
+const sshdIncludeDropIns = "Include /etc/ssh/sshd_config.d/*.conf"
+
+// configureSSH writes the sshd_config drop-in of the SSH access policy and reloads sshd. The drop-in is removed again
+// when sshd rejects it, so that a bad policy never locks the node out.
+func (a *App) configureSSH(ctx context.Context, config *aksnodeconfigv1.Configuration, root string) error {
+	if config.GetSshConfig().GetMode() == aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_ENTRA_ID {
+		if _, err := os.Stat(filepath.Join(root, parser.AADCertHandler)); err != nil {
+			return fmt.Errorf("Microsoft Entra ID SSH login isn't installed: %w", err)
+		}
+	}
+	if err := ensureSSHDIncludesDropIns(filepath.Join(root, "etc/ssh/sshd_config")); err != nil {
+		return err
+	}
+	dropIn := filepath.Join(root, parser.SSHDConfigDropIn)
+	if err := writeFileAtomic(dropIn, []byte(parser.SSHDConfigDropInContent(config)), 0600); err != nil {
+		return err
+	}
+	if err := a.cmdRunner(exec.CommandContext(ctx, "sshd", "-t")); err != nil {
+		if removeErr := os.Remove(dropIn); removeErr != nil {
+			slog.Error("failed to remove the rejected sshd_config drop-in", "error", removeErr)
+		}
+		return fmt.Errorf("sshd rejected %s: %w", parser.SSHDConfigDropIn, err)
+	}
+	if err := a.cmdRunner(exec.CommandContext(ctx, "systemctl", "reload", "sshd")); err != nil {
+		return fmt.Errorf("reload sshd: %w", err)
+	}
+	slog.Info("SSH access policy applied", "mode", config.GetSshConfig().GetMode())
+	return nil
+}
+
+// ensureSSHDIncludesDropIns adds the Include of the drop-ins at the top of sshd_config unless it's there already.
+func ensureSSHDIncludesDropIns(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(line) == sshdIncludeDropIns {
+			return nil
+		}
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, bytes.Join([][]byte{[]byte(sshdIncludeDropIns), content}, []byte("\n")), info.Mode().Perm())
+}
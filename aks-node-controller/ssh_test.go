@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_configureSSH(t *testing.T) {
+	config := &aksnodeconfigv1.Configuration{SshConfig: &aksnodeconfigv1.SshConfig{
+		Mode:         aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_PUBLIC_KEY,
+		AllowedCidrs: []string{"10.0.0.0/8"},
+	}}
+	newRoot := func(t *testing.T) string {
+		root := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "etc/ssh"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, "etc/ssh/sshd_config"), []byte("PasswordAuthentication yes\n"), 0644))
+		return root
+	}
+
+	t.Run("applied", func(t *testing.T) {
+		root := newRoot(t)
+		var commands []string
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			commands = append(commands, strings.Join(cmd.Args, " "))
+			return nil
+		}}
+
+		require.NoError(t, app.configureSSH(context.Background(), config, root))
+		assert.Equal(t, []string{"sshd -t", "systemctl reload sshd"}, commands)
+		content, err := os.ReadFile(filepath.Join(root, parser.SSHDConfigDropIn))
+		require.NoError(t, err)
+		assert.Equal(t, parser.SSHDConfigDropInContent(config), string(content))
+		sshdConfig, err := os.ReadFile(filepath.Join(root, "etc/ssh/sshd_config"))
+		require.NoError(t, err)
+		assert.Equal(t, "Include /etc/ssh/sshd_config.d/*.conf\nPasswordAuthentication yes\n", string(sshdConfig))
+
+		// the Include isn't added twice.
+		require.NoError(t, app.configureSSH(context.Background(), config, root))
+		sshdConfig, err = os.ReadFile(filepath.Join(root, "etc/ssh/sshd_config"))
+		require.NoError(t, err)
+		assert.Equal(t, "Include /etc/ssh/sshd_config.d/*.conf\nPasswordAuthentication yes\n", string(sshdConfig))
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		root := newRoot(t)
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			if cmd.Args[0] == "sshd" {
+				return errors.New("exit status 255")
+			}
+			return nil
+		}}
+
+		err := app.configureSSH(context.Background(), config, root)
+		assert.EqualError(t, err, "sshd rejected /etc/ssh/sshd_config.d/50-aks-node-controller.conf: exit status 255")
+		assert.NoFileExists(t, filepath.Join(root, parser.SSHDConfigDropIn))
+	})
+
+	t.Run("entra ID login not installed", func(t *testing.T) {
+		root := newRoot(t)
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			t.Fatalf("unexpected command %v", cmd.Args)
+			return nil
+		}}
+		entraID := &aksnodeconfigv1.Configuration{SshConfig: &aksnodeconfigv1.SshConfig{Mode: aksnodeconfigv1.SshAccessMode_SSH_ACCESS_MODE_ENTRA_ID}}
+
+		err := app.configureSSH(context.Background(), entraID, root)
+		assert.ErrorContains(t, err, "Microsoft Entra ID SSH login isn't installed")
+	})
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+const (
+	swapFile         = "/aks-swapfile"
+	zramDevice       = "/dev/zram0"
+	zramSwapUnit     = "aks-zram-swap.service"
+	zramSwapPriority = "100"
+)
+
+// swapPaths are the host paths used to configure swap, overridden by tests.
+type swapPaths struct {
+	// SwapsFile is /proc/swaps.
+	SwapsFile string
+	// CgroupControllers only exists on the cgroup v2 unified hierarchy.
+	CgroupControllers string
+	// Fstab is /etc/fstab.
+	Fstab string
+	// SystemdDir is where the zram swap unit is written.
+	SystemdDir string
+	// Root is prepended to the swap file.
+	Root string
+}
+
+var defaultSwapPaths = swapPaths{
+	SwapsFile:         "/proc/swaps",
+	CgroupControllers: "/sys/fs/cgroup/cgroup.controllers",
+	Fstab:             "/etc/fstab",
+	SystemdDir:        systemdUnitDir,
+	Root:              "/",
+}
+
+// configureSwap creates and enables the swap of config, a swap file persisted in fstab or a zram device set up by a
+// systemd unit on every boot. It is a no-op when the swap is already active. kubelet lets pods use it through the
+// drop-in written with the other kubelet config drop-ins.
+func (a *App) configureSwap(ctx context.Context, config *aksnodeconfigv1.SwapConfig, paths swapPaths) error {
+	if _, err := os.Stat(paths.CgroupControllers); err != nil {
+		return errors.New("swap requires cgroup v2, the node uses cgroup v1")
+	}
+	device := filepath.Join(paths.Root, swapFile)
+	if config.GetBackend() == aksnodeconfigv1.SwapBackend_SWAP_BACKEND_ZRAM {
+		device = zramDevice
+	}
+	active, err := isSwapActive(paths.SwapsFile, device)
+	if err != nil {
+		return err
+	}
+	if active {
+		slog.Info("swap is already active", "device", device)
+		return nil
+	}
+
+	size := strconv.Itoa(int(config.GetSizeMb()))
+	switch config.GetBackend() {
+	case aksnodeconfigv1.SwapBackend_SWAP_BACKEND_ZRAM:
+		unit := zramSwapUnitContent(size)
+		if err := writeFileAtomic(filepath.Join(paths.SystemdDir, zramSwapUnit), []byte(unit), 0644); err != nil {
+			return err
+		}
+		if err := a.cmdRunner(exec.CommandContext(ctx, "systemctl", "daemon-reload")); err != nil {
+			return fmt.Errorf("systemctl daemon-reload: %w", err)
+		}
+		if err := a.cmdRunner(exec.CommandContext(ctx, "systemctl", "enable", "--now", zramSwapUnit)); err != nil {
+			return fmt.Errorf("enable %s: %w", zramSwapUnit, err)
+		}
+	default:
+		for _, args := range [][]string{
+			{"fallocate", "-l", size + "M", device},
+			{"chmod", "600", device},
+			{"mkswap", device},
+			{"swapon", device},
+		} {
+			if err := a.cmdRunner(exec.CommandContext(ctx, args[0], args[1:]...)); err != nil {
+				return fmt.Errorf("%s: %w", args[0], err)
+			}
+		}
+		if err := appendSwapFstab(paths.Fstab, device); err != nil {
+			return err
+		}
+	}
+	slog.Info("swap configured", "device", device, "sizeMB", config.GetSizeMb())
+	return nil
+}
+
+func zramSwapUnitContent(sizeMB string) string {
+	return fmt.Sprintf(`[Unit]
+Description=AKS zram swap
+After=local-fs.target
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=/sbin/modprobe zram num_devices=1
+ExecStart=/bin/sh -c 'echo %sM > /sys/block/zram0/disksize'
+ExecStart=/sbin/mkswap %s
+ExecStart=/sbin/swapon -p %s %s
+ExecStop=/sbin/swapoff %s
+
+[Install]
+WantedBy=multi-user.target
+`, sizeMB, zramDevice, zramSwapPriority, zramDevice, zramDevice)
+}
+
+// isSwapActive returns whether device is listed in swapsFile.
+func isSwapActive(swapsFile, device string) (bool, error) {
+	data, err := os.ReadFile(swapsFile)
+	if err != nil {
+		return false, fmt.Errorf("read %s: %w", swapsFile, err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 && fields[0] == device {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// appendSwapFstab adds the swap file to fstab unless it already has an entry.
+func appendSwapFstab(fstab, device string) error {
+	data, err := os.ReadFile(fstab)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read %s: %w", fstab, err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if fields := strings.Fields(line); len(fields) > 0 && fields[0] == device {
+			return nil
+		}
+	}
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		data = append(data, '\n')
+	}
+	return writeFileAtomic(fstab, append(data, device+" none swap sw 0 0\n"...), 0644)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_ConfigureSwap(t *testing.T) {
+	setup := func(t *testing.T, swaps string) swapPaths {
+		dir := t.TempDir()
+		paths := swapPaths{
+			SwapsFile:         filepath.Join(dir, "swaps"),
+			CgroupControllers: filepath.Join(dir, "cgroup.controllers"),
+			Fstab:             filepath.Join(dir, "fstab"),
+			SystemdDir:        filepath.Join(dir, "systemd"),
+			Root:              filepath.Join(dir, "root"),
+		}
+		require.NoError(t, os.WriteFile(paths.SwapsFile, []byte("Filename\tType\tSize\tUsed\tPriority\n"+swaps), 0644))
+		require.NoError(t, os.WriteFile(paths.CgroupControllers, []byte("cpu memory"), 0644))
+		require.NoError(t, os.WriteFile(paths.Fstab, []byte("UUID=abc / ext4 defaults 0 1"), 0644))
+		return paths
+	}
+	recorder := func(commands *[]string) *App {
+		return &App{cmdRunner: func(cmd *exec.Cmd) error {
+			*commands = append(*commands, strings.Join(cmd.Args, " "))
+			return nil
+		}}
+	}
+
+	t.Run("swap file", func(t *testing.T) {
+		paths := setup(t, "")
+		var commands []string
+		config := &aksnodeconfigv1.SwapConfig{Backend: aksnodeconfigv1.SwapBackend_SWAP_BACKEND_FILE, SizeMb: 2048}
+
+		require.NoError(t, recorder(&commands).configureSwap(context.Background(), config, paths))
+		file := filepath.Join(paths.Root, "aks-swapfile")
+		assert.Equal(t, []string{
+			"fallocate -l 2048M " + file,
+			"chmod 600 " + file,
+			"mkswap " + file,
+			"swapon " + file,
+		}, commands)
+		fstab, err := os.ReadFile(paths.Fstab)
+		require.NoError(t, err)
+		assert.Equal(t, "UUID=abc / ext4 defaults 0 1\n"+file+" none swap sw 0 0\n", string(fstab))
+
+		// the fstab entry isn't duplicated.
+		require.NoError(t, appendSwapFstab(paths.Fstab, file))
+		fstabAgain, err := os.ReadFile(paths.Fstab)
+		require.NoError(t, err)
+		assert.Equal(t, string(fstab), string(fstabAgain))
+	})
+
+	t.Run("zram", func(t *testing.T) {
+		paths := setup(t, "")
+		var commands []string
+		config := &aksnodeconfigv1.SwapConfig{Backend: aksnodeconfigv1.SwapBackend_SWAP_BACKEND_ZRAM, SizeMb: 1024}
+
+		require.NoError(t, recorder(&commands).configureSwap(context.Background(), config, paths))
+		assert.Equal(t, []string{"systemctl daemon-reload", "systemctl enable --now aks-zram-swap.service"}, commands)
+		unit, err := os.ReadFile(filepath.Join(paths.SystemdDir, zramSwapUnit))
+		require.NoError(t, err)
+		assert.Contains(t, string(unit), "echo 1024M > /sys/block/zram0/disksize")
+		assert.Contains(t, string(unit), "ExecStart=/sbin/swapon -p 100 /dev/zram0")
+	})
+
+	t.Run("active swap is left untouched", func(t *testing.T) {
+		paths := setup(t, "/dev/zram0\tpartition\t1048572\t0\t100\n")
+		var commands []string
+		config := &aksnodeconfigv1.SwapConfig{Backend: aksnodeconfigv1.SwapBackend_SWAP_BACKEND_ZRAM, SizeMb: 1024}
+
+		require.NoError(t, recorder(&commands).configureSwap(context.Background(), config, paths))
+		assert.Empty(t, commands)
+	})
+
+	t.Run("cgroup v1", func(t *testing.T) {
+		paths := setup(t, "")
+		require.NoError(t, os.Remove(paths.CgroupControllers))
+		var commands []string
+		config := &aksnodeconfigv1.SwapConfig{Backend: aksnodeconfigv1.SwapBackend_SWAP_BACKEND_FILE, SizeMb: 1024}
+
+		err := recorder(&commands).configureSwap(context.Background(), config, paths)
+		assert.EqualError(t, err, "swap requires cgroup v2, the node uses cgroup v1")
+		assert.Empty(t, commands)
+	})
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// timeSyncTimeout is how long provisioning waits for chrony to synchronize the clock.
+const timeSyncTimeout = 2 * time.Minute
+
+// chronyConfigPath returns the chrony configuration file of the distro under root: Ubuntu keeps it in /etc/chrony,
+// Azure Linux directly in /etc.
+func chronyConfigPath(root string) string {
+	if info, err := os.Stat(filepath.Join(root, "etc/chrony")); err == nil && info.IsDir() {
+		return filepath.Join(root, "etc/chrony/chrony.conf")
+	}
+	return filepath.Join(root, "etc/chrony.conf")
+}
+
+// configureTimeSync writes the chrony configuration, restarts chrony and waits until the clock is synchronized, so
+// that kubelet doesn't bootstrap its TLS credentials with a skewed clock.
+func (a *App) configureTimeSync(ctx context.Context, config *aksnodeconfigv1.Configuration, root string, timeout time.Duration) error {
+	if err := writeFileAtomic(chronyConfigPath(root), []byte(parser.ChronyConfig(config)), 0644); err != nil {
+		return err
+	}
+	if err := a.cmdRunner(exec.CommandContext(ctx, "systemctl", "restart", "chronyd")); err != nil {
+		return fmt.Errorf("restart chronyd: %w", err)
+	}
+	// waitsync checks every second whether chrony is synchronized, with any remaining correction.
+	tries := strconv.Itoa(int(timeout / time.Second))
+	if err := a.cmdRunner(exec.CommandContext(ctx, "chronyc", "waitsync", tries, "0", "0", "1")); err != nil {
+		return fmt.Errorf("clock not synchronized after %s: %w", timeout, err)
+	}
+	slog.Info("clock synchronized", "servers", config.GetTimeSyncConfig().GetNtpServers())
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_configureTimeSync(t *testing.T) {
+	config := &aksnodeconfigv1.Configuration{
+		TimeSyncConfig: &aksnodeconfigv1.TimeSyncConfig{NtpServers: []string{"time.contoso.com"}},
+	}
+
+	t.Run("ubuntu", func(t *testing.T) {
+		root := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "etc/chrony"), 0755))
+		var commands []string
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			commands = append(commands, strings.Join(cmd.Args, " "))
+			return nil
+		}}
+
+		require.NoError(t, app.configureTimeSync(context.Background(), config, root, time.Minute))
+		assert.Equal(t, []string{"systemctl restart chronyd", "chronyc waitsync 60 0 0 1"}, commands)
+		content, err := os.ReadFile(filepath.Join(root, "etc/chrony/chrony.conf"))
+		require.NoError(t, err)
+		assert.Equal(t, parser.ChronyConfig(config), string(content))
+	})
+
+	t.Run("azure linux not synchronized", func(t *testing.T) {
+		root := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "etc"), 0755))
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			if cmd.Args[0] == "chronyc" {
+				return errors.New("exit status 1")
+			}
+			return nil
+		}}
+
+		err := app.configureTimeSync(context.Background(), config, root, time.Minute)
+		assert.EqualError(t, err, "clock not synchronized after 1m0s: exit status 1")
+		assert.FileExists(t, filepath.Join(root, "etc/chrony.conf"))
+	})
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// trustedCAFilePrefix prefixes the trust anchors written by aks-node-controller, so that the ones of removed
+	// certificates are cleaned up without touching the other anchors.
+	trustedCAFilePrefix = "aks-node-controller-trusted-ca-"
+	// containerdDefaultHostDir holds the containerd registry configuration of the registries without their own
+	// host directory.
+	containerdDefaultHostDir = "_default"
+	trustedCABundleFile      = "aks-trusted-ca.crt"
+)
+
+// trustStore is where the trusted CA certificates are installed on the node.
+type trustStore struct {
+	// AnchorsDir is the directory of the CA certificates added to the system trust store.
+	AnchorsDir string
+	// UpdateCommand regenerates the system CA bundle from AnchorsDir.
+	UpdateCommand []string
+	// ContainerdCertsDir is the containerd registry config_path.
+	ContainerdCertsDir string
+}
+
+// ubuntuTrustStore and azureLinuxTrustStore are the trust stores of the supported distros.
+var (
+	ubuntuTrustStore = trustStore{
+		AnchorsDir:         "/usr/local/share/ca-certificates",
+		UpdateCommand:      []string{"update-ca-certificates"},
+		ContainerdCertsDir: containerdCertsDir,
+	}
+	azureLinuxTrustStore = trustStore{
+		AnchorsDir:         "/etc/pki/ca-trust/source/anchors",
+		UpdateCommand:      []string{"update-ca-trust", "extract"},
+		ContainerdCertsDir: containerdCertsDir,
+	}
+)
+
+// nodeTrustStore returns the trust store of the node under root.
+func nodeTrustStore(root string) trustStore {
+	store := ubuntuTrustStore
+	if _, err := os.Stat(filepath.Join(root, azureLinuxTrustStore.AnchorsDir)); err == nil {
+		store = azureLinuxTrustStore
+	}
+	store.AnchorsDir = filepath.Join(root, store.AnchorsDir)
+	store.ContainerdCertsDir = filepath.Join(root, store.ContainerdCertsDir)
+	return store
+}
+
+// parseTrustedCACertificate returns the certificates of a PEM blob, which must all be valid CA certificates at now.
+func parseTrustedCACertificate(blob string, now time.Time) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(blob)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block %q", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse certificate: %w", err)
+		}
+		if !cert.IsCA {
+			return nil, fmt.Errorf("certificate %q is not a CA", cert.Subject)
+		}
+		if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+			return nil, fmt.Errorf("certificate %q is only valid from %s to %s", cert.Subject,
+				cert.NotBefore.Format(time.RFC3339), cert.NotAfter.Format(time.RFC3339))
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM certificate found")
+	}
+	return certs, nil
+}
+
+func validateTrustedCACertificates(blobs []string) error {
+	for i, blob := range blobs {
+		if _, err := parseTrustedCACertificate(blob, time.Now()); err != nil {
+			return fmt.Errorf("trusted CA certificate %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// trustedCAFiles returns the trust anchor files of the certificates keyed by name, the name is derived from the
+// content so that unchanged certificates keep their file.
+func trustedCAFiles(blobs []string) map[string][]byte {
+	files := map[string][]byte{}
+	for _, blob := range blobs {
+		content := []byte(strings.TrimSpace(blob) + "\n")
+		sum := sha256.Sum256(content)
+		files[trustedCAFilePrefix+hex.EncodeToString(sum[:8])+".crt"] = content
+	}
+	return files
+}
+
+// installTrustedCACertificates adds the certificates to the system trust store and to the containerd registry
+// configuration. Certificates installed by a previous run which are no longer configured are removed, and the
+// system CA bundle is only regenerated when the anchors changed, so that running it again is cheap.
+func (a *App) installTrustedCACertificates(ctx context.Context, blobs []string, store trustStore) error {
+	files := trustedCAFiles(blobs)
+	changed, err := syncTrustAnchors(store.AnchorsDir, files)
+	if err != nil {
+		return err
+	}
+	if changed {
+		cmd := exec.CommandContext(ctx, store.UpdateCommand[0], store.UpdateCommand[1:]...)
+		if err := a.cmdRunner(cmd); err != nil {
+			return fmt.Errorf("%s: %w", store.UpdateCommand[0], err)
+		}
+	}
+
+	// containerd loads the system CAs once, the registry configuration is read on every pull so that the
+	// certificates are trusted without restarting it. Registries with mirrors have their own host directory.
+	hostDir := filepath.Join(store.ContainerdCertsDir, containerdDefaultHostDir)
+	bundlePath := filepath.Join(hostDir, trustedCABundleFile)
+	if err := writeFileAtomic(bundlePath, trustedCABundle(files), 0644); err != nil {
+		return err
+	}
+	hostsTOML := fmt.Sprintf("ca = [%q]\n", bundlePath)
+	if err := writeFileAtomic(filepath.Join(hostDir, "hosts.toml"), []byte(hostsTOML), 0644); err != nil {
+		return err
+	}
+	slog.Info("trusted CA certificates installed", "count", len(files), "anchorsUpdated", changed)
+	return nil
+}
+
+// syncTrustAnchors writes files into dir and removes the previously written anchors which aren't in files. It
+// returns whether dir changed.
+func syncTrustAnchors(dir string, files map[string][]byte) (bool, error) {
+	changed := false
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, content) {
+			continue
+		}
+		if err := writeFileAtomic(path, content, 0644); err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	previous, err := filepath.Glob(filepath.Join(dir, trustedCAFilePrefix+"*.crt"))
+	if err != nil {
+		return false, err
+	}
+	for _, path := range previous {
+		if _, ok := files[filepath.Base(path)]; ok {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return false, fmt.Errorf("remove %s: %w", path, err)
+		}
+		changed = true
+	}
+	return changed, nil
+}
+
+// trustedCABundle concatenates the certificates in a stable order.
+func trustedCABundle(files map[string][]byte) []byte {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var bundle []byte
+	for _, name := range names {
+		bundle = append(bundle, files[name]...)
+	}
+	return bundle
+}
+
+// addRootCAs returns the root CAs of transport, the system ones by default, with the PEM certificates added.
+func addRootCAs(transport *http.Transport, pems ...[]byte) (*x509.CertPool, error) {
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	var pool *x509.CertPool
+	var err error
+	if transport.TLSClientConfig.RootCAs != nil {
+		pool = transport.TLSClientConfig.RootCAs.Clone()
+	} else if pool, err = x509.SystemCertPool(); err != nil {
+		pool = x509.NewCertPool()
+	}
+	for _, ca := range pems {
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("no PEM certificate found")
+		}
+	}
+	return pool, nil
+}
+
+// trustedClient returns a copy of client which trusts the certificates in addition to its root CAs, or client
+// itself without certificates.
+func trustedClient(client *http.Client, blobs []string) (*http.Client, error) {
+	if len(blobs) == 0 {
+		return client, nil
+	}
+	transport := clientTransport(client)
+	pems := make([][]byte, len(blobs))
+	for i, blob := range blobs {
+		pems[i] = []byte(blob)
+	}
+	pool, err := addRootCAs(transport, pems...)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig.RootCAs = pool
+	trusted := *client
+	trusted.Transport = transport
+	return &trusted, nil
+}
+
+// clientTransport returns a copy of the transport of client, or of the default transport.
+func clientTransport(client *http.Client) *http.Transport {
+	if t, ok := client.Transport.(*http.Transport); ok {
+		return t.Clone()
+	}
+	return http.DefaultTransport.(*http.Transport).Clone()
+}
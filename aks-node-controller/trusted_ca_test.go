@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCertificatePEM(t *testing.T, isCA bool, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "proxy-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestValidateTrustedCACertificates(t *testing.T) {
+	valid := testCertificatePEM(t, true, time.Now().Add(time.Hour))
+	tests := []struct {
+		name    string
+		blobs   []string
+		wantErr string
+	}{
+		{name: "no certificates"},
+		{name: "CA certificates", blobs: []string{valid, valid + valid}},
+		{name: "not PEM", blobs: []string{valid, "not a certificate"}, wantErr: "trusted CA certificate 1: no PEM certificate found"},
+		{name: "not a CA", blobs: []string{testCertificatePEM(t, false, time.Now().Add(time.Hour))}, wantErr: `certificate "CN=proxy-ca" is not a CA`},
+		{name: "expired", blobs: []string{testCertificatePEM(t, true, time.Now().Add(-time.Minute))}, wantErr: "is only valid from"},
+		{
+			name:    "private key",
+			blobs:   []string{string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}))},
+			wantErr: `unexpected PEM block "PRIVATE KEY"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTrustedCACertificates(tt.blobs)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApp_InstallTrustedCACertificates(t *testing.T) {
+	root := t.TempDir()
+	store := trustStore{
+		AnchorsDir:         filepath.Join(root, "anchors"),
+		UpdateCommand:      []string{"update-ca-certificates"},
+		ContainerdCertsDir: filepath.Join(root, "certs.d"),
+	}
+	require.NoError(t, os.MkdirAll(store.AnchorsDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(store.AnchorsDir, "corporate.crt"), []byte("other anchor"), 0644))
+	updates := 0
+	app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+		assert.Equal(t, []string{"update-ca-certificates"}, cmd.Args)
+		updates++
+		return nil
+	}}
+	first := testCertificatePEM(t, true, time.Now().Add(time.Hour))
+	second := testCertificatePEM(t, true, time.Now().Add(time.Hour))
+
+	require.NoError(t, app.installTrustedCACertificates(context.Background(), []string{first, second}, store))
+	anchors, err := filepath.Glob(filepath.Join(store.AnchorsDir, trustedCAFilePrefix+"*.crt"))
+	require.NoError(t, err)
+	assert.Len(t, anchors, 2)
+	assert.Equal(t, 1, updates)
+	hostsTOML, err := os.ReadFile(filepath.Join(store.ContainerdCertsDir, "_default", "hosts.toml"))
+	require.NoError(t, err)
+	bundlePath := filepath.Join(store.ContainerdCertsDir, "_default", trustedCABundleFile)
+	assert.Equal(t, `ca = ["`+bundlePath+`"]`+"\n", string(hostsTOML))
+	bundle, err := os.ReadFile(bundlePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(bundle), first)
+	assert.Contains(t, string(bundle), second)
+
+	// installing the same certificates again doesn't regenerate the system bundle.
+	require.NoError(t, app.installTrustedCACertificates(context.Background(), []string{second, first}, store))
+	assert.Equal(t, 1, updates)
+
+	// removed certificates are removed from the trust store, other anchors are kept.
+	require.NoError(t, app.installTrustedCACertificates(context.Background(), []string{second}, store))
+	assert.Equal(t, 2, updates)
+	anchors, err = filepath.Glob(filepath.Join(store.AnchorsDir, trustedCAFilePrefix+"*.crt"))
+	require.NoError(t, err)
+	assert.Len(t, anchors, 1)
+	assert.FileExists(t, filepath.Join(store.AnchorsDir, "corporate.crt"))
+	bundle, err = os.ReadFile(bundlePath)
+	require.NoError(t, err)
+	assert.Equal(t, second, string(bundle))
+}
+
+func TestNodeTrustStore(t *testing.T) {
+	root := t.TempDir()
+	assert.Equal(t, filepath.Join(root, "/usr/local/share/ca-certificates"), nodeTrustStore(root).AnchorsDir)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "/etc/pki/ca-trust/source/anchors"), 0755))
+	store := nodeTrustStore(root)
+	assert.Equal(t, filepath.Join(root, "/etc/pki/ca-trust/source/anchors"), store.AnchorsDir)
+	assert.Equal(t, []string{"update-ca-trust", "extract"}, store.UpdateCommand)
+	assert.Equal(t, filepath.Join(root, containerdCertsDir), store.ContainerdCertsDir)
+}
+
+func TestTrustedClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	_, err := (&http.Client{}).Get(server.URL)
+	require.Error(t, err)
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	client, err := trustedClient(&http.Client{}, []string{string(ca)})
+	require.NoError(t, err)
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
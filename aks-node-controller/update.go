@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+type UpdateFlags struct {
+	// Version is the pinned aks-node-controller version to install.
+	Version string
+	// SHA256 is the expected hex encoded checksum of the binary.
+	SHA256 string
+	// ArtifactEndpoint is the base URL the binary is downloaded from.
+	ArtifactEndpoint string
+	// BinaryPath is the path of the binary which is replaced.
+	BinaryPath string
+}
+
+func (f UpdateFlags) downloadURL() string {
+	return fmt.Sprintf("%s/aks-node-controller/%s/binaries/%s",
+		strings.TrimSuffix(f.ArtifactEndpoint, "/"), f.Version, binaryName(runtime.GOOS, runtime.GOARCH))
+}
+
+// binaryName is the name of the released aks-node-controller binary of the platform.
+func binaryName(goos, goarch string) string {
+	name := fmt.Sprintf("aks-node-controller-%s-%s", goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Update downloads the pinned aks-node-controller binary, verifies its checksum and atomically replaces the current binary.
+// The new binary is written next to the current one and renamed over it, so a failed update never leaves a partial binary behind.
+func (a *App) Update(ctx context.Context, flags UpdateFlags) error {
+	expectedSum, err := hex.DecodeString(flags.SHA256)
+	if err != nil || len(expectedSum) != sha256.Size {
+		return fmt.Errorf("invalid sha256 checksum %q", flags.SHA256)
+	}
+
+	url := flags.downloadURL()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("create request for %s: %w", url, err)
+	}
+	resp, err := a.httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download %s: unexpected status %s", url, resp.Status)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(flags.BinaryPath), ".aks-node-controller-update-*")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name()) // no-op once the file has been renamed
+
+	hash := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(tmpFile, hash), resp.Body); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("write %s: %w", tmpFile.Name(), err)
+	}
+	if err = tmpFile.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", tmpFile.Name(), err)
+	}
+
+	if actualSum := hash.Sum(nil); !strings.EqualFold(hex.EncodeToString(actualSum), flags.SHA256) {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %x", url, flags.SHA256, actualSum)
+	}
+
+	if err = os.Chmod(tmpFile.Name(), 0755); err != nil {
+		return fmt.Errorf("chmod %s: %w", tmpFile.Name(), err)
+	}
+	if err = replaceBinary(tmpFile.Name(), flags.BinaryPath); err != nil {
+		return fmt.Errorf("replace %s: %w", flags.BinaryPath, err)
+	}
+	slog.Info("aks-node-controller updated", "version", flags.Version, "path", flags.BinaryPath)
+	return nil
+}
+
+func (a *App) httpClient() *http.Client {
+	if a.client != nil {
+		return a.client
+	}
+	return http.DefaultClient
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_Update(t *testing.T) {
+	newBinary := []byte("new aks-node-controller")
+	sum := sha256.Sum256(newBinary)
+	validChecksum := hex.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/aks-node-controller/v1.2.3/binaries/"+binaryName(runtime.GOOS, runtime.GOARCH) {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(newBinary)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		version    string
+		checksum   string
+		wantErr    string
+		wantBinary []byte
+	}{
+		{
+			name:       "binary is replaced",
+			version:    "v1.2.3",
+			checksum:   validChecksum,
+			wantBinary: newBinary,
+		},
+		{
+			name:       "checksum mismatch keeps the current binary",
+			version:    "v1.2.3",
+			checksum:   hex.EncodeToString(make([]byte, sha256.Size)),
+			wantErr:    "checksum mismatch",
+			wantBinary: []byte("old"),
+		},
+		{
+			name:       "invalid checksum",
+			version:    "v1.2.3",
+			checksum:   "not-a-checksum",
+			wantErr:    "invalid sha256 checksum",
+			wantBinary: []byte("old"),
+		},
+		{
+			name:       "unknown version",
+			version:    "v9.9.9",
+			checksum:   validChecksum,
+			wantErr:    "unexpected status 404",
+			wantBinary: []byte("old"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			binaryPath := filepath.Join(dir, "aks-node-controller")
+			require.NoError(t, os.WriteFile(binaryPath, []byte("old"), 0755))
+
+			app := &App{client: server.Client()}
+			err := app.Update(context.Background(), UpdateFlags{
+				Version:          tt.version,
+				SHA256:           tt.checksum,
+				ArtifactEndpoint: server.URL,
+				BinaryPath:       binaryPath,
+			})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			data, err := os.ReadFile(binaryPath)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBinary, data)
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Len(t, entries, 1, "temporary files should be cleaned up")
+		})
+	}
+}
+
+func TestBinaryName(t *testing.T) {
+	assert.Equal(t, "aks-node-controller-linux-arm64", binaryName("linux", "arm64"))
+	assert.Equal(t, "aks-node-controller-windows-amd64.exe", binaryName("windows", "amd64"))
+}
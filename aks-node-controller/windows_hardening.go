@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Azure/agentbaker/aks-node-controller/parser"
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+)
+
+// windowsHardeningReportFilePath is where the inventory of the applied Windows security baseline is written.
+const windowsHardeningReportFilePath = `C:\AzureData\aks-windows-hardening.json`
+
+// WindowsHardeningReport is the inventory of the settings the Windows security baseline applied.
+type WindowsHardeningReport struct {
+	Profile   string                           `json:"profile"`
+	AppliedAt time.Time                        `json:"appliedAt"`
+	Settings  []parser.WindowsHardeningSetting `json:"settings"`
+}
+
+// applyWindowsHardening applies the hardening profile of the node and writes the inventory of its settings to
+// reportPath. The report is only written once every setting is applied.
+func (a *App) applyWindowsHardening(ctx context.Context, config *aksnodeconfigv1.Configuration, reportPath string, now func() time.Time) error {
+	cmd, err := parser.BuildWindowsHardeningCmd(ctx, config)
+	if err != nil {
+		return err
+	}
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := a.cmdRunner(cmd); err != nil {
+		return fmt.Errorf("apply hardening profile %s: %w: %s", config.GetWindowsConfig().GetHardeningProfile(), err,
+			strings.TrimSpace(output.String()))
+	}
+	report := WindowsHardeningReport{
+		Profile:   config.GetWindowsConfig().GetHardeningProfile(),
+		AppliedAt: now().UTC(),
+		Settings:  parser.WindowsHardeningSettings(config),
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("marshal hardening report: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(reportPath), 0755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", reportPath, err)
+	}
+	if err := os.WriteFile(reportPath, data, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", reportPath, err)
+	}
+	slog.Info("windows hardening profile applied", "profile", report.Profile, "settings", len(report.Settings))
+	return nil
+}
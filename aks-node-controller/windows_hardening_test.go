@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_applyWindowsHardening(t *testing.T) {
+	config := &aksnodeconfigv1.Configuration{
+		WindowsConfig: &aksnodeconfigv1.WindowsConfig{HardeningProfile: "aks-baseline"},
+	}
+	now := func() time.Time { return time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC) }
+
+	t.Run("applied", func(t *testing.T) {
+		var commands [][]string
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			commands = append(commands, cmd.Args)
+			return nil
+		}}
+		reportPath := filepath.Join(t.TempDir(), "AzureData", "aks-windows-hardening.json")
+		require.NoError(t, app.applyWindowsHardening(context.Background(), config, reportPath, now))
+		require.Len(t, commands, 1)
+		assert.Equal(t, "powershell.exe", commands[0][0])
+		assert.Contains(t, commands[0][len(commands[0])-1], "Set-SmbServerConfiguration -EnableSMB1Protocol $false")
+
+		data, err := os.ReadFile(reportPath)
+		require.NoError(t, err)
+		var report WindowsHardeningReport
+		require.NoError(t, json.Unmarshal(data, &report))
+		assert.Equal(t, "aks-baseline", report.Profile)
+		assert.Equal(t, now(), report.AppliedAt)
+		assert.Contains(t, string(data), `{"category":"Defender","name":"ExclusionPath","value":"C:\\ProgramData\\containerd"}`)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+			_, _ = cmd.Stderr.Write([]byte("Access is denied.\n"))
+			return errors.New("exit status 1")
+		}}
+		reportPath := filepath.Join(t.TempDir(), "aks-windows-hardening.json")
+		err := app.applyWindowsHardening(context.Background(), config, reportPath, now)
+		assert.EqualError(t, err, "apply hardening profile aks-baseline: exit status 1: Access is denied.")
+		assert.NoFileExists(t, reportPath)
+	})
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// LogCommand is a command whose output is added to reproduction bundles with --include-logs, under
+// logs/commands/<Name>.
+type LogCommand struct {
+	Name string
+	Args []string
+}
+
+// windowsReproBundleLogs are the node logs added to reproduction bundles of Windows nodes with --include-logs: the
+// CSE logs, the kubelet, kube-proxy and containerd logs, and the panic log containerd writes when it crashes.
+var windowsReproBundleLogs = []string{
+	`C:\AzureData\CustomDataSetupScript.log`,
+	`C:\WindowsAzure\Logs\Plugins\Microsoft.Compute.CustomScriptExtension`,
+	`C:\k\kubelet.log`,
+	`C:\k\kubelet.err.log`,
+	`C:\k\kubeproxy.log`,
+	`C:\k\kubeproxy.err.log`,
+	`C:\k\containerd.log`,
+	`C:\ProgramData\containerd\root\panic.log`,
+	windowsHardeningReportFilePath,
+}
+
+func powershellLogCommand(name, script string) LogCommand {
+	return LogCommand{Name: name, Args: []string{"powershell.exe", "-NoProfile", "-NonInteractive", "-Command", script}}
+}
+
+// windowsLogCommands collect what Windows nodes keep outside of log files: the HNS state kube-proxy and the CNI
+// program, and the event logs of the kubelet, kube-proxy and containerd services and of the Host Compute Service.
+var windowsLogCommands = []LogCommand{
+	powershellLogCommand("hns-networks.json", "Get-HnsNetwork | ConvertTo-Json -Depth 20"),
+	powershellLogCommand("hns-endpoints.json", "Get-HnsEndpoint | ConvertTo-Json -Depth 20"),
+	powershellLogCommand("hns-policies.json", "Get-HnsPolicyList | ConvertTo-Json -Depth 20"),
+	powershellLogCommand("service-events.txt", "Get-WinEvent -FilterHashtable @{LogName='System'; ProviderName='Service Control Manager'} "+
+		"-MaxEvents 2000 | Where-Object { $_.Message -match 'kubelet|kubeproxy|containerd|csi-proxy' } | "+
+		"Format-List TimeCreated, Id, LevelDisplayName, Message"),
+	powershellLogCommand("hcs-events.txt", "Get-WinEvent -LogName 'Microsoft-Windows-Hyper-V-Compute-Operational' -MaxEvents 2000 | "+
+		"Format-List TimeCreated, Id, LevelDisplayName, Message"),
+	powershellLogCommand("services.txt", "Get-Service kubelet, kubeproxy, containerd, csi-proxy -ErrorAction SilentlyContinue | "+
+		"Format-List Name, Status, StartType"),
+}
+
+// nodeLogSources returns the log files and the log commands of the OS the controller runs on, the bundles of both
+// OSes share the same layout.
+func nodeLogSources(goos string) ([]string, []LogCommand) {
+	if goos == "windows" {
+		return windowsReproBundleLogs, windowsLogCommands
+	}
+	return reproBundleLogs, nil
+}
+
+// runLogCommand returns the output of the log command.
+func (a *App) runLogCommand(ctx context.Context, command LogCommand) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, command.Args[0], command.Args[1:]...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := a.cmdRunner(cmd); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
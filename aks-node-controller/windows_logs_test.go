@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	aksnodeconfigv1 "github.com/Azure/agentbaker/aks-node-controller/pkg/gen/aksnodeconfig/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeLogSources(t *testing.T) {
+	paths, commands := nodeLogSources("linux")
+	assert.Equal(t, reproBundleLogs, paths)
+	assert.Empty(t, commands)
+
+	paths, commands = nodeLogSources("windows")
+	assert.Contains(t, paths, `C:\AzureData\CustomDataSetupScript.log`)
+	assert.Contains(t, paths, `C:\ProgramData\containerd\root\panic.log`)
+	names := make([]string, 0, len(commands))
+	for _, command := range commands {
+		names = append(names, command.Name)
+	}
+	assert.Contains(t, names, "hns-networks.json")
+	assert.Contains(t, names, "service-events.txt")
+}
+
+func TestApp_ReproBundle_WindowsLogCommands(t *testing.T) {
+	dir := t.TempDir()
+	config := &aksnodeconfigv1.Configuration{
+		Version:       "v0",
+		AuthConfig:    &aksnodeconfigv1.AuthConfig{ServicePrincipalSecret: "sp-secret"},
+		WindowsConfig: &aksnodeconfigv1.WindowsConfig{KubernetesPackageUrl: "https://acs-mirror.azureedge.net/kubernetes/v1.29.2/windowszip/v1.29.2-1int.zip"},
+	}
+	flags := ReproBundleFlags{
+		ProvisionConfig: filepath.Join(dir, "config.json"),
+		Output:          filepath.Join(dir, "bundle.tar.gz"),
+		IncludeLogs:     true,
+		LogCommands: []LogCommand{
+			powershellLogCommand("hns-networks.json", "Get-HnsNetwork | ConvertTo-Json -Depth 20"),
+			powershellLogCommand("hcs-events.txt", "Get-WinEvent -LogName 'Microsoft-Windows-Hyper-V-Compute-Operational'"),
+		},
+	}
+	require.NoError(t, writeProvisionedConfig(flags.ProvisionConfig, config))
+
+	app := &App{cmdRunner: func(cmd *exec.Cmd) error {
+		if cmd.Args[len(cmd.Args)-1] == "Get-HnsNetwork | ConvertTo-Json -Depth 20" {
+			_, err := fmt.Fprint(cmd.Stdout, `{"Name": "azure", "Secret": "sp-secret"}`)
+			return err
+		}
+		_, _ = fmt.Fprint(cmd.Stderr, "the log doesn't exist")
+		return errors.New("exit status 1")
+	}}
+	require.NoError(t, app.ReproBundle(context.Background(), flags))
+
+	files := readTarGz(t, flags.Output)
+	assert.Equal(t, `{"Name": "azure", "Secret": "[REDACTED]"}`, files["logs/commands/hns-networks.json"])
+	assert.NotContains(t, files, "logs/commands/hcs-events.txt")
+	assert.Contains(t, files, "artifacts/cse-cmd.ps1")
+	assert.NotContains(t, files, "artifacts/cse-cmd.sh")
+
+	var manifest ReproBundleManifest
+	require.NoError(t, json.Unmarshal([]byte(files["manifest.json"]), &manifest))
+	assert.Contains(t, manifest.Errors, "hcs-events.txt: exit status 1: the log doesn't exist")
+}
@@ -7,11 +7,14 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/Azure/agentbaker/pkg/agent"
 	"github.com/Azure/agentbaker/pkg/agent/toggles"
 )
 
 const (
 	readHeaderTimeoutSeconds = 5
+	// DefaultMaxPreviousTemplateBundles is the default number of previous template bundles which can be pinned.
+	DefaultMaxPreviousTemplateBundles = 3
 )
 
 // OptionConfigurator is a function which can configure an Options object.
@@ -21,6 +24,11 @@ type OptionConfigurator func(opts *Options)
 type Options struct {
 	Addr    string
 	Toggles toggles.Toggles
+	// PreviousTemplateBundleDirs are directories holding the parts of previous agentbaker versions,
+	// nodepools pinned to one of them keep getting bootstrapping data generated from it.
+	PreviousTemplateBundleDirs []string
+	// MaxPreviousTemplateBundles is the maximum number of previous template bundles served.
+	MaxPreviousTemplateBundles int
 }
 
 func (o *Options) validate() error {
@@ -36,7 +44,8 @@ func (o *Options) validate() error {
 
 // APIServer contains the connections details required to run the api.
 type APIServer struct {
-	Options *Options
+	Options         *Options
+	TemplateBundles *agent.TemplateBundles
 }
 
 // NewAPIServer creates an APIServer object with defaults.
@@ -45,8 +54,23 @@ func NewAPIServer(o *Options) (*APIServer, error) {
 		return nil, err
 	}
 
+	previous := make([]*agent.TemplateBundle, 0, len(o.PreviousTemplateBundleDirs))
+	for _, dir := range o.PreviousTemplateBundleDirs {
+		bundle, err := agent.LoadTemplateBundle(dir)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("Serving previous template bundle %s from %s\n", bundle.Hash, dir)
+		previous = append(previous, bundle)
+	}
+	bundles, err := agent.NewTemplateBundles(previous, o.MaxPreviousTemplateBundles)
+	if err != nil {
+		return nil, err
+	}
+
 	s := &APIServer{
-		Options: o,
+		Options:         o,
+		TemplateBundles: bundles,
 	}
 
 	return s, nil
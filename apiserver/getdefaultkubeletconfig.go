@@ -0,0 +1,56 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Azure/agentbaker/pkg/agent"
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+)
+
+const (
+	// RoutePathDefaultKubeletConfig the route path to get the default kubelet configuration.
+	RoutePathDefaultKubeletConfig string = "/getdefaultkubeletconfig"
+)
+
+// GetDefaultKubeletConfig endpoint for getting the kubelet configuration generated for a node.
+func (api *APIServer) GetDefaultKubeletConfig(w http.ResponseWriter, r *http.Request) {
+	var request datamodel.GetDefaultKubeletConfigurationRequest
+
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	agentBaker, err := agent.NewAgentBaker()
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if api.Options != nil && api.Options.Toggles != nil {
+		agentBaker = agentBaker.WithToggles(api.Options.Toggles)
+	}
+
+	kubeletConfig, err := agentBaker.GetDefaultKubeletConfiguration(&request)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := json.Marshal(kubeletConfig)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, string(result))
+}
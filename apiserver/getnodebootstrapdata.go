@@ -43,6 +43,12 @@ func (api *APIServer) GetNodeBootstrapData(w http.ResponseWriter, r *http.Reques
 	if api.Options != nil && api.Options.Toggles != nil {
 		agentBaker = agentBaker.WithToggles(api.Options.Toggles)
 	}
+	if api.TemplateBundles != nil {
+		agentBaker = agentBaker.WithTemplateBundles(api.TemplateBundles)
+	}
+	// raw=true returns the unencoded bootstrapping files as plain text, for review during development.
+	raw := r.URL.Query().Get("raw") == "true"
+	agentBaker = agentBaker.WithRawOutput(raw)
 
 	nodeBootStrapping, err := agentBaker.GetNodeBootstrapping(ctx, &config)
 	if err != nil {
@@ -51,6 +57,13 @@ func (api *APIServer) GetNodeBootstrapData(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if raw {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, nodeBootStrapping.RawText())
+		return
+	}
+
 	result, err := json.Marshal(nodeBootStrapping)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -0,0 +1,52 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/Azure/agentbaker/pkg/agent"
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+)
+
+const (
+	// RoutePathRequiredArtifacts the route path to get the artifacts a node needs while bootstrapping.
+	RoutePathRequiredArtifacts string = "/getrequiredartifacts"
+)
+
+// GetRequiredArtifacts endpoint for listing the artifacts a node needs while bootstrapping.
+func (api *APIServer) GetRequiredArtifacts(w http.ResponseWriter, r *http.Request) {
+	var config datamodel.NodeBootstrappingConfiguration
+
+	err := json.NewDecoder(r.Body).Decode(&config)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	agentBaker, err := agent.NewAgentBaker()
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	artifacts, err := agentBaker.GetRequiredArtifacts(&config)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := json.Marshal(artifacts)
+	if err != nil {
+		log.Println(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, string(result))
+}
@@ -40,6 +40,24 @@ func (api *APIServer) NewRouter() *mux.Router {
 		Name("GetDistroSigImageConfig").
 		HandlerFunc(api.GetDistroSigImageConfig)
 
+	router.
+		Methods("POST").
+		Path(RoutePathDefaultKubeletConfig).
+		Name("GetDefaultKubeletConfig").
+		HandlerFunc(api.GetDefaultKubeletConfig)
+
+	router.
+		Methods("POST").
+		Path(RoutePathComponentVersions).
+		Name("GetComponentVersions").
+		HandlerFunc(api.GetComponentVersions)
+
+	router.
+		Methods("POST").
+		Path(RoutePathRequiredArtifacts).
+		Name("GetRequiredArtifacts").
+		HandlerFunc(api.GetRequiredArtifacts)
+
 	router.Methods("GET").Path("/healthz").Name("healthz").HandlerFunc(healthz)
 
 	// global timeout and panic handlers.
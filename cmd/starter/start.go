@@ -16,6 +16,10 @@ import (
 func Execute(configurators ...apiserver.OptionConfigurator) {
 	rootCmd.AddCommand(startCmd)
 	startCmd.Flags().StringVar(&options.Addr, "addr", ":8080", "the addr to serve the api on")
+	startCmd.Flags().StringSliceVar(&options.PreviousTemplateBundleDirs, "previous-template-bundle", nil,
+		"directory holding the parts of a previous agentbaker version, which pinned nodepools keep using")
+	startCmd.Flags().IntVar(&options.MaxPreviousTemplateBundles, "max-previous-template-bundles", apiserver.DefaultMaxPreviousTemplateBundles,
+		"maximum number of previous template bundles served")
 
 	for _, configurator := range configurators {
 		configurator(options)
@@ -99,7 +99,7 @@ func nbcToAKSNodeConfigV1(nbc *datamodel.NodeBootstrappingConfiguration) *aksnod
 			KubeletConfigFileContent: base64.StdEncoding.EncodeToString([]byte(agent.GetKubeletConfigFileContent(nbc.KubeletConfig, nbc.AgentPoolProfile.CustomKubeletConfig))),
 			EnableKubeletConfigFile:  false,
 			KubeletFlags:             helpers.GetKubeletConfigFlag(nbc.KubeletConfig, cs, agentPool, false),
-			KubeletNodeLabels:        helpers.GetKubeletNodeLabels(agentPool),
+			KubeletNodeLabels:        helpers.GetKubeletNodeLabels(nbc),
 		},
 		BootstrappingConfig: &aksnodeconfigv1.BootstrappingConfig{
 			TlsBootstrappingToken: nbc.KubeletClientTLSBootstrapToken,
@@ -0,0 +1,35 @@
+package toolkit
+
+import (
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v6"
+)
+
+// ApplyVMSecurityProfile sets the securityProfile of the VMs of a pool with the trusted launch or confidential VM
+// security type, standard VMs have none. The guest state of the ephemeral OS disk of confidential VMs isn't persisted.
+func ApplyVMSecurityProfile(model *armcompute.VirtualMachineScaleSet, profile *datamodel.VMSecurityProfile) {
+	var securityType armcompute.SecurityTypes
+	switch profile.GetVMSecurityType() {
+	case datamodel.VMSecurityTypeTrustedLaunch:
+		securityType = armcompute.SecurityTypesTrustedLaunch
+	case datamodel.VMSecurityTypeConfidentialVM:
+		securityType = armcompute.SecurityTypesConfidentialVM
+		osDisk := model.Properties.VirtualMachineProfile.StorageProfile.OSDisk
+		if osDisk.ManagedDisk == nil {
+			osDisk.ManagedDisk = &armcompute.VirtualMachineScaleSetManagedDiskParameters{}
+		}
+		osDisk.ManagedDisk.SecurityProfile = &armcompute.VMDiskSecurityProfile{
+			SecurityEncryptionType: to.Ptr(armcompute.SecurityEncryptionTypesNonPersistedTPM),
+		}
+	default:
+		return
+	}
+	model.Properties.VirtualMachineProfile.SecurityProfile = &armcompute.SecurityProfile{
+		SecurityType: to.Ptr(securityType),
+		UefiSettings: &armcompute.UefiSettings{
+			SecureBootEnabled: to.Ptr(profile.IsSecureBootEnabled()),
+			VTpmEnabled:       to.Ptr(profile.IsVTPMEnabled()),
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package toolkit
+
+import (
+	"testing"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v6"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyVMSecurityProfile(t *testing.T) {
+	newModel := func() *armcompute.VirtualMachineScaleSet {
+		return &armcompute.VirtualMachineScaleSet{
+			Properties: &armcompute.VirtualMachineScaleSetProperties{
+				VirtualMachineProfile: &armcompute.VirtualMachineScaleSetVMProfile{
+					StorageProfile: &armcompute.VirtualMachineScaleSetStorageProfile{
+						OSDisk: &armcompute.VirtualMachineScaleSetOSDisk{
+							ManagedDisk: &armcompute.VirtualMachineScaleSetManagedDiskParameters{
+								StorageAccountType: to.Ptr(armcompute.StorageAccountTypesPremiumLRS),
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("standard", func(t *testing.T) {
+		model := newModel()
+		ApplyVMSecurityProfile(model, nil)
+		assert.Nil(t, model.Properties.VirtualMachineProfile.SecurityProfile)
+		assert.Nil(t, model.Properties.VirtualMachineProfile.StorageProfile.OSDisk.ManagedDisk.SecurityProfile)
+	})
+
+	t.Run("trusted launch", func(t *testing.T) {
+		model := newModel()
+		ApplyVMSecurityProfile(model, &datamodel.VMSecurityProfile{
+			SecurityType:     datamodel.VMSecurityTypeTrustedLaunch,
+			EnableSecureBoot: to.Ptr(false),
+		})
+		assert.Equal(t, &armcompute.SecurityProfile{
+			SecurityType: to.Ptr(armcompute.SecurityTypesTrustedLaunch),
+			UefiSettings: &armcompute.UefiSettings{SecureBootEnabled: to.Ptr(false), VTpmEnabled: to.Ptr(true)},
+		}, model.Properties.VirtualMachineProfile.SecurityProfile)
+		assert.Nil(t, model.Properties.VirtualMachineProfile.StorageProfile.OSDisk.ManagedDisk.SecurityProfile)
+	})
+
+	t.Run("confidential VM", func(t *testing.T) {
+		model := newModel()
+		ApplyVMSecurityProfile(model, &datamodel.VMSecurityProfile{SecurityType: datamodel.VMSecurityTypeConfidentialVM})
+		assert.Equal(t, &armcompute.SecurityProfile{
+			SecurityType: to.Ptr(armcompute.SecurityTypesConfidentialVM),
+			UefiSettings: &armcompute.UefiSettings{SecureBootEnabled: to.Ptr(true), VTpmEnabled: to.Ptr(true)},
+		}, model.Properties.VirtualMachineProfile.SecurityProfile)
+		managedDisk := model.Properties.VirtualMachineProfile.StorageProfile.OSDisk.ManagedDisk
+		assert.Equal(t, armcompute.StorageAccountTypesPremiumLRS, *managedDisk.StorageAccountType)
+		require.NotNil(t, managedDisk.SecurityProfile)
+		assert.Equal(t, armcompute.SecurityEncryptionTypesNonPersistedTPM, *managedDisk.SecurityProfile.SecurityEncryptionType)
+	})
+
+	t.Run("confidential VM without managed disk parameters", func(t *testing.T) {
+		model := newModel()
+		model.Properties.VirtualMachineProfile.StorageProfile.OSDisk.ManagedDisk = nil
+		ApplyVMSecurityProfile(model, &datamodel.VMSecurityProfile{SecurityType: datamodel.VMSecurityTypeConfidentialVM})
+		managedDisk := model.Properties.VirtualMachineProfile.StorageProfile.OSDisk.ManagedDisk
+		require.NotNil(t, managedDisk)
+		assert.Equal(t, armcompute.SecurityEncryptionTypesNonPersistedTPM, *managedDisk.SecurityProfile.SecurityEncryptionType)
+	})
+}
@@ -6,8 +6,11 @@ package agent
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"io/fs"
 	"reflect"
 	"strconv"
 	"strings"
@@ -15,29 +18,56 @@ import (
 
 	"github.com/Azure/agentbaker/parts"
 	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/Azure/agentbaker/pkg/agent/gpuskus"
+	"github.com/Azure/agentbaker/pkg/agent/nodelabels"
 	"github.com/Azure/go-autorest/autorest/to"
 )
 
-// TemplateGenerator represents the object that performs the template generation.
-type TemplateGenerator struct{}
+// TemplateGenerator represents the object that performs the template generation. It is safe for concurrent use, the
+// templates are parsed on first use or by Precompile.
+type TemplateGenerator struct {
+	// templates is the template bundle the bootstrapping data is rendered from.
+	templates fs.FS
+	// cache holds the parsed templates, which the generators of a bundle share.
+	cache *templateCache
+}
 
-// InitializeTemplateGenerator creates a new template generator object.
+// InitializeTemplateGenerator creates a new template generator object using the embedded templates.
 func InitializeTemplateGenerator() *TemplateGenerator {
-	t := &TemplateGenerator{}
-	return t
+	return newTemplateGenerator(parts.Templates)
+}
+
+func newTemplateGenerator(templates fs.FS) *TemplateGenerator {
+	return &TemplateGenerator{templates: templates, cache: &templateCache{}}
 }
 
 // GetNodeBootstrappingPayload get node bootstrapping data.
 // This function only can be called after the validation of the input NodeBootstrappingConfiguration.
-func (t *TemplateGenerator) getNodeBootstrappingPayload(config *datamodel.NodeBootstrappingConfiguration) string {
-	var customData string
+func (t *TemplateGenerator) getNodeBootstrappingPayload(ctx context.Context, config *datamodel.NodeBootstrappingConfiguration) (string, error) {
+	customData, err := t.getNodeBootstrappingCustomData(ctx, config)
+	if err != nil {
+		return "", err
+	}
+	return encodeNodeBootstrappingPayload(config, customData), nil
+}
+
+// getNodeBootstrappingCustomData returns the unencoded custom data, the cloud-init YAML of Linux nodes.
+func (t *TemplateGenerator) getNodeBootstrappingCustomData(ctx context.Context, config *datamodel.NodeBootstrappingConfiguration) (string, error) {
+	var customDataJSON string
+	var err error
 	if config.AgentPoolProfile.IsWindows() {
-		customData = getCustomDataFromJSON(t.getWindowsNodeCustomDataJSONObject(config))
+		customDataJSON, err = t.getWindowsNodeCustomDataJSONObject(ctx, config)
 	} else {
-		customData = getCustomDataFromJSON(t.getLinuxNodeCustomDataJSONObject(config))
+		customDataJSON, err = t.getLinuxNodeCustomDataJSONObject(ctx, config)
+	}
+	if err != nil {
+		return "", err
 	}
+	return getCustomDataFromJSON(customDataJSON), nil
+}
 
-	if config.AgentPoolProfile.IsWindows() {
+func encodeNodeBootstrappingPayload(config *datamodel.NodeBootstrappingConfiguration, customData string) string {
+	if config.AgentPoolProfile.IsWindows() || config.AgentPoolProfile.Distro.IsIgnitionDistro() {
 		return base64.StdEncoding.EncodeToString([]byte(customData))
 	}
 
@@ -46,93 +76,109 @@ func (t *TemplateGenerator) getNodeBootstrappingPayload(config *datamodel.NodeBo
 
 // GetLinuxNodeCustomDataJSONObject returns Linux customData JSON object in the form.
 // { "customData": "<customData string>" }.
-func (t *TemplateGenerator) getLinuxNodeCustomDataJSONObject(config *datamodel.NodeBootstrappingConfiguration) string {
+func (t *TemplateGenerator) getLinuxNodeCustomDataJSONObject(ctx context.Context, config *datamodel.NodeBootstrappingConfiguration) (string, error) {
 	// get parameters
-	parameters := getParameters(config)
+	parameters, err := getParameters(config)
+	if err != nil {
+		return "", err
+	}
 	// get variable cloudInit
-	variables := getCustomDataVariables(config)
-	str, e := t.getSingleLineForTemplate(kubernetesNodeCustomDataYaml, config.AgentPoolProfile, getBakerFuncMap(config, parameters, variables), true)
+	variables := t.getCustomDataVariables(ctx, config)
+	str, e := t.getSingleLineForTemplate(ctx, kubernetesNodeCustomDataYaml, config.AgentPoolProfile, t.getBakerFuncMap(ctx, config, parameters, variables), true)
 
 	if e != nil {
-		panic(e)
+		return "", e
 	}
 
-	return fmt.Sprintf("{\"customData\": \"%s\"}", str)
+	return fmt.Sprintf("{\"customData\": \"%s\"}", str), nil
 }
 
 // GetWindowsNodeCustomDataJSONObject returns Windows customData JSON object in the form.
 // { "customData": "<customData string>" }.
-func (t *TemplateGenerator) getWindowsNodeCustomDataJSONObject(config *datamodel.NodeBootstrappingConfiguration) string {
+func (t *TemplateGenerator) getWindowsNodeCustomDataJSONObject(ctx context.Context, config *datamodel.NodeBootstrappingConfiguration) (string, error) {
 	cs := config.ContainerService
 	profile := config.AgentPoolProfile
 	// get parameters
-	parameters := getParameters(config)
+	parameters, err := getParameters(config)
+	if err != nil {
+		return "", err
+	}
 	// get variable custom data
 	variables := getWindowsCustomDataVariables(config)
-	str, e := t.getSingleLineForTemplate(kubernetesWindowsAgentCustomDataPS1, profile, getBakerFuncMap(config, parameters, variables), false)
+	str, e := t.getSingleLineForTemplate(ctx, kubernetesWindowsAgentCustomDataPS1, profile, t.getBakerFuncMap(ctx, config, parameters, variables), false)
 
 	if e != nil {
-		panic(e)
+		return "", e
 	}
 
 	preprovisionCmd := ""
 
 	if profile.PreprovisionExtension != nil {
-		preprovisionCmd = makeAgentExtensionScriptCommands(cs, profile)
+		if preprovisionCmd, e = makeAgentExtensionScriptCommands(ctx, cs, profile); e != nil {
+			return "", e
+		}
 	}
 
 	str = strings.ReplaceAll(str, "PREPROVISION_EXTENSION", escapeSingleLine(strings.TrimSpace(preprovisionCmd)))
-	return fmt.Sprintf("{\"customData\": \"%s\"}", str)
+	return fmt.Sprintf("{\"customData\": \"%s\"}", str), nil
 }
 
 // GetNodeBootstrappingCmd get node bootstrapping cmd.
 // This function only can be called after the validation of the input NodeBootstrappingConfiguration.
-func (t *TemplateGenerator) getNodeBootstrappingCmd(config *datamodel.NodeBootstrappingConfiguration) string {
+func (t *TemplateGenerator) getNodeBootstrappingCmd(ctx context.Context, config *datamodel.NodeBootstrappingConfiguration) (string, error) {
 	if config.AgentPoolProfile.IsWindows() {
-		return t.getWindowsNodeCSECommand(config)
+		return t.getWindowsNodeCSECommand(ctx, config)
 	}
-	return t.getLinuxNodeCSECommand(config)
+	return t.getLinuxNodeCSECommand(ctx, config)
 }
 
 // getLinuxNodeCSECommand returns Linux node custom script extension execution command.
-func (t *TemplateGenerator) getLinuxNodeCSECommand(config *datamodel.NodeBootstrappingConfiguration) string {
+func (t *TemplateGenerator) getLinuxNodeCSECommand(ctx context.Context, config *datamodel.NodeBootstrappingConfiguration) (string, error) {
 	// get parameters
-	parameters := getParameters(config)
+	parameters, err := getParameters(config)
+	if err != nil {
+		return "", err
+	}
 	// get variable
 	variables := getCSECommandVariables(config)
 	// NOTE: that CSE command will be executed by VM/VMSS extension so it doesn't need extra escaping like custom data does
 	str, e := t.getSingleLine(
+		ctx,
 		kubernetesCSECommandString,
 		config.AgentPoolProfile,
-		getBakerFuncMap(config, parameters, variables),
+		t.getBakerFuncMap(ctx, config, parameters, variables),
 		true,
 	)
 
 	if e != nil {
-		panic(e)
+		return "", e
 	}
 	// NOTE: we break the one-line CSE command into different lines in a file for better management
 	// so we need to combine them into one line here
-	return strings.ReplaceAll(str, "\n", " ")
+	return strings.ReplaceAll(str, "\n", " "), nil
 }
 
 // getWindowsNodeCSECommand returns Windows node custom script extension execution command.
-func (t *TemplateGenerator) getWindowsNodeCSECommand(config *datamodel.NodeBootstrappingConfiguration) string {
+func (t *TemplateGenerator) getWindowsNodeCSECommand(ctx context.Context, config *datamodel.NodeBootstrappingConfiguration) (string, error) {
 	// get parameters
-	parameters := getParameters(config)
+	parameters, err := getParameters(config)
+	if err != nil {
+		return "", err
+	}
 	// get variable
 	variables := getCSECommandVariables(config)
 
 	// NOTE: that CSE command will be executed by VMSS extension so it doesn't need extra escaping like custom data does
 	str, e := t.getSingleLine(
+		ctx,
 		kubernetesWindowsAgentCSECommandPS1,
 		config.AgentPoolProfile,
-		getBakerFuncMap(config, parameters, variables),
+		t.getBakerFuncMap(ctx, config, parameters, variables),
 		false,
 	)
 
 	if e != nil {
-		panic(e)
+		return "", e
 	}
 	/* NOTE(qinahao): windows cse cmd uses esapced \" to quote Powershell command in
 	[csecmd.p1](https://github.com/Azure/AgentBaker/blob/master/parts/windows/csecmd.ps1). */
@@ -141,12 +187,13 @@ func (t *TemplateGenerator) getWindowsNodeCSECommand(config *datamodel.NodeBoots
 
 	// NOTE: we break the one-line CSE command into different lines in a file for better management
 	// so we need to combine them into one line here
-	return strings.ReplaceAll(str, "\n", " ")
+	return strings.ReplaceAll(str, "\n", " "), nil
 }
 
 // getSingleLineForTemplate returns the file as a single line for embedding in an arm template.
-func (t *TemplateGenerator) getSingleLineForTemplate(textFilename string, profile interface{}, funcMap template.FuncMap, isLinux bool) (string, error) {
-	expandedTemplate, err := t.getSingleLine(textFilename, profile, funcMap, isLinux)
+func (t *TemplateGenerator) getSingleLineForTemplate(ctx context.Context, textFilename string, profile interface{}, funcMap template.FuncMap,
+	isLinux bool) (string, error) {
+	expandedTemplate, err := t.getSingleLine(ctx, textFilename, profile, funcMap, isLinux)
 	if err != nil {
 		return "", err
 	}
@@ -156,24 +203,20 @@ func (t *TemplateGenerator) getSingleLineForTemplate(textFilename string, profil
 	return textStr, nil
 }
 
-// getSingleLine returns the file as a single line.
-func (t *TemplateGenerator) getSingleLine(textFilename string, profile interface{}, funcMap template.FuncMap, isLinux bool) (string, error) {
-	b, err := parts.Templates.ReadFile(textFilename)
-	if err != nil {
-		return "", fmt.Errorf("yaml file %s does not exist", textFilename)
+// getSingleLine returns the file as a single line. The rendering stops at the next write to the output once ctx is done.
+func (t *TemplateGenerator) getSingleLine(ctx context.Context, textFilename string, profile interface{}, funcMap template.FuncMap,
+	isLinux bool) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("render %s: %w", textFilename, err)
 	}
-	if isLinux {
-		b = removeComments(b)
-	}
-
-	// use go templates to process the text filename
-	templ := template.New("customdata template").Option("missingkey=zero").Funcs(funcMap)
-	if _, err = templ.New(textFilename).Parse(string(b)); err != nil {
-		return "", fmt.Errorf("error parsing file %s: %w", textFilename, err)
+	templ, err := t.customDataTemplate(textFilename, isLinux, funcMap)
+	if err != nil {
+		return "", err
 	}
+	templ.Funcs(funcMap)
 
 	var buffer bytes.Buffer
-	if err = templ.ExecuteTemplate(&buffer, textFilename, profile); err != nil {
+	if err = templ.ExecuteTemplate(&contextWriter{ctx: ctx, w: &buffer}, textFilename, profile); err != nil {
 		return "", fmt.Errorf("error executing template for file %s: %w", textFilename, err)
 	}
 	expandedTemplate := buffer.String()
@@ -181,9 +224,22 @@ func (t *TemplateGenerator) getSingleLine(textFilename string, profile interface
 	return expandedTemplate, nil
 }
 
+// contextWriter fails the writes once its context is done, which aborts the template execution writing to it.
+type contextWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (c *contextWriter) Write(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.w.Write(p)
+}
+
 // getTemplateFuncMap returns the general purpose template func map from getContainerServiceFuncMap.
-func getBakerFuncMap(config *datamodel.NodeBootstrappingConfiguration, params paramsMap, variables paramsMap) template.FuncMap {
-	funcMap := getContainerServiceFuncMap(config)
+func (t *TemplateGenerator) getBakerFuncMap(ctx context.Context, config *datamodel.NodeBootstrappingConfiguration, params paramsMap, variables paramsMap) template.FuncMap {
+	funcMap := t.getContainerServiceFuncMap(ctx, config)
 
 	funcMap["GetParameter"] = func(s string) interface{} {
 		if v, ok := params[s].(paramsMap); ok && v != nil {
@@ -358,7 +414,7 @@ func validateAndSetWindowsNodeBootstrappingConfiguration(config *datamodel.NodeB
 /* These funcs are a thin wrapper for template generation operations,
 all business logic is implemented in the underlying func. */
 //nolint:gocognit, funlen, cyclop, gocyclo
-func getContainerServiceFuncMap(config *datamodel.NodeBootstrappingConfiguration) template.FuncMap {
+func (t *TemplateGenerator) getContainerServiceFuncMap(ctx context.Context, config *datamodel.NodeBootstrappingConfiguration) template.FuncMap {
 	cs := config.ContainerService
 	profile := config.AgentPoolProfile
 	return template.FuncMap{
@@ -378,7 +434,7 @@ func getContainerServiceFuncMap(config *datamodel.NodeBootstrappingConfiguration
 			return cs.Properties.OrchestratorProfile.IsKubernetes() && IsKubernetesVersionGe(cs.Properties.OrchestratorProfile.OrchestratorVersion, version)
 		},
 		"GetAgentKubernetesLabels": func(profile *datamodel.AgentPoolProfile) string {
-			return profile.GetKubernetesLabels()
+			return nodelabels.Format(config.GetNodeLabels())
 		},
 		"GetAgentKubernetesLabelsDeprecated": func(profile *datamodel.AgentPoolProfile) string {
 			return profile.GetKubernetesLabels()
@@ -390,10 +446,10 @@ func getContainerServiceFuncMap(config *datamodel.NodeBootstrappingConfiguration
 			return config.GPUInstanceProfile != ""
 		},
 		"GetKubeletConfigFileContent": func() string {
-			return GetKubeletConfigFileContent(config.KubeletConfig, profile.CustomKubeletConfig)
+			return GetKubeletConfigFileContent(config.KubeletConfig, getCustomKubeletConfig(config))
 		},
 		"GetKubeletConfigFileContentBase64": func() string {
-			return base64.StdEncoding.EncodeToString([]byte(GetKubeletConfigFileContent(config.KubeletConfig, profile.CustomKubeletConfig)))
+			return base64.StdEncoding.EncodeToString([]byte(GetKubeletConfigFileContent(config.KubeletConfig, getCustomKubeletConfig(config))))
 		},
 		"IsKubeletConfigFileEnabled": func() bool {
 			return IsKubeletConfigFileEnabled(cs, profile, config.EnableKubeletConfigFile)
@@ -425,7 +481,8 @@ func getContainerServiceFuncMap(config *datamodel.NodeBootstrappingConfiguration
 			return config.GetOrderedKubeproxyConfigStringForPowershell()
 		},
 		"IsCgroupV2": func() bool {
-			return profile.Is2204VHDDistro() || profile.IsAzureLinuxCgroupV2VHDDistro() || profile.Is2404VHDDistro()
+			capabilities, _ := profile.Distro.Capabilities()
+			return capabilities.CgroupVersion == 2
 		},
 		"GetKubeProxyFeatureGatesPsh": func() string {
 			return cs.Properties.GetKubeProxyFeatureGatesWindowsArguments()
@@ -505,7 +562,8 @@ func getContainerServiceFuncMap(config *datamodel.NodeBootstrappingConfiguration
 			return profile.Distro.IsKataDistro()
 		},
 		"IsCustomImage": func() bool {
-			return profile.Distro == datamodel.CustomizedImage || profile.Distro == datamodel.CustomizedImageKata
+			return profile.Distro == datamodel.CustomizedImage || profile.Distro == datamodel.CustomizedImageKata ||
+				profile.Distro == datamodel.CustomizedImageFlatcar
 		},
 		"EnableHostsConfigAgent": func() bool {
 			return cs.Properties.OrchestratorProfile.KubernetesConfig != nil &&
@@ -518,13 +576,15 @@ func getContainerServiceFuncMap(config *datamodel.NodeBootstrappingConfiguration
 		"GetSshPublicKeysPowerShell": func() string {
 			return getSSHPublicKeysPowerShell(cs.Properties.LinuxProfile)
 		},
-		"GetKubernetesAgentPreprovisionYaml": func(profile *datamodel.AgentPoolProfile) string {
-			str := ""
-			if profile.PreprovisionExtension != nil {
-				str += "\n"
-				str += makeAgentExtensionScriptCommands(cs, profile)
+		"GetKubernetesAgentPreprovisionYaml": func(profile *datamodel.AgentPoolProfile) (string, error) {
+			if profile.PreprovisionExtension == nil {
+				return "", nil
+			}
+			commands, err := makeAgentExtensionScriptCommands(ctx, cs, profile)
+			if err != nil {
+				return "", err
 			}
-			return str
+			return "\n" + commands, nil
 		},
 		"GetKubernetesWindowsAgentFunctions": func() string {
 			// Collect all the parts into a zip
@@ -542,7 +602,7 @@ func getContainerServiceFuncMap(config *datamodel.NodeBootstrappingConfiguration
 				if err != nil {
 					panic(err)
 				}
-				partContents, err := parts.Templates.ReadFile(part)
+				partContents, err := fs.ReadFile(t.templates, part)
 				if err != nil {
 					panic(err)
 				}
@@ -639,6 +699,9 @@ func getContainerServiceFuncMap(config *datamodel.NodeBootstrappingConfiguration
 		"IsKubenet": func() bool {
 			return cs.Properties.OrchestratorProfile.KubernetesConfig.NetworkPlugin == NetworkPluginKubenet
 		},
+		"GetPodNetworkMode": func() string {
+			return string(getPodNetworkMode(cs.Properties.OrchestratorProfile.KubernetesConfig))
+		},
 		"NeedsContainerd": func() bool {
 			if profile != nil && profile.KubernetesConfig != nil && profile.KubernetesConfig.ContainerRuntime != "" {
 				return profile.KubernetesConfig.NeedsContainerd()
@@ -689,14 +752,14 @@ func getContainerServiceFuncMap(config *datamodel.NodeBootstrappingConfiguration
 			return base64.StdEncoding.EncodeToString([]byte(kubenetCniTemplate))
 		},
 		"GetContainerdConfigContent": func() string {
-			output, err := containerdConfigFromTemplate(config, profile, containerdConfigTemplateString)
+			output, err := t.containerdConfigFromTemplate(ctx, config, profile, containerdConfigTemplateString)
 			if err != nil {
 				panic(err)
 			}
 			return output
 		},
 		"GetContainerdConfigNoGPUContent": func() string {
-			output, err := containerdConfigFromTemplate(config, profile, containerdConfigNoGpuTemplateString)
+			output, err := t.containerdConfigFromTemplate(ctx, config, profile, containerdConfigNoGpuTemplateString)
 			if err != nil {
 				panic(err)
 			}
@@ -925,7 +988,7 @@ func getContainerServiceFuncMap(config *datamodel.NodeBootstrappingConfiguration
 			return GetAKSGPUImageSHA(profile.VMSize)
 		},
 		"GPUDriverType": func() string {
-			return getGPUDriverType(profile.VMSize)
+			return GetGPUDriverType(profile.VMSize)
 		},
 		"GetHnsRemediatorIntervalInMinutes": func() uint32 {
 			// Only need to enable HNSRemediator for Windows 2019
@@ -949,12 +1012,18 @@ func getContainerServiceFuncMap(config *datamodel.NodeBootstrappingConfiguration
 			}
 			return 0
 		},
+		"GetWindowsContainerdConfigContent": func() (string, error) {
+			return getWindowsContainerdConfigContent(cs, profile)
+		},
 		"ShouldDisableSSH": func() bool {
 			return config.SSHStatus == datamodel.SSHOff
 		},
 		"GetSysctlContent": func() (string, error) {
 			templateFuncMap := make(template.FuncMap)
 			templateFuncMap["getPortRangeEndValue"] = getPortRangeEndValue
+			templateFuncMap["GetPodNetworkSysctls"] = func() []string {
+				return podNetworkSysctls(getPodNetworkMode(cs.Properties.OrchestratorProfile.KubernetesConfig))
+			}
 			sysctlTemplate, err := template.New("sysctl").Funcs(templateFuncMap).Parse(sysctlTemplateString)
 			if err != nil {
 				return "", fmt.Errorf("failed to parse sysctl template: %w", err)
@@ -1053,8 +1122,11 @@ func isStandardNCv1(size string) bool {
 	return strings.HasPrefix(tmp, "standard_nc") && !strings.Contains(tmp, "_v")
 }
 
+// useGridDrivers returns whether the size uses the "converged" GRID driver, which supports both cuda and grid workloads.
+// installing vanilla cuda drivers on those sizes fails with opaque errors.
 func useGridDrivers(size string) bool {
-	return datamodel.ConvergedGPUDriverSizes[strings.ToLower(size)]
+	sku, ok := gpuskus.Lookup(size)
+	return ok && sku.Driver == gpuskus.DriverGRID
 }
 
 func GetAKSGPUImageSHA(size string) string {
@@ -1064,15 +1136,24 @@ func GetAKSGPUImageSHA(size string) string {
 	return datamodel.AKSGPUCudaVersionSuffix
 }
 
-func getGPUDriverType(size string) string {
+func GetGPUDriverType(size string) string {
 	if useGridDrivers(size) {
-		return "grid"
+		return gpuskus.DriverGRID
 	}
-	return "cuda"
+	return gpuskus.DriverCUDA
 }
 
+// GPUNeedsFabricManager returns whether the GPUs of the size are connected through NVSwitches, which the fabric manager
+// configures.
 func GPUNeedsFabricManager(size string) bool {
-	return datamodel.FabricManagerGPUSizes[strings.ToLower(size)]
+	sku, ok := gpuskus.Lookup(size)
+	return ok && sku.NeedsFabricManager()
+}
+
+// IsNvidiaEnabledSKU returns whether the size has NVIDIA GPUs agentbaker knows about.
+func IsNvidiaEnabledSKU(size string) bool {
+	_, ok := gpuskus.Lookup(size)
+	return ok
 }
 
 func areCustomCATrustCertsPopulated(config datamodel.NodeBootstrappingConfiguration) bool {
@@ -1088,6 +1169,9 @@ const sysctlTemplateString = `# This is a partial workaround to this upstream Ku
 net.ipv4.tcp_retries2=8
 net.core.message_burst=80
 net.core.message_cost=40
+{{- range GetPodNetworkSysctls}}
+{{.}}
+{{- end}}
 {{- if .CustomLinuxOSConfig}}
 {{- if .CustomLinuxOSConfig.Sysctls}}
 {{- if .CustomLinuxOSConfig.Sysctls.NetCoreSomaxconn}}
@@ -1473,17 +1557,21 @@ root = "{{GetDataDir}}"{{- end}}
 {{- end}}
 `
 
-func containerdConfigFromTemplate(
+func (t *TemplateGenerator) containerdConfigFromTemplate(
+	ctx context.Context,
 	config *datamodel.NodeBootstrappingConfiguration,
 	profile *datamodel.AgentPoolProfile,
 	tmpl string,
 ) (string, error) {
-	parameters := getParameters(config)
-	variables := getCustomDataVariables(config)
-	bakerFuncMap := getBakerFuncMap(config, parameters, variables)
+	parameters, err := getParameters(config)
+	if err != nil {
+		return "", err
+	}
+	variables := t.getCustomDataVariables(ctx, config)
+	bakerFuncMap := t.getBakerFuncMap(ctx, config, parameters, variables)
 	containerdConfigTemplate := template.Must(template.New("kubenet").Funcs(bakerFuncMap).Parse(tmpl))
 	var b bytes.Buffer
-	if err := containerdConfigTemplate.Execute(&b, profile); err != nil {
+	if err := containerdConfigTemplate.Execute(&contextWriter{ctx: ctx, w: &b}, profile); err != nil {
 		return "", fmt.Errorf("failed to execute sysctl template: %w", err)
 	}
 	return base64.StdEncoding.EncodeToString(b.Bytes()), nil
@@ -5,9 +5,15 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/Azure/agentbaker/pkg/agent/catalog"
 	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/Azure/agentbaker/pkg/agent/kubeversion"
+	"github.com/Azure/agentbaker/pkg/agent/podcidr"
 	"github.com/Azure/agentbaker/pkg/agent/toggles"
 )
 
@@ -15,15 +21,40 @@ type AgentBaker interface {
 	GetNodeBootstrapping(ctx context.Context, config *datamodel.NodeBootstrappingConfiguration) (*datamodel.NodeBootstrapping, error)
 	GetLatestSigImageConfig(sigConfig datamodel.SIGConfig, distro datamodel.Distro, envInfo *datamodel.EnvironmentInfo) (*datamodel.SigImageConfig, error)
 	GetDistroSigImageConfig(sigConfig datamodel.SIGConfig, envInfo *datamodel.EnvironmentInfo) (map[datamodel.Distro]datamodel.SigImageConfig, error)
+	GetDefaultKubeletConfiguration(request *datamodel.GetDefaultKubeletConfigurationRequest) (*datamodel.DefaultKubeletConfiguration, error)
+	GetComponentVersions(request *datamodel.GetComponentVersionsRequest) ([]catalog.Artifact, error)
+	GetRequiredArtifacts(config *datamodel.NodeBootstrappingConfiguration) ([]datamodel.ArtifactDependency, error)
+	ValidateHybridPools(linuxConfig, windowsConfig *datamodel.NodeBootstrappingConfiguration) error
 }
 
 type agentBakerImpl struct {
 	toggles toggles.Toggles
+	// bundles are the template bundles which can be pinned, only the current bundle is served when nil.
+	bundles *TemplateBundles
+	// rawOutput adds the unencoded bootstrapping files to the node bootstrapping data.
+	rawOutput bool
+	// overrides replace or supplement the assets of the template bundles.
+	overrides []TemplateOverride
+	// offloader uploads the files moved out of custom data which doesn't fit in the Azure limit.
+	offloader PayloadOffloader
+	// offline generation makes no network calls.
+	offline bool
+	// timeout bounds the generation of the node bootstrapping data, on top of the deadline of the caller.
+	timeout time.Duration
 }
 
 var _ AgentBaker = (*agentBakerImpl)(nil)
 
+// NewAgentBaker returns an AgentBaker generating from the embedded templates, which are precompiled once. It is safe
+// for concurrent use.
 func NewAgentBaker() (*agentBakerImpl, error) {
+	bundle, err := CurrentTemplateBundle()
+	if err != nil {
+		return nil, err
+	}
+	if err := bundle.templateGenerator().Precompile(); err != nil {
+		return nil, fmt.Errorf("precompile templates: %w", err)
+	}
 	return &agentBakerImpl{
 		toggles: toggles.NewDefaultToggles(),
 	}, nil
@@ -34,24 +65,164 @@ func (agentBaker *agentBakerImpl) WithToggles(toggles toggles.Toggles) *agentBak
 	return agentBaker
 }
 
-//nolint:revive, nolintlint // ctx is not used, but may be in the future
+func (agentBaker *agentBakerImpl) WithTemplateBundles(bundles *TemplateBundles) *agentBakerImpl {
+	agentBaker.bundles = bundles
+	return agentBaker
+}
+
+// WithRawOutput adds the unencoded custom data, CSE command and kubelet config to the node bootstrapping data, so
+// that they can be reviewed during development.
+func (agentBaker *agentBakerImpl) WithRawOutput(rawOutput bool) *agentBakerImpl {
+	agentBaker.rawOutput = rawOutput
+	return agentBaker
+}
+
+// WithTemplateOverrides renders the bootstrapping data with the overrides applied to the template bundle, instead of
+// the embedded assets they replace. The template bundle hash of the bootstrapping data is the hash of the bundle with
+// the overrides, callers caching bootstrapping data pin it.
+func (agentBaker *agentBakerImpl) WithTemplateOverrides(overrides ...TemplateOverride) *agentBakerImpl {
+	agentBaker.overrides = overrides
+	return agentBaker
+}
+
+// WithPayloadOffloader moves the largest files out of custom data which doesn't fit in the Azure limit, the node
+// downloads them from the URLs the offloader returns instead. Generating custom data over the limit fails otherwise.
+func (agentBaker *agentBakerImpl) WithPayloadOffloader(offloader PayloadOffloader) *agentBakerImpl {
+	agentBaker.offloader = offloader
+	return agentBaker
+}
+
+// WithOffline guarantees GetNodeBootstrapping makes no network calls, for air-gapped build environments. The templates
+// and image configs are built in, the toggles, which may be backed by a remote service, and the payload offloader are
+// the only calls out. Offline generation doesn't make them and fails with an OfflineError listing them instead.
+func (agentBaker *agentBakerImpl) WithOffline(offline bool) *agentBakerImpl {
+	agentBaker.offline = offline
+	return agentBaker
+}
+
+// WithTimeout cancels the generations of node bootstrapping data which take longer than timeout, they fail with an
+// error wrapping context.DeadlineExceeded. Generations are only bound by the context of the caller when timeout is 0.
+func (agentBaker *agentBakerImpl) WithTimeout(timeout time.Duration) *agentBakerImpl {
+	agentBaker.timeout = timeout
+	return agentBaker
+}
+
+func (agentBaker *agentBakerImpl) templateBundle(hash string) (*TemplateBundle, error) {
+	bundles := agentBaker.bundles
+	if bundles == nil {
+		var err error
+		if bundles, err = NewTemplateBundles(nil, 0); err != nil {
+			return nil, err
+		}
+	}
+	if len(agentBaker.overrides) > 0 {
+		return bundles.GetWithOverrides(hash, agentBaker.overrides)
+	}
+	return bundles.Get(hash)
+}
+
 func (agentBaker *agentBakerImpl) GetNodeBootstrapping(ctx context.Context, config *datamodel.NodeBootstrappingConfiguration) (*datamodel.NodeBootstrapping, error) {
+	if agentBaker.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, agentBaker.timeout)
+		defer cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("generate node bootstrapping data: %w", err)
+	}
 	// validate and fix input before passing config to the template generator.
 	if config.AgentPoolProfile.IsWindows() {
 		validateAndSetWindowsNodeBootstrappingConfiguration(config)
 	} else {
 		ValidateAndSetLinuxNodeBootstrappingConfiguration(config)
 	}
-
-	templateGenerator := InitializeTemplateGenerator()
+	if err := validateOrchestratorVersion(config.ContainerService.Properties.OrchestratorProfile); err != nil {
+		return nil, err
+	}
+	if err := validatePodNetworkMode(config.ContainerService, config.AgentPoolProfile); err != nil {
+		return nil, err
+	}
+	setPodNetworkKubeletFlags(config)
+	warnings := applyKubeletFlagCompatibility(config)
+	if err := validatePodCIDRAllocation(config.ContainerService.Properties.OrchestratorProfile, config.AgentPoolProfile); err != nil {
+		return nil, err
+	}
+	if err := validateNICFeatures(config.ContainerService, config.AgentPoolProfile); err != nil {
+		return nil, err
+	}
+	if err := validateSpotPool(config.AgentPoolProfile); err != nil {
+		return nil, err
+	}
+	architectureWarnings, err := validateArchitecture(config)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, architectureWarnings...)
+	if err := validateBootstrapTarget(config); err != nil {
+		return nil, err
+	}
+	if err := validateDistroCapabilities(config); err != nil {
+		return nil, err
+	}
+	if err := validateVMSecurityProfile(config); err != nil {
+		return nil, err
+	}
+	if err := validateWindowsGPU(config); err != nil {
+		return nil, err
+	}
+	if err := validateKeyVaultReferences(config.ContainerService.Properties.CertificateProfile); err != nil {
+		return nil, err
+	}
+	if _, err := servicePrincipalSecrets(config.ContainerService.Properties); err != nil {
+		return nil, err
+	}
+	if err := validateExtensionReferences(config); err != nil {
+		return nil, err
+	}
+	bundle, err := agentBaker.templateBundle(config.TemplateBundleHash)
+	if err != nil {
+		return nil, err
+	}
+	templateGenerator := bundle.templateGenerator()
+	var fetches []string
+	offloader := agentBaker.offloader
+	if agentBaker.offline && offloader != nil {
+		offloader = offlineOffloader(&fetches)
+	}
+	customData, err := templateGenerator.getNodeBootstrappingCustomData(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	cloudConfigWarnings, err := validateCloudConfig(config, customData)
+	if err != nil {
+		return nil, err
+	}
+	warnings = append(warnings, cloudConfigWarnings...)
+	customData, payloadSize, err := fitCustomData(ctx, config, customData, offloader)
+	if err != nil {
+		return nil, err
+	}
+	cse, err := templateGenerator.getNodeBootstrappingCmd(ctx, config)
+	if err != nil {
+		return nil, err
+	}
 	nodeBootstrapping := &datamodel.NodeBootstrapping{
-		CustomData: templateGenerator.getNodeBootstrappingPayload(config),
-		CSE:        templateGenerator.getNodeBootstrappingCmd(config),
+		CustomData:         encodeNodeBootstrappingPayload(config, customData),
+		CSE:                cse,
+		TemplateBundleHash: bundle.Hash,
+		VMExtensions:       getNodeBootstrappingVMExtensions(config, cse),
+		Artifacts:          getNodeBootstrappingArtifacts(config),
+		PayloadSize:        payloadSize,
+		Warnings:           warnings,
+	}
+	if agentBaker.rawOutput {
+		nodeBootstrapping.RawFiles = getNodeBootstrappingRawFiles(config, customData, cse)
 	}
 
 	distro := config.AgentPoolProfile.Distro
-	if distro == datamodel.CustomizedWindowsOSImage || distro == datamodel.CustomizedImage || distro == datamodel.CustomizedImageKata {
-		return nodeBootstrapping, nil
+	if distro == datamodel.CustomizedWindowsOSImage || distro == datamodel.CustomizedImage || distro == datamodel.CustomizedImageKata ||
+		distro == datamodel.CustomizedImageFlatcar {
+		return offlineResult(nodeBootstrapping, fetches)
 	}
 
 	osImageConfigMap, hasCloud := datamodel.AzureCloudToOSImageMap[config.CloudSpecConfig.CloudName]
@@ -75,14 +246,18 @@ func (agentBaker *agentBakerImpl) GetNodeBootstrapping(ctx context.Context, conf
 
 	if !config.AgentPoolProfile.IsWindows() {
 		// handle node image version toggle/override
-		e := toggles.NewEntityFromNodeBootstrappingConfiguration(config)
-		imageVersion := agentBaker.toggles.GetLinuxNodeImageVersion(e, distro)
-		if imageVersion != "" {
-			nodeBootstrapping.SigImageConfig.Version = imageVersion
+		if agentBaker.offline && !toggles.IsDefault(agentBaker.toggles) {
+			fetches = append(fetches, "the node image version toggle of distro "+string(distro))
+		} else {
+			e := toggles.NewEntityFromNodeBootstrappingConfiguration(config)
+			imageVersion := agentBaker.toggles.GetLinuxNodeImageVersion(e, distro)
+			if imageVersion != "" {
+				nodeBootstrapping.SigImageConfig.Version = imageVersion
+			}
 		}
 	}
 
-	return nodeBootstrapping, nil
+	return offlineResult(nodeBootstrapping, fetches)
 }
 
 func (agentBaker *agentBakerImpl) GetLatestSigImageConfig(sigConfig datamodel.SIGConfig,
@@ -156,6 +331,216 @@ func (agentBaker *agentBakerImpl) GetDistroSigImageConfig(
 	return allDistros, nil
 }
 
+// GetDefaultKubeletConfiguration returns the kubelet configuration which would be generated for a node
+// with the given kubernetes version, distro and VM size, without generating the full bootstrapping payload.
+func (agentBaker *agentBakerImpl) GetDefaultKubeletConfiguration(
+	request *datamodel.GetDefaultKubeletConfigurationRequest) (*datamodel.DefaultKubeletConfiguration, error) {
+	if request == nil {
+		return nil, errors.New("request can not be nil")
+	}
+	if request.KubernetesVersion == "" {
+		return nil, errors.New("kubernetes version is required")
+	}
+
+	// copy the flags so the validation below doesn't modify the caller's map.
+	kubeletConfig := make(map[string]string, len(request.KubeletConfig))
+	for k, v := range request.KubeletConfig {
+		kubeletConfig[k] = v
+	}
+
+	profile := &datamodel.AgentPoolProfile{
+		VMSize: request.VMSize,
+		Distro: request.Distro,
+		OSType: request.OSType,
+		KubernetesConfig: &datamodel.KubernetesConfig{
+			ContainerRuntime: request.ContainerRuntime,
+		},
+		CustomKubeletConfig: request.CustomKubeletConfig,
+	}
+	cs := &datamodel.ContainerService{
+		Properties: &datamodel.Properties{
+			OrchestratorProfile: &datamodel.OrchestratorProfile{
+				OrchestratorType:    datamodel.Kubernetes,
+				OrchestratorVersion: request.KubernetesVersion,
+			},
+			AgentPoolProfiles: []*datamodel.AgentPoolProfile{profile},
+		},
+	}
+	config := &datamodel.NodeBootstrappingConfiguration{
+		ContainerService:        cs,
+		AgentPoolProfile:        profile,
+		KubeletConfig:           kubeletConfig,
+		EnableKubeletConfigFile: request.EnableKubeletConfigFile,
+	}
+
+	if profile.IsWindows() {
+		validateAndSetWindowsNodeBootstrappingConfiguration(config)
+		warnings := applyKubeletFlagCompatibility(config)
+		return &datamodel.DefaultKubeletConfiguration{
+			Flags:            config.KubeletConfig,
+			CommandLineFlags: config.GetOrderedKubeletConfigStringForPowershell(profile.CustomKubeletConfig),
+			Warnings:         warnings,
+		}, nil
+	}
+
+	ValidateAndSetLinuxNodeBootstrappingConfiguration(config)
+	warnings := applyKubeletFlagCompatibility(config)
+	result := &datamodel.DefaultKubeletConfiguration{
+		Flags:             config.KubeletConfig,
+		CommandLineFlags:  GetOrderedKubeletConfigFlagString(config),
+		ConfigFileEnabled: IsKubeletConfigFileEnabled(cs, profile, config.EnableKubeletConfigFile),
+		Warnings:          warnings,
+	}
+	if result.ConfigFileEnabled {
+		result.ConfigFileContent = GetKubeletConfigFileContent(config.KubeletConfig, getCustomKubeletConfig(config))
+	}
+	return result, nil
+}
+
+// validateOrchestratorVersion makes sure the Kubernetes version parses, the features gated on a minimum version would
+// otherwise all be silently disabled.
+func validateOrchestratorVersion(orchestratorProfile *datamodel.OrchestratorProfile) error {
+	if orchestratorProfile == nil || orchestratorProfile.OrchestratorVersion == "" {
+		return nil
+	}
+	if _, err := kubeversion.ParseLenient(orchestratorProfile.OrchestratorVersion); err != nil {
+		return fmt.Errorf("invalid Kubernetes version: %w", err)
+	}
+	return nil
+}
+
+// validatePodCIDRAllocation makes sure the per-node pod CIDRs of a kubenet cluster fit in the cluster subnet, and that
+// the cluster subnet doesn't overlap the VNET of the pool.
+func validatePodCIDRAllocation(orchestratorProfile *datamodel.OrchestratorProfile, profile *datamodel.AgentPoolProfile) error {
+	if orchestratorProfile == nil || orchestratorProfile.KubernetesConfig == nil ||
+		orchestratorProfile.KubernetesConfig.NetworkPlugin != NetworkPluginKubenet {
+		return nil
+	}
+	strategy, err := orchestratorProfile.KubernetesConfig.PodCIDRStrategy()
+	if err != nil {
+		return fmt.Errorf("invalid pod CIDR allocation: %w", err)
+	}
+	if profile != nil {
+		if err := podcidr.CheckOverlaps(strategy.ClusterCIDR(), profile.VnetCidrs); err != nil {
+			return fmt.Errorf("invalid pod CIDR allocation: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateNICFeatures makes sure the NIC features requested for the pool are supported by its VM size and network plugin.
+func validateNICFeatures(cs *datamodel.ContainerService, profile *datamodel.AgentPoolProfile) error {
+	if profile.IsAcceleratedNetworkingEnabled() && !supportsAcceleratedNetworking(profile.VMSize) {
+		return fmt.Errorf("VM size %s doesn't support accelerated networking", profile.VMSize)
+	}
+	if profile.IsInfiniBandEnabled() {
+		if profile.IsWindows() {
+			return errors.New("InfiniBand isn't supported on Windows nodes")
+		}
+		if !datamodel.IsRDMACapableSKU(profile.VMSize) {
+			return fmt.Errorf("VM size %s doesn't have an InfiniBand NIC", profile.VMSize)
+		}
+	}
+	if profile.EnableIPForwarding != nil && !*profile.EnableIPForwarding && isKubenet(cs) {
+		return errors.New("kubenet requires IP forwarding on the NIC")
+	}
+	return nil
+}
+
+// validateKeyVaultReferences checks the certificates which reference a Key Vault object: only secrets and
+// certificates can be passed as parameters.
+func validateKeyVaultReferences(profile *datamodel.CertificateProfile) error {
+	_, err := certificateSecrets(profile)
+	return err
+}
+
+// validateArchitecture makes sure the Windows and GPU settings of the pool match the CPU architecture of the node.
+// The distro and VM size are only compared with it for warnings: the VM size is matched against the naming of Arm-based
+// sizes, and callers set IsARM64 along with the distro of the image they deploy.
+func validateArchitecture(config *datamodel.NodeBootstrappingConfiguration) ([]string, error) {
+	profile := config.AgentPoolProfile
+	arch := config.GetArch()
+	var warnings []string
+	if arch == datamodel.ArchARM64 {
+		if profile.IsWindows() {
+			return nil, errors.New("arm64 Windows nodes aren't supported")
+		}
+		// ConfigGPUDriverIfNeeded only installs drivers on GPU sizes, which arm64 sizes aren't.
+		if config.EnableNvidia {
+			return nil, errors.New("GPU drivers aren't supported on arm64 nodes")
+		}
+		if profile.Distro != "" && !profile.Distro.IsARM64Distro() && !strings.HasPrefix(string(profile.Distro), "Customized") {
+			warnings = append(warnings, fmt.Sprintf("distro %s isn't an arm64 distro", profile.Distro))
+		}
+	}
+	if profile.VMSize != "" && isARM64VMSize(profile.VMSize) != (arch == datamodel.ArchARM64) {
+		warnings = append(warnings, fmt.Sprintf("VM size %s doesn't look like a size of the %s architecture of the node", profile.VMSize, arch))
+	}
+	return warnings, nil
+}
+
+// validateDistroCapabilities checks that the image of the distro supports the features the node uses. The capabilities
+// of custom images are unknown, they aren't checked.
+func validateDistroCapabilities(config *datamodel.NodeBootstrappingConfiguration) error {
+	distro := config.AgentPoolProfile.Distro
+	capabilities, ok := distro.Capabilities()
+	if !ok {
+		return nil
+	}
+	if config.EnableNvidia && !capabilities.GPUDrivers {
+		return fmt.Errorf("GPU drivers aren't supported on distro %s", distro)
+	}
+	if capabilities.OS == "windows" != config.AgentPoolProfile.IsWindows() {
+		return fmt.Errorf("distro %s doesn't match the %s OS type of the node", distro, config.AgentPoolProfile.OSType)
+	}
+	return nil
+}
+
+// validateVMSecurityProfile makes sure the VM size and image of the pool support its trusted launch or confidential
+// VM setting, and that measured boot has a vTPM to measure into. Only the images whose capabilities are known are
+// checked.
+func validateVMSecurityProfile(config *datamodel.NodeBootstrappingConfiguration) error {
+	profile := config.AgentPoolProfile
+	securityProfile := profile.VMSecurityProfile
+	securityType := securityProfile.GetVMSecurityType()
+	switch securityType {
+	case datamodel.VMSecurityTypeStandard:
+		if securityProfile != nil && (securityProfile.EnableSecureBoot != nil || securityProfile.EnableVTPM != nil) {
+			return errors.New("secure boot and vTPM require the TrustedLaunch or ConfidentialVM security type")
+		}
+	case datamodel.VMSecurityTypeTrustedLaunch, datamodel.VMSecurityTypeConfidentialVM:
+	default:
+		return fmt.Errorf("unknown VM security type %q", securityType)
+	}
+	if securityProfile.IsMeasuredBootEnabled() {
+		if profile.IsWindows() {
+			return errors.New("measured boot isn't supported on Windows nodes")
+		}
+		if !securityProfile.IsVTPMEnabled() {
+			return errors.New("measured boot requires a vTPM")
+		}
+	}
+	if securityType == datamodel.VMSecurityTypeConfidentialVM &&
+		!datamodel.GetConfidentialComputingType(profile.VMSize).IsConfidentialVM() {
+		return fmt.Errorf("VM size %s isn't a confidential VM size", profile.VMSize)
+	}
+
+	capabilities, ok := profile.Distro.Capabilities()
+	if !ok {
+		return nil
+	}
+	switch {
+	case securityType != datamodel.VMSecurityTypeStandard && !capabilities.Gen2:
+		return fmt.Errorf("%s requires a Gen2 image, distro %s is Gen1", securityType, profile.Distro)
+	// the Gen2 Windows images are signed for trusted launch, the Linux ones have dedicated images.
+	case securityType == datamodel.VMSecurityTypeTrustedLaunch && capabilities.OS != "windows" && !capabilities.TrustedLaunch:
+		return fmt.Errorf("distro %s doesn't support trusted launch", profile.Distro)
+	case securityType == datamodel.VMSecurityTypeConfidentialVM && !capabilities.ConfidentialVM:
+		return fmt.Errorf("distro %s doesn't support confidential VMs", profile.Distro)
+	}
+	return nil
+}
+
 func findSIGImageConfig(sigConfig datamodel.SIGAzureEnvironmentSpecConfig, distro datamodel.Distro) *datamodel.SigImageConfig {
 	if imageConfig, ok := sigConfig.SigUbuntuImageConfig[distro]; ok {
 		return &imageConfig
@@ -175,3 +560,39 @@ func findSIGImageConfig(sigConfig datamodel.SIGAzureEnvironmentSpecConfig, distr
 
 	return nil
 }
+
+// GetComponentVersions returns the versions, download URLs and checksums of the components installed on nodes of
+// the requested distro, architecture and Kubernetes version, from the component catalog.
+func (agentBaker *agentBakerImpl) GetComponentVersions(
+	request *datamodel.GetComponentVersionsRequest) ([]catalog.Artifact, error) {
+	if request == nil {
+		return nil, errors.New("request can not be nil")
+	}
+	components, err := catalog.Default()
+	if err != nil {
+		return nil, err
+	}
+	query := catalogQuery(request)
+	artifacts := components.Lookup(query)
+	if len(artifacts) == 0 && request.Component != "" {
+		return nil, fmt.Errorf("no version of component %q found for distro %q, arch %q and kubernetes version %q",
+			request.Component, request.Distro, query.Arch, request.KubernetesVersion)
+	}
+	return artifacts, nil
+}
+
+// GetRequiredArtifacts returns the container images, packages and files a node of the configuration needs while
+// bootstrapping, with their versions and digests, so that they can be mirrored to a private registry before the node
+// is created.
+func (agentBaker *agentBakerImpl) GetRequiredArtifacts(
+	config *datamodel.NodeBootstrappingConfiguration) ([]datamodel.ArtifactDependency, error) {
+	if config == nil || config.AgentPoolProfile == nil || config.CloudSpecConfig == nil || config.ContainerService == nil ||
+		config.ContainerService.Properties == nil || config.ContainerService.Properties.OrchestratorProfile == nil {
+		return nil, errors.New("config, its agent pool profile, cloud spec config and orchestrator profile can not be nil")
+	}
+	components, err := catalog.Default()
+	if err != nil {
+		return nil, err
+	}
+	return requiredArtifacts(config, components), nil
+}
@@ -434,4 +434,84 @@ var _ = Describe("AgentBaker API implementation tests", func() {
 		})
 
 	})
+
+	Context("GetDefaultKubeletConfiguration", func() {
+		It("should return an error when the kubernetes version is missing", func() {
+			agentBaker, err := NewAgentBaker()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = agentBaker.GetDefaultKubeletConfiguration(&datamodel.GetDefaultKubeletConfigurationRequest{})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return the validated linux kubelet flags without modifying the request", func() {
+			agentBaker, err := NewAgentBaker()
+			Expect(err).NotTo(HaveOccurred())
+
+			request := &datamodel.GetDefaultKubeletConfigurationRequest{
+				KubernetesVersion: "1.29.2",
+				Distro:            datamodel.AKSUbuntuContainerd2204,
+				VMSize:            "Standard_DS1_v2",
+				OSType:            datamodel.Linux,
+				ContainerRuntime:  datamodel.Containerd,
+				KubeletConfig: map[string]string{
+					"--max-pods":           "110",
+					"--network-plugin":     "kubenet",
+					"--dynamic-config-dir": "/var/lib/kubelet",
+					"--feature-gates":      "DynamicKubeletConfig=false,a=true",
+				},
+			}
+			result, err := agentBaker.GetDefaultKubeletConfiguration(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Flags).To(Equal(map[string]string{
+				"--max-pods":      "110",
+				"--feature-gates": "a=true",
+			}))
+			Expect(result.CommandLineFlags).To(Equal("--feature-gates=a=true --max-pods=110 "))
+			Expect(result.ConfigFileEnabled).To(BeFalse())
+			Expect(result.ConfigFileContent).To(BeEmpty())
+			Expect(request.KubeletConfig).To(HaveKey("--dynamic-config-dir"))
+		})
+
+		It("should return the kubelet config file content when the config file is enabled", func() {
+			agentBaker, err := NewAgentBaker()
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := agentBaker.GetDefaultKubeletConfiguration(&datamodel.GetDefaultKubeletConfigurationRequest{
+				KubernetesVersion:       "1.29.2",
+				Distro:                  datamodel.AKSUbuntuContainerd2204,
+				OSType:                  datamodel.Linux,
+				EnableKubeletConfigFile: true,
+				KubeletConfig: map[string]string{
+					"--max-pods":      "110",
+					"--cluster-dns":   "10.0.0.10",
+					"--feature-gates": "a=true",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.ConfigFileEnabled).To(BeTrue())
+			Expect(result.ConfigFileContent).To(ContainSubstring(`"maxPods": 110`))
+			Expect(result.CommandLineFlags).NotTo(ContainSubstring("--max-pods"))
+		})
+
+		It("should return the powershell flags for windows", func() {
+			agentBaker, err := NewAgentBaker()
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := agentBaker.GetDefaultKubeletConfiguration(&datamodel.GetDefaultKubeletConfigurationRequest{
+				KubernetesVersion: "1.29.2",
+				OSType:            datamodel.Windows,
+				KubeletConfig: map[string]string{
+					"--max-pods":           "30",
+					"--dynamic-config-dir": "c:\\k\\dynamic",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Flags).NotTo(HaveKey("--dynamic-config-dir"))
+			Expect(result.CommandLineFlags).To(Equal(`"--feature-gates=", "--max-pods=30"`))
+		})
+	})
 })
@@ -0,0 +1,73 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+)
+
+// validateBootstrapTarget checks that the node can bootstrap as the kind of machine it is.
+func validateBootstrapTarget(config *datamodel.NodeBootstrappingConfiguration) error {
+	switch target := config.BootstrapTarget; target {
+	case "", datamodel.BootstrapTargetStandaloneVM:
+	case datamodel.BootstrapTargetVMSSUniform, datamodel.BootstrapTargetVMSSFlex:
+		if config.AgentPoolProfile.IsAvailabilitySets() {
+			return fmt.Errorf("bootstrap target %s doesn't match the availability set of agent pool %s", target,
+				config.AgentPoolProfile.Name)
+		}
+	case datamodel.BootstrapTargetArcMachine:
+		if config.EnableIMDSRestriction {
+			return errors.New("IMDS restriction isn't supported on Arc machines, which have no IMDS")
+		}
+	default:
+		return fmt.Errorf("unknown bootstrap target %q", target)
+	}
+	return nil
+}
+
+// getVMType returns the VM type the cloud provider manages the node as.
+func getVMType(config *datamodel.NodeBootstrappingConfiguration) string {
+	switch config.BootstrapTarget {
+	case datamodel.BootstrapTargetVMSSUniform:
+		return datamodel.VMSSVMType
+	case datamodel.BootstrapTargetVMSSFlex:
+		return datamodel.VMSSFlexVMType
+	case datamodel.BootstrapTargetStandaloneVM, datamodel.BootstrapTargetArcMachine:
+		return datamodel.StandardVMType
+	}
+	return config.ContainerService.Properties.GetVMType()
+}
+
+// getPrimaryScaleSetName returns the scale set of the node, standalone VMs and Arc machines have none.
+func getPrimaryScaleSetName(config *datamodel.NodeBootstrappingConfiguration) string {
+	switch config.BootstrapTarget {
+	case datamodel.BootstrapTargetStandaloneVM, datamodel.BootstrapTargetArcMachine:
+		return ""
+	}
+	return config.PrimaryScaleSetName
+}
+
+// getPrimaryAvailabilitySetName returns the availability set of the node, only standalone VMs have one.
+func getPrimaryAvailabilitySetName(config *datamodel.NodeBootstrappingConfiguration) string {
+	switch config.BootstrapTarget {
+	case datamodel.BootstrapTargetVMSSUniform, datamodel.BootstrapTargetVMSSFlex, datamodel.BootstrapTargetArcMachine:
+		return ""
+	}
+	return config.ContainerService.Properties.GetPrimaryAvailabilitySetName()
+}
+
+// getCSEExtensionResource returns the name and resource type of the extension running the CSE command, VMSS nodes get
+// it from the model of their scale set.
+func getCSEExtensionResource(config *datamodel.NodeBootstrappingConfiguration) (string, string) {
+	switch config.BootstrapTarget {
+	case datamodel.BootstrapTargetStandaloneVM:
+		return "cse", "Microsoft.Compute/virtualMachines/extensions"
+	case datamodel.BootstrapTargetArcMachine:
+		return "cse", "Microsoft.HybridCompute/machines/extensions"
+	}
+	return "vmssCSE", "Microsoft.Compute/virtualMachineScaleSets/extensions"
+}
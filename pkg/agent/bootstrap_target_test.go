@@ -0,0 +1,116 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBootstrapTarget(t *testing.T) {
+	tests := []struct {
+		name                string
+		target              datamodel.BootstrapTarget
+		availabilityProfile string
+		imdsRestriction     bool
+		wantErr             string
+		wantVMType          string
+		wantScaleSet        string
+		wantAvailabilitySet string
+		wantInstanceMeta    string
+		wantExtension       string
+		wantResourceType    string
+	}{
+		{
+			name:                "derived from the cluster",
+			availabilityProfile: datamodel.VirtualMachineScaleSets,
+			wantVMType:          datamodel.VMSSVMType,
+			wantScaleSet:        "aks-nodepool1-vmss",
+			wantInstanceMeta:    "true",
+			wantExtension:       "vmssCSE",
+			wantResourceType:    "Microsoft.Compute/virtualMachineScaleSets/extensions",
+		},
+		{
+			name:                "vmss flex",
+			target:              datamodel.BootstrapTargetVMSSFlex,
+			availabilityProfile: datamodel.VirtualMachineScaleSets,
+			wantVMType:          datamodel.VMSSFlexVMType,
+			wantScaleSet:        "aks-nodepool1-vmss",
+			wantInstanceMeta:    "true",
+			wantExtension:       "vmssCSE",
+			wantResourceType:    "Microsoft.Compute/virtualMachineScaleSets/extensions",
+		},
+		{
+			name:                "standalone vm",
+			target:              datamodel.BootstrapTargetStandaloneVM,
+			availabilityProfile: datamodel.AvailabilitySet,
+			wantVMType:          datamodel.StandardVMType,
+			wantAvailabilitySet: "nodepool1-availabilitySet-12345678",
+			wantInstanceMeta:    "true",
+			wantExtension:       "cse",
+			wantResourceType:    "Microsoft.Compute/virtualMachines/extensions",
+		},
+		{
+			name:                "arc machine",
+			target:              datamodel.BootstrapTargetArcMachine,
+			availabilityProfile: datamodel.VirtualMachineScaleSets,
+			wantVMType:          datamodel.StandardVMType,
+			wantInstanceMeta:    "false",
+			wantExtension:       "cse",
+			wantResourceType:    "Microsoft.HybridCompute/machines/extensions",
+		},
+		{
+			name:                "vmss in an availability set",
+			target:              datamodel.BootstrapTargetVMSSUniform,
+			availabilityProfile: datamodel.AvailabilitySet,
+			wantErr:             "bootstrap target VMSSUniform doesn't match the availability set of agent pool nodepool1",
+		},
+		{
+			name:                "arc machine with IMDS restriction",
+			target:              datamodel.BootstrapTargetArcMachine,
+			availabilityProfile: datamodel.VirtualMachineScaleSets,
+			imdsRestriction:     true,
+			wantErr:             "IMDS restriction isn't supported on Arc machines, which have no IMDS",
+		},
+		{
+			name:    "unknown",
+			target:  "Bare",
+			wantErr: `unknown bootstrap target "Bare"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := &datamodel.AgentPoolProfile{Name: "nodepool1", AvailabilityProfile: tt.availabilityProfile}
+			config := &datamodel.NodeBootstrappingConfiguration{
+				ContainerService: &datamodel.ContainerService{Properties: &datamodel.Properties{
+					OrchestratorProfile: &datamodel.OrchestratorProfile{KubernetesConfig: &datamodel.KubernetesConfig{
+						UseInstanceMetadata: to.BoolPtr(true),
+					}},
+					AgentPoolProfiles: []*datamodel.AgentPoolProfile{profile},
+					ClusterID:         "12345678",
+				}},
+				AgentPoolProfile:      profile,
+				PrimaryScaleSetName:   "aks-nodepool1-vmss",
+				BootstrapTarget:       tt.target,
+				EnableIMDSRestriction: tt.imdsRestriction,
+			}
+			err := validateBootstrapTarget(config)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantVMType, getVMType(config))
+			assert.Equal(t, tt.wantScaleSet, getPrimaryScaleSetName(config))
+			assert.Equal(t, tt.wantAvailabilitySet, getPrimaryAvailabilitySetName(config))
+			assert.Equal(t, tt.wantInstanceMeta, useInstanceMetadata(config))
+			extensions := getNodeBootstrappingVMExtensions(config, "cse")
+			assert.Equal(t, tt.wantExtension, extensions[0].Name)
+			assert.Equal(t, tt.wantResourceType, extensions[0].ResourceType)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/Azure/agentbaker/parts"
+)
+
+// ErrTemplateBundleNotServed is returned when the pinned template bundle is neither the current one nor a retained previous one.
+var ErrTemplateBundleNotServed = errors.New("template bundle is not served")
+
+// TemplateBundle is a set of bootstrapping templates, identified by the hash of its content.
+type TemplateBundle struct {
+	Hash      string
+	Templates fs.FS
+	// cache holds the parsed templates, as the bundle is generated from many times.
+	cache *templateCache
+}
+
+// NewTemplateBundle hashes the files of templates. The hash only depends on file paths and contents.
+func NewTemplateBundle(templates fs.FS) (*TemplateBundle, error) {
+	h := sha256.New()
+	// fs.WalkDir visits files in lexical order, which makes the hash deterministic.
+	err := fs.WalkDir(templates, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := fs.ReadFile(templates, path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", path, len(content))
+		h.Write(content)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("hash template bundle: %w", err)
+	}
+	return &TemplateBundle{Hash: hex.EncodeToString(h.Sum(nil)), Templates: templates, cache: &templateCache{}}, nil
+}
+
+// Assets returns the paths of the assets of the bundle in lexical order, including the ones supplemented by overrides.
+func (b *TemplateBundle) Assets() ([]string, error) {
+	var assets []string
+	err := fs.WalkDir(b.Templates, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		assets = append(assets, path)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list template bundle assets: %w", err)
+	}
+	return assets, nil
+}
+
+// templateGenerator returns a generator of the bundle, the generators of a bundle share its parsed templates.
+func (b *TemplateBundle) templateGenerator() *TemplateGenerator {
+	if b.cache == nil {
+		return newTemplateGenerator(b.Templates)
+	}
+	return &TemplateGenerator{templates: b.Templates, cache: b.cache}
+}
+
+// LoadTemplateBundle loads a bundle from a directory with the layout of the parts directory.
+func LoadTemplateBundle(dir string) (*TemplateBundle, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("load template bundle: %w", err)
+	}
+	return NewTemplateBundle(os.DirFS(dir))
+}
+
+//nolint:gochecknoglobals
+var currentTemplateBundle = sync.OnceValues(func() (*TemplateBundle, error) {
+	return NewTemplateBundle(parts.Templates)
+})
+
+// CurrentTemplateBundle returns the bundle of the templates embedded in this agentbaker version.
+func CurrentTemplateBundle() (*TemplateBundle, error) {
+	return currentTemplateBundle()
+}
+
+// TemplateBundles is the current template bundle and the previous bundles which are still served.
+type TemplateBundles struct {
+	current  *TemplateBundle
+	previous []*TemplateBundle
+}
+
+// NewTemplateBundles returns the bundles served with the embedded bundle as the current one.
+// At most maxPrevious previous bundles are retained, so that the supported pinning window stays explicit.
+func NewTemplateBundles(previous []*TemplateBundle, maxPrevious int) (*TemplateBundles, error) {
+	if len(previous) > maxPrevious {
+		return nil, fmt.Errorf("%d previous template bundles given, at most %d are served", len(previous), maxPrevious)
+	}
+	current, err := CurrentTemplateBundle()
+	if err != nil {
+		return nil, err
+	}
+	return &TemplateBundles{current: current, previous: previous}, nil
+}
+
+// Get returns the bundle with the given hash, or the current bundle when hash is empty.
+func (b *TemplateBundles) Get(hash string) (*TemplateBundle, error) {
+	if hash == "" || hash == b.current.Hash {
+		return b.current, nil
+	}
+	hashes := []string{b.current.Hash}
+	for _, bundle := range b.previous {
+		if bundle.Hash == hash {
+			return bundle, nil
+		}
+		hashes = append(hashes, bundle.Hash)
+	}
+	return nil, fmt.Errorf("%w: %s, served bundles: %s", ErrTemplateBundleNotServed, hash, strings.Join(hashes, ", "))
+}
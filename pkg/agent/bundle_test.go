@@ -0,0 +1,94 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTemplateBundle(t *testing.T) {
+	bundle := func(files map[string]string) string {
+		fsys := fstest.MapFS{}
+		for name, content := range files {
+			fsys[name] = &fstest.MapFile{Data: []byte(content)}
+		}
+		b, err := NewTemplateBundle(fsys)
+		require.NoError(t, err)
+		return b.Hash
+	}
+
+	base := bundle(map[string]string{"linux/cse_main.sh": "a", "windows/cse.ps1": "b"})
+	assert.Equal(t, base, bundle(map[string]string{"windows/cse.ps1": "b", "linux/cse_main.sh": "a"}))
+	assert.NotEqual(t, base, bundle(map[string]string{"linux/cse_main.sh": "a", "windows/cse.ps1": "c"}))
+	assert.NotEqual(t, base, bundle(map[string]string{"linux/cse_main.sh": "a", "windows/cse2.ps1": "b"}))
+	// file boundaries are part of the hash.
+	assert.NotEqual(t, bundle(map[string]string{"a": "bc"}), bundle(map[string]string{"a": "b", "c": ""}))
+}
+
+func TestTemplateBundle_Assets(t *testing.T) {
+	bundle := testTemplateBundle(t)
+	assets, err := bundle.Assets()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"linux/cloud-init/artifacts/cse_config.sh", "linux/cloud-init/artifacts/cse_install.sh"}, assets)
+
+	overridden, err := bundle.WithOverrides(TemplateOverride{Name: "linux/cloud-init/artifacts/cse_extra.sh", Content: []byte("extra"), Supplemental: true})
+	require.NoError(t, err)
+	assets, err = overridden.Assets()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"linux/cloud-init/artifacts/cse_config.sh",
+		"linux/cloud-init/artifacts/cse_extra.sh",
+		"linux/cloud-init/artifacts/cse_install.sh",
+	}, assets)
+
+	current, err := CurrentTemplateBundle()
+	require.NoError(t, err)
+	assets, err = current.Assets()
+	require.NoError(t, err)
+	assert.Contains(t, assets, "linux/cloud-init/artifacts/components.json")
+}
+
+func TestTemplateBundles(t *testing.T) {
+	previous, err := NewTemplateBundle(fstest.MapFS{"linux/cse_main.sh": &fstest.MapFile{Data: []byte("previous")}})
+	require.NoError(t, err)
+	current, err := CurrentTemplateBundle()
+	require.NoError(t, err)
+
+	_, err = NewTemplateBundles([]*TemplateBundle{previous, previous}, 1)
+	assert.EqualError(t, err, "2 previous template bundles given, at most 1 are served")
+
+	bundles, err := NewTemplateBundles([]*TemplateBundle{previous}, 1)
+	require.NoError(t, err)
+
+	got, err := bundles.Get("")
+	require.NoError(t, err)
+	assert.Equal(t, current.Hash, got.Hash)
+
+	got, err = bundles.Get(previous.Hash)
+	require.NoError(t, err)
+	assert.Same(t, previous, got)
+
+	_, err = bundles.Get("unknown")
+	assert.True(t, errors.Is(err, ErrTemplateBundleNotServed))
+	assert.ErrorContains(t, err, previous.Hash)
+}
+
+func TestAgentBaker_TemplateBundle(t *testing.T) {
+	agentBaker, err := NewAgentBaker()
+	require.NoError(t, err)
+	current, err := CurrentTemplateBundle()
+	require.NoError(t, err)
+
+	got, err := agentBaker.templateBundle(current.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, current.Hash, got.Hash)
+
+	_, err = agentBaker.templateBundle("previous")
+	assert.ErrorIs(t, err, ErrTemplateBundleNotServed)
+}
@@ -0,0 +1,299 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+// Package catalog answers which versions of a component agentbaker installs, and where they are downloaded from,
+// for a distro, architecture and Kubernetes version. It is derived from components.json, the manifest the VHD
+// build, CSE and the Go code read their component versions from.
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Azure/agentbaker/parts"
+)
+
+const (
+	ArchAMD64 = "amd64"
+	ArchARM64 = "arm64"
+
+	// ReleaseCurrent is the release used for the distro releases without their own entry.
+	ReleaseCurrent = "current"
+	// OSDefault is the OS used for the distros without their own entry.
+	OSDefault = "default"
+
+	componentsFile = "linux/cloud-init/artifacts/components.json"
+)
+
+// kubernetesVersionedComponents are the components released with Kubernetes, their versions are filtered by the
+// Kubernetes minor version of a query.
+//
+//nolint:gochecknoglobals
+var kubernetesVersionedComponents = map[string]bool{
+	"kubernetes-binaries":           true,
+	"kube-proxy":                    true,
+	"azure-acr-credential-provider": true,
+	"kubectl":                       true,
+	"kubelet":                       true,
+}
+
+// Artifact is a version of a component.
+type Artifact struct {
+	Component string `json:"component"`
+	Version   string `json:"version"`
+	// URL is the image reference of container images and the download URL of packages, it is empty for packages
+	// installed from the distro package repositories.
+	URL      string `json:"url,omitempty"`
+	Checksum string `json:"checksum,omitempty"`
+	// OS and Release are empty for container images, which don't depend on the distro.
+	OS      string `json:"os,omitempty"`
+	Release string `json:"release,omitempty"`
+	Arch    string `json:"arch"`
+}
+
+// Query selects artifacts, empty fields match everything except Arch, which defaults to amd64.
+type Query struct {
+	Component string
+	// OS is ubuntu, mariner or azurelinux, and Release the OS release such as r2204. Packages fall back to the
+	// current release of the OS and then to the default OS.
+	OS      string
+	Release string
+	Arch    string
+	// KubernetesVersion keeps the versions of the Kubernetes components with the same minor version.
+	KubernetesVersion string
+}
+
+// Catalog is the set of artifacts of all components.
+type Catalog struct {
+	artifacts []Artifact
+}
+
+type componentVersion struct {
+	RenovateTag           string `json:"renovateTag"`
+	LatestVersion         string `json:"latestVersion"`
+	PreviousLatestVersion string `json:"previousLatestVersion,omitempty"`
+	Checksum              string `json:"checksum,omitempty"`
+}
+
+type containerImage struct {
+	DownloadURL         string             `json:"downloadURL"`
+	AMD64OnlyVersions   []string           `json:"amd64OnlyVersions"`
+	MultiArchVersionsV2 []componentVersion `json:"multiArchVersionsV2"`
+}
+
+type gpuContainerImage struct {
+	DownloadURL string           `json:"downloadURL"`
+	GPUVersion  componentVersion `json:"gpuVersion"`
+}
+
+type releaseDownloadURI struct {
+	VersionsV2  []componentVersion `json:"versionsV2"`
+	DownloadURL string             `json:"downloadURL"`
+}
+
+type packageComponent struct {
+	Name         string                                   `json:"name"`
+	DownloadURIs map[string]map[string]releaseDownloadURI `json:"downloadURIs"`
+}
+
+type components struct {
+	ContainerImages    []containerImage    `json:"ContainerImages"`
+	Packages           []packageComponent  `json:"Packages"`
+	GPUContainerImages []gpuContainerImage `json:"GPUContainerImages"`
+}
+
+//nolint:gochecknoglobals
+var (
+	defaultCatalog     *Catalog
+	defaultCatalogErr  error
+	defaultCatalogOnce sync.Once
+)
+
+// Default returns the catalog of the components.json embedded in agentbaker.
+func Default() (*Catalog, error) {
+	defaultCatalogOnce.Do(func() {
+		data, err := parts.Templates.ReadFile(componentsFile)
+		if err != nil {
+			defaultCatalogErr = fmt.Errorf("failed to read components.json: %w", err)
+			return
+		}
+		defaultCatalog, defaultCatalogErr = Load(data)
+	})
+	return defaultCatalog, defaultCatalogErr
+}
+
+// Load builds the catalog of a components.json.
+func Load(data []byte) (*Catalog, error) {
+	var c components
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal components.json: %w", err)
+	}
+	catalog := &Catalog{}
+	for _, image := range c.ContainerImages {
+		name := imageName(image.DownloadURL)
+		for _, version := range image.AMD64OnlyVersions {
+			catalog.addImage(name, image.DownloadURL, componentVersion{LatestVersion: version}, ArchAMD64)
+		}
+		for _, version := range image.MultiArchVersionsV2 {
+			catalog.addImage(name, image.DownloadURL, version, ArchAMD64, ArchARM64)
+		}
+	}
+	for _, image := range c.GPUContainerImages {
+		catalog.addImage(imageName(image.DownloadURL), image.DownloadURL, image.GPUVersion, ArchAMD64)
+	}
+	for _, pkg := range c.Packages {
+		for os, releases := range pkg.DownloadURIs {
+			for release, uri := range releases {
+				for _, version := range uri.VersionsV2 {
+					for _, v := range version.versions() {
+						for _, arch := range []string{ArchAMD64, ArchARM64} {
+							catalog.artifacts = append(catalog.artifacts, Artifact{
+								Component: pkg.Name,
+								Version:   v,
+								URL:       packageURL(uri.DownloadURL, v, arch),
+								Checksum:  version.Checksum,
+								OS:        os,
+								Release:   release,
+								Arch:      arch,
+							})
+						}
+					}
+				}
+			}
+		}
+	}
+	catalog.sort()
+	return catalog, nil
+}
+
+func (c *Catalog) addImage(name, downloadURL string, version componentVersion, archs ...string) {
+	for _, v := range version.versions() {
+		for _, arch := range archs {
+			c.artifacts = append(c.artifacts, Artifact{
+				Component: name,
+				Version:   v,
+				URL:       strings.Replace(downloadURL, "*", v, 1),
+				Checksum:  version.Checksum,
+				Arch:      arch,
+			})
+		}
+	}
+}
+
+func (c *Catalog) sort() {
+	sort.SliceStable(c.artifacts, func(i, j int) bool {
+		a, b := c.artifacts[i], c.artifacts[j]
+		for _, pair := range [][2]string{{a.Component, b.Component}, {a.OS, b.OS}, {a.Release, b.Release}, {a.Arch, b.Arch}, {a.Version, b.Version}} {
+			if pair[0] != pair[1] {
+				return pair[0] < pair[1]
+			}
+		}
+		return false
+	})
+}
+
+// versions returns the latest and the previous latest version, which VHDs still carry.
+func (v componentVersion) versions() []string {
+	var versions []string
+	if v.LatestVersion != "" {
+		versions = append(versions, v.LatestVersion)
+	}
+	if v.PreviousLatestVersion != "" && v.PreviousLatestVersion != v.LatestVersion {
+		versions = append(versions, v.PreviousLatestVersion)
+	}
+	return versions
+}
+
+// imageName returns the repository name of an image reference, such as kube-proxy for
+// mcr.microsoft.com/oss/kubernetes/kube-proxy:*.
+func imageName(downloadURL string) string {
+	repository, _, _ := strings.Cut(downloadURL, ":")
+	return path.Base(repository)
+}
+
+func packageURL(downloadURL, version, arch string) string {
+	if downloadURL == "" {
+		return ""
+	}
+	return strings.NewReplacer("${version}", version, "${CPU_ARCH}", arch).Replace(downloadURL)
+}
+
+// Components returns the names of the components of the catalog.
+func (c *Catalog) Components() []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, artifact := range c.artifacts {
+		if !seen[artifact.Component] {
+			seen[artifact.Component] = true
+			names = append(names, artifact.Component)
+		}
+	}
+	return names
+}
+
+// Lookup returns the artifacts matching query.
+func (c *Catalog) Lookup(query Query) []Artifact {
+	arch := query.Arch
+	if arch == "" {
+		arch = ArchAMD64
+	}
+	var matches []Artifact
+	for _, artifact := range c.artifacts {
+		if (query.Component != "" && artifact.Component != query.Component) || artifact.Arch != arch {
+			continue
+		}
+		if query.KubernetesVersion != "" && kubernetesVersionedComponents[artifact.Component] &&
+			minorVersion(artifact.Version) != minorVersion(query.KubernetesVersion) {
+			continue
+		}
+		matches = append(matches, artifact)
+	}
+	if query.OS == "" {
+		return matches
+	}
+	return selectDistro(matches, query.OS, query.Release)
+}
+
+// selectDistro keeps the container images, and for every package the artifacts of the most specific distro entry:
+// the release of the OS, the current release of the OS, or the current release of the default OS.
+func selectDistro(artifacts []Artifact, os, release string) []Artifact {
+	candidates := [][2]string{{os, release}, {os, ReleaseCurrent}, {OSDefault, ReleaseCurrent}}
+	best := map[string]int{}
+	for _, artifact := range artifacts {
+		if artifact.OS == "" {
+			continue
+		}
+		for rank, candidate := range candidates {
+			if artifact.OS == candidate[0] && artifact.Release == candidate[1] {
+				if current, ok := best[artifact.Component]; !ok || rank < current {
+					best[artifact.Component] = rank
+				}
+			}
+		}
+	}
+	var selected []Artifact
+	for _, artifact := range artifacts {
+		if artifact.OS == "" {
+			selected = append(selected, artifact)
+			continue
+		}
+		rank, ok := best[artifact.Component]
+		if ok && artifact.OS == candidates[rank][0] && artifact.Release == candidates[rank][1] {
+			selected = append(selected, artifact)
+		}
+	}
+	return selected
+}
+
+// minorVersion returns the major.minor of a version such as v1.30.2 or 1.30.2-hotfix.20240101.
+func minorVersion(version string) string {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
@@ -0,0 +1,137 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+)
+
+const testComponents = `{
+  "ContainerImages": [
+    {
+      "downloadURL": "mcr.microsoft.com/oss/kubernetes/kube-proxy:*",
+      "multiArchVersionsV2": [
+        {"renovateTag": "<DO_NOT_UPDATE>", "latestVersion": "v1.30.5-hotfix.20241101", "previousLatestVersion": "v1.30.4"},
+        {"renovateTag": "<DO_NOT_UPDATE>", "latestVersion": "v1.31.2"}
+      ]
+    },
+    {
+      "downloadURL": "mcr.microsoft.com/oss/kubernetes/pause:*",
+      "amd64OnlyVersions": ["3.6"]
+    }
+  ],
+  "Packages": [
+    {
+      "name": "runc",
+      "downloadURIs": {
+        "ubuntu": {
+          "r2004": {"versionsV2": [{"latestVersion": "1.1.12"}]},
+          "current": {"versionsV2": [{"latestVersion": "1.1.14"}]}
+        },
+        "azurelinux": {
+          "current": {"versionsV2": [{"latestVersion": "1.1.13"}]}
+        }
+      }
+    },
+    {
+      "name": "kubernetes-binaries",
+      "downloadURIs": {
+        "default": {
+          "current": {
+            "versionsV2": [
+              {"latestVersion": "1.30.5", "checksum": "sha256:abc"},
+              {"latestVersion": "1.31.2"}
+            ],
+            "downloadURL": "https://acs-mirror.azureedge.net/kubernetes/v${version}/binaries/kubernetes-node-linux-${CPU_ARCH}.tar.gz"
+          }
+        }
+      }
+    }
+  ],
+  "GPUContainerImages": [
+    {"downloadURL": "mcr.microsoft.com/aks/aks-gpu-cuda:*", "gpuVersion": {"latestVersion": "550.90.12-20241021235610"}}
+  ]
+}`
+
+func TestLookup(t *testing.T) {
+	catalog, err := Load([]byte(testComponents))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		query Query
+		want  []Artifact
+	}{
+		{
+			name:  "image versions for arm64",
+			query: Query{Component: "kube-proxy", Arch: ArchARM64},
+			want: []Artifact{
+				{Component: "kube-proxy", Version: "v1.30.4", URL: "mcr.microsoft.com/oss/kubernetes/kube-proxy:v1.30.4", Arch: ArchARM64},
+				{Component: "kube-proxy", Version: "v1.30.5-hotfix.20241101", URL: "mcr.microsoft.com/oss/kubernetes/kube-proxy:v1.30.5-hotfix.20241101", Arch: ArchARM64},
+				{Component: "kube-proxy", Version: "v1.31.2", URL: "mcr.microsoft.com/oss/kubernetes/kube-proxy:v1.31.2", Arch: ArchARM64},
+			},
+		},
+		{
+			name:  "amd64 only images aren't available on arm64",
+			query: Query{Component: "pause", Arch: ArchARM64},
+		},
+		{
+			name:  "kubernetes components are filtered by minor version",
+			query: Query{Component: "kube-proxy", KubernetesVersion: "1.31.1"},
+			want: []Artifact{
+				{Component: "kube-proxy", Version: "v1.31.2", URL: "mcr.microsoft.com/oss/kubernetes/kube-proxy:v1.31.2", Arch: ArchAMD64},
+			},
+		},
+		{
+			name:  "package of the release",
+			query: Query{Component: "runc", OS: "ubuntu", Release: "r2004"},
+			want:  []Artifact{{Component: "runc", Version: "1.1.12", OS: "ubuntu", Release: "r2004", Arch: ArchAMD64}},
+		},
+		{
+			name:  "package falls back to the current release",
+			query: Query{Component: "runc", OS: "ubuntu", Release: "r2204"},
+			want:  []Artifact{{Component: "runc", Version: "1.1.14", OS: "ubuntu", Release: ReleaseCurrent, Arch: ArchAMD64}},
+		},
+		{
+			name:  "package falls back to the default OS",
+			query: Query{Component: "kubernetes-binaries", OS: "mariner", KubernetesVersion: "v1.30.0"},
+			want: []Artifact{{
+				Component: "kubernetes-binaries",
+				Version:   "1.30.5",
+				URL:       "https://acs-mirror.azureedge.net/kubernetes/v1.30.5/binaries/kubernetes-node-linux-amd64.tar.gz",
+				Checksum:  "sha256:abc",
+				OS:        OSDefault,
+				Release:   ReleaseCurrent,
+				Arch:      ArchAMD64,
+			}},
+		},
+		{
+			name:  "unknown component",
+			query: Query{Component: "docker"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := catalog.Lookup(tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lookup() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComponents(t *testing.T) {
+	catalog, err := Load([]byte(testComponents))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"aks-gpu-cuda", "kube-proxy", "kubernetes-binaries", "pause", "runc"}
+	if got := catalog.Components(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Components() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	if _, err := Load([]byte("{")); err == nil {
+		t.Error("Load() succeeded with invalid JSON")
+	}
+}
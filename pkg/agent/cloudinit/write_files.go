@@ -0,0 +1,190 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+// Package cloudinit models the write_files of the cloud-config of the node custom data, which the code paths producing
+// custom data build the same way: each file has its path, owner, mode and encoding, and a cloud-config never writes
+// the same path twice unless it appends to it.
+package cloudinit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Encoding is the encoding of the content of a file in the cloud-config, cloud-init decodes it before writing the
+// file.
+type Encoding string
+
+const (
+	EncodingPlain      Encoding = ""
+	EncodingBase64     Encoding = "b64"
+	EncodingGzipBase64 Encoding = "gz+b64"
+)
+
+// WriteFile is a file cloud-init writes on the node.
+type WriteFile struct {
+	// Path is the absolute path of the file.
+	Path string
+	// Owner is the user:group owning the file, root:root when empty.
+	Owner string
+	// Mode is the permissions of the file, 0644 when zero.
+	Mode fs.FileMode
+	// Encoding is how Content is encoded in the cloud-config, Content itself is never encoded.
+	Encoding Encoding
+	Content  []byte
+	// Append appends Content to the file instead of overwriting it.
+	Append bool
+}
+
+// FileSize is the size the content of a file adds to the cloud-config.
+type FileSize struct {
+	Path string
+	Size int
+}
+
+type writeFileEntry struct {
+	Path        string `yaml:"path"`
+	Permissions string `yaml:"permissions,omitempty"`
+	Owner       string `yaml:"owner,omitempty"`
+	Encoding    string `yaml:"encoding,omitempty"`
+	Content     string `yaml:"content"`
+	Append      bool   `yaml:"append,omitempty"`
+}
+
+// Validate checks the paths, the encodings and that no path is written twice. A path can be written again when the
+// later files append to it.
+func Validate(files []WriteFile) error {
+	for _, file := range files {
+		if err := file.Validate(); err != nil {
+			return err
+		}
+	}
+	if duplicates := DuplicatePaths(files); len(duplicates) > 0 {
+		return fmt.Errorf("duplicate write_files path %s", duplicates[0])
+	}
+	return nil
+}
+
+// Validate checks the path, the encoding and the mode of the file.
+func (f WriteFile) Validate() error {
+	if !path.IsAbs(f.Path) || path.Clean(f.Path) != f.Path {
+		return fmt.Errorf("write_files path %q must be a clean absolute path", f.Path)
+	}
+	switch f.Encoding {
+	case EncodingPlain, EncodingBase64, EncodingGzipBase64:
+	default:
+		return fmt.Errorf("unknown encoding %q of write_files path %s", f.Encoding, f.Path)
+	}
+	if f.Mode&^fs.ModePerm != 0 {
+		return fmt.Errorf("mode %v of write_files path %s has more than permission bits", f.Mode, f.Path)
+	}
+	return nil
+}
+
+// DuplicatePaths returns the paths written more than once without appending, in the order of their second write.
+// cloud-init writes the files in order, so the last write wins.
+func DuplicatePaths(files []WriteFile) []string {
+	var duplicates []string
+	written := map[string]bool{}
+	for _, file := range files {
+		if written[file.Path] && !file.Append {
+			duplicates = append(duplicates, file.Path)
+		}
+		written[file.Path] = true
+	}
+	return duplicates
+}
+
+// Render validates the files and returns the cloud-config writing them.
+func Render(files []WriteFile) (string, error) {
+	if err := Validate(files); err != nil {
+		return "", err
+	}
+	entries := make([]writeFileEntry, 0, len(files))
+	for _, file := range files {
+		entry, err := file.entry()
+		if err != nil {
+			return "", err
+		}
+		entries = append(entries, entry)
+	}
+	var b bytes.Buffer
+	b.WriteString("#cloud-config\n")
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(struct {
+		WriteFiles []writeFileEntry `yaml:"write_files"`
+	}{entries}); err != nil {
+		return "", fmt.Errorf("encode cloud-config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("encode cloud-config: %w", err)
+	}
+	return b.String(), nil
+}
+
+// Sizes returns the size the encoded content of each file adds to the cloud-config, largest first.
+func Sizes(files []WriteFile) ([]FileSize, error) {
+	sizes := make([]FileSize, 0, len(files))
+	for _, file := range files {
+		content, err := file.EncodedContent()
+		if err != nil {
+			return nil, err
+		}
+		sizes = append(sizes, FileSize{Path: file.Path, Size: len(content)})
+	}
+	sort.SliceStable(sizes, func(i, j int) bool { return sizes[i].Size > sizes[j].Size })
+	return sizes, nil
+}
+
+// EncodedContent returns the content of the file as written in the cloud-config.
+func (f WriteFile) EncodedContent() (string, error) {
+	switch f.Encoding {
+	case EncodingPlain:
+		return string(f.Content), nil
+	case EncodingBase64:
+		return base64.StdEncoding.EncodeToString(f.Content), nil
+	case EncodingGzipBase64:
+		var b bytes.Buffer
+		w := gzip.NewWriter(&b)
+		if _, err := w.Write(f.Content); err != nil {
+			return "", fmt.Errorf("compress %s: %w", f.Path, err)
+		}
+		if err := w.Close(); err != nil {
+			return "", fmt.Errorf("compress %s: %w", f.Path, err)
+		}
+		return base64.StdEncoding.EncodeToString(b.Bytes()), nil
+	default:
+		return "", fmt.Errorf("unknown encoding %q of write_files path %s", f.Encoding, f.Path)
+	}
+}
+
+// Permissions returns the mode of the file as cloud-init expects it, empty when it is the default.
+func (f WriteFile) Permissions() string {
+	if f.Mode == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%04o", f.Mode.Perm())
+}
+
+func (f WriteFile) entry() (writeFileEntry, error) {
+	content, err := f.EncodedContent()
+	if err != nil {
+		return writeFileEntry{}, err
+	}
+	return writeFileEntry{
+		Path:        f.Path,
+		Permissions: f.Permissions(),
+		Owner:       f.Owner,
+		Encoding:    string(f.Encoding),
+		Content:     content,
+		Append:      f.Append,
+	}, nil
+}
@@ -0,0 +1,85 @@
+package cloudinit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRender(t *testing.T) {
+	cloudConfig, err := Render([]WriteFile{
+		{Path: "/etc/kubernetes/plain.conf", Owner: "root:kube", Mode: 0600, Content: []byte("line 1\nline 2\n")},
+		{Path: "/opt/azure/containers/config.json", Owner: "root", Mode: 0755, Encoding: EncodingBase64, Content: []byte(`{"a":1}`)},
+		{Path: "/opt/azure/containers/provision.sh", Mode: 0744, Encoding: EncodingGzipBase64, Content: []byte("#!/bin/bash\n")},
+		{Path: "/etc/kubernetes/plain.conf", Append: true, Content: []byte("line 3\n")},
+	})
+	require.NoError(t, err)
+	want := `#cloud-config
+write_files:
+  - path: /etc/kubernetes/plain.conf
+    permissions: "0600"
+    owner: root:kube
+    content: |
+      line 1
+      line 2
+  - path: /opt/azure/containers/config.json
+    permissions: "0755"
+    owner: root
+    encoding: b64
+    content: eyJhIjoxfQ==
+`
+	// the gzipped content isn't stable across Go versions, it is decoded below.
+	require.Greater(t, len(cloudConfig), len(want))
+	assert.Equal(t, want, cloudConfig[:len(want)])
+
+	var parsed struct {
+		WriteFiles []writeFileEntry `yaml:"write_files"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(cloudConfig), &parsed))
+	require.Len(t, parsed.WriteFiles, 4)
+	provision := parsed.WriteFiles[2]
+	assert.Equal(t, "0744", provision.Permissions)
+	assert.Equal(t, "gz+b64", provision.Encoding)
+	compressed, err := base64.StdEncoding.DecodeString(provision.Content)
+	require.NoError(t, err)
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/bash\n", string(content))
+	assert.True(t, parsed.WriteFiles[3].Append)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(nil))
+	assert.EqualError(t, Validate([]WriteFile{{Path: "/etc/a"}, {Path: "/etc/b"}, {Path: "/etc/a"}}),
+		"duplicate write_files path /etc/a")
+	assert.NoError(t, Validate([]WriteFile{{Path: "/etc/a"}, {Path: "/etc/a", Append: true}}))
+	assert.EqualError(t, Validate([]WriteFile{{Path: "etc/a"}}), `write_files path "etc/a" must be a clean absolute path`)
+	assert.EqualError(t, Validate([]WriteFile{{Path: "/etc/../a"}}), `write_files path "/etc/../a" must be a clean absolute path`)
+	assert.EqualError(t, Validate([]WriteFile{{Path: "/etc/a", Encoding: "base32"}}), `unknown encoding "base32" of write_files path /etc/a`)
+	assert.EqualError(t, Validate([]WriteFile{{Path: "/etc/a", Mode: fs.ModeDir | 0755}}),
+		"mode drwxr-xr-x of write_files path /etc/a has more than permission bits")
+
+	assert.Equal(t, []string{"/etc/a"}, DuplicatePaths([]WriteFile{{Path: "/etc/a"}, {Path: "/etc/a", Append: true}, {Path: "/etc/a"}}))
+	assert.Empty(t, DuplicatePaths([]WriteFile{{Path: "/etc/a"}, {Path: "/etc/b"}}))
+
+	_, err := Render([]WriteFile{{Path: "/etc/a"}, {Path: "/etc/a"}})
+	assert.EqualError(t, err, "duplicate write_files path /etc/a")
+}
+
+func TestSizes(t *testing.T) {
+	sizes, err := Sizes([]WriteFile{
+		{Path: "/etc/a", Content: []byte("abc")},
+		{Path: "/etc/b", Encoding: EncodingBase64, Content: []byte("abcdef")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []FileSize{{Path: "/etc/b", Size: 8}, {Path: "/etc/a", Size: 3}}, sizes)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+)
+
+// HashNodeBootstrappingConfiguration returns a digest of the configuration and of the template bundle it is generated
+// with, the pinned bundle or else the current one. GetNodeBootstrapping generates the same custom data and CSE for
+// configurations with the same digest, so callers can cache them keyed by it instead of generating them for every
+// node. The OS image configs aren't covered, they depend on the node image version toggles.
+func HashNodeBootstrappingConfiguration(config *datamodel.NodeBootstrappingConfiguration) (string, error) {
+	bundleHash := config.TemplateBundleHash
+	if bundleHash == "" {
+		bundle, err := CurrentTemplateBundle()
+		if err != nil {
+			return "", err
+		}
+		bundleHash = bundle.Hash
+	}
+	// the bundle is hashed once, whether it's pinned or not.
+	unpinned := *config
+	unpinned.TemplateBundleHash = ""
+	// json.Marshal writes struct fields in declaration order and map keys sorted, which makes the digest independent
+	// of the order the maps were filled in.
+	data, err := json.Marshal(&unpinned)
+	if err != nil {
+		return "", fmt.Errorf("hash node bootstrapping configuration: %w", err)
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00", bundleHash)
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
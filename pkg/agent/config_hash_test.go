@@ -0,0 +1,61 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"testing"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashNodeBootstrappingConfiguration(t *testing.T) {
+	config := func(kubeletConfig map[string]string) *datamodel.NodeBootstrappingConfiguration {
+		agentPool := &datamodel.AgentPoolProfile{Name: "nodepool1", Distro: datamodel.AKSUbuntuContainerd2204}
+		return &datamodel.NodeBootstrappingConfiguration{
+			ContainerService: &datamodel.ContainerService{Location: "eastus", Properties: &datamodel.Properties{
+				AgentPoolProfiles: []*datamodel.AgentPoolProfile{agentPool},
+			}},
+			AgentPoolProfile: agentPool,
+			KubeletConfig:    kubeletConfig,
+		}
+	}
+	hash := func(config *datamodel.NodeBootstrappingConfiguration) string {
+		h, err := HashNodeBootstrappingConfiguration(config)
+		require.NoError(t, err)
+		return h
+	}
+
+	base := hash(config(map[string]string{"--max-pods": "30", "--cluster-dns": "10.0.0.10"}))
+	// the order maps are filled in doesn't change the digest.
+	ordered := map[string]string{}
+	ordered["--cluster-dns"] = "10.0.0.10"
+	ordered["--max-pods"] = "30"
+	assert.Equal(t, base, hash(config(ordered)))
+	assert.NotEqual(t, base, hash(config(map[string]string{"--max-pods": "110", "--cluster-dns": "10.0.0.10"})))
+
+	current, err := CurrentTemplateBundle()
+	require.NoError(t, err)
+	pinned := config(map[string]string{"--max-pods": "30", "--cluster-dns": "10.0.0.10"})
+	pinned.TemplateBundleHash = current.Hash
+	assert.Equal(t, base, hash(pinned))
+	pinned.TemplateBundleHash = "previous"
+	assert.NotEqual(t, base, hash(pinned))
+}
+
+func TestGetBase64EncodedGzippedCustomScriptFromStr_Deterministic(t *testing.T) {
+	encoded := getBase64EncodedGzippedCustomScriptFromStr("#cloud-config\n")
+	assert.Equal(t, encoded, getBase64EncodedGzippedCustomScriptFromStr("#cloud-config\n"))
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.True(t, r.ModTime.IsZero())
+	assert.Empty(t, r.Name)
+}
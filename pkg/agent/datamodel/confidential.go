@@ -0,0 +1,67 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package datamodel
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ConfidentialComputingType is the confidential computing technology of a VM size.
+type ConfidentialComputingType string
+
+const (
+	ConfidentialComputingNone ConfidentialComputingType = ""
+	// ConfidentialComputingSGX sizes run Intel SGX enclaves, the rest of the VM memory isn't encrypted.
+	ConfidentialComputingSGX ConfidentialComputingType = "sgx"
+	// ConfidentialComputingSEVSNP sizes are confidential VMs whose whole memory is encrypted by AMD SEV-SNP.
+	ConfidentialComputingSEVSNP ConfidentialComputingType = "sev-snp"
+	// ConfidentialComputingTDX sizes are confidential VMs whose whole memory is encrypted by Intel TDX.
+	ConfidentialComputingTDX ConfidentialComputingType = "tdx"
+)
+
+// IsConfidentialVM returns whether the whole VM runs in a trusted execution environment, as opposed to SGX enclaves.
+func (t ConfidentialComputingType) IsConfidentialVM() bool {
+	return t == ConfidentialComputingSEVSNP || t == ConfidentialComputingTDX
+}
+
+// confidentialSizeRegex matches the DC and EC sizes, such as Standard_DC4ds_v3 or Standard_EC8as_cc_v5, capturing the
+// family, the feature letters and the version.
+//
+//nolint:gochecknoglobals
+var confidentialSizeRegex = regexp.MustCompile(`^standard_(dc|ec)\d+([a-z]*)(?:_cc)?_v(\d+)$`)
+
+// GetConfidentialComputingType returns the confidential computing technology of a VM size: SGX for DCsv2 and DCsv3/
+// DCdsv3, AMD SEV-SNP for DCasv5/DCadsv5 and ECasv5/ECadsv5 and Intel TDX for DCesv5 and ECesv5. The DCv1 sizes are
+// SGX too, see IsSgxEnabledSKU.
+func GetConfidentialComputingType(vmSize string) ConfidentialComputingType {
+	size := strings.ToLower(vmSize)
+	if size == "standard_dc2s" || size == "standard_dc4s" {
+		return ConfidentialComputingSGX
+	}
+	match := confidentialSizeRegex.FindStringSubmatch(size)
+	if match == nil {
+		return ConfidentialComputingNone
+	}
+	family, features := match[1], match[2]
+	version, err := strconv.Atoi(match[3])
+	if err != nil {
+		return ConfidentialComputingNone
+	}
+	switch {
+	case family == "dc" && (version == 2 || version == 3):
+		return ConfidentialComputingSGX
+	case version >= 5 && strings.Contains(features, "a"):
+		return ConfidentialComputingSEVSNP
+	case version >= 5 && strings.Contains(features, "e"):
+		return ConfidentialComputingTDX
+	}
+	return ConfidentialComputingNone
+}
+
+// IsConfidentialComputingSKU returns whether the VM size supports SGX enclaves or is a confidential VM.
+func IsConfidentialComputingSKU(vmSize string) bool {
+	return GetConfidentialComputingType(vmSize) != ConfidentialComputingNone
+}
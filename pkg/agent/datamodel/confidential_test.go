@@ -0,0 +1,41 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package datamodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetConfidentialComputingType(t *testing.T) {
+	tests := []struct {
+		vmSize   string
+		expected ConfidentialComputingType
+	}{
+		{vmSize: "Standard_DC2s", expected: ConfidentialComputingSGX},
+		{vmSize: "Standard_DC8_v2", expected: ConfidentialComputingSGX},
+		{vmSize: "Standard_DC4s_v3", expected: ConfidentialComputingSGX},
+		{vmSize: "Standard_DC48ds_v3", expected: ConfidentialComputingSGX},
+		{vmSize: "Standard_DC4as_v5", expected: ConfidentialComputingSEVSNP},
+		{vmSize: "standard_dc96ads_v5", expected: ConfidentialComputingSEVSNP},
+		{vmSize: "Standard_DC4as_cc_v5", expected: ConfidentialComputingSEVSNP},
+		{vmSize: "Standard_EC16as_v5", expected: ConfidentialComputingSEVSNP},
+		{vmSize: "Standard_EC64ads_v5", expected: ConfidentialComputingSEVSNP},
+		{vmSize: "Standard_DC8es_v5", expected: ConfidentialComputingTDX},
+		{vmSize: "Standard_EC32eds_v5", expected: ConfidentialComputingTDX},
+		{vmSize: "Standard_D4s_v3", expected: ConfidentialComputingNone},
+		{vmSize: "Standard_E16as_v5", expected: ConfidentialComputingNone},
+		{vmSize: "Standard_NC12", expected: ConfidentialComputingNone},
+		{vmSize: "", expected: ConfidentialComputingNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.vmSize, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GetConfidentialComputingType(tt.vmSize))
+			assert.Equal(t, tt.expected != ConfidentialComputingNone, IsConfidentialComputingSKU(tt.vmSize))
+		})
+	}
+	assert.True(t, ConfidentialComputingTDX.IsConfidentialVM())
+	assert.False(t, ConfidentialComputingSGX.IsConfidentialVM())
+}
@@ -38,6 +38,10 @@ const (
 	VirtualMachineScaleSets = "VirtualMachineScaleSets"
 	// ScaleSetPrioritySpot means the ScaleSet will use Spot VMs.
 	ScaleSetPrioritySpot = "Spot"
+	// ScaleSetEvictionPolicyDelete means the evicted Spot VMs are deleted.
+	ScaleSetEvictionPolicyDelete = "Delete"
+	// ScaleSetEvictionPolicyDeallocate means the evicted Spot VMs are deallocated, their disks are kept.
+	ScaleSetEvictionPolicyDeallocate = "Deallocate"
 )
 
 // Supported container runtimes.
@@ -58,8 +62,18 @@ const (
 	NetworkPluginAzure = "azure"
 	// NetworkPluginNone is the string expression for no CNI plugin.
 	NetworkPluginNone = "none"
+	// NetworkPluginModeOverlay is the network plugin mode of Azure CNI Overlay, where pods get IPs from an overlay
+	// network instead of the VNET.
+	NetworkPluginModeOverlay = "overlay"
+	// NetworkDataplaneAzure is the default network dataplane, where kube-proxy and the Azure CNI route pod traffic.
+	NetworkDataplaneAzure = "azure"
+	// NetworkDataplaneCilium is the network dataplane where Cilium, instead of kube-proxy and the Azure CNI, routes
+	// pod traffic and enforces network policies.
+	NetworkDataplaneCilium = "cilium"
 	// VMSSVMType is the string const for the vmss VM Type.
 	VMSSVMType = "vmss"
+	// VMSSFlexVMType is the string const for the vmss VM Type in flexible orchestration mode.
+	VMSSFlexVMType = "vmssflex"
 	// StandardVMType is the string const for the standard VM Type.
 	StandardVMType = "standard"
 )
@@ -0,0 +1,36 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package datamodel
+
+// DistroCapabilities is what the image of a distro supports. Generation and validation consult it instead of
+// checking for distros one by one, the capabilities of each distro are declared in distroCapabilities.
+type DistroCapabilities struct {
+	// OS is ubuntu, mariner, azurelinux or windows, and Release its release, such as 22.04, v3 or 2022.
+	OS      string
+	Release string
+	Arch    string
+	// CgroupVersion is the cgroup version the image boots with, it is 0 on Windows.
+	CgroupVersion int
+	// Containerd is whether the container runtime of the image is containerd instead of docker.
+	Containerd bool
+	// FIPS is whether the kernel and crypto libraries of the image are FIPS 140 validated.
+	FIPS bool
+	// GPUDrivers is whether NVIDIA GPU drivers can be installed on the image.
+	GPUDrivers bool
+	// Kata is whether the image runs pods in Kata VM sandboxes.
+	Kata bool
+	// Gen2 is whether the image boots Gen2 VMs.
+	Gen2 bool
+	// TrustedLaunch and ConfidentialVM are whether the image has the signed boot chain and kernel of trusted launch and
+	// confidential VMs.
+	TrustedLaunch  bool
+	ConfidentialVM bool
+}
+
+// Capabilities returns the capabilities of the image of the distro, it returns false for custom images, whose
+// capabilities are unknown.
+func (d Distro) Capabilities() (DistroCapabilities, bool) {
+	capabilities, ok := distroCapabilities[d]
+	return capabilities, ok
+}
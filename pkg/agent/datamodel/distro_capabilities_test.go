@@ -0,0 +1,79 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package datamodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistroCapabilities(t *testing.T) {
+	tests := []struct {
+		distro Distro
+		want   DistroCapabilities
+	}{
+		{
+			distro: AKSUbuntuContainerd2204Gen2,
+			want: DistroCapabilities{OS: "ubuntu", Release: "22.04", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true,
+				GPUDrivers: true, Gen2: true},
+		},
+		{
+			distro: AKSUbuntuFipsContainerd2004,
+			want:   DistroCapabilities{OS: "ubuntu", Release: "20.04", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, FIPS: true, GPUDrivers: true},
+		},
+		{
+			distro: AKSUbuntuContainerd2004CVMGen2,
+			want: DistroCapabilities{OS: "ubuntu", Release: "20.04", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true,
+				GPUDrivers: true, Gen2: true, ConfidentialVM: true},
+		},
+		{
+			distro: AKSUbuntuArm64Containerd2404Gen2,
+			want:   DistroCapabilities{OS: "ubuntu", Release: "24.04", Arch: ArchARM64, CgroupVersion: 2, Containerd: true, Gen2: true},
+		},
+		{
+			distro: AKSCBLMarinerV2KataGen2TL,
+			want: DistroCapabilities{OS: "mariner", Release: "v2", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, Kata: true,
+				Gen2: true, TrustedLaunch: true},
+		},
+		{
+			distro: AKSAzureLinuxV3Gen2FIPS,
+			want: DistroCapabilities{OS: "azurelinux", Release: "v3", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, FIPS: true,
+				GPUDrivers: true, Gen2: true},
+		},
+		{
+			distro: AKSWindows2019,
+			want:   DistroCapabilities{OS: "windows", Release: "2019", Arch: ArchAMD64},
+		},
+		{
+			distro: AKSWindows23H2Gen2,
+			want:   DistroCapabilities{OS: "windows", Release: "23H2", Arch: ArchAMD64, Containerd: true, GPUDrivers: true, Gen2: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.distro), func(t *testing.T) {
+			capabilities, ok := tt.distro.Capabilities()
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, capabilities)
+		})
+	}
+
+	for _, distro := range []Distro{CustomizedImage, CustomizedImageKata, CustomizedWindowsOSImage, Distro("")} {
+		_, ok := distro.Capabilities()
+		assert.False(t, ok, distro)
+	}
+	// every distro with an image has its capabilities declared, and they agree with the distro lists.
+	for _, distro := range AKSDistrosAvailableOnVHD {
+		capabilities, ok := distro.Capabilities()
+		assert.True(t, ok, distro)
+		assert.Equal(t, distro.IsGen2Distro(), capabilities.Gen2, distro)
+		assert.Equal(t, distro.IsContainerdDistro(), capabilities.Containerd, distro)
+	}
+	for _, distro := range append(append([]Distro{}, AvailableWindowsSIGDistros...), AvailableWindowsPIRDistros...) {
+		_, ok := distro.Capabilities()
+		assert.Equal(t, distro != CustomizedWindowsOSImage, ok, distro)
+	}
+	assert.True(t, CustomizedImageKata.IsKataDistro())
+	assert.True(t, AKSAzureLinuxV2Arm64Gen2.IsARM64Distro())
+}
@@ -35,7 +35,8 @@ func ValidateDNSPrefix(dnsName string) error {
 	return nil
 }
 
-// IsSgxEnabledSKU determines if an VM SKU has SGX driver support.
+// IsSgxEnabledSKU determines if an VM SKU has SGX driver support. Only the DCv1 sizes need the SGX driver installed,
+// the newer SGX sizes use the in-kernel driver, see GetConfidentialComputingType.
 func IsSgxEnabledSKU(vmSize string) bool {
 	switch vmSize {
 	case "Standard_DC2s", "Standard_DC4s":
@@ -0,0 +1,129 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package datamodel
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// KeyVaultObjectType is the type of a Key Vault object, as named in its resource ID.
+type KeyVaultObjectType string
+
+const (
+	KeyVaultObjectTypeSecret KeyVaultObjectType = "secrets"
+	// KeyVaultObjectTypeCertificate references the secret backing a certificate, which has the name of the certificate
+	// and holds it along with its private key.
+	KeyVaultObjectTypeCertificate KeyVaultObjectType = "certificates"
+	KeyVaultObjectTypeKey         KeyVaultObjectType = "keys"
+)
+
+const (
+	keyVaultProvider       = "Microsoft.KeyVault"
+	keyVaultTypeVaults     = "vaults"
+	keyVaultTypeManagedHSM = "managedHSMs"
+)
+
+//nolint:gochecknoglobals
+var (
+	keyVaultNameRe       = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]{1,22}[a-zA-Z0-9]$`)
+	keyVaultObjectNameRe = regexp.MustCompile(`^[a-zA-Z0-9-]{1,127}$`)
+	keyVaultVersionRe    = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+)
+
+// keyVaultObjectTypes are the object types each type of vault holds, managed HSMs only hold keys.
+//
+//nolint:gochecknoglobals
+var keyVaultObjectTypes = map[string][]KeyVaultObjectType{
+	keyVaultTypeVaults:     {KeyVaultObjectTypeSecret, KeyVaultObjectTypeCertificate, KeyVaultObjectTypeKey},
+	keyVaultTypeManagedHSM: {KeyVaultObjectTypeKey},
+}
+
+// IsKeyVaultObjectID returns whether s is meant as the resource ID of a Key Vault object rather than a plain value.
+func IsKeyVaultObjectID(s string) bool {
+	lower := strings.ToLower(s)
+	return (strings.HasPrefix(lower, "/subscriptions/") || strings.HasPrefix(lower, "/tenants/")) &&
+		strings.Contains(lower, "/providers/"+strings.ToLower(keyVaultProvider)+"/")
+}
+
+/*
+ParseKeyVaultObjectID parses the resource ID of an object of a key vault or of a managed HSM:
+
+	[/tenants/<TENANT_ID>]/subscriptions/<SUB_ID>/resourceGroups/<RG_NAME>/providers/Microsoft.KeyVault/<TYPE>/<NAME>/<OBJECT_TYPE>/<OBJECT_NAME>[/<VERSION>]
+
+where <TYPE> is vaults or managedHSMs and <OBJECT_TYPE> is secrets, certificates or keys. Managed HSMs only hold keys.
+The tenants prefix references a vault of another tenant than the one of the cluster. The latest version is referenced
+when the version is omitted.
+*/
+func ParseKeyVaultObjectID(id string) (*KeyVaultRef, error) {
+	invalid := func(format string, a ...interface{}) error {
+		return fmt.Errorf("invalid Key Vault object ID %q: %s", id, fmt.Sprintf(format, a...))
+	}
+	segments := strings.Split(strings.TrimPrefix(id, "/"), "/")
+	var tenantID string
+	if len(segments) >= 2 && strings.EqualFold(segments[0], "tenants") {
+		tenantID, segments = segments[1], segments[2:]
+		if tenantID == "" {
+			return nil, invalid("the tenant ID is empty")
+		}
+	}
+	// subscriptions/<SUB_ID>/resourceGroups/<RG_NAME>/providers/Microsoft.KeyVault/<TYPE>/<NAME>/<OBJECT_TYPE>/<OBJECT_NAME>
+	const minSegments, maxSegments = 10, 11
+	if len(segments) < minSegments || len(segments) > maxSegments {
+		return nil, invalid("it must be /subscriptions/<SUB_ID>/resourceGroups/<RG_NAME>/providers/Microsoft.KeyVault/<TYPE>/<NAME>/<OBJECT_TYPE>/<OBJECT_NAME>[/<VERSION>]")
+	}
+	for i, keyword := range map[int]string{0: "subscriptions", 2: "resourceGroups", 4: "providers", 5: keyVaultProvider} {
+		if !strings.EqualFold(segments[i], keyword) {
+			return nil, invalid("expected %s instead of %q", keyword, segments[i])
+		}
+	}
+	if segments[1] == "" || segments[3] == "" {
+		return nil, invalid("the subscription ID and the resource group are required")
+	}
+	var vaultType string
+	for t := range keyVaultObjectTypes {
+		if strings.EqualFold(segments[6], t) {
+			vaultType = t
+		}
+	}
+	if vaultType == "" {
+		return nil, invalid("unknown vault type %q, it must be %s or %s", segments[6], keyVaultTypeVaults, keyVaultTypeManagedHSM)
+	}
+	vaultName := segments[7]
+	if !keyVaultNameRe.MatchString(vaultName) {
+		return nil, invalid("invalid vault name %q, it must be 3 to 24 letters, digits and dashes starting with a letter", vaultName)
+	}
+	objectType := KeyVaultObjectType(strings.ToLower(segments[8]))
+	supported := false
+	for _, t := range keyVaultObjectTypes[vaultType] {
+		supported = supported || objectType == t
+	}
+	if !supported {
+		return nil, invalid("%s %s doesn't hold %s", vaultType, vaultName, segments[8])
+	}
+	objectName := segments[9]
+	if !keyVaultObjectNameRe.MatchString(objectName) {
+		return nil, invalid("invalid object name %q, it must be 1 to 127 letters, digits and dashes", objectName)
+	}
+	var version string
+	if len(segments) == maxSegments {
+		if version = segments[10]; !keyVaultVersionRe.MatchString(version) {
+			return nil, invalid("invalid object version %q", version)
+		}
+	}
+
+	ref := &KeyVaultRef{
+		KeyVault:      KeyVaultID{ID: "/" + strings.Join(segments[:8], "/")},
+		TenantID:      tenantID,
+		ObjectType:    objectType,
+		SecretVersion: version,
+	}
+	if objectType == KeyVaultObjectTypeKey {
+		ref.KeyName = objectName
+	} else {
+		ref.SecretName = objectName
+	}
+	return ref, nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package datamodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKeyVaultObjectID(t *testing.T) {
+	const vaultID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/my-vault"
+	tests := []struct {
+		name    string
+		id      string
+		want    *KeyVaultRef
+		wantErr string
+	}{
+		{
+			name: "secret",
+			id:   vaultID + "/secrets/apiserver",
+			want: &KeyVaultRef{KeyVault: KeyVaultID{ID: vaultID}, ObjectType: KeyVaultObjectTypeSecret, SecretName: "apiserver"},
+		},
+		{
+			name: "versioned certificate",
+			id:   vaultID + "/certificates/apiserver/0123456789abcdef0123456789abcdef",
+			want: &KeyVaultRef{KeyVault: KeyVaultID{ID: vaultID}, ObjectType: KeyVaultObjectTypeCertificate, SecretName: "apiserver",
+				SecretVersion: "0123456789abcdef0123456789abcdef"},
+		},
+		{
+			name: "cross-tenant",
+			id:   "/tenants/tenant/" + vaultID[1:] + "/secrets/ca",
+			want: &KeyVaultRef{KeyVault: KeyVaultID{ID: vaultID}, TenantID: "tenant", ObjectType: KeyVaultObjectTypeSecret, SecretName: "ca"},
+		},
+		{
+			name: "managed HSM key",
+			id:   "/subscriptions/sub/resourceGroups/rg/providers/microsoft.keyvault/managedhsms/my-hsm/keys/kms",
+			want: &KeyVaultRef{KeyVault: KeyVaultID{ID: "/subscriptions/sub/resourceGroups/rg/providers/microsoft.keyvault/managedhsms/my-hsm"},
+				ObjectType: KeyVaultObjectTypeKey, KeyName: "kms"},
+		},
+		{
+			name:    "managed HSM secret",
+			id:      "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.KeyVault/managedHSMs/my-hsm/secrets/ca",
+			wantErr: `invalid Key Vault object ID "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.KeyVault/managedHSMs/my-hsm/secrets/ca": managedHSMs my-hsm doesn't hold secrets`,
+		},
+		{
+			name:    "missing object",
+			id:      vaultID,
+			wantErr: "it must be /subscriptions/<SUB_ID>/resourceGroups/<RG_NAME>/providers/Microsoft.KeyVault/<TYPE>/<NAME>/<OBJECT_TYPE>/<OBJECT_NAME>[/<VERSION>]",
+		},
+		{
+			name:    "invalid vault name",
+			id:      "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/my_vault/secrets/ca",
+			wantErr: `invalid vault name "my_vault", it must be 3 to 24 letters, digits and dashes starting with a letter`,
+		},
+		{
+			name:    "unknown vault type",
+			id:      "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.KeyVault/stores/my-vault/secrets/ca",
+			wantErr: `unknown vault type "stores", it must be vaults or managedHSMs`,
+		},
+		{
+			name:    "wrong provider",
+			id:      "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/vaults/my-vault/secrets/ca",
+			wantErr: `expected Microsoft.KeyVault instead of "Microsoft.Storage"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseKeyVaultObjectID(tt.id)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.True(t, IsKeyVaultObjectID(vaultID+"/secrets/ca"))
+	assert.True(t, IsKeyVaultObjectID("/tenants/tenant/"+vaultID[1:]+"/secrets/ca"))
+	assert.False(t, IsKeyVaultObjectID("LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0t"))
+}
@@ -0,0 +1,20 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package datamodel
+
+import (
+	"regexp"
+	"strings"
+)
+
+// rdmaSizeRegex matches the HPC and GPU sizes, such as Standard_HB120rs_v3, Standard_HB120-96rs_v3 or
+// Standard_ND96isr_H100_v5, capturing the feature letters.
+var rdmaSizeRegex = regexp.MustCompile(`^standard_(?:h|hb|hc|hx|nc|nd)\d+(?:-\d+)?([a-z]*)(?:_[a-z]+\d+[a-z]*)?(?:_v\d+)?$`)
+
+// IsRDMACapableSKU returns whether the VM size has an InfiniBand NIC exposed to the VM through SR-IOV, the sizes of
+// the H, HB, HC, HX, NC and ND families with the r feature letter, such as Standard_HB120rs_v3 or Standard_ND96asr_v4.
+func IsRDMACapableSKU(vmSize string) bool {
+	match := rdmaSizeRegex.FindStringSubmatch(strings.ToLower(vmSize))
+	return match != nil && strings.Contains(match[1], "r")
+}
@@ -0,0 +1,42 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package datamodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRDMACapableSKU(t *testing.T) {
+	tests := []struct {
+		vmSize   string
+		expected bool
+	}{
+		{vmSize: "Standard_H16r", expected: true},
+		{vmSize: "Standard_H16mr", expected: true},
+		{vmSize: "Standard_HB120rs_v3", expected: true},
+		{vmSize: "Standard_HB120-96rs_v3", expected: true},
+		{vmSize: "Standard_HB176rs_v4", expected: true},
+		{vmSize: "Standard_HC44rs", expected: true},
+		{vmSize: "Standard_HX176rs", expected: true},
+		{vmSize: "Standard_NC24rs_v3", expected: true},
+		{vmSize: "Standard_ND40rs_v2", expected: true},
+		{vmSize: "standard_nd96asr_v4", expected: true},
+		{vmSize: "Standard_ND96amsr_A100_v4", expected: true},
+		{vmSize: "Standard_ND96isr_H100_v5", expected: true},
+		{vmSize: "Standard_ND96isr_MI300X_v5", expected: true},
+		{vmSize: "Standard_H16", expected: false},
+		{vmSize: "Standard_HB60-15s_v2", expected: false},
+		{vmSize: "Standard_NC24ads_A100_v4", expected: false},
+		{vmSize: "Standard_NC6s_v3", expected: false},
+		{vmSize: "Standard_D4rs_v5", expected: false},
+		{vmSize: "", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.vmSize, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsRDMACapableSKU(tt.vmSize))
+		})
+	}
+}
@@ -14,6 +14,8 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/Azure/agentbaker/pkg/agent/nodelabels"
+	"github.com/Azure/agentbaker/pkg/agent/podcidr"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/Masterminds/semver/v3"
 )
@@ -56,11 +58,17 @@ type KeyVaultID struct {
 	ID string `json:"id,omitempty"`
 }
 
-// KeyVaultRef represents a reference to KeyVault instance on Azure.
+// KeyVaultRef represents a reference to an object of a KeyVault instance on Azure.
 type KeyVaultRef struct {
-	KeyVault      KeyVaultID `json:"keyVault"`
-	SecretName    string     `json:"secretName"`
-	SecretVersion string     `json:"secretVersion,omitempty"`
+	KeyVault KeyVaultID `json:"keyVault"`
+	// TenantID is the tenant of the key vault when it isn't the tenant of the cluster.
+	TenantID   string             `json:"tenantId,omitempty"`
+	ObjectType KeyVaultObjectType `json:"objectType,omitempty"`
+	// SecretName is the name of the secret, or of the certificate.
+	SecretName string `json:"secretName,omitempty"`
+	KeyName    string `json:"keyName,omitempty"`
+	// SecretVersion is the version of the object, the latest version when empty.
+	SecretVersion string `json:"secretVersion,omitempty"`
 }
 
 // KeyVaultSecrets specifies certificates to install on the pool of machines from a given key vault.
@@ -220,6 +228,12 @@ const (
 	CustomizedImage          Distro = "CustomizedImage"
 	CustomizedImageKata      Distro = "CustomizedImageKata"
 	CustomizedWindowsOSImage Distro = "CustomizedWindowsOSImage"
+	// CustomizedImageFlatcar stands for a custom image of an immutable distro provisioned by Ignition, such as Flatcar.
+	CustomizedImageFlatcar Distro = "CustomizedImageFlatcar"
+
+	// ArchAMD64 and ArchARM64 are the CPU architectures of nodes.
+	ArchAMD64 = "amd64"
+	ArchARM64 = "arm64"
 
 	// USNatCloud is a const string reference identifier for USNat.
 	USNatCloud = "USNatCloud"
@@ -281,6 +295,70 @@ var AKSDistrosAvailableOnVHD = []Distro{
 	AKSUbuntuContainerd2404Gen2,
 }
 
+// distroCapabilities is the capability matrix of the distros with an AKS image. Each distro of AKSDistrosAvailableOnVHD,
+// AvailableWindowsSIGDistros and AvailableWindowsPIRDistros is declared here, custom images are not.
+//
+//nolint:gochecknoglobals
+var distroCapabilities = map[Distro]DistroCapabilities{
+	AKSUbuntu1604:                       {OS: "ubuntu", Release: "16.04", Arch: ArchAMD64, CgroupVersion: 1, GPUDrivers: true},
+	AKSUbuntu1804:                       {OS: "ubuntu", Release: "18.04", Arch: ArchAMD64, CgroupVersion: 1, GPUDrivers: true},
+	AKSUbuntu1804Gen2:                   {OS: "ubuntu", Release: "18.04", Arch: ArchAMD64, CgroupVersion: 1, GPUDrivers: true, Gen2: true},
+	AKSUbuntuGPU1804:                    {OS: "ubuntu", Release: "18.04", Arch: ArchAMD64, CgroupVersion: 1, GPUDrivers: true},
+	AKSUbuntuGPU1804Gen2:                {OS: "ubuntu", Release: "18.04", Arch: ArchAMD64, CgroupVersion: 1, GPUDrivers: true, Gen2: true},
+	AKSUbuntuContainerd1804:             {OS: "ubuntu", Release: "18.04", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, GPUDrivers: true},
+	AKSUbuntuContainerd1804Gen2:         {OS: "ubuntu", Release: "18.04", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, GPUDrivers: true, Gen2: true},
+	AKSUbuntuGPUContainerd1804:          {OS: "ubuntu", Release: "18.04", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, GPUDrivers: true},
+	AKSUbuntuGPUContainerd1804Gen2:      {OS: "ubuntu", Release: "18.04", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, GPUDrivers: true, Gen2: true},
+	AKSCBLMarinerV1:                     {OS: "mariner", Release: "v1", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, GPUDrivers: true},
+	AKSCBLMarinerV2:                     {OS: "mariner", Release: "v2", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, GPUDrivers: true},
+	AKSAzureLinuxV2:                     {OS: "azurelinux", Release: "v2", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, GPUDrivers: true},
+	AKSAzureLinuxV3:                     {OS: "azurelinux", Release: "v3", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, GPUDrivers: true},
+	AKSCBLMarinerV2Gen2:                 {OS: "mariner", Release: "v2", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, GPUDrivers: true, Gen2: true},
+	AKSAzureLinuxV2Gen2:                 {OS: "azurelinux", Release: "v2", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, GPUDrivers: true, Gen2: true},
+	AKSAzureLinuxV3Gen2:                 {OS: "azurelinux", Release: "v3", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, GPUDrivers: true, Gen2: true},
+	AKSCBLMarinerV2FIPS:                 {OS: "mariner", Release: "v2", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, FIPS: true, GPUDrivers: true},
+	AKSAzureLinuxV2FIPS:                 {OS: "azurelinux", Release: "v2", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, FIPS: true, GPUDrivers: true},
+	AKSAzureLinuxV3FIPS:                 {OS: "azurelinux", Release: "v3", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, FIPS: true, GPUDrivers: true},
+	AKSCBLMarinerV2Gen2FIPS:             {OS: "mariner", Release: "v2", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, FIPS: true, GPUDrivers: true, Gen2: true},
+	AKSAzureLinuxV2Gen2FIPS:             {OS: "azurelinux", Release: "v2", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, FIPS: true, GPUDrivers: true, Gen2: true},
+	AKSAzureLinuxV3Gen2FIPS:             {OS: "azurelinux", Release: "v3", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, FIPS: true, GPUDrivers: true, Gen2: true},
+	AKSCBLMarinerV2Gen2Kata:             {OS: "mariner", Release: "v2", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, Kata: true, Gen2: true},
+	AKSAzureLinuxV2Gen2Kata:             {OS: "azurelinux", Release: "v2", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, Kata: true, Gen2: true},
+	AKSCBLMarinerV2Gen2TL:               {OS: "mariner", Release: "v2", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, GPUDrivers: true, Gen2: true, TrustedLaunch: true},
+	AKSAzureLinuxV2Gen2TL:               {OS: "azurelinux", Release: "v2", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, GPUDrivers: true, Gen2: true, TrustedLaunch: true},
+	AKSCBLMarinerV2KataGen2TL:           {OS: "mariner", Release: "v2", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, Kata: true, Gen2: true, TrustedLaunch: true},
+	AKSUbuntuFipsContainerd1804:         {OS: "ubuntu", Release: "18.04", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, FIPS: true, GPUDrivers: true},
+	AKSUbuntuFipsContainerd1804Gen2:     {OS: "ubuntu", Release: "18.04", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, FIPS: true, GPUDrivers: true, Gen2: true},
+	AKSUbuntuFipsContainerd2004:         {OS: "ubuntu", Release: "20.04", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, FIPS: true, GPUDrivers: true},
+	AKSUbuntuFipsContainerd2004Gen2:     {OS: "ubuntu", Release: "20.04", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, FIPS: true, GPUDrivers: true, Gen2: true},
+	AKSUbuntuFipsContainerd2204:         {OS: "ubuntu", Release: "22.04", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, FIPS: true, GPUDrivers: true},
+	AKSUbuntuFipsContainerd2204Gen2:     {OS: "ubuntu", Release: "22.04", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, FIPS: true, GPUDrivers: true, Gen2: true},
+	AKSUbuntuEdgeZoneContainerd1804:     {OS: "ubuntu", Release: "18.04", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, GPUDrivers: true},
+	AKSUbuntuEdgeZoneContainerd1804Gen2: {OS: "ubuntu", Release: "18.04", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, GPUDrivers: true, Gen2: true},
+	AKSUbuntuEdgeZoneContainerd2204:     {OS: "ubuntu", Release: "22.04", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, GPUDrivers: true},
+	AKSUbuntuEdgeZoneContainerd2204Gen2: {OS: "ubuntu", Release: "22.04", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, GPUDrivers: true, Gen2: true},
+	AKSUbuntuContainerd2204:             {OS: "ubuntu", Release: "22.04", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, GPUDrivers: true},
+	AKSUbuntuContainerd2204Gen2:         {OS: "ubuntu", Release: "22.04", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, GPUDrivers: true, Gen2: true},
+	AKSUbuntuContainerd2004CVMGen2:      {OS: "ubuntu", Release: "20.04", Arch: ArchAMD64, CgroupVersion: 1, Containerd: true, GPUDrivers: true, Gen2: true, ConfidentialVM: true},
+	AKSUbuntuArm64Containerd2204Gen2:    {OS: "ubuntu", Release: "22.04", Arch: ArchARM64, CgroupVersion: 2, Containerd: true, Gen2: true},
+	AKSUbuntuArm64Containerd2404Gen2:    {OS: "ubuntu", Release: "24.04", Arch: ArchARM64, CgroupVersion: 2, Containerd: true, Gen2: true},
+	AKSCBLMarinerV2Arm64Gen2:            {OS: "mariner", Release: "v2", Arch: ArchARM64, CgroupVersion: 1, Containerd: true, Gen2: true},
+	AKSAzureLinuxV2Arm64Gen2:            {OS: "azurelinux", Release: "v2", Arch: ArchARM64, CgroupVersion: 2, Containerd: true, Gen2: true},
+	AKSAzureLinuxV3Arm64Gen2:            {OS: "azurelinux", Release: "v3", Arch: ArchARM64, CgroupVersion: 2, Containerd: true, Gen2: true},
+	AKSUbuntuContainerd2204TLGen2:       {OS: "ubuntu", Release: "22.04", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, GPUDrivers: true, Gen2: true, TrustedLaunch: true},
+	AKSUbuntuMinimalContainerd2204:      {OS: "ubuntu", Release: "22.04", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, GPUDrivers: true},
+	AKSUbuntuMinimalContainerd2204Gen2:  {OS: "ubuntu", Release: "22.04", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, GPUDrivers: true, Gen2: true},
+	AKSUbuntuContainerd2404:             {OS: "ubuntu", Release: "24.04", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, GPUDrivers: true},
+	AKSUbuntuContainerd2404Gen2:         {OS: "ubuntu", Release: "24.04", Arch: ArchAMD64, CgroupVersion: 2, Containerd: true, GPUDrivers: true, Gen2: true},
+	AKSWindows2019:                      {OS: "windows", Release: "2019", Arch: ArchAMD64},
+	AKSWindows2019Containerd:            {OS: "windows", Release: "2019", Arch: ArchAMD64, Containerd: true},
+	AKSWindows2022Containerd:            {OS: "windows", Release: "2022", Arch: ArchAMD64, Containerd: true, GPUDrivers: true},
+	AKSWindows2022ContainerdGen2:        {OS: "windows", Release: "2022", Arch: ArchAMD64, Containerd: true, GPUDrivers: true, Gen2: true},
+	AKSWindows23H2:                      {OS: "windows", Release: "23H2", Arch: ArchAMD64, Containerd: true, GPUDrivers: true},
+	AKSWindows23H2Gen2:                  {OS: "windows", Release: "23H2", Arch: ArchAMD64, Containerd: true, GPUDrivers: true, Gen2: true},
+	AKSWindows2019PIR:                   {OS: "windows", Release: "2019", Arch: ArchAMD64},
+}
+
 type CustomConfigurationComponent string
 
 const (
@@ -326,7 +404,20 @@ func (d Distro) IsAzureLinuxCgroupV2VHDDistro() bool {
 }
 
 func (d Distro) IsKataDistro() bool {
-	return d == AKSCBLMarinerV2Gen2Kata || d == AKSAzureLinuxV2Gen2Kata || d == AKSCBLMarinerV2KataGen2TL || d == CustomizedImageKata
+	capabilities, _ := d.Capabilities()
+	return capabilities.Kata || d == CustomizedImageKata
+}
+
+// IsARM64Distro returns whether the images of the distro are arm64 ones.
+func (d Distro) IsARM64Distro() bool {
+	capabilities, _ := d.Capabilities()
+	return capabilities.Arch == ArchARM64
+}
+
+// IsIgnitionDistro returns whether the distro is provisioned by Ignition, its custom data is then an Ignition config
+// instead of a cloud-config.
+func (d Distro) IsIgnitionDistro() bool {
+	return d == CustomizedImageFlatcar
 }
 
 /*
@@ -676,6 +767,9 @@ type KubernetesConfig struct {
 	MaximumLoadBalancerRuleCount      int               `json:"maximumLoadBalancerRuleCount,omitempty"`
 	PrivateAzureRegistryServer        string            `json:"privateAzureRegistryServer,omitempty"`
 	NetworkPluginMode                 string            `json:"networkPluginMode,omitempty"`
+	NetworkDataplane                  string            `json:"networkDataplane,omitempty"`
+	NodeCIDRMaskSize                  int               `json:"nodeCIDRMaskSize,omitempty"`
+	WindowsNodeCIDRMaskSize           int               `json:"windowsNodeCIDRMaskSize,omitempty"`
 }
 
 /*
@@ -711,6 +805,10 @@ type CustomKubeletConfig struct {
 	ContainerLogMaxFiles  *int32    `json:"containerLogMaxFiles,omitempty"`
 	PodMaxPids            *int32    `json:"podMaxPids,omitempty"`
 	SeccompDefault        *bool     `json:"seccompDefault,omitempty"`
+	// ShutdownGracePeriod and ShutdownGracePeriodCriticalPods are durations such as 30s, they default to the
+	// eviction notice of Spot pools.
+	ShutdownGracePeriod             string `json:"shutdownGracePeriod,omitempty"`
+	ShutdownGracePeriodCriticalPods string `json:"shutdownGracePeriodCriticalPods,omitempty"`
 }
 
 // CustomLinuxOSConfig represents custom os configurations for agent pool nodes.
@@ -802,6 +900,36 @@ type AgentPoolProfile struct {
 	behavior to reboot Windows node when it is nil. */
 	NotRebootWindowsNode    *bool                    `json:"notRebootWindowsNode,omitempty"`
 	AgentPoolWindowsProfile *AgentPoolWindowsProfile `json:"agentPoolWindowsProfile,omitempty"`
+	// EnableAcceleratedNetworking is the accelerated networking setting of the NICs of the pool.
+	EnableAcceleratedNetworking *bool `json:"enableAcceleratedNetworking,omitempty"`
+	// EnableInfiniBand sets up the InfiniBand NIC of the RDMA capable VM sizes, installing the MOFED drivers.
+	EnableInfiniBand *bool `json:"enableInfiniBand,omitempty"`
+	// VMSecurityProfile is the trusted launch or confidential VM setting of the VMs of the pool.
+	VMSecurityProfile *VMSecurityProfile `json:"vmSecurityProfile,omitempty"`
+	// EnableIPForwarding is the IP forwarding setting of the NICs of the pool, it defaults to true with kubenet.
+	EnableIPForwarding *bool `json:"enableIPForwarding,omitempty"`
+	// ScaleSetPriority is Regular, the default, or Spot.
+	ScaleSetPriority string `json:"scaleSetPriority,omitempty"`
+	// ScaleSetEvictionPolicy is what happens to the evicted VMs of a Spot pool, Delete, the default, or Deallocate.
+	ScaleSetEvictionPolicy string `json:"scaleSetEvictionPolicy,omitempty"`
+	// SpotMaxPrice is the maximum price per hour of the VMs of a Spot pool, in US dollars. -1, the default, caps the
+	// price at the regular price, so that the VMs are only evicted for capacity.
+	SpotMaxPrice *float64 `json:"spotMaxPrice,omitempty"`
+}
+
+// IsSpotScaleSet returns true if the pool runs on Spot VMs, which Azure evicts with a 30 seconds notice.
+func (a *AgentPoolProfile) IsSpotScaleSet() bool {
+	return a != nil && strings.EqualFold(a.ScaleSetPriority, ScaleSetPrioritySpot)
+}
+
+// IsAcceleratedNetworkingEnabled returns true if the NICs of the pool have accelerated networking.
+func (a *AgentPoolProfile) IsAcceleratedNetworkingEnabled() bool {
+	return a != nil && a.EnableAcceleratedNetworking != nil && *a.EnableAcceleratedNetworking
+}
+
+// IsInfiniBandEnabled returns true if the InfiniBand NIC of the nodes of the pool is set up.
+func (a *AgentPoolProfile) IsInfiniBandEnabled() bool {
+	return a != nil && a.EnableInfiniBand != nil && *a.EnableInfiniBand
 }
 
 func (a *AgentPoolProfile) GetCustomLinuxOSConfig() *CustomLinuxOSConfig {
@@ -1188,19 +1316,28 @@ func (a *AgentPoolProfile) IsAvailabilitySets() bool {
 
 // GetKubernetesLabels returns a k8s API-compliant labels string for nodes in this profile.
 func (a *AgentPoolProfile) GetKubernetesLabels() string {
-	var buf bytes.Buffer
-	buf.WriteString(fmt.Sprintf("agentpool=%s", a.Name))
-	buf.WriteString(fmt.Sprintf(",kubernetes.azure.com/agentpool=%s", a.Name))
+	return nodelabels.Format(a.GetNodeLabels())
+}
 
-	keys := []string{}
-	for key := range a.CustomNodeLabels {
-		keys = append(keys, key)
+// GetNodeLabels returns the standard node labels which can be derived from this profile.
+func (a *AgentPoolProfile) GetNodeLabels() map[string]string {
+	return nodelabels.Compute(a.nodeLabelsInput())
+}
+
+func (a *AgentPoolProfile) nodeLabelsInput() nodelabels.Input {
+	in := nodelabels.Input{
+		AgentPoolName: a.Name,
+		CustomLabels:  a.CustomNodeLabels,
 	}
-	sort.Strings(keys)
-	for _, key := range keys {
-		buf.WriteString(fmt.Sprintf(",%s=%s", key, a.CustomNodeLabels[key]))
+	if a.IsSpotScaleSet() {
+		in.ScaleSetPriority = nodelabels.ScaleSetPrioritySpot
+	}
+	if strings.EqualFold(a.StorageProfile, ManagedDisks) {
+		if storageTier, err := GetStorageAccountType(a.VMSize); err == nil {
+			in.StorageTier = storageTier
+		}
 	}
-	return buf.String()
+	return in
 }
 
 // HasSecrets returns true if the customer specified secrets to install.
@@ -1509,6 +1646,13 @@ func (k *KubernetesConfig) IsUsingNetworkPluginMode(mode string) bool {
 	return strings.EqualFold(k.NetworkPluginMode, mode)
 }
 
+// PodCIDRStrategy returns the strategy used to carve per-node pod CIDRs out of the cluster subnet for kubenet.
+// For dual-stack clusters the pod CIDRs are allocated from the first cluster subnet.
+func (k *KubernetesConfig) PodCIDRStrategy() (podcidr.Strategy, error) {
+	clusterSubnet, _, _ := strings.Cut(k.ClusterSubnet, ",")
+	return podcidr.NewContiguous(clusterSubnet, k.NodeCIDRMaskSize, k.WindowsNodeCIDRMaskSize)
+}
+
 func setCustomKubletConfigFromSettings(customKc *CustomKubeletConfig, kubeletConfig map[string]string) map[string]string {
 	// Settings from customKubeletConfig, only take if it's set.
 	if customKc != nil {
@@ -1685,6 +1829,44 @@ type GetLatestSigImageConfigRequest struct {
 	Distro         Distro
 }
 
+// GetDefaultKubeletConfigurationRequest describes the input for a GetDefaultKubeletConfiguration HTTP request.
+// It carries only the settings which influence the kubelet configuration, so callers can inspect
+// the generated configuration without constructing a full NodeBootstrappingConfiguration.
+type GetDefaultKubeletConfigurationRequest struct {
+	KubernetesVersion       string
+	Distro                  Distro
+	VMSize                  string
+	OSType                  OSType
+	ContainerRuntime        string
+	EnableKubeletConfigFile bool
+	// KubeletConfig holds the kubelet flags as they would be set in NodeBootstrappingConfiguration.KubeletConfig.
+	KubeletConfig       map[string]string
+	CustomKubeletConfig *CustomKubeletConfig
+}
+
+// DefaultKubeletConfiguration is the kubelet configuration generated for a node.
+type DefaultKubeletConfiguration struct {
+	// Flags are the kubelet flags after defaulting and validation.
+	Flags map[string]string `json:"flags,omitempty"`
+	// CommandLineFlags is the flag string passed to kubelet on the command line.
+	CommandLineFlags string `json:"commandLineFlags,omitempty"`
+	// ConfigFileEnabled is true when the flags are translated into a kubelet config file.
+	ConfigFileEnabled bool `json:"configFileEnabled"`
+	// ConfigFileContent is the JSON content of the kubelet config file, only set when ConfigFileEnabled is true.
+	ConfigFileContent string `json:"configFileContent,omitempty"`
+	// Warnings are the kubelet flags dropped or translated for the Kubernetes version.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// GetComponentVersionsRequest describes the input for a GetComponentVersions HTTP request.
+// Empty fields match every version, the architecture defaults to the one of Distro, or amd64.
+type GetComponentVersionsRequest struct {
+	Component         string
+	Distro            Distro
+	Arch              string
+	KubernetesVersion string
+}
+
 // NodeBootstrappingConfiguration represents configurations for node bootstrapping.
 type NodeBootstrappingConfiguration struct {
 	ContainerService              *ContainerService
@@ -1730,6 +1912,7 @@ type NodeBootstrappingConfiguration struct {
 	EnableRuncShimV2                       bool
 	GPUInstanceProfile                     string
 	PrimaryScaleSetName                    string
+	BootstrapTarget                        BootstrapTarget
 	SIGConfig                              SIGConfig
 	IsARM64                                bool
 	CustomCATrustConfig                    *CustomCATrustConfig
@@ -1746,6 +1929,42 @@ type NodeBootstrappingConfiguration struct {
 
 	// Version is required for aks-node-controller application to determine the version of the config file.
 	Version string
+
+	// TemplateBundleHash pins the template bundle the bootstrapping data is generated from, so that nodes of a
+	// nodepool keep the same behavior when agentbaker is upgraded. The current bundle is used when empty.
+	TemplateBundleHash string
+}
+
+// GetArch returns the CPU architecture of the node, arm64 when IsARM64 is set or the distro is an arm64 one.
+func (config *NodeBootstrappingConfiguration) GetArch() string {
+	if config.IsARM64 || (config.AgentPoolProfile != nil && config.AgentPoolProfile.Distro.IsARM64Distro()) {
+		return ArchARM64
+	}
+	return ArchAMD64
+}
+
+// GetBootstrapTarget returns the kind of machine the node is. When BootstrapTarget isn't set, it is derived from the
+// availability profile, a VMSS in uniform orchestration mode or a standalone VM.
+func (config *NodeBootstrappingConfiguration) GetBootstrapTarget() BootstrapTarget {
+	if config.BootstrapTarget != "" {
+		return config.BootstrapTarget
+	}
+	if config.AgentPoolProfile != nil && config.AgentPoolProfile.IsVirtualMachineScaleSets() {
+		return BootstrapTargetVMSSUniform
+	}
+	return BootstrapTargetStandaloneVM
+}
+
+// GetNodeLabels returns the standard node labels for the node being bootstrapped.
+func (config *NodeBootstrappingConfiguration) GetNodeLabels() map[string]string {
+	in := config.AgentPoolProfile.nodeLabelsInput()
+	in.OSSKU = config.OSSKU
+	in.FIPSEnabled = config.FIPSEnabled
+	in.Arch = config.GetArch()
+	if config.EnableNvidia {
+		in.Accelerator = nodelabels.AcceleratorNvidia
+	}
+	return nodelabels.Compute(in)
 }
 
 type SSHStatus int
@@ -1758,10 +1977,113 @@ const (
 
 // NodeBootstrapping represents the custom data, CSE, and OS image info needed for node bootstrapping.
 type NodeBootstrapping struct {
-	CustomData     string
+	// CustomData is the cloud-init payload of Linux nodes, gzipped and base64 encoded, and the base64 encoded custom
+	// data of Windows nodes.
+	CustomData string
+	// CSE is the command the custom script extension runs, VMExtensions has the extension running it.
 	CSE            string
 	OSImageConfig  *AzureOSImageConfig
 	SigImageConfig *SigImageConfig
+	// TemplateBundleHash is the hash of the template bundle used, callers pin it to keep generating the same bootstrapping data.
+	TemplateBundleHash string
+	// VMExtensions are the VM extensions the node must be created with.
+	VMExtensions []VMExtension
+	// Artifacts are the container images and files the node downloads while bootstrapping, beyond the ones cached on
+	// the VHD.
+	Artifacts []ArtifactDependency
+	// RawFiles are the unencoded custom data, CSE command and kubelet config, they are only set when raw output is
+	// requested.
+	RawFiles []BootstrappingFile `json:",omitempty"`
+	// PayloadSize is the size of the custom data against the Azure limit.
+	PayloadSize *PayloadSize `json:",omitempty"`
+	// Warnings are the changes made to the configuration so that the node can bootstrap, such as dropped kubelet flags.
+	Warnings []string `json:",omitempty"`
+}
+
+// PayloadSize is the size of the encoded custom data, and the contribution of the files it writes.
+type PayloadSize struct {
+	Size  int
+	Limit int
+	// Files are the files the custom data writes, largest first.
+	Files []PayloadFileSize `json:",omitempty"`
+}
+
+// PayloadFileSize is the estimated size a file adds to the encoded custom data.
+type PayloadFileSize struct {
+	Path string
+	Size int
+	// URL is where the node downloads the file from when it was moved out of the custom data, it then adds nothing.
+	URL string `json:",omitempty"`
+}
+
+// BootstrappingFile is an unencoded bootstrapping file.
+type BootstrappingFile struct {
+	Name    string
+	Content string
+}
+
+// RawText returns the raw files of the node bootstrapping data, each preceded by a line with its name.
+func (n *NodeBootstrapping) RawText() string {
+	var b strings.Builder
+	for i, file := range n.RawFiles {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "==> %s <==\n%s", file.Name, file.Content)
+		if !strings.HasSuffix(file.Content, "\n") {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// VMExtension is a VM extension of a node, with the settings it is created with.
+type VMExtension struct {
+	Name                    string
+	ResourceType            string
+	Publisher               string
+	Type                    string
+	TypeHandlerVersion      string
+	AutoUpgradeMinorVersion bool
+	// ProtectedSettings are encrypted and only readable on the node, they hold the CSE command.
+	ProtectedSettings map[string]string
+}
+
+// BootstrapTarget is the kind of machine a node is, which decides how it is named, whether it can use IMDS and how
+// the CSE command is run. Nodes without a target are bootstrapped from the availability profiles of the cluster.
+type BootstrapTarget string
+
+const (
+	// BootstrapTargetVMSSUniform is an instance of a VMSS in uniform orchestration mode.
+	BootstrapTargetVMSSUniform BootstrapTarget = "VMSSUniform"
+	// BootstrapTargetVMSSFlex is a VM of a VMSS in flexible orchestration mode.
+	BootstrapTargetVMSSFlex BootstrapTarget = "VMSSFlex"
+	// BootstrapTargetStandaloneVM is a VM which doesn't belong to a VMSS.
+	BootstrapTargetStandaloneVM BootstrapTarget = "StandaloneVM"
+	// BootstrapTargetArcMachine is a machine outside Azure connected with Azure Arc, it has no IMDS.
+	BootstrapTargetArcMachine BootstrapTarget = "ArcMachine"
+)
+
+// ArtifactType is the kind of an artifact a node downloads.
+type ArtifactType string
+
+const (
+	ArtifactTypeImage ArtifactType = "image"
+	ArtifactTypeURL   ArtifactType = "url"
+	// ArtifactTypePackage is a package installed from the package repositories of the distro, it has no reference.
+	ArtifactTypePackage ArtifactType = "package"
+)
+
+// ArtifactDependency is a container image or a file a node downloads while bootstrapping.
+type ArtifactDependency struct {
+	Type ArtifactType
+	// Name is the component the artifact is, such as kube-proxy or azure-cni.
+	Name string
+	// Reference is the image reference of container images and the download URL of files.
+	Reference string
+	// Version and Digest are only set when the component catalog or the image reference has them.
+	Version string `json:",omitempty"`
+	Digest  string `json:",omitempty"`
 }
 
 // HTTPProxyConfig represents configurations of http proxy.
@@ -2121,6 +2443,16 @@ type AKSKubeletConfiguration struct {
 	// Default: false
 	// +optional
 	SeccompDefault *bool `json:"seccompDefault,omitempty"`
+	// ShutdownGracePeriod is how long the node delays its shutdown to terminate its pods, 0 disables the graceful node
+	// shutdown.
+	// Default: "0s"
+	// +optional
+	ShutdownGracePeriod Duration `json:"shutdownGracePeriod,omitempty"`
+	// ShutdownGracePeriodCriticalPods is the part of ShutdownGracePeriod kept to terminate the critical pods, once the
+	// other pods are terminated.
+	// Default: "0s"
+	// +optional
+	ShutdownGracePeriodCriticalPods Duration `json:"shutdownGracePeriodCriticalPods,omitempty"`
 }
 
 type Duration string
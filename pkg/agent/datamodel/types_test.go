@@ -1205,6 +1205,23 @@ func TestAgentPoolProfileGetKubernetesLabels(t *testing.T) {
 			fipsEnabled:   false,
 			expected:      "agentpool=,kubernetes.azure.com/agentpool=,mycustomlabel1=foo,mycustomlabel2=bar",
 		},
+		{
+			name: "with managed disks",
+			ap: AgentPoolProfile{
+				Name:           "pool1",
+				VMSize:         "Standard_DS2_v2",
+				StorageProfile: ManagedDisks,
+				CustomNodeLabels: map[string]string{
+					"kubernetes.azure.com/storagetier": "Standard_LRS",
+				},
+			},
+			rg:            "my-resource-group",
+			deprecated:    true,
+			nvidiaEnabled: false,
+			fipsEnabled:   false,
+			expected: "agentpool=pool1,kubernetes.azure.com/agentpool=pool1,kubernetes.azure.com/storageprofile=managed," +
+				"kubernetes.azure.com/storagetier=Standard_LRS",
+		},
 	}
 
 	for _, c := range cases {
@@ -2492,6 +2509,29 @@ func TestKubernetesConfig_UserAssignedIDEnabled(t *testing.T) {
 	}
 }
 
+func TestKubernetesConfig_PodCIDRStrategy(t *testing.T) {
+	k := KubernetesConfig{
+		ClusterSubnet:           "10.240.0.0/12,fd00:10:244::/56",
+		NodeCIDRMaskSize:        25,
+		WindowsNodeCIDRMaskSize: 24,
+	}
+	strategy, err := k.PodCIDRStrategy()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strategy.ClusterCIDR().String() != "10.240.0.0/12" {
+		t.Errorf("expected pod CIDRs to be allocated from the first cluster subnet, got %s", strategy.ClusterCIDR())
+	}
+	if strategy.NodeMaskSize(false) != 25 || strategy.NodeMaskSize(true) != 24 {
+		t.Errorf("expected node mask sizes 25 and 24, got %d and %d", strategy.NodeMaskSize(false), strategy.NodeMaskSize(true))
+	}
+
+	k = KubernetesConfig{ClusterSubnet: "10.244.0.0/24", NodeCIDRMaskSize: 16}
+	if _, err = k.PodCIDRStrategy(); err == nil {
+		t.Errorf("expected an error when the node mask size doesn't fit in the cluster subnet")
+	}
+}
+
 func TestGetOrderedKubeproxyConfigStringForPowershell(t *testing.T) {
 	cases := []struct {
 		name     string
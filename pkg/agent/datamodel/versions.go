@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/Azure/agentbaker/pkg/agent/kubeversion"
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
 )
@@ -491,11 +492,11 @@ func IsValidMinVersion(orchType, orchRelease, orchVersion, minVersion string) (b
 	return true, nil
 }
 
-// IsKubernetesVersionGe returns true if actualVersion is greater than or equal to version.
+// IsKubernetesVersionGe returns true if actualVersion is greater than or equal to version. actualVersion is parsed
+// leniently, and false is returned when either version can't be parsed, use kubeversion.GreaterOrEqual to get the error.
 func IsKubernetesVersionGe(actualVersion, version string) bool {
-	v1, _ := semver.Make(actualVersion)
-	v2, _ := semver.Make(version)
-	return v1.GE(v2)
+	ge, err := kubeversion.GreaterOrEqual(actualVersion, version)
+	return err == nil && ge
 }
 
 /*
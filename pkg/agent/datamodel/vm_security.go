@@ -0,0 +1,56 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package datamodel
+
+// VMSecurityType is the security type of the VMs of a pool.
+type VMSecurityType string
+
+const (
+	VMSecurityTypeStandard VMSecurityType = ""
+	// VMSecurityTypeTrustedLaunch VMs boot Gen2 images with secure boot and a vTPM.
+	VMSecurityTypeTrustedLaunch VMSecurityType = "TrustedLaunch"
+	// VMSecurityTypeConfidentialVM VMs additionally run on the AMD SEV-SNP or Intel TDX confidential VM sizes, with
+	// their guest state encrypted.
+	VMSecurityTypeConfidentialVM VMSecurityType = "ConfidentialVM"
+)
+
+// VMSecurityProfile is the security profile of the VMs of a pool, the securityProfile of their VM resource.
+type VMSecurityProfile struct {
+	SecurityType VMSecurityType `json:"securityType,omitempty"`
+	// EnableSecureBoot and EnableVTPM default to true with a security type, as on Azure.
+	EnableSecureBoot *bool `json:"enableSecureBoot,omitempty"`
+	EnableVTPM       *bool `json:"enableVTPM,omitempty"`
+	// EnableMeasuredBoot makes provisioning load an IMA policy measuring the executables, libraries and kernel modules
+	// the node runs into the vTPM.
+	EnableMeasuredBoot bool `json:"enableMeasuredBoot,omitempty"`
+}
+
+// GetVMSecurityType returns the security type of the VMs, standard when there is no profile.
+func (p *VMSecurityProfile) GetVMSecurityType() VMSecurityType {
+	if p == nil {
+		return VMSecurityTypeStandard
+	}
+	return p.SecurityType
+}
+
+// IsSecureBootEnabled returns whether the VMs boot with UEFI secure boot.
+func (p *VMSecurityProfile) IsSecureBootEnabled() bool {
+	if p.GetVMSecurityType() == VMSecurityTypeStandard {
+		return false
+	}
+	return p.EnableSecureBoot == nil || *p.EnableSecureBoot
+}
+
+// IsVTPMEnabled returns whether the VMs have a virtual TPM.
+func (p *VMSecurityProfile) IsVTPMEnabled() bool {
+	if p.GetVMSecurityType() == VMSecurityTypeStandard {
+		return false
+	}
+	return p.EnableVTPM == nil || *p.EnableVTPM
+}
+
+// IsMeasuredBootEnabled returns whether provisioning measures what the node runs into the vTPM.
+func (p *VMSecurityProfile) IsMeasuredBootEnabled() bool {
+	return p != nil && p.EnableMeasuredBoot
+}
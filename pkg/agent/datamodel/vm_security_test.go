@@ -0,0 +1,31 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package datamodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVMSecurityProfile(t *testing.T) {
+	var profile *VMSecurityProfile
+	assert.Equal(t, VMSecurityTypeStandard, profile.GetVMSecurityType())
+	assert.False(t, profile.IsSecureBootEnabled())
+	assert.False(t, profile.IsVTPMEnabled())
+	assert.False(t, profile.IsMeasuredBootEnabled())
+
+	profile = &VMSecurityProfile{SecurityType: VMSecurityTypeTrustedLaunch}
+	assert.True(t, profile.IsSecureBootEnabled())
+	assert.True(t, profile.IsVTPMEnabled())
+
+	disabled := false
+	profile.EnableSecureBoot = &disabled
+	assert.False(t, profile.IsSecureBootEnabled())
+	assert.True(t, profile.IsVTPMEnabled())
+
+	profile = &VMSecurityProfile{EnableVTPM: new(bool), EnableMeasuredBoot: true}
+	assert.False(t, profile.IsVTPMEnabled())
+	assert.True(t, profile.IsMeasuredBootEnabled())
+}
@@ -0,0 +1,73 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+	"text/template"
+	"time"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSingleLineContext(t *testing.T) {
+	generator := newTemplateGenerator(fstest.MapFS{
+		"linux/node.yml": &fstest.MapFile{Data: []byte("#cloud-config\n{{range .}}{{Cancel .}}\n{{end}}")},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var rendered []string
+	funcMap := template.FuncMap{"Cancel": func(line string) string {
+		rendered = append(rendered, line)
+		if line == "second" {
+			cancel()
+		}
+		return line
+	}}
+
+	_, err := generator.getSingleLine(ctx, "linux/node.yml", []string{"first", "second", "third"}, funcMap, true)
+	assert.ErrorIs(t, err, context.Canceled)
+	// the rendering stops at the first write once the context is done.
+	assert.Equal(t, []string{"first", "second"}, rendered)
+
+	_, err = generator.getSingleLine(ctx, "linux/node.yml", nil, funcMap, true)
+	assert.EqualError(t, err, "render linux/node.yml: context canceled")
+
+	line, err := generator.getSingleLine(context.Background(), "linux/node.yml", []string{"first"}, template.FuncMap{
+		"Cancel": func(line string) string { return line },
+	}, true)
+	require.NoError(t, err)
+	assert.Equal(t, "#cloud-config\nfirst\n", line)
+}
+
+func TestGetNodeBootstrappingContext(t *testing.T) {
+	newConfig := func() *datamodel.NodeBootstrappingConfiguration {
+		return &datamodel.NodeBootstrappingConfiguration{
+			ContainerService: &datamodel.ContainerService{Properties: &datamodel.Properties{
+				OrchestratorProfile: &datamodel.OrchestratorProfile{OrchestratorVersion: "1.29.2"},
+			}},
+			AgentPoolProfile: &datamodel.AgentPoolProfile{Name: "nodepool1"},
+		}
+	}
+	agentBaker, err := NewAgentBaker()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = agentBaker.GetNodeBootstrapping(ctx, newConfig())
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = agentBaker.WithTimeout(time.Nanosecond).GetNodeBootstrapping(context.Background(), newConfig())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	commands, err := makeAgentExtensionScriptCommands(ctx, &datamodel.ContainerService{}, &datamodel.AgentPoolProfile{
+		PreprovisionExtension: &datamodel.Extension{Name: "hello"},
+	})
+	assert.Empty(t, commands)
+	assert.EqualError(t, err, "make the commands of extension hello: context canceled")
+}
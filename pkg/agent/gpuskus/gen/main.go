@@ -0,0 +1,134 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+// Command gen generates skus.json from the VM sizes of the Azure resource SKUs API, as listed by
+// `az vm list-skus --resource-type virtualMachines --all -o json` on stdin. The API only reports the number of GPUs,
+// the GPU model, driver and topology of a size come from its family in gpuFamilies.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/agentbaker/pkg/agent/gpuskus"
+)
+
+type gpuFamily struct {
+	model         string
+	driver        string
+	windowsDriver string
+	topology      gpuskus.Topology
+}
+
+// gpuFamilies are the VM size families with NVIDIA GPUs, by lower case family name. NVv4 is AMD and NCv1/NCv2/ND are
+// retired, they have no entry. The sizes of the families missing here, such as the ones in preview, are added to
+// skus.json by hand and kept by -base.
+var gpuFamilies = map[string]gpuFamily{
+	"standardncasv3_t4family":    {"T4", gpuskus.DriverCUDA, gpuskus.DriverCUDA, gpuskus.TopologyPCIe},
+	"standardncsv3family":        {"V100", gpuskus.DriverCUDA, gpuskus.DriverCUDA, gpuskus.TopologyPCIe},
+	"standardncadsa100v4family":  {"A100", gpuskus.DriverCUDA, gpuskus.DriverCUDA, gpuskus.TopologyPCIe},
+	"standardndasv4_a100family":  {"A100", gpuskus.DriverCUDA, "", gpuskus.TopologyNVSwitch},
+	"standardndamsv4_a100family": {"A100 80GB", gpuskus.DriverCUDA, "", gpuskus.TopologyNVSwitch},
+	"standardndsh100v5family":    {"H100", gpuskus.DriverCUDA, "", gpuskus.TopologyNVSwitch},
+	"standardncadsh100v5family":  {"H100 NVL", gpuskus.DriverCUDA, "", gpuskus.TopologyPCIe},
+	"standardnvsv3family":        {"M60", gpuskus.DriverCUDA, gpuskus.DriverGRID, gpuskus.TopologyPCIe},
+	"standardnvadsa10v5family":   {"A10", gpuskus.DriverGRID, gpuskus.DriverGRID, gpuskus.TopologyPCIe},
+	"standardncadsa10v4family":   {"A10", gpuskus.DriverGRID, "", gpuskus.TopologyPCIe},
+}
+
+type resourceSKU struct {
+	Name         string `json:"name"`
+	Family       string `json:"family"`
+	ResourceType string `json:"resourceType"`
+	Capabilities []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"capabilities"`
+}
+
+func (r resourceSKU) gpus() (float64, bool) {
+	for _, capability := range r.Capabilities {
+		if capability.Name == "GPUs" {
+			gpus, err := strconv.ParseFloat(capability.Value, 64)
+			return gpus, err == nil && gpus > 0
+		}
+	}
+	return 0, false
+}
+
+func generate(in io.Reader, base *gpuskus.Catalog) ([]gpuskus.SKU, error) {
+	var resourceSKUs []resourceSKU
+	if err := json.NewDecoder(in).Decode(&resourceSKUs); err != nil {
+		return nil, fmt.Errorf("failed to decode resource SKUs: %w", err)
+	}
+	skus := map[string]gpuskus.SKU{}
+	// the sizes the API no longer lists in any region, or only lists in regions the caller has no access to, are kept.
+	for _, sku := range base.SKUs() {
+		skus[sku.Name] = sku
+	}
+	for _, r := range resourceSKUs {
+		family, ok := gpuFamilies[strings.ToLower(r.Family)]
+		if !ok || !strings.EqualFold(r.ResourceType, "virtualMachines") {
+			continue
+		}
+		name := strings.ToLower(r.Name)
+		gpus, ok := r.gpus()
+		if !ok {
+			// the API reports the partial GPUs of the sizes sharing a GPU inconsistently, the known count is kept.
+			existing, known := skus[name]
+			if !known {
+				continue
+			}
+			gpus = existing.GPUs
+		}
+		skus[name] = gpuskus.SKU{
+			Name:          name,
+			GPUModel:      family.model,
+			GPUs:          gpus,
+			Driver:        family.driver,
+			Topology:      family.topology,
+			WindowsDriver: family.windowsDriver,
+		}
+	}
+	sorted := make([]gpuskus.SKU, 0, len(skus))
+	for _, sku := range skus {
+		sorted = append(sorted, sku)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted, nil
+}
+
+func main() {
+	basePath := flag.String("base", "", "skus.json whose sizes are kept when the API doesn't list them")
+	flag.Parse()
+
+	base := &gpuskus.Catalog{}
+	if *basePath != "" {
+		data, err := os.ReadFile(*basePath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if base, err = gpuskus.Load(data); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	skus, err := generate(os.Stdin, base)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	data, err := json.MarshalIndent(skus, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s\n", data)
+}
@@ -0,0 +1,177 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+// Package gpuskus describes the NVIDIA GPUs of the Azure VM sizes: their model and count, the driver family agentbaker
+// installs and how the GPUs are interconnected. The sizes come from skus.json, which gen generates from the Azure
+// resource SKUs API, and from an optional override file for the sizes the API doesn't list, such as private SKUs.
+package gpuskus
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//go:generate sh -c "az vm list-skus --resource-type virtualMachines --all -o json | go run ./gen -base skus.json > skus.json.tmp && mv skus.json.tmp skus.json"
+
+// Driver families of the NVIDIA GPUs.
+const (
+	DriverCUDA = "cuda"
+	// DriverGRID is the GRID driver, which provides graphics APIs on top of CUDA.
+	DriverGRID = "grid"
+)
+
+// Topology is how the GPUs of a VM size are interconnected.
+type Topology string
+
+const (
+	TopologyPCIe   Topology = "pcie"
+	TopologyNVLink Topology = "nvlink"
+	// TopologyNVSwitch GPUs are connected through NVSwitches, which the fabric manager must configure before CUDA can
+	// use the GPUs.
+	TopologyNVSwitch Topology = "nvswitch"
+)
+
+// SKU is a VM size with NVIDIA GPUs.
+type SKU struct {
+	// Name is the lower case VM size, such as standard_nc24ads_a100_v4.
+	Name     string `json:"name"`
+	GPUModel string `json:"gpuModel"`
+	// GPUs is the number of GPUs, partial GPUs of the sizes sharing a GPU between several VMs are fractions.
+	GPUs     float64  `json:"gpus"`
+	Driver   string   `json:"driver"`
+	Topology Topology `json:"topology"`
+	// WindowsDriver is the driver family installed on Windows nodes, empty when the GPUs have no Windows driver.
+	WindowsDriver string `json:"windowsDriver,omitempty"`
+}
+
+// NeedsFabricManager returns whether the fabric manager must run on the node for CUDA to use the GPUs.
+func (s SKU) NeedsFabricManager() bool {
+	return s.Topology == TopologyNVSwitch
+}
+
+// Catalog is a set of GPU VM sizes.
+type Catalog struct {
+	skus map[string]SKU
+}
+
+// Load returns the catalog of a JSON array of SKUs.
+func Load(data []byte) (*Catalog, error) {
+	var skus []SKU
+	if err := json.Unmarshal(data, &skus); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal GPU SKUs: %w", err)
+	}
+	c := &Catalog{skus: make(map[string]SKU, len(skus))}
+	for _, sku := range skus {
+		sku.Name = strings.ToLower(sku.Name)
+		if err := sku.validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := c.skus[sku.Name]; ok {
+			return nil, fmt.Errorf("GPU SKU %s is listed more than once", sku.Name)
+		}
+		c.skus[sku.Name] = sku
+	}
+	return c, nil
+}
+
+func (s SKU) validate() error {
+	if s.Name == "" {
+		return errors.New("GPU SKU name is required")
+	}
+	if s.Driver != DriverCUDA && s.Driver != DriverGRID {
+		return fmt.Errorf("GPU SKU %s has unknown driver %q, it must be %s or %s", s.Name, s.Driver, DriverCUDA, DriverGRID)
+	}
+	if s.WindowsDriver != "" && s.WindowsDriver != DriverCUDA && s.WindowsDriver != DriverGRID {
+		return fmt.Errorf("GPU SKU %s has unknown Windows driver %q, it must be %s or %s", s.Name, s.WindowsDriver, DriverCUDA, DriverGRID)
+	}
+	switch s.Topology {
+	case TopologyPCIe, TopologyNVLink, TopologyNVSwitch:
+	default:
+		return fmt.Errorf("GPU SKU %s has unknown topology %q", s.Name, s.Topology)
+	}
+	if s.GPUs <= 0 {
+		return fmt.Errorf("GPU SKU %s must have GPUs", s.Name)
+	}
+	return nil
+}
+
+// Lookup returns the SKU of a VM size, whatever its case.
+func (c *Catalog) Lookup(vmSize string) (SKU, bool) {
+	sku, ok := c.skus[strings.ToLower(vmSize)]
+	return sku, ok
+}
+
+// SKUs returns the SKUs of the catalog sorted by name.
+func (c *Catalog) SKUs() []SKU {
+	skus := make([]SKU, 0, len(c.skus))
+	for _, sku := range c.skus {
+		skus = append(skus, sku)
+	}
+	sort.Slice(skus, func(i, j int) bool { return skus[i].Name < skus[j].Name })
+	return skus
+}
+
+// Merge returns a catalog with the SKUs of both catalogs, those of overrides replace the SKUs of c with the same name.
+func (c *Catalog) Merge(overrides *Catalog) *Catalog {
+	merged := &Catalog{skus: make(map[string]SKU, len(c.skus)+len(overrides.skus))}
+	for name, sku := range c.skus {
+		merged.skus[name] = sku
+	}
+	for name, sku := range overrides.skus {
+		merged.skus[name] = sku
+	}
+	return merged
+}
+
+//go:embed skus.json
+var defaultSKUs []byte
+
+//nolint:gochecknoglobals
+var (
+	defaultCatalog   *Catalog
+	defaultCatalogMu sync.RWMutex
+)
+
+//nolint:gochecknoinits
+func init() {
+	c, err := Load(defaultSKUs)
+	if err != nil {
+		panic(fmt.Sprintf("failed to load the embedded GPU SKUs: %v", err))
+	}
+	defaultCatalog = c
+}
+
+// Default returns the catalog of the embedded SKUs, along with the overrides loaded with LoadOverrides.
+func Default() *Catalog {
+	defaultCatalogMu.RLock()
+	defer defaultCatalogMu.RUnlock()
+	return defaultCatalog
+}
+
+// LoadOverrides adds the SKUs of the JSON file at path to the default catalog, replacing the embedded SKUs with the
+// same name. Services call it at startup to describe the sizes skus.json doesn't have yet.
+func LoadOverrides(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read GPU SKU overrides: %w", err)
+	}
+	overrides, err := Load(data)
+	if err != nil {
+		return fmt.Errorf("invalid GPU SKU overrides %s: %w", path, err)
+	}
+	defaultCatalogMu.Lock()
+	defer defaultCatalogMu.Unlock()
+	defaultCatalog = defaultCatalog.Merge(overrides)
+	return nil
+}
+
+// Lookup returns the SKU of a VM size in the default catalog.
+func Lookup(vmSize string) (SKU, bool) {
+	return Default().Lookup(vmSize)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package gpuskus
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultCatalog(t *testing.T) {
+	tests := []struct {
+		vmSize             string
+		driver             string
+		windowsDriver      string
+		needsFabricManager bool
+	}{
+		{vmSize: "Standard_NC6s_v3", driver: DriverCUDA, windowsDriver: DriverCUDA},
+		{vmSize: "standard_nc24ads_a100_v4", driver: DriverCUDA, windowsDriver: DriverCUDA},
+		{vmSize: "Standard_NV36adms_A10_V5", driver: DriverGRID, windowsDriver: DriverGRID},
+		{vmSize: "standard_nc8ads_a10_v4", driver: DriverGRID},
+		{vmSize: "standard_nv12s_v3", driver: DriverCUDA, windowsDriver: DriverGRID},
+		{vmSize: "standard_nd96asr_v4", driver: DriverCUDA, needsFabricManager: true},
+		{vmSize: "standard_nd96isr_h100_v5", driver: DriverCUDA, needsFabricManager: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.vmSize, func(t *testing.T) {
+			sku, ok := Lookup(tt.vmSize)
+			require.True(t, ok)
+			assert.Equal(t, tt.driver, sku.Driver)
+			assert.Equal(t, tt.windowsDriver, sku.WindowsDriver)
+			assert.Equal(t, tt.needsFabricManager, sku.NeedsFabricManager())
+		})
+	}
+
+	_, ok := Lookup("standard_d4s_v3")
+	assert.False(t, ok)
+}
+
+func TestSKUsJSONIsSorted(t *testing.T) {
+	// skus.json is kept in the output format of gen so that regenerating it only shows the sizes that changed.
+	data, err := json.MarshalIndent(Default().SKUs(), "", "  ")
+	require.NoError(t, err)
+	assert.Equal(t, string(data)+"\n", string(defaultSKUs))
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			data: `[{"name":"Standard_ND96isr_GB200_v6","gpuModel":"GB200","gpus":4,"driver":"cuda","topology":"nvlink"}]`,
+		},
+		{
+			name:    "unknown driver",
+			data:    `[{"name":"standard_nc6s_v3","gpuModel":"V100","gpus":1,"driver":"rocm","topology":"pcie"}]`,
+			wantErr: `GPU SKU standard_nc6s_v3 has unknown driver "rocm"`,
+		},
+		{
+			name:    "unknown topology",
+			data:    `[{"name":"standard_nc6s_v3","gpuModel":"V100","gpus":1,"driver":"cuda","topology":"infiniband"}]`,
+			wantErr: `GPU SKU standard_nc6s_v3 has unknown topology "infiniband"`,
+		},
+		{
+			name:    "no GPUs",
+			data:    `[{"name":"standard_nc6s_v3","gpuModel":"V100","driver":"cuda","topology":"pcie"}]`,
+			wantErr: "GPU SKU standard_nc6s_v3 must have GPUs",
+		},
+		{
+			name: "duplicate",
+			data: `[{"name":"standard_nc6s_v3","gpuModel":"V100","gpus":1,"driver":"cuda","topology":"pcie"},
+				{"name":"Standard_NC6s_v3","gpuModel":"V100","gpus":1,"driver":"cuda","topology":"pcie"}]`,
+			wantErr: "GPU SKU standard_nc6s_v3 is listed more than once",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Load([]byte(tt.data))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			sku, ok := c.Lookup("standard_nd96isr_gb200_v6")
+			require.True(t, ok)
+			assert.Equal(t, "standard_nd96isr_gb200_v6", sku.Name)
+			assert.Equal(t, TopologyNVLink, sku.Topology)
+		})
+	}
+}
+
+func TestLoadOverrides(t *testing.T) {
+	original := Default()
+	t.Cleanup(func() { defaultCatalog = original })
+
+	path := filepath.Join(t.TempDir(), "overrides.json")
+	overrides := `[
+		{"name":"standard_nd96xr_private_v1","gpuModel":"GB300","gpus":4,"driver":"cuda","topology":"nvswitch"},
+		{"name":"standard_nc8ads_a10_v4","gpuModel":"A10","gpus":1,"driver":"grid","topology":"pcie","windowsDriver":"grid"}
+	]`
+	require.NoError(t, os.WriteFile(path, []byte(overrides), 0600))
+	require.NoError(t, LoadOverrides(path))
+
+	sku, ok := Lookup("Standard_ND96xr_Private_v1")
+	require.True(t, ok)
+	assert.True(t, sku.NeedsFabricManager())
+	sku, ok = Lookup("standard_nc8ads_a10_v4")
+	require.True(t, ok)
+	assert.Equal(t, DriverGRID, sku.WindowsDriver)
+	_, ok = Lookup("standard_nc6s_v3")
+	assert.True(t, ok, "the embedded SKUs are kept")
+	_, ok = original.Lookup("standard_nd96xr_private_v1")
+	assert.False(t, ok, "the catalog returned by Default isn't modified")
+
+	require.NoError(t, os.WriteFile(path, []byte(`[{"name":"standard_nc6s_v3"}]`), 0600))
+	err := LoadOverrides(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid GPU SKU overrides")
+}
@@ -0,0 +1,121 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+)
+
+const (
+	kubeProxyModeKernelspace = "kernelspace"
+	kubeProxyModeUserspace   = "userspace"
+)
+
+// hybridPoolNetworkSettings are the cluster network settings every agent pool of a cluster must agree on: pods reach
+// services and pods of the other OS through them.
+//
+//nolint:gochecknoglobals
+var hybridPoolNetworkSettings = []struct {
+	name  string
+	value func(*datamodel.KubernetesConfig) string
+}{
+	{"service CIDR", func(k *datamodel.KubernetesConfig) string { return k.ServiceCIDR }},
+	{"DNS service IP", func(k *datamodel.KubernetesConfig) string { return k.DNSServiceIP }},
+	{"cluster subnet", func(k *datamodel.KubernetesConfig) string { return k.ClusterSubnet }},
+	{"network plugin", func(k *datamodel.KubernetesConfig) string { return k.NetworkPlugin }},
+	{"network plugin mode", func(k *datamodel.KubernetesConfig) string { return k.NetworkPluginMode }},
+	{"network policy", func(k *datamodel.KubernetesConfig) string { return k.NetworkPolicy }},
+}
+
+// windowsUnsupportedNetworkPlugins are the network plugins Windows nodes can't join a cluster with.
+//
+//nolint:gochecknoglobals
+var windowsUnsupportedNetworkPlugins = map[string]bool{
+	NetworkPluginKubenet:        true,
+	NetworkPluginFlannel:        true,
+	NetworkPluginCilium:         true,
+	datamodel.NetworkPluginNone: true,
+}
+
+// kubeProxyMode returns the --proxy-mode of kube-proxy on the nodes of the agent pool, or the default mode of its OS.
+func kubeProxyMode(config *datamodel.NodeBootstrappingConfiguration) string {
+	if mode := config.KubeproxyConfig["--proxy-mode"]; mode != "" {
+		return mode
+	}
+	if config.AgentPoolProfile.IsWindows() {
+		return kubeProxyModeKernelspace
+	}
+	return "iptables"
+}
+
+// validateHybridPools returns the inconsistencies between a Linux and a Windows agent pool of the same cluster which
+// break the workloads spanning both, in the order they are checked.
+func validateHybridPools(linuxConfig, windowsConfig *datamodel.NodeBootstrappingConfiguration) []string {
+	var issues []string
+	if linuxConfig.AgentPoolProfile.IsWindows() {
+		issues = append(issues, fmt.Sprintf("agent pool %s isn't a Linux pool", linuxConfig.AgentPoolProfile.Name))
+	}
+	if !windowsConfig.AgentPoolProfile.IsWindows() {
+		issues = append(issues, fmt.Sprintf("agent pool %s isn't a Windows pool", windowsConfig.AgentPoolProfile.Name))
+	}
+	if len(issues) > 0 {
+		// the other checks depend on the OS of each pool.
+		return issues
+	}
+
+	linuxKubernetesConfig := linuxConfig.ContainerService.Properties.OrchestratorProfile.KubernetesConfig
+	windowsKubernetesConfig := windowsConfig.ContainerService.Properties.OrchestratorProfile.KubernetesConfig
+	for _, setting := range hybridPoolNetworkSettings {
+		linuxValue, windowsValue := setting.value(linuxKubernetesConfig), setting.value(windowsKubernetesConfig)
+		if !strings.EqualFold(linuxValue, windowsValue) {
+			issues = append(issues, fmt.Sprintf("the %s of the Linux pool (%q) doesn't match the %s of the Windows pool (%q)",
+				setting.name, linuxValue, setting.name, windowsValue))
+		}
+	}
+	if plugin := strings.ToLower(windowsKubernetesConfig.NetworkPlugin); windowsUnsupportedNetworkPlugins[plugin] {
+		issues = append(issues, fmt.Sprintf("network plugin %s isn't supported on Windows nodes", plugin))
+	}
+	if strings.EqualFold(windowsKubernetesConfig.NetworkPolicy, NetworkPolicyCilium) {
+		issues = append(issues, "network policy cilium isn't supported on Windows nodes")
+	}
+
+	// Windows only implements kernelspace, Linux implements every mode but kernelspace. Both must also SNAT the same pod
+	// CIDR, or the traffic between the pods of each OS is masqueraded on one side only.
+	linuxMode, windowsMode := kubeProxyMode(linuxConfig), kubeProxyMode(windowsConfig)
+	if linuxMode == kubeProxyModeKernelspace || linuxMode == kubeProxyModeUserspace {
+		issues = append(issues, fmt.Sprintf("kube-proxy mode %s of the Linux pool isn't supported on Linux nodes", linuxMode))
+	}
+	if windowsMode != kubeProxyModeKernelspace {
+		issues = append(issues, fmt.Sprintf("kube-proxy mode %s of the Windows pool isn't supported on Windows nodes, it must be %s",
+			windowsMode, kubeProxyModeKernelspace))
+	}
+	linuxClusterCIDR, windowsClusterCIDR := linuxConfig.KubeproxyConfig["--cluster-cidr"], windowsConfig.KubeproxyConfig["--cluster-cidr"]
+	if linuxClusterCIDR != "" && windowsClusterCIDR != "" && linuxClusterCIDR != windowsClusterCIDR {
+		issues = append(issues, fmt.Sprintf("the kube-proxy cluster CIDR of the Linux pool (%s) doesn't match the one of the Windows pool (%s)",
+			linuxClusterCIDR, windowsClusterCIDR))
+	}
+	return issues
+}
+
+// ValidateHybridPools checks that a Linux and a Windows agent pool of the same cluster agree on the service CIDR, the
+// network plugin and kube-proxy, whose mismatches otherwise only surface once pods of both OSes talk to each other.
+func (agentBaker *agentBakerImpl) ValidateHybridPools(linuxConfig, windowsConfig *datamodel.NodeBootstrappingConfiguration) error {
+	for _, config := range []*datamodel.NodeBootstrappingConfiguration{linuxConfig, windowsConfig} {
+		if config == nil || config.AgentPoolProfile == nil || config.ContainerService == nil || config.ContainerService.Properties == nil ||
+			config.ContainerService.Properties.OrchestratorProfile == nil ||
+			config.ContainerService.Properties.OrchestratorProfile.KubernetesConfig == nil {
+			return errors.New("configs, their agent pool profile and kubernetes config can not be nil")
+		}
+	}
+	issues := validateHybridPools(linuxConfig, windowsConfig)
+	if len(issues) == 0 {
+		return nil
+	}
+	return fmt.Errorf("inconsistent hybrid agent pools %s and %s: %s", linuxConfig.AgentPoolProfile.Name,
+		windowsConfig.AgentPoolProfile.Name, strings.Join(issues, "; "))
+}
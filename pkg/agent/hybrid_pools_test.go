@@ -0,0 +1,106 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateHybridPools(t *testing.T) {
+	newConfig := func(name string, osType datamodel.OSType) *datamodel.NodeBootstrappingConfiguration {
+		return &datamodel.NodeBootstrappingConfiguration{
+			ContainerService: &datamodel.ContainerService{Properties: &datamodel.Properties{
+				OrchestratorProfile: &datamodel.OrchestratorProfile{KubernetesConfig: &datamodel.KubernetesConfig{
+					ServiceCIDR:       "10.0.0.0/16",
+					DNSServiceIP:      "10.0.0.10",
+					ClusterSubnet:     "10.244.0.0/16",
+					NetworkPlugin:     NetworkPluginAzure,
+					NetworkPluginMode: "overlay",
+				}},
+			}},
+			AgentPoolProfile: &datamodel.AgentPoolProfile{Name: name, OSType: osType},
+		}
+	}
+	tests := []struct {
+		name    string
+		mutate  func(linux, windows *datamodel.NodeBootstrappingConfiguration)
+		wantErr string
+	}{
+		{
+			name:   "consistent",
+			mutate: func(_, _ *datamodel.NodeBootstrappingConfiguration) {},
+		},
+		{
+			name: "consistent kube-proxy config",
+			mutate: func(linux, windows *datamodel.NodeBootstrappingConfiguration) {
+				linux.KubeproxyConfig = map[string]string{"--proxy-mode": "ipvs", "--cluster-cidr": "10.244.0.0/16"}
+				windows.KubeproxyConfig = map[string]string{"--proxy-mode": "kernelspace", "--cluster-cidr": "10.244.0.0/16"}
+			},
+		},
+		{
+			name: "swapped pools",
+			mutate: func(linux, windows *datamodel.NodeBootstrappingConfiguration) {
+				linux.AgentPoolProfile.OSType = datamodel.Windows
+				windows.AgentPoolProfile.OSType = datamodel.Linux
+			},
+			wantErr: "inconsistent hybrid agent pools nodepool1 and npwin: agent pool nodepool1 isn't a Linux pool; " +
+				"agent pool npwin isn't a Windows pool",
+		},
+		{
+			name: "mismatched service CIDR",
+			mutate: func(_, windows *datamodel.NodeBootstrappingConfiguration) {
+				windows.ContainerService.Properties.OrchestratorProfile.KubernetesConfig.ServiceCIDR = "172.16.0.0/16"
+			},
+			wantErr: `inconsistent hybrid agent pools nodepool1 and npwin: the service CIDR of the Linux pool ("10.0.0.0/16") ` +
+				`doesn't match the service CIDR of the Windows pool ("172.16.0.0/16")`,
+		},
+		{
+			name: "kubenet",
+			mutate: func(linux, windows *datamodel.NodeBootstrappingConfiguration) {
+				linux.ContainerService.Properties.OrchestratorProfile.KubernetesConfig.NetworkPlugin = NetworkPluginKubenet
+				windows.ContainerService.Properties.OrchestratorProfile.KubernetesConfig.NetworkPlugin = NetworkPluginKubenet
+			},
+			wantErr: "inconsistent hybrid agent pools nodepool1 and npwin: network plugin kubenet isn't supported on Windows nodes",
+		},
+		{
+			name: "cilium",
+			mutate: func(linux, windows *datamodel.NodeBootstrappingConfiguration) {
+				linux.ContainerService.Properties.OrchestratorProfile.KubernetesConfig.NetworkPolicy = NetworkPolicyCilium
+				windows.ContainerService.Properties.OrchestratorProfile.KubernetesConfig.NetworkPolicy = NetworkPolicyCilium
+			},
+			wantErr: "inconsistent hybrid agent pools nodepool1 and npwin: network policy cilium isn't supported on Windows nodes",
+		},
+		{
+			name: "kube-proxy modes",
+			mutate: func(linux, windows *datamodel.NodeBootstrappingConfiguration) {
+				linux.KubeproxyConfig = map[string]string{"--proxy-mode": "kernelspace", "--cluster-cidr": "10.244.0.0/16"}
+				windows.KubeproxyConfig = map[string]string{"--proxy-mode": "ipvs", "--cluster-cidr": "10.245.0.0/16"}
+			},
+			wantErr: "inconsistent hybrid agent pools nodepool1 and npwin: kube-proxy mode kernelspace of the Linux pool isn't supported on Linux nodes; " +
+				"kube-proxy mode ipvs of the Windows pool isn't supported on Windows nodes, it must be kernelspace; " +
+				"the kube-proxy cluster CIDR of the Linux pool (10.244.0.0/16) doesn't match the one of the Windows pool (10.245.0.0/16)",
+		},
+	}
+	agentBaker, err := NewAgentBaker()
+	require.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			linux, windows := newConfig("nodepool1", datamodel.Linux), newConfig("npwin", datamodel.Windows)
+			tt.mutate(linux, windows)
+			err := agentBaker.ValidateHybridPools(linux, windows)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+
+	assert.EqualError(t, agentBaker.ValidateHybridPools(newConfig("nodepool1", datamodel.Linux), nil),
+		"configs, their agent pool profile and kubernetes config can not be nil")
+}
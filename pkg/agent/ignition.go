@@ -0,0 +1,241 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ignitionVersion = "3.3.0"
+	// ignitionRunCmdScript runs the bootcmd and runcmd commands of the cloud-config, which Ignition has no equivalent
+	// of, from ignitionRunCmdUnit.
+	ignitionRunCmdScript = "/opt/azure/containers/ignition-runcmd.sh"
+	ignitionRunCmdUnit   = "aks-ignition-runcmd.service"
+)
+
+// cloudConfig is the subset of cloud-config the node custom data uses.
+type cloudConfig struct {
+	WriteFiles []cloudConfigFile `yaml:"write_files"`
+	BootCmd    []yaml.Node       `yaml:"bootcmd"`
+	RunCmd     []yaml.Node       `yaml:"runcmd"`
+}
+
+type cloudConfigFile struct {
+	Path        string    `yaml:"path"`
+	Permissions string    `yaml:"permissions"`
+	Owner       string    `yaml:"owner"`
+	Encoding    string    `yaml:"encoding"`
+	Content     yaml.Node `yaml:"content"`
+	Append      bool      `yaml:"append"`
+	Source      struct {
+		URI string `yaml:"uri"`
+	} `yaml:"source"`
+}
+
+type ignitionConfig struct {
+	Ignition struct {
+		Version string `json:"version"`
+	} `json:"ignition"`
+	Storage struct {
+		Files []ignitionFile `json:"files,omitempty"`
+	} `json:"storage"`
+	Systemd struct {
+		Units []ignitionUnit `json:"units,omitempty"`
+	} `json:"systemd"`
+}
+
+type ignitionFile struct {
+	Path      string             `json:"path"`
+	Mode      *int               `json:"mode,omitempty"`
+	Overwrite *bool              `json:"overwrite,omitempty"`
+	User      *ignitionName      `json:"user,omitempty"`
+	Group     *ignitionName      `json:"group,omitempty"`
+	Contents  *ignitionResource  `json:"contents,omitempty"`
+	Append    []ignitionResource `json:"append,omitempty"`
+}
+
+type ignitionName struct {
+	Name string `json:"name"`
+}
+
+type ignitionResource struct {
+	Source      string `json:"source"`
+	Compression string `json:"compression,omitempty"`
+}
+
+type ignitionUnit struct {
+	Name     string `json:"name"`
+	Enabled  bool   `json:"enabled"`
+	Contents string `json:"contents"`
+}
+
+// cloudInitToIgnition renders the node custom data cloud-config as an Ignition config, for the immutable distros
+// which are provisioned by Ignition instead of cloud-init. Files are written with the same paths, permissions and
+// owners, and the bootcmd and runcmd commands are run by a systemd unit once the files are written.
+func cloudInitToIgnition(customData string) (string, error) {
+	var keys map[string]yaml.Node
+	if err := yaml.Unmarshal([]byte(customData), &keys); err != nil {
+		return "", fmt.Errorf("parse cloud-config: %w", err)
+	}
+	var unsupported []string
+	for key := range keys {
+		if key != "write_files" && key != "bootcmd" && key != "runcmd" {
+			unsupported = append(unsupported, key)
+		}
+	}
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		return "", fmt.Errorf("cloud-config keys %s have no Ignition equivalent", strings.Join(unsupported, ", "))
+	}
+	var cloud cloudConfig
+	if err := yaml.Unmarshal([]byte(customData), &cloud); err != nil {
+		return "", fmt.Errorf("parse cloud-config: %w", err)
+	}
+
+	var config ignitionConfig
+	config.Ignition.Version = ignitionVersion
+	for _, file := range cloud.WriteFiles {
+		ignition, err := ignitionFileFromCloudConfig(file)
+		if err != nil {
+			return "", fmt.Errorf("write_files %s: %w", file.Path, err)
+		}
+		config.Storage.Files = append(config.Storage.Files, ignition)
+	}
+
+	var commands []string
+	for _, section := range [][]yaml.Node{cloud.BootCmd, cloud.RunCmd} {
+		for _, node := range section {
+			command, err := cloudConfigCommand(&node)
+			if err != nil {
+				return "", err
+			}
+			commands = append(commands, command)
+		}
+	}
+	if len(commands) > 0 {
+		script := "#!/bin/bash\nset -e\n" + strings.Join(commands, "\n") + "\n"
+		mode := 0o744
+		config.Storage.Files = append(config.Storage.Files, ignitionFile{
+			Path:     ignitionRunCmdScript,
+			Mode:     &mode,
+			Contents: &ignitionResource{Source: dataURL([]byte(script))},
+		})
+		config.Systemd.Units = append(config.Systemd.Units, ignitionUnit{
+			Name:    ignitionRunCmdUnit,
+			Enabled: true,
+			Contents: strings.Join([]string{
+				"[Unit]",
+				"Description=AKS cloud-config commands",
+				"After=network-online.target",
+				"Wants=network-online.target",
+				"",
+				"[Service]",
+				"Type=oneshot",
+				"RemainAfterExit=yes",
+				"ExecStart=" + ignitionRunCmdScript,
+				"",
+				"[Install]",
+				"WantedBy=multi-user.target",
+			}, "\n") + "\n",
+		})
+	}
+
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	// the commands and unit contents are kept readable.
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(config); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func ignitionFileFromCloudConfig(file cloudConfigFile) (ignitionFile, error) {
+	ignition := ignitionFile{Path: file.Path}
+	var resource ignitionResource
+	if file.Source.URI != "" {
+		resource.Source = file.Source.URI
+	} else {
+		content, compressed, err := decodeCloudConfigContent(file)
+		if err != nil {
+			return ignition, err
+		}
+		resource.Source = dataURL(content)
+		if compressed {
+			// Ignition decompresses the content itself.
+			resource.Compression = "gzip"
+		}
+	}
+	if file.Append {
+		ignition.Append = []ignitionResource{resource}
+	} else {
+		overwrite := true
+		ignition.Overwrite = &overwrite
+		ignition.Contents = &resource
+	}
+
+	if file.Permissions != "" {
+		mode, err := strconv.ParseInt(file.Permissions, 8, 32)
+		if err != nil {
+			return ignition, fmt.Errorf("invalid permissions %q", file.Permissions)
+		}
+		m := int(mode)
+		ignition.Mode = &m
+	}
+	if file.Owner != "" {
+		user, group, _ := strings.Cut(file.Owner, ":")
+		ignition.User = &ignitionName{Name: user}
+		if group != "" {
+			ignition.Group = &ignitionName{Name: group}
+		}
+	}
+	return ignition, nil
+}
+
+// decodeCloudConfigContent returns the content of the file, base64 decoded, and whether it is gzipped.
+func decodeCloudConfigContent(file cloudConfigFile) ([]byte, bool, error) {
+	content := []byte(file.Content.Value)
+	encoding := strings.ToLower(file.Encoding)
+	if file.Content.Tag == "!!binary" || strings.Contains(encoding, "b64") || strings.Contains(encoding, "base64") {
+		decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(file.Content.Value), ""))
+		if err != nil {
+			return nil, false, fmt.Errorf("decode content: %w", err)
+		}
+		content = decoded
+	}
+	return content, strings.HasPrefix(encoding, "gz"), nil
+}
+
+// cloudConfigCommand returns the shell command of a bootcmd or runcmd entry, which is either a command line or a
+// list of arguments.
+func cloudConfigCommand(node *yaml.Node) (string, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value, nil
+	case yaml.SequenceNode:
+		var args []string
+		if err := node.Decode(&args); err != nil {
+			return "", fmt.Errorf("invalid command at line %d: %w", node.Line, err)
+		}
+		for i, arg := range args {
+			args[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		return strings.Join(args, " "), nil
+	default:
+		return "", fmt.Errorf("invalid command at line %d", node.Line)
+	}
+}
+
+func dataURL(content []byte) string {
+	return "data:;base64," + base64.StdEncoding.EncodeToString(content)
+}
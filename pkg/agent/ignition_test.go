@@ -0,0 +1,104 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudInitToIgnition(t *testing.T) {
+	gzipped := getBase64EncodedGzippedCustomScriptFromStr("#!/bin/bash\necho provision\n")
+	customData := `#cloud-config
+
+write_files:
+- path: /opt/azure/containers/provision_start.sh
+  permissions: "0744"
+  encoding: gzip
+  owner: root
+  content: !!binary |
+    ` + gzipped + `
+- path: /etc/systemd/system/kubelet.service.d/10-aks.conf
+  permissions: "0600"
+  owner: root:root
+  content: |
+    [Service]
+    Environment=KUBELET_FLAGS=--max-pods=30
+- path: /etc/environment
+  append: true
+  content: HTTP_PROXY=http://proxy
+runcmd:
+- systemctl daemon-reload
+- [sh, -c, "echo 'done'"]
+`
+
+	ignition, err := cloudInitToIgnition(customData)
+	require.NoError(t, err)
+	var config ignitionConfig
+	require.NoError(t, json.Unmarshal([]byte(ignition), &config))
+
+	assert.Equal(t, "3.3.0", config.Ignition.Version)
+	require.Len(t, config.Storage.Files, 4)
+	provision := config.Storage.Files[0]
+	assert.Equal(t, "/opt/azure/containers/provision_start.sh", provision.Path)
+	assert.Equal(t, 0o744, *provision.Mode)
+	assert.Equal(t, "root", provision.User.Name)
+	assert.Nil(t, provision.Group)
+	assert.Equal(t, "gzip", provision.Contents.Compression)
+	assert.Equal(t, "data:;base64,"+gzipped, provision.Contents.Source)
+
+	dropIn := config.Storage.Files[1]
+	assert.Equal(t, 0o600, *dropIn.Mode)
+	assert.Equal(t, "root", dropIn.Group.Name)
+	assert.Equal(t, "data:;base64,"+base64.StdEncoding.EncodeToString([]byte("[Service]\nEnvironment=KUBELET_FLAGS=--max-pods=30\n")),
+		dropIn.Contents.Source)
+
+	environment := config.Storage.Files[2]
+	assert.Nil(t, environment.Contents)
+	assert.Equal(t, []ignitionResource{{Source: "data:;base64," + base64.StdEncoding.EncodeToString([]byte("HTTP_PROXY=http://proxy"))}},
+		environment.Append)
+
+	runCmd := config.Storage.Files[3]
+	assert.Equal(t, ignitionRunCmdScript, runCmd.Path)
+	assert.Equal(t, "data:;base64,"+base64.StdEncoding.EncodeToString([]byte("#!/bin/bash\nset -e\nsystemctl daemon-reload\n'sh' '-c' 'echo '\\''done'\\'''\n")),
+		runCmd.Contents.Source)
+	require.Len(t, config.Systemd.Units, 1)
+	assert.Equal(t, ignitionRunCmdUnit, config.Systemd.Units[0].Name)
+	assert.True(t, config.Systemd.Units[0].Enabled)
+	assert.Contains(t, config.Systemd.Units[0].Contents, "ExecStart="+ignitionRunCmdScript)
+}
+
+func TestCloudInitToIgnition_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		customData string
+		wantErr    string
+	}{
+		{
+			name:       "unsupported keys",
+			customData: "#cloud-config\nmounts: []\npackages: [curl]\nruncmd: [ls]\n",
+			wantErr:    "cloud-config keys mounts, packages have no Ignition equivalent",
+		},
+		{
+			name:       "invalid permissions",
+			customData: "#cloud-config\nwrite_files:\n- path: /etc/a\n  permissions: \"rwx\"\n  content: a\n",
+			wantErr:    `write_files /etc/a: invalid permissions "rwx"`,
+		},
+		{
+			name:       "invalid base64",
+			customData: "#cloud-config\nwrite_files:\n- path: /etc/a\n  encoding: b64\n  content: \"!!\"\n",
+			wantErr:    "write_files /etc/a: decode content",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := cloudInitToIgnition(tt.customData)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
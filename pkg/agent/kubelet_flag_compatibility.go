@@ -0,0 +1,104 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+)
+
+// kubeletFlagChange is a kubelet flag which the kubelet refuses to start with from a Kubernetes version on.
+type kubeletFlagChange struct {
+	flag      string
+	removedIn string
+	// translate returns the flag and value replacing the flag, which is dropped when translate is nil.
+	translate func(value string) (string, string)
+}
+
+// kubeletFeatureGateChange is a feature gate which the kubelet refuses to start with from a Kubernetes version on.
+type kubeletFeatureGateChange struct {
+	gate      string
+	removedIn string
+}
+
+// kubeletFlagCompatibility lists the kubelet flags removed from the kubelet, which older node configs still set.
+//
+//nolint:gochecknoglobals
+var kubeletFlagCompatibility = []kubeletFlagChange{
+	// dockershim was removed in 1.24, along with the flags configuring it.
+	{flag: "--cni-bin-dir", removedIn: "1.24.0"},
+	{flag: "--cni-cache-dir", removedIn: "1.24.0"},
+	{flag: "--cni-conf-dir", removedIn: "1.24.0"},
+	{flag: "--docker-endpoint", removedIn: "1.24.0"},
+	{flag: "--image-pull-progress-deadline", removedIn: "1.24.0"},
+	{flag: "--network-plugin", removedIn: "1.24.0"},
+	{flag: "--network-plugin-mtu", removedIn: "1.24.0"},
+	{flag: "--non-masquerade-cidr", removedIn: "1.24.0"},
+	{flag: "--dynamic-config-dir", removedIn: "1.24.0"},
+	{flag: "--container-runtime", removedIn: "1.27.0"},
+	// the in-tree cloud providers are disabled from 1.29, the cloud-node-manager initializes the node instead.
+	{flag: "--cloud-provider", removedIn: "1.29.0", translate: func(value string) (string, string) {
+		if value == "" || value == "external" {
+			return "--cloud-provider", value
+		}
+		return "--cloud-provider", "external"
+	}},
+	// the in-tree ACR credential provider was removed in 1.30, --image-credential-provider-config configures the
+	// out-of-tree one.
+	{flag: "--azure-container-registry-config", removedIn: "1.30.0"},
+	{flag: "--keep-terminated-pod-volumes", removedIn: "1.31.0"},
+}
+
+// kubeletFeatureGateCompatibility lists the feature gates removed from the kubelet, which older node configs still set.
+//
+//nolint:gochecknoglobals
+var kubeletFeatureGateCompatibility = []kubeletFeatureGateChange{
+	{gate: "DisableAcceleratorUsageMetrics", removedIn: "1.25.0"},
+	{gate: "DynamicKubeletConfig", removedIn: "1.26.0"},
+	{gate: "CSIMigration", removedIn: "1.27.0"},
+}
+
+// applyKubeletFlagCompatibility drops or translates the kubelet flags and feature gates which the kubelet version of
+// the node refuses to start with, and returns a warning for every change.
+func applyKubeletFlagCompatibility(config *datamodel.NodeBootstrappingConfiguration) []string {
+	kubeletFlags := config.KubeletConfig
+	if kubeletFlags == nil {
+		return nil
+	}
+	version := config.ContainerService.Properties.OrchestratorProfile.OrchestratorVersion
+	var warnings []string
+	for _, change := range kubeletFlagCompatibility {
+		value, ok := kubeletFlags[change.flag]
+		if !ok || !IsKubernetesVersionGe(version, change.removedIn) {
+			continue
+		}
+		if change.translate == nil {
+			delete(kubeletFlags, change.flag)
+			warnings = append(warnings, fmt.Sprintf("dropped kubelet flag %s, which was removed in Kubernetes %s", change.flag,
+				change.removedIn))
+			continue
+		}
+		flag, translated := change.translate(value)
+		if flag == change.flag && translated == value {
+			continue
+		}
+		delete(kubeletFlags, change.flag)
+		kubeletFlags[flag] = translated
+		warnings = append(warnings, fmt.Sprintf("replaced kubelet flag %s=%s with %s=%s, which is required from Kubernetes %s",
+			change.flag, value, flag, translated, change.removedIn))
+	}
+
+	gates := strKeyValToMapBool(kubeletFlags["--feature-gates"], ",", "=")
+	for _, change := range kubeletFeatureGateCompatibility {
+		if _, ok := gates[change.gate]; ok && IsKubernetesVersionGe(version, change.removedIn) {
+			kubeletFlags["--feature-gates"] = removeFeatureGateString(kubeletFlags["--feature-gates"], change.gate)
+			warnings = append(warnings, fmt.Sprintf("dropped kubelet feature gate %s, which was removed in Kubernetes %s",
+				change.gate, change.removedIn))
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyKubeletFlagCompatibility(t *testing.T) {
+	flags := func() map[string]string {
+		return map[string]string{
+			"--cloud-provider":                   "azure",
+			"--azure-container-registry-config":  "/etc/kubernetes/azure.json",
+			"--container-runtime":                "remote",
+			"--feature-gates":                    "CSIMigration=true,RotateKubeletServerCertificate=true",
+			"--max-pods":                         "30",
+			"--container-runtime-endpoint":       "unix:///run/containerd/containerd.sock",
+			"--image-credential-provider-config": "/var/lib/kubelet/credential-provider-config.yaml",
+		}
+	}
+	tests := []struct {
+		name         string
+		version      string
+		wantFlags    map[string]string
+		wantWarnings []string
+	}{
+		{
+			name:      "supported",
+			version:   "1.26.3",
+			wantFlags: flags(),
+		},
+		{
+			name:    "removed",
+			version: "1.30.0",
+			wantFlags: map[string]string{
+				"--cloud-provider":                   "external",
+				"--feature-gates":                    "RotateKubeletServerCertificate=true",
+				"--max-pods":                         "30",
+				"--container-runtime-endpoint":       "unix:///run/containerd/containerd.sock",
+				"--image-credential-provider-config": "/var/lib/kubelet/credential-provider-config.yaml",
+			},
+			wantWarnings: []string{
+				"dropped kubelet feature gate CSIMigration, which was removed in Kubernetes 1.27.0",
+				"dropped kubelet flag --azure-container-registry-config, which was removed in Kubernetes 1.30.0",
+				"dropped kubelet flag --container-runtime, which was removed in Kubernetes 1.27.0",
+				"replaced kubelet flag --cloud-provider=azure with --cloud-provider=external, which is required from Kubernetes 1.29.0",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &datamodel.NodeBootstrappingConfiguration{
+				ContainerService: &datamodel.ContainerService{Properties: &datamodel.Properties{
+					OrchestratorProfile: &datamodel.OrchestratorProfile{OrchestratorVersion: tt.version},
+				}},
+				KubeletConfig: flags(),
+			}
+			warnings := applyKubeletFlagCompatibility(config)
+			assert.Equal(t, tt.wantWarnings, warnings)
+			assert.Equal(t, tt.wantFlags, config.KubeletConfig)
+		})
+	}
+}
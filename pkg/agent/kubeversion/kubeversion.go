@@ -0,0 +1,59 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+// Package kubeversion parses and compares Kubernetes versions. Pre-release versions sort before their release, so
+// 1.30.0-rc.1 is lower than 1.30.0, and build metadata is ignored, so v1.29.0+build equals 1.29.0.
+package kubeversion
+
+import (
+	"fmt"
+
+	"github.com/blang/semver"
+)
+
+// Parse parses a strict semantic version: major.minor.patch with an optional pre-release and build metadata.
+func Parse(version string) (semver.Version, error) {
+	v, err := semver.Parse(version)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("invalid version %q: %w", version, err)
+	}
+	return v, nil
+}
+
+// ParseLenient parses a version the way users write Kubernetes versions: surrounding spaces and a v prefix are
+// ignored and a missing minor or patch version is 0, so v1.28 is 1.28.0. A short version can't have a pre-release or
+// build metadata.
+func ParseLenient(version string) (semver.Version, error) {
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return semver.Version{}, fmt.Errorf("invalid version %q: %w", version, err)
+	}
+	return v, nil
+}
+
+// Compare returns -1, 0 or 1 when the strict version a is lower than, equal to or greater than b.
+func Compare(a, b string) (int, error) {
+	va, err := Parse(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := Parse(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.Compare(vb), nil
+}
+
+// GreaterOrEqual returns whether actual is greater than or equal to minimum. actual is parsed leniently as it comes
+// from the configuration, minimum must be strict.
+func GreaterOrEqual(actual, minimum string) (bool, error) {
+	va, err := ParseLenient(actual)
+	if err != nil {
+		return false, err
+	}
+	vm, err := Parse(minimum)
+	if err != nil {
+		return false, err
+	}
+	return va.GTE(vm), nil
+}
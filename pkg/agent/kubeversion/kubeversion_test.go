@@ -0,0 +1,105 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package kubeversion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		version        string
+		strict         string
+		lenient        string
+		strictErr      bool
+		lenientErr     bool
+		wantPreRelease bool
+	}{
+		{version: "1.29.2", strict: "1.29.2", lenient: "1.29.2"},
+		{version: "1.30.0-rc.1", strict: "1.30.0-rc.1", lenient: "1.30.0-rc.1", wantPreRelease: true},
+		{version: "1.29.0+build.1", strict: "1.29.0+build.1", lenient: "1.29.0+build.1"},
+		{version: "v1.29.0+build", strictErr: true, lenient: "1.29.0+build"},
+		{version: "1.28", strictErr: true, lenient: "1.28.0"},
+		{version: " 1 ", strictErr: true, lenient: "1.0.0"},
+		{version: "1.28-rc.1", strictErr: true, lenientErr: true},
+		{version: "", strictErr: true, lenientErr: true},
+		{version: "latest", strictErr: true, lenientErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			strict, err := Parse(tt.version)
+			if tt.strictErr {
+				assert.ErrorContains(t, err, "invalid version")
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.strict, strict.String())
+				assert.Equal(t, tt.wantPreRelease, len(strict.Pre) > 0)
+			}
+			lenient, err := ParseLenient(tt.version)
+			if tt.lenientErr {
+				assert.ErrorContains(t, err, "invalid version")
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.lenient, lenient.String())
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		want    int
+		wantErr string
+	}{
+		{a: "1.29.0", b: "1.28.5", want: 1},
+		{a: "1.30.0-rc.1", b: "1.30.0", want: -1},
+		{a: "1.30.0-alpha.2", b: "1.30.0-alpha.1", want: 1},
+		{a: "1.29.0+build.1", b: "1.29.0", want: 0},
+		{a: "1.29", b: "1.29.0", wantErr: `invalid version "1.29"`},
+		{a: "1.29.0", b: "x", wantErr: `invalid version "x"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			got, err := Compare(tt.a, tt.b)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGreaterOrEqual(t *testing.T) {
+	tests := []struct {
+		actual, minimum string
+		want            bool
+		wantErr         string
+	}{
+		{actual: "1.30.1", minimum: "1.30.0", want: true},
+		{actual: "1.29.9", minimum: "1.30.0", want: false},
+		{actual: "1.30", minimum: "1.30.0", want: true},
+		{actual: "v1.30.0+build", minimum: "1.30.0", want: true},
+		{actual: "1.30.0-rc.1", minimum: "1.30.0", want: false},
+		{actual: "1.30.0-rc.1", minimum: "1.29.0", want: true},
+		{actual: "", minimum: "1.30.0", wantErr: `invalid version ""`},
+		{actual: "1.30.0", minimum: "1.30", wantErr: `invalid version "1.30"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.actual+" "+tt.minimum, func(t *testing.T) {
+			got, err := GreaterOrEqual(tt.actual, tt.minimum)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+)
+
+// getNodeBootstrappingVMExtensions returns the custom script extension running the CSE command.
+func getNodeBootstrappingVMExtensions(config *datamodel.NodeBootstrappingConfiguration, cse string) []datamodel.VMExtension {
+	if cse == "" {
+		return nil
+	}
+	name, resourceType := getCSEExtensionResource(config)
+	extension := datamodel.VMExtension{
+		Name:                    name,
+		ResourceType:            resourceType,
+		Publisher:               "Microsoft.Azure.Extensions",
+		Type:                    "CustomScript",
+		TypeHandlerVersion:      "2.0",
+		AutoUpgradeMinorVersion: true,
+		ProtectedSettings:       map[string]string{"commandToExecute": cse},
+	}
+	if config.AgentPoolProfile.IsWindows() {
+		extension.Publisher = "Microsoft.Compute"
+		extension.Type = "CustomScriptExtension"
+		extension.TypeHandlerVersion = "1.10"
+	}
+	return []datamodel.VMExtension{extension}
+}
+
+// getNodeBootstrappingArtifacts returns the container images and files the bootstrapping data references, in a
+// stable order. Empty references aren't downloaded and are left out.
+func getNodeBootstrappingArtifacts(config *datamodel.NodeBootstrappingConfiguration) []datamodel.ArtifactDependency {
+	var artifacts []datamodel.ArtifactDependency
+	add := func(artifactType datamodel.ArtifactType, name, reference string) {
+		if reference != "" {
+			artifacts = append(artifacts, datamodel.ArtifactDependency{Type: artifactType, Name: name, Reference: reference})
+		}
+	}
+	k8sComponents := config.K8sComponents
+	if k8sComponents == nil {
+		k8sComponents = &datamodel.K8sComponents{}
+	}
+	kubernetesConfig := config.ContainerService.Properties.OrchestratorProfile.KubernetesConfig
+	if kubernetesConfig == nil {
+		kubernetesConfig = &datamodel.KubernetesConfig{}
+	}
+
+	add(datamodel.ArtifactTypeImage, "pause", k8sComponents.PodInfraContainerImageURL)
+	if config.AgentPoolProfile.IsWindows() {
+		add(datamodel.ArtifactTypeURL, "kubernetes-binaries", k8sComponents.WindowsPackageURL)
+		add(datamodel.ArtifactTypeURL, "azure-acr-credential-provider", k8sComponents.WindowsCredentialProviderURL)
+		add(datamodel.ArtifactTypeURL, "azure-cni", kubernetesConfig.GetAzureCNIURLWindows(config.CloudSpecConfig))
+		add(datamodel.ArtifactTypeURL, "containerd", kubernetesConfig.WindowsContainerdURL)
+		add(datamodel.ArtifactTypeURL, "windows-sdn-plugin", kubernetesConfig.WindowsSdnPluginURL)
+		return artifacts
+	}
+
+	add(datamodel.ArtifactTypeImage, "kube-proxy", kubernetesConfig.CustomKubeProxyImage)
+	if kubernetesConfig.CustomKubeBinaryURL != "" {
+		add(datamodel.ArtifactTypeURL, "kubernetes-binaries", kubernetesConfig.CustomKubeBinaryURL)
+	} else {
+		add(datamodel.ArtifactTypeURL, "kubernetes-binaries", k8sComponents.LinuxPrivatePackageURL)
+	}
+	add(datamodel.ArtifactTypeURL, "azure-acr-credential-provider", k8sComponents.LinuxCredentialProviderURL)
+	arm64 := config.GetArch() == datamodel.ArchARM64
+	if config.ContainerService.Properties.OrchestratorProfile.IsAzureCNI() {
+		if arm64 {
+			add(datamodel.ArtifactTypeURL, "azure-cni", kubernetesConfig.GetAzureCNIURLARM64Linux(config.CloudSpecConfig))
+		} else {
+			add(datamodel.ArtifactTypeURL, "azure-cni", kubernetesConfig.GetAzureCNIURLLinux(config.CloudSpecConfig))
+		}
+	}
+	if isKubenet(config.ContainerService) {
+		if arm64 {
+			add(datamodel.ArtifactTypeURL, "cni-plugins", config.CloudSpecConfig.KubernetesSpecConfig.CNIARM64PluginsDownloadURL)
+		} else {
+			add(datamodel.ArtifactTypeURL, "cni-plugins", config.CloudSpecConfig.KubernetesSpecConfig.CNIPluginsDownloadURL)
+		}
+	}
+	add(datamodel.ArtifactTypeURL, "containerd", config.ContainerdPackageURL)
+	add(datamodel.ArtifactTypeURL, "runc", config.RuncPackageURL)
+	if config.EnableACRTeleportPlugin {
+		add(datamodel.ArtifactTypeURL, "teleportd", config.TeleportdPluginURL)
+	}
+	return artifacts
+}
+
+// getNodeBootstrappingRawFiles returns the unencoded custom data, CSE command and, when the node uses one, kubelet
+// config file.
+func getNodeBootstrappingRawFiles(config *datamodel.NodeBootstrappingConfiguration, customData, cse string) []datamodel.BootstrappingFile {
+	if config.AgentPoolProfile.IsWindows() {
+		return []datamodel.BootstrappingFile{
+			{Name: "custom-data.ps1", Content: customData},
+			{Name: "cse-cmd.ps1", Content: cse},
+		}
+	}
+	customDataName := "cloud-init.yaml"
+	if config.AgentPoolProfile.Distro.IsIgnitionDistro() {
+		customDataName = "ignition.json"
+	}
+	files := []datamodel.BootstrappingFile{
+		{Name: customDataName, Content: customData},
+		{Name: "cse-cmd.sh", Content: cse},
+	}
+	if IsKubeletConfigFileEnabled(config.ContainerService, config.AgentPoolProfile, config.EnableKubeletConfigFile) {
+		files = append(files, datamodel.BootstrappingFile{
+			Name:    "kubelet-config.json",
+			Content: GetKubeletConfigFileContent(config.KubeletConfig, getCustomKubeletConfig(config)),
+		})
+	}
+	return files
+}
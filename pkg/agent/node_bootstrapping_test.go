@@ -0,0 +1,349 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNodeBootstrappingVMExtensions(t *testing.T) {
+	linux := &datamodel.NodeBootstrappingConfiguration{AgentPoolProfile: &datamodel.AgentPoolProfile{OSType: datamodel.Linux}}
+	windows := &datamodel.NodeBootstrappingConfiguration{AgentPoolProfile: &datamodel.AgentPoolProfile{OSType: datamodel.Windows}}
+
+	assert.Equal(t, []datamodel.VMExtension{{
+		Name:                    "vmssCSE",
+		ResourceType:            "Microsoft.Compute/virtualMachineScaleSets/extensions",
+		Publisher:               "Microsoft.Azure.Extensions",
+		Type:                    "CustomScript",
+		TypeHandlerVersion:      "2.0",
+		AutoUpgradeMinorVersion: true,
+		ProtectedSettings:       map[string]string{"commandToExecute": "cse"},
+	}}, getNodeBootstrappingVMExtensions(linux, "cse"))
+
+	extensions := getNodeBootstrappingVMExtensions(windows, "cse")
+	assert.Len(t, extensions, 1)
+	assert.Equal(t, "Microsoft.Compute", extensions[0].Publisher)
+	assert.Equal(t, "CustomScriptExtension", extensions[0].Type)
+	assert.Equal(t, "1.10", extensions[0].TypeHandlerVersion)
+
+	assert.Empty(t, getNodeBootstrappingVMExtensions(linux, ""))
+}
+
+func TestGetNodeBootstrappingArtifacts(t *testing.T) {
+	config := func(osType datamodel.OSType, networkPlugin string) *datamodel.NodeBootstrappingConfiguration {
+		return &datamodel.NodeBootstrappingConfiguration{
+			ContainerService: &datamodel.ContainerService{Properties: &datamodel.Properties{
+				OrchestratorProfile: &datamodel.OrchestratorProfile{KubernetesConfig: &datamodel.KubernetesConfig{
+					NetworkPlugin:        networkPlugin,
+					CustomKubeProxyImage: "mcr.microsoft.com/oss/kubernetes/kube-proxy:v1.30.3",
+					WindowsContainerdURL: "https://acs-mirror.azureedge.net/containerd/windows/containerd.tar.gz",
+				}},
+			}},
+			CloudSpecConfig: &datamodel.AzureEnvironmentSpecConfig{KubernetesSpecConfig: datamodel.KubernetesSpecConfig{
+				VnetCNILinuxPluginsDownloadURL:   "https://acs-mirror.azureedge.net/azure-cni/linux.tgz",
+				VnetCNIWindowsPluginsDownloadURL: "https://acs-mirror.azureedge.net/azure-cni/windows.zip",
+				CNIARM64PluginsDownloadURL:       "https://acs-mirror.azureedge.net/cni/arm64.tgz",
+			}},
+			K8sComponents: &datamodel.K8sComponents{
+				PodInfraContainerImageURL: "mcr.microsoft.com/oss/kubernetes/pause:3.6",
+				LinuxPrivatePackageURL:    "https://acs-mirror.azureedge.net/kubernetes/v1.30.3/binaries/v1.30.3.tar.gz",
+				WindowsPackageURL:         "https://acs-mirror.azureedge.net/kubernetes/v1.30.3/windowszip/v1.30.3-1int.zip",
+			},
+			AgentPoolProfile:        &datamodel.AgentPoolProfile{OSType: osType},
+			RuncPackageURL:          "https://example.com/runc.deb",
+			TeleportdPluginURL:      "https://example.com/teleportd",
+			EnableACRTeleportPlugin: false,
+		}
+	}
+
+	assert.Equal(t, []datamodel.ArtifactDependency{
+		{Type: datamodel.ArtifactTypeImage, Name: "pause", Reference: "mcr.microsoft.com/oss/kubernetes/pause:3.6"},
+		{Type: datamodel.ArtifactTypeImage, Name: "kube-proxy", Reference: "mcr.microsoft.com/oss/kubernetes/kube-proxy:v1.30.3"},
+		{Type: datamodel.ArtifactTypeURL, Name: "kubernetes-binaries", Reference: "https://acs-mirror.azureedge.net/kubernetes/v1.30.3/binaries/v1.30.3.tar.gz"},
+		{Type: datamodel.ArtifactTypeURL, Name: "azure-cni", Reference: "https://acs-mirror.azureedge.net/azure-cni/linux.tgz"},
+		{Type: datamodel.ArtifactTypeURL, Name: "runc", Reference: "https://example.com/runc.deb"},
+	}, getNodeBootstrappingArtifacts(config(datamodel.Linux, datamodel.NetworkPluginAzure)))
+
+	// kubenet nodes download the CNI plugins instead of azure CNI, of their architecture.
+	kubenet := config(datamodel.Linux, NetworkPluginKubenet)
+	kubenet.IsARM64 = true
+	assert.Contains(t, getNodeBootstrappingArtifacts(kubenet),
+		datamodel.ArtifactDependency{Type: datamodel.ArtifactTypeURL, Name: "cni-plugins", Reference: "https://acs-mirror.azureedge.net/cni/arm64.tgz"})
+	for _, artifact := range getNodeBootstrappingArtifacts(kubenet) {
+		assert.NotEqual(t, "azure-cni", artifact.Name)
+	}
+
+	assert.Equal(t, []datamodel.ArtifactDependency{
+		{Type: datamodel.ArtifactTypeImage, Name: "pause", Reference: "mcr.microsoft.com/oss/kubernetes/pause:3.6"},
+		{Type: datamodel.ArtifactTypeURL, Name: "kubernetes-binaries", Reference: "https://acs-mirror.azureedge.net/kubernetes/v1.30.3/windowszip/v1.30.3-1int.zip"},
+		{Type: datamodel.ArtifactTypeURL, Name: "azure-cni", Reference: "https://acs-mirror.azureedge.net/azure-cni/windows.zip"},
+		{Type: datamodel.ArtifactTypeURL, Name: "containerd", Reference: "https://acs-mirror.azureedge.net/containerd/windows/containerd.tar.gz"},
+	}, getNodeBootstrappingArtifacts(config(datamodel.Windows, datamodel.NetworkPluginAzure)))
+}
+
+func TestGetNodeBootstrappingRawFiles(t *testing.T) {
+	agentPool := &datamodel.AgentPoolProfile{OSType: datamodel.Linux, CustomKubeletConfig: &datamodel.CustomKubeletConfig{}}
+	config := &datamodel.NodeBootstrappingConfiguration{
+		ContainerService: &datamodel.ContainerService{Properties: &datamodel.Properties{
+			AgentPoolProfiles: []*datamodel.AgentPoolProfile{agentPool},
+		}},
+		AgentPoolProfile: agentPool,
+		KubeletConfig:    map[string]string{"--max-pods": "30"},
+	}
+
+	files := getNodeBootstrappingRawFiles(config, "#cloud-config\n", "/opt/azure/containers/provision_start.sh")
+	assert.Len(t, files, 3)
+	assert.Equal(t, datamodel.BootstrappingFile{Name: "cloud-init.yaml", Content: "#cloud-config\n"}, files[0])
+	assert.Equal(t, datamodel.BootstrappingFile{Name: "cse-cmd.sh", Content: "/opt/azure/containers/provision_start.sh"}, files[1])
+	assert.Equal(t, "kubelet-config.json", files[2].Name)
+	assert.Contains(t, files[2].Content, `"maxPods": 30`)
+
+	nodeBootstrapping := &datamodel.NodeBootstrapping{RawFiles: files[:2]}
+	assert.Equal(t, "==> cloud-init.yaml <==\n#cloud-config\n\n==> cse-cmd.sh <==\n/opt/azure/containers/provision_start.sh\n",
+		nodeBootstrapping.RawText())
+
+	windows := &datamodel.NodeBootstrappingConfiguration{AgentPoolProfile: &datamodel.AgentPoolProfile{OSType: datamodel.Windows}}
+	assert.Equal(t, []datamodel.BootstrappingFile{
+		{Name: "custom-data.ps1", Content: "<powershell>"},
+		{Name: "cse-cmd.ps1", Content: "powershell.exe"},
+	}, getNodeBootstrappingRawFiles(windows, "<powershell>", "powershell.exe"))
+}
+
+func TestValidateArchitecture(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       *datamodel.NodeBootstrappingConfiguration
+		wantWarnings []string
+		wantErr      string
+	}{
+		{
+			name: "amd64",
+			config: &datamodel.NodeBootstrappingConfiguration{
+				AgentPoolProfile: &datamodel.AgentPoolProfile{VMSize: "Standard_D4ds_v5", Distro: datamodel.AKSUbuntuContainerd2204Gen2},
+			},
+		},
+		{
+			name: "arm64 distro",
+			config: &datamodel.NodeBootstrappingConfiguration{
+				AgentPoolProfile: &datamodel.AgentPoolProfile{VMSize: "Standard_D4pds_v5", Distro: datamodel.AKSUbuntuArm64Containerd2204Gen2},
+			},
+		},
+		{
+			name: "arm64 custom image",
+			config: &datamodel.NodeBootstrappingConfiguration{
+				AgentPoolProfile: &datamodel.AgentPoolProfile{VMSize: "Standard_E2ps_v5", Distro: datamodel.CustomizedImage},
+				IsARM64:          true,
+			},
+		},
+		{
+			name: "arm64 VM size with amd64 distro",
+			config: &datamodel.NodeBootstrappingConfiguration{
+				AgentPoolProfile: &datamodel.AgentPoolProfile{VMSize: "Standard_D4pds_v5", Distro: datamodel.AKSUbuntuContainerd2204Gen2},
+			},
+			wantWarnings: []string{"VM size Standard_D4pds_v5 doesn't look like a size of the amd64 architecture of the node"},
+		},
+		{
+			name: "amd64 VM size with arm64 distro",
+			config: &datamodel.NodeBootstrappingConfiguration{
+				AgentPoolProfile: &datamodel.AgentPoolProfile{VMSize: "Standard_D4ds_v5", Distro: datamodel.AKSAzureLinuxV3Arm64Gen2},
+			},
+			wantWarnings: []string{"VM size Standard_D4ds_v5 doesn't look like a size of the arm64 architecture of the node"},
+		},
+		{
+			name: "arm64 with amd64 distro",
+			config: &datamodel.NodeBootstrappingConfiguration{
+				AgentPoolProfile: &datamodel.AgentPoolProfile{Distro: datamodel.AKSUbuntuContainerd2204Gen2},
+				IsARM64:          true,
+			},
+			wantWarnings: []string{"distro aks-ubuntu-containerd-22.04-gen2 isn't an arm64 distro"},
+		},
+		{
+			name: "arm64 GPU",
+			config: &datamodel.NodeBootstrappingConfiguration{
+				AgentPoolProfile: &datamodel.AgentPoolProfile{Distro: datamodel.AKSUbuntuArm64Containerd2204Gen2},
+				EnableNvidia:     true,
+			},
+			wantErr: "GPU drivers aren't supported on arm64 nodes",
+		},
+		{
+			name: "arm64 Windows",
+			config: &datamodel.NodeBootstrappingConfiguration{
+				AgentPoolProfile: &datamodel.AgentPoolProfile{OSType: datamodel.Windows, Distro: datamodel.AKSWindows2022ContainerdGen2},
+				IsARM64:          true,
+			},
+			wantErr: "arm64 Windows nodes aren't supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := validateArchitecture(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantWarnings, warnings)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDistroCapabilities(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *datamodel.NodeBootstrappingConfiguration
+		wantErr string
+	}{
+		{
+			name: "gpu",
+			config: &datamodel.NodeBootstrappingConfiguration{
+				AgentPoolProfile: &datamodel.AgentPoolProfile{OSType: datamodel.Linux, Distro: datamodel.AKSUbuntuContainerd2204Gen2},
+				EnableNvidia:     true,
+			},
+		},
+		{
+			name: "custom image",
+			config: &datamodel.NodeBootstrappingConfiguration{
+				AgentPoolProfile: &datamodel.AgentPoolProfile{OSType: datamodel.Linux, Distro: datamodel.CustomizedImageKata},
+				EnableNvidia:     true,
+			},
+		},
+		{
+			name: "gpu on kata",
+			config: &datamodel.NodeBootstrappingConfiguration{
+				AgentPoolProfile: &datamodel.AgentPoolProfile{OSType: datamodel.Linux, Distro: datamodel.AKSAzureLinuxV2Gen2Kata},
+				EnableNvidia:     true,
+			},
+			wantErr: "GPU drivers aren't supported on distro aks-azurelinux-v2-gen2-kata",
+		},
+		{
+			name: "windows distro on linux",
+			config: &datamodel.NodeBootstrappingConfiguration{
+				AgentPoolProfile: &datamodel.AgentPoolProfile{OSType: datamodel.Linux, Distro: datamodel.AKSWindows2022Containerd},
+			},
+			wantErr: "distro aks-windows-2022-containerd doesn't match the Linux OS type of the node",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDistroCapabilities(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateVMSecurityProfile(t *testing.T) {
+	enabled, disabled := true, false
+	newConfig := func(distro datamodel.Distro, vmSize string, securityProfile *datamodel.VMSecurityProfile) *datamodel.NodeBootstrappingConfiguration {
+		osType := datamodel.Linux
+		if distro.IsWindowsDistro() {
+			osType = datamodel.Windows
+		}
+		return &datamodel.NodeBootstrappingConfiguration{AgentPoolProfile: &datamodel.AgentPoolProfile{
+			OSType: osType, Distro: distro, VMSize: vmSize, VMSecurityProfile: securityProfile,
+		}}
+	}
+	tests := []struct {
+		name    string
+		config  *datamodel.NodeBootstrappingConfiguration
+		wantErr string
+	}{
+		{
+			name:   "standard",
+			config: newConfig(datamodel.AKSUbuntuContainerd2204, "Standard_D4s_v5", nil),
+		},
+		{
+			name: "trusted launch with measured boot",
+			config: newConfig(datamodel.AKSUbuntuContainerd2204TLGen2, "Standard_D4s_v5", &datamodel.VMSecurityProfile{
+				SecurityType: datamodel.VMSecurityTypeTrustedLaunch, EnableMeasuredBoot: true,
+			}),
+		},
+		{
+			name: "trusted launch on a Gen2 Windows image",
+			config: newConfig(datamodel.AKSWindows2022ContainerdGen2, "Standard_D4s_v5", &datamodel.VMSecurityProfile{
+				SecurityType: datamodel.VMSecurityTypeTrustedLaunch,
+			}),
+		},
+		{
+			name: "trusted launch on a custom image",
+			config: newConfig(datamodel.CustomizedImage, "Standard_D4s_v5", &datamodel.VMSecurityProfile{
+				SecurityType: datamodel.VMSecurityTypeTrustedLaunch,
+			}),
+		},
+		{
+			name: "confidential VM",
+			config: newConfig(datamodel.AKSUbuntuContainerd2004CVMGen2, "Standard_DC4as_v5", &datamodel.VMSecurityProfile{
+				SecurityType: datamodel.VMSecurityTypeConfidentialVM,
+			}),
+		},
+		{
+			name:    "unknown security type",
+			config:  newConfig(datamodel.AKSUbuntuContainerd2204TLGen2, "Standard_D4s_v5", &datamodel.VMSecurityProfile{SecurityType: "Shielded"}),
+			wantErr: `unknown VM security type "Shielded"`,
+		},
+		{
+			name:    "secure boot without a security type",
+			config:  newConfig(datamodel.AKSUbuntuContainerd2204Gen2, "Standard_D4s_v5", &datamodel.VMSecurityProfile{EnableSecureBoot: &enabled}),
+			wantErr: "secure boot and vTPM require the TrustedLaunch or ConfidentialVM security type",
+		},
+		{
+			name: "measured boot without a vTPM",
+			config: newConfig(datamodel.AKSUbuntuContainerd2204TLGen2, "Standard_D4s_v5", &datamodel.VMSecurityProfile{
+				SecurityType: datamodel.VMSecurityTypeTrustedLaunch, EnableVTPM: &disabled, EnableMeasuredBoot: true,
+			}),
+			wantErr: "measured boot requires a vTPM",
+		},
+		{
+			name: "measured boot on Windows",
+			config: newConfig(datamodel.AKSWindows2022ContainerdGen2, "Standard_D4s_v5", &datamodel.VMSecurityProfile{
+				SecurityType: datamodel.VMSecurityTypeTrustedLaunch, EnableMeasuredBoot: true,
+			}),
+			wantErr: "measured boot isn't supported on Windows nodes",
+		},
+		{
+			name: "trusted launch on a Gen1 image",
+			config: newConfig(datamodel.AKSUbuntuContainerd2204, "Standard_D4s_v5", &datamodel.VMSecurityProfile{
+				SecurityType: datamodel.VMSecurityTypeTrustedLaunch,
+			}),
+			wantErr: "TrustedLaunch requires a Gen2 image, distro aks-ubuntu-containerd-22.04 is Gen1",
+		},
+		{
+			name: "trusted launch on an image without the signed boot chain",
+			config: newConfig(datamodel.AKSUbuntuContainerd2204Gen2, "Standard_D4s_v5", &datamodel.VMSecurityProfile{
+				SecurityType: datamodel.VMSecurityTypeTrustedLaunch,
+			}),
+			wantErr: "distro aks-ubuntu-containerd-22.04-gen2 doesn't support trusted launch",
+		},
+		{
+			name: "confidential VM on a general purpose size",
+			config: newConfig(datamodel.AKSUbuntuContainerd2004CVMGen2, "Standard_D4s_v5", &datamodel.VMSecurityProfile{
+				SecurityType: datamodel.VMSecurityTypeConfidentialVM,
+			}),
+			wantErr: "VM size Standard_D4s_v5 isn't a confidential VM size",
+		},
+		{
+			name: "confidential VM on a trusted launch image",
+			config: newConfig(datamodel.AKSUbuntuContainerd2204TLGen2, "Standard_EC8es_v5", &datamodel.VMSecurityProfile{
+				SecurityType: datamodel.VMSecurityTypeConfidentialVM,
+			}),
+			wantErr: "distro aks-ubuntu-containerd-22.04-tl-gen2 doesn't support confidential VMs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateVMSecurityProfile(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
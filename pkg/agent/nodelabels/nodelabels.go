@@ -0,0 +1,120 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+// Package nodelabels computes the standard AKS node labels.
+// It is shared by the Linux and Windows bootstrapping paths and by aks-node-controller bootstrappers,
+// so every OS ends up with the same set of labels for the same agent pool.
+package nodelabels
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+// Standard label keys.
+const (
+	AgentPool      = "agentpool"
+	AKSAgentPool   = "kubernetes.azure.com/agentpool"
+	StorageProfile = "kubernetes.azure.com/storageprofile"
+	StorageTier    = "kubernetes.azure.com/storagetier"
+	OSSKU          = "kubernetes.azure.com/os-sku"
+	FIPSEnabled    = "kubernetes.azure.com/fips_enabled"
+	Accelerator    = "kubernetes.azure.com/accelerator"
+	// ScaleSetPriority is only set on Spot nodes, so that workloads tolerating evictions can select them.
+	ScaleSetPriority = "kubernetes.azure.com/scalesetpriority"
+	// Arch is the well-known architecture label, which kubelet accepts in --node-labels so that the node is
+	// schedulable by architecture before it registers.
+	Arch = "kubernetes.io/arch"
+)
+
+// AcceleratorNvidia is the accelerator label value for nodes with Nvidia GPUs.
+const AcceleratorNvidia = "nvidia"
+
+// ScaleSetPrioritySpot is the scale set priority label value of Spot nodes.
+const ScaleSetPrioritySpot = "spot"
+
+// leadingLabels are always rendered first, in this order, to keep the label string stable.
+//
+//nolint:gochecknoglobals
+var leadingLabels = []string{AgentPool, AKSAgentPool}
+
+// Input holds the node properties the standard labels are computed from.
+// Empty fields don't produce a label.
+type Input struct {
+	AgentPoolName string
+	// StorageTier is the managed disk storage tier, it is only set for agent pools using managed disks.
+	StorageTier string
+	OSSKU       string
+	FIPSEnabled bool
+	Accelerator string
+	// ScaleSetPriority is set for Spot nodes only.
+	ScaleSetPriority string
+	// Arch is the CPU architecture, amd64 or arm64.
+	Arch string
+	// CustomLabels are user or RP provided labels, they take precedence over the computed ones.
+	CustomLabels map[string]string
+}
+
+// Compute returns the node labels for the given input.
+func Compute(in Input) map[string]string {
+	labels := map[string]string{
+		AgentPool:    in.AgentPoolName,
+		AKSAgentPool: in.AgentPoolName,
+	}
+	if in.StorageTier != "" {
+		labels[StorageProfile] = "managed"
+		labels[StorageTier] = in.StorageTier
+	}
+	if in.OSSKU != "" {
+		labels[OSSKU] = in.OSSKU
+	}
+	if in.FIPSEnabled {
+		labels[FIPSEnabled] = "true"
+	}
+	if in.Accelerator != "" {
+		labels[Accelerator] = in.Accelerator
+	}
+	if in.Arch != "" {
+		labels[Arch] = in.Arch
+	}
+	if in.ScaleSetPriority != "" {
+		labels[ScaleSetPriority] = in.ScaleSetPriority
+	}
+	for key, val := range in.CustomLabels {
+		labels[key] = val
+	}
+	return labels
+}
+
+// Format returns a k8s API-compliant labels string, e.g. "agentpool=pool1,kubernetes.azure.com/agentpool=pool1,a=b".
+// The agent pool labels come first, followed by the remaining labels sorted by key.
+func Format(labels map[string]string) string {
+	var buf bytes.Buffer
+	leading := map[string]bool{}
+	for _, key := range leadingLabels {
+		leading[key] = true
+		if val, ok := labels[key]; ok {
+			writeLabel(&buf, key, val)
+		}
+	}
+
+	keys := []string{}
+	for key := range labels {
+		if !leading[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		writeLabel(&buf, key, labels[key])
+	}
+	return buf.String()
+}
+
+func writeLabel(buf *bytes.Buffer, key, val string) {
+	if buf.Len() > 0 {
+		buf.WriteString(",")
+	}
+	buf.WriteString(fmt.Sprintf("%s=%s", key, val))
+}
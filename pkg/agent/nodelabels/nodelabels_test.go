@@ -0,0 +1,106 @@
+package nodelabels
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name string
+		in   Input
+		want map[string]string
+	}{
+		{
+			name: "agent pool labels only",
+			in:   Input{AgentPoolName: "pool1"},
+			want: map[string]string{
+				"agentpool":                      "pool1",
+				"kubernetes.azure.com/agentpool": "pool1",
+			},
+		},
+		{
+			name: "all standard labels",
+			in: Input{
+				AgentPoolName:    "pool1",
+				StorageTier:      "Premium_LRS",
+				OSSKU:            "AzureLinux",
+				FIPSEnabled:      true,
+				Accelerator:      AcceleratorNvidia,
+				Arch:             "arm64",
+				ScaleSetPriority: ScaleSetPrioritySpot,
+			},
+			want: map[string]string{
+				"agentpool":                             "pool1",
+				"kubernetes.azure.com/agentpool":        "pool1",
+				"kubernetes.azure.com/storageprofile":   "managed",
+				"kubernetes.azure.com/storagetier":      "Premium_LRS",
+				"kubernetes.azure.com/os-sku":           "AzureLinux",
+				"kubernetes.azure.com/fips_enabled":     "true",
+				"kubernetes.azure.com/accelerator":      "nvidia",
+				"kubernetes.io/arch":                    "arm64",
+				"kubernetes.azure.com/scalesetpriority": "spot",
+			},
+		},
+		{
+			name: "custom labels take precedence",
+			in: Input{
+				AgentPoolName: "pool1",
+				OSSKU:         "Ubuntu",
+				CustomLabels: map[string]string{
+					"kubernetes.azure.com/os-sku": "AzureLinux",
+					"a":                           "b",
+				},
+			},
+			want: map[string]string{
+				"agentpool":                      "pool1",
+				"kubernetes.azure.com/agentpool": "pool1",
+				"kubernetes.azure.com/os-sku":    "AzureLinux",
+				"a":                              "b",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Compute(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Compute() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   string
+	}{
+		{
+			name:   "empty",
+			labels: map[string]string{},
+			want:   "",
+		},
+		{
+			name: "agent pool labels come first",
+			labels: map[string]string{
+				"a":                              "b",
+				"kubernetes.azure.com/agentpool": "pool1",
+				"agentpool":                      "pool1",
+				"kubernetes.azure.com/os-sku":    "Ubuntu",
+			},
+			want: "agentpool=pool1,kubernetes.azure.com/agentpool=pool1,a=b,kubernetes.azure.com/os-sku=Ubuntu",
+		},
+		{
+			name:   "without agent pool labels",
+			labels: map[string]string{"b": "2", "a": "1"},
+			want:   "a=1,b=2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Format(tt.labels); got != tt.want {
+				t.Errorf("Format() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+)
+
+// OfflineError is returned by offline generation which needed the network, it lists what would have been fetched.
+type OfflineError struct {
+	Fetches []string
+}
+
+func (e *OfflineError) Error() string {
+	return "offline generation would have used the network for " + strings.Join(e.Fetches, ", ")
+}
+
+// offlineOffloader records the files which would have been uploaded, the placeholder URL it returns lets generation
+// carry on so that every file is listed.
+func offlineOffloader(fetches *[]string) PayloadOffloader {
+	return func(_ context.Context, path string, _ []byte) (string, error) {
+		*fetches = append(*fetches, "the upload of "+path+" out of the custom data")
+		return "offline://" + strings.TrimPrefix(path, "/"), nil
+	}
+}
+
+// offlineResult fails offline generation which needed the network.
+func offlineResult(nodeBootstrapping *datamodel.NodeBootstrapping, fetches []string) (*datamodel.NodeBootstrapping, error) {
+	if len(fetches) > 0 {
+		return nil, &OfflineError{Fetches: fetches}
+	}
+	return nodeBootstrapping, nil
+}
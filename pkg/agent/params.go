@@ -4,14 +4,13 @@
 package agent
 
 import (
-	"encoding/base64"
 	"strconv"
 	"strings"
 
 	"github.com/Azure/agentbaker/pkg/agent/datamodel"
 )
 
-func getParameters(config *datamodel.NodeBootstrappingConfiguration) paramsMap {
+func getParameters(config *datamodel.NodeBootstrappingConfiguration) (paramsMap, error) {
 	cs := config.ContainerService
 	profile := config.AgentPoolProfile
 	properties := cs.Properties
@@ -43,7 +42,9 @@ func getParameters(config *datamodel.NodeBootstrappingConfiguration) paramsMap {
 
 	// Kubernetes Parameters
 	if properties.OrchestratorProfile.IsKubernetes() {
-		assignKubernetesParameters(properties, parametersMap, cloudSpecConfig, config.K8sComponents, config)
+		if err := assignKubernetesParameters(properties, parametersMap, cloudSpecConfig, config.K8sComponents, config); err != nil {
+			return nil, err
+		}
 		if profile != nil {
 			assignKubernetesParametersFromAgentProfile(profile, parametersMap, config)
 		}
@@ -73,7 +74,7 @@ func getParameters(config *datamodel.NodeBootstrappingConfiguration) paramsMap {
 		addValue(parametersMap, "containerdWindowsRuntimeHandlers", properties.WindowsProfile.GetContainerdWindowsRuntimeHandlers())
 	}
 
-	return parametersMap
+	return parametersMap, nil
 }
 
 func assignKubernetesParametersFromAgentProfile(profile *datamodel.AgentPoolProfile, parametersMap paramsMap,
@@ -82,6 +83,9 @@ func assignKubernetesParametersFromAgentProfile(profile *datamodel.AgentPoolProf
 		addValue(parametersMap, "runcVersion", config.RuncVersion)
 	}
 	addValue(parametersMap, "runcPackageURL", config.RuncPackageURL)
+	addValue(parametersMap, "enableAcceleratedNetworking", profile.IsAcceleratedNetworkingEnabled())
+	addValue(parametersMap, "enableInfiniBand", profile.IsInfiniBandEnabled())
+	addValue(parametersMap, "enableIPForwarding", isIPForwardingEnabled(config.ContainerService, profile))
 	if profile.KubernetesConfig == nil || profile.KubernetesConfig.ContainerRuntime == "" {
 		return
 	}
@@ -151,13 +155,18 @@ func assignKubernetesParametersfromKubernetesConfig(properties *datamodel.Proper
 		"cloudProviderDisableOutboundSNAT":  kubernetesConfig.CloudProviderDisableOutboundSNAT,
 	})
 	addValue(parametersMap, "kubeClusterCidr", kubernetesConfig.ClusterSubnet)
+	if kubernetesConfig.NetworkPlugin == NetworkPluginKubenet {
+		if podCIDRStrategy, err := kubernetesConfig.PodCIDRStrategy(); err == nil {
+			addValue(parametersMap, "kubeNodeCidrMaskSize", strconv.Itoa(podCIDRStrategy.NodeMaskSize(config.AgentPoolProfile.IsWindows())))
+		}
+	}
 	addValue(parametersMap, "dockerBridgeCidr", kubernetesConfig.DockerBridgeSubnet)
 	addValue(parametersMap, "networkPolicy", kubernetesConfig.NetworkPolicy)
 	addValue(parametersMap, "networkPlugin", kubernetesConfig.NetworkPlugin)
 	addValue(parametersMap, "networkMode", kubernetesConfig.NetworkMode)
 	addValue(parametersMap, "containerRuntime", kubernetesConfig.ContainerRuntime)
 	addValue(parametersMap, "containerdDownloadURLBase", cloudSpecConfig.KubernetesSpecConfig.ContainerdDownloadURLBase)
-	if config.IsARM64 {
+	if config.GetArch() == datamodel.ArchARM64 {
 		addValue(parametersMap, "vnetCniLinuxPluginsURL", kubernetesConfig.GetAzureCNIURLARM64Linux(cloudSpecConfig))
 	} else {
 		addValue(parametersMap, "vnetCniLinuxPluginsURL", kubernetesConfig.GetAzureCNIURLLinux(cloudSpecConfig))
@@ -179,7 +188,7 @@ func assignKubernetesParametersfromKubernetesConfig(properties *datamodel.Proper
 func assignKubernetesParameters(properties *datamodel.Properties, parametersMap paramsMap,
 	cloudSpecConfig *datamodel.AzureEnvironmentSpecConfig,
 	k8sComponents *datamodel.K8sComponents,
-	config *datamodel.NodeBootstrappingConfiguration) {
+	config *datamodel.NodeBootstrappingConfiguration) error {
 	orchestratorProfile := properties.OrchestratorProfile
 
 	if orchestratorProfile.IsKubernetes() {
@@ -192,11 +201,6 @@ func assignKubernetesParameters(properties *datamodel.Properties, parametersMap
 
 		if servicePrincipalProfile != nil {
 			addValue(parametersMap, "servicePrincipalClientId", servicePrincipalProfile.ClientID)
-			encodedServicePrincipalClientSecret := base64.StdEncoding.EncodeToString([]byte(servicePrincipalProfile.Secret))
-			addValue(parametersMap, "servicePrincipalClientSecret", servicePrincipalProfile.Secret)
-			// base64 encoding is to escape special characters like quotes in service principal
-			// reference: https://github.com/Azure/aks-engine/pull/1174
-			addValue(parametersMap, "encodedServicePrincipalClientSecret", encodedServicePrincipalClientSecret)
 		}
 
 		/**
@@ -210,12 +214,13 @@ func assignKubernetesParameters(properties *datamodel.Properties, parametersMap
 
 		 To refer to a keyvault secret, the value of the parameter in the api model file should be formatted as:
 
-		 "<PARAMETER>": "/subscriptions/<SUB_ID>/resourceGroups/<RG_NAME>/providers/Microsoft.KeyVault/vaults/<KV_NAME>/secrets/<NAME>[/<VERSION>]"
+		 "<PARAMETER>": "[/tenants/<TENANT_ID>]/subscriptions/<SUB_ID>/resourceGroups/<RG_NAME>/providers/Microsoft.KeyVault/vaults/<KV_NAME>/<secrets|certificates>/<NAME>[/<VERSION>]"
 		 where:
+		   <TENANT_ID> (optional) is the tenant of the keyvault when it isn't the tenant of the cluster
 		   <SUB_ID> is the subscription ID of the keyvault
 		   <RG_NAME> is the resource group of the keyvault
 		   <KV_NAME> is the name of the keyvault
-		   <NAME> is the name of the secret.
+		   <NAME> is the name of the secret, or of the certificate.
 		   <VERSION> (optional) is the version of the secret (default: the latest version)
 
 		 This will generate a reference block in the parameters file:
@@ -224,17 +229,25 @@ func assignKubernetesParameters(properties *datamodel.Properties, parametersMap
 		   "keyVault": {
 		     "id": "/subscriptions/<SUB_ID>/resourceGroups/<RG_NAME>/providers/Microsoft.KeyVault/vaults/<KV_NAME>"
 		   },
+		   "tenantId": "<TENANT_ID>",
+		   "objectType": "<secrets|certificates>",
 		   "secretName": "<NAME>"
 		   "secretVersion": "<VERSION>"
 		}
 		**/
 
-		certificateProfile := properties.CertificateProfile
-		if certificateProfile != nil {
-			addSecret(parametersMap, "apiServerCertificate", certificateProfile.APIServerCertificate, true)
-			addSecret(parametersMap, "caCertificate", certificateProfile.CaCertificate, true)
-			addSecret(parametersMap, "clientCertificate", certificateProfile.ClientCertificate, true)
-			addSecret(parametersMap, "clientPrivateKey", certificateProfile.ClientPrivateKey, true)
+		// the inline values are encoded as secretParameterEncodings says.
+		secrets, err := servicePrincipalSecrets(properties)
+		if err != nil {
+			return err
+		}
+		certificates, err := certificateSecrets(properties.CertificateProfile)
+		if err != nil {
+			return err
+		}
+		for _, s := range append(secrets, certificates...) {
+			addSecret(parametersMap, s.name, s.secret)
 		}
 	}
+	return nil
 }
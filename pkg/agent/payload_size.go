@@ -0,0 +1,254 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/agentbaker/pkg/agent/cloudinit"
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"gopkg.in/yaml.v3"
+)
+
+// customDataSizeLimit is the maximum length of the base64 encoded custom data of an Azure VM, 64KiB once decoded.
+const customDataSizeLimit = 87380
+
+// PayloadOffloader uploads a file moved out of the custom data and returns the URL the node downloads it from. The
+// URL must be readable from the node without credentials, such as a SAS URL.
+type PayloadOffloader func(ctx context.Context, path string, content []byte) (string, error)
+
+// fitCustomData renders the custom data of the cloud-config and checks it fits in the Azure limit. When it doesn't
+// and an offloader is set, the largest files are moved out of the custom data, one at a time, until it fits.
+func fitCustomData(ctx context.Context, config *datamodel.NodeBootstrappingConfiguration, customData string,
+	offloader PayloadOffloader) (string, *datamodel.PayloadSize, error) {
+	offloaded := map[string]string{}
+	for {
+		rendered := customData
+		if config.AgentPoolProfile.Distro.IsIgnitionDistro() {
+			var err error
+			if rendered, err = cloudInitToIgnition(customData); err != nil {
+				return "", nil, fmt.Errorf("render ignition config: %w", err)
+			}
+		}
+		size := &datamodel.PayloadSize{
+			Size:  len(encodeNodeBootstrappingPayload(config, rendered)),
+			Limit: customDataSizeLimit,
+		}
+		if !config.AgentPoolProfile.IsWindows() {
+			// the breakdown is best effort, the total size is what Azure checks.
+			size.Files, _ = cloudConfigFileSizes(customData, offloaded)
+		}
+		if size.Size <= size.Limit {
+			return rendered, size, nil
+		}
+
+		var largest *datamodel.PayloadFileSize
+		for i := range size.Files {
+			if size.Files[i].URL == "" {
+				largest = &size.Files[i]
+				break
+			}
+		}
+		if offloader == nil || largest == nil {
+			return "", nil, payloadSizeError(size)
+		}
+		var err error
+		if customData, err = offloadCloudConfigFile(ctx, customData, largest.Path, offloader, offloaded); err != nil {
+			return "", nil, fmt.Errorf("move %s out of the custom data: %w", largest.Path, err)
+		}
+	}
+}
+
+// validateCloudConfig checks the write_files of the cloud-config of Linux nodes. A path written twice is only a warning,
+// cloud-init writes the files in order and the last write wins.
+func validateCloudConfig(config *datamodel.NodeBootstrappingConfiguration, customData string) ([]string, error) {
+	if config.AgentPoolProfile.IsWindows() {
+		return nil, nil
+	}
+	files, err := cloudConfigWriteFiles(customData)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if err := file.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid cloud-config: %w", err)
+		}
+	}
+	var warnings []string
+	for _, path := range cloudinit.DuplicatePaths(files) {
+		warnings = append(warnings, fmt.Sprintf("the cloud-config writes %s more than once, the last write wins", path))
+	}
+	return warnings, nil
+}
+
+func payloadSizeError(size *datamodel.PayloadSize) error {
+	var largest []string
+	for _, file := range size.Files {
+		if file.URL == "" && len(largest) < 5 {
+			largest = append(largest, fmt.Sprintf("%s (%d bytes)", file.Path, file.Size))
+		}
+	}
+	err := fmt.Sprintf("custom data is %d bytes, over the %d bytes limit of Azure", size.Size, size.Limit)
+	if len(largest) > 0 {
+		err += ", the largest files are " + strings.Join(largest, ", ")
+	}
+	return errors.New(err)
+}
+
+// cloudConfigFileSizes returns the estimated size each file adds to the encoded custom data, largest first.
+func cloudConfigFileSizes(customData string, offloaded map[string]string) ([]datamodel.PayloadFileSize, error) {
+	var cloud cloudConfig
+	if err := yaml.Unmarshal([]byte(customData), &cloud); err != nil {
+		return nil, fmt.Errorf("parse cloud-config: %w", err)
+	}
+	files := make([]datamodel.PayloadFileSize, 0, len(cloud.WriteFiles))
+	for _, file := range cloud.WriteFiles {
+		if url, ok := offloaded[file.Path]; ok {
+			files = append(files, datamodel.PayloadFileSize{Path: file.Path, URL: url})
+			continue
+		}
+		// the custom data is gzipped then base64 encoded, which is the size the content adds, give or take the
+		// compression of its neighbours.
+		files = append(files, datamodel.PayloadFileSize{
+			Path: file.Path,
+			Size: len(getBase64EncodedGzippedCustomScriptFromStr(file.Content.Value)),
+		})
+	}
+	sort.SliceStable(files, func(i, j int) bool { return files[i].Size > files[j].Size })
+	return files, nil
+}
+
+// cloudConfigWriteFiles returns the files the cloud-config writes. The content of the files it downloads is empty.
+func cloudConfigWriteFiles(customData string) ([]cloudinit.WriteFile, error) {
+	var cloud cloudConfig
+	if err := yaml.Unmarshal([]byte(customData), &cloud); err != nil {
+		return nil, fmt.Errorf("parse cloud-config: %w", err)
+	}
+	files := make([]cloudinit.WriteFile, 0, len(cloud.WriteFiles))
+	for _, file := range cloud.WriteFiles {
+		writeFile := cloudinit.WriteFile{Path: file.Path, Owner: file.Owner, Append: file.Append}
+		if file.Permissions != "" {
+			mode, err := strconv.ParseUint(file.Permissions, 8, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid permissions %q of %s", file.Permissions, file.Path)
+			}
+			writeFile.Mode = fs.FileMode(mode)
+		}
+		if file.Source.URI != "" {
+			files = append(files, writeFile)
+			continue
+		}
+		content, compressed, err := decodeCloudConfigContent(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Path, err)
+		}
+		encoding := strings.ToLower(file.Encoding)
+		switch {
+		case compressed:
+			writeFile.Encoding = cloudinit.EncodingGzipBase64
+			if content, err = gunzip(content); err != nil {
+				return nil, fmt.Errorf("%s: %w", file.Path, err)
+			}
+		case file.Content.Tag == "!!binary" || strings.Contains(encoding, "b64") || strings.Contains(encoding, "base64"):
+			writeFile.Encoding = cloudinit.EncodingBase64
+		}
+		writeFile.Content = content
+		files = append(files, writeFile)
+	}
+	return files, nil
+}
+
+func gunzip(content []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("decompress content: %w", err)
+	}
+	if content, err = io.ReadAll(r); err != nil {
+		return nil, fmt.Errorf("decompress content: %w", err)
+	}
+	return content, nil
+}
+
+// offloadCloudConfigFile uploads the content of the file and replaces it with a source URI, which cloud-init
+// downloads the file from.
+func offloadCloudConfigFile(ctx context.Context, customData, path string, offloader PayloadOffloader,
+	offloaded map[string]string) (string, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(customData), &document); err != nil {
+		return "", fmt.Errorf("parse cloud-config: %w", err)
+	}
+	writeFiles := yamlMappingValue(document.Content[0], "write_files")
+	if writeFiles == nil {
+		return "", errors.New("the cloud-config has no write_files")
+	}
+	for _, entry := range writeFiles.Content {
+		if pathNode := yamlMappingValue(entry, "path"); pathNode == nil || pathNode.Value != path {
+			continue
+		}
+		var file cloudConfigFile
+		if err := entry.Decode(&file); err != nil {
+			return "", err
+		}
+		content, compressed, err := decodeCloudConfigContent(file)
+		if err != nil {
+			return "", err
+		}
+		if compressed {
+			// the downloaded file is written as is.
+			if content, err = gunzip(content); err != nil {
+				return "", err
+			}
+		}
+		url, err := offloader(ctx, path, content)
+		if err != nil {
+			return "", err
+		}
+
+		var kept []*yaml.Node
+		for i := 0; i+1 < len(entry.Content); i += 2 {
+			if key := entry.Content[i].Value; key != "content" && key != "encoding" {
+				kept = append(kept, entry.Content[i], entry.Content[i+1])
+			}
+		}
+		entry.Content = append(kept,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: "source"},
+			&yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Value: "uri"},
+				{Kind: yaml.ScalarNode, Value: url},
+			}})
+		offloaded[path] = url
+
+		out, err := yaml.Marshal(&document)
+		if err != nil {
+			return "", err
+		}
+		// cloud-init only reads custom data starting with the #cloud-config header.
+		if !strings.HasPrefix(string(out), "#cloud-config") {
+			out = append([]byte("#cloud-config\n"), out...)
+		}
+		return string(out), nil
+	}
+	return "", fmt.Errorf("the cloud-config doesn't write %s", path)
+}
+
+func yamlMappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/Azure/agentbaker/pkg/agent/cloudinit"
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFitCustomData(t *testing.T) {
+	// random bytes don't compress, so the file alone is over the limit.
+	large := make([]byte, 70000)
+	rand.New(rand.NewSource(1)).Read(large)
+	customData := `#cloud-config
+
+write_files:
+- path: /opt/azure/containers/large.bin
+  permissions: "0644"
+  encoding: b64
+  content: ` + base64.StdEncoding.EncodeToString(large) + `
+- path: /etc/kubernetes/small.conf
+  content: small
+runcmd:
+- systemctl daemon-reload
+`
+	newConfig := func(distro datamodel.Distro) *datamodel.NodeBootstrappingConfiguration {
+		return &datamodel.NodeBootstrappingConfiguration{AgentPoolProfile: &datamodel.AgentPoolProfile{Distro: distro}}
+	}
+
+	t.Run("over the limit", func(t *testing.T) {
+		_, _, err := fitCustomData(context.Background(), newConfig(datamodel.AKSUbuntuContainerd2204), customData, nil)
+		require.Error(t, err)
+		assert.Regexp(t, `^custom data is \d+ bytes, over the 87380 bytes limit of Azure, the largest files are `+
+			`/opt/azure/containers/large.bin \(\d+ bytes\), /etc/kubernetes/small.conf \(\d+ bytes\)$`, err.Error())
+	})
+
+	t.Run("offloaded", func(t *testing.T) {
+		var uploaded map[string][]byte
+		offloader := func(_ context.Context, path string, content []byte) (string, error) {
+			if uploaded == nil {
+				uploaded = map[string][]byte{}
+			}
+			uploaded[path] = content
+			return "https://storage/" + strings.TrimPrefix(path, "/"), nil
+		}
+		rendered, size, err := fitCustomData(context.Background(), newConfig(datamodel.AKSUbuntuContainerd2204), customData, offloader)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"/opt/azure/containers/large.bin": large}, uploaded)
+		assert.True(t, strings.HasPrefix(rendered, "#cloud-config\n"))
+		assert.Contains(t, rendered, "uri: https://storage/opt/azure/containers/large.bin")
+		assert.Contains(t, rendered, "systemctl daemon-reload")
+		assert.NotContains(t, rendered, "encoding")
+		assert.Equal(t, 87380, size.Limit)
+		assert.Less(t, size.Size, size.Limit)
+		require.Len(t, size.Files, 2)
+		assert.Equal(t, datamodel.PayloadFileSize{Path: "/etc/kubernetes/small.conf", Size: size.Files[0].Size}, size.Files[0])
+		assert.Equal(t, datamodel.PayloadFileSize{
+			Path: "/opt/azure/containers/large.bin",
+			URL:  "https://storage/opt/azure/containers/large.bin",
+		}, size.Files[1])
+
+		var files cloudConfig
+		require.NoError(t, yaml.Unmarshal([]byte(rendered), &files))
+		assert.Equal(t, "0644", files.WriteFiles[0].Permissions)
+	})
+
+	t.Run("offloaded ignition", func(t *testing.T) {
+		offloader := func(_ context.Context, path string, _ []byte) (string, error) {
+			return "https://storage" + path, nil
+		}
+		rendered, _, err := fitCustomData(context.Background(), newConfig(datamodel.CustomizedImageFlatcar), customData, offloader)
+		require.NoError(t, err)
+		var ignition ignitionConfig
+		require.NoError(t, json.Unmarshal([]byte(rendered), &ignition))
+		assert.Equal(t, "https://storage/opt/azure/containers/large.bin", ignition.Storage.Files[0].Contents.Source)
+		assert.Empty(t, ignition.Storage.Files[0].Contents.Compression)
+	})
+
+	t.Run("offline", func(t *testing.T) {
+		var fetches []string
+		_, _, err := fitCustomData(context.Background(), newConfig(datamodel.AKSUbuntuContainerd2204), customData, offlineOffloader(&fetches))
+		require.NoError(t, err)
+		_, err = offlineResult(&datamodel.NodeBootstrapping{}, fetches)
+		var offlineErr *OfflineError
+		require.ErrorAs(t, err, &offlineErr)
+		assert.EqualError(t, err, "offline generation would have used the network for the upload of /opt/azure/containers/large.bin out of the custom data")
+	})
+}
+
+func TestValidateCloudConfig(t *testing.T) {
+	config := &datamodel.NodeBootstrappingConfiguration{AgentPoolProfile: &datamodel.AgentPoolProfile{Distro: datamodel.AKSUbuntuContainerd2204}}
+	warnings, err := validateCloudConfig(config, `#cloud-config
+write_files:
+- path: /etc/kubernetes/small.conf
+  content: small
+- path: /etc/kubernetes/small.conf
+  append: true
+  content: more
+- path: /etc/kubernetes/small.conf
+  content: again
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"the cloud-config writes /etc/kubernetes/small.conf more than once, the last write wins"}, warnings)
+
+	_, err = validateCloudConfig(config, "write_files:\n- path: etc/a\n  content: a\n")
+	assert.EqualError(t, err, `invalid cloud-config: write_files path "etc/a" must be a clean absolute path`)
+
+	windows := &datamodel.NodeBootstrappingConfiguration{AgentPoolProfile: &datamodel.AgentPoolProfile{OSType: datamodel.Windows}}
+	warnings, err = validateCloudConfig(windows, "not a cloud-config")
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestCloudConfigWriteFiles(t *testing.T) {
+	files, err := cloudConfigWriteFiles(`#cloud-config
+write_files:
+- path: /etc/kubernetes/plain.conf
+  permissions: "0600"
+  owner: root:kube
+  content: plain
+- path: /etc/kubernetes/binary.conf
+  content: !!binary |
+    YmluYXJ5
+- path: /opt/azure/containers/provision.sh
+  permissions: "0744"
+  encoding: gzip
+  content: !!binary |
+    ` + getBase64EncodedGzippedCustomScriptFromStr("#!/bin/bash") + `
+- path: /etc/kubernetes/plain.conf
+  append: true
+  content: more
+- path: /opt/azure/containers/large.bin
+  source:
+    uri: https://storage/opt/azure/containers/large.bin
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []cloudinit.WriteFile{
+		{Path: "/etc/kubernetes/plain.conf", Owner: "root:kube", Mode: 0600, Content: []byte("plain")},
+		{Path: "/etc/kubernetes/binary.conf", Encoding: cloudinit.EncodingBase64, Content: []byte("binary")},
+		{Path: "/opt/azure/containers/provision.sh", Mode: 0744, Encoding: cloudinit.EncodingGzipBase64, Content: []byte("#!/bin/bash")},
+		{Path: "/etc/kubernetes/plain.conf", Append: true, Content: []byte("more")},
+		{Path: "/opt/azure/containers/large.bin"},
+	}, files)
+	assert.NoError(t, cloudinit.Validate(files))
+
+	_, err = cloudConfigWriteFiles("write_files:\n- path: /etc/a\n  permissions: \"rw\"\n")
+	assert.EqualError(t, err, `invalid permissions "rw" of /etc/a`)
+}
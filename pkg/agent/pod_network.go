@@ -0,0 +1,116 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+)
+
+// podNetworkMode is how the pods of a node are networked, which decides the CNI configuration, the sysctls and the
+// kubelet flags of the node.
+type podNetworkMode string
+
+const (
+	// podNetworkModeKubenet bridges the pods to host-local IPs, pod traffic between nodes goes through a route table.
+	podNetworkModeKubenet podNetworkMode = "kubenet"
+	// podNetworkModeAzureCNI gives the pods IPs of the VNET.
+	podNetworkModeAzureCNI podNetworkMode = "azure"
+	// podNetworkModeAzureCNIOverlay gives the pods IPs of an overlay network, from the cluster subnet.
+	podNetworkModeAzureCNIOverlay podNetworkMode = "overlay"
+	// podNetworkModeCilium is the Azure CNI with the Cilium dataplane, the Cilium agent writes the CNI configuration
+	// once it runs on the node.
+	podNetworkModeCilium podNetworkMode = "cilium"
+	// podNetworkModeBYOCNI leaves the CNI to the user, who installs it once the node joined the cluster.
+	podNetworkModeBYOCNI podNetworkMode = "none"
+)
+
+// getPodNetworkMode returns the pod network mode of the cluster. The other network plugins, such as flannel, are
+// modes of their own without dataplane specific configuration.
+func getPodNetworkMode(kubernetesConfig *datamodel.KubernetesConfig) podNetworkMode {
+	if kubernetesConfig == nil {
+		return ""
+	}
+	plugin := kubernetesConfig.NetworkPlugin
+	switch {
+	case strings.EqualFold(kubernetesConfig.NetworkDataplane, datamodel.NetworkDataplaneCilium), strings.EqualFold(plugin, NetworkPluginCilium):
+		return podNetworkModeCilium
+	case strings.EqualFold(plugin, NetworkPluginAzure) && kubernetesConfig.IsUsingNetworkPluginMode(datamodel.NetworkPluginModeOverlay):
+		return podNetworkModeAzureCNIOverlay
+	case strings.EqualFold(plugin, NetworkPluginAzure):
+		return podNetworkModeAzureCNI
+	case strings.EqualFold(plugin, datamodel.NetworkPluginNone):
+		return podNetworkModeBYOCNI
+	case strings.EqualFold(plugin, NetworkPluginKubenet):
+		return podNetworkModeKubenet
+	}
+	return podNetworkMode(strings.ToLower(plugin))
+}
+
+// validatePodNetworkMode makes sure the network plugin, its mode, the dataplane and the network policy of the cluster
+// combine into a pod network mode the node supports.
+func validatePodNetworkMode(cs *datamodel.ContainerService, profile *datamodel.AgentPoolProfile) error {
+	if cs == nil || cs.Properties == nil || cs.Properties.OrchestratorProfile == nil || cs.Properties.OrchestratorProfile.KubernetesConfig == nil {
+		return nil
+	}
+	kubernetesConfig := cs.Properties.OrchestratorProfile.KubernetesConfig
+	if dataplane := kubernetesConfig.NetworkDataplane; dataplane != "" &&
+		!strings.EqualFold(dataplane, datamodel.NetworkDataplaneAzure) && !strings.EqualFold(dataplane, datamodel.NetworkDataplaneCilium) {
+		return fmt.Errorf("invalid network dataplane %q, it must be %s or %s", dataplane, datamodel.NetworkDataplaneAzure,
+			datamodel.NetworkDataplaneCilium)
+	}
+	if kubernetesConfig.IsUsingNetworkPluginMode(datamodel.NetworkPluginModeOverlay) &&
+		!strings.EqualFold(kubernetesConfig.NetworkPlugin, NetworkPluginAzure) {
+		return fmt.Errorf("network plugin mode %s requires the %s network plugin", datamodel.NetworkPluginModeOverlay, NetworkPluginAzure)
+	}
+	policy := kubernetesConfig.NetworkPolicy
+	switch getPodNetworkMode(kubernetesConfig) {
+	case podNetworkModeCilium:
+		if !strings.EqualFold(kubernetesConfig.NetworkPlugin, NetworkPluginAzure) && !strings.EqualFold(kubernetesConfig.NetworkPlugin, NetworkPluginCilium) {
+			return fmt.Errorf("the %s dataplane requires the %s network plugin", datamodel.NetworkDataplaneCilium, NetworkPluginAzure)
+		}
+		if policy != "" && !strings.EqualFold(policy, NetworkPolicyCilium) {
+			return fmt.Errorf("network policy %s isn't supported with the %s dataplane, which enforces the network policies",
+				policy, datamodel.NetworkDataplaneCilium)
+		}
+		if profile != nil && profile.IsWindows() {
+			return fmt.Errorf("the %s dataplane isn't supported on Windows nodes", datamodel.NetworkDataplaneCilium)
+		}
+	case podNetworkModeBYOCNI:
+		if policy != "" {
+			return fmt.Errorf("network policy %s requires a network plugin, the CNI brought by the user enforces the network policies", policy)
+		}
+	}
+	return nil
+}
+
+// podNetworkSysctls returns the sysctls the pod network mode needs on top of the ones every node gets, the custom
+// sysctls of the pool are applied after them.
+func podNetworkSysctls(mode podNetworkMode) []string {
+	if mode == podNetworkModeCilium {
+		// the reverse path filter drops the packets Cilium routes through its own interfaces.
+		return []string{"net.ipv4.conf.all.rp_filter=0", "net.ipv4.conf.default.rp_filter=0"}
+	}
+	return nil
+}
+
+// setPodNetworkKubeletFlags makes the network plugin of the kubelet match the pod network mode, kubenet is built into
+// the kubelet while every other mode, and kubenet with calico, goes through a CNI. The flag is only set by the versions
+// that still have it.
+func setPodNetworkKubeletFlags(config *datamodel.NodeBootstrappingConfiguration) {
+	orchestratorProfile := config.ContainerService.Properties.OrchestratorProfile
+	if _, ok := config.KubeletConfig["--network-plugin"]; !ok || orchestratorProfile == nil {
+		return
+	}
+	kubernetesConfig := orchestratorProfile.KubernetesConfig
+	switch mode := getPodNetworkMode(kubernetesConfig); {
+	case mode == "":
+	case mode == podNetworkModeKubenet && kubernetesConfig.NetworkPolicy != NetworkPolicyCalico:
+		config.KubeletConfig["--network-plugin"] = NetworkPluginKubenet
+	default:
+		config.KubeletConfig["--network-plugin"] = "cni"
+	}
+}
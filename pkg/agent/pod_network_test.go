@@ -0,0 +1,164 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func podNetworkContainerService(kubernetesConfig *datamodel.KubernetesConfig) *datamodel.ContainerService {
+	return &datamodel.ContainerService{Properties: &datamodel.Properties{
+		OrchestratorProfile: &datamodel.OrchestratorProfile{OrchestratorVersion: "1.29.2", KubernetesConfig: kubernetesConfig},
+	}}
+}
+
+func TestGetPodNetworkMode(t *testing.T) {
+	tests := []struct {
+		kubernetesConfig *datamodel.KubernetesConfig
+		want             podNetworkMode
+	}{
+		{kubernetesConfig: nil, want: ""},
+		{kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "kubenet"}, want: podNetworkModeKubenet},
+		{kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "azure"}, want: podNetworkModeAzureCNI},
+		{kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "azure", NetworkDataplane: "azure"}, want: podNetworkModeAzureCNI},
+		{kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "azure", NetworkPluginMode: "Overlay"}, want: podNetworkModeAzureCNIOverlay},
+		{
+			kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "azure", NetworkPluginMode: "overlay", NetworkDataplane: "cilium"},
+			want:             podNetworkModeCilium,
+		},
+		{kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "cilium"}, want: podNetworkModeCilium},
+		{kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "none"}, want: podNetworkModeBYOCNI},
+		{kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "Flannel"}, want: "flannel"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, getPodNetworkMode(tt.kubernetesConfig), "%+v", tt.kubernetesConfig)
+	}
+}
+
+func TestValidatePodNetworkMode(t *testing.T) {
+	tests := []struct {
+		name             string
+		kubernetesConfig *datamodel.KubernetesConfig
+		windows          bool
+		wantErr          string
+	}{
+		{name: "kubenet with calico", kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "kubenet", NetworkPolicy: "calico"}},
+		{name: "overlay", kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "azure", NetworkPluginMode: "overlay"}},
+		{
+			name:             "cilium",
+			kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "azure", NetworkDataplane: "cilium", NetworkPolicy: "cilium"},
+		},
+		{name: "bring your own CNI", kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "none"}},
+		{
+			name:             "unknown dataplane",
+			kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "azure", NetworkDataplane: "ebpf"},
+			wantErr:          `invalid network dataplane "ebpf", it must be azure or cilium`,
+		},
+		{
+			name:             "overlay without azure",
+			kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "kubenet", NetworkPluginMode: "overlay"},
+			wantErr:          "network plugin mode overlay requires the azure network plugin",
+		},
+		{
+			name:             "cilium dataplane with kubenet",
+			kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "kubenet", NetworkDataplane: "cilium"},
+			wantErr:          "the cilium dataplane requires the azure network plugin",
+		},
+		{
+			name:             "cilium dataplane with calico",
+			kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "azure", NetworkDataplane: "cilium", NetworkPolicy: "calico"},
+			wantErr:          "network policy calico isn't supported with the cilium dataplane, which enforces the network policies",
+		},
+		{
+			name:             "cilium dataplane on windows",
+			kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "azure", NetworkDataplane: "cilium"},
+			windows:          true,
+			wantErr:          "the cilium dataplane isn't supported on Windows nodes",
+		},
+		{
+			name:             "bring your own CNI with a network policy",
+			kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "none", NetworkPolicy: "azure"},
+			wantErr:          "network policy azure requires a network plugin, the CNI brought by the user enforces the network policies",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := &datamodel.AgentPoolProfile{OSType: datamodel.Linux}
+			if tt.windows {
+				profile.OSType = datamodel.Windows
+			}
+			err := validatePodNetworkMode(podNetworkContainerService(tt.kubernetesConfig), profile)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSetPodNetworkKubeletFlags(t *testing.T) {
+	tests := []struct {
+		kubernetesConfig *datamodel.KubernetesConfig
+		kubeletConfig    map[string]string
+		want             map[string]string
+	}{
+		{
+			kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "kubenet"},
+			kubeletConfig:    map[string]string{"--network-plugin": "cni"},
+			want:             map[string]string{"--network-plugin": "kubenet"},
+		},
+		{
+			kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "kubenet", NetworkPolicy: "calico"},
+			kubeletConfig:    map[string]string{"--network-plugin": "kubenet"},
+			want:             map[string]string{"--network-plugin": "cni"},
+		},
+		{
+			kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "azure", NetworkDataplane: "cilium"},
+			kubeletConfig:    map[string]string{"--network-plugin": "kubenet"},
+			want:             map[string]string{"--network-plugin": "cni"},
+		},
+		{
+			// the kubelet versions without the flag are left alone.
+			kubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: "none"},
+			kubeletConfig:    map[string]string{"--max-pods": "30"},
+			want:             map[string]string{"--max-pods": "30"},
+		},
+	}
+	for _, tt := range tests {
+		config := &datamodel.NodeBootstrappingConfiguration{
+			ContainerService: podNetworkContainerService(tt.kubernetesConfig),
+			KubeletConfig:    tt.kubeletConfig,
+		}
+		setPodNetworkKubeletFlags(config)
+		assert.Equal(t, tt.want, config.KubeletConfig, "%+v", tt.kubernetesConfig)
+	}
+}
+
+func TestGetSysctlContentPodNetwork(t *testing.T) {
+	sysctls := func(kubernetesConfig *datamodel.KubernetesConfig) string {
+		profile := &datamodel.AgentPoolProfile{Name: "nodepool1"}
+		cs := podNetworkContainerService(kubernetesConfig)
+		cs.Properties.AgentPoolProfiles = []*datamodel.AgentPoolProfile{profile}
+		funcMap := InitializeTemplateGenerator().getContainerServiceFuncMap(context.Background(), &datamodel.NodeBootstrappingConfiguration{
+			ContainerService: cs,
+			AgentPoolProfile: profile,
+		})
+		content, err := funcMap["GetSysctlContent"].(func() (string, error))() //nolint:errcheck // the func map is built in this package
+		require.NoError(t, err)
+		decoded, err := base64.StdEncoding.DecodeString(content)
+		require.NoError(t, err)
+		return string(decoded)
+	}
+
+	assert.NotContains(t, sysctls(&datamodel.KubernetesConfig{NetworkPlugin: "azure"}), "rp_filter")
+	assert.Contains(t, sysctls(&datamodel.KubernetesConfig{NetworkPlugin: "azure", NetworkDataplane: "cilium"}),
+		"net.core.message_cost=40\nnet.ipv4.conf.all.rp_filter=0\nnet.ipv4.conf.default.rp_filter=0\n")
+}
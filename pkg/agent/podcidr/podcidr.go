@@ -0,0 +1,99 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+// Package podcidr models how the pod CIDRs of kubenet-style networking are carved out of the cluster subnet, where
+// every node owns a slice of the cluster subnet and pod traffic is routed to the node through a route table. The pod
+// CIDRs themselves are allocated by kube-controller-manager, generation only checks the cluster subnet and mask sizes.
+package podcidr
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+const (
+	// DefaultClusterCIDR is the cluster subnet used when none is configured.
+	DefaultClusterCIDR = "10.244.0.0/16"
+	// DefaultNodeMaskSize is the prefix length of the pod CIDR assigned to each node when none is configured.
+	DefaultNodeMaskSize = 24
+)
+
+// Strategy is how pod CIDRs are assigned to nodes.
+type Strategy interface {
+	// ClusterCIDR is the range all pod CIDRs are allocated from.
+	ClusterCIDR() netip.Prefix
+	// NodeMaskSize returns the prefix length of the pod CIDR assigned to a Linux or Windows node.
+	NodeMaskSize(windows bool) int
+}
+
+// Contiguous is the range allocator of kube-controller-manager, which hands out consecutive, non-overlapping blocks of
+// the cluster CIDR. Linux and Windows nodes can use different mask sizes.
+type Contiguous struct {
+	Cluster  netip.Prefix
+	MaskSize int
+	// WindowsMaskSize is the mask size used for Windows nodes, MaskSize is used when it is zero.
+	WindowsMaskSize int
+}
+
+var _ Strategy = &Contiguous{}
+
+// NewContiguous returns a Contiguous strategy, empty or zero arguments fall back to the defaults.
+func NewContiguous(clusterCIDR string, maskSize, windowsMaskSize int) (*Contiguous, error) {
+	if clusterCIDR == "" {
+		clusterCIDR = DefaultClusterCIDR
+	}
+	cluster, err := netip.ParsePrefix(clusterCIDR)
+	if err != nil {
+		return nil, fmt.Errorf("parse cluster CIDR %q: %w", clusterCIDR, err)
+	}
+	if maskSize == 0 {
+		maskSize = DefaultNodeMaskSize
+		if cluster.Addr().Is6() {
+			maskSize = 64
+		}
+	}
+	s := &Contiguous{Cluster: cluster.Masked(), MaskSize: maskSize, WindowsMaskSize: windowsMaskSize}
+	if err := s.validateMaskSize(s.NodeMaskSize(false)); err != nil {
+		return nil, err
+	}
+	if err := s.validateMaskSize(s.NodeMaskSize(true)); err != nil {
+		return nil, fmt.Errorf("windows: %w", err)
+	}
+	return s, nil
+}
+
+// ClusterCIDR implements Strategy.
+func (s *Contiguous) ClusterCIDR() netip.Prefix {
+	return s.Cluster
+}
+
+// NodeMaskSize implements Strategy.
+func (s *Contiguous) NodeMaskSize(windows bool) int {
+	if windows && s.WindowsMaskSize != 0 {
+		return s.WindowsMaskSize
+	}
+	return s.MaskSize
+}
+
+func (s *Contiguous) validateMaskSize(maskSize int) error {
+	if maskSize < s.Cluster.Bits() || maskSize > s.Cluster.Addr().BitLen() {
+		return fmt.Errorf("node mask size /%d doesn't fit in cluster CIDR %s", maskSize, s.Cluster)
+	}
+	return nil
+}
+
+// CheckOverlaps returns an error when the cluster CIDR overlaps one of the ranges, such as the address spaces of the
+// VNET, pod traffic would otherwise be routed to the nodes instead of the VNET hosts. Ranges of the other IP family are
+// ignored.
+func CheckOverlaps(cluster netip.Prefix, ranges []string) error {
+	for _, r := range ranges {
+		prefix, err := netip.ParsePrefix(r)
+		if err != nil {
+			return fmt.Errorf("parse CIDR %q: %w", r, err)
+		}
+		if prefix.Addr().Is4() == cluster.Addr().Is4() && prefix.Overlaps(cluster) {
+			return fmt.Errorf("cluster CIDR %s overlaps %s", cluster, prefix)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package podcidr
+
+import (
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewContiguous(t *testing.T) {
+	tests := []struct {
+		name            string
+		clusterCIDR     string
+		maskSize        int
+		windowsMaskSize int
+		want            *Contiguous
+		wantErr         string
+	}{
+		{
+			name: "defaults",
+			want: &Contiguous{Cluster: netip.MustParsePrefix("10.244.0.0/16"), MaskSize: 24},
+		},
+		{
+			name:        "custom base and mask size",
+			clusterCIDR: "172.16.1.0/12",
+			maskSize:    26,
+			want:        &Contiguous{Cluster: netip.MustParsePrefix("172.16.0.0/12"), MaskSize: 26},
+		},
+		{
+			name:            "windows mask size",
+			clusterCIDR:     "10.244.0.0/16",
+			maskSize:        25,
+			windowsMaskSize: 24,
+			want:            &Contiguous{Cluster: netip.MustParsePrefix("10.244.0.0/16"), MaskSize: 25, WindowsMaskSize: 24},
+		},
+		{
+			name:        "ipv6",
+			clusterCIDR: "fd00:10:244::/56",
+			want:        &Contiguous{Cluster: netip.MustParsePrefix("fd00:10:244::/56"), MaskSize: 64},
+		},
+		{
+			name:        "mask size larger than the cluster CIDR",
+			clusterCIDR: "10.244.0.0/24",
+			maskSize:    16,
+			wantErr:     "node mask size /16 doesn't fit in cluster CIDR 10.244.0.0/24",
+		},
+		{
+			name:            "windows mask size larger than the cluster CIDR",
+			clusterCIDR:     "10.244.0.0/24",
+			maskSize:        25,
+			windowsMaskSize: 16,
+			wantErr:         "windows: node mask size /16 doesn't fit in cluster CIDR 10.244.0.0/24",
+		},
+		{
+			name:        "invalid cluster CIDR",
+			clusterCIDR: "10.244.0.0",
+			wantErr:     "parse cluster CIDR",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewContiguous(tt.clusterCIDR, tt.maskSize, tt.windowsMaskSize)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewContiguous() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckOverlaps(t *testing.T) {
+	cluster := netip.MustParsePrefix("10.244.0.0/16")
+	tests := []struct {
+		name    string
+		ranges  []string
+		wantErr string
+	}{
+		{name: "no ranges"},
+		{name: "disjoint ranges", ranges: []string{"10.224.0.0/12", "10.245.0.0/16"}},
+		{name: "ipv6 ranges are ignored", ranges: []string{"fd00::/8"}},
+		{name: "range in the cluster CIDR", ranges: []string{"10.224.0.0/12", "10.244.8.0/24"}, wantErr: "cluster CIDR 10.244.0.0/16 overlaps 10.244.8.0/24"},
+		{name: "range containing the cluster CIDR", ranges: []string{"10.0.0.0/8"}, wantErr: "cluster CIDR 10.244.0.0/16 overlaps 10.0.0.0/8"},
+		{name: "invalid range", ranges: []string{"10.0.0.0"}, wantErr: "parse CIDR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckOverlaps(cluster, tt.ranges)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckOverlaps() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckOverlaps() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
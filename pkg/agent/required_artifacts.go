@@ -0,0 +1,73 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"strings"
+
+	"github.com/Azure/agentbaker/pkg/agent/catalog"
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+)
+
+// requiredArtifacts returns the artifacts the bootstrapping data references, followed by the components the catalog
+// installs on the distro of the node which it doesn't reference, with their versions and digests.
+func requiredArtifacts(config *datamodel.NodeBootstrappingConfiguration, components *catalog.Catalog) []datamodel.ArtifactDependency {
+	artifacts := getNodeBootstrappingArtifacts(config)
+	var catalogArtifacts []catalog.Artifact
+	// the catalog only has the components of Linux nodes.
+	if !config.AgentPoolProfile.IsWindows() {
+		catalogArtifacts = components.Lookup(catalogQuery(&datamodel.GetComponentVersionsRequest{
+			Distro:            config.AgentPoolProfile.Distro,
+			Arch:              config.GetArch(),
+			KubernetesVersion: config.ContainerService.Properties.OrchestratorProfile.OrchestratorVersion,
+		}))
+	}
+	byURL := map[string]catalog.Artifact{}
+	for _, artifact := range catalogArtifacts {
+		if artifact.URL != "" {
+			byURL[artifact.URL] = artifact
+		}
+	}
+
+	referenced := map[string]bool{}
+	for i := range artifacts {
+		artifact := &artifacts[i]
+		referenced[artifact.Name] = true
+		if known, ok := byURL[artifact.Reference]; ok {
+			artifact.Version, artifact.Digest = known.Version, known.Checksum
+		} else if artifact.Type == datamodel.ArtifactTypeImage {
+			artifact.Version, artifact.Digest = parseImageReference(artifact.Reference)
+		}
+	}
+	for _, known := range catalogArtifacts {
+		if referenced[known.Component] {
+			continue
+		}
+		artifactType := datamodel.ArtifactTypePackage
+		switch {
+		case known.OS == "":
+			artifactType = datamodel.ArtifactTypeImage
+		case known.URL != "":
+			artifactType = datamodel.ArtifactTypeURL
+		}
+		artifacts = append(artifacts, datamodel.ArtifactDependency{
+			Type:      artifactType,
+			Name:      known.Component,
+			Reference: known.URL,
+			Version:   known.Version,
+			Digest:    known.Checksum,
+		})
+	}
+	return artifacts
+}
+
+// parseImageReference returns the tag and the digest of an image reference such as
+// mcr.microsoft.com/oss/kubernetes/pause:3.6@sha256:....
+func parseImageReference(reference string) (string, string) {
+	reference, digest, _ := strings.Cut(reference, "@")
+	// the tag follows the last colon after the registry, whose host may have a port.
+	repository := reference[strings.LastIndex(reference, "/")+1:]
+	_, tag, _ := strings.Cut(repository, ":")
+	return tag, digest
+}
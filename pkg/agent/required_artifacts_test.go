@@ -0,0 +1,96 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/Azure/agentbaker/pkg/agent/catalog"
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredArtifacts(t *testing.T) {
+	components, err := catalog.Load([]byte(`{
+  "ContainerImages": [
+    {
+      "downloadURL": "mcr.microsoft.com/oss/kubernetes/kube-proxy:*",
+      "multiArchVersionsV2": [{"latestVersion": "v1.30.5", "previousLatestVersion": "v1.30.4"}, {"latestVersion": "v1.31.2"}]
+    },
+    {"downloadURL": "mcr.microsoft.com/oss/kubernetes/pause:*", "amd64OnlyVersions": ["3.6"]}
+  ],
+  "Packages": [
+    {
+      "name": "runc",
+      "downloadURIs": {"ubuntu": {"current": {"versionsV2": [{"latestVersion": "1.1.14"}]}}}
+    },
+    {
+      "name": "kubernetes-binaries",
+      "downloadURIs": {
+        "default": {
+          "current": {
+            "versionsV2": [{"latestVersion": "1.30.5", "checksum": "sha256:abc"}],
+            "downloadURL": "https://acs-mirror.azureedge.net/kubernetes/v${version}/binaries/kubernetes-node-linux-${CPU_ARCH}.tar.gz"
+          }
+        }
+      }
+    }
+  ]
+}`))
+	require.NoError(t, err)
+	config := func(osType datamodel.OSType) *datamodel.NodeBootstrappingConfiguration {
+		return &datamodel.NodeBootstrappingConfiguration{
+			ContainerService: &datamodel.ContainerService{Properties: &datamodel.Properties{
+				OrchestratorProfile: &datamodel.OrchestratorProfile{
+					OrchestratorVersion: "1.30.5",
+					KubernetesConfig:    &datamodel.KubernetesConfig{NetworkPlugin: datamodel.NetworkPluginNone},
+				},
+			}},
+			CloudSpecConfig:  &datamodel.AzureEnvironmentSpecConfig{},
+			AgentPoolProfile: &datamodel.AgentPoolProfile{OSType: osType, Distro: datamodel.AKSUbuntuContainerd2204},
+			K8sComponents: &datamodel.K8sComponents{
+				PodInfraContainerImageURL: "mcr.microsoft.com/oss/kubernetes/pause:3.6",
+				LinuxPrivatePackageURL:    "https://acs-mirror.azureedge.net/kubernetes/v1.30.5/binaries/kubernetes-node-linux-amd64.tar.gz",
+			},
+		}
+	}
+
+	assert.Equal(t, []datamodel.ArtifactDependency{
+		{Type: datamodel.ArtifactTypeImage, Name: "pause", Reference: "mcr.microsoft.com/oss/kubernetes/pause:3.6", Version: "3.6"},
+		{
+			Type:      datamodel.ArtifactTypeURL,
+			Name:      "kubernetes-binaries",
+			Reference: "https://acs-mirror.azureedge.net/kubernetes/v1.30.5/binaries/kubernetes-node-linux-amd64.tar.gz",
+			Version:   "1.30.5",
+			Digest:    "sha256:abc",
+		},
+		{Type: datamodel.ArtifactTypeImage, Name: "kube-proxy", Reference: "mcr.microsoft.com/oss/kubernetes/kube-proxy:v1.30.4", Version: "v1.30.4"},
+		{Type: datamodel.ArtifactTypeImage, Name: "kube-proxy", Reference: "mcr.microsoft.com/oss/kubernetes/kube-proxy:v1.30.5", Version: "v1.30.5"},
+		{Type: datamodel.ArtifactTypePackage, Name: "runc", Version: "1.1.14"},
+	}, requiredArtifacts(config(datamodel.Linux), components))
+
+	// the catalog doesn't have the components of Windows nodes.
+	assert.Equal(t, []datamodel.ArtifactDependency{
+		{Type: datamodel.ArtifactTypeImage, Name: "pause", Reference: "mcr.microsoft.com/oss/kubernetes/pause:3.6", Version: "3.6"},
+	}, requiredArtifacts(config(datamodel.Windows), components))
+}
+
+func TestParseImageReference(t *testing.T) {
+	tests := []struct {
+		reference  string
+		wantTag    string
+		wantDigest string
+	}{
+		{reference: "mcr.microsoft.com/oss/kubernetes/pause:3.6", wantTag: "3.6"},
+		{reference: "registry:5000/pause:3.6@sha256:0123", wantTag: "3.6", wantDigest: "sha256:0123"},
+		{reference: "registry:5000/pause@sha256:0123", wantDigest: "sha256:0123"},
+		{reference: "pause"},
+	}
+	for _, tt := range tests {
+		tag, digest := parseImageReference(tt.reference)
+		assert.Equal(t, tt.wantTag, tag, tt.reference)
+		assert.Equal(t, tt.wantDigest, digest, tt.reference)
+	}
+}
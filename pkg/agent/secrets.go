@@ -0,0 +1,128 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+)
+
+// secretSource is where the value of a secret parameter comes from.
+type secretSource int
+
+const (
+	// secretSourceInline is a secret whose value is in the configuration.
+	secretSourceInline secretSource = iota
+	// secretSourceKeyVault is a secret or a certificate of a key vault, which is resolved when the parameters are
+	// deployed.
+	secretSourceKeyVault
+)
+
+// secretEncoding is how the inline value of a secret parameter is encoded. The values of key vault references are
+// resolved as they are stored.
+type secretEncoding int
+
+const (
+	secretEncodingNone secretEncoding = iota
+	// secretEncodingBase64 escapes the special characters of the value, such as the quotes of a service principal
+	// secret or the new lines of a PEM certificate, the templates decode it.
+	secretEncodingBase64
+)
+
+// secretParameterEncodings are the encodings of the secret parameters, the parameters which aren't listed aren't
+// encoded.
+//
+//nolint:gochecknoglobals
+var secretParameterEncodings = map[string]secretEncoding{
+	"apiServerCertificate":                secretEncodingBase64,
+	"caCertificate":                       secretEncodingBase64,
+	"clientCertificate":                   secretEncodingBase64,
+	"clientPrivateKey":                    secretEncodingBase64,
+	"servicePrincipalClientSecret":        secretEncodingNone,
+	"encodedServicePrincipalClientSecret": secretEncodingBase64,
+}
+
+// secret is the value of a secret parameter and where it comes from.
+type secret struct {
+	source    secretSource
+	value     string
+	reference *datamodel.KeyVaultRef
+}
+
+// newSecret returns the secret of the configuration value of the named parameter. A key vault object ID references
+// the object, which must be a secret or a certificate, any other value is inline.
+func newSecret(name, value string) (secret, error) {
+	if !datamodel.IsKeyVaultObjectID(value) {
+		return secret{source: secretSourceInline, value: value}, nil
+	}
+	ref, err := datamodel.ParseKeyVaultObjectID(value)
+	if err != nil {
+		return secret{}, fmt.Errorf("%s: %w", name, err)
+	}
+	if ref.ObjectType == datamodel.KeyVaultObjectTypeKey {
+		return secret{}, fmt.Errorf("%s references key %s, only secrets and certificates can be used", name, ref.KeyName)
+	}
+	return secret{source: secretSourceKeyVault, reference: ref}, nil
+}
+
+// namedSecret is a secret and the name of its parameter.
+type namedSecret struct {
+	name   string
+	secret secret
+}
+
+// certificateSecrets returns the secrets of the certificates of the profile.
+func certificateSecrets(profile *datamodel.CertificateProfile) ([]namedSecret, error) {
+	if profile == nil {
+		return nil, nil
+	}
+	var secrets []namedSecret
+	for _, certificate := range []struct {
+		name  string
+		value string
+	}{
+		{"apiServerCertificate", profile.APIServerCertificate},
+		{"caCertificate", profile.CaCertificate},
+		{"clientCertificate", profile.ClientCertificate},
+		{"clientPrivateKey", profile.ClientPrivateKey},
+	} {
+		s, err := newSecret(certificate.name, certificate.value)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, namedSecret{name: certificate.name, secret: s})
+	}
+	return secrets, nil
+}
+
+// servicePrincipalSecrets returns the secrets of the service principal.
+func servicePrincipalSecrets(properties *datamodel.Properties) ([]namedSecret, error) {
+	if properties.ServicePrincipalProfile == nil {
+		return nil, nil
+	}
+	s, err := newSecret("servicePrincipalClientSecret", properties.ServicePrincipalProfile.Secret)
+	if err != nil {
+		return nil, err
+	}
+	return []namedSecret{
+		{name: "servicePrincipalClientSecret", secret: s},
+		{name: "encodedServicePrincipalClientSecret", secret: s},
+	}, nil
+}
+
+// addSecret adds the secret parameter with the encoding of its parameter.
+func addSecret(m paramsMap, k string, s secret) {
+	switch s.source {
+	case secretSourceKeyVault:
+		addKeyvaultReference(m, k, s.reference)
+	default:
+		if secretParameterEncodings[k] == secretEncodingBase64 {
+			addValue(m, k, base64.StdEncoding.EncodeToString([]byte(s.value)))
+		} else {
+			addValue(m, k, s.value)
+		}
+	}
+}
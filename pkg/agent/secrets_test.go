@@ -0,0 +1,103 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddSecret(t *testing.T) {
+	const vaultID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/my-vault"
+	m := paramsMap{}
+	secrets, err := certificateSecrets(&datamodel.CertificateProfile{
+		CaCertificate:    vaultID + "/certificates/ca/0123456789abcdef",
+		ClientPrivateKey: "private-key",
+	})
+	require.NoError(t, err)
+	for _, s := range secrets {
+		addSecret(m, s.name, s.secret)
+	}
+	addSecret(m, "servicePrincipalClientSecret", secret{source: secretSourceInline, value: `p"ss`})
+	addSecret(m, "encodedServicePrincipalClientSecret", secret{source: secretSourceInline, value: `p"ss`})
+
+	assert.Equal(t, paramsMap{"reference": &datamodel.KeyVaultRef{
+		KeyVault:      datamodel.KeyVaultID{ID: vaultID},
+		ObjectType:    datamodel.KeyVaultObjectTypeCertificate,
+		SecretName:    "ca",
+		SecretVersion: "0123456789abcdef",
+	}}, m["caCertificate"])
+	assert.Equal(t, paramsMap{"value": "cHJpdmF0ZS1rZXk="}, m["clientPrivateKey"])
+	assert.Equal(t, paramsMap{"value": ""}, m["apiServerCertificate"])
+	assert.Equal(t, paramsMap{"value": `p"ss`}, m["servicePrincipalClientSecret"])
+	assert.Equal(t, paramsMap{"value": "cCJzcw=="}, m["encodedServicePrincipalClientSecret"])
+}
+
+func TestNewSecret(t *testing.T) {
+	const vaultID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/my-vault"
+	s, err := newSecret("clientCertificate", "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0t")
+	require.NoError(t, err)
+	assert.Equal(t, secret{source: secretSourceInline, value: "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0t"}, s)
+
+	s, err = newSecret("clientCertificate", "/tenants/tenant/"+vaultID[1:]+"/secrets/client")
+	require.NoError(t, err)
+	assert.Equal(t, secretSourceKeyVault, s.source)
+	assert.Equal(t, "tenant", s.reference.TenantID)
+
+	// an invalid reference is an error rather than an inline value.
+	_, err = newSecret("clientCertificate", vaultID+"/secrets/client/v1.0")
+	assert.EqualError(t, err, `clientCertificate: invalid Key Vault object ID "`+vaultID+`/secrets/client/v1.0": invalid object version "v1.0"`)
+	_, err = newSecret("clientCertificate", vaultID+"/keys/client")
+	assert.EqualError(t, err, "clientCertificate references key client, only secrets and certificates can be used")
+}
+
+func TestServicePrincipalSecrets(t *testing.T) {
+	const vaultID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/my-vault"
+	properties := func(secret string, useManagedIdentity bool) *datamodel.Properties {
+		return &datamodel.Properties{
+			OrchestratorProfile: &datamodel.OrchestratorProfile{
+				KubernetesConfig: &datamodel.KubernetesConfig{UseManagedIdentity: useManagedIdentity},
+			},
+			ServicePrincipalProfile: &datamodel.ServicePrincipalProfile{ClientID: "client", Secret: secret},
+		}
+	}
+	params := func(secrets []namedSecret) paramsMap {
+		m := paramsMap{}
+		for _, s := range secrets {
+			addSecret(m, s.name, s.secret)
+		}
+		return m
+	}
+
+	secrets, err := servicePrincipalSecrets(&datamodel.Properties{})
+	require.NoError(t, err)
+	assert.Empty(t, secrets)
+
+	secrets, err = servicePrincipalSecrets(properties("secret", false))
+	require.NoError(t, err)
+	assert.Equal(t, paramsMap{
+		"servicePrincipalClientSecret":        paramsMap{"value": "secret"},
+		"encodedServicePrincipalClientSecret": paramsMap{"value": "c2VjcmV0"},
+	}, params(secrets))
+
+	// clusters with a managed identity keep the secret of their service principal.
+	secrets, err = servicePrincipalSecrets(properties("secret", true))
+	require.NoError(t, err)
+	assert.Equal(t, paramsMap{
+		"servicePrincipalClientSecret":        paramsMap{"value": "secret"},
+		"encodedServicePrincipalClientSecret": paramsMap{"value": "c2VjcmV0"},
+	}, params(secrets))
+
+	secrets, err = servicePrincipalSecrets(properties(vaultID+"/secrets/sp", false))
+	require.NoError(t, err)
+	m := params(secrets)
+	assert.Contains(t, m["servicePrincipalClientSecret"], "reference")
+	assert.Contains(t, m["encodedServicePrincipalClientSecret"], "reference")
+
+	_, err = servicePrincipalSecrets(properties(vaultID+"/keys/sp", false))
+	assert.EqualError(t, err, "servicePrincipalClientSecret references key sp, only secrets and certificates can be used")
+}
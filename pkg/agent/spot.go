@@ -0,0 +1,72 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+)
+
+// Azure notifies Spot VMs of their eviction 30 seconds ahead, the kubelet delays the shutdown of the node by as much to
+// terminate its pods, keeping the last 10 seconds for the critical pods.
+const (
+	spotShutdownGracePeriod             = "30s"
+	spotShutdownGracePeriodCriticalPods = "10s"
+)
+
+// validateSpotPool makes sure the eviction policy and the max price are only set on Spot pools, and are valid.
+func validateSpotPool(profile *datamodel.AgentPoolProfile) error {
+	if profile == nil {
+		return nil
+	}
+	if profile.ScaleSetPriority != "" && !strings.EqualFold(profile.ScaleSetPriority, "Regular") && !profile.IsSpotScaleSet() {
+		return fmt.Errorf("invalid scale set priority %q, it must be Regular or %s", profile.ScaleSetPriority, datamodel.ScaleSetPrioritySpot)
+	}
+	if !profile.IsSpotScaleSet() {
+		if profile.ScaleSetEvictionPolicy != "" || profile.SpotMaxPrice != nil {
+			return fmt.Errorf("the eviction policy and the max price of agent pool %s require the %s scale set priority",
+				profile.Name, datamodel.ScaleSetPrioritySpot)
+		}
+		return nil
+	}
+	switch {
+	case profile.ScaleSetEvictionPolicy == "",
+		strings.EqualFold(profile.ScaleSetEvictionPolicy, datamodel.ScaleSetEvictionPolicyDelete),
+		strings.EqualFold(profile.ScaleSetEvictionPolicy, datamodel.ScaleSetEvictionPolicyDeallocate):
+	default:
+		return fmt.Errorf("invalid eviction policy %q, it must be %s or %s", profile.ScaleSetEvictionPolicy,
+			datamodel.ScaleSetEvictionPolicyDelete, datamodel.ScaleSetEvictionPolicyDeallocate)
+	}
+	if maxPrice := profile.SpotMaxPrice; maxPrice != nil && *maxPrice != -1 && *maxPrice <= 0 {
+		return fmt.Errorf("invalid Spot max price %g, it must be -1 or a positive price", *maxPrice)
+	}
+	return nil
+}
+
+// getCustomKubeletConfig returns the custom kubelet config of the kubelet config file of the node. On Spot pools it
+// sets the grace periods of the graceful node shutdown, so that the pods of a node shut down by a Deallocate or Delete
+// eviction are terminated before the VM stops, the grace periods set in the custom kubelet config are kept. The kubelet
+// only sees the shutdown of the OS, a Preempt eviction isn't handled. The profile shared by the nodes of the pool is
+// left untouched, and nodes without a kubelet config file don't get one for it.
+func getCustomKubeletConfig(config *datamodel.NodeBootstrappingConfiguration) *datamodel.CustomKubeletConfig {
+	profile := config.AgentPoolProfile
+	// the graceful node shutdown is enabled by default from 1.21.
+	if !profile.IsSpotScaleSet() ||
+		!IsKubernetesVersionGe(config.ContainerService.Properties.OrchestratorProfile.OrchestratorVersion, "1.21.0") {
+		return profile.CustomKubeletConfig
+	}
+	customKc := datamodel.CustomKubeletConfig{}
+	if profile.CustomKubeletConfig != nil {
+		customKc = *profile.CustomKubeletConfig
+	}
+	if customKc.ShutdownGracePeriod == "" {
+		customKc.ShutdownGracePeriod = spotShutdownGracePeriod
+	}
+	if customKc.ShutdownGracePeriodCriticalPods == "" {
+		customKc.ShutdownGracePeriodCriticalPods = spotShutdownGracePeriodCriticalPods
+	}
+	return &customKc
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSpotPool(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile *datamodel.AgentPoolProfile
+		wantErr string
+	}{
+		{name: "regular", profile: &datamodel.AgentPoolProfile{Name: "pool1"}},
+		{name: "explicit regular", profile: &datamodel.AgentPoolProfile{Name: "pool1", ScaleSetPriority: "Regular"}},
+		{
+			name: "spot",
+			profile: &datamodel.AgentPoolProfile{Name: "pool1", ScaleSetPriority: "spot",
+				ScaleSetEvictionPolicy: datamodel.ScaleSetEvictionPolicyDeallocate, SpotMaxPrice: to.Float64Ptr(0.05)},
+		},
+		{
+			name:    "spot without price cap",
+			profile: &datamodel.AgentPoolProfile{Name: "pool1", ScaleSetPriority: "Spot", SpotMaxPrice: to.Float64Ptr(-1)},
+		},
+		{
+			name:    "unknown priority",
+			profile: &datamodel.AgentPoolProfile{Name: "pool1", ScaleSetPriority: "Low"},
+			wantErr: `invalid scale set priority "Low", it must be Regular or Spot`,
+		},
+		{
+			name:    "eviction policy without spot",
+			profile: &datamodel.AgentPoolProfile{Name: "pool1", ScaleSetEvictionPolicy: datamodel.ScaleSetEvictionPolicyDelete},
+			wantErr: "the eviction policy and the max price of agent pool pool1 require the Spot scale set priority",
+		},
+		{
+			name:    "unknown eviction policy",
+			profile: &datamodel.AgentPoolProfile{Name: "pool1", ScaleSetPriority: "Spot", ScaleSetEvictionPolicy: "Stop"},
+			wantErr: `invalid eviction policy "Stop", it must be Delete or Deallocate`,
+		},
+		{
+			name:    "free",
+			profile: &datamodel.AgentPoolProfile{Name: "pool1", ScaleSetPriority: "Spot", SpotMaxPrice: to.Float64Ptr(0)},
+			wantErr: "invalid Spot max price 0, it must be -1 or a positive price",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSpotPool(tt.profile)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSpotShutdownGracePeriods(t *testing.T) {
+	newConfig := func(profile *datamodel.AgentPoolProfile, version string) *datamodel.NodeBootstrappingConfiguration {
+		return &datamodel.NodeBootstrappingConfiguration{
+			ContainerService: &datamodel.ContainerService{Properties: &datamodel.Properties{
+				OrchestratorProfile: &datamodel.OrchestratorProfile{OrchestratorVersion: version},
+			}},
+			AgentPoolProfile: profile,
+			KubeletConfig:    map[string]string{},
+		}
+	}
+
+	spot := &datamodel.AgentPoolProfile{Name: "spot", ScaleSetPriority: datamodel.ScaleSetPrioritySpot}
+	config := newConfig(spot, "1.29.2")
+	config.EnableKubeletConfigFile = true
+	var kubeletConfig datamodel.AKSKubeletConfiguration
+	require.NoError(t, json.Unmarshal([]byte(GetKubeletConfigFileContent(map[string]string{}, getCustomKubeletConfig(config))), &kubeletConfig))
+	assert.Equal(t, datamodel.Duration("30s"), kubeletConfig.ShutdownGracePeriod)
+	assert.Equal(t, datamodel.Duration("10s"), kubeletConfig.ShutdownGracePeriodCriticalPods)
+	// the profile shared by the nodes of the pool is left untouched, and doesn't enable the kubelet config file.
+	ValidateAndSetLinuxNodeBootstrappingConfiguration(newConfig(spot, "1.29.2"))
+	assert.Nil(t, spot.CustomKubeletConfig)
+	assert.False(t, IsKubeletConfigFileEnabled(newConfig(spot, "1.29.2").ContainerService, spot, false))
+	assert.Equal(t, "spot", spot.GetNodeLabels()["kubernetes.azure.com/scalesetpriority"])
+
+	custom := &datamodel.AgentPoolProfile{Name: "spot", ScaleSetPriority: datamodel.ScaleSetPrioritySpot,
+		CustomKubeletConfig: &datamodel.CustomKubeletConfig{ShutdownGracePeriod: "20s"}}
+	customKc := getCustomKubeletConfig(newConfig(custom, "1.29.2"))
+	assert.Equal(t, "20s", customKc.ShutdownGracePeriod)
+	assert.Equal(t, "10s", customKc.ShutdownGracePeriodCriticalPods)
+	assert.Empty(t, custom.CustomKubeletConfig.ShutdownGracePeriodCriticalPods)
+
+	old := &datamodel.AgentPoolProfile{Name: "spot", ScaleSetPriority: datamodel.ScaleSetPrioritySpot}
+	assert.Nil(t, getCustomKubeletConfig(newConfig(old, "1.20.9")))
+
+	regular := &datamodel.AgentPoolProfile{Name: "regular", CustomKubeletConfig: &datamodel.CustomKubeletConfig{}}
+	assert.Same(t, regular.CustomKubeletConfig, getCustomKubeletConfig(newConfig(regular, "1.29.2")))
+	assert.NotContains(t, regular.GetNodeLabels(), "kubernetes.azure.com/scalesetpriority")
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+	"text/template"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+)
+
+// customDataTemplates are the assets rendered by getSingleLine, the Linux ones have their comments removed.
+//
+//nolint:gochecknoglobals
+var customDataTemplates = []struct {
+	name    string
+	isLinux bool
+}{
+	{kubernetesNodeCustomDataYaml, true},
+	{kubernetesCSECommandString, true},
+	{kubernetesWindowsAgentCustomDataPS1, false},
+	{kubernetesWindowsAgentCSECommandPS1, false},
+}
+
+// customScriptTemplates are the assets rendered by getBase64EncodedGzippedCustomScript.
+//
+//nolint:gochecknoglobals
+var customScriptTemplates = []string{
+	kubernetesCSEStartScript, kubernetesCSEMainScript, kubernetesCSEHelpersScript, kubernetesCSEHelpersScriptUbuntu,
+	kubernetesCSEHelpersScriptMariner, kubernetesCSEInstall, kubernetesCSEInstallUbuntu, kubernetesCSEInstallMariner,
+	kubernetesCSEConfig, kubernetesCSESendLogs, kubernetesCSERedactCloudConfig, kubernetesCISScript,
+	kubernetesCustomSearchDomainsScript, kubeletSystemdService, kmsSystemdService, aptPreferences,
+	dockerClearMountPropagationFlags, reconcilePrivateHostsScript, reconcilePrivateHostsService, bindMountScript,
+	bindMountSystemdService, snapshotUpdateScript, snapshotUpdateSystemdService, snapshotUpdateSystemdTimer,
+	packageUpdateScriptMariner, packageUpdateSystemdServiceMariner, packageUpdateSystemdTimerMariner, migPartitionScript,
+	migPartitionSystemdService, ensureIMDSRestrictionScript, dhcpv6SystemdService, dhcpv6ConfigurationScript,
+	initAKSCustomCloudScript, initAKSCustomCloudMarinerScript, ensureNoDupEbtablesScript, ensureNoDupEbtablesService,
+	containerdKubeletDropin, cgroupv2KubeletDropin, componentConfigDropin, tlsBootstrapDropin, bindMountDropin,
+	httpProxyDropin, componentManifestFile,
+}
+
+//nolint:gochecknoglobals
+var (
+	funcNamesOnce sync.Once
+	funcNames     template.FuncMap
+)
+
+// templateFuncNames returns the functions the templates are parsed with. Parsing only needs the names of the
+// functions, which don't depend on the configuration, the functions of the configuration are set before execution.
+func templateFuncNames() template.FuncMap {
+	funcNamesOnce.Do(func() {
+		funcNames = (&TemplateGenerator{}).getBakerFuncMap(context.Background(), &datamodel.NodeBootstrappingConfiguration{}, nil, nil)
+	})
+	return funcNames
+}
+
+// templateCache holds the parsed templates of a template bundle by asset name, the assets of a bundle never change.
+// It is safe for concurrent use.
+type templateCache struct {
+	templates sync.Map
+	// precompiled is done once all the templates of the bundle are parsed.
+	precompiled   sync.Once
+	precompileErr error
+}
+
+// get returns a clone of the template of key, which parse parses on the first call. The clone can be given the
+// functions of a configuration without affecting the other generations. Parsing errors aren't cached.
+func (c *templateCache) get(key string, parse func() (*template.Template, error)) (*template.Template, error) {
+	cached, ok := c.templates.Load(key)
+	if !ok {
+		templ, err := parse()
+		if err != nil {
+			return nil, err
+		}
+		cached, _ = c.templates.LoadOrStore(key, templ)
+	}
+	return cached.(*template.Template).Clone() //nolint:errcheck // the cache only has templates.
+}
+
+// customDataTemplate returns the template of a custom data or CSE command asset.
+func (t *TemplateGenerator) customDataTemplate(name string, isLinux bool, funcMap template.FuncMap) (*template.Template, error) {
+	return t.cache.get(fmt.Sprintf("customdata:%s:%t", name, isLinux), func() (*template.Template, error) {
+		b, err := fs.ReadFile(t.templates, name)
+		if err != nil {
+			return nil, fmt.Errorf("yaml file %s does not exist", name)
+		}
+		if isLinux {
+			b = removeComments(b)
+		}
+
+		// use go templates to process the text filename
+		templ := template.New("customdata template").Option("missingkey=zero").Funcs(templateFuncNames()).Funcs(funcMap)
+		if _, err = templ.New(name).Parse(string(b)); err != nil {
+			return nil, fmt.Errorf("error parsing file %s: %w", name, err)
+		}
+		return templ, nil
+	})
+}
+
+// customScriptTemplate returns the template of a file the custom data writes.
+func (t *TemplateGenerator) customScriptTemplate(name string) (*template.Template, error) {
+	return t.cache.get("script:"+name, func() (*template.Template, error) {
+		b, err := fs.ReadFile(t.templates, name)
+		if err != nil {
+			return nil, err
+		}
+		// translate the parameters.
+		return template.New("ContainerService template").Option("missingkey=error").Funcs(templateFuncNames()).Parse(string(removeComments(b)))
+	})
+}
+
+// Precompile parses all the templates of the generator, which then only executes them. Precompiling is done once,
+// the generator is safe for concurrent use either way. The assets missing from the bundle are skipped, as an older
+// bundle may not have them, rendering them fails instead.
+func (t *TemplateGenerator) Precompile() error {
+	t.cache.precompiled.Do(func() {
+		var errs []error
+		exists := func(name string) bool {
+			_, err := fs.Stat(t.templates, name)
+			return !errors.Is(err, fs.ErrNotExist)
+		}
+		for _, asset := range customDataTemplates {
+			if exists(asset.name) {
+				_, err := t.customDataTemplate(asset.name, asset.isLinux, nil)
+				errs = append(errs, err)
+			}
+		}
+		for _, name := range customScriptTemplates {
+			if exists(name) {
+				_, err := t.customScriptTemplate(name)
+				errs = append(errs, err)
+			}
+		}
+		t.cache.precompileErr = errors.Join(errs...)
+	})
+	return t.cache.precompileErr
+}
@@ -0,0 +1,120 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"text/template"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTemplates returns a bundle with the Linux custom data and CSE start script, which render the location with the
+// functions of the configuration.
+func testTemplates(lines int) fstest.MapFS {
+	var customData strings.Builder
+	customData.WriteString("#cloud-config\n# comment\nwrite_files:\n")
+	for i := 0; i < lines; i++ {
+		fmt.Fprintf(&customData, "- path: /etc/file%d\n  content: {{GetTargetEnvironment}}{{if IsKubenet}} kubenet{{end}}\n", i)
+	}
+	return fstest.MapFS{
+		kubernetesNodeCustomDataYaml: &fstest.MapFile{Data: []byte(customData.String())},
+		kubernetesCSEStartScript:     &fstest.MapFile{Data: []byte("#!/bin/bash\nLOCATION={{.Location}}\n")},
+	}
+}
+
+func newTestConfig(location string) *datamodel.NodeBootstrappingConfiguration {
+	return &datamodel.NodeBootstrappingConfiguration{
+		ContainerService: &datamodel.ContainerService{Location: location, Properties: &datamodel.Properties{
+			OrchestratorProfile: &datamodel.OrchestratorProfile{KubernetesConfig: &datamodel.KubernetesConfig{}},
+		}},
+		AgentPoolProfile: &datamodel.AgentPoolProfile{},
+	}
+}
+
+func renderTestCustomData(t testing.TB, generator *TemplateGenerator, location string) string {
+	config := newTestConfig(location)
+	funcMap := template.FuncMap{
+		"GetTargetEnvironment": func() string { return config.ContainerService.Location },
+		"IsKubenet":            func() bool { return false },
+	}
+	line, err := generator.getSingleLine(context.Background(), kubernetesNodeCustomDataYaml, config.AgentPoolProfile, funcMap, true)
+	require.NoError(t, err)
+	return line
+}
+
+func TestPrecompile(t *testing.T) {
+	templates := testTemplates(1)
+	generator := newTemplateGenerator(templates)
+	// the other assets are missing from the bundle, and skipped.
+	require.NoError(t, generator.Precompile())
+
+	templates[kubernetesNodeCustomDataYaml].Data = []byte("changed")
+	templates[kubernetesCSEStartScript].Data = []byte("changed")
+	assert.Equal(t, "#cloud-config\nwrite_files:\n- path: /etc/file0\n  content: westus\n", renderTestCustomData(t, generator, "westus"))
+	script := generator.getBase64EncodedGzippedCustomScript(context.Background(), kubernetesCSEStartScript, newTestConfig("westus"))
+	assert.Equal(t, getBase64EncodedGzippedCustomScriptFromStr("#!/bin/bash\nLOCATION=westus\n"), script)
+
+	invalid := newTemplateGenerator(fstest.MapFS{
+		kubernetesNodeCustomDataYaml: &fstest.MapFile{Data: []byte("{{if}}")},
+		kubernetesCSEMainScript:      &fstest.MapFile{Data: []byte("{{Unknown}}")},
+	})
+	err := invalid.Precompile()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error parsing file linux/cloud-init/nodecustomdata.yml")
+	assert.Contains(t, err.Error(), `function "Unknown" not defined`)
+	assert.Equal(t, err, invalid.Precompile(), "precompiling is done once")
+}
+
+func TestTemplateGeneratorConcurrent(t *testing.T) {
+	generator := newTemplateGenerator(testTemplates(3))
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(location string) {
+			defer wg.Done()
+			assert.Equal(t, 3, strings.Count(renderTestCustomData(t, generator, location), "content: "+location+"\n"))
+			script := generator.getBase64EncodedGzippedCustomScript(context.Background(), kubernetesCSEStartScript, newTestConfig(location))
+			assert.Equal(t, getBase64EncodedGzippedCustomScriptFromStr("#!/bin/bash\nLOCATION="+location+"\n"), script)
+		}(fmt.Sprintf("location%d", i))
+	}
+	wg.Wait()
+}
+
+func BenchmarkGetSingleLine(b *testing.B) {
+	templates := testTemplates(500)
+	b.Run("parsed every time", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			renderTestCustomData(b, newTemplateGenerator(templates), "westus")
+		}
+	})
+	b.Run("precompiled", func(b *testing.B) {
+		generator := newTemplateGenerator(templates)
+		require.NoError(b, generator.Precompile())
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			renderTestCustomData(b, generator, "westus")
+		}
+	})
+	b.Run("precompiled parallel", func(b *testing.B) {
+		generator := newTemplateGenerator(templates)
+		require.NoError(b, generator.Precompile())
+		b.ReportAllocs()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				renderTestCustomData(b, generator, "westus")
+			}
+		})
+	})
+}
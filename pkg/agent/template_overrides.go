@@ -0,0 +1,152 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// TemplateOverride replaces an asset of a template bundle, or supplements the bundle with a new one.
+type TemplateOverride struct {
+	// Name is the path of the asset in the bundle, such as linux/cloud-init/artifacts/cse_config.sh.
+	Name    string
+	Content []byte
+	// Supplemental adds an asset the bundle doesn't have, in a directory it has. Overrides replace an existing asset
+	// otherwise.
+	Supplemental bool
+}
+
+// WithOverrides returns the bundle with the overrides applied. The overrides must replace assets the bundle has, or
+// supplement it with new ones, and parse as templates with the functions the assets are rendered with, so that a
+// typo fails here rather than when bootstrapping data is generated. The hash of the returned bundle covers the
+// overrides.
+func (b *TemplateBundle) WithOverrides(overrides ...TemplateOverride) (*TemplateBundle, error) {
+	files := map[string][]byte{}
+	for _, override := range overrides {
+		if err := validateTemplateOverride(b.Templates, override); err != nil {
+			return nil, fmt.Errorf("template override %s: %w", override.Name, err)
+		}
+		if _, ok := files[override.Name]; ok {
+			return nil, fmt.Errorf("template override %s: overridden more than once", override.Name)
+		}
+		files[override.Name] = override.Content
+	}
+	return NewTemplateBundle(&overlayFS{base: b.Templates, files: files})
+}
+
+func validateTemplateOverride(templates fs.FS, override TemplateOverride) error {
+	if !fs.ValidPath(override.Name) || override.Name == "." {
+		return errors.New("invalid asset name")
+	}
+	info, err := fs.Stat(templates, override.Name)
+	switch {
+	case err == nil && info.IsDir():
+		return errors.New("is a directory")
+	case err == nil && override.Supplemental:
+		return errors.New("supplements an existing asset")
+	case errors.Is(err, fs.ErrNotExist) && !override.Supplemental:
+		return errors.New("replaces an asset the bundle doesn't have")
+	case err != nil && !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+	if dir, err := fs.Stat(templates, path.Dir(override.Name)); err != nil || !dir.IsDir() {
+		return fmt.Errorf("directory %s isn't in the bundle", path.Dir(override.Name))
+	}
+	if !utf8.Valid(override.Content) {
+		// binary assets aren't rendered.
+		return nil
+	}
+	if _, err := template.New(override.Name).Funcs(templateFuncNames()).Parse(string(override.Content)); err != nil {
+		return fmt.Errorf("parse: %w", err)
+	}
+	return nil
+}
+
+// GetWithOverrides returns the bundle whose hash, once the overrides are applied, is the given one, or the current
+// bundle with the overrides when hash is empty.
+func (b *TemplateBundles) GetWithOverrides(hash string, overrides []TemplateOverride) (*TemplateBundle, error) {
+	current, err := b.current.WithOverrides(overrides...)
+	if err != nil {
+		return nil, err
+	}
+	if hash == "" || hash == current.Hash {
+		return current, nil
+	}
+	hashes := []string{current.Hash}
+	for _, bundle := range b.previous {
+		// the overrides may not apply to a previous bundle, which then can't have been pinned with them.
+		overridden, err := bundle.WithOverrides(overrides...)
+		if err != nil {
+			continue
+		}
+		if overridden.Hash == hash {
+			return overridden, nil
+		}
+		hashes = append(hashes, overridden.Hash)
+	}
+	return nil, fmt.Errorf("%w: %s, served bundles with overrides: %v", ErrTemplateBundleNotServed, hash, hashes)
+}
+
+// overlayFS is a file system whose files replace or supplement the files of base.
+type overlayFS struct {
+	base  fs.FS
+	files map[string][]byte
+}
+
+func (o *overlayFS) Open(name string) (fs.File, error) {
+	if content, ok := o.files[name]; ok {
+		return &overlayFile{name: path.Base(name), Reader: bytes.NewReader(content), size: int64(len(content))}, nil
+	}
+	return o.base.Open(name)
+}
+
+func (o *overlayFS) ReadFile(name string) ([]byte, error) {
+	if content, ok := o.files[name]; ok {
+		return bytes.Clone(content), nil
+	}
+	return fs.ReadFile(o.base, name)
+}
+
+// ReadDir lists the supplemental files with the files of base, which fs.WalkDir relies on.
+func (o *overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := fs.ReadDir(o.base, name)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		seen[entry.Name()] = true
+	}
+	for file, content := range o.files {
+		if path.Dir(file) == name && !seen[path.Base(file)] {
+			entries = append(entries, fs.FileInfoToDirEntry(&overlayFile{name: path.Base(file), size: int64(len(content))}))
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// overlayFile is a file of an overlayFS, and its fs.FileInfo.
+type overlayFile struct {
+	*bytes.Reader
+	name string
+	size int64
+}
+
+func (f *overlayFile) Stat() (fs.FileInfo, error) { return f, nil }
+func (f *overlayFile) Close() error               { return nil }
+func (f *overlayFile) Name() string               { return f.name }
+func (f *overlayFile) Size() int64                { return f.size }
+func (f *overlayFile) Mode() fs.FileMode          { return 0o444 }
+func (f *overlayFile) ModTime() time.Time         { return time.Time{} }
+func (f *overlayFile) IsDir() bool                { return false }
+func (f *overlayFile) Sys() any                   { return nil }
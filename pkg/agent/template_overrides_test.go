@@ -0,0 +1,131 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTemplateBundle(t *testing.T) *TemplateBundle {
+	bundle, err := NewTemplateBundle(fstest.MapFS{
+		"linux/cloud-init/artifacts/cse_config.sh":  &fstest.MapFile{Data: []byte("configure")},
+		"linux/cloud-init/artifacts/cse_install.sh": &fstest.MapFile{Data: []byte("install")},
+	})
+	require.NoError(t, err)
+	return bundle
+}
+
+func TestTemplateBundle_WithOverrides(t *testing.T) {
+	bundle := testTemplateBundle(t)
+
+	overridden, err := bundle.WithOverrides(
+		TemplateOverride{Name: "linux/cloud-init/artifacts/cse_config.sh", Content: []byte(`configure {{GetParameter "kubernetesVersion"}}`)},
+		TemplateOverride{Name: "linux/cloud-init/artifacts/cse_extra.sh", Content: []byte("extra"), Supplemental: true},
+	)
+	require.NoError(t, err)
+	assert.NotEqual(t, bundle.Hash, overridden.Hash)
+
+	content, err := fs.ReadFile(overridden.Templates, "linux/cloud-init/artifacts/cse_config.sh")
+	require.NoError(t, err)
+	assert.Equal(t, `configure {{GetParameter "kubernetesVersion"}}`, string(content))
+	content, err = fs.ReadFile(overridden.Templates, "linux/cloud-init/artifacts/cse_install.sh")
+	require.NoError(t, err)
+	assert.Equal(t, "install", string(content))
+	entries, err := fs.ReadDir(overridden.Templates, "linux/cloud-init/artifacts")
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{"cse_config.sh", "cse_extra.sh", "cse_install.sh"}, names)
+
+	// the same overrides give the same hash.
+	again, err := bundle.WithOverrides(
+		TemplateOverride{Name: "linux/cloud-init/artifacts/cse_extra.sh", Content: []byte("extra"), Supplemental: true},
+		TemplateOverride{Name: "linux/cloud-init/artifacts/cse_config.sh", Content: []byte(`configure {{GetParameter "kubernetesVersion"}}`)},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, overridden.Hash, again.Hash)
+}
+
+func TestTemplateBundle_WithOverrides_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides []TemplateOverride
+		wantErr   string
+	}{
+		{
+			name:      "invalid name",
+			overrides: []TemplateOverride{{Name: "../cse_config.sh"}},
+			wantErr:   "template override ../cse_config.sh: invalid asset name",
+		},
+		{
+			name:      "missing asset",
+			overrides: []TemplateOverride{{Name: "linux/cloud-init/artifacts/cse_main.sh"}},
+			wantErr:   "template override linux/cloud-init/artifacts/cse_main.sh: replaces an asset the bundle doesn't have",
+		},
+		{
+			name:      "existing supplemental asset",
+			overrides: []TemplateOverride{{Name: "linux/cloud-init/artifacts/cse_config.sh", Supplemental: true}},
+			wantErr:   "template override linux/cloud-init/artifacts/cse_config.sh: supplements an existing asset",
+		},
+		{
+			name:      "directory",
+			overrides: []TemplateOverride{{Name: "linux/cloud-init"}},
+			wantErr:   "template override linux/cloud-init: is a directory",
+		},
+		{
+			name:      "new directory",
+			overrides: []TemplateOverride{{Name: "windows/cse.ps1", Supplemental: true}},
+			wantErr:   "template override windows/cse.ps1: directory windows isn't in the bundle",
+		},
+		{
+			name: "overridden twice",
+			overrides: []TemplateOverride{
+				{Name: "linux/cloud-init/artifacts/cse_config.sh"},
+				{Name: "linux/cloud-init/artifacts/cse_config.sh"},
+			},
+			wantErr: "template override linux/cloud-init/artifacts/cse_config.sh: overridden more than once",
+		},
+		{
+			name:      "unknown function",
+			overrides: []TemplateOverride{{Name: "linux/cloud-init/artifacts/cse_config.sh", Content: []byte("{{GetParamter \"a\"}}")}},
+			wantErr:   `template override linux/cloud-init/artifacts/cse_config.sh: parse: template: linux/cloud-init/artifacts/cse_config.sh:1: function "GetParamter" not defined`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testTemplateBundle(t).WithOverrides(tt.overrides...)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTemplateBundles_GetWithOverrides(t *testing.T) {
+	previous := testTemplateBundle(t)
+	bundles, err := NewTemplateBundles([]*TemplateBundle{previous}, 1)
+	require.NoError(t, err)
+	overrides := []TemplateOverride{{Name: "extra.sh", Content: []byte("extra"), Supplemental: true}}
+
+	current, err := bundles.GetWithOverrides("", overrides)
+	require.NoError(t, err)
+	embedded, err := CurrentTemplateBundle()
+	require.NoError(t, err)
+	assert.NotEqual(t, embedded.Hash, current.Hash)
+
+	previousOverridden, err := previous.WithOverrides(overrides...)
+	require.NoError(t, err)
+	got, err := bundles.GetWithOverrides(previousOverridden.Hash, overrides)
+	require.NoError(t, err)
+	assert.Equal(t, previousOverridden.Hash, got.Hash)
+
+	// a bundle pinned without the overrides isn't served with them.
+	_, err = bundles.GetWithOverrides(previous.Hash, overrides)
+	assert.ErrorIs(t, err, ErrTemplateBundleNotServed)
+}
@@ -29,6 +29,12 @@ func NewDefaultToggles() Toggles {
 	return &defaultToggles{}
 }
 
+// IsDefault returns whether the toggles are the default ones, which resolve nothing.
+func IsDefault(t Toggles) bool {
+	_, ok := t.(*defaultToggles)
+	return ok
+}
+
 // NewEntityFromEnvironmentInfo constructs and returns a new Entity populated with fields
 // from the specified EnvironmentInfo.
 func NewEntityFromEnvironmentInfo(envInfo *datamodel.EnvironmentInfo) *Entity {
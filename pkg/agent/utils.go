@@ -6,20 +6,23 @@ package agent
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
-	"text/template"
+	"sync"
 
-	"github.com/Azure/agentbaker/parts"
+	"github.com/Azure/agentbaker/pkg/agent/catalog"
 	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/Azure/agentbaker/pkg/agent/kubeversion"
+	"github.com/Azure/agentbaker/pkg/agent/windowscontainerd"
 	"github.com/Azure/go-autorest/autorest/to"
-	"github.com/blang/semver"
 )
 
 /*
@@ -80,39 +83,47 @@ func addValue(m paramsMap, k string, v interface{}) {
 	}
 }
 
-func addKeyvaultReference(m paramsMap, k string, vaultID, secretName, secretVersion string) {
+func addKeyvaultReference(m paramsMap, k string, ref *datamodel.KeyVaultRef) {
 	m[k] = paramsMap{
-		"reference": &datamodel.KeyVaultRef{
-			KeyVault: datamodel.KeyVaultID{
-				ID: vaultID,
-			},
-			SecretName:    secretName,
-			SecretVersion: secretVersion,
-		},
+		"reference": ref,
 	}
 }
 
-//nolint:unparam,nolintlint
-func addSecret(m paramsMap, k string, v interface{}, encode bool) {
-	str, ok := v.(string)
-	if !ok {
-		addValue(m, k, v)
-		return
-	}
-	keyvaultSecretPathRe := regexp.MustCompile(`^(/subscriptions/\S+/resourceGroups/\S+/providers/Microsoft.KeyVault/vaults/\S+)/secrets/([^/\s]+)(/(\S+))?$`) //nolint:lll
-	parts := keyvaultSecretPathRe.FindStringSubmatch(str)
-	if parts == nil || len(parts) != 5 {
-		if encode {
-			addValue(m, k, base64.StdEncoding.EncodeToString([]byte(str)))
-		} else {
-			addValue(m, k, str)
+// findExtensionProfile returns the extension profile an extension references.
+func findExtensionProfile(extension *datamodel.Extension, extensionProfiles []*datamodel.ExtensionProfile) (*datamodel.ExtensionProfile, error) {
+	for _, eP := range extensionProfiles {
+		if strings.EqualFold(eP.Name, extension.Name) {
+			return eP, nil
 		}
-		return
 	}
-	addKeyvaultReference(m, k, parts[1], parts[2], parts[4])
+	return nil, fmt.Errorf("%s extension referenced was not found in the extension profile", extension.Name)
+}
+
+// validateExtensionReferences checks that the extension profiles have every extension the agent pools reference, and
+// reports all the unresolved references at once.
+func validateExtensionReferences(config *datamodel.NodeBootstrappingConfiguration) error {
+	profiles := append([]*datamodel.AgentPoolProfile{config.AgentPoolProfile}, config.ContainerService.Properties.AgentPoolProfiles...)
+	var unresolved []string
+	seen := map[string]bool{}
+	for _, profile := range profiles {
+		if profile == nil || profile.PreprovisionExtension == nil || seen[profile.Name] {
+			continue
+		}
+		seen[profile.Name] = true
+		if _, err := findExtensionProfile(profile.PreprovisionExtension, config.ContainerService.Properties.ExtensionProfiles); err != nil {
+			unresolved = append(unresolved, fmt.Sprintf("%s (agent pool %s)", profile.PreprovisionExtension.Name, profile.Name))
+		}
+	}
+	if len(unresolved) > 0 {
+		return fmt.Errorf("extensions referenced but not found in the extension profiles: %s", strings.Join(unresolved, ", "))
+	}
+	return nil
 }
 
-func makeAgentExtensionScriptCommands(cs *datamodel.ContainerService, profile *datamodel.AgentPoolProfile) string {
+func makeAgentExtensionScriptCommands(ctx context.Context, cs *datamodel.ContainerService, profile *datamodel.AgentPoolProfile) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("make the commands of extension %s: %w", profile.PreprovisionExtension.Name, err)
+	}
 	if profile.OSType == datamodel.Windows {
 		return makeWindowsExtensionScriptCommands(profile.PreprovisionExtension,
 			cs.Properties.ExtensionProfiles)
@@ -121,17 +132,10 @@ func makeAgentExtensionScriptCommands(cs *datamodel.ContainerService, profile *d
 		"", cs.Properties.ExtensionProfiles)
 }
 
-func makeExtensionScriptCommands(extension *datamodel.Extension, curlCaCertOpt string, extensionProfiles []*datamodel.ExtensionProfile) string {
-	var extensionProfile *datamodel.ExtensionProfile
-	for _, eP := range extensionProfiles {
-		if strings.EqualFold(eP.Name, extension.Name) {
-			extensionProfile = eP
-			break
-		}
-	}
-
-	if extensionProfile == nil {
-		panic(fmt.Sprintf("%s extension referenced was not found in the extension profile", extension.Name))
+func makeExtensionScriptCommands(extension *datamodel.Extension, curlCaCertOpt string, extensionProfiles []*datamodel.ExtensionProfile) (string, error) {
+	extensionProfile, err := findExtensionProfile(extension, extensionProfiles)
+	if err != nil {
+		return "", err
 	}
 
 	extensionsParameterReference := fmt.Sprintf("parameters('%sParameters')", extensionProfile.Name)
@@ -140,27 +144,20 @@ func makeExtensionScriptCommands(extension *datamodel.Extension, curlCaCertOpt s
 	scriptFilePath := fmt.Sprintf("/opt/azure/containers/extensions/%s/%s", extensionProfile.Name, extensionProfile.Script)
 	return fmt.Sprintf("- sudo /usr/bin/curl --retry 5 --retry-delay 10 --retry-max-time 30 -o %s --create-dirs %s \"%s\" \n- sudo /bin/"+
 		"chmod 744 %s \n- sudo %s ',%s,' > /var/log/%s-output.log", scriptFilePath, curlCaCertOpt, scriptURL, scriptFilePath, scriptFilePath,
-		extensionsParameterReference, extensionProfile.Name)
+		extensionsParameterReference, extensionProfile.Name), nil
 }
 
-func makeWindowsExtensionScriptCommands(extension *datamodel.Extension, extensionProfiles []*datamodel.ExtensionProfile) string {
-	var extensionProfile *datamodel.ExtensionProfile
-	for _, eP := range extensionProfiles {
-		if strings.EqualFold(eP.Name, extension.Name) {
-			extensionProfile = eP
-			break
-		}
-	}
-
-	if extensionProfile == nil {
-		panic(fmt.Sprintf("%s extension referenced was not found in the extension profile", extension.Name))
+func makeWindowsExtensionScriptCommands(extension *datamodel.Extension, extensionProfiles []*datamodel.ExtensionProfile) (string, error) {
+	extensionProfile, err := findExtensionProfile(extension, extensionProfiles)
+	if err != nil {
+		return "", err
 	}
 
 	scriptURL := getExtensionURL(extensionProfile.RootURL, extensionProfile.Name, extensionProfile.Version, extensionProfile.Script,
 		extensionProfile.URLQuery)
 	scriptFileDir := fmt.Sprintf("$env:SystemDrive:/AzureData/extensions/%s", extensionProfile.Name)
 	scriptFilePath := fmt.Sprintf("%s/%s", scriptFileDir, extensionProfile.Script)
-	return fmt.Sprintf("New-Item -ItemType Directory -Force -Path \"%s\" ; curl.exe --retry 5 --retry-delay 0 -L \"%s\" -o \"%s\" ; powershell \"%s `\"',parameters('%sParameters'),'`\"\"\n", scriptFileDir, scriptURL, scriptFilePath, scriptFilePath, extensionProfile.Name) //nolint:lll
+	return fmt.Sprintf("New-Item -ItemType Directory -Force -Path \"%s\" ; curl.exe --retry 5 --retry-delay 0 -L \"%s\" -o \"%s\" ; powershell \"%s `\"',parameters('%sParameters'),'`\"\"\n", scriptFileDir, scriptURL, scriptFilePath, scriptFilePath, extensionProfile.Name), nil //nolint:lll
 }
 
 func escapeSingleLine(escapedStr string) string {
@@ -172,30 +169,25 @@ func escapeSingleLine(escapedStr string) string {
 	return escapedStr
 }
 
-// getBase64EncodedGzippedCustomScript will return a base64 of the CSE.
-func getBase64EncodedGzippedCustomScript(csFilename string, config *datamodel.NodeBootstrappingConfiguration) string {
-	b, err := parts.Templates.ReadFile(csFilename)
+// getBase64EncodedGzippedCustomScript will return a base64 of the CSE. The script is gzipped and encoded as the
+// template executes, without buffering it.
+func (t *TemplateGenerator) getBase64EncodedGzippedCustomScript(ctx context.Context, csFilename string, config *datamodel.NodeBootstrappingConfiguration) string {
+	templ, err := t.customScriptTemplate(csFilename)
 	if err != nil {
 		// this should never happen and this is a bug.
 		panic(fmt.Sprintf("BUG: %s", err.Error()))
 	}
-	// translate the parameters.
-	b = removeComments(b)
-	templ := template.New("ContainerService template").Option("missingkey=error").Funcs(getContainerServiceFuncMap(config))
-	_, err = templ.Parse(string(b))
-	if err != nil {
-		// this should never happen and this is a bug.
-		panic(fmt.Sprintf("BUG: %s", err.Error()))
+	encoder := newGzipBase64Encoder()
+	w := &crlfWriter{w: encoder}
+	err = templ.Funcs(t.getContainerServiceFuncMap(ctx, config)).Execute(w, config.ContainerService)
+	if err == nil {
+		err = w.Flush()
 	}
-	var buffer bytes.Buffer
-	err = templ.Execute(&buffer, config.ContainerService)
 	if err != nil {
 		// this should never happen and this is a bug.
 		panic(fmt.Sprintf("BUG: %s", err.Error()))
 	}
-	csStr := buffer.String()
-	csStr = strings.ReplaceAll(csStr, "\r\n", "\n")
-	return getBase64EncodedGzippedCustomScriptFromStr(csStr)
+	return encoder.String()
 }
 
 // This is "best-effort" - removes MOST of the comments with obvious formats, to lower the space required by CustomData component.
@@ -244,25 +236,99 @@ func isCommentAtTheEndOfLine(lastHashIndex int, trimmedToCheck string) bool {
 	return getSlice(lastHashIndex-1, lastHashIndex+1, trimmedToCheck) != "<#" && getSlice(lastHashIndex, lastHashIndex+tailingCommentSegmentLen, trimmedToCheck) == "# "
 }
 
-func newGzipWriter(buf *bytes.Buffer) *gzip.Writer {
-	writer, err := gzip.NewWriterLevel(buf, gzip.BestCompression)
-	if err == nil {
-		return writer
+// gzipWriters are reused as each one allocates the large buffers of the best compression.
+//
+//nolint:gochecknoglobals
+var gzipWriters = sync.Pool{New: func() any {
+	writer, err := gzip.NewWriterLevel(nil, gzip.BestCompression)
+	if err != nil {
+		return gzip.NewWriter(nil)
 	}
-	return gzip.NewWriter(buf)
+	return writer
+}}
+
+// gzipBase64Encoder gzips and base64 encodes what is written to it as it goes. The gzip header has no name nor
+// modification time, so the same data always gives the same string.
+type gzipBase64Encoder struct {
+	encoded strings.Builder
+	base64  io.WriteCloser
+	gzip    *gzip.Writer
+}
+
+func newGzipBase64Encoder() *gzipBase64Encoder {
+	e := &gzipBase64Encoder{}
+	e.base64 = base64.NewEncoder(base64.StdEncoding, &e.encoded)
+	e.gzip = gzipWriters.Get().(*gzip.Writer) //nolint:errcheck // the pool only has gzip writers.
+	e.gzip.Reset(e.base64)
+	return e
+}
+
+func (e *gzipBase64Encoder) Write(p []byte) (int, error) {
+	return e.gzip.Write(p)
+}
+
+// String flushes the encoder and returns the encoded data, the encoder can't be written to afterwards.
+func (e *gzipBase64Encoder) String() string {
+	// neither writer fails as the encoded data is written to memory.
+	_ = e.gzip.Close()
+	_ = e.base64.Close()
+	gzipWriters.Put(e.gzip)
+	e.gzip = nil
+	return e.encoded.String()
+}
+
+// crlfWriter converts the CRLF line endings written to it to LF.
+type crlfWriter struct {
+	w io.Writer
+	// cr is set when the last byte written was a CR, which may start a CRLF split across writes.
+	cr bool
+}
+
+func (c *crlfWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if n == 0 {
+		return 0, nil
+	}
+	if c.cr {
+		c.cr = false
+		if p[0] != '\n' {
+			if _, err := c.w.Write([]byte{'\r'}); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if p[len(p)-1] == '\r' {
+		c.cr = true
+		p = p[:len(p)-1]
+	}
+	if bytes.Contains(p, []byte("\r\n")) {
+		p = bytes.ReplaceAll(p, []byte("\r\n"), []byte("\n"))
+	}
+	if _, err := c.w.Write(p); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// Flush writes the CR ending the data written.
+func (c *crlfWriter) Flush() error {
+	if !c.cr {
+		return nil
+	}
+	c.cr = false
+	_, err := c.w.Write([]byte{'\r'})
+	return err
 }
 
 // getBase64EncodedGzippedCustomScriptFromStr will return a base64-encoded string of the gzip'd source data.
+// The gzip header has no name nor modification time, so the same data always gives the same string.
 func getBase64EncodedGzippedCustomScriptFromStr(str string) string {
-	var gzipB bytes.Buffer
-	w := newGzipWriter(&gzipB)
-	_, err := w.Write([]byte(str))
-	if err != nil {
+	encoder := newGzipBase64Encoder()
+	if _, err := io.WriteString(encoder, str); err != nil {
 		// this should never happen and this is a bug.
 		panic(fmt.Sprintf("BUG: %s", err.Error()))
 	}
-	w.Close()
-	return base64.StdEncoding.EncodeToString(gzipB.Bytes())
+	return encoder.String()
 }
 
 func getExtensionURL(rootURL, extensionName, version, fileName, query string) string {
@@ -314,11 +380,11 @@ func GetCloudTargetEnv(location string) string {
 	}
 }
 
-// IsKubernetesVersionGe returns true if actualVersion is greater than or equal to version.
+// IsKubernetesVersionGe returns true if actualVersion is greater than or equal to version. actualVersion is parsed
+// leniently, and false is returned when either version can't be parsed, use kubeversion.GreaterOrEqual to get the error.
 func IsKubernetesVersionGe(actualVersion, version string) bool {
-	v1, _ := semver.Make(actualVersion)
-	v2, _ := semver.Make(version)
-	return v1.GE(v2)
+	ge, err := kubeversion.GreaterOrEqual(actualVersion, version)
+	return err == nil && ge
 }
 
 func getCustomDataFromJSON(jsonStr string) string {
@@ -527,6 +593,12 @@ func setCustomKubeletConfig(customKc *datamodel.CustomKubeletConfig,
 		if customKc.SeccompDefault != nil {
 			kubeletConfig.SeccompDefault = customKc.SeccompDefault
 		}
+		if customKc.ShutdownGracePeriod != "" {
+			kubeletConfig.ShutdownGracePeriod = datamodel.Duration(customKc.ShutdownGracePeriod)
+		}
+		if customKc.ShutdownGracePeriodCriticalPods != "" {
+			kubeletConfig.ShutdownGracePeriodCriticalPods = datamodel.Duration(customKc.ShutdownGracePeriodCriticalPods)
+		}
 	}
 }
 
@@ -677,3 +749,103 @@ func addFeatureGateString(featureGates string, key string, value bool) string {
 	}
 	return strings.Join(pairs, ",")
 }
+
+// windowsBuilds maps the Windows containerd distros to their Windows Server build.
+var windowsBuilds = map[datamodel.Distro]windowscontainerd.Build{
+	datamodel.AKSWindows2019Containerd:     windowscontainerd.Build2019,
+	datamodel.AKSWindows2022Containerd:     windowscontainerd.Build2022,
+	datamodel.AKSWindows2022ContainerdGen2: windowscontainerd.Build2022,
+	datamodel.AKSWindows23H2:               windowscontainerd.Build23H2,
+	datamodel.AKSWindows23H2Gen2:           windowscontainerd.Build23H2,
+}
+
+// getWindowsContainerdConfigContent returns the base64 encoded containerd config.toml of a Windows node,
+// built from the defaults of the node's Windows Server build.
+func getWindowsContainerdConfigContent(cs *datamodel.ContainerService, profile *datamodel.AgentPoolProfile) (string, error) {
+	build, ok := windowsBuilds[profile.Distro]
+	if !ok {
+		return "", fmt.Errorf("distro %q has no containerd Windows build", profile.Distro)
+	}
+	windowsProfile := cs.Properties.WindowsProfile
+	options := windowscontainerd.Options{
+		Build:                   build,
+		PauseImage:              windowsProfile.WindowsPauseImageURL,
+		CNIBinDir:               `c:\k\azurecni\bin`,
+		CNIConfDir:              `c:\k\azurecni\netconf`,
+		DefaultSandboxIsolation: windowsProfile.GetDefaultContainerdWindowsSandboxIsolation(),
+	}
+	if windowsProfile.ContainerdWindowsRuntimes != nil {
+		for _, handler := range windowsProfile.ContainerdWindowsRuntimes.RuntimeHandlers {
+			options.RuntimeHandlers = append(options.RuntimeHandlers, windowscontainerd.Build(handler.BuildNumber))
+		}
+	}
+	config, err := windowscontainerd.New(options)
+	if err != nil {
+		return "", fmt.Errorf("build windows containerd config: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(config.MarshalTOML()), nil
+}
+
+// supportsAcceleratedNetworking returns false for the VM sizes known not to support accelerated networking:
+// the basic tier, the A series and the first generation of the B series.
+func supportsAcceleratedNetworking(vmSize string) bool {
+	size := strings.ToLower(vmSize)
+	switch {
+	case strings.HasPrefix(size, "basic_"), strings.HasPrefix(size, "standard_a"):
+		return false
+	case strings.HasPrefix(size, "standard_b") && !strings.Contains(size, "_v"):
+		return false
+	}
+	return true
+}
+
+// arm64VMSizeRegex matches the VM sizes with Arm-based processors, which have the p additive feature, such as
+// Standard_D4pds_v5.
+var arm64VMSizeRegex = regexp.MustCompile(`(?i)^standard_[a-z]+\d+(-\d+)?[a-z]*p[a-z]*_v\d+$`)
+
+func isARM64VMSize(vmSize string) bool {
+	return arm64VMSizeRegex.MatchString(vmSize)
+}
+
+func isKubenet(cs *datamodel.ContainerService) bool {
+	return cs != nil && cs.Properties != nil && cs.Properties.OrchestratorProfile != nil &&
+		cs.Properties.OrchestratorProfile.KubernetesConfig != nil &&
+		cs.Properties.OrchestratorProfile.KubernetesConfig.NetworkPlugin == NetworkPluginKubenet
+}
+
+// isIPForwardingEnabled returns whether the NICs of the pool forward traffic, which defaults to true with kubenet.
+func isIPForwardingEnabled(cs *datamodel.ContainerService, profile *datamodel.AgentPoolProfile) bool {
+	if profile != nil && profile.EnableIPForwarding != nil {
+		return *profile.EnableIPForwarding
+	}
+	return isKubenet(cs)
+}
+
+// catalogQuery returns the component catalog query of a request, the OS, release and architecture are derived from
+// the distro.
+func catalogQuery(request *datamodel.GetComponentVersionsRequest) catalog.Query {
+	query := catalog.Query{
+		Component:         request.Component,
+		Arch:              request.Arch,
+		KubernetesVersion: request.KubernetesVersion,
+	}
+	distro := string(request.Distro)
+	switch {
+	case distro == "":
+	case strings.Contains(distro, "azurelinux"):
+		query.OS = "azurelinux"
+	case strings.Contains(distro, "mariner"):
+		query.OS = "mariner"
+	case strings.Contains(distro, "ubuntu"):
+		query.OS = "ubuntu"
+		for _, release := range []string{"18.04", "20.04", "22.04", "24.04"} {
+			if strings.Contains(distro, release) {
+				query.Release = "r" + strings.ReplaceAll(release, ".", "")
+			}
+		}
+	}
+	if query.Arch == "" && strings.Contains(distro, "arm64") {
+		query.Arch = catalog.ArchARM64
+	}
+	return query
+}
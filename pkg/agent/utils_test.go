@@ -4,13 +4,24 @@
 package agent
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"io"
+	"strings"
 	"testing"
+	"testing/fstest"
+	"text/template"
 
+	"github.com/Azure/agentbaker/pkg/agent/catalog"
 	"github.com/Azure/agentbaker/pkg/agent/datamodel"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -838,3 +849,260 @@ var _ = Describe("Test removeComments", func() {
 	})
 
 })
+
+func TestGetWindowsContainerdConfigContent(t *testing.T) {
+	cs := &datamodel.ContainerService{Properties: &datamodel.Properties{WindowsProfile: &datamodel.WindowsProfile{
+		WindowsPauseImageURL: "mcr.microsoft.com/oss/kubernetes/pause:3.9",
+		ContainerdWindowsRuntimes: &datamodel.ContainerdWindowsRuntimes{
+			RuntimeHandlers: []datamodel.RuntimeHandlers{{BuildNumber: "17763"}},
+		},
+	}}}
+	content, err := getWindowsContainerdConfigContent(cs, &datamodel.AgentPoolProfile{Distro: datamodel.AKSWindows2022Containerd})
+	assert.NoError(t, err)
+	toml, err := base64.StdEncoding.DecodeString(content)
+	assert.NoError(t, err)
+	assert.Contains(t, string(toml), `sandbox_image = "mcr.microsoft.com/oss/kubernetes/pause:3.9-windows-10.0.20348-amd64"`)
+	assert.Contains(t, string(toml), `[plugins."io.containerd.grpc.v1.cri".containerd.runtimes."runhcs-wcow-hypervisor-17763"]`)
+
+	_, err = getWindowsContainerdConfigContent(cs, &datamodel.AgentPoolProfile{Distro: datamodel.AKSWindows2019})
+	assert.Error(t, err)
+}
+
+func TestValidateNICFeatures(t *testing.T) {
+	kubenet := &datamodel.ContainerService{Properties: &datamodel.Properties{OrchestratorProfile: &datamodel.OrchestratorProfile{
+		KubernetesConfig: &datamodel.KubernetesConfig{NetworkPlugin: NetworkPluginKubenet},
+	}}}
+	tests := []struct {
+		name    string
+		cs      *datamodel.ContainerService
+		profile *datamodel.AgentPoolProfile
+		wantErr string
+	}{
+		{
+			name:    "accelerated networking on a supported size",
+			profile: &datamodel.AgentPoolProfile{VMSize: "Standard_D4s_v5", EnableAcceleratedNetworking: to.BoolPtr(true)},
+		},
+		{
+			name:    "accelerated networking on a B series v2 size",
+			profile: &datamodel.AgentPoolProfile{VMSize: "Standard_B4ls_v2", EnableAcceleratedNetworking: to.BoolPtr(true)},
+		},
+		{
+			name:    "accelerated networking on an A series size",
+			profile: &datamodel.AgentPoolProfile{VMSize: "Standard_A2_v2", EnableAcceleratedNetworking: to.BoolPtr(true)},
+			wantErr: "VM size Standard_A2_v2 doesn't support accelerated networking",
+		},
+		{
+			name:    "accelerated networking disabled on a B series size",
+			profile: &datamodel.AgentPoolProfile{VMSize: "Standard_B2ms", EnableAcceleratedNetworking: to.BoolPtr(false)},
+		},
+		{
+			name:    "IP forwarding disabled with kubenet",
+			cs:      kubenet,
+			profile: &datamodel.AgentPoolProfile{VMSize: "Standard_D4s_v5", EnableIPForwarding: to.BoolPtr(false)},
+			wantErr: "kubenet requires IP forwarding on the NIC",
+		},
+		{
+			name:    "InfiniBand on an RDMA capable size",
+			profile: &datamodel.AgentPoolProfile{VMSize: "Standard_HB120rs_v3", EnableInfiniBand: to.BoolPtr(true)},
+		},
+		{
+			name:    "InfiniBand on a size without an InfiniBand NIC",
+			profile: &datamodel.AgentPoolProfile{VMSize: "Standard_NC24ads_A100_v4", EnableInfiniBand: to.BoolPtr(true)},
+			wantErr: "VM size Standard_NC24ads_A100_v4 doesn't have an InfiniBand NIC",
+		},
+		{
+			name: "InfiniBand on Windows",
+			profile: &datamodel.AgentPoolProfile{VMSize: "Standard_ND96asr_v4", OSType: datamodel.Windows,
+				EnableInfiniBand: to.BoolPtr(true)},
+			wantErr: "InfiniBand isn't supported on Windows nodes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateNICFeatures(tt.cs, tt.profile)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+
+	assert.True(t, isIPForwardingEnabled(kubenet, &datamodel.AgentPoolProfile{}))
+	assert.False(t, isIPForwardingEnabled(&datamodel.ContainerService{}, &datamodel.AgentPoolProfile{}))
+}
+
+func TestCatalogQuery(t *testing.T) {
+	tests := []struct {
+		request datamodel.GetComponentVersionsRequest
+		want    catalog.Query
+	}{
+		{
+			request: datamodel.GetComponentVersionsRequest{Component: "runc"},
+			want:    catalog.Query{Component: "runc"},
+		},
+		{
+			request: datamodel.GetComponentVersionsRequest{Distro: datamodel.AKSUbuntuContainerd2204Gen2, KubernetesVersion: "1.30.1"},
+			want:    catalog.Query{OS: "ubuntu", Release: "r2204", KubernetesVersion: "1.30.1"},
+		},
+		{
+			request: datamodel.GetComponentVersionsRequest{Distro: datamodel.AKSUbuntuArm64Containerd2404Gen2},
+			want:    catalog.Query{OS: "ubuntu", Release: "r2404", Arch: catalog.ArchARM64},
+		},
+		{
+			request: datamodel.GetComponentVersionsRequest{Distro: datamodel.AKSAzureLinuxV3Arm64Gen2, Arch: catalog.ArchAMD64},
+			want:    catalog.Query{OS: "azurelinux", Arch: catalog.ArchAMD64},
+		},
+		{
+			request: datamodel.GetComponentVersionsRequest{Distro: datamodel.AKSCBLMarinerV2Gen2},
+			want:    catalog.Query{OS: "mariner"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.request.Distro), func(t *testing.T) {
+			assert.Equal(t, tt.want, catalogQuery(&tt.request))
+		})
+	}
+}
+
+func TestValidateKeyVaultReferences(t *testing.T) {
+	const vaultID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/my-vault"
+	assert.NoError(t, validateKeyVaultReferences(nil))
+	assert.NoError(t, validateKeyVaultReferences(&datamodel.CertificateProfile{
+		CaCertificate:        "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0t",
+		APIServerCertificate: vaultID + "/certificates/apiserver",
+		ClientPrivateKey:     "/tenants/tenant/" + vaultID[1:] + "/secrets/client-key",
+	}))
+	assert.EqualError(t, validateKeyVaultReferences(&datamodel.CertificateProfile{ClientCertificate: vaultID + "/keys/client"}),
+		"clientCertificate references key client, only secrets and certificates can be used")
+	assert.EqualError(t, validateKeyVaultReferences(&datamodel.CertificateProfile{CaCertificate: vaultID + "/secrets/ca/v1.0"}),
+		`caCertificate: invalid Key Vault object ID "`+vaultID+`/secrets/ca/v1.0": invalid object version "v1.0"`)
+}
+
+func TestValidateExtensionReferences(t *testing.T) {
+	hello := &datamodel.Extension{Name: "hello"}
+	missing := &datamodel.Extension{Name: "missing"}
+	config := &datamodel.NodeBootstrappingConfiguration{
+		ContainerService: &datamodel.ContainerService{Properties: &datamodel.Properties{
+			ExtensionProfiles: []*datamodel.ExtensionProfile{{Name: "Hello", Version: "v1", RootURL: "https://example.com/", Script: "hello.sh"}},
+			AgentPoolProfiles: []*datamodel.AgentPoolProfile{
+				{Name: "nodepool1", PreprovisionExtension: hello},
+				{Name: "nodepool2", PreprovisionExtension: missing},
+				{Name: "npwin", OSType: datamodel.Windows, PreprovisionExtension: &datamodel.Extension{Name: "other"}},
+			},
+		}},
+		AgentPoolProfile: &datamodel.AgentPoolProfile{Name: "nodepool1", PreprovisionExtension: hello},
+	}
+	assert.EqualError(t, validateExtensionReferences(config),
+		"extensions referenced but not found in the extension profiles: missing (agent pool nodepool2), other (agent pool npwin)")
+
+	commands, err := makeAgentExtensionScriptCommands(context.Background(), config.ContainerService, config.AgentPoolProfile)
+	assert.NoError(t, err)
+	assert.Contains(t, commands, "/opt/azure/containers/extensions/Hello/hello.sh")
+	_, err = makeAgentExtensionScriptCommands(context.Background(), config.ContainerService, config.ContainerService.Properties.AgentPoolProfiles[2])
+	assert.EqualError(t, err, "other extension referenced was not found in the extension profile")
+
+	config.ContainerService.Properties.AgentPoolProfiles = config.ContainerService.Properties.AgentPoolProfiles[:1]
+	assert.NoError(t, validateExtensionReferences(config))
+}
+
+func TestValidateOrchestratorVersion(t *testing.T) {
+	assert.NoError(t, validateOrchestratorVersion(nil))
+	assert.NoError(t, validateOrchestratorVersion(&datamodel.OrchestratorProfile{OrchestratorVersion: "1.29.2"}))
+	assert.NoError(t, validateOrchestratorVersion(&datamodel.OrchestratorProfile{OrchestratorVersion: "v1.28"}))
+	assert.EqualError(t, validateOrchestratorVersion(&datamodel.OrchestratorProfile{OrchestratorVersion: "1.29.x"}),
+		`invalid Kubernetes version: invalid version "1.29.x": Invalid character(s) found in patch number "x"`)
+
+	assert.True(t, IsKubernetesVersionGe("1.28", "1.28.0"))
+	assert.True(t, IsKubernetesVersionGe("v1.29.0+build", "1.29.0"))
+	assert.False(t, IsKubernetesVersionGe("1.30.0", "1.30"), "the minimum version must be strict")
+}
+
+func TestGzipBase64Encoder(t *testing.T) {
+	reference := func(data string) string {
+		var b bytes.Buffer
+		w, err := gzip.NewWriterLevel(&b, gzip.BestCompression)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(data))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		return base64.StdEncoding.EncodeToString(b.Bytes())
+	}
+	for _, data := range []string{"", "#cloud-config\n", strings.Repeat("write_files:\n- path: /etc/kubernetes/azure.json\n", 5000)} {
+		// the gzip writers of the pool are reset, so the second encoding is the same.
+		assert.Equal(t, reference(data), getBase64EncodedGzippedCustomScriptFromStr(data))
+		assert.Equal(t, reference(data), getBase64EncodedGzippedCustomScriptFromStr(data))
+	}
+}
+
+func TestCRLFWriter(t *testing.T) {
+	var b bytes.Buffer
+	w := &crlfWriter{w: &b}
+	for _, p := range []string{"a\r\nb\r", "\nc\r", "d\r", "", "\n\r\n\r"} {
+		n, err := w.Write([]byte(p))
+		require.NoError(t, err)
+		assert.Equal(t, len(p), n)
+	}
+	require.NoError(t, w.Flush())
+	assert.Equal(t, "a\nb\nc\rd\n\n\r", b.String())
+}
+
+func TestGetBase64EncodedGzippedCustomScript(t *testing.T) {
+	templates := fstest.MapFS{
+		"linux/cse_helpers.sh": &fstest.MapFile{Data: []byte("#!/bin/bash\r\n# comment\r\nLOCATION={{.Location}}\r\n")},
+	}
+	generator := newTemplateGenerator(templates)
+	newConfig := func(location string) *datamodel.NodeBootstrappingConfiguration {
+		return &datamodel.NodeBootstrappingConfiguration{
+			ContainerService: &datamodel.ContainerService{Location: location, Properties: &datamodel.Properties{}},
+			AgentPoolProfile: &datamodel.AgentPoolProfile{},
+		}
+	}
+	decode := func(encoded string) string {
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		require.NoError(t, err)
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		require.NoError(t, err)
+		script, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(script)
+	}
+
+	ctx := context.Background()
+	assert.Equal(t, "#!/bin/bash\nLOCATION=westus\n", decode(generator.getBase64EncodedGzippedCustomScript(ctx, "linux/cse_helpers.sh", newConfig("westus"))))
+	// the template is parsed once, the cached template is executed with each configuration.
+	templates["linux/cse_helpers.sh"].Data = []byte("changed")
+	assert.Equal(t, "#!/bin/bash\nLOCATION=eastus\n", decode(generator.getBase64EncodedGzippedCustomScript(ctx, "linux/cse_helpers.sh", newConfig("eastus"))))
+}
+
+func TestTemplateCache(t *testing.T) {
+	var cache templateCache
+	parses := 0
+	parse := func() (*template.Template, error) {
+		parses++
+		return template.New("t").Funcs(template.FuncMap{"Name": func() string { return "" }}).Parse("{{Name}}")
+	}
+	execute := func(name string) string {
+		templ, err := cache.get("t", parse)
+		require.NoError(t, err)
+		var b strings.Builder
+		require.NoError(t, templ.Funcs(template.FuncMap{"Name": func() string { return name }}).Execute(&b, nil))
+		return b.String()
+	}
+	assert.Equal(t, "first", execute("first"))
+	assert.Equal(t, "second", execute("second"))
+	assert.Equal(t, 1, parses)
+
+	_, err := cache.get("invalid", func() (*template.Template, error) { return nil, errors.New("parse failed") })
+	assert.EqualError(t, err, "parse failed")
+	_, err = cache.get("invalid", parse)
+	assert.NoError(t, err, "parsing errors aren't cached")
+}
+
+func BenchmarkGetBase64EncodedGzippedCustomScriptFromStr(b *testing.B) {
+	customData := strings.Repeat("write_files:\n- path: /etc/kubernetes/azure.json\n  content: {}\n", 2000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		getBase64EncodedGzippedCustomScriptFromStr(customData)
+	}
+}
@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -12,47 +13,47 @@ import (
 )
 
 // getCustomDataVariables returns cloudinit data used by Linux.
-func getCustomDataVariables(config *datamodel.NodeBootstrappingConfiguration) paramsMap {
+func (t *TemplateGenerator) getCustomDataVariables(ctx context.Context, config *datamodel.NodeBootstrappingConfiguration) paramsMap {
 	cs := config.ContainerService
 	cloudInitFiles := map[string]interface{}{
 		"cloudInitData": paramsMap{
-			"provisionStartScript":         getBase64EncodedGzippedCustomScript(kubernetesCSEStartScript, config),
-			"provisionScript":              getBase64EncodedGzippedCustomScript(kubernetesCSEMainScript, config),
-			"provisionSource":              getBase64EncodedGzippedCustomScript(kubernetesCSEHelpersScript, config),
-			"provisionSourceUbuntu":        getBase64EncodedGzippedCustomScript(kubernetesCSEHelpersScriptUbuntu, config),
-			"provisionSourceMariner":       getBase64EncodedGzippedCustomScript(kubernetesCSEHelpersScriptMariner, config),
-			"provisionInstalls":            getBase64EncodedGzippedCustomScript(kubernetesCSEInstall, config),
-			"provisionInstallsUbuntu":      getBase64EncodedGzippedCustomScript(kubernetesCSEInstallUbuntu, config),
-			"provisionInstallsMariner":     getBase64EncodedGzippedCustomScript(kubernetesCSEInstallMariner, config),
-			"provisionConfigs":             getBase64EncodedGzippedCustomScript(kubernetesCSEConfig, config),
-			"provisionSendLogs":            getBase64EncodedGzippedCustomScript(kubernetesCSESendLogs, config),
-			"provisionRedactCloudConfig":   getBase64EncodedGzippedCustomScript(kubernetesCSERedactCloudConfig, config),
-			"customSearchDomainsScript":    getBase64EncodedGzippedCustomScript(kubernetesCustomSearchDomainsScript, config),
-			"dhcpv6SystemdService":         getBase64EncodedGzippedCustomScript(dhcpv6SystemdService, config),
-			"dhcpv6ConfigurationScript":    getBase64EncodedGzippedCustomScript(dhcpv6ConfigurationScript, config),
-			"kubeletSystemdService":        getBase64EncodedGzippedCustomScript(kubeletSystemdService, config),
-			"reconcilePrivateHostsScript":  getBase64EncodedGzippedCustomScript(reconcilePrivateHostsScript, config),
-			"reconcilePrivateHostsService": getBase64EncodedGzippedCustomScript(reconcilePrivateHostsService, config),
-			"ensureNoDupEbtablesScript":    getBase64EncodedGzippedCustomScript(ensureNoDupEbtablesScript, config),
-			"ensureNoDupEbtablesService":   getBase64EncodedGzippedCustomScript(ensureNoDupEbtablesService, config),
-			"bindMountScript":              getBase64EncodedGzippedCustomScript(bindMountScript, config),
-			"bindMountSystemdService":      getBase64EncodedGzippedCustomScript(bindMountSystemdService, config),
-			"migPartitionSystemdService":   getBase64EncodedGzippedCustomScript(migPartitionSystemdService, config),
-			"migPartitionScript":           getBase64EncodedGzippedCustomScript(migPartitionScript, config),
-			"ensureIMDSRestrictionScript":  getBase64EncodedGzippedCustomScript(ensureIMDSRestrictionScript, config),
-			"containerdKubeletDropin":      getBase64EncodedGzippedCustomScript(containerdKubeletDropin, config),
-			"cgroupv2KubeletDropin":        getBase64EncodedGzippedCustomScript(cgroupv2KubeletDropin, config),
-			"componentConfigDropin":        getBase64EncodedGzippedCustomScript(componentConfigDropin, config),
-			"tlsBootstrapDropin":           getBase64EncodedGzippedCustomScript(tlsBootstrapDropin, config),
-			"bindMountDropin":              getBase64EncodedGzippedCustomScript(bindMountDropin, config),
-			"httpProxyDropin":              getBase64EncodedGzippedCustomScript(httpProxyDropin, config),
-			"snapshotUpdateScript":         getBase64EncodedGzippedCustomScript(snapshotUpdateScript, config),
-			"snapshotUpdateService":        getBase64EncodedGzippedCustomScript(snapshotUpdateSystemdService, config),
-			"snapshotUpdateTimer":          getBase64EncodedGzippedCustomScript(snapshotUpdateSystemdTimer, config),
-			"packageUpdateScriptMariner":   getBase64EncodedGzippedCustomScript(packageUpdateScriptMariner, config),
-			"packageUpdateServiceMariner":  getBase64EncodedGzippedCustomScript(packageUpdateSystemdServiceMariner, config),
-			"packageUpdateTimerMariner":    getBase64EncodedGzippedCustomScript(packageUpdateSystemdTimerMariner, config),
-			"componentManifestFile":        getBase64EncodedGzippedCustomScript(componentManifestFile, config),
+			"provisionStartScript":         t.getBase64EncodedGzippedCustomScript(ctx, kubernetesCSEStartScript, config),
+			"provisionScript":              t.getBase64EncodedGzippedCustomScript(ctx, kubernetesCSEMainScript, config),
+			"provisionSource":              t.getBase64EncodedGzippedCustomScript(ctx, kubernetesCSEHelpersScript, config),
+			"provisionSourceUbuntu":        t.getBase64EncodedGzippedCustomScript(ctx, kubernetesCSEHelpersScriptUbuntu, config),
+			"provisionSourceMariner":       t.getBase64EncodedGzippedCustomScript(ctx, kubernetesCSEHelpersScriptMariner, config),
+			"provisionInstalls":            t.getBase64EncodedGzippedCustomScript(ctx, kubernetesCSEInstall, config),
+			"provisionInstallsUbuntu":      t.getBase64EncodedGzippedCustomScript(ctx, kubernetesCSEInstallUbuntu, config),
+			"provisionInstallsMariner":     t.getBase64EncodedGzippedCustomScript(ctx, kubernetesCSEInstallMariner, config),
+			"provisionConfigs":             t.getBase64EncodedGzippedCustomScript(ctx, kubernetesCSEConfig, config),
+			"provisionSendLogs":            t.getBase64EncodedGzippedCustomScript(ctx, kubernetesCSESendLogs, config),
+			"provisionRedactCloudConfig":   t.getBase64EncodedGzippedCustomScript(ctx, kubernetesCSERedactCloudConfig, config),
+			"customSearchDomainsScript":    t.getBase64EncodedGzippedCustomScript(ctx, kubernetesCustomSearchDomainsScript, config),
+			"dhcpv6SystemdService":         t.getBase64EncodedGzippedCustomScript(ctx, dhcpv6SystemdService, config),
+			"dhcpv6ConfigurationScript":    t.getBase64EncodedGzippedCustomScript(ctx, dhcpv6ConfigurationScript, config),
+			"kubeletSystemdService":        t.getBase64EncodedGzippedCustomScript(ctx, kubeletSystemdService, config),
+			"reconcilePrivateHostsScript":  t.getBase64EncodedGzippedCustomScript(ctx, reconcilePrivateHostsScript, config),
+			"reconcilePrivateHostsService": t.getBase64EncodedGzippedCustomScript(ctx, reconcilePrivateHostsService, config),
+			"ensureNoDupEbtablesScript":    t.getBase64EncodedGzippedCustomScript(ctx, ensureNoDupEbtablesScript, config),
+			"ensureNoDupEbtablesService":   t.getBase64EncodedGzippedCustomScript(ctx, ensureNoDupEbtablesService, config),
+			"bindMountScript":              t.getBase64EncodedGzippedCustomScript(ctx, bindMountScript, config),
+			"bindMountSystemdService":      t.getBase64EncodedGzippedCustomScript(ctx, bindMountSystemdService, config),
+			"migPartitionSystemdService":   t.getBase64EncodedGzippedCustomScript(ctx, migPartitionSystemdService, config),
+			"migPartitionScript":           t.getBase64EncodedGzippedCustomScript(ctx, migPartitionScript, config),
+			"ensureIMDSRestrictionScript":  t.getBase64EncodedGzippedCustomScript(ctx, ensureIMDSRestrictionScript, config),
+			"containerdKubeletDropin":      t.getBase64EncodedGzippedCustomScript(ctx, containerdKubeletDropin, config),
+			"cgroupv2KubeletDropin":        t.getBase64EncodedGzippedCustomScript(ctx, cgroupv2KubeletDropin, config),
+			"componentConfigDropin":        t.getBase64EncodedGzippedCustomScript(ctx, componentConfigDropin, config),
+			"tlsBootstrapDropin":           t.getBase64EncodedGzippedCustomScript(ctx, tlsBootstrapDropin, config),
+			"bindMountDropin":              t.getBase64EncodedGzippedCustomScript(ctx, bindMountDropin, config),
+			"httpProxyDropin":              t.getBase64EncodedGzippedCustomScript(ctx, httpProxyDropin, config),
+			"snapshotUpdateScript":         t.getBase64EncodedGzippedCustomScript(ctx, snapshotUpdateScript, config),
+			"snapshotUpdateService":        t.getBase64EncodedGzippedCustomScript(ctx, snapshotUpdateSystemdService, config),
+			"snapshotUpdateTimer":          t.getBase64EncodedGzippedCustomScript(ctx, snapshotUpdateSystemdTimer, config),
+			"packageUpdateScriptMariner":   t.getBase64EncodedGzippedCustomScript(ctx, packageUpdateScriptMariner, config),
+			"packageUpdateServiceMariner":  t.getBase64EncodedGzippedCustomScript(ctx, packageUpdateSystemdServiceMariner, config),
+			"packageUpdateTimerMariner":    t.getBase64EncodedGzippedCustomScript(ctx, packageUpdateSystemdTimerMariner, config),
+			"componentManifestFile":        t.getBase64EncodedGzippedCustomScript(ctx, componentManifestFile, config),
 		},
 	}
 
@@ -60,17 +61,17 @@ func getCustomDataVariables(config *datamodel.NodeBootstrappingConfiguration) pa
 	if cs.IsAKSCustomCloud() {
 		// TODO(ace): do we care about both? 2nd one should be more general and catch custom VHD for mariner.
 		if config.AgentPoolProfile.Distro.IsAzureLinuxDistro() || isMariner(config.OSSKU) {
-			cloudInitData["initAKSCustomCloud"] = getBase64EncodedGzippedCustomScript(initAKSCustomCloudMarinerScript, config)
+			cloudInitData["initAKSCustomCloud"] = t.getBase64EncodedGzippedCustomScript(ctx, initAKSCustomCloudMarinerScript, config)
 		} else {
-			cloudInitData["initAKSCustomCloud"] = getBase64EncodedGzippedCustomScript(initAKSCustomCloudScript, config)
+			cloudInitData["initAKSCustomCloud"] = t.getBase64EncodedGzippedCustomScript(ctx, initAKSCustomCloudScript, config)
 		}
 	}
 
 	if !cs.Properties.IsVHDDistroForAllNodes() {
-		cloudInitData["provisionCIS"] = getBase64EncodedGzippedCustomScript(kubernetesCISScript, config)
-		cloudInitData["kmsSystemdService"] = getBase64EncodedGzippedCustomScript(kmsSystemdService, config)
-		cloudInitData["aptPreferences"] = getBase64EncodedGzippedCustomScript(aptPreferences, config)
-		cloudInitData["dockerClearMountPropagationFlags"] = getBase64EncodedGzippedCustomScript(dockerClearMountPropagationFlags, config)
+		cloudInitData["provisionCIS"] = t.getBase64EncodedGzippedCustomScript(ctx, kubernetesCISScript, config)
+		cloudInitData["kmsSystemdService"] = t.getBase64EncodedGzippedCustomScript(ctx, kmsSystemdService, config)
+		cloudInitData["aptPreferences"] = t.getBase64EncodedGzippedCustomScript(ctx, aptPreferences, config)
+		cloudInitData["dockerClearMountPropagationFlags"] = t.getBase64EncodedGzippedCustomScript(ctx, dockerClearMountPropagationFlags, config)
 	}
 
 	return cloudInitFiles
@@ -87,15 +88,15 @@ func getWindowsCustomDataVariables(config *datamodel.NodeBootstrappingConfigurat
 		"subscriptionId":                       config.SubscriptionID,
 		"resourceGroup":                        config.ResourceGroupName,
 		"location":                             cs.Location,
-		"vmType":                               cs.Properties.GetVMType(),
+		"vmType":                               getVMType(config),
 		"subnetName":                           cs.Properties.GetSubnetName(),
 		"nsgName":                              cs.Properties.GetNSGName(),
 		"virtualNetworkName":                   cs.Properties.GetVirtualNetworkName(),
 		"routeTableName":                       cs.Properties.GetRouteTableName(),
-		"primaryAvailabilitySetName":           cs.Properties.GetPrimaryAvailabilitySetName(),
-		"primaryScaleSetName":                  config.PrimaryScaleSetName,
+		"primaryAvailabilitySetName":           getPrimaryAvailabilitySetName(config),
+		"primaryScaleSetName":                  getPrimaryScaleSetName(config),
 		"useManagedIdentityExtension":          useManagedIdentity(cs),
-		"useInstanceMetadata":                  useInstanceMetadata(cs),
+		"useInstanceMetadata":                  useInstanceMetadata(config),
 		"loadBalancerSku":                      cs.Properties.OrchestratorProfile.KubernetesConfig.LoadBalancerSku,
 		"excludeMasterFromStandardLB":          true,
 		"windowsEnableCSIProxy":                cs.Properties.WindowsProfile.IsCSIProxyEnabled(),
@@ -108,6 +109,9 @@ func getWindowsCustomDataVariables(config *datamodel.NodeBootstrappingConfigurat
 		"windowsSecureTlsEnabled":              cs.Properties.WindowsProfile.IsWindowsSecureTlsEnabled(),
 		"windowsGmsaPackageUrl":                cs.Properties.WindowsProfile.WindowsGmsaPackageUrl,
 		"windowsGpuDriverURL":                  cs.Properties.WindowsProfile.GpuDriverURL,
+		"windowsGpuDriverType":                 GetWindowsGPUDriverType(config.AgentPoolProfile.VMSize),
+		"windowsGpuNode":                       strconv.FormatBool(isWindowsGPUNode(config)),
+		"windowsEnableGpuDevicePlugin":         strconv.FormatBool(isWindowsGPUNode(config) && config.EnableGPUDevicePluginIfNeeded),
 		"windowsCSEScriptsPackageURL":          cs.Properties.WindowsProfile.CseScriptsPackageURL,
 		"isDisableWindowsOutboundNat":          strconv.FormatBool(config.AgentPoolProfile.IsDisableWindowsOutboundNat()),
 		"isSkipCleanupNetwork":                 strconv.FormatBool(config.AgentPoolProfile.IsSkipCleanupNetwork()),
@@ -124,16 +128,16 @@ func getCSECommandVariables(config *datamodel.NodeBootstrappingConfiguration) pa
 		"subscriptionId":                  config.SubscriptionID,
 		"resourceGroup":                   config.ResourceGroupName,
 		"location":                        cs.Location,
-		"vmType":                          cs.Properties.GetVMType(),
+		"vmType":                          getVMType(config),
 		"subnetName":                      cs.Properties.GetSubnetName(),
 		"nsgName":                         cs.Properties.GetNSGName(),
 		"virtualNetworkName":              cs.Properties.GetVirtualNetworkName(),
 		"virtualNetworkResourceGroupName": cs.Properties.GetVNetResourceGroupName(),
 		"routeTableName":                  cs.Properties.GetRouteTableName(),
-		"primaryAvailabilitySetName":      cs.Properties.GetPrimaryAvailabilitySetName(),
-		"primaryScaleSetName":             config.PrimaryScaleSetName,
+		"primaryAvailabilitySetName":      getPrimaryAvailabilitySetName(config),
+		"primaryScaleSetName":             getPrimaryScaleSetName(config),
 		"useManagedIdentityExtension":     useManagedIdentity(cs),
-		"useInstanceMetadata":             useInstanceMetadata(cs),
+		"useInstanceMetadata":             useInstanceMetadata(config),
 		"loadBalancerSku":                 cs.Properties.OrchestratorProfile.KubernetesConfig.LoadBalancerSku,
 		"excludeMasterFromStandardLB":     true,
 		"maximumLoadBalancerRuleCount":    getMaximumLoadBalancerRuleCount(cs),
@@ -141,6 +145,9 @@ func getCSECommandVariables(config *datamodel.NodeBootstrappingConfiguration) pa
 		"isVHD":                           isVHD(profile),
 		"gpuNode":                         strconv.FormatBool(config.EnableNvidia),
 		"sgxNode":                         strconv.FormatBool(datamodel.IsSgxEnabledSKU(profile.VMSize)),
+		"confidentialComputingType":       string(datamodel.GetConfidentialComputingType(profile.VMSize)),
+		"infiniBandNode":                  strconv.FormatBool(profile.IsInfiniBandEnabled()),
+		"enableMeasuredBoot":              strconv.FormatBool(profile.VMSecurityProfile.IsMeasuredBootEnabled()),
 		"configGPUDriverIfNeeded":         config.ConfigGPUDriverIfNeeded,
 		"enableGPUDevicePluginIfNeeded":   config.EnableGPUDevicePluginIfNeeded,
 		"migNode":                         strconv.FormatBool(datamodel.IsMIGNode(config.GPUInstanceProfile)),
@@ -154,8 +161,11 @@ func useManagedIdentity(cs *datamodel.ContainerService) string {
 	return strconv.FormatBool(useManagedIdentity)
 }
 
-func useInstanceMetadata(cs *datamodel.ContainerService) string {
-	useInstanceMetadata := cs.Properties.OrchestratorProfile.KubernetesConfig != nil &&
+func useInstanceMetadata(config *datamodel.NodeBootstrappingConfiguration) string {
+	cs := config.ContainerService
+	// Arc machines have no IMDS.
+	useInstanceMetadata := config.BootstrapTarget != datamodel.BootstrapTargetArcMachine &&
+		cs.Properties.OrchestratorProfile.KubernetesConfig != nil &&
 		cs.Properties.OrchestratorProfile.KubernetesConfig.UseInstanceMetadata != nil &&
 		*cs.Properties.OrchestratorProfile.KubernetesConfig.UseInstanceMetadata
 	return strconv.FormatBool(useInstanceMetadata)
@@ -0,0 +1,48 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/Azure/agentbaker/pkg/agent/gpuskus"
+)
+
+// IsWindowsNvidiaEnabledSKU returns whether the GPUs of the VM size have Windows drivers.
+func IsWindowsNvidiaEnabledSKU(vmSize string) bool {
+	sku, ok := gpuskus.Lookup(vmSize)
+	return ok && sku.WindowsDriver != ""
+}
+
+// GetWindowsGPUDriverType returns the driver the Windows CSE installs on the VM size, grid or cuda, and an empty string
+// for sizes without a supported GPU.
+func GetWindowsGPUDriverType(vmSize string) string {
+	sku, _ := gpuskus.Lookup(vmSize)
+	return sku.WindowsDriver
+}
+
+// isWindowsGPUNode returns whether the Windows CSE installs the GPU driver and enables the GPUs of the node.
+func isWindowsGPUNode(config *datamodel.NodeBootstrappingConfiguration) bool {
+	return config.AgentPoolProfile.IsWindows() && (config.EnableNvidia || config.ConfigGPUDriverIfNeeded) &&
+		IsWindowsNvidiaEnabledSKU(config.AgentPoolProfile.VMSize)
+}
+
+// validateWindowsGPU checks that a Windows node with GPU drivers enabled has a GPU supported on Windows and a driver to
+// install. ConfigGPUDriverIfNeeded is a no-op on sizes without a GPU, as on Linux.
+func validateWindowsGPU(config *datamodel.NodeBootstrappingConfiguration) error {
+	profile := config.AgentPoolProfile
+	if !profile.IsWindows() {
+		return nil
+	}
+	if config.EnableNvidia && !IsWindowsNvidiaEnabledSKU(profile.VMSize) {
+		return fmt.Errorf("VM size %s doesn't have a GPU supported on Windows", profile.VMSize)
+	}
+	windowsProfile := config.ContainerService.Properties.WindowsProfile
+	if isWindowsGPUNode(config) && (windowsProfile == nil || windowsProfile.GpuDriverURL == "") {
+		return errors.New("the Windows GPU driver URL is required to install the GPU driver")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+package agent
+
+import (
+	"testing"
+
+	"github.com/Azure/agentbaker/pkg/agent/datamodel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWindowsGPUDriverType(t *testing.T) {
+	assert.Equal(t, "grid", GetWindowsGPUDriverType("Standard_NV36ads_A10_v5"))
+	assert.Equal(t, "cuda", GetWindowsGPUDriverType("Standard_NC4as_T4_v3"))
+	assert.Equal(t, "", GetWindowsGPUDriverType("Standard_NV8as_v4"))
+	assert.False(t, IsWindowsNvidiaEnabledSKU("Standard_D4s_v3"))
+}
+
+func TestValidateWindowsGPU(t *testing.T) {
+	newConfig := func(vmSize, driverURL string) *datamodel.NodeBootstrappingConfiguration {
+		return &datamodel.NodeBootstrappingConfiguration{
+			ContainerService: &datamodel.ContainerService{Properties: &datamodel.Properties{
+				WindowsProfile: &datamodel.WindowsProfile{GpuDriverURL: driverURL},
+			}},
+			AgentPoolProfile: &datamodel.AgentPoolProfile{OSType: datamodel.Windows, VMSize: vmSize},
+		}
+	}
+	tests := []struct {
+		name    string
+		config  *datamodel.NodeBootstrappingConfiguration
+		mutate  func(*datamodel.NodeBootstrappingConfiguration)
+		wantErr string
+	}{
+		{
+			name:   "gpu",
+			config: newConfig("Standard_NC4as_T4_v3", "https://acs-mirror.azureedge.net/aks/windows/gpu/cuda.exe"),
+			mutate: func(c *datamodel.NodeBootstrappingConfiguration) {
+				c.EnableNvidia = true
+				c.ConfigGPUDriverIfNeeded = true
+			},
+		},
+		{
+			name:    "size without gpu",
+			config:  newConfig("Standard_D4s_v3", ""),
+			mutate:  func(c *datamodel.NodeBootstrappingConfiguration) { c.EnableNvidia = true },
+			wantErr: "VM size Standard_D4s_v3 doesn't have a GPU supported on Windows",
+		},
+		{
+			name:   "driver if needed on a size without gpu",
+			config: newConfig("Standard_D4s_v3", ""),
+			mutate: func(c *datamodel.NodeBootstrappingConfiguration) { c.ConfigGPUDriverIfNeeded = true },
+		},
+		{
+			name:    "no driver URL",
+			config:  newConfig("Standard_NV6ads_A10_v5", ""),
+			mutate:  func(c *datamodel.NodeBootstrappingConfiguration) { c.ConfigGPUDriverIfNeeded = true },
+			wantErr: "the Windows GPU driver URL is required to install the GPU driver",
+		},
+		{
+			name:   "linux",
+			config: newConfig("Standard_D4s_v3", ""),
+			mutate: func(c *datamodel.NodeBootstrappingConfiguration) {
+				c.AgentPoolProfile.OSType = datamodel.Linux
+				c.EnableNvidia = true
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mutate(tt.config)
+			err := validateWindowsGPU(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
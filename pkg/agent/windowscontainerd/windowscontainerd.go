@@ -0,0 +1,275 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT license.
+
+// Package windowscontainerd builds the containerd configuration of Windows nodes from a typed model with defaults
+// per Windows Server build, and serializes it to the TOML read by containerd.
+package windowscontainerd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Build is a Windows Server build number.
+type Build string
+
+const (
+	// Build2019 is Windows Server 2019.
+	Build2019 Build = "17763"
+	// Build2022 is Windows Server 2022.
+	Build2022 Build = "20348"
+	// Build23H2 is Windows Server 23H2.
+	Build23H2 Build = "25398"
+)
+
+const (
+	// IsolationProcess runs pods as process-isolated containers sharing the host kernel.
+	IsolationProcess = "process"
+	// IsolationHyperV runs pods in Hyper-V utility VMs.
+	IsolationHyperV = "hyperv"
+
+	runtimeType             = "io.containerd.runhcs.v1"
+	processRuntimeName      = "runhcs-wcow-process"
+	hypervisorRuntimePrefix = "runhcs-wcow-hypervisor-"
+	sandboxPlatform         = "windows/amd64"
+)
+
+// BuildDefaults are the containerd settings which depend on the Windows Server build of the node.
+type BuildDefaults struct {
+	// ScaleCPULimitsToSandbox scales the CPU limits of Hyper-V isolated containers to their utility VM.
+	ScaleCPULimitsToSandbox bool
+	// PodAnnotations are the annotation patterns passed to the runtime.
+	PodAnnotations []string
+	// HypervisorBuilds are the guest builds which can run in Hyper-V isolation on the build.
+	HypervisorBuilds []Build
+	// EnableUnprivilegedPorts lets containers bind ports below 1024 without privileges.
+	EnableUnprivilegedPorts bool
+}
+
+var buildDefaults = map[Build]BuildDefaults{
+	Build2019: {
+		HypervisorBuilds: []Build{Build2019},
+	},
+	Build2022: {
+		ScaleCPULimitsToSandbox: true,
+		PodAnnotations:          []string{"io.microsoft.container.*", "io.microsoft.virtualmachine.*"},
+		HypervisorBuilds:        []Build{Build2019, Build2022},
+	},
+	Build23H2: {
+		ScaleCPULimitsToSandbox: true,
+		PodAnnotations:          []string{"io.microsoft.container.*", "io.microsoft.virtualmachine.*"},
+		HypervisorBuilds:        []Build{Build2019, Build2022, Build23H2},
+		EnableUnprivilegedPorts: true,
+	},
+}
+
+// Defaults returns the containerd defaults of build.
+func Defaults(build Build) (BuildDefaults, error) {
+	defaults, ok := buildDefaults[build]
+	if !ok {
+		return BuildDefaults{}, fmt.Errorf("unsupported Windows build %q", build)
+	}
+	return defaults, nil
+}
+
+// Options are the node settings the containerd configuration is built from.
+type Options struct {
+	Build Build
+	// PauseImage is the sandbox image without the "-windows-<version>-amd64" tag suffix.
+	PauseImage string
+	CNIBinDir  string
+	CNIConfDir string
+	// DefaultSandboxIsolation is IsolationProcess or IsolationHyperV, IsolationProcess is used when empty.
+	DefaultSandboxIsolation string
+	// RuntimeHandlers are the guest builds a Hyper-V runtime handler is added for.
+	RuntimeHandlers []Build
+	// LogLevel is the containerd log level, "info" is used when empty.
+	LogLevel string
+}
+
+// Config is the containerd configuration of a Windows node.
+type Config struct {
+	Root               string
+	State              string
+	GRPCAddress        string
+	SandboxImage       string
+	EnableUnprivileged bool
+	DefaultRuntimeName string
+	// Runtimes are keyed by runtime handler name.
+	Runtimes       map[string]Runtime
+	CNIBinDir      string
+	CNIConfDir     string
+	RegistryConfig string
+	LogLevel       string
+}
+
+// Runtime is a runhcs runtime handler.
+type Runtime struct {
+	PodAnnotations          []string
+	ContainerAnnotations    []string
+	SandboxImage            string
+	SandboxIsolation        int
+	ScaleCPULimitsToSandbox bool
+}
+
+// New builds the containerd configuration of a node from options and the defaults of its build.
+func New(options Options) (*Config, error) {
+	defaults, err := Defaults(options.Build)
+	if err != nil {
+		return nil, err
+	}
+	isolation := options.DefaultSandboxIsolation
+	if isolation == "" {
+		isolation = IsolationProcess
+	}
+	if isolation != IsolationProcess && isolation != IsolationHyperV {
+		return nil, fmt.Errorf("invalid sandbox isolation %q, it must be %s or %s", isolation, IsolationProcess, IsolationHyperV)
+	}
+	logLevel := options.LogLevel
+	if logLevel == "" {
+		logLevel = "info"
+	}
+
+	config := &Config{
+		Root:               `C:\ProgramData\containerd\root`,
+		State:              `C:\ProgramData\containerd\state`,
+		GRPCAddress:        `\\.\pipe\containerd-containerd`,
+		SandboxImage:       sandboxImage(options.PauseImage, options.Build),
+		EnableUnprivileged: defaults.EnableUnprivilegedPorts,
+		DefaultRuntimeName: processRuntimeName,
+		Runtimes: map[string]Runtime{
+			processRuntimeName: {
+				PodAnnotations:          defaults.PodAnnotations,
+				ContainerAnnotations:    defaults.PodAnnotations,
+				SandboxImage:            sandboxImage(options.PauseImage, options.Build),
+				ScaleCPULimitsToSandbox: defaults.ScaleCPULimitsToSandbox,
+			},
+		},
+		CNIBinDir:      options.CNIBinDir,
+		CNIConfDir:     options.CNIConfDir,
+		RegistryConfig: `C:\ProgramData\containerd\certs.d`,
+		LogLevel:       logLevel,
+	}
+
+	for _, guest := range options.RuntimeHandlers {
+		if !supportsHypervisor(defaults, guest) {
+			return nil, fmt.Errorf("build %s can't run build %s with Hyper-V isolation", options.Build, guest)
+		}
+		config.Runtimes[hypervisorRuntimePrefix+string(guest)] = Runtime{
+			PodAnnotations:          defaults.PodAnnotations,
+			ContainerAnnotations:    defaults.PodAnnotations,
+			SandboxImage:            sandboxImage(options.PauseImage, guest),
+			SandboxIsolation:        1,
+			ScaleCPULimitsToSandbox: defaults.ScaleCPULimitsToSandbox,
+		}
+	}
+	if isolation == IsolationHyperV {
+		// the hypervisor runtime of the node's own build is the default, it is added when not requested.
+		name := hypervisorRuntimePrefix + string(options.Build)
+		if _, ok := config.Runtimes[name]; !ok {
+			runtime := config.Runtimes[processRuntimeName]
+			runtime.SandboxIsolation = 1
+			config.Runtimes[name] = runtime
+		}
+		config.DefaultRuntimeName = name
+	}
+	return config, nil
+}
+
+func supportsHypervisor(defaults BuildDefaults, guest Build) bool {
+	for _, build := range defaults.HypervisorBuilds {
+		if build == guest {
+			return true
+		}
+	}
+	return false
+}
+
+func sandboxImage(pauseImage string, build Build) string {
+	return fmt.Sprintf("%s-windows-10.0.%s-amd64", pauseImage, build)
+}
+
+// MarshalTOML serializes the configuration to the version 2 containerd TOML format.
+func (c *Config) MarshalTOML() []byte {
+	const cri = `plugins."io.containerd.grpc.v1.cri"`
+	var b strings.Builder
+	b.WriteString("version = 2\n")
+	writeKey(&b, "", "root", quote(c.Root))
+	writeKey(&b, "", "state", quote(c.State))
+
+	writeTable(&b, "grpc")
+	writeKey(&b, "  ", "address", quote(c.GRPCAddress))
+
+	writeTable(&b, cri)
+	writeKey(&b, "  ", "sandbox_image", quote(c.SandboxImage))
+	writeKey(&b, "  ", "enable_selinux", "false")
+	if c.EnableUnprivileged {
+		writeKey(&b, "  ", "enable_unprivileged_ports", "true")
+	}
+
+	writeTable(&b, cri+".containerd")
+	writeKey(&b, "  ", "snapshotter", quote("windows"))
+	writeKey(&b, "  ", "default_runtime_name", quote(c.DefaultRuntimeName))
+	writeKey(&b, "  ", "discard_unpacked_layers", "true")
+
+	names := make([]string, 0, len(c.Runtimes))
+	for name := range c.Runtimes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		runtime := c.Runtimes[name]
+		table := cri + ".containerd.runtimes." + quote(name)
+		writeTable(&b, table)
+		writeKey(&b, "  ", "runtime_type", quote(runtimeType))
+		if len(runtime.PodAnnotations) > 0 {
+			writeKey(&b, "  ", "pod_annotations", quoteList(runtime.PodAnnotations))
+		}
+		if len(runtime.ContainerAnnotations) > 0 {
+			writeKey(&b, "  ", "container_annotations", quoteList(runtime.ContainerAnnotations))
+		}
+		writeTable(&b, table+".options")
+		writeKey(&b, "  ", "Debug", "true")
+		writeKey(&b, "  ", "DebugType", "2")
+		writeKey(&b, "  ", "SandboxImage", quote(runtime.SandboxImage))
+		writeKey(&b, "  ", "SandboxPlatform", quote(sandboxPlatform))
+		writeKey(&b, "  ", "SandboxIsolation", strconv.Itoa(runtime.SandboxIsolation))
+		if runtime.ScaleCPULimitsToSandbox {
+			writeKey(&b, "  ", "ScaleCpuLimitsToSandbox", "true")
+		}
+	}
+
+	writeTable(&b, cri+".cni")
+	writeKey(&b, "  ", "bin_dir", quote(c.CNIBinDir))
+	writeKey(&b, "  ", "conf_dir", quote(c.CNIConfDir))
+
+	writeTable(&b, cri+".registry")
+	writeKey(&b, "  ", "config_path", quote(c.RegistryConfig))
+
+	writeTable(&b, "debug")
+	writeKey(&b, "  ", "level", quote(c.LogLevel))
+	return []byte(b.String())
+}
+
+func writeTable(b *strings.Builder, name string) {
+	fmt.Fprintf(b, "\n[%s]\n", name)
+}
+
+func writeKey(b *strings.Builder, indent, key, value string) {
+	fmt.Fprintf(b, "%s%s = %s\n", indent, key, value)
+}
+
+// quote returns a TOML basic string.
+func quote(s string) string {
+	return strconv.Quote(s)
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = quote(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
@@ -0,0 +1,125 @@
+package windowscontainerd
+
+import (
+	"strings"
+	"testing"
+)
+
+const pauseImage = "mcr.microsoft.com/oss/kubernetes/pause:3.9"
+
+func TestNewPerBuild(t *testing.T) {
+	tests := []struct {
+		name         string
+		options      Options
+		want         []string
+		wantAbsent   []string
+		wantRuntimes []string
+		wantDefault  string
+	}{
+		{
+			name:    "2019",
+			options: Options{Build: Build2019},
+			want: []string{
+				`sandbox_image = "mcr.microsoft.com/oss/kubernetes/pause:3.9-windows-10.0.17763-amd64"`,
+			},
+			wantAbsent:   []string{"ScaleCpuLimitsToSandbox", "pod_annotations", "enable_unprivileged_ports"},
+			wantRuntimes: []string{"runhcs-wcow-process"},
+			wantDefault:  "runhcs-wcow-process",
+		},
+		{
+			name:    "2022 with hypervisor handlers",
+			options: Options{Build: Build2022, RuntimeHandlers: []Build{Build2019, Build2022}},
+			want: []string{
+				`ScaleCpuLimitsToSandbox = true`,
+				`pod_annotations = ["io.microsoft.container.*", "io.microsoft.virtualmachine.*"]`,
+				`SandboxImage = "mcr.microsoft.com/oss/kubernetes/pause:3.9-windows-10.0.17763-amd64"`,
+			},
+			wantAbsent:   []string{"enable_unprivileged_ports"},
+			wantRuntimes: []string{"runhcs-wcow-hypervisor-17763", "runhcs-wcow-hypervisor-20348", "runhcs-wcow-process"},
+			wantDefault:  "runhcs-wcow-process",
+		},
+		{
+			name:         "23H2 with hyperv isolation",
+			options:      Options{Build: Build23H2, DefaultSandboxIsolation: IsolationHyperV},
+			want:         []string{`enable_unprivileged_ports = true`, `SandboxIsolation = 1`},
+			wantRuntimes: []string{"runhcs-wcow-hypervisor-25398", "runhcs-wcow-process"},
+			wantDefault:  "runhcs-wcow-hypervisor-25398",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.options.PauseImage = pauseImage
+			config, err := New(tt.options)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if config.DefaultRuntimeName != tt.wantDefault {
+				t.Errorf("DefaultRuntimeName = %s, want %s", config.DefaultRuntimeName, tt.wantDefault)
+			}
+			if len(config.Runtimes) != len(tt.wantRuntimes) {
+				t.Errorf("Runtimes = %v, want %v", config.Runtimes, tt.wantRuntimes)
+			}
+			toml := string(config.MarshalTOML())
+			for _, runtime := range tt.wantRuntimes {
+				want := `[plugins."io.containerd.grpc.v1.cri".containerd.runtimes."` + runtime + `"]`
+				if !strings.Contains(toml, want) {
+					t.Errorf("MarshalTOML() is missing %s:\n%s", want, toml)
+				}
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(toml, want) {
+					t.Errorf("MarshalTOML() is missing %s:\n%s", want, toml)
+				}
+			}
+			for _, absent := range tt.wantAbsent {
+				if strings.Contains(toml, absent) {
+					t.Errorf("MarshalTOML() contains %s:\n%s", absent, toml)
+				}
+			}
+		})
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		wantErr string
+	}{
+		{
+			name:    "unsupported build",
+			options: Options{Build: "14393"},
+			wantErr: `unsupported Windows build "14393"`,
+		},
+		{
+			name:    "newer guest build",
+			options: Options{Build: Build2019, RuntimeHandlers: []Build{Build2022}},
+			wantErr: "build 17763 can't run build 20348 with Hyper-V isolation",
+		},
+		{
+			name:    "invalid isolation",
+			options: Options{Build: Build2022, DefaultSandboxIsolation: "vm"},
+			wantErr: `invalid sandbox isolation "vm", it must be process or hyperv`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.options); err == nil || err.Error() != tt.wantErr {
+				t.Errorf("New() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMarshalTOMLEscapesWindowsPaths(t *testing.T) {
+	config, err := New(Options{Build: Build2022, PauseImage: pauseImage, CNIBinDir: `c:\k\azurecni\bin`})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	toml := string(config.MarshalTOML())
+	for _, want := range []string{`root = "C:\\ProgramData\\containerd\\root"`, `bin_dir = "c:\\k\\azurecni\\bin"`, `address = "\\\\.\\pipe\\containerd-containerd"`} {
+		if !strings.Contains(toml, want) {
+			t.Errorf("MarshalTOML() is missing %s:\n%s", want, toml)
+		}
+	}
+}